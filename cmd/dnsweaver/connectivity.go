@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/config"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// connectivityResult is one provider's row in the connectivity report.
+type connectivityResult struct {
+	Provider string
+	Type     string
+	Records  int
+	Latency  time.Duration // Round trip of Ping
+	Err      error
+}
+
+// runTestConnectivity handles --test-connectivity. It loads the
+// configuration, creates every provider instance, and calls Ping and List on
+// each, then prints a report to stdout. Nothing is reconciled and no record
+// is modified. Returns the process exit code: 0 if every provider is
+// reachable, 1 otherwise.
+func runTestConnectivity() int {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	cfg, err := config.Load()
+	if err != nil {
+		var verr *config.ValidationError
+		if errors.As(err, &verr) {
+			reportProblems(logger, verr.Errors)
+		} else {
+			reportProblems(logger, []string{err.Error()})
+		}
+		return 1
+	}
+	logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: parseLogLevel(cfg.LogLevel())}))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	registry := provider.NewRegistry(logger)
+	registerProviderFactories(registry)
+	defer func() { _ = registry.Close() }()
+
+	// Create every instance first so meta-providers (failover) can resolve
+	// the instances they delegate to. Instances that cannot be created are
+	// reported without being contacted.
+	createErrs := make(map[string]error)
+	for _, inst := range cfg.ProviderInstances {
+		if err := registry.CreateInstance(inst.ToProviderConfig()); err != nil {
+			createErrs[inst.Name] = err
+		}
+	}
+
+	results := make([]connectivityResult, 0, len(cfg.ProviderInstances))
+	for _, inst := range cfg.ProviderInstances {
+		if err, failed := createErrs[inst.Name]; failed {
+			results = append(results, connectivityResult{Provider: inst.Name, Type: inst.TypeName, Err: err})
+			continue
+		}
+		if pi, ok := registry.Get(inst.Name); ok {
+			results = append(results, checkConnectivity(ctx, pi))
+		}
+	}
+
+	if err := writeConnectivityReport(os.Stdout, results); err != nil {
+		logger.Error("writing report failed", slog.String("error", err.Error()))
+		return 1
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			return 1
+		}
+	}
+	return 0
+}
+
+// checkConnectivity pings a provider and lists its records. List is only
+// attempted once Ping succeeds.
+func checkConnectivity(ctx context.Context, inst *provider.ProviderInstance) connectivityResult {
+	result := connectivityResult{Provider: inst.Name(), Type: inst.Provider.Type()}
+
+	start := time.Now()
+	err := inst.Provider.Ping(ctx)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Err = fmt.Errorf("ping: %w", err)
+		return result
+	}
+
+	records, err := inst.Provider.List(ctx)
+	if err != nil {
+		result.Err = fmt.Errorf("list: %w", err)
+		return result
+	}
+	result.Records = len(records)
+	return result
+}
+
+// writeConnectivityReport prints one aligned row per provider, followed by
+// a summary line.
+func writeConnectivityReport(w io.Writer, results []connectivityResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROVIDER\tTYPE\tSTATUS\tRECORDS\tLATENCY\tERROR")
+
+	failed := 0
+	for _, r := range results {
+		status, records, latency, msg := "ok", fmt.Sprint(r.Records), "-", "-"
+		switch {
+		case r.Latency >= time.Millisecond:
+			latency = r.Latency.Round(time.Millisecond).String()
+		case r.Latency > 0:
+			latency = r.Latency.Round(time.Microsecond).String()
+		}
+		if r.Err != nil {
+			failed++
+			status, records, msg = "FAILED", "-", r.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Provider, r.Type, status, records, latency, msg)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d of %d providers reachable\n", len(results)-failed, len(results))
+	return err
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/config"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// dumpRecord is one line of `dnsweaver dump` output.
+type dumpRecord struct {
+	Provider string `json:"provider"`
+	Hostname string `json:"hostname"`
+	Type     string `json:"type"`
+	Target   string `json:"target"`
+	TTL      int    `json:"ttl"`
+}
+
+// runDump lists the records held by every configured provider (or the one
+// named by --provider) and writes them to stdout as newline-delimited JSON.
+// Ownership TXT records are included. Logs go to stderr so the output can be
+// piped. Returns the process exit code: 1 if any provider fails to list.
+func runDump(args []string) int {
+	fs := flag.NewFlagSet("dump", flag.ContinueOnError)
+	only := fs.String("provider", "", "Only dump records from this provider instance")
+	// Dump never changes anything; --dry-run is accepted for symmetry
+	_ = fs.Bool("dry-run", false, "Accepted for compatibility; dump is always read-only")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	cfg, err := config.Load()
+	if err != nil {
+		var verr *config.ValidationError
+		if errors.As(err, &verr) {
+			reportProblems(logger, verr.Errors)
+		} else {
+			reportProblems(logger, []string{err.Error()})
+		}
+		return 1
+	}
+	logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: parseLogLevel(cfg.LogLevel())}))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	registry := provider.NewRegistry(logger)
+	registerProviderFactories(registry)
+	defer func() { _ = registry.Close() }()
+
+	// Create every instance so meta-providers (failover) can resolve the
+	// instances they delegate to, even when only one provider is dumped
+	var names []string
+	failed := false
+	for _, inst := range cfg.ProviderInstances {
+		dumped := *only == "" || inst.Name == *only
+		if err := registry.CreateInstance(inst.ToProviderConfig()); err != nil {
+			if dumped {
+				logger.Error("failed to create provider",
+					slog.String("provider", inst.Name),
+					slog.String("error", err.Error()),
+				)
+				failed = true
+			}
+			continue
+		}
+		if dumped {
+			names = append(names, inst.Name)
+		}
+	}
+	if *only != "" && len(names) == 0 && !failed {
+		logger.Error("provider not found", slog.String("provider", *only))
+		return 1
+	}
+
+	if err := dumpRecords(ctx, os.Stdout, registry, names, logger); err != nil {
+		failed = true
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// dumpRecords writes the records of the named providers to w, one JSON object
+// per line, sorted by hostname, type, and target within each provider.
+// Every provider is attempted; an error is returned if any of them failed.
+func dumpRecords(ctx context.Context, w io.Writer, registry *provider.Registry, names []string, logger *slog.Logger) error {
+	enc := json.NewEncoder(w)
+	var failed []string
+
+	for _, name := range names {
+		inst, ok := registry.Get(name)
+		if !ok {
+			continue
+		}
+
+		records, err := inst.Provider.List(ctx)
+		if err != nil {
+			logger.Error("failed to list records",
+				slog.String("provider", name),
+				slog.String("error", err.Error()),
+			)
+			failed = append(failed, name)
+			continue
+		}
+
+		sort.Slice(records, func(i, j int) bool {
+			a, b := records[i], records[j]
+			if a.Hostname != b.Hostname {
+				return a.Hostname < b.Hostname
+			}
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+			return a.Target < b.Target
+		})
+
+		for _, rec := range records {
+			if err := enc.Encode(dumpRecord{
+				Provider: name,
+				Hostname: rec.Hostname,
+				Type:     string(rec.Type),
+				Target:   rec.Target,
+				TTL:      rec.TTL,
+			}); err != nil {
+				return fmt.Errorf("writing output: %w", err)
+			}
+		}
+
+		logger.Debug("dumped provider records",
+			slog.String("provider", name),
+			slog.Int("records", len(records)),
+		)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("listing records failed for %v", failed)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/config"
+)
+
+// runExportConfig loads the configuration (env vars and YAML file) and prints
+// the effective result as YAML to stdout, with secrets redacted. Nothing is
+// started and no connections are made. Returns the process exit code.
+func runExportConfig() int {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	cfg, err := config.Load()
+	if err != nil {
+		var verr *config.ValidationError
+		if errors.As(err, &verr) {
+			reportProblems(logger, verr.Errors)
+		} else {
+			reportProblems(logger, []string{err.Error()})
+		}
+		return 1
+	}
+
+	fmt.Print(config.ExportYAML(cfg))
+	return 0
+}
@@ -5,6 +5,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
@@ -14,20 +15,41 @@ import (
 	"syscall"
 	"time"
 
+	"gitlab.bluewillows.net/root/dnsweaver/internal/api"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/audit"
 	"gitlab.bluewillows.net/root/dnsweaver/internal/config"
 	"gitlab.bluewillows.net/root/dnsweaver/internal/docker"
 	"gitlab.bluewillows.net/root/dnsweaver/internal/health"
 	"gitlab.bluewillows.net/root/dnsweaver/internal/metrics"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/notify"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/policy"
 	"gitlab.bluewillows.net/root/dnsweaver/internal/reconciler"
 	"gitlab.bluewillows.net/root/dnsweaver/internal/watcher"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/adguard"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/clouddns"
 	"gitlab.bluewillows.net/root/dnsweaver/providers/cloudflare"
+	cloudflaretunnel "gitlab.bluewillows.net/root/dnsweaver/providers/cloudflare-tunnel"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/coredns"
 	"gitlab.bluewillows.net/root/dnsweaver/providers/dnsmasq"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/failover"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/hosts"
+	httpprovider "gitlab.bluewillows.net/root/dnsweaver/providers/http"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/knot"
 	"gitlab.bluewillows.net/root/dnsweaver/providers/pihole"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/powerdns"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/route53"
 	"gitlab.bluewillows.net/root/dnsweaver/providers/technitium"
 	"gitlab.bluewillows.net/root/dnsweaver/providers/webhook"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/windns"
+	"gitlab.bluewillows.net/root/dnsweaver/sources/consul"
 	dnsweaversource "gitlab.bluewillows.net/root/dnsweaver/sources/dnsweaver"
+	"gitlab.bluewillows.net/root/dnsweaver/sources/etcd"
+	"gitlab.bluewillows.net/root/dnsweaver/sources/nginx"
+	"gitlab.bluewillows.net/root/dnsweaver/sources/nginxproxy"
+	"gitlab.bluewillows.net/root/dnsweaver/sources/nomad"
+	"gitlab.bluewillows.net/root/dnsweaver/sources/static"
 	"gitlab.bluewillows.net/root/dnsweaver/sources/traefik"
 )
 
@@ -42,6 +64,10 @@ func main() {
 	// Parse command-line flags
 	configPath := flag.String("config", "", "Path to YAML configuration file")
 	showVersion := flag.Bool("version", false, "Show version and exit")
+	validateOnly := flag.Bool("validate", false, "Validate configuration and exit without connecting to anything")
+	once := flag.Bool("once", false, "Run a single reconciliation and exit (non-zero if any record failed)")
+	exportConfig := flag.Bool("export-config", false, "Print the effective configuration as YAML (secrets redacted) and exit")
+	testConnectivity := flag.Bool("test-connectivity", false, "Ping every provider and list its records, print a report and exit without reconciling")
 	flag.Parse()
 
 	if *showVersion {
@@ -58,13 +84,50 @@ func main() {
 		}
 	}
 
-	if err := run(); err != nil {
+	if *exportConfig {
+		os.Exit(runExportConfig())
+	}
+
+	if *validateOnly || flag.Arg(0) == "validate" {
+		os.Exit(runValidate())
+	}
+
+	if *testConnectivity {
+		os.Exit(runTestConnectivity())
+	}
+
+	if flag.Arg(0) == "dump" {
+		os.Exit(runDump(flag.Args()[1:]))
+	}
+
+	if flag.Arg(0) == "reconcile" {
+		os.Exit(runReconcile(flag.Args()[1:]))
+	}
+
+	if flag.Arg(0) == "snapshot" {
+		os.Exit(runSnapshot(flag.Args()[1:]))
+	}
+
+	if flag.Arg(0) == "restore" {
+		os.Exit(runRestore(flag.Args()[1:]))
+	}
+
+	if err := run(runOptions{once: *once}); err != nil {
 		slog.Error("fatal error", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
 }
 
-func run() error {
+// runOptions selects how run exits after startup. With neither field set,
+// dnsweaver runs as a daemon until it receives a shutdown signal.
+type runOptions struct {
+	// once runs a single full reconciliation and exits (--once).
+	once bool
+	// hostname reconciles only this hostname and exits (reconcile --hostname).
+	hostname string
+}
+
+func run(opts runOptions) error {
 	// Load configuration first (fail fast per DECISIONS.md)
 	cfg, err := config.Load()
 	if err != nil {
@@ -96,6 +159,7 @@ func run() error {
 		docker.WithMode(parseDockerMode(cfg.DockerMode())),
 		docker.WithLogger(logger),
 		docker.WithCleanupOnStop(cfg.CleanupOnStop()),
+		docker.WithLabelFilter(cfg.DockerLabelFilter()...),
 	)
 	if err != nil {
 		return fmt.Errorf("creating docker client: %w", err)
@@ -107,7 +171,10 @@ func run() error {
 	)
 
 	// Initialize source registry
-	sourceRegistry := source.NewRegistry(logger)
+	sourceRegistry := source.NewRegistry(logger,
+		source.WithCaseInsensitiveDedup(),
+		source.WithSourcePriorities(cfg.SourcePriorities()),
+	)
 	if err := registerSources(sourceRegistry, cfg, logger); err != nil {
 		return fmt.Errorf("registering sources: %w", err)
 	}
@@ -120,6 +187,7 @@ func run() error {
 
 	providerManager := provider.NewManager(providerRegistry,
 		provider.WithManagerLogger(logger),
+		provider.WithManagerMaxPendingDuration(cfg.ProviderMaxPending()),
 	)
 	if err := initializeProviders(providerManager, cfg); err != nil {
 		return fmt.Errorf("initializing providers: %w", err)
@@ -154,11 +222,70 @@ func run() error {
 		AdoptExisting:     cfg.AdoptExisting(),
 		ReconcileInterval: cfg.ReconcileInterval(),
 		Enabled:           true,
+
+		MaxHostnamesPerReconcile: cfg.MaxHostnamesPerReconcile(),
+		IgnoreLabels:             cfg.IgnoreHostnames(),
+		DomainsAllowlist:         cfg.DomainsAllowlist(),
+		LabelPriorityMap:         cfg.SourcePriorities(),
+		ProviderFallback:         cfg.ProviderFallback(),
 	}
-	rec := reconciler.New(dockerClient, sourceRegistry, providerRegistry,
+	reconcilerOpts := []reconciler.Option{
 		reconciler.WithConfig(reconcilerCfg),
 		reconciler.WithLogger(logger),
-	)
+		reconciler.WithRecordCacheTTL(cfg.RecordCacheTTL()),
+		reconciler.WithRetry(cfg.RetryAttempts(), cfg.RetryBackoff()),
+		reconciler.WithReconcileBackoff(cfg.BackoffThreshold(), cfg.BackoffInitial(), cfg.BackoffMax()),
+	}
+	if path := cfg.AuditLog(); path != "" {
+		auditLog, err := audit.Open(path, audit.WithLogger(logger))
+		if err != nil {
+			return fmt.Errorf("opening audit log: %w", err)
+		}
+		defer func() { _ = auditLog.Close() }()
+		reconcilerOpts = append(reconcilerOpts, reconciler.WithAuditLogger(auditLog))
+		logger.Info("audit logging enabled", slog.String("path", path))
+	}
+	var notifiers []notify.Notifier
+	if url := cfg.NotifySlackWebhook(); url != "" {
+		notifiers = append(notifiers, notify.NewSlackNotifier(url))
+	}
+	if url := cfg.NotifyDiscordWebhook(); url != "" {
+		notifiers = append(notifiers, notify.NewDiscordNotifier(url))
+	}
+	if len(notifiers) > 0 {
+		reconcilerOpts = append(reconcilerOpts, reconciler.WithNotifier(notify.NewDispatcher(notifiers,
+			notify.WithMinActions(cfg.NotifyMinActions()),
+			notify.WithLogger(logger),
+		)))
+		logger.Info("reconciliation notifications enabled",
+			slog.Int("sinks", len(notifiers)),
+			slog.Int("min_actions", cfg.NotifyMinActions()),
+		)
+	}
+	if url := cfg.ChangeWebhookURL(); url != "" {
+		changeWebhook := reconciler.NewWebhookChangeHandler(url, reconciler.WithChangeWebhookLogger(logger))
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), reconciler.DefaultChangeWebhookTimeout)
+			defer cancel()
+			if err := changeWebhook.Close(ctx); err != nil {
+				logger.Warn("change webhook did not deliver all events before shutdown", slog.String("error", err.Error()))
+			}
+		}()
+		reconcilerOpts = append(reconcilerOpts, reconciler.WithChangeHandler(changeWebhook))
+		logger.Info("record change webhook enabled")
+	}
+	if url := cfg.OPAURL(); url != "" {
+		reconcilerOpts = append(reconcilerOpts, reconciler.WithPolicy(policy.NewOPA(url,
+			policy.WithTimeout(cfg.OPATimeout()),
+			policy.WithFailOpen(cfg.OPAFailOpen()),
+		)))
+		logger.Info("admission policy enabled",
+			slog.String("opa_url", url),
+			slog.Duration("timeout", cfg.OPATimeout()),
+			slog.Bool("fail_open", cfg.OPAFailOpen()),
+		)
+	}
+	rec := reconciler.New(dockerClient, sourceRegistry, providerRegistry, reconcilerOpts...)
 
 	// Recover ownership state from DNS providers on startup (#40)
 	// This enables orphan cleanup to work for records created before a restart
@@ -167,9 +294,45 @@ func run() error {
 		// Continue anyway - this is not fatal, just means orphan cleanup may miss some records
 	}
 
+	// Rewrite legacy ownership records to the versioned format
+	rec.MigrateOwnership(ctx)
+
+	// Optionally push metrics to a Prometheus Pushgateway after reconciliations
+	var pushGateway *metrics.PushGateway
+	if url := cfg.MetricsPushGatewayURL(); url != "" {
+		pushGateway = metrics.NewPushGateway(url,
+			metrics.WithPushInterval(cfg.MetricsPushInterval()),
+			metrics.WithPushLogger(logger),
+		)
+		logger.Info("pushing metrics to Pushgateway",
+			slog.String("url", url),
+			slog.Duration("interval", cfg.MetricsPushInterval()),
+		)
+	}
+
+	// One-shot mode: reconcile once and exit without starting watchers or servers
+	if opts.once {
+		return runOnce(ctx, rec, pushGateway, logger)
+	}
+	if opts.hostname != "" {
+		return runHostname(ctx, rec, opts.hostname, logger)
+	}
+
+	// Reconciliations get their own context so shutdown can let in-flight
+	// ones finish instead of cancelling them mid-update
+	reconcileCtx, cancelReconcile := context.WithCancel(context.Background())
+	defer cancelReconcile()
+
 	// Create reconciliation trigger function
 	triggerReconcile := func() {
-		result, err := rec.Reconcile(ctx)
+		result, err := rec.Reconcile(reconcileCtx)
+		if pushGateway != nil {
+			go pushGateway.PushIfDue(ctx)
+		}
+		if errors.Is(err, reconciler.ErrShuttingDown) {
+			logger.Debug("skipping reconciliation during shutdown")
+			return
+		}
 		if err != nil {
 			logger.Error("reconciliation failed", slog.String("error", err.Error()))
 			return
@@ -181,6 +344,9 @@ func run() error {
 			slog.Int("errors", result.FailedCount()),
 			slog.Duration("duration", result.Duration()),
 		)
+		if diff := result.Diff(); diff != "" {
+			logger.Info("reconciliation changes", slog.String("diff", diff))
+		}
 	}
 
 	// Initialize Docker event watcher (#5)
@@ -192,10 +358,14 @@ func run() error {
 		}),
 	)
 
-	// Initialize file watcher for sources with file discovery (#22)
+	// Initialize file watcher for sources with file or API discovery (#22)
 	var fileWatcher *source.FileWatcher
-	if cfg.HasFileDiscovery() {
-		logger.Info("file discovery enabled, starting file watcher")
+	if len(sourceRegistry.DiscoverableSources()) > 0 {
+		logger.Info("discovery enabled, starting file watcher")
+		watcherOpts := []source.FileWatcherOption{source.WithWatcherLogger(logger)}
+		if interval := discoveryPollInterval(sourceRegistry); interval > 0 {
+			watcherOpts = append(watcherOpts, source.WithPollInterval(interval))
+		}
 		fileWatcher = source.NewFileWatcher(sourceRegistry,
 			func(sourceName string, hostnames []source.Hostname) {
 				logger.Info("file watcher detected changes",
@@ -204,28 +374,58 @@ func run() error {
 				)
 				triggerReconcile()
 			},
-			source.WithWatcherLogger(logger),
+			watcherOpts...,
 		)
 	}
 
 	// Start health server with provider manager status (#10, #125)
 	healthServer := health.New(cfg.HealthPort(),
 		health.WithLogger(logger),
+		health.WithDeepTimeout(cfg.HealthDeepTimeout()),
+		health.WithProviderStatus(func() []health.ProviderStatus {
+			instances := providerRegistry.All()
+			statuses := make([]health.ProviderStatus, len(instances))
+			for i, inst := range instances {
+				st := inst.Status()
+				statuses[i] = health.ProviderStatus{
+					Name:              inst.Name(),
+					Type:              inst.Type(),
+					CircuitState:      string(inst.CircuitState()),
+					Healthy:           st.Healthy,
+					LastError:         st.LastError,
+					ConsecutiveErrors: st.ConsecutiveErrors,
+					AvgLatencyMs:      st.AvgLatencyMs,
+				}
+				if !st.LastSuccess.IsZero() {
+					statuses[i].LastSuccess = &st.LastSuccess
+				}
+				if !st.LastSuccessfulCreateAt.IsZero() {
+					statuses[i].LastSuccessfulCreateAt = &st.LastSuccessfulCreateAt
+				}
+				if !st.LastSuccessfulDeleteAt.IsZero() {
+					statuses[i].LastSuccessfulDeleteAt = &st.LastSuccessfulDeleteAt
+				}
+				if !st.LastListAt.IsZero() {
+					statuses[i].LastListAt = &st.LastListAt
+				}
+			}
+			return statuses
+		}),
 	)
 
-	// Register provider health checkers for /ready endpoint
-	// Ready providers get connectivity checks
+	// Register provider health checkers for /ready and /health/deep
 	for _, inst := range providerRegistry.All() {
-		inst := inst // capture for closure
-		healthServer.RegisterChecker("provider:"+inst.Name(), func(ctx context.Context) error {
-			return inst.Ping(ctx)
-		})
+		registerProviderCheckers(healthServer, inst)
 	}
 
 	// Register a degraded checker for pending providers (#125)
 	// This reports degraded status (not unhealthy) when providers are pending
 	healthServer.RegisterDegradedChecker("provider-manager", func(ctx context.Context) (bool, string) {
 		if providerManager.PendingCount() > 0 {
+			if failed := providerManager.FailedCount(); failed > 0 {
+				return true, fmt.Sprintf("%d providers pending (%d permanently failed, send SIGHUP to retry)",
+					providerManager.PendingCount(), failed)
+			}
 			pending := providerManager.PendingProviders()
 			names := make([]string, len(pending))
 			for i, p := range pending {
@@ -251,9 +451,47 @@ func run() error {
 		}
 	}
 
-	// Run initial reconciliation
-	logger.Info("running initial reconciliation")
-	triggerReconcile()
+	// Start push-based sources (e.g., Consul blocking queries)
+	for _, src := range sourceRegistry.All() {
+		notifier, ok := src.(source.Notifier)
+		if !ok {
+			continue
+		}
+		go func(name string) {
+			if err := notifier.Watch(ctx, triggerReconcile); err != nil && ctx.Err() == nil {
+				logger.Error("source watch stopped",
+					slog.String("source", name),
+					slog.String("error", err.Error()),
+				)
+			}
+		}(src.Name())
+	}
+
+	// Start record management API if enabled
+	var apiServer *api.Server
+	if cfg.APIEnabled() {
+		if cfg.APIToken() == "" {
+			logger.Warn("record management API enabled without DNSWEAVER_API_TOKEN, API is only served on 127.0.0.1")
+		}
+		apiServer = api.New(cfg.APIPort(), rec,
+			api.WithLogger(logger),
+			api.WithToken(cfg.APIToken()),
+			api.WithProviderResetter(providerManager),
+			api.WithProviderDescriber(providerRegistry),
+			api.WithBackoffResetter(rec),
+		)
+		if err := apiServer.Start(); err != nil {
+			return fmt.Errorf("starting API server: %w", err)
+		}
+	}
+
+	// Run initial reconciliation unless disabled; --once always reconciles
+	if cfg.ReconcileOnStart() {
+		logger.Info("running initial reconciliation")
+		triggerReconcile()
+	} else {
+		logger.Info("skipping initial reconciliation, waiting for the first event or interval tick")
+	}
 
 	// Start periodic reconciliation timer as a safety net
 	// This catches any missed Docker events and ensures eventual consistency
@@ -284,12 +522,22 @@ func run() error {
 		slog.Int("health_port", cfg.HealthPort()),
 	)
 
-	// Handle shutdown signals
+	// Handle signals: SIGHUP reloads the log level and provider instances,
+	// resets permanently failed providers and reconcile backoff, others shut down
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 
 	// Wait for shutdown signal
 	sig := <-sigChan
+	for sig == syscall.SIGHUP {
+		reloadLogLevel(logger)
+		reloadProviders(providerManager, healthServer, cfg, logger)
+		logger.Info("received SIGHUP, resetting failed providers",
+			slog.Int("reset", providerManager.ResetFailed()),
+			slog.Int("backoff_reset", rec.ResetBackoff()),
+		)
+		sig = <-sigChan
+	}
 	logger.Info("received shutdown signal", slog.String("signal", sig.String()))
 
 	// Graceful shutdown
@@ -301,19 +549,94 @@ func run() error {
 		fileWatcher.Stop()
 	}
 
+	// Let in-flight reconciliations finish, then cancel any still running
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), cfg.DrainTimeout())
+	if err := rec.Shutdown(drainCtx); err != nil {
+		logger.Warn("in-flight reconciliations did not finish before the drain timeout",
+			slog.Duration("drain_timeout", cfg.DrainTimeout()),
+			slog.String("error", err.Error()),
+		)
+	}
+	drainCancel()
+	cancelReconcile()
+
 	// Shutdown health server with timeout
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer shutdownCancel()
 	if err := healthServer.Shutdown(shutdownCtx); err != nil {
 		logger.Warn("health server shutdown error", slog.String("error", err.Error()))
 	}
+	if apiServer != nil {
+		if err := apiServer.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("API server shutdown error", slog.String("error", err.Error()))
+		}
+	}
 
 	logger.Info("dnsweaver shutdown complete")
 	return nil
 }
 
+// runOnce runs a single reconciliation, prints its summary, and returns an
+// error if the reconciliation or any of its record actions failed.
+func runOnce(ctx context.Context, rec *reconciler.Reconciler, pushGateway *metrics.PushGateway, logger *slog.Logger) error {
+	logger.Info("running single reconciliation (--once)")
+
+	result, err := rec.Reconcile(ctx)
+	if pushGateway != nil {
+		if pushErr := pushGateway.Push(ctx); pushErr != nil {
+			logger.Warn("failed to push metrics", slog.String("error", pushErr.Error()))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("reconciliation failed: %w", err)
+	}
+
+	if result.HasErrors() {
+		fmt.Fprint(os.Stderr, result.Summary())
+		fmt.Fprint(os.Stderr, result.Diff())
+		return fmt.Errorf("%d record actions failed", result.FailedCount())
+	}
+
+	fmt.Print(result.Summary())
+	fmt.Print(result.Diff())
+	return nil
+}
+
+// registerProviderCheckers adds the health checkers of a ready provider
+// instance. /ready uses the tracked status; /health/deep always probes the
+// backend.
+func registerProviderCheckers(hs *health.Server, inst *provider.ProviderInstance) {
+	hs.RegisterChecker("provider:"+inst.Name(), func(ctx context.Context) error {
+		return checkProviderStatus(ctx, inst)
+	})
+	hs.RegisterDeepChecker(inst.Name(), func(ctx context.Context) error {
+		return inst.Ping(ctx)
+	})
+}
+
+// unregisterProviderCheckers removes the health checkers of a provider instance.
+func unregisterProviderCheckers(hs *health.Server, name string) {
+	hs.UnregisterChecker("provider:" + name)
+	hs.UnregisterDeepChecker(name)
+}
+
+// checkProviderStatus reports a provider's readiness from the status tracked
+// across its recent operations. The backend is only probed when nothing has
+// been observed yet or the last operation failed, so a healthy provider costs
+// no extra API calls per readiness check.
+func checkProviderStatus(ctx context.Context, inst *provider.ProviderInstance) error {
+	if st := inst.Status(); st.Known() && st.Healthy {
+		return nil
+	}
+	return inst.Ping(ctx)
+}
+
+// logLevel holds the active log level. SIGHUP updates it in place, so loggers
+// derived from setupLogger pick up the change without being rebuilt.
+var logLevel = new(slog.LevelVar)
+
 func setupLogger(level, format string) *slog.Logger {
-	logLevel := parseLogLevel(level)
+	logLevel.Set(parseLogLevel(level))
 
 	var handler slog.Handler
 	if format == "text" {
@@ -325,6 +648,75 @@ func setupLogger(level, format string) *slog.Logger {
 	return slog.New(handler)
 }
 
+// reloadLogLevel re-reads the log level from DNSWEAVER_LOG_LEVEL and the config
+// file and applies it if it changed.
+func reloadLogLevel(logger *slog.Logger) {
+	level, err := config.LoadLogLevel()
+	if err != nil {
+		logger.Warn("failed to reload log level, keeping current level",
+			slog.String("level", logLevel.Level().String()),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	newLevel := parseLogLevel(level)
+	if newLevel == logLevel.Level() {
+		return
+	}
+	logLevel.Set(newLevel)
+	logger.Log(context.Background(), newLevel, "log level changed to "+level)
+}
+
+// reloadProviders re-reads the configuration and applies added, removed, and
+// changed provider instances to the manager. Changed instances are removed and
+// added again, and the health checkers follow the ready instances. Other
+// settings still require a restart. On a configuration error the running
+// providers are kept.
+func reloadProviders(manager *provider.Manager, hs *health.Server, cfg *config.Config, logger *slog.Logger) {
+	next, diff, err := config.Reload(cfg)
+	if err != nil {
+		logger.Warn("failed to reload configuration, keeping current providers",
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	if diff.Empty() {
+		return
+	}
+
+	for _, name := range diff.RemovedProviders {
+		manager.RemoveProvider(name)
+		unregisterProviderCheckers(hs, name)
+	}
+	for _, inst := range diff.ChangedProviders {
+		manager.RemoveProvider(inst.Name)
+		unregisterProviderCheckers(hs, inst.Name)
+	}
+	for _, inst := range append(diff.ChangedProviders, diff.AddedProviders...) {
+		if err := manager.AddProvider(inst.ToProviderConfig()); err != nil {
+			logger.Error("failed to add reloaded provider",
+				slog.String("provider", inst.Name),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		// Providers still pending are reported by the provider-manager degraded checker
+		if ready, ok := manager.Registry().Get(inst.Name); ok {
+			registerProviderCheckers(hs, ready)
+		}
+	}
+
+	cfg.ProviderNames = next.ProviderNames
+	cfg.ProviderInstances = next.ProviderInstances
+
+	logger.Info("provider configuration reloaded",
+		slog.Int("added", len(diff.AddedProviders)),
+		slog.Int("removed", len(diff.RemovedProviders)),
+		slog.Int("changed", len(diff.ChangedProviders)),
+	)
+}
+
 // parseLogLevel converts a string log level to slog.Level.
 func parseLogLevel(level string) slog.Level {
 	switch level {
@@ -371,6 +763,103 @@ func registerSources(registry *source.Registry, cfg *config.Config, logger *slog
 				slog.String("name", name),
 				slog.Bool("file_discovery", src.SupportsDiscovery()),
 			)
+		case "nomad":
+			nomadCfg, err := nomad.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("loading nomad source config: %w", err)
+			}
+			src, err := nomad.New(nomadCfg, nomad.WithLogger(logger))
+			if err != nil {
+				return fmt.Errorf("creating nomad source: %w", err)
+			}
+			if err := registry.Register(src); err != nil {
+				return fmt.Errorf("registering nomad source: %w", err)
+			}
+			logger.Info("registered source",
+				slog.String("name", name),
+				slog.String("addr", nomadCfg.Addr),
+				slog.String("namespace", nomadCfg.Namespace),
+			)
+		case "consul":
+			consulCfg, err := consul.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("loading consul source config: %w", err)
+			}
+			src, err := consul.New(consulCfg, consul.WithLogger(logger))
+			if err != nil {
+				return fmt.Errorf("creating consul source: %w", err)
+			}
+			if err := registry.Register(src); err != nil {
+				return fmt.Errorf("registering consul source: %w", err)
+			}
+			logger.Info("registered source",
+				slog.String("name", name),
+				slog.String("addr", consulCfg.Addr),
+				slog.String("datacenter", consulCfg.Datacenter),
+			)
+		case "etcd":
+			etcdCfg, err := etcd.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("loading etcd source config: %w", err)
+			}
+			src, err := etcd.New(etcdCfg, etcd.WithLogger(logger))
+			if err != nil {
+				return fmt.Errorf("creating etcd source: %w", err)
+			}
+			if err := registry.Register(src); err != nil {
+				return fmt.Errorf("registering etcd source: %w", err)
+			}
+			logger.Info("registered source",
+				slog.String("name", name),
+				slog.Any("endpoints", etcdCfg.Endpoints),
+				slog.String("prefix", etcdCfg.Prefix),
+			)
+		case "static":
+			staticCfg, err := static.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("loading static source config: %w", err)
+			}
+			src, err := static.New(staticCfg, static.WithLogger(logger))
+			if err != nil {
+				return fmt.Errorf("creating static source: %w", err)
+			}
+			if err := registry.Register(src); err != nil {
+				return fmt.Errorf("registering static source: %w", err)
+			}
+			logger.Info("registered source",
+				slog.String("name", name),
+				slog.String("file", staticCfg.File),
+			)
+		case "nginx-proxy":
+			proxyCfg := nginxproxy.LoadConfig()
+			src, err := nginxproxy.New(proxyCfg, nginxproxy.WithLogger(logger))
+			if err != nil {
+				return fmt.Errorf("creating nginx-proxy source: %w", err)
+			}
+			if err := registry.Register(src); err != nil {
+				return fmt.Errorf("registering nginx-proxy source: %w", err)
+			}
+			logger.Info("registered source",
+				slog.String("name", name),
+				slog.String("label", proxyCfg.HostLabel()),
+			)
+		case "nginx":
+			nginxCfg, err := nginx.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("loading nginx source config: %w", err)
+			}
+			src, err := nginx.New(nginxCfg, nginx.WithLogger(logger))
+			if err != nil {
+				return fmt.Errorf("creating nginx source: %w", err)
+			}
+			if err := registry.Register(src); err != nil {
+				return fmt.Errorf("registering nginx source: %w", err)
+			}
+			logger.Info("registered source",
+				slog.String("name", name),
+				slog.String("dir", nginxCfg.ConfigDir),
+				slog.String("pattern", nginxCfg.Pattern),
+			)
 		default:
 			logger.Warn("unknown source, skipping", slog.String("source", name))
 		}
@@ -393,9 +882,48 @@ func createTraefikSource(cfg *config.Config, logger *slog.Logger) *traefik.Traef
 		)
 	}
 
+	// Only create records for routers on the configured entrypoints
+	if srcCfg != nil && len(srcCfg.Entrypoints) > 0 {
+		opts = append(opts, traefik.WithEntrypoints(srcCfg.Entrypoints))
+		logger.Debug("traefik entrypoint filter configured",
+			slog.Any("entrypoints", srcCfg.Entrypoints),
+		)
+	}
+
+	// Configure Traefik API polling if an endpoint is set
+	if srcCfg != nil && srcCfg.APIEndpoint != "" {
+		opts = append(opts, traefik.WithAPIEndpoint(srcCfg.APIEndpoint, srcCfg.APIPollInterval))
+		logger.Debug("traefik API discovery configured",
+			slog.String("endpoint", srcCfg.APIEndpoint),
+			slog.Duration("poll_interval", srcCfg.APIPollInterval),
+		)
+	}
+
 	return traefik.New(opts...)
 }
 
+// apiPoller is implemented by sources that poll a remote API for hostnames.
+type apiPoller interface {
+	APIPollInterval() time.Duration
+}
+
+// discoveryPollInterval returns the shortest API poll interval among registered
+// sources, so the watcher polls often enough for API-backed discovery.
+// Returns zero if no source polls an API.
+func discoveryPollInterval(registry *source.Registry) time.Duration {
+	var interval time.Duration
+	for _, src := range registry.DiscoverableSources() {
+		p, ok := src.(apiPoller)
+		if !ok {
+			continue
+		}
+		if d := p.APIPollInterval(); d > 0 && (interval == 0 || d < interval) {
+			interval = d
+		}
+	}
+	return interval
+}
+
 func registerProviderFactories(registry *provider.Registry) {
 	// Register Technitium provider factory (private DNS)
 	registry.RegisterFactory("technitium", technitium.Factory())
@@ -403,14 +931,47 @@ func registerProviderFactories(registry *provider.Registry) {
 	// Register Cloudflare provider factory (public DNS)
 	registry.RegisterFactory("cloudflare", cloudflare.Factory())
 
+	// Register Cloudflare Tunnel provider factory (public hostnames on a tunnel)
+	registry.RegisterFactory("cloudflare-tunnel", cloudflaretunnel.Factory())
+
 	// Register Webhook provider factory (custom integrations)
 	registry.RegisterFactory("webhook", webhook.Factory())
 
 	// Register dnsmasq provider factory (local DNS, Pi-hole backend)
 	registry.RegisterFactory("dnsmasq", dnsmasq.Factory())
 
+	// Register hosts file provider factory (/etc/hosts, locally or over SSH)
+	registry.RegisterFactory("hosts", hosts.Factory())
+
 	// Register Pi-hole provider factory (local DNS via Pi-hole API or file mode)
 	registry.RegisterFactory("pihole", pihole.Factory())
+
+	// Register PowerDNS provider factory (authoritative server HTTP API)
+	registry.RegisterFactory("powerdns", powerdns.Factory())
+
+	// Register AWS Route53 provider factory (hosted zones via the AWS SDK)
+	registry.RegisterFactory("route53", route53.Factory())
+
+	// Register Google Cloud DNS provider factory (managed zones via the Cloud DNS API)
+	registry.RegisterFactory("clouddns", clouddns.Factory())
+
+	// Register Knot DNS provider factory (knotc, locally or over SSH)
+	registry.RegisterFactory("knot", knot.Factory())
+
+	// Register CoreDNS provider factory (etcd plugin, SkyDNS format)
+	registry.RegisterFactory("coredns", coredns.Factory())
+
+	// Register Windows DNS provider factory (PowerShell over WinRM or SSH)
+	registry.RegisterFactory("windns", windns.Factory())
+
+	// Register AdGuard Home provider factory (DNS rewrites via HTTP API)
+	registry.RegisterFactory("adguard", adguard.Factory())
+
+	// Register HTTP provider factory (user-defined URL templates per operation)
+	registry.RegisterFactory("http", httpprovider.Factory())
+
+	// Register failover meta-provider factory (primary/secondary instances)
+	registry.RegisterFactory("failover", failover.Factory())
 }
 
 // initializeProviders initializes all configured providers using the manager.
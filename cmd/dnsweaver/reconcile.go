@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/reconciler"
+)
+
+// runReconcile handles `dnsweaver reconcile --hostname=<name>`. It starts up
+// like the daemon (loads config, connects providers) but only reconciles the
+// given hostname, prints every action with its status and error, and exits
+// without starting watchers. Returns the process exit code.
+func runReconcile(args []string) int {
+	fs := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	hostname := fs.String("hostname", "", "Hostname to reconcile (required)")
+	dryRun := fs.Bool("dry-run", false, "Show what would change without applying it")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *hostname == "" {
+		fmt.Fprintln(os.Stderr, "reconcile: --hostname is required")
+		fs.Usage()
+		return 2
+	}
+
+	// --dry-run overrides the configured value, like --config does for DNSWEAVER_CONFIG
+	if *dryRun {
+		if err := os.Setenv("DNSWEAVER_DRY_RUN", "true"); err != nil {
+			slog.Error("failed to set DNSWEAVER_DRY_RUN", slog.String("error", err.Error()))
+			return 1
+		}
+	}
+
+	if err := run(runOptions{hostname: *hostname}); err != nil {
+		slog.Error("reconcile failed", slog.String("error", err.Error()))
+		return 1
+	}
+	return 0
+}
+
+// runHostname reconciles a single hostname and prints the full result.
+// Returns an error if any action failed.
+func runHostname(ctx context.Context, rec *reconciler.Reconciler, hostname string, logger *slog.Logger) error {
+	logger.Info("reconciling single hostname", slog.String("hostname", hostname))
+
+	result, err := rec.ReconcileHostname(ctx, hostname)
+	if err != nil {
+		return fmt.Errorf("reconciling %s: %w", hostname, err)
+	}
+
+	fmt.Printf("Actions for %s:\n", hostname)
+	if len(result.Actions) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, a := range result.Actions {
+		fmt.Printf("  %s\n", a.String())
+		if a.Attempts > 1 {
+			fmt.Printf("    attempts: %d\n", a.Attempts)
+		}
+	}
+	fmt.Print(result.Summary())
+
+	if result.HasErrors() {
+		return fmt.Errorf("%d record actions failed", result.FailedCount())
+	}
+	return nil
+}
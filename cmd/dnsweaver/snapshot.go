@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/config"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// runSnapshot handles `dnsweaver snapshot --output=<file>`. It lists the
+// records of every configured provider and writes them as a JSON snapshot
+// that `dnsweaver restore` can apply. Returns the process exit code.
+func runSnapshot(args []string) int {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	output := fs.String("output", "", "File to write the snapshot to (- for stdout)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *output == "" {
+		fmt.Fprintln(os.Stderr, "snapshot: --output is required")
+		fs.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	registry, logger, ok := loadSnapshotRegistry()
+	if !ok {
+		return 1
+	}
+	defer func() { _ = registry.Close() }()
+
+	snap, err := registry.Snapshot(ctx)
+	if err != nil {
+		logger.Error("snapshot failed", slog.String("error", err.Error()))
+		return 1
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		logger.Error("encoding snapshot failed", slog.String("error", err.Error()))
+		return 1
+	}
+	data = append(data, '\n')
+
+	if *output == "-" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(*output, data, 0o600)
+	}
+	if err != nil {
+		logger.Error("writing snapshot failed", slog.String("error", err.Error()))
+		return 1
+	}
+
+	records := 0
+	for _, ps := range snap.Providers {
+		records += len(ps.Records)
+	}
+	logger.Info("snapshot written",
+		slog.String("output", *output),
+		slog.Int("providers", len(snap.Providers)),
+		slog.Int("records", records),
+	)
+	return 0
+}
+
+// runRestore handles `dnsweaver restore --input=<file>`. It brings every
+// provider in the snapshot back to its snapshotted records. With --dry-run
+// the planned changes are printed and nothing is changed. Returns the
+// process exit code: 1 if any change failed.
+func runRestore(args []string) int {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	input := fs.String("input", "", "Snapshot file written by `dnsweaver snapshot` (required)")
+	dryRun := fs.Bool("dry-run", false, "Print the changes without applying them")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *input == "" {
+		fmt.Fprintln(os.Stderr, "restore: --input is required")
+		fs.Usage()
+		return 2
+	}
+
+	data, err := os.ReadFile(*input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "restore: %v\n", err)
+		return 1
+	}
+	var snap provider.Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		fmt.Fprintf(os.Stderr, "restore: parsing %s: %v\n", *input, err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	registry, logger, ok := loadSnapshotRegistry()
+	if !ok {
+		return 1
+	}
+	defer func() { _ = registry.Close() }()
+
+	if *dryRun {
+		changes, err := registry.PlanRestore(ctx, &snap)
+		if err != nil {
+			logger.Error("planning restore failed", slog.String("error", err.Error()))
+			return 1
+		}
+		fmt.Printf("Restore of %s (dry run):\n", *input)
+		if len(changes) == 0 {
+			fmt.Println("  (no changes)")
+		}
+		for _, c := range changes {
+			fmt.Printf("  %s %s %s -> %s (%s)\n", c.Operation, c.Record.Type, c.Record.Hostname, c.Record.Target, c.Provider)
+		}
+		return 0
+	}
+
+	if err := registry.Restore(ctx, &snap); err != nil {
+		logger.Error("restore failed", slog.String("error", err.Error()))
+		return 1
+	}
+	logger.Info("restore complete", slog.String("input", *input))
+	return 0
+}
+
+// loadSnapshotRegistry loads the configuration and creates every provider
+// instance. Logs go to stderr so snapshot output can be piped. Reports false
+// if the configuration is invalid or any provider cannot be created, since a
+// partial registry would snapshot or restore only some providers.
+func loadSnapshotRegistry() (*provider.Registry, *slog.Logger, bool) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	cfg, err := config.Load()
+	if err != nil {
+		var verr *config.ValidationError
+		if errors.As(err, &verr) {
+			reportProblems(logger, verr.Errors)
+		} else {
+			reportProblems(logger, []string{err.Error()})
+		}
+		return nil, logger, false
+	}
+	logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: parseLogLevel(cfg.LogLevel())}))
+
+	registry := provider.NewRegistry(logger)
+	registerProviderFactories(registry)
+
+	ok := true
+	for _, inst := range cfg.ProviderInstances {
+		if err := registry.CreateInstance(inst.ToProviderConfig()); err != nil {
+			logger.Error("failed to create provider",
+				slog.String("provider", inst.Name),
+				slog.String("error", err.Error()),
+			)
+			ok = false
+		}
+	}
+	if !ok {
+		_ = registry.Close()
+		return nil, logger, false
+	}
+	return registry, logger, true
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/config"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/matcher"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+// runValidate loads and checks the configuration without connecting to Docker
+// or any DNS provider. Each problem is logged as its own structured entry.
+// Returns the process exit code: 0 if valid, 1 otherwise.
+func runValidate() int {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	cfg, err := config.Load()
+	if err != nil {
+		var verr *config.ValidationError
+		if errors.As(err, &verr) {
+			reportProblems(logger, verr.Errors)
+		} else {
+			reportProblems(logger, []string{err.Error()})
+		}
+		return 1
+	}
+
+	logger = setupLogger(cfg.LogLevel(), cfg.LogFormat())
+	problems := validateConfiguration(cfg)
+	if len(problems) > 0 {
+		reportProblems(logger, problems)
+		return 1
+	}
+
+	logger.Info("configuration is valid",
+		slog.Int("providers", len(cfg.ProviderInstances)),
+		slog.Any("sources", cfg.SourceNames()),
+	)
+	return 0
+}
+
+// validateConfiguration runs the checks that config.Load leaves to startup:
+// provider types, per-instance validation, domain patterns, and source config.
+// Nothing here opens a network connection.
+func validateConfiguration(cfg *config.Config) []string {
+	quiet := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	providers := provider.NewRegistry(quiet)
+	registerProviderFactories(providers)
+	problems := cfg.ValidateProviderTypes(providers.Types())
+
+	for _, inst := range cfg.ProviderInstances {
+		providerCfg := inst.ToProviderConfig()
+		if err := providerCfg.Validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("provider %s: %s", inst.Name, err))
+			continue
+		}
+		_, err := matcher.NewDomainMatcher(matcher.DomainMatcherConfig{
+			Includes: providerCfg.GetIncludes(),
+			Excludes: providerCfg.GetExcludes(),
+			UseRegex: providerCfg.UseRegex(),
+		})
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("provider %s: %s", inst.Name, err))
+		}
+	}
+
+	if err := registerSources(source.NewRegistry(quiet), cfg, quiet); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	return problems
+}
+
+// reportProblems logs each configuration problem as a separate entry.
+func reportProblems(logger *slog.Logger, problems []string) {
+	for _, p := range problems {
+		logger.Error("configuration problem", slog.String("error", p))
+	}
+	logger.Error("configuration is invalid", slog.Int("problems", len(problems)))
+}
@@ -0,0 +1,209 @@
+//go:build integration
+
+// Package integration runs the full reconciler against real DNS servers.
+//
+// The servers are defined in docker-compose.test.yml; each provider test is
+// skipped unless its DNSWEAVER_IT_* environment variables are set. Run with:
+//
+//	make test-integration
+package integration
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/docker"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/reconciler"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+	"gitlab.bluewillows.net/root/dnsweaver/sources/traefik"
+)
+
+// testTimeout bounds every call made against a real server.
+const testTimeout = 30 * time.Second
+
+// staticLister is a reconciler.WorkloadLister backed by an in-memory list.
+type staticLister struct {
+	mu        sync.Mutex
+	workloads []docker.Workload
+}
+
+func (l *staticLister) ListWorkloads(_ context.Context) ([]docker.Workload, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]docker.Workload(nil), l.workloads...), nil
+}
+
+func (l *staticLister) Mode() docker.Mode { return docker.ModeStandalone }
+
+func (l *staticLister) set(workloads ...docker.Workload) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.workloads = workloads
+}
+
+// harness wires a single real provider instance into a reconciler.
+type harness struct {
+	logger     *slog.Logger
+	providers  *provider.Registry
+	sources    *source.Registry
+	inst       *provider.ProviderInstance
+	lister     *staticLister
+	reconciler *reconciler.Reconciler
+}
+
+func newHarness(t *testing.T, typeName string, factory provider.Factory, cfg provider.ProviderInstanceConfig) *harness {
+	t.Helper()
+
+	logger := testLogger()
+	providers := provider.NewRegistry(logger)
+	providers.RegisterFactory(typeName, factory)
+	cfg.TypeName = typeName
+	if err := providers.CreateInstance(cfg); err != nil {
+		t.Fatalf("creating %s instance: %v", typeName, err)
+	}
+	inst, _ := providers.Get(cfg.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := inst.Ping(ctx); err != nil {
+		t.Fatalf("%s is not reachable: %v", typeName, err)
+	}
+
+	sources := source.NewRegistry(logger, source.WithCaseInsensitiveDedup())
+	if err := sources.Register(traefik.New(traefik.WithLogger(logger))); err != nil {
+		t.Fatalf("registering traefik source: %v", err)
+	}
+
+	lister := &staticLister{}
+	h := &harness{
+		logger:     logger,
+		providers:  providers,
+		sources:    sources,
+		inst:       inst,
+		lister:     lister,
+		reconciler: reconciler.New(lister, sources, providers, reconciler.WithLogger(logger)),
+	}
+	t.Cleanup(func() { h.cleanup(t) })
+	return h
+}
+
+// hostname returns a hostname in zone that is unique to this test run.
+func (h *harness) hostname(zone string) string {
+	return fmt.Sprintf("it-%d.%s", time.Now().UnixNano(), zone)
+}
+
+// deploy replaces the running workloads with one Traefik router per hostname.
+func (h *harness) deploy(hostnames ...string) {
+	labels := make(map[string]string, len(hostnames))
+	for i, hostname := range hostnames {
+		labels[fmt.Sprintf("traefik.http.routers.it%d.rule", i)] = fmt.Sprintf("Host(`%s`)", hostname)
+	}
+	h.lister.set(docker.Workload{ID: "integration", Name: "integration", Labels: labels, Type: docker.WorkloadTypeContainer})
+}
+
+// reconcile runs one reconciliation and fails the test on any failed action.
+func (h *harness) reconcile(t *testing.T) *reconciler.Result {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	result, err := h.reconciler.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	for _, action := range result.Failed() {
+		t.Errorf("action %s %s on %s failed: %s", action.Type, action.Hostname, action.Provider, action.Error)
+	}
+	return result
+}
+
+// records returns the non-ownership records for hostname.
+func (h *harness) records(t *testing.T, hostname string) []provider.Record {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	records, err := h.inst.GetExistingRecords(ctx, hostname)
+	if err != nil {
+		t.Fatalf("listing records for %s: %v", hostname, err)
+	}
+	return records
+}
+
+// owned reports whether hostname has an ownership TXT record.
+func (h *harness) owned(t *testing.T, hostname string) bool {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	owned, err := h.inst.HasOwnershipRecord(ctx, hostname)
+	if err != nil {
+		t.Fatalf("checking ownership of %s: %v", hostname, err)
+	}
+	return owned
+}
+
+// cleanup removes everything the test created. A fresh reconciler with no
+// workloads recovers ownership from the server and deletes the orphans, the
+// same path dnsweaver takes after a restart; anything left over is removed
+// directly.
+func (h *harness) cleanup(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	fresh := reconciler.New(&staticLister{}, h.sources, h.providers, reconciler.WithLogger(h.logger))
+	if err := fresh.RecoverOwnership(ctx); err != nil {
+		t.Errorf("cleanup: recovering ownership: %v", err)
+	}
+	if _, err := fresh.Reconcile(ctx); err != nil {
+		t.Errorf("cleanup: reconcile: %v", err)
+	}
+
+	hostnames, err := h.inst.RecoverOwnedHostnames(ctx)
+	if err != nil {
+		t.Errorf("cleanup: listing owned hostnames: %v", err)
+		return
+	}
+	for _, hostname := range hostnames {
+		t.Logf("cleanup: removing leftover records for %s", hostname)
+		if err := h.inst.DeleteRecord(ctx, hostname); err != nil && !provider.IsNotFound(err) {
+			t.Errorf("cleanup: deleting %s: %v", hostname, err)
+		}
+		if err := h.inst.DeleteOwnershipRecord(ctx, hostname); err != nil && !provider.IsNotFound(err) {
+			t.Errorf("cleanup: deleting ownership record for %s: %v", hostname, err)
+		}
+	}
+}
+
+// testLogger logs at debug level when DNSWEAVER_IT_VERBOSE is set.
+func testLogger() *slog.Logger {
+	if os.Getenv("DNSWEAVER_IT_VERBOSE") == "" {
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// envOrSkip returns the value of key or skips the test when it is unset.
+func envOrSkip(t *testing.T, key string) string {
+	t.Helper()
+	v := os.Getenv(key)
+	if v == "" {
+		t.Skipf("%s not set", key)
+	}
+	return v
+}
+
+// envOrDefault returns the value of key, or def when it is unset.
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
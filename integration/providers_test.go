@@ -0,0 +1,153 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/pihole"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/technitium"
+)
+
+// testZone is the zone every integration test writes into.
+const testZone = "integration.test"
+
+func TestTechnitium(t *testing.T) {
+	baseURL := envOrSkip(t, "DNSWEAVER_IT_TECHNITIUM_URL")
+	token := technitiumSetup(t, baseURL, envOrDefault("DNSWEAVER_IT_TECHNITIUM_PASSWORD", "dnsweaver-test"))
+
+	h := newHarness(t, "technitium", technitium.Factory(), provider.ProviderInstanceConfig{
+		Name:       "technitium-it",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.99.0.1",
+		TTL:        60,
+		Domains:    []string{"*." + testZone},
+		ProviderConfig: map[string]string{
+			"URL":   baseURL,
+			"TOKEN": token,
+			"ZONE":  testZone,
+		},
+	})
+
+	runLifecycle(t, h)
+}
+
+func TestPihole(t *testing.T) {
+	baseURL := envOrSkip(t, "DNSWEAVER_IT_PIHOLE_URL")
+
+	h := newHarness(t, "pihole", pihole.Factory(), provider.ProviderInstanceConfig{
+		Name:       "pihole-it",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.99.0.1",
+		TTL:        60,
+		Domains:    []string{"*." + testZone},
+		ProviderConfig: map[string]string{
+			"api_endpoint": baseURL,
+			"api_password": envOrDefault("DNSWEAVER_IT_PIHOLE_PASSWORD", "dnsweaver-test"),
+			"zone":         testZone,
+		},
+	})
+
+	runLifecycle(t, h)
+}
+
+// runLifecycle exercises create, idempotent re-run and orphan removal against
+// the harness provider.
+func runLifecycle(t *testing.T, h *harness) {
+	kept := h.hostname(testZone)
+	removed := h.hostname(testZone)
+
+	t.Run("create", func(t *testing.T) {
+		h.deploy(kept, removed)
+		h.reconcile(t)
+
+		for _, hostname := range []string{kept, removed} {
+			records := h.records(t, hostname)
+			if len(records) != 1 || records[0].Type != provider.RecordTypeA || records[0].Target != "10.99.0.1" {
+				t.Errorf("records for %s = %+v, want one A record to 10.99.0.1", hostname, records)
+			}
+			if !h.owned(t, hostname) {
+				t.Errorf("%s has no ownership record", hostname)
+			}
+		}
+	})
+
+	t.Run("idempotent", func(t *testing.T) {
+		result := h.reconcile(t)
+		if n := result.CreatedCount(); n != 0 {
+			t.Errorf("second reconcile created %d records, want 0", n)
+		}
+	})
+
+	t.Run("orphan cleanup", func(t *testing.T) {
+		h.deploy(kept)
+		h.reconcile(t)
+
+		if records := h.records(t, removed); len(records) != 0 {
+			t.Errorf("records for removed %s = %+v, want none", removed, records)
+		}
+		if h.owned(t, removed) {
+			t.Errorf("ownership record for removed %s still exists", removed)
+		}
+		if records := h.records(t, kept); len(records) != 1 {
+			t.Errorf("records for kept %s = %+v, want one", kept, records)
+		}
+	})
+}
+
+// technitiumSetup logs in as admin and creates the test zone, returning an
+// API token for the provider.
+func technitiumSetup(t *testing.T, baseURL, password string) string {
+	t.Helper()
+
+	var login struct {
+		Status       string `json:"status"`
+		Token        string `json:"token"`
+		ErrorMessage string `json:"errorMessage"`
+	}
+	technitiumCall(t, baseURL, "/api/user/login", url.Values{"user": {"admin"}, "pass": {password}}, &login)
+	if login.Status != "ok" {
+		t.Fatalf("technitium login: %s", login.ErrorMessage)
+	}
+
+	var create struct {
+		Status       string `json:"status"`
+		ErrorMessage string `json:"errorMessage"`
+	}
+	technitiumCall(t, baseURL, "/api/zones/create", url.Values{"token": {login.Token}, "zone": {testZone}, "type": {"Primary"}}, &create)
+	if create.Status != "ok" && !strings.Contains(create.ErrorMessage, "already exists") {
+		t.Fatalf("technitium zone create: %s", create.ErrorMessage)
+	}
+
+	return login.Token
+}
+
+func technitiumCall(t *testing.T, baseURL, path string, params url.Values, out any) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+path+"?"+params.Encode(), nil)
+	if err != nil {
+		t.Fatalf("technitium %s: %v", path, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("technitium %s: %v", path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("technitium %s: %s", path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("technitium %s: %v", path, fmt.Errorf("decoding response: %w", err))
+	}
+}
@@ -0,0 +1,358 @@
+// Package api provides an optional HTTP API for on-demand DNS record management.
+//
+// The API shares the running reconciler, allowing records to be reconciled or
+// removed imperatively without waiting for the next reconcile loop.
+//
+// Endpoints:
+//
+//	GET    /api/v1/records            List known hostnames
+//	POST   /api/v1/records            Reconcile a single hostname
+//	DELETE /api/v1/records/{hostname} Remove records for a hostname
+//	POST   /api/v1/reconcile          Trigger a full reconciliation
+//	GET    /api/v1/providers          Describe the provider instance configuration
+//	POST   /api/v1/providers/reset    Resume retries for permanently failed providers
+//	POST   /api/v1/backoff/reset      Clear reconcile backoff for failing hostnames
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/reconciler"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+// Reconciler is the subset of reconciler.Reconciler used by the API.
+type Reconciler interface {
+	Reconcile(ctx context.Context) (*reconciler.Result, error)
+	ReconcileHostname(ctx context.Context, hostname string) (*reconciler.Result, error)
+	RemoveHostname(ctx context.Context, hostname string) (*reconciler.Result, error)
+	KnownHostnames() []string
+}
+
+// ProviderResetter resets providers that have stopped retrying.
+// It is implemented by provider.Manager.
+type ProviderResetter interface {
+	ResetFailed() int
+}
+
+// ProviderDescriber describes the effective configuration of provider instances.
+// It is implemented by provider.Registry.
+type ProviderDescriber interface {
+	Describe() []provider.ProviderDescription
+}
+
+// BackoffResetter clears the reconcile backoff of hostnames that kept failing.
+// It is implemented by reconciler.Reconciler.
+type BackoffResetter interface {
+	ResetBackoff() int
+}
+
+// RecordRequest is the request body for POST /api/v1/records.
+type RecordRequest struct {
+	Hostname string `json:"hostname"`
+}
+
+// RecordsResponse is the response body for GET /api/v1/records.
+type RecordsResponse struct {
+	Hostnames []string `json:"hostnames"`
+}
+
+// ResultResponse wraps a reconciliation result.
+type ResultResponse struct {
+	Result *reconciler.Result `json:"result"`
+}
+
+// ResetResponse is the response body for POST /api/v1/providers/reset and
+// POST /api/v1/backoff/reset.
+type ResetResponse struct {
+	Reset int `json:"reset"`
+}
+
+// ErrorResponse is returned for failed requests.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// DefaultOperationTimeout bounds reconciliations started through the API.
+const DefaultOperationTimeout = 5 * time.Minute
+
+// Server serves the record management API.
+type Server struct {
+	port       int
+	token      string
+	timeout    time.Duration
+	reconciler Reconciler
+	providers  ProviderResetter
+	describer  ProviderDescriber
+	backoff    BackoffResetter
+	mux        *http.ServeMux
+	server     *http.Server
+	logger     *slog.Logger
+}
+
+// Option is a functional option for configuring the Server.
+type Option func(*Server)
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// WithToken requires requests to carry "Authorization: Bearer <token>".
+// An empty token disables authentication, and the server then only listens
+// on the loopback interface.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithOperationTimeout bounds reconciliations started through the API.
+// Non-positive values are ignored.
+func WithOperationTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		if d > 0 {
+			s.timeout = d
+		}
+	}
+}
+
+// WithProviderResetter enables POST /api/v1/providers/reset.
+func WithProviderResetter(r ProviderResetter) Option {
+	return func(s *Server) {
+		s.providers = r
+	}
+}
+
+// WithProviderDescriber enables GET /api/v1/providers.
+func WithProviderDescriber(d ProviderDescriber) Option {
+	return func(s *Server) {
+		s.describer = d
+	}
+}
+
+// WithBackoffResetter enables POST /api/v1/backoff/reset.
+func WithBackoffResetter(r BackoffResetter) Option {
+	return func(s *Server) {
+		s.backoff = r
+	}
+}
+
+// New creates a new API server on the specified port.
+func New(port int, rec Reconciler, opts ...Option) *Server {
+	s := &Server{
+		port:       port,
+		timeout:    DefaultOperationTimeout,
+		reconciler: rec,
+		mux:        http.NewServeMux(),
+		logger:     slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.setupRoutes()
+	return s
+}
+
+func (s *Server) setupRoutes() {
+	s.mux.HandleFunc("GET /api/v1/records", s.handleListRecords)
+	s.mux.HandleFunc("POST /api/v1/records", s.handleCreateRecord)
+	s.mux.HandleFunc("DELETE /api/v1/records/{hostname}", s.handleDeleteRecord)
+	s.mux.HandleFunc("POST /api/v1/reconcile", s.handleReconcile)
+	s.mux.HandleFunc("GET /api/v1/providers", s.handleListProviders)
+	s.mux.HandleFunc("POST /api/v1/providers/reset", s.handleResetProviders)
+	s.mux.HandleFunc("POST /api/v1/backoff/reset", s.handleResetBackoff)
+}
+
+// Handler returns the API handler with authentication applied.
+func (s *Server) Handler() http.Handler {
+	return s.authenticate(s.mux)
+}
+
+// authenticate enforces bearer token authentication when a token is configured.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.token != "" {
+			provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(s.token)) != 1 {
+				s.logger.Warn("unauthorized API request",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+				)
+				writeJSON(w, http.StatusUnauthorized, ErrorResponse{Error: "unauthorized"})
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleListRecords(w http.ResponseWriter, _ *http.Request) {
+	hostnames := s.reconciler.KnownHostnames()
+	sort.Strings(hostnames)
+	writeJSON(w, http.StatusOK, RecordsResponse{Hostnames: hostnames})
+}
+
+func (s *Server) handleCreateRecord(w http.ResponseWriter, r *http.Request) {
+	var req RecordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("invalid request body: %v", err)})
+		return
+	}
+
+	hostname := source.NormalizeHostname(req.Hostname)
+	if err := source.ValidateHostname(hostname); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	s.logger.Info("API reconcile hostname requested", slog.String("hostname", hostname))
+
+	ctx, cancel := s.operationContext(r)
+	defer cancel()
+
+	result, err := s.reconciler.ReconcileHostname(ctx, hostname)
+	s.writeResult(w, result, err)
+}
+
+func (s *Server) handleDeleteRecord(w http.ResponseWriter, r *http.Request) {
+	hostname := source.NormalizeHostname(r.PathValue("hostname"))
+	if err := source.ValidateHostname(hostname); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	s.logger.Info("API remove hostname requested", slog.String("hostname", hostname))
+
+	ctx, cancel := s.operationContext(r)
+	defer cancel()
+
+	result, err := s.reconciler.RemoveHostname(ctx, hostname)
+	s.writeResult(w, result, err)
+}
+
+func (s *Server) handleReconcile(w http.ResponseWriter, r *http.Request) {
+	s.logger.Info("API full reconciliation requested")
+
+	ctx, cancel := s.operationContext(r)
+	defer cancel()
+
+	result, err := s.reconciler.Reconcile(ctx)
+	s.writeResult(w, result, err)
+}
+
+func (s *Server) handleListProviders(w http.ResponseWriter, _ *http.Request) {
+	if s.describer == nil {
+		writeJSON(w, http.StatusNotImplemented, ErrorResponse{Error: "provider descriptions not available"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.describer.Describe())
+}
+
+func (s *Server) handleResetProviders(w http.ResponseWriter, _ *http.Request) {
+	if s.providers == nil {
+		writeJSON(w, http.StatusNotImplemented, ErrorResponse{Error: "provider reset not available"})
+		return
+	}
+
+	count := s.providers.ResetFailed()
+	s.logger.Info("API provider reset requested", slog.Int("reset", count))
+	writeJSON(w, http.StatusOK, ResetResponse{Reset: count})
+}
+
+func (s *Server) handleResetBackoff(w http.ResponseWriter, _ *http.Request) {
+	if s.backoff == nil {
+		writeJSON(w, http.StatusNotImplemented, ErrorResponse{Error: "backoff reset not available"})
+		return
+	}
+
+	count := s.backoff.ResetBackoff()
+	s.logger.Info("API backoff reset requested", slog.Int("reset", count))
+	writeJSON(w, http.StatusOK, ResetResponse{Reset: count})
+}
+
+// operationContext returns the context for a reconciliation started by r.
+// It is detached from the request so a client disconnecting does not abort
+// provider writes halfway, and bounded by the operation timeout instead.
+func (s *Server) operationContext(r *http.Request) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(r.Context()), s.timeout)
+}
+
+// writeResult writes a reconciliation result, or an error if reconciliation failed.
+// Results with failed actions are returned with 207 Multi-Status.
+func (s *Server) writeResult(w http.ResponseWriter, result *reconciler.Result, err error) {
+	if errors.Is(err, reconciler.ErrHostnameFiltered) {
+		writeJSON(w, http.StatusUnprocessableEntity, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		s.logger.Error("API reconciliation failed", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	status := http.StatusOK
+	if result.HasErrors() {
+		status = http.StatusMultiStatus
+	}
+	writeJSON(w, status, ResultResponse{Result: result})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// Start starts the API server in a goroutine.
+func (s *Server) Start() error {
+	s.server = &http.Server{
+		Addr:              s.addr(),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		s.logger.Info("API server starting",
+			slog.String("addr", s.server.Addr),
+			slog.Bool("auth", s.token != ""),
+		)
+		if err := s.server.ListenAndServe(); err != http.ErrServerClosed {
+			s.logger.Error("API server error", slog.String("error", err.Error()))
+		}
+	}()
+
+	return nil
+}
+
+// addr returns the listen address. Without a token the API is unauthenticated,
+// so it is only served on the loopback interface.
+func (s *Server) addr() string {
+	if s.token == "" {
+		return fmt.Sprintf("127.0.0.1:%d", s.port)
+	}
+	return fmt.Sprintf(":%d", s.port)
+}
+
+// Shutdown gracefully shuts down the API server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.server == nil {
+		return nil
+	}
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,384 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/reconciler"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// mockReconciler records calls made by the API.
+type mockReconciler struct {
+	known      []string
+	reconciled []string
+	removed    []string
+	fullRuns   int
+	ctxErr     error
+	deadline   bool
+	err        error
+	failAction bool
+}
+
+func (m *mockReconciler) result() *reconciler.Result {
+	result := reconciler.NewResult(false)
+	if m.failAction {
+		result.AddAction(reconciler.Action{Type: reconciler.ActionCreate, Status: reconciler.StatusFailed, Error: "boom"})
+	}
+	result.Complete()
+	return result
+}
+
+func (m *mockReconciler) Reconcile(ctx context.Context) (*reconciler.Result, error) {
+	m.fullRuns++
+	m.ctxErr = ctx.Err()
+	_, m.deadline = ctx.Deadline()
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.result(), nil
+}
+
+func (m *mockReconciler) ReconcileHostname(_ context.Context, hostname string) (*reconciler.Result, error) {
+	m.reconciled = append(m.reconciled, hostname)
+	return m.result(), m.err
+}
+
+func (m *mockReconciler) RemoveHostname(_ context.Context, hostname string) (*reconciler.Result, error) {
+	m.removed = append(m.removed, hostname)
+	return m.result(), m.err
+}
+
+func (m *mockReconciler) KnownHostnames() []string {
+	return m.known
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func serve(s *Server, method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, req)
+	return w
+}
+
+func TestServer_ListRecords(t *testing.T) {
+	rec := &mockReconciler{known: []string{"b.example.com", "a.example.com"}}
+	s := New(0, rec, WithLogger(testLogger()))
+
+	w := serve(s, http.MethodGet, "/api/v1/records", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var resp RecordsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Hostnames) != 2 || resp.Hostnames[0] != "a.example.com" {
+		t.Errorf("unexpected hostnames: %v", resp.Hostnames)
+	}
+}
+
+func TestServer_CreateRecord(t *testing.T) {
+	rec := &mockReconciler{}
+	s := New(0, rec, WithLogger(testLogger()))
+
+	w := serve(s, http.MethodPost, "/api/v1/records", `{"hostname": "App.Example.com."}`, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(rec.reconciled) != 1 || rec.reconciled[0] != "app.example.com" {
+		t.Errorf("expected normalized hostname to be reconciled, got %v", rec.reconciled)
+	}
+
+	var resp ResultResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Result == nil {
+		t.Error("expected result in response")
+	}
+}
+
+func TestServer_CreateRecord_Invalid(t *testing.T) {
+	rec := &mockReconciler{}
+	s := New(0, rec, WithLogger(testLogger()))
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"malformed JSON", `{`},
+		{"missing hostname", `{}`},
+		{"invalid hostname", `{"hostname": "bad_host!"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(s, http.MethodPost, "/api/v1/records", tt.body, nil)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", w.Code)
+			}
+		})
+	}
+
+	if len(rec.reconciled) != 0 {
+		t.Errorf("expected no reconciliation, got %v", rec.reconciled)
+	}
+}
+
+func TestServer_CreateRecord_Filtered(t *testing.T) {
+	rec := &mockReconciler{err: reconciler.ErrHostnameFiltered}
+	s := New(0, rec, WithLogger(testLogger()))
+
+	w := serve(s, http.MethodPost, "/api/v1/records", `{"hostname": "admin.example.com"}`, nil)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestServer_DeleteRecord(t *testing.T) {
+	rec := &mockReconciler{}
+	s := New(0, rec, WithLogger(testLogger()))
+
+	w := serve(s, http.MethodDelete, "/api/v1/records/app.example.com", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if len(rec.removed) != 1 || rec.removed[0] != "app.example.com" {
+		t.Errorf("expected hostname to be removed, got %v", rec.removed)
+	}
+}
+
+func TestServer_Reconcile(t *testing.T) {
+	rec := &mockReconciler{}
+	s := New(0, rec, WithLogger(testLogger()))
+
+	w := serve(s, http.MethodPost, "/api/v1/reconcile", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if rec.fullRuns != 1 {
+		t.Errorf("expected 1 reconciliation, got %d", rec.fullRuns)
+	}
+}
+
+func TestServer_Reconcile_Errors(t *testing.T) {
+	t.Run("reconcile error", func(t *testing.T) {
+		s := New(0, &mockReconciler{err: errors.New("docker unavailable")}, WithLogger(testLogger()))
+		w := serve(s, http.MethodPost, "/api/v1/reconcile", "", nil)
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", w.Code)
+		}
+	})
+
+	t.Run("failed actions", func(t *testing.T) {
+		s := New(0, &mockReconciler{failAction: true}, WithLogger(testLogger()))
+		w := serve(s, http.MethodPost, "/api/v1/reconcile", "", nil)
+		if w.Code != http.StatusMultiStatus {
+			t.Errorf("expected status 207, got %d", w.Code)
+		}
+	})
+}
+
+func TestServer_Authentication(t *testing.T) {
+	rec := &mockReconciler{}
+	s := New(0, rec, WithLogger(testLogger()), WithToken("secret"))
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   int
+	}{
+		{"missing token", nil, http.StatusUnauthorized},
+		{"wrong token", map[string]string{"Authorization": "Bearer wrong"}, http.StatusUnauthorized},
+		{"wrong scheme", map[string]string{"Authorization": "Basic secret"}, http.StatusUnauthorized},
+		{"valid token", map[string]string{"Authorization": "Bearer secret"}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(s, http.MethodGet, "/api/v1/records", "", tt.header)
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestServer_MethodNotAllowed(t *testing.T) {
+	s := New(0, &mockReconciler{}, WithLogger(testLogger()))
+
+	w := serve(s, http.MethodGet, "/api/v1/reconcile", "", nil)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", w.Code)
+	}
+}
+
+type mockResetter struct {
+	calls int
+}
+
+func (m *mockResetter) ResetFailed() int {
+	m.calls++
+	return 2
+}
+
+func TestServer_ResetProviders(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		s := New(0, &mockReconciler{}, WithLogger(testLogger()))
+		w := serve(s, http.MethodPost, "/api/v1/providers/reset", "", nil)
+		if w.Code != http.StatusNotImplemented {
+			t.Errorf("expected status 501, got %d", w.Code)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		resetter := &mockResetter{}
+		s := New(0, &mockReconciler{}, WithLogger(testLogger()), WithProviderResetter(resetter))
+		w := serve(s, http.MethodPost, "/api/v1/providers/reset", "", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", w.Code)
+		}
+
+		var resp ResetResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Reset != 2 || resetter.calls != 1 {
+			t.Errorf("unexpected reset response %+v (calls=%d)", resp, resetter.calls)
+		}
+	})
+}
+
+func (m *mockResetter) ResetBackoff() int {
+	m.calls++
+	return 3
+}
+
+func TestServer_ResetBackoff(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		s := New(0, &mockReconciler{}, WithLogger(testLogger()))
+		w := serve(s, http.MethodPost, "/api/v1/backoff/reset", "", nil)
+		if w.Code != http.StatusNotImplemented {
+			t.Errorf("expected status 501, got %d", w.Code)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		resetter := &mockResetter{}
+		s := New(0, &mockReconciler{}, WithLogger(testLogger()), WithBackoffResetter(resetter))
+		w := serve(s, http.MethodPost, "/api/v1/backoff/reset", "", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", w.Code)
+		}
+
+		var resp ResetResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Reset != 3 || resetter.calls != 1 {
+			t.Errorf("unexpected reset response %+v (calls=%d)", resp, resetter.calls)
+		}
+	})
+}
+
+type mockDescriber []provider.ProviderDescription
+
+func (m mockDescriber) Describe() []provider.ProviderDescription {
+	return m
+}
+
+func TestServer_ListProviders(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		s := New(0, &mockReconciler{}, WithLogger(testLogger()))
+		w := serve(s, http.MethodGet, "/api/v1/providers", "", nil)
+		if w.Code != http.StatusNotImplemented {
+			t.Errorf("expected status 501, got %d", w.Code)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		describer := mockDescriber{{
+			Name:             "internal",
+			Type:             "technitium",
+			Domains:          []string{"*.example.com"},
+			RecordType:       provider.RecordTypeA,
+			Target:           "10.0.0.1",
+			TTL:              300,
+			Mode:             provider.ModeManaged,
+			ProviderSpecific: map[string]string{"TOKEN": "***"},
+		}}
+		s := New(0, &mockReconciler{}, WithLogger(testLogger()), WithProviderDescriber(describer))
+		w := serve(s, http.MethodGet, "/api/v1/providers", "", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", w.Code)
+		}
+
+		var resp []provider.ProviderDescription
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp) != 1 || resp[0].Name != "internal" || resp[0].ProviderSpecific["TOKEN"] != "***" {
+			t.Errorf("unexpected response %+v", resp)
+		}
+	})
+}
+
+func TestServer_ReconcileOutlivesRequest(t *testing.T) {
+	rec := &mockReconciler{}
+	s := New(0, rec, WithLogger(testLogger()), WithOperationTimeout(time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/reconcile", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if rec.fullRuns != 1 {
+		t.Fatalf("expected 1 full reconcile, got %d", rec.fullRuns)
+	}
+	if rec.ctxErr != nil {
+		t.Errorf("reconcile context inherited request cancellation: %v", rec.ctxErr)
+	}
+	if !rec.deadline {
+		t.Error("expected reconcile context to have a deadline")
+	}
+}
+
+func TestServer_Addr(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{name: "no token binds loopback", want: "127.0.0.1:8081"},
+		{name: "token binds all interfaces", token: "secret", want: ":8081"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(8081, &mockReconciler{}, WithToken(tt.token))
+			if got := s.addr(); got != tt.want {
+				t.Errorf("addr() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Package audit writes a tamper-evident log of DNS record mutations.
+//
+// Entries are written as JSON lines. Each entry includes the SHA-256 hash of
+// the previous entry, so any edit, removal, or reordering of earlier lines
+// breaks the chain and is detected by Verify.
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Entry is a single audit record.
+type Entry struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Action     string    `json:"action"` // create, update, delete
+	Status     string    `json:"status"` // success, failed
+	Hostname   string    `json:"hostname"`
+	RecordType string    `json:"record_type"`
+	OldValue   string    `json:"old_value,omitempty"`
+	NewValue   string    `json:"new_value,omitempty"`
+	Provider   string    `json:"provider"`
+	Error      string    `json:"error,omitempty"`
+	ErrorCode  string    `json:"error_code,omitempty"` // provider error code of a failure
+
+	// Annotations are the source annotations of the hostname, if any.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// PrevHash is the Hash of the previous entry (empty for the first entry).
+	PrevHash string `json:"prev_hash"`
+
+	// Hash is the SHA-256 of PrevHash and this entry with Hash unset.
+	Hash string `json:"hash"`
+}
+
+// computeHash returns the chain hash for the entry.
+func (e Entry) computeHash() (string, error) {
+	e.Hash = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(e.PrevHash), data...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Logger appends hash-chained entries to a writer.
+// It is safe for concurrent use.
+type Logger struct {
+	mu       sync.Mutex
+	w        io.Writer
+	closer   io.Closer
+	lastHash string
+	logger   *slog.Logger
+}
+
+// Option is a functional option for configuring the Logger.
+type Option func(*Logger)
+
+// WithLogger sets the logger used to report audit write failures.
+func WithLogger(logger *slog.Logger) Option {
+	return func(l *Logger) {
+		l.logger = logger
+	}
+}
+
+// New creates an audit logger writing to w, starting a new chain.
+func New(w io.Writer, opts ...Option) *Logger {
+	l := &Logger{
+		w:      w,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// Open creates an audit logger for path. "-" or "stdout" writes to standard
+// output. Files are opened in append mode and the chain continues from the
+// last entry already in the file.
+func Open(path string, opts ...Option) (*Logger, error) {
+	if path == "-" || path == "stdout" {
+		return New(os.Stdout, opts...), nil
+	}
+
+	lastHash, err := lastHashInFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+
+	l := New(f, opts...)
+	l.closer = f
+	l.lastHash = lastHash
+	return l, nil
+}
+
+// lastHashInFile returns the hash of the last entry in an existing audit log.
+func lastHashInFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading audit log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var last Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &last); err != nil {
+			return "", fmt.Errorf("parsing audit log %s: %w", path, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("reading audit log: %w", err)
+	}
+	return last.Hash, nil
+}
+
+// Log appends an entry to the audit log, filling in the timestamp (if unset)
+// and the chain hashes.
+func (l *Logger) Log(e Entry) error {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+	e.Timestamp = e.Timestamp.UTC()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e.PrevHash = l.lastHash
+	hash, err := e.computeHash()
+	if err != nil {
+		return fmt.Errorf("hashing audit entry: %w", err)
+	}
+	e.Hash = hash
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encoding audit entry: %w", err)
+	}
+	if _, err := l.w.Write(append(data, '\n')); err != nil {
+		l.logger.Error("failed to write audit entry",
+			slog.String("hostname", e.Hostname),
+			slog.String("action", e.Action),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("writing audit entry: %w", err)
+	}
+
+	l.lastHash = hash
+	return nil
+}
+
+// Close closes the underlying file, if any.
+func (l *Logger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// Verify reads an audit log and checks that every entry's hash is correct and
+// chains to the previous entry. Returns the number of verified entries.
+func Verify(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	prev := ""
+	count := 0
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		count++
+
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return count - 1, fmt.Errorf("entry %d: %w", count, err)
+		}
+		if e.PrevHash != prev {
+			return count - 1, fmt.Errorf("entry %d: chain broken (prev_hash does not match previous entry)", count)
+		}
+		hash, err := e.computeHash()
+		if err != nil {
+			return count - 1, fmt.Errorf("entry %d: %w", count, err)
+		}
+		if hash != e.Hash {
+			return count - 1, fmt.Errorf("entry %d: hash mismatch (entry modified)", count)
+		}
+		prev = e.Hash
+	}
+
+	if err := scanner.Err(); err != nil {
+		return count, fmt.Errorf("reading audit log: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLogger_ChainsEntries(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+
+	entries := []Entry{
+		{Action: "create", Status: "success", Hostname: "app.example.com", RecordType: "A", NewValue: "10.0.0.1", Provider: "internal"},
+		{Action: "update", Status: "success", Hostname: "app.example.com", RecordType: "A", OldValue: "10.0.0.1", NewValue: "10.0.0.2", Provider: "internal"},
+		{Action: "delete", Status: "failed", Hostname: "app.example.com", RecordType: "A", OldValue: "10.0.0.2", Provider: "internal", Error: "timeout"},
+	}
+	for _, e := range entries {
+		if err := l.Log(e); err != nil {
+			t.Fatalf("Log() error = %v", err)
+		}
+	}
+
+	count, err := Verify(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Verify() count = %d, want 3", count)
+	}
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	for i := 0; i < 3; i++ {
+		_ = l.Log(Entry{Action: "create", Status: "success", Hostname: fmt.Sprintf("app%d.example.com", i), NewValue: "10.0.0.1"})
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	t.Run("modified entry", func(t *testing.T) {
+		tampered := append([]string{}, lines...)
+		tampered[1] = strings.Replace(tampered[1], "10.0.0.1", "10.6.6.6", 1)
+		if _, err := Verify(strings.NewReader(strings.Join(tampered, "\n"))); err == nil {
+			t.Error("expected error for modified entry")
+		}
+	})
+
+	t.Run("removed entry", func(t *testing.T) {
+		tampered := []string{lines[0], lines[2]}
+		if _, err := Verify(strings.NewReader(strings.Join(tampered, "\n"))); err == nil {
+			t.Error("expected error for removed entry")
+		}
+	})
+}
+
+func TestOpen_ContinuesChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	l, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	_ = l.Log(Entry{Action: "create", Status: "success", Hostname: "a.example.com"})
+	_ = l.Close()
+
+	l, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	_ = l.Log(Entry{Action: "delete", Status: "success", Hostname: "a.example.com"})
+	_ = l.Close()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	count, err := Verify(f)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Verify() count = %d, want 2", count)
+	}
+}
+
+func TestLogger_Concurrent(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = l.Log(Entry{Action: "create", Status: "success", Hostname: fmt.Sprintf("app%d.example.com", i)})
+		}(i)
+	}
+	wg.Wait()
+
+	count, err := Verify(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if count != 50 {
+		t.Errorf("Verify() count = %d, want 50", count)
+	}
+}
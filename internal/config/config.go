@@ -98,7 +98,7 @@ func Load() (*Config, error) {
 		for _, fp := range fileProviders {
 			providerNames = append(providerNames, fp.Name)
 			// Apply env var overrides to file-based provider config
-			mergeProviderEnvOverrides(fp)
+			allErrors = append(allErrors, mergeProviderEnvOverrides(fp)...)
 			instances = append(instances, fp)
 		}
 	} else {
@@ -179,11 +179,167 @@ func (c *Config) ReconcileInterval() time.Duration {
 	return c.Global.ReconcileInterval
 }
 
+// ReconcileOnStart returns whether a reconciliation runs immediately at
+// startup. When false, the first Docker event or interval tick triggers it.
+func (c *Config) ReconcileOnStart() bool {
+	return c.Global.ReconcileOnStart
+}
+
 // HealthPort returns the health server port.
 func (c *Config) HealthPort() int {
 	return c.Global.HealthPort
 }
 
+// HealthDeepTimeout returns the timeout for /health/deep provider probes.
+func (c *Config) HealthDeepTimeout() time.Duration {
+	return c.Global.HealthDeepTimeout
+}
+
+// ProviderMaxPending returns how long a provider may stay pending before retries stop.
+// Zero means providers are retried forever.
+func (c *Config) ProviderMaxPending() time.Duration {
+	return c.Global.ProviderMaxPending
+}
+
+// RecordCacheTTL returns how long the provider record cache is shared across
+// reconciliations. Zero means a new cache is built for every reconciliation.
+func (c *Config) RecordCacheTTL() time.Duration {
+	return c.Global.RecordCacheTTL
+}
+
+// RetryAttempts returns how many times a failed record operation is tried.
+func (c *Config) RetryAttempts() int {
+	return c.Global.RetryAttempts
+}
+
+// RetryBackoff returns the wait before the first retry of a failed record operation.
+func (c *Config) RetryBackoff() time.Duration {
+	return c.Global.RetryBackoff
+}
+
+// DrainTimeout returns how long shutdown waits for in-flight reconciliations.
+func (c *Config) DrainTimeout() time.Duration {
+	return c.Global.DrainTimeout
+}
+
+// MaxHostnamesPerReconcile returns the per-reconciliation hostname limit
+// (0 if unlimited).
+func (c *Config) MaxHostnamesPerReconcile() int {
+	return c.Global.MaxHostnamesPerReconcile
+}
+
+// IgnoreHostnames returns the glob patterns of hostnames that are never managed.
+func (c *Config) IgnoreHostnames() []string {
+	return c.Global.IgnoreHostnames
+}
+
+// ProviderFallback returns whether each hostname is written only to the first
+// matching provider instance that succeeds.
+func (c *Config) ProviderFallback() bool {
+	return c.Global.ProviderFallback
+}
+
+// SourcePriorities returns the priority of each source name for resolving
+// hostnames defined by more than one source (nil if unset).
+func (c *Config) SourcePriorities() map[string]int {
+	return c.Global.SourcePriorities
+}
+
+// DomainsAllowlist returns the glob patterns of the only hostnames that are
+// managed (empty if every hostname is allowed).
+func (c *Config) DomainsAllowlist() []string {
+	return c.Global.DomainsAllowlist
+}
+
+// BackoffThreshold returns how many consecutive failures of a hostname in a
+// provider start a reconcile backoff (0 if disabled).
+func (c *Config) BackoffThreshold() int {
+	return c.Global.BackoffThreshold
+}
+
+// BackoffInitial returns the first reconcile backoff period.
+func (c *Config) BackoffInitial() time.Duration {
+	return c.Global.BackoffInitial
+}
+
+// BackoffMax returns the longest reconcile backoff period.
+func (c *Config) BackoffMax() time.Duration {
+	return c.Global.BackoffMax
+}
+
+// AuditLog returns the audit log path (empty if auditing is disabled).
+func (c *Config) AuditLog() string {
+	return c.Global.AuditLog
+}
+
+// NotifySlackWebhook returns the Slack incoming webhook URL (empty if disabled).
+func (c *Config) NotifySlackWebhook() string {
+	return c.Global.NotifySlackWebhook
+}
+
+// NotifyDiscordWebhook returns the Discord webhook URL (empty if disabled).
+func (c *Config) NotifyDiscordWebhook() string {
+	return c.Global.NotifyDiscordWebhook
+}
+
+// ChangeWebhookURL returns the URL that receives record change events
+// (empty if disabled).
+func (c *Config) ChangeWebhookURL() string {
+	return c.Global.ChangeWebhookURL
+}
+
+// NotifyMinActions returns the minimum number of record changes in a
+// reconciliation before a notification is sent.
+func (c *Config) NotifyMinActions() int {
+	return c.Global.NotifyMinActions
+}
+
+// OPAURL returns the OPA decision endpoint for admission checks (empty if disabled).
+func (c *Config) OPAURL() string {
+	return c.Global.OPAURL
+}
+
+// OPATimeout returns the timeout for each admission policy query.
+func (c *Config) OPATimeout() time.Duration {
+	return c.Global.OPATimeout
+}
+
+// OPAFailOpen returns whether record operations are allowed when the
+// admission policy cannot be queried.
+func (c *Config) OPAFailOpen() bool {
+	return c.Global.OPAFailOpen
+}
+
+// MetricsPushGatewayURL returns the Prometheus Pushgateway URL (empty if disabled).
+func (c *Config) MetricsPushGatewayURL() string {
+	return c.Global.MetricsPushGatewayURL
+}
+
+// MetricsPushInterval returns the minimum interval between metric pushes.
+// Defaults to the reconcile interval when not set.
+func (c *Config) MetricsPushInterval() time.Duration {
+	if c.Global.MetricsPushInterval > 0 {
+		return c.Global.MetricsPushInterval
+	}
+	return c.Global.ReconcileInterval
+}
+
+// APIEnabled returns whether the record management API is enabled.
+func (c *Config) APIEnabled() bool {
+	return c.Global.APIEnabled
+}
+
+// APIPort returns the record management API port.
+func (c *Config) APIPort() int {
+	return c.Global.APIPort
+}
+
+// APIToken returns the bearer token required by the record management API.
+// An empty token disables authentication.
+func (c *Config) APIToken() string {
+	return c.Global.APIToken
+}
+
 // DockerHost returns the Docker socket/host path.
 func (c *Config) DockerHost() string {
 	return c.Global.DockerHost
@@ -194,6 +350,11 @@ func (c *Config) DockerMode() string {
 	return c.Global.DockerMode
 }
 
+// DockerLabelFilter returns the Docker label filters restricting listed workloads.
+func (c *Config) DockerLabelFilter() []string {
+	return c.Global.DockerLabelFilter
+}
+
 // Source returns the hostname source type.
 func (c *Config) Source() string {
 	return c.Global.Source
@@ -230,6 +391,11 @@ func (c *Config) HasFileDiscovery() bool {
 	return c.Sources != nil && c.Sources.HasFileDiscovery()
 }
 
+// HasAPIDiscovery returns true if any source has API discovery configured.
+func (c *Config) HasAPIDiscovery() bool {
+	return c.Sources != nil && c.Sources.HasAPIDiscovery()
+}
+
 // String returns a summary of the configuration (without secrets).
 func (c *Config) String() string {
 	sourceNames := "[]"
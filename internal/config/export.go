@@ -0,0 +1,268 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// redacted replaces secret values in exported configuration.
+const redacted = "***"
+
+// exportDocument is the YAML layout written by ExportYAML. The logging,
+// docker, server, sources, and providers sections use the config file format;
+// the remaining sections show settings that are only available as env vars.
+type exportDocument struct {
+	Logging    FileLoggingConfig    `yaml:"logging"`
+	Reconciler exportReconciler     `yaml:"reconciler"`
+	Docker     FileDockerConfig     `yaml:"docker"`
+	Server     FileServerConfig     `yaml:"server"`
+	API        exportAPI            `yaml:"api"`
+	Metrics    exportMetrics        `yaml:"metrics,omitempty"`
+	AuditLog   string               `yaml:"audit_log,omitempty"`
+	Notify     *exportNotify        `yaml:"notify,omitempty"`
+	Policy     *exportPolicy        `yaml:"policy,omitempty"`
+	Sources    []exportSource       `yaml:"sources"`
+	Providers  []FileProviderConfig `yaml:"providers"`
+}
+
+// exportReconciler holds the effective reconciler settings, including values
+// left at their defaults.
+type exportReconciler struct {
+	Interval           string         `yaml:"interval"`
+	DryRun             bool           `yaml:"dry_run"`
+	CleanupOrphans     bool           `yaml:"cleanup_orphans"`
+	CleanupOnStop      bool           `yaml:"cleanup_on_stop"`
+	ReconcileOnStart   bool           `yaml:"reconcile_on_startup"`
+	OwnershipTracking  bool           `yaml:"ownership_tracking"`
+	AdoptExisting      bool           `yaml:"adopt_existing"`
+	DefaultTTL         int            `yaml:"default_ttl"`
+	RecordCacheTTL     string         `yaml:"record_cache_ttl"`
+	ProviderMaxPending string         `yaml:"provider_max_pending"`
+	RetryAttempts      int            `yaml:"retry_attempts"`
+	RetryBackoff       string         `yaml:"retry_backoff"`
+	DrainTimeout       string         `yaml:"drain_timeout"`
+	MaxHostnames       int            `yaml:"max_hostnames_per_reconcile"`
+	IgnoreHostnames    []string       `yaml:"ignore_hostnames,omitempty"`
+	DomainsAllowlist   []string       `yaml:"domains_allowlist,omitempty"`
+	ProviderFallback   bool           `yaml:"provider_fallback"`
+	SourcePriorities   map[string]int `yaml:"source_priorities,omitempty"`
+	BackoffThreshold   int            `yaml:"backoff_threshold"`
+	BackoffInitial     string         `yaml:"backoff"`
+	BackoffMax         string         `yaml:"backoff_max"`
+}
+
+// exportAPI holds the record management API settings.
+type exportAPI struct {
+	Enabled bool   `yaml:"enabled"`
+	Port    int    `yaml:"port"`
+	Token   string `yaml:"token,omitempty"`
+}
+
+// exportMetrics holds the Prometheus Pushgateway settings.
+type exportMetrics struct {
+	PushGatewayURL string `yaml:"pushgateway_url,omitempty"`
+	PushInterval   string `yaml:"push_interval,omitempty"`
+}
+
+// exportNotify holds the reconciliation notification settings.
+type exportNotify struct {
+	SlackWebhook   string `yaml:"slack_webhook,omitempty"`
+	DiscordWebhook string `yaml:"discord_webhook,omitempty"`
+	ChangeWebhook  string `yaml:"change_webhook,omitempty"`
+	MinActions     int    `yaml:"min_actions"`
+}
+
+// exportPolicy holds the admission policy settings.
+type exportPolicy struct {
+	OPAURL   string `yaml:"opa_url"`
+	Timeout  string `yaml:"timeout"`
+	FailOpen bool   `yaml:"fail_open"`
+}
+
+// exportSource is a source in the config file format plus its API discovery settings.
+type exportSource struct {
+	Name            string                   `yaml:"name"`
+	FileDiscovery   *FileFileDiscoveryConfig `yaml:"file_discovery,omitempty"`
+	APIEndpoint     string                   `yaml:"api_endpoint,omitempty"`
+	APIPollInterval string                   `yaml:"api_poll_interval,omitempty"`
+	Entrypoints     []string                 `yaml:"entrypoints,omitempty"`
+}
+
+// ExportYAML renders the effective configuration as a YAML document.
+// Values loaded from _FILE secrets are resolved, but secret values
+// (tokens, passwords, API keys) are replaced with "***".
+func ExportYAML(cfg *Config) string {
+	g := cfg.Global
+	doc := exportDocument{
+		Logging: FileLoggingConfig{Level: g.LogLevel, Format: g.LogFormat},
+		Reconciler: exportReconciler{
+			Interval:           g.ReconcileInterval.String(),
+			DryRun:             g.DryRun,
+			CleanupOrphans:     g.CleanupOrphans,
+			CleanupOnStop:      g.CleanupOnStop,
+			ReconcileOnStart:   g.ReconcileOnStart,
+			OwnershipTracking:  g.OwnershipTracking,
+			AdoptExisting:      g.AdoptExisting,
+			DefaultTTL:         g.DefaultTTL,
+			RecordCacheTTL:     g.RecordCacheTTL.String(),
+			ProviderMaxPending: g.ProviderMaxPending.String(),
+			RetryAttempts:      g.RetryAttempts,
+			RetryBackoff:       g.RetryBackoff.String(),
+			DrainTimeout:       g.DrainTimeout.String(),
+			MaxHostnames:       g.MaxHostnamesPerReconcile,
+			IgnoreHostnames:    g.IgnoreHostnames,
+			DomainsAllowlist:   g.DomainsAllowlist,
+			ProviderFallback:   g.ProviderFallback,
+			SourcePriorities:   g.SourcePriorities,
+			BackoffThreshold:   g.BackoffThreshold,
+			BackoffInitial:     g.BackoffInitial.String(),
+			BackoffMax:         g.BackoffMax.String(),
+		},
+		Docker: FileDockerConfig{Host: g.DockerHost, Mode: g.DockerMode, LabelFilter: g.DockerLabelFilter},
+		Server: FileServerConfig{Port: g.HealthPort, DeepTimeout: g.HealthDeepTimeout.String()},
+		API: exportAPI{
+			Enabled: g.APIEnabled,
+			Port:    g.APIPort,
+			Token:   redact(g.APIToken),
+		},
+		Metrics: exportMetrics{
+			PushGatewayURL: g.MetricsPushGatewayURL,
+			PushInterval:   durationOrEmpty(g.MetricsPushInterval),
+		},
+		AuditLog: g.AuditLog,
+	}
+
+	if g.NotifySlackWebhook != "" || g.NotifyDiscordWebhook != "" || g.ChangeWebhookURL != "" {
+		doc.Notify = &exportNotify{
+			SlackWebhook:   redact(g.NotifySlackWebhook),
+			DiscordWebhook: redact(g.NotifyDiscordWebhook),
+			ChangeWebhook:  redact(g.ChangeWebhookURL),
+			MinActions:     g.NotifyMinActions,
+		}
+	}
+
+	if g.OPAURL != "" {
+		doc.Policy = &exportPolicy{
+			OPAURL:   g.OPAURL,
+			Timeout:  g.OPATimeout.String(),
+			FailOpen: g.OPAFailOpen,
+		}
+	}
+
+	if cfg.Sources != nil {
+		for _, inst := range cfg.Sources.Instances {
+			doc.Sources = append(doc.Sources, exportSourceConfig(inst))
+		}
+	}
+
+	for _, inst := range cfg.ProviderInstances {
+		doc.Providers = append(doc.Providers, exportProviderConfig(inst))
+	}
+
+	var buf strings.Builder
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	// The document only holds strings, numbers, slices, and maps, so encoding cannot fail
+	_ = enc.Encode(doc)
+	_ = enc.Close()
+	return buf.String()
+}
+
+// exportSourceConfig converts a source instance to its exported form.
+func exportSourceConfig(inst *SourceInstanceConfig) exportSource {
+	out := exportSource{
+		Name:            inst.Name,
+		APIEndpoint:     inst.APIEndpoint,
+		APIPollInterval: durationOrEmpty(inst.APIPollInterval),
+		Entrypoints:     inst.Entrypoints,
+	}
+	if fd := inst.FileDiscovery; len(fd.FilePaths) > 0 {
+		out.FileDiscovery = &FileFileDiscoveryConfig{
+			Paths:        fd.FilePaths,
+			Pattern:      fd.FilePattern,
+			PollInterval: durationOrEmpty(fd.PollInterval),
+			WatchMethod:  fd.WatchMethod,
+		}
+	}
+	return out
+}
+
+// exportProviderConfig converts a provider instance to the config file format,
+// redacting secret provider settings.
+func exportProviderConfig(inst *ProviderInstanceConfig) FileProviderConfig {
+	out := FileProviderConfig{
+		Name:                inst.Name,
+		Type:                inst.TypeName,
+		Domains:             inst.Domains,
+		DomainsRegex:        inst.DomainsRegex,
+		ExcludeDomains:      inst.ExcludeDomains,
+		ExcludeDomainsRegex: inst.ExcludeDomainsRegex,
+		RecordType:          string(inst.RecordType),
+		Target:              inst.Target,
+		Targets:             inst.Targets,
+		TTL:                 inst.TTL,
+		Mode:                string(inst.Mode),
+		RateLimitQueue:      inst.RateLimitQueue,
+	}
+	if inst.RateLimit > 0 {
+		out.RateLimit = formatRateLimit(inst.RateLimit)
+	}
+	priority := inst.Priority
+	out.Priority = &priority
+	threshold := inst.CircuitBreakerThreshold
+	out.CircuitBreakerThreshold = &threshold
+	if threshold > 0 {
+		out.CircuitBreakerCooldown = inst.CircuitBreakerCooldown.String()
+	}
+
+	if len(inst.ProviderConfig) > 0 {
+		out.Config = make(map[string]string, len(inst.ProviderConfig))
+		for key, value := range inst.ProviderConfig {
+			if isSecretField(key) {
+				value = redact(value)
+			}
+			out.Config[key] = value
+		}
+	}
+	return out
+}
+
+// formatRateLimit converts an interval between operations back to the
+// "count/unit" form accepted by RATE_LIMIT, using the smallest exact unit.
+func formatRateLimit(interval time.Duration) string {
+	for _, u := range []struct {
+		per  time.Duration
+		unit string
+	}{{time.Second, "s"}, {time.Minute, "m"}} {
+		if u.per%interval == 0 {
+			return fmt.Sprintf("%d/%s", u.per/interval, u.unit)
+		}
+	}
+	return fmt.Sprintf("%d/h", max(time.Hour/interval, 1))
+}
+
+// isSecretField reports whether a provider setting holds a secret value.
+func isSecretField(key string) bool {
+	return provider.IsSecretSetting(key)
+}
+
+// redact returns "***" for a non-empty secret and "" otherwise.
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redacted
+}
+
+// durationOrEmpty formats d, or returns "" for zero.
+func durationOrEmpty(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestExportYAML(t *testing.T) {
+	clearAllEnv(t)
+	defer clearAllEnv(t)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("super-secret-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("DNSWEAVER_INSTANCES", "internal-dns")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_TYPE", "technitium")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_URL", "http://dns:5380")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_TOKEN_FILE", tokenFile)
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_ZONE", "example.com")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_TARGET", "10.0.0.100")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_DOMAINS", "*.example.com")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_RATE_LIMIT", "10/s")
+	os.Setenv("DNSWEAVER_API_TOKEN", "api-secret")
+	os.Setenv("DNSWEAVER_DRY_RUN", "true")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	out := ExportYAML(cfg)
+	if strings.Contains(out, "super-secret-token") || strings.Contains(out, "api-secret") {
+		t.Fatalf("ExportYAML() leaked a secret:\n%s", out)
+	}
+
+	var doc exportDocument
+	if err := yaml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("ExportYAML() produced invalid YAML: %v\n%s", err, out)
+	}
+
+	if !doc.Reconciler.DryRun {
+		t.Error("reconciler.dry_run = false, want true")
+	}
+	if doc.API.Token != "***" {
+		t.Errorf("api.token = %q, want ***", doc.API.Token)
+	}
+	if len(doc.Providers) != 1 {
+		t.Fatalf("providers length = %d, want 1", len(doc.Providers))
+	}
+	p := doc.Providers[0]
+	if p.Name != "internal-dns" || p.Type != "technitium" || p.Target != "10.0.0.100" {
+		t.Errorf("provider = %+v, want internal-dns/technitium/10.0.0.100", p)
+	}
+	if p.Config["TOKEN"] != "***" {
+		t.Errorf("config.TOKEN = %q, want ***", p.Config["TOKEN"])
+	}
+	if p.Config["URL"] != "http://dns:5380" {
+		t.Errorf("config.URL = %q, want http://dns:5380", p.Config["URL"])
+	}
+	if p.RateLimit != "10/s" {
+		t.Errorf("rate_limit = %q, want 10/s", p.RateLimit)
+	}
+	if len(doc.Sources) == 0 || doc.Sources[0].Name != DefaultSource {
+		t.Errorf("sources = %+v, want default source %q", doc.Sources, DefaultSource)
+	}
+}
+
+func TestExportYAML_ReconcilerRoundTrip(t *testing.T) {
+	clearAllEnv(t)
+	defer clearAllEnv(t)
+
+	os.Setenv("DNSWEAVER_INSTANCES", "internal-dns")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_TYPE", "technitium")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_URL", "http://dns:5380")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_TOKEN", "token")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_ZONE", "example.com")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_TARGET", "10.0.0.100")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_DOMAINS", "*.example.com")
+	os.Setenv("DNSWEAVER_DEFAULT_TTL", "120")
+	os.Setenv("DNSWEAVER_RECORD_CACHE_TTL", "45s")
+	os.Setenv("DNSWEAVER_PROVIDER_MAX_PENDING", "1h")
+	os.Setenv("DNSWEAVER_RETRY_ATTEMPTS", "5")
+	os.Setenv("DNSWEAVER_RETRY_BACKOFF", "2s")
+	os.Setenv("DNSWEAVER_RECONCILE_BACKOFF_THRESHOLD", "7")
+	os.Setenv("DNSWEAVER_RECONCILE_BACKOFF", "2m")
+	os.Setenv("DNSWEAVER_RECONCILE_BACKOFF_MAX", "3h")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	// Every exported reconciler setting must load back from a config file
+	var doc struct {
+		Reconciler FileReconcilerConfig `yaml:"reconciler"`
+	}
+	if err := yaml.Unmarshal([]byte(ExportYAML(cfg)), &doc); err != nil {
+		t.Fatalf("ExportYAML() produced invalid YAML: %v", err)
+	}
+	got := (&FileConfig{Reconciler: &doc.Reconciler}).ToGlobalConfig()
+	want := cfg.Global
+
+	if got.DefaultTTL != want.DefaultTTL {
+		t.Errorf("DefaultTTL = %d, want %d", got.DefaultTTL, want.DefaultTTL)
+	}
+	if got.RecordCacheTTL != want.RecordCacheTTL {
+		t.Errorf("RecordCacheTTL = %s, want %s", got.RecordCacheTTL, want.RecordCacheTTL)
+	}
+	if got.ProviderMaxPending != want.ProviderMaxPending {
+		t.Errorf("ProviderMaxPending = %s, want %s", got.ProviderMaxPending, want.ProviderMaxPending)
+	}
+	if got.RetryAttempts != want.RetryAttempts || got.RetryBackoff != want.RetryBackoff {
+		t.Errorf("retry = %d/%s, want %d/%s", got.RetryAttempts, got.RetryBackoff, want.RetryAttempts, want.RetryBackoff)
+	}
+	if got.BackoffThreshold != want.BackoffThreshold {
+		t.Errorf("BackoffThreshold = %d, want %d", got.BackoffThreshold, want.BackoffThreshold)
+	}
+	if got.BackoffInitial != want.BackoffInitial || got.BackoffMax != want.BackoffMax {
+		t.Errorf("backoff = %s/%s, want %s/%s", got.BackoffInitial, got.BackoffMax, want.BackoffInitial, want.BackoffMax)
+	}
+}
+
+func TestFormatRateLimit(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		want     string
+	}{
+		{100 * time.Millisecond, "10/s"},
+		{time.Second, "1/s"},
+		{2 * time.Second, "30/m"},
+		{7 * time.Minute, "8/h"},
+	}
+	for _, tt := range tests {
+		if got := formatRateLimit(tt.interval); got != tt.want {
+			t.Errorf("formatRateLimit(%v) = %q, want %q", tt.interval, got, tt.want)
+		}
+	}
+}
+
+func TestIsSecretField(t *testing.T) {
+	for _, key := range []string{"TOKEN", "API_KEY", "PASSWORD", "API_PASSWORD", "AUTH_TOKEN", "SSH_PASSWORD", "CREDENTIALS"} {
+		if !isSecretField(key) {
+			t.Errorf("isSecretField(%q) = false, want true", key)
+		}
+	}
+	for _, key := range []string{"URL", "ZONE", "AUTH_HEADER", "SSH_KEY_FILE"} {
+		if isSecretField(key) {
+			t.Errorf("isSecretField(%q) = true, want false", key)
+		}
+	}
+}
@@ -41,19 +41,35 @@ type FileLoggingConfig struct {
 
 // FileReconcilerConfig holds reconciliation settings.
 type FileReconcilerConfig struct {
-	Interval          string `yaml:"interval,omitempty"`           // Go duration format (e.g., "60s", "5m")
-	DryRun            *bool  `yaml:"dry_run,omitempty"`            // Pointer to distinguish unset from false
-	CleanupOrphans    *bool  `yaml:"cleanup_orphans,omitempty"`    // Delete records for removed workloads
-	CleanupOnStop     *bool  `yaml:"cleanup_on_stop,omitempty"`    // Delete records when containers stop
-	OwnershipTracking *bool  `yaml:"ownership_tracking,omitempty"` // Use TXT records for ownership
-	AdoptExisting     *bool  `yaml:"adopt_existing,omitempty"`     // Adopt pre-existing DNS records
-	OrphanDelay       string `yaml:"orphan_delay,omitempty"`       // Delay before orphan cleanup
+	Interval          string         `yaml:"interval,omitempty"`                    // Go duration format (e.g., "60s", "5m")
+	DryRun            *bool          `yaml:"dry_run,omitempty"`                     // Pointer to distinguish unset from false
+	CleanupOrphans    *bool          `yaml:"cleanup_orphans,omitempty"`             // Delete records for removed workloads
+	CleanupOnStop     *bool          `yaml:"cleanup_on_stop,omitempty"`             // Delete records when containers stop
+	ReconcileOnStart  *bool          `yaml:"reconcile_on_startup,omitempty"`        // Reconcile immediately at startup
+	OwnershipTracking *bool          `yaml:"ownership_tracking,omitempty"`          // Use TXT records for ownership
+	AdoptExisting     *bool          `yaml:"adopt_existing,omitempty"`              // Adopt pre-existing DNS records
+	OrphanDelay       string         `yaml:"orphan_delay,omitempty"`                // Delay before orphan cleanup
+	DrainTimeout      string         `yaml:"drain_timeout,omitempty"`               // Wait for in-flight reconciliations on shutdown
+	MaxHostnames      int            `yaml:"max_hostnames_per_reconcile,omitempty"` // Hostnames per reconciliation (0 = unlimited)
+	IgnoreHostnames   []string       `yaml:"ignore_hostnames,omitempty"`            // Glob patterns of hostnames never managed
+	DomainsAllowlist  []string       `yaml:"domains_allowlist,omitempty"`           // Glob patterns of the only hostnames managed
+	ProviderFallback  *bool          `yaml:"provider_fallback,omitempty"`           // Write to the first provider that succeeds
+	SourcePriorities  map[string]int `yaml:"source_priorities,omitempty"`           // Source name -> priority for duplicate hostnames
+	DefaultTTL        int            `yaml:"default_ttl,omitempty"`                 // TTL for providers without their own
+	RecordCacheTTL    string         `yaml:"record_cache_ttl,omitempty"`            // Reuse provider record lists (0 = per reconciliation)
+	MaxPending        string         `yaml:"provider_max_pending,omitempty"`        // Give up on unreachable providers (0 = never)
+	RetryAttempts     int            `yaml:"retry_attempts,omitempty"`              // Attempts per record operation
+	RetryBackoff      string         `yaml:"retry_backoff,omitempty"`               // Wait before the first retry, doubled each time
+	BackoffThreshold  int            `yaml:"backoff_threshold,omitempty"`           // Failures before a hostname backs off (0 = disabled)
+	Backoff           string         `yaml:"backoff,omitempty"`                     // First reconcile backoff
+	BackoffMax        string         `yaml:"backoff_max,omitempty"`                 // Longest reconcile backoff
 }
 
 // FileDockerConfig holds Docker connection settings.
 type FileDockerConfig struct {
-	Host string `yaml:"host,omitempty"` // unix:///var/run/docker.sock or tcp://...
-	Mode string `yaml:"mode,omitempty"` // auto, swarm, standalone
+	Host        string   `yaml:"host,omitempty"`         // unix:///var/run/docker.sock or tcp://...
+	Mode        string   `yaml:"mode,omitempty"`         // auto, swarm, standalone
+	LabelFilter []string `yaml:"label_filter,omitempty"` // Docker label filters, e.g. traefik.enable=true
 }
 
 // FileSourceConfig holds configuration for a hostname source.
@@ -72,22 +88,30 @@ type FileFileDiscoveryConfig struct {
 
 // FileProviderConfig holds configuration for a DNS provider instance.
 type FileProviderConfig struct {
-	Name                string            `yaml:"name"`                            // Unique instance name
-	Type                string            `yaml:"type"`                            // technitium, cloudflare, pihole, etc.
-	Domains             []string          `yaml:"domains,omitempty"`               // Glob patterns
-	DomainsRegex        []string          `yaml:"domains_regex,omitempty"`         // Regex patterns
-	ExcludeDomains      []string          `yaml:"exclude_domains,omitempty"`       // Glob exclude patterns
-	ExcludeDomainsRegex []string          `yaml:"exclude_domains_regex,omitempty"` // Regex exclude patterns
-	RecordType          string            `yaml:"record_type,omitempty"`           // A, AAAA, CNAME
-	Target              string            `yaml:"target"`                          // IP or hostname
-	TTL                 int               `yaml:"ttl,omitempty"`                   // Default TTL
-	Mode                string            `yaml:"mode,omitempty"`                  // managed, authoritative, additive
-	Config              map[string]string `yaml:"config,omitempty"`                // Provider-specific settings
+	Name                    string            `yaml:"name"`                                // Unique instance name
+	Type                    string            `yaml:"type"`                                // technitium, cloudflare, pihole, etc.
+	Domains                 []string          `yaml:"domains,omitempty"`                   // Glob patterns
+	DomainsRegex            []string          `yaml:"domains_regex,omitempty"`             // Regex patterns
+	ExcludeDomains          []string          `yaml:"exclude_domains,omitempty"`           // Glob exclude patterns
+	ExcludeDomainsRegex     []string          `yaml:"exclude_domains_regex,omitempty"`     // Regex exclude patterns
+	RecordType              string            `yaml:"record_type,omitempty"`               // A, AAAA, CNAME
+	Target                  string            `yaml:"target"`                              // IP or hostname
+	Targets                 []string          `yaml:"targets,omitempty"`                   // Round-robin targets (alternative to target)
+	TTL                     int               `yaml:"ttl,omitempty"`                       // Default TTL
+	Mode                    string            `yaml:"mode,omitempty"`                      // managed, authoritative, additive
+	Priority                *int              `yaml:"priority,omitempty"`                  // Lower values are preferred (default 100)
+	RateLimit               string            `yaml:"rate_limit,omitempty"`                // e.g. "10/s"
+	RateLimitQueue          int               `yaml:"rate_limit_queue,omitempty"`          // Max queued operations
+	CircuitBreakerThreshold *int              `yaml:"circuit_breaker_threshold,omitempty"` // Consecutive failures before the circuit opens (0 disables)
+	CircuitBreakerCooldown  string            `yaml:"circuit_breaker_cooldown,omitempty"`  // e.g. "60s"
+	Config                  map[string]string `yaml:"config,omitempty"`                    // Provider-specific settings
+	ProviderConfig          map[string]string `yaml:"provider_config,omitempty"`           // Alias for config; wins on duplicate keys
 }
 
 // FileServerConfig holds health/metrics server settings.
 type FileServerConfig struct {
-	Port int `yaml:"port,omitempty"` // Port for health/metrics endpoints
+	Port        int    `yaml:"port,omitempty"`         // Port for health/metrics endpoints
+	DeepTimeout string `yaml:"deep_timeout,omitempty"` // Timeout for /health/deep provider probes
 }
 
 // envVarPattern matches ${VAR} or ${VAR:-default} syntax.
@@ -125,11 +149,20 @@ func (c *FileConfig) interpolateEnvVars() {
 	if c.Reconciler != nil {
 		c.Reconciler.Interval = InterpolateEnvVars(c.Reconciler.Interval)
 		c.Reconciler.OrphanDelay = InterpolateEnvVars(c.Reconciler.OrphanDelay)
+		c.Reconciler.DrainTimeout = InterpolateEnvVars(c.Reconciler.DrainTimeout)
+		c.Reconciler.RecordCacheTTL = InterpolateEnvVars(c.Reconciler.RecordCacheTTL)
+		c.Reconciler.MaxPending = InterpolateEnvVars(c.Reconciler.MaxPending)
+		c.Reconciler.RetryBackoff = InterpolateEnvVars(c.Reconciler.RetryBackoff)
+		c.Reconciler.Backoff = InterpolateEnvVars(c.Reconciler.Backoff)
+		c.Reconciler.BackoffMax = InterpolateEnvVars(c.Reconciler.BackoffMax)
 	}
 
 	if c.Docker != nil {
 		c.Docker.Host = InterpolateEnvVars(c.Docker.Host)
 		c.Docker.Mode = InterpolateEnvVars(c.Docker.Mode)
+		for i := range c.Docker.LabelFilter {
+			c.Docker.LabelFilter[i] = InterpolateEnvVars(c.Docker.LabelFilter[i])
+		}
 	}
 
 	for i := range c.Sources {
@@ -150,8 +183,12 @@ func (c *FileConfig) interpolateEnvVars() {
 		p.Name = InterpolateEnvVars(p.Name)
 		p.Type = InterpolateEnvVars(p.Type)
 		p.Target = InterpolateEnvVars(p.Target)
+		for j := range p.Targets {
+			p.Targets[j] = InterpolateEnvVars(p.Targets[j])
+		}
 		p.RecordType = InterpolateEnvVars(p.RecordType)
 		p.Mode = InterpolateEnvVars(p.Mode)
+		p.RateLimit = InterpolateEnvVars(p.RateLimit)
 		for j := range p.Domains {
 			p.Domains[j] = InterpolateEnvVars(p.Domains[j])
 		}
@@ -167,6 +204,9 @@ func (c *FileConfig) interpolateEnvVars() {
 		for k, v := range p.Config {
 			p.Config[k] = InterpolateEnvVars(v)
 		}
+		for k, v := range p.ProviderConfig {
+			p.ProviderConfig[k] = InterpolateEnvVars(v)
+		}
 	}
 }
 
@@ -202,10 +242,23 @@ func (c *FileConfig) ToGlobalConfig() *GlobalConfig {
 		AdoptExisting:     DefaultAdoptExisting,
 		DefaultTTL:        DefaultTTL,
 		ReconcileInterval: DefaultReconcileInterval,
+		ReconcileOnStart:  DefaultReconcileOnStart,
 		HealthPort:        DefaultHealthPort,
+		APIEnabled:        DefaultAPIEnabled,
+		APIPort:           DefaultAPIPort,
 		DockerHost:        DefaultDockerHost,
 		DockerMode:        DefaultDockerMode,
 		Source:            DefaultSource,
+		RetryAttempts:     DefaultRetryAttempts,
+		RetryBackoff:      DefaultRetryBackoff,
+		HealthDeepTimeout: DefaultHealthDeepTimeout,
+		DrainTimeout:      DefaultDrainTimeout,
+		NotifyMinActions:  DefaultNotifyMinActions,
+		BackoffThreshold:  DefaultBackoffThreshold,
+		BackoffInitial:    DefaultBackoffInitial,
+		BackoffMax:        DefaultBackoffMax,
+		OPATimeout:        DefaultOPATimeout,
+		OPAFailOpen:       DefaultOPAFailOpen,
 	}
 
 	if c.Logging != nil {
@@ -227,6 +280,9 @@ func (c *FileConfig) ToGlobalConfig() *GlobalConfig {
 		if c.Reconciler.CleanupOnStop != nil {
 			cfg.CleanupOnStop = *c.Reconciler.CleanupOnStop
 		}
+		if c.Reconciler.ReconcileOnStart != nil {
+			cfg.ReconcileOnStart = *c.Reconciler.ReconcileOnStart
+		}
 		if c.Reconciler.OwnershipTracking != nil {
 			cfg.OwnershipTracking = *c.Reconciler.OwnershipTracking
 		}
@@ -238,6 +294,60 @@ func (c *FileConfig) ToGlobalConfig() *GlobalConfig {
 				cfg.ReconcileInterval = interval
 			}
 		}
+		if c.Reconciler.DrainTimeout != "" {
+			if d, err := time.ParseDuration(c.Reconciler.DrainTimeout); err == nil && d >= 0 {
+				cfg.DrainTimeout = d
+			}
+		}
+		if c.Reconciler.MaxHostnames > 0 {
+			cfg.MaxHostnamesPerReconcile = c.Reconciler.MaxHostnames
+		}
+		if len(c.Reconciler.IgnoreHostnames) > 0 {
+			cfg.IgnoreHostnames = c.Reconciler.IgnoreHostnames
+		}
+		if len(c.Reconciler.DomainsAllowlist) > 0 {
+			cfg.DomainsAllowlist = c.Reconciler.DomainsAllowlist
+		}
+		if c.Reconciler.ProviderFallback != nil {
+			cfg.ProviderFallback = *c.Reconciler.ProviderFallback
+		}
+		if len(c.Reconciler.SourcePriorities) > 0 {
+			cfg.SourcePriorities = c.Reconciler.SourcePriorities
+		}
+		if c.Reconciler.DefaultTTL > 0 {
+			cfg.DefaultTTL = c.Reconciler.DefaultTTL
+		}
+		if c.Reconciler.RecordCacheTTL != "" {
+			if d, err := time.ParseDuration(c.Reconciler.RecordCacheTTL); err == nil && d >= 0 {
+				cfg.RecordCacheTTL = d
+			}
+		}
+		if c.Reconciler.MaxPending != "" {
+			if d, err := time.ParseDuration(c.Reconciler.MaxPending); err == nil && d >= 0 {
+				cfg.ProviderMaxPending = d
+			}
+		}
+		if c.Reconciler.RetryAttempts > 0 {
+			cfg.RetryAttempts = c.Reconciler.RetryAttempts
+		}
+		if c.Reconciler.RetryBackoff != "" {
+			if d, err := time.ParseDuration(c.Reconciler.RetryBackoff); err == nil && d > 0 {
+				cfg.RetryBackoff = d
+			}
+		}
+		if c.Reconciler.BackoffThreshold > 0 {
+			cfg.BackoffThreshold = c.Reconciler.BackoffThreshold
+		}
+		if c.Reconciler.Backoff != "" {
+			if d, err := time.ParseDuration(c.Reconciler.Backoff); err == nil && d > 0 {
+				cfg.BackoffInitial = d
+			}
+		}
+		if c.Reconciler.BackoffMax != "" {
+			if d, err := time.ParseDuration(c.Reconciler.BackoffMax); err == nil && d > 0 {
+				cfg.BackoffMax = d
+			}
+		}
 	}
 
 	if c.Docker != nil {
@@ -247,12 +357,20 @@ func (c *FileConfig) ToGlobalConfig() *GlobalConfig {
 		if c.Docker.Mode != "" {
 			cfg.DockerMode = strings.ToLower(c.Docker.Mode)
 		}
+		if len(c.Docker.LabelFilter) > 0 {
+			cfg.DockerLabelFilter = c.Docker.LabelFilter
+		}
 	}
 
 	if c.Server != nil {
 		if c.Server.Port > 0 && c.Server.Port <= 65535 {
 			cfg.HealthPort = c.Server.Port
 		}
+		if c.Server.DeepTimeout != "" {
+			if d, err := time.ParseDuration(c.Server.DeepTimeout); err == nil && d > 0 {
+				cfg.HealthDeepTimeout = d
+			}
+		}
 	}
 
 	// Source is derived from sources list, keeping first one as primary
@@ -251,6 +251,37 @@ func TestToGlobalConfig(t *testing.T) {
 	}
 }
 
+func TestToGlobalConfig_ReconcileOnStart(t *testing.T) {
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name             string
+		reconcileOnStart *bool
+		want             bool
+	}{
+		{name: "unset reconciles on start", reconcileOnStart: nil, want: true},
+		{name: "enabled", reconcileOnStart: &enabled, want: true},
+		{name: "disabled", reconcileOnStart: &disabled, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileCfg := &FileConfig{
+				Reconciler: &FileReconcilerConfig{ReconcileOnStart: tt.reconcileOnStart},
+			}
+
+			global := fileCfg.ToGlobalConfig()
+			if global.ReconcileOnStart != tt.want {
+				t.Errorf("ReconcileOnStart = %v, want %v", global.ReconcileOnStart, tt.want)
+			}
+			// The startup reconciliation is independent of orphan cleanup
+			if global.CleanupOrphans != DefaultCleanupOrphans {
+				t.Errorf("CleanupOrphans = %v, want default %v", global.CleanupOrphans, DefaultCleanupOrphans)
+			}
+		})
+	}
+}
+
 func TestLoadFileNotFound(t *testing.T) {
 	_, err := LoadFile("/nonexistent/path/config.yml")
 	if err == nil {
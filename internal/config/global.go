@@ -18,10 +18,23 @@ const (
 	DefaultAdoptExisting     = false
 	DefaultTTL               = 300
 	DefaultReconcileInterval = 60 * time.Second
+	DefaultReconcileOnStart  = true
 	DefaultHealthPort        = 8080
+	DefaultAPIEnabled        = false
+	DefaultAPIPort           = 8081
 	DefaultDockerHost        = "unix:///var/run/docker.sock"
 	DefaultDockerMode        = "auto"
 	DefaultSource            = "traefik"
+	DefaultRetryAttempts     = 1
+	DefaultRetryBackoff      = time.Second
+	DefaultHealthDeepTimeout = 5 * time.Second
+	DefaultDrainTimeout      = 30 * time.Second
+	DefaultNotifyMinActions  = 1
+	DefaultBackoffThreshold  = 0 // disabled
+	DefaultBackoffInitial    = time.Minute
+	DefaultBackoffMax        = time.Hour
+	DefaultOPATimeout        = 2 * time.Second
+	DefaultOPAFailOpen       = false
 )
 
 // GlobalConfig holds application-wide settings.
@@ -39,12 +52,88 @@ type GlobalConfig struct {
 	AdoptExisting     bool          // If true, adopt existing DNS records by creating ownership TXT records
 	DefaultTTL        int           // Default TTL for records if not specified per-provider
 	ReconcileInterval time.Duration // How often to reconcile DNS records
+	ReconcileOnStart  bool          // If false, skip the reconciliation at startup
 	HealthPort        int           // Port for health/metrics endpoints
 
+	// HealthDeepTimeout bounds the provider probes run by /health/deep.
+	HealthDeepTimeout time.Duration
+
+	// ProviderMaxPending is how long a provider may fail to initialize before
+	// retries stop (0 retries forever).
+	ProviderMaxPending time.Duration
+
+	// RecordCacheTTL shares the provider record cache across reconciliations
+	// for this long (0 lists providers on every reconciliation).
+	RecordCacheTTL time.Duration
+
+	// RetryAttempts is how many times a failed record operation is tried
+	// (1 disables retries); RetryBackoff is the wait before the first retry.
+	RetryAttempts int
+	RetryBackoff  time.Duration
+
+	// DrainTimeout is how long shutdown waits for in-flight reconciliations.
+	DrainTimeout time.Duration
+
+	// MaxHostnamesPerReconcile caps the hostnames processed per reconciliation
+	// (0 = unlimited). The rest are deferred to the next reconciliation.
+	MaxHostnamesPerReconcile int
+
+	// IgnoreHostnames holds glob patterns for discovered hostnames that are
+	// never managed.
+	IgnoreHostnames []string
+
+	// DomainsAllowlist holds glob patterns restricting which discovered
+	// hostnames are managed at all (empty allows every hostname).
+	DomainsAllowlist []string
+
+	// ProviderFallback writes each hostname to the first matching provider
+	// instance, in priority order, that succeeds instead of to every match.
+	ProviderFallback bool
+
+	// SourcePriorities maps source names to priorities; when sources define
+	// the same hostname, the highest priority wins (equal keeps the first).
+	SourcePriorities map[string]int
+
+	// BackoffThreshold is how many consecutive failed reconciliations of a
+	// hostname in a provider start a reconcile backoff (0 disables it).
+	// BackoffInitial is the first backoff, doubled per failure up to BackoffMax.
+	BackoffThreshold int
+	BackoffInitial   time.Duration
+	BackoffMax       time.Duration
+
+	// AuditLog is the path of the record mutation audit log ("-" for stdout, empty disables).
+	AuditLog string
+
+	// Reconciliation notifications (empty webhook URLs disable a sink)
+	NotifySlackWebhook   string // Slack incoming webhook URL
+	NotifyDiscordWebhook string // Discord webhook URL
+	NotifyMinActions     int    // Minimum record changes before a notification is sent
+
+	// ChangeWebhookURL receives a JSON event for every record change (empty disables).
+	ChangeWebhookURL string
+
+	// Admission policy (empty OPAURL disables policy checks)
+	OPAURL      string        // OPA decision endpoint queried before each record operation
+	OPATimeout  time.Duration // Timeout for each policy query
+	OPAFailOpen bool          // If true, allow operations when OPA cannot be queried
+
+	// Prometheus Pushgateway
+	MetricsPushGatewayURL string        // Pushgateway URL (empty disables pushing)
+	MetricsPushInterval   time.Duration // Minimum interval between pushes (0 = reconcile interval)
+
+	// REST API
+	APIEnabled bool   // If true, serve the record management API
+	APIPort    int    // Port for the record management API
+	APIToken   string // Bearer token required by the API (empty disables auth)
+
 	// Docker connection
 	DockerHost string // Docker socket path or TCP URL
 	DockerMode string // auto, swarm, standalone
 
+	// DockerLabelFilter restricts listed workloads to those matching every
+	// Docker label filter ("key" or "key=value"). Empty lists all workloads.
+	DockerLabelFilter []string
+
 	// Source
 	Source string // traefik, labels, or custom source name
 }
@@ -127,6 +216,13 @@ func loadGlobalConfig() (*GlobalConfig, []string) {
 		cfg.CleanupOnStop = DefaultCleanupOnStop
 	}
 
+	// Parse RECONCILE_ON_STARTUP
+	if reconcileOnStartStr := getEnv("DNSWEAVER_RECONCILE_ON_STARTUP"); reconcileOnStartStr != "" {
+		cfg.ReconcileOnStart = parseBool(reconcileOnStartStr, DefaultReconcileOnStart)
+	} else {
+		cfg.ReconcileOnStart = DefaultReconcileOnStart
+	}
+
 	// Parse OWNERSHIP_TRACKING
 	if ownershipStr := getEnv("DNSWEAVER_OWNERSHIP_TRACKING"); ownershipStr != "" {
 		cfg.OwnershipTracking = parseBool(ownershipStr, DefaultOwnershipTracking)
@@ -183,5 +279,226 @@ func loadGlobalConfig() (*GlobalConfig, []string) {
 		cfg.HealthPort = DefaultHealthPort
 	}
 
+	// Parse HEALTH_DEEP_TIMEOUT
+	cfg.HealthDeepTimeout = DefaultHealthDeepTimeout
+	if durStr := getEnv("DNSWEAVER_HEALTH_DEEP_TIMEOUT"); durStr != "" {
+		d, err := time.ParseDuration(durStr)
+		if err != nil || d <= 0 {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_HEALTH_DEEP_TIMEOUT: invalid duration %q (use format like 5s, 500ms)", durStr))
+		} else {
+			cfg.HealthDeepTimeout = d
+		}
+	}
+
+	// Parse PROVIDER_MAX_PENDING (0 disables the limit)
+	if durStr := getEnv("DNSWEAVER_PROVIDER_MAX_PENDING"); durStr != "" {
+		d, err := time.ParseDuration(durStr)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_PROVIDER_MAX_PENDING: invalid duration %q (use format like 30m, 1h)", durStr))
+		} else if d < 0 {
+			errs = append(errs, "DNSWEAVER_PROVIDER_MAX_PENDING: must not be negative")
+		} else {
+			cfg.ProviderMaxPending = d
+		}
+	}
+
+	// Parse RECORD_CACHE_TTL (0 builds a cache per reconciliation)
+	if durStr := getEnv("DNSWEAVER_RECORD_CACHE_TTL"); durStr != "" {
+		d, err := time.ParseDuration(durStr)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_RECORD_CACHE_TTL: invalid duration %q (use format like 30s, 5m)", durStr))
+		} else if d < 0 {
+			errs = append(errs, "DNSWEAVER_RECORD_CACHE_TTL: must not be negative")
+		} else {
+			cfg.RecordCacheTTL = d
+		}
+	}
+
+	// Parse RETRY_ATTEMPTS and RETRY_BACKOFF
+	cfg.RetryAttempts = DefaultRetryAttempts
+	if attemptsStr := getEnv("DNSWEAVER_RETRY_ATTEMPTS"); attemptsStr != "" {
+		attempts, err := strconv.Atoi(attemptsStr)
+		if err != nil || attempts < 1 {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_RETRY_ATTEMPTS: must be a positive integer, got %q", attemptsStr))
+		} else {
+			cfg.RetryAttempts = attempts
+		}
+	}
+	cfg.RetryBackoff = DefaultRetryBackoff
+	if durStr := getEnv("DNSWEAVER_RETRY_BACKOFF"); durStr != "" {
+		d, err := time.ParseDuration(durStr)
+		if err != nil || d <= 0 {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_RETRY_BACKOFF: invalid duration %q (use format like 500ms, 2s)", durStr))
+		} else {
+			cfg.RetryBackoff = d
+		}
+	}
+
+	// Parse DRAIN_TIMEOUT
+	cfg.DrainTimeout = DefaultDrainTimeout
+	if durStr := getEnv("DNSWEAVER_DRAIN_TIMEOUT"); durStr != "" {
+		d, err := time.ParseDuration(durStr)
+		if err != nil || d < 0 {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_DRAIN_TIMEOUT: invalid duration %q (use format like 30s, 1m)", durStr))
+		} else {
+			cfg.DrainTimeout = d
+		}
+	}
+
+	// Parse MAX_HOSTNAMES_PER_RECONCILE
+	if maxStr := getEnv("DNSWEAVER_MAX_HOSTNAMES_PER_RECONCILE"); maxStr != "" {
+		n, err := strconv.Atoi(maxStr)
+		if err != nil || n < 0 {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_MAX_HOSTNAMES_PER_RECONCILE: must be a non-negative integer, got %q", maxStr))
+		} else {
+			cfg.MaxHostnamesPerReconcile = n
+		}
+	}
+
+	// Parse DOCKER_LABEL_FILTER
+	if v := getEnv("DNSWEAVER_DOCKER_LABEL_FILTER"); v != "" {
+		cfg.DockerLabelFilter = splitPatterns(v)
+	}
+
+	// Parse IGNORE_HOSTNAMES
+	if v := getEnv("DNSWEAVER_IGNORE_HOSTNAMES"); v != "" {
+		cfg.IgnoreHostnames = splitPatterns(v)
+	}
+
+	// Parse PROVIDER_FALLBACK
+	cfg.ProviderFallback = parseBool(getEnv("DNSWEAVER_PROVIDER_FALLBACK"), false)
+
+	// Parse SOURCE_PRIORITIES
+	if v := getEnv("DNSWEAVER_SOURCE_PRIORITIES"); v != "" {
+		priorities, err := parseSourcePriorities(v)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_SOURCE_PRIORITIES: %v", err))
+		} else {
+			cfg.SourcePriorities = priorities
+		}
+	}
+
+	// Parse DOMAINS_ALLOWLIST
+	if v := getEnv("DNSWEAVER_DOMAINS_ALLOWLIST"); v != "" {
+		cfg.DomainsAllowlist = splitPatterns(v)
+	}
+
+	// Parse RECONCILE_BACKOFF_THRESHOLD, RECONCILE_BACKOFF, and RECONCILE_BACKOFF_MAX
+	cfg.BackoffThreshold = DefaultBackoffThreshold
+	if thresholdStr := getEnv("DNSWEAVER_RECONCILE_BACKOFF_THRESHOLD"); thresholdStr != "" {
+		threshold, err := strconv.Atoi(thresholdStr)
+		if err != nil || threshold < 0 {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_RECONCILE_BACKOFF_THRESHOLD: must be a non-negative integer, got %q", thresholdStr))
+		} else {
+			cfg.BackoffThreshold = threshold
+		}
+	}
+	cfg.BackoffInitial = DefaultBackoffInitial
+	if durStr := getEnv("DNSWEAVER_RECONCILE_BACKOFF"); durStr != "" {
+		d, err := time.ParseDuration(durStr)
+		if err != nil || d <= 0 {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_RECONCILE_BACKOFF: invalid duration %q (use format like 1m, 5m)", durStr))
+		} else {
+			cfg.BackoffInitial = d
+		}
+	}
+	cfg.BackoffMax = DefaultBackoffMax
+	if durStr := getEnv("DNSWEAVER_RECONCILE_BACKOFF_MAX"); durStr != "" {
+		d, err := time.ParseDuration(durStr)
+		if err != nil || d <= 0 {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_RECONCILE_BACKOFF_MAX: invalid duration %q (use format like 1h, 30m)", durStr))
+		} else {
+			cfg.BackoffMax = d
+		}
+	}
+
+	// Parse AUDIT_LOG
+	cfg.AuditLog = getEnv("DNSWEAVER_AUDIT_LOG")
+
+	// Parse NOTIFY_* (webhook URLs support _FILE suffix for Docker secrets)
+	cfg.NotifySlackWebhook = getEnvOrFile("DNSWEAVER_NOTIFY_SLACK_WEBHOOK", "DNSWEAVER_NOTIFY_SLACK_WEBHOOK_FILE")
+	cfg.NotifyDiscordWebhook = getEnvOrFile("DNSWEAVER_NOTIFY_DISCORD_WEBHOOK", "DNSWEAVER_NOTIFY_DISCORD_WEBHOOK_FILE")
+	cfg.NotifyMinActions = DefaultNotifyMinActions
+	if minStr := getEnv("DNSWEAVER_NOTIFY_MIN_ACTIONS"); minStr != "" {
+		n, err := strconv.Atoi(minStr)
+		if err != nil || n < 1 {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_NOTIFY_MIN_ACTIONS: must be a positive integer, got %q", minStr))
+		} else {
+			cfg.NotifyMinActions = n
+		}
+	}
+
+	// Parse CHANGE_WEBHOOK_URL (supports _FILE suffix for Docker secrets)
+	cfg.ChangeWebhookURL = getEnvOrFile("DNSWEAVER_CHANGE_WEBHOOK_URL", "DNSWEAVER_CHANGE_WEBHOOK_URL_FILE")
+
+	// Parse OPA_URL, OPA_TIMEOUT, and OPA_FAIL_OPEN
+	cfg.OPAURL = getEnv("DNSWEAVER_OPA_URL")
+	cfg.OPATimeout = DefaultOPATimeout
+	if durStr := getEnv("DNSWEAVER_OPA_TIMEOUT"); durStr != "" {
+		d, err := time.ParseDuration(durStr)
+		if err != nil || d <= 0 {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_OPA_TIMEOUT: invalid duration %q (use format like 500ms, 2s)", durStr))
+		} else {
+			cfg.OPATimeout = d
+		}
+	}
+	cfg.OPAFailOpen = parseBool(getEnv("DNSWEAVER_OPA_FAIL_OPEN"), DefaultOPAFailOpen)
+
+	// Parse METRICS_PUSHGATEWAY_URL and METRICS_PUSH_INTERVAL
+	cfg.MetricsPushGatewayURL = getEnv("DNSWEAVER_METRICS_PUSHGATEWAY_URL")
+	if intervalStr := getEnv("DNSWEAVER_METRICS_PUSH_INTERVAL"); intervalStr != "" {
+		interval, err := time.ParseDuration(intervalStr)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_METRICS_PUSH_INTERVAL: invalid duration %q (use format like 60s, 5m)", intervalStr))
+		} else if interval < 0 {
+			errs = append(errs, "DNSWEAVER_METRICS_PUSH_INTERVAL: must not be negative")
+		} else {
+			cfg.MetricsPushInterval = interval
+		}
+	}
+
+	// Parse API_ENABLED
+	if apiEnabledStr := getEnv("DNSWEAVER_API_ENABLED"); apiEnabledStr != "" {
+		cfg.APIEnabled = parseBool(apiEnabledStr, DefaultAPIEnabled)
+	} else {
+		cfg.APIEnabled = DefaultAPIEnabled
+	}
+
+	// Parse API_PORT
+	if portStr := getEnv("DNSWEAVER_API_PORT"); portStr != "" {
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_API_PORT: invalid integer %q", portStr))
+		} else if port < 1 || port > 65535 {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_API_PORT: must be between 1 and 65535, got %d", port))
+		} else {
+			cfg.APIPort = port
+		}
+	} else {
+		cfg.APIPort = DefaultAPIPort
+	}
+
+	// Parse API_TOKEN (supports _FILE suffix for Docker secrets)
+	cfg.APIToken = getEnvOrFile("DNSWEAVER_API_TOKEN", "DNSWEAVER_API_TOKEN_FILE")
+
 	return cfg, errs
 }
+
+// parseSourcePriorities parses comma-separated source:priority pairs
+// (e.g. "traefik:10,dnsweaver:5").
+func parseSourcePriorities(s string) (map[string]int, error) {
+	priorities := make(map[string]int)
+	for _, pair := range splitPatterns(s) {
+		name, value, ok := strings.Cut(pair, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid entry %q (must be source:priority)", pair)
+		}
+		priority, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority for source %q: %q", name, value)
+		}
+		priorities[name] = priority
+	}
+	return priorities, nil
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -18,10 +19,36 @@ func clearGlobalEnv(t *testing.T) {
 		"DNSWEAVER_ADOPT_EXISTING",
 		"DNSWEAVER_DEFAULT_TTL",
 		"DNSWEAVER_RECONCILE_INTERVAL",
+		"DNSWEAVER_RECONCILE_ON_STARTUP",
 		"DNSWEAVER_HEALTH_PORT",
 		"DNSWEAVER_DOCKER_HOST",
 		"DNSWEAVER_DOCKER_MODE",
+		"DNSWEAVER_DOCKER_LABEL_FILTER",
 		"DNSWEAVER_SOURCE",
+		"DNSWEAVER_API_ENABLED",
+		"DNSWEAVER_API_PORT",
+		"DNSWEAVER_API_TOKEN",
+		"DNSWEAVER_API_TOKEN_FILE",
+		"DNSWEAVER_PROVIDER_MAX_PENDING",
+		"DNSWEAVER_RECORD_CACHE_TTL",
+		"DNSWEAVER_METRICS_PUSHGATEWAY_URL",
+		"DNSWEAVER_AUDIT_LOG",
+		"DNSWEAVER_METRICS_PUSH_INTERVAL",
+		"DNSWEAVER_NOTIFY_SLACK_WEBHOOK",
+		"DNSWEAVER_NOTIFY_DISCORD_WEBHOOK",
+		"DNSWEAVER_NOTIFY_MIN_ACTIONS",
+		"DNSWEAVER_CHANGE_WEBHOOK_URL",
+		"DNSWEAVER_MAX_HOSTNAMES_PER_RECONCILE",
+		"DNSWEAVER_IGNORE_HOSTNAMES",
+		"DNSWEAVER_DOMAINS_ALLOWLIST",
+		"DNSWEAVER_PROVIDER_FALLBACK",
+		"DNSWEAVER_SOURCE_PRIORITIES",
+		"DNSWEAVER_RECONCILE_BACKOFF_THRESHOLD",
+		"DNSWEAVER_RECONCILE_BACKOFF",
+		"DNSWEAVER_RECONCILE_BACKOFF_MAX",
+		"DNSWEAVER_OPA_URL",
+		"DNSWEAVER_OPA_TIMEOUT",
+		"DNSWEAVER_OPA_FAIL_OPEN",
 	}
 	for _, v := range envVars {
 		os.Unsetenv(v)
@@ -292,3 +319,389 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestLoadGlobalConfig_API(t *testing.T) {
+	clearGlobalEnv(t)
+
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.APIEnabled != DefaultAPIEnabled {
+		t.Errorf("APIEnabled = %v, want %v", cfg.APIEnabled, DefaultAPIEnabled)
+	}
+	if cfg.APIPort != DefaultAPIPort {
+		t.Errorf("APIPort = %d, want %d", cfg.APIPort, DefaultAPIPort)
+	}
+
+	os.Setenv("DNSWEAVER_API_ENABLED", "true")
+	os.Setenv("DNSWEAVER_API_PORT", "9090")
+	os.Setenv("DNSWEAVER_API_TOKEN", "secret")
+	defer clearGlobalEnv(t)
+
+	cfg, errs = loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !cfg.APIEnabled {
+		t.Error("APIEnabled = false, want true")
+	}
+	if cfg.APIPort != 9090 {
+		t.Errorf("APIPort = %d, want 9090", cfg.APIPort)
+	}
+	if cfg.APIToken != "secret" {
+		t.Errorf("APIToken = %q, want %q", cfg.APIToken, "secret")
+	}
+
+	os.Setenv("DNSWEAVER_API_PORT", "70000")
+	if _, errs = loadGlobalConfig(); len(errs) == 0 {
+		t.Error("expected error for out-of-range API port")
+	}
+}
+
+func TestLoadGlobalConfig_ProviderMaxPending(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.ProviderMaxPending != 0 {
+		t.Errorf("ProviderMaxPending = %v, want 0", cfg.ProviderMaxPending)
+	}
+
+	os.Setenv("DNSWEAVER_PROVIDER_MAX_PENDING", "30m")
+	cfg, errs = loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.ProviderMaxPending != 30*time.Minute {
+		t.Errorf("ProviderMaxPending = %v, want 30m", cfg.ProviderMaxPending)
+	}
+
+	os.Setenv("DNSWEAVER_PROVIDER_MAX_PENDING", "soon")
+	if _, errs = loadGlobalConfig(); len(errs) == 0 {
+		t.Error("expected error for invalid duration")
+	}
+}
+
+func TestLoadGlobalConfig_RecordCacheTTL(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.RecordCacheTTL != 0 {
+		t.Errorf("RecordCacheTTL = %v, want 0", cfg.RecordCacheTTL)
+	}
+
+	os.Setenv("DNSWEAVER_RECORD_CACHE_TTL", "30s")
+	cfg, errs = loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.RecordCacheTTL != 30*time.Second {
+		t.Errorf("RecordCacheTTL = %v, want 30s", cfg.RecordCacheTTL)
+	}
+
+	os.Setenv("DNSWEAVER_RECORD_CACHE_TTL", "-1s")
+	if _, errs = loadGlobalConfig(); len(errs) == 0 {
+		t.Error("expected error for negative duration")
+	}
+}
+
+func TestLoadGlobalConfig_MetricsPush(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	os.Setenv("DNSWEAVER_METRICS_PUSHGATEWAY_URL", "http://pushgateway:9091")
+	os.Setenv("DNSWEAVER_RECONCILE_INTERVAL", "2m")
+
+	global, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	cfg := &Config{Global: global}
+	if cfg.MetricsPushGatewayURL() != "http://pushgateway:9091" {
+		t.Errorf("MetricsPushGatewayURL = %q", cfg.MetricsPushGatewayURL())
+	}
+	if cfg.MetricsPushInterval() != 2*time.Minute {
+		t.Errorf("MetricsPushInterval = %v, want reconcile interval 2m", cfg.MetricsPushInterval())
+	}
+
+	os.Setenv("DNSWEAVER_METRICS_PUSH_INTERVAL", "30s")
+	global, errs = loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	cfg = &Config{Global: global}
+	if cfg.MetricsPushInterval() != 30*time.Second {
+		t.Errorf("MetricsPushInterval = %v, want 30s", cfg.MetricsPushInterval())
+	}
+
+	os.Setenv("DNSWEAVER_METRICS_PUSH_INTERVAL", "often")
+	if _, errs = loadGlobalConfig(); len(errs) == 0 {
+		t.Error("expected error for invalid push interval")
+	}
+}
+
+func TestLoadGlobalConfig_Notify(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.NotifySlackWebhook != "" || cfg.NotifyDiscordWebhook != "" {
+		t.Errorf("webhooks = %q/%q, want empty", cfg.NotifySlackWebhook, cfg.NotifyDiscordWebhook)
+	}
+	if cfg.NotifyMinActions != DefaultNotifyMinActions {
+		t.Errorf("NotifyMinActions = %d, want %d", cfg.NotifyMinActions, DefaultNotifyMinActions)
+	}
+	if cfg.ChangeWebhookURL != "" {
+		t.Errorf("ChangeWebhookURL = %q, want empty", cfg.ChangeWebhookURL)
+	}
+
+	os.Setenv("DNSWEAVER_NOTIFY_SLACK_WEBHOOK", "https://hooks.slack.com/services/T/B/X")
+	os.Setenv("DNSWEAVER_NOTIFY_DISCORD_WEBHOOK", "https://discord.com/api/webhooks/1/abc")
+	os.Setenv("DNSWEAVER_NOTIFY_MIN_ACTIONS", "5")
+	os.Setenv("DNSWEAVER_CHANGE_WEBHOOK_URL", "https://hooks.example.com/dns")
+	cfg, errs = loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.NotifySlackWebhook != "https://hooks.slack.com/services/T/B/X" {
+		t.Errorf("NotifySlackWebhook = %q", cfg.NotifySlackWebhook)
+	}
+	if cfg.NotifyDiscordWebhook != "https://discord.com/api/webhooks/1/abc" {
+		t.Errorf("NotifyDiscordWebhook = %q", cfg.NotifyDiscordWebhook)
+	}
+	if cfg.NotifyMinActions != 5 {
+		t.Errorf("NotifyMinActions = %d, want 5", cfg.NotifyMinActions)
+	}
+	if cfg.ChangeWebhookURL != "https://hooks.example.com/dns" {
+		t.Errorf("ChangeWebhookURL = %q", cfg.ChangeWebhookURL)
+	}
+
+	os.Setenv("DNSWEAVER_NOTIFY_MIN_ACTIONS", "0")
+	if _, errs = loadGlobalConfig(); len(errs) == 0 {
+		t.Error("expected error for non-positive min actions")
+	}
+}
+
+func TestLoadGlobalConfig_ReconcileBackoff(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.BackoffThreshold != 0 || cfg.BackoffInitial != DefaultBackoffInitial || cfg.BackoffMax != DefaultBackoffMax {
+		t.Errorf("backoff = %d/%v/%v, want defaults", cfg.BackoffThreshold, cfg.BackoffInitial, cfg.BackoffMax)
+	}
+
+	os.Setenv("DNSWEAVER_RECONCILE_BACKOFF_THRESHOLD", "3")
+	os.Setenv("DNSWEAVER_RECONCILE_BACKOFF", "30s")
+	os.Setenv("DNSWEAVER_RECONCILE_BACKOFF_MAX", "10m")
+	cfg, errs = loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.BackoffThreshold != 3 || cfg.BackoffInitial != 30*time.Second || cfg.BackoffMax != 10*time.Minute {
+		t.Errorf("backoff = %d/%v/%v, want 3/30s/10m", cfg.BackoffThreshold, cfg.BackoffInitial, cfg.BackoffMax)
+	}
+
+	os.Setenv("DNSWEAVER_RECONCILE_BACKOFF_THRESHOLD", "-1")
+	os.Setenv("DNSWEAVER_RECONCILE_BACKOFF", "soon")
+	if _, errs = loadGlobalConfig(); len(errs) != 2 {
+		t.Errorf("expected 2 errors, got %v", errs)
+	}
+}
+
+func TestLoadGlobalConfig_MaxHostnamesPerReconcile(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.MaxHostnamesPerReconcile != 0 {
+		t.Errorf("MaxHostnamesPerReconcile = %d, want 0", cfg.MaxHostnamesPerReconcile)
+	}
+
+	os.Setenv("DNSWEAVER_MAX_HOSTNAMES_PER_RECONCILE", "500")
+	cfg, errs = loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.MaxHostnamesPerReconcile != 500 {
+		t.Errorf("MaxHostnamesPerReconcile = %d, want 500", cfg.MaxHostnamesPerReconcile)
+	}
+
+	os.Setenv("DNSWEAVER_MAX_HOSTNAMES_PER_RECONCILE", "-1")
+	if _, errs = loadGlobalConfig(); len(errs) == 0 {
+		t.Error("expected error for negative limit")
+	}
+}
+
+func TestLoadGlobalConfig_IgnoreHostnames(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	os.Setenv("DNSWEAVER_IGNORE_HOSTNAMES", "admin.example.com, metrics.*,")
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	want := []string{"admin.example.com", "metrics.*"}
+	if !reflect.DeepEqual(cfg.IgnoreHostnames, want) {
+		t.Errorf("IgnoreHostnames = %v, want %v", cfg.IgnoreHostnames, want)
+	}
+}
+
+func TestLoadGlobalConfig_DomainsAllowlist(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(cfg.DomainsAllowlist) != 0 {
+		t.Errorf("DomainsAllowlist = %v, want empty (all hostnames allowed)", cfg.DomainsAllowlist)
+	}
+
+	os.Setenv("DNSWEAVER_DOMAINS_ALLOWLIST", "*.internal.example.com,*.public.example.com")
+	cfg, errs = loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	want := []string{"*.internal.example.com", "*.public.example.com"}
+	if !reflect.DeepEqual(cfg.DomainsAllowlist, want) {
+		t.Errorf("DomainsAllowlist = %v, want %v", cfg.DomainsAllowlist, want)
+	}
+}
+
+func TestLoadGlobalConfig_ProviderFallback(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.ProviderFallback {
+		t.Error("ProviderFallback = true, want false by default")
+	}
+
+	os.Setenv("DNSWEAVER_PROVIDER_FALLBACK", "true")
+	cfg, errs = loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !cfg.ProviderFallback {
+		t.Error("ProviderFallback = false, want true")
+	}
+}
+
+func TestLoadGlobalConfig_ReconcileOnStart(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !cfg.ReconcileOnStart {
+		t.Error("ReconcileOnStart = false, want true by default")
+	}
+
+	os.Setenv("DNSWEAVER_RECONCILE_ON_STARTUP", "false")
+	cfg, errs = loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.ReconcileOnStart {
+		t.Error("ReconcileOnStart = true, want false")
+	}
+}
+
+func TestLoadGlobalConfig_DockerLabelFilter(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.DockerLabelFilter != nil {
+		t.Errorf("DockerLabelFilter = %v, want none by default", cfg.DockerLabelFilter)
+	}
+
+	os.Setenv("DNSWEAVER_DOCKER_LABEL_FILTER", "traefik.enable=true, dnsweaver.managed")
+	cfg, errs = loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	want := []string{"traefik.enable=true", "dnsweaver.managed"}
+	if !reflect.DeepEqual(cfg.DockerLabelFilter, want) {
+		t.Errorf("DockerLabelFilter = %v, want %v", cfg.DockerLabelFilter, want)
+	}
+}
+
+func TestLoadGlobalConfig_SourcePriorities(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	os.Setenv("DNSWEAVER_SOURCE_PRIORITIES", "traefik:10, dnsweaver:5,static:-1")
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	want := map[string]int{"traefik": 10, "dnsweaver": 5, "static": -1}
+	if !reflect.DeepEqual(cfg.SourcePriorities, want) {
+		t.Errorf("SourcePriorities = %v, want %v", cfg.SourcePriorities, want)
+	}
+
+	for _, invalid := range []string{"traefik", "traefik:high", ":10"} {
+		os.Setenv("DNSWEAVER_SOURCE_PRIORITIES", invalid)
+		if _, errs := loadGlobalConfig(); len(errs) == 0 {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestLoadGlobalConfig_OPA(t *testing.T) {
+	clearGlobalEnv(t)
+	defer clearGlobalEnv(t)
+
+	cfg, errs := loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.OPAURL != "" || cfg.OPATimeout != DefaultOPATimeout || cfg.OPAFailOpen {
+		t.Errorf("OPA = %q/%v/%v, want disabled with defaults", cfg.OPAURL, cfg.OPATimeout, cfg.OPAFailOpen)
+	}
+
+	os.Setenv("DNSWEAVER_OPA_URL", "http://opa:8181/v1/data/dnsweaver/allow")
+	os.Setenv("DNSWEAVER_OPA_TIMEOUT", "500ms")
+	os.Setenv("DNSWEAVER_OPA_FAIL_OPEN", "true")
+	cfg, errs = loadGlobalConfig()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.OPAURL != "http://opa:8181/v1/data/dnsweaver/allow" || cfg.OPATimeout != 500*time.Millisecond || !cfg.OPAFailOpen {
+		t.Errorf("OPA = %q/%v/%v, want configured values", cfg.OPAURL, cfg.OPATimeout, cfg.OPAFailOpen)
+	}
+
+	os.Setenv("DNSWEAVER_OPA_TIMEOUT", "0s")
+	if _, errs = loadGlobalConfig(); len(errs) != 1 {
+		t.Errorf("expected 1 error, got %v", errs)
+	}
+}
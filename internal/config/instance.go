@@ -3,8 +3,10 @@ package config
 import (
 	"fmt"
 	"log/slog"
+	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 )
@@ -24,6 +26,10 @@ type ProviderInstanceConfig struct {
 	// Target is the IPv4 (for A), IPv6 (for AAAA), or hostname (for CNAME) target.
 	Target string
 
+	// Targets lists all targets when several are configured via TARGETS.
+	// Target is always Targets[0] in that case.
+	Targets []string
+
 	// TTL for DNS records.
 	TTL int
 
@@ -31,6 +37,22 @@ type ProviderInstanceConfig struct {
 	// Defaults to "managed" if not set.
 	Mode provider.OperationalMode
 
+	// Priority orders instances matching the same hostname (lower first).
+	Priority int
+
+	// RateLimit is the minimum interval between provider operations (0 = unlimited).
+	RateLimit time.Duration
+
+	// RateLimitQueue is the maximum number of operations waiting for the rate limiter.
+	RateLimitQueue int
+
+	// CircuitBreakerThreshold is the number of consecutive failures that open
+	// the provider's circuit (0 = disabled).
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is how long an open circuit fails fast before probing.
+	CircuitBreakerCooldown time.Duration
+
 	// Domain matching patterns
 	Domains             []string // Glob patterns (default)
 	DomainsRegex        []string // Regex patterns (opt-in)
@@ -45,17 +67,23 @@ type ProviderInstanceConfig struct {
 // ToProviderConfig converts this config to the provider package's config type.
 func (c *ProviderInstanceConfig) ToProviderConfig() provider.ProviderInstanceConfig {
 	return provider.ProviderInstanceConfig{
-		Name:                c.Name,
-		TypeName:            c.TypeName,
-		RecordType:          c.RecordType,
-		Target:              c.Target,
-		TTL:                 c.TTL,
-		Mode:                c.Mode,
-		Domains:             c.Domains,
-		DomainsRegex:        c.DomainsRegex,
-		ExcludeDomains:      c.ExcludeDomains,
-		ExcludeDomainsRegex: c.ExcludeDomainsRegex,
-		ProviderConfig:      c.ProviderConfig,
+		Name:                    c.Name,
+		TypeName:                c.TypeName,
+		RecordType:              c.RecordType,
+		Target:                  c.Target,
+		Targets:                 c.Targets,
+		TTL:                     c.TTL,
+		Mode:                    c.Mode,
+		Priority:                c.Priority,
+		RateLimit:               c.RateLimit,
+		RateLimitQueue:          c.RateLimitQueue,
+		CircuitBreakerThreshold: c.CircuitBreakerThreshold,
+		CircuitBreakerCooldown:  c.CircuitBreakerCooldown,
+		Domains:                 c.Domains,
+		DomainsRegex:            c.DomainsRegex,
+		ExcludeDomains:          c.ExcludeDomains,
+		ExcludeDomainsRegex:     c.ExcludeDomainsRegex,
+		ProviderConfig:          c.ProviderConfig,
 	}
 }
 
@@ -92,8 +120,11 @@ func loadInstanceConfig(instanceName string, defaultTTL int) (*ProviderInstanceC
 	prefix := envPrefix(instanceName)
 
 	cfg := &ProviderInstanceConfig{
-		Name:           instanceName,
-		ProviderConfig: make(map[string]string),
+		Name:                    instanceName,
+		Priority:                provider.DefaultPriority,
+		CircuitBreakerThreshold: provider.DefaultCircuitBreakerThreshold,
+		CircuitBreakerCooldown:  provider.DefaultCircuitBreakerCooldown,
+		ProviderConfig:          make(map[string]string),
 	}
 
 	// TYPE is required
@@ -115,12 +146,29 @@ func loadInstanceConfig(instanceName string, defaultTTL int) (*ProviderInstanceC
 		errs = append(errs, fmt.Sprintf("%sRECORD_TYPE: invalid value %q (must be A, AAAA, or CNAME)", prefix, recordTypeStr))
 	}
 
-	// TARGET is required
-	cfg.Target = getEnv(prefix + "TARGET")
-	if cfg.Target == "" {
+	// TARGET (or TARGETS for round-robin records) is required
+	targetStr := getEnv(prefix + "TARGET")
+	targetsStr := getEnv(prefix + "TARGETS")
+	if targetStr != "" && targetsStr != "" {
+		errs = append(errs, fmt.Sprintf("%s: cannot set both TARGET and TARGETS", prefix[:len(prefix)-1]))
+	} else if targetsStr != "" {
+		cfg.setTargets(splitPatterns(targetsStr))
+	} else {
+		cfg.Target = targetStr
+	}
+	if targetStr == "" && cfg.Target == "" {
 		errs = append(errs, fmt.Sprintf("%sTARGET: required but not set", prefix))
 	}
 
+	// Infer AAAA when RECORD_TYPE is not set and TARGET is an IPv6 address
+	if recordTypeStr == "" && isIPv6Target(cfg.Target) {
+		cfg.RecordType = provider.RecordTypeAAAA
+		slog.Warn("RECORD_TYPE not set, inferred AAAA from IPv6 target",
+			slog.String("instance", instanceName),
+			slog.String("target", cfg.Target),
+		)
+	}
+
 	// TTL (optional, defaults to global default)
 	if ttlStr := getEnv(prefix + "TTL"); ttlStr != "" {
 		ttl, err := strconv.Atoi(ttlStr)
@@ -147,6 +195,32 @@ func loadInstanceConfig(instanceName string, defaultTTL int) (*ProviderInstanceC
 		cfg.Mode = provider.ModeManaged
 	}
 
+	// PRIORITY (optional, defaults to provider.DefaultPriority)
+	errs = append(errs, loadPriorityEnv(cfg, prefix)...)
+
+	// RATE_LIMIT (optional, e.g. "10/s")
+	if rateStr := getEnv(prefix + "RATE_LIMIT"); rateStr != "" {
+		interval, err := provider.ParseRateLimit(rateStr)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%sRATE_LIMIT: %s", prefix, err.Error()))
+		} else {
+			cfg.RateLimit = interval
+		}
+	}
+
+	// RATE_LIMIT_QUEUE (optional, defaults to provider.DefaultRateLimitQueue)
+	if queueStr := getEnv(prefix + "RATE_LIMIT_QUEUE"); queueStr != "" {
+		queue, err := strconv.Atoi(queueStr)
+		if err != nil || queue < 1 {
+			errs = append(errs, fmt.Sprintf("%sRATE_LIMIT_QUEUE: must be a positive integer", prefix))
+		} else {
+			cfg.RateLimitQueue = queue
+		}
+	}
+
+	// CIRCUIT_BREAKER_THRESHOLD / CIRCUIT_BREAKER_COOLDOWN (optional)
+	errs = append(errs, loadCircuitBreakerEnv(cfg, prefix)...)
+
 	// Domain patterns - either DOMAINS or DOMAINS_REGEX, not both
 	domainsStr := getEnv(prefix + "DOMAINS")
 	domainsRegexStr := getEnv(prefix + "DOMAINS_REGEX")
@@ -174,48 +248,105 @@ func loadInstanceConfig(instanceName string, defaultTTL int) (*ProviderInstanceC
 	}
 
 	// Load provider-specific config using shared field definitions
-	// Secrets support the _FILE suffix for Docker secrets
+	// Every field supports the _FILE suffix for Docker secrets
 	for _, field := range providerConfigFields {
-		var value string
-		if field.isSecret {
-			value = getEnvWithFileFallback(prefix, field.name)
-		} else {
-			value = getEnv(prefix + field.name)
+		value, err := readSecretOrFile(prefix+field, prefix+field+"_FILE")
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
 		}
 		if value != "" {
-			cfg.ProviderConfig[field.name] = value
+			cfg.ProviderConfig[field] = value
 		}
 	}
 
 	return cfg, errs
 }
 
+// setTargets sets Target and, when more than one is given, Targets.
+func (c *ProviderInstanceConfig) setTargets(targets []string) {
+	c.Targets = nil
+	if len(targets) == 0 {
+		return
+	}
+	c.Target = targets[0]
+	if len(targets) > 1 {
+		c.Targets = targets
+	}
+}
+
+// isIPv6Target reports whether target is an IPv6 address (not IPv4 or IPv4-mapped).
+func isIPv6Target(target string) bool {
+	ip := net.ParseIP(target)
+	return ip != nil && ip.To4() == nil
+}
+
 // providerConfigFields defines all provider-specific configuration fields.
 // This is shared between env var loading and file config merging.
-// Fields marked as secrets support the _FILE suffix pattern for Docker secrets.
-var providerConfigFields = []struct {
-	name     string
-	isSecret bool
-}{
-	{"URL", false},
-	{"TOKEN", true},
-	{"ZONE", false},
-	{"ZONE_ID", false},
-	{"API_KEY", true},
-	{"API_EMAIL", false},
-	{"PROXIED", false},              // Cloudflare-specific
-	{"AUTH_HEADER", false},          // Webhook-specific
-	{"AUTH_TOKEN", true},            // Webhook-specific
-	{"TIMEOUT", false},              // Webhook-specific
-	{"RETRIES", false},              // Webhook-specific
-	{"RETRY_DELAY", false},          // Webhook-specific
-	{"HOST_FILE", false},            // dnsmasq-specific
-	{"BACKUP", false},               // dnsmasq-specific
-	{"INCLUDE_MARKER", false},       // dnsmasq-specific
-	{"RELOAD_COMMAND", false},       // dnsmasq-specific
-	{"MODE", false},                 // Pi-hole specific (api/file)
-	{"PASSWORD", true},              // Pi-hole specific
-	{"INSECURE_SKIP_VERIFY", false}, // TLS certificate verification skip
+// Every field supports the _FILE suffix pattern for Docker secrets: if
+// {PREFIX}{FIELD}_FILE is set, the file contents are used as the value.
+var providerConfigFields = []string{
+	"URL",
+	"TOKEN", // Secret
+	"ZONE",
+	"ZONE_ID",
+	"API_KEY", // Secret
+	"API_EMAIL",
+	"PROXIED",                 // Cloudflare-specific
+	"CLOUDFLARE_APEX_FLATTEN", // Cloudflare-specific
+	"AUTH_HEADER",             // Webhook and HTTP provider
+	"AUTH_TOKEN",              // Webhook and HTTP provider (secret)
+	"TIMEOUT",                 // Webhook-specific
+	"RETRIES",                 // Webhook-specific
+	"RETRY_DELAY",             // Webhook-specific
+	"HOST_FILE",               // dnsmasq-specific
+	"BACKUP",                  // dnsmasq-specific
+	"INCLUDE_MARKER",          // dnsmasq-specific
+	"RELOAD_COMMAND",          // dnsmasq-specific
+	"MODE",                    // Pi-hole specific (api/file)
+	"PASSWORD",                // Pi-hole and AdGuard Home (secret)
+	"API_ENDPOINT",            // Pi-hole v6 API mode
+	"API_PASSWORD",            // Pi-hole v6 API mode (secret)
+	"INSECURE_SKIP_VERIFY",    // TLS certificate verification skip
+	"SKIP_TLS_VERIFY",         // Webhook alias for INSECURE_SKIP_VERIFY
+	"CLIENT_CERT_FILE",        // Webhook mutual TLS certificate
+	"CLIENT_KEY_FILE",         // Webhook mutual TLS key
+	"PRIMARY",                 // Failover primary instance
+	"SECONDARY",               // Failover secondary instance
+	"HEALTH_CHECK_INTERVAL",   // Failover primary health check interval
+	"HTTP2",                   // Technitium HTTP/2 negotiation
+	"MAX_IDLE_CONNS",          // Technitium connection pool size
+	"HOST",                    // Windows DNS WinRM target or SSH jump host
+	"USER",                    // Windows DNS account
+	"DNS_SERVER",              // Windows DNS server when it is not HOST
+	"KNOWN_HOSTS_FILE",        // SSH known_hosts file (Windows DNS, Knot, hosts file)
+	"SERVER_ID",               // PowerDNS server ID
+	"SOCKET",                  // Knot control socket
+	"KNOTC",                   // Knot knotc command
+	"SSH_HOST",                // Knot and hosts file SSH host
+	"SSH_PORT",                // Knot and hosts file SSH port
+	"SSH_USER",                // Knot and hosts file SSH user
+	"SSH_KEY_FILE",            // Knot and hosts file SSH private key
+	"SSH_PASSWORD",            // Knot and hosts file SSH password (secret)
+	"SSH_PROXY_JUMP",          // Knot and hosts file SSH bastion host
+	"FILE",                    // hosts file path
+	"ETCD_ENDPOINTS",          // CoreDNS etcd client URLs
+	"ETCD_PREFIX",             // CoreDNS etcd key prefix
+	"ACCOUNT_ID",              // Cloudflare Tunnel account ID
+	"API_TOKEN",               // Cloudflare Tunnel API token (secret)
+	"TUNNEL_ID",               // Cloudflare Tunnel UUID
+	"USERNAME",                // AdGuard Home admin username
+	"CREATE_URL",              // HTTP provider create URL template
+	"CREATE_METHOD",           // HTTP provider create method
+	"DELETE_URL",              // HTTP provider delete URL template
+	"DELETE_METHOD",           // HTTP provider delete method
+	"LIST_URL",                // HTTP provider list URL
+	"REGION",                  // Route53 AWS region
+	"ACCESS_KEY_ID",           // Route53 AWS access key ID (secret)
+	"SECRET_ACCESS_KEY",       // Route53 AWS secret access key (secret)
+	"PROJECT",                 // Cloud DNS GCP project ID
+	"ZONE_NAME",               // Cloud DNS managed zone name
+	"CREDENTIALS",             // Cloud DNS service account key JSON (secret)
 }
 
 // mergeProviderEnvOverrides applies environment variable overrides to a
@@ -225,10 +356,13 @@ var providerConfigFields = []struct {
 //  3. Use Docker secrets with the _FILE suffix pattern
 //
 // Environment variables use the pattern: DNSWEAVER_{PROVIDER_NAME}_{FIELD}
-// For secrets, DNSWEAVER_{PROVIDER_NAME}_{FIELD}_FILE is also checked.
+// DNSWEAVER_{PROVIDER_NAME}_{FIELD}_FILE is also checked for every field.
 //
-// Any env var that is set will override the corresponding YAML value.
-func mergeProviderEnvOverrides(cfg *ProviderInstanceConfig) {
+// Any env var that is set will override the corresponding YAML value; fields
+// without an env var keep their YAML value. Returns errors for invalid values
+// and for _FILE variables whose file cannot be read.
+func mergeProviderEnvOverrides(cfg *ProviderInstanceConfig) []string {
+	var errs []string
 	prefix := envPrefix(cfg.Name)
 
 	// Ensure ProviderConfig map exists
@@ -238,35 +372,68 @@ func mergeProviderEnvOverrides(cfg *ProviderInstanceConfig) {
 
 	// Check for provider-specific config field overrides
 	for _, field := range providerConfigFields {
-		var value string
-		if field.isSecret {
-			value = getEnvWithFileFallback(prefix, field.name)
-		} else {
-			value = getEnv(prefix + field.name)
+		value, err := readSecretOrFile(prefix+field, prefix+field+"_FILE")
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
 		}
 		// Only override if env var is explicitly set
 		if value != "" {
 			slog.Debug("env override applied to provider config",
 				slog.String("provider", cfg.Name),
-				slog.String("field", field.name),
+				slog.String("field", field),
 			)
-			cfg.ProviderConfig[field.name] = value
+			cfg.ProviderConfig[field] = value
 		}
 	}
 
-	// Also check for top-level provider settings that might be overridden
-	// TARGET override
-	if target := getEnv(prefix + "TARGET"); target != "" {
+	// Top-level provider settings. Invalid values are reported with the same
+	// messages as the env-only path instead of silently keeping the YAML value.
+
+	// RECORD_TYPE override
+	if recordTypeStr := strings.ToUpper(getEnv(prefix + "RECORD_TYPE")); recordTypeStr != "" {
+		switch recordTypeStr {
+		case "A", "AAAA", "CNAME":
+			slog.Debug("env override applied to provider record type",
+				slog.String("provider", cfg.Name),
+				slog.String("record_type", recordTypeStr),
+			)
+			cfg.RecordType = provider.RecordType(recordTypeStr)
+		default:
+			errs = append(errs, fmt.Sprintf("%sRECORD_TYPE: invalid value %q (must be A, AAAA, or CNAME)", prefix, recordTypeStr))
+		}
+	}
+
+	// TARGET / TARGETS override
+	targetStr := getEnv(prefix + "TARGET")
+	targetsStr := getEnv(prefix + "TARGETS")
+	switch {
+	case targetStr != "" && targetsStr != "":
+		errs = append(errs, fmt.Sprintf("%s: cannot set both TARGET and TARGETS", prefix[:len(prefix)-1]))
+	case targetStr != "":
 		slog.Debug("env override applied to provider target",
 			slog.String("provider", cfg.Name),
-			slog.String("target", target),
+			slog.String("target", targetStr),
 		)
-		cfg.Target = target
+		cfg.Target = targetStr
+		cfg.Targets = nil
+	case targetsStr != "":
+		slog.Debug("env override applied to provider targets",
+			slog.String("provider", cfg.Name),
+			slog.String("targets", targetsStr),
+		)
+		cfg.setTargets(splitPatterns(targetsStr))
 	}
 
 	// TTL override
 	if ttlStr := getEnv(prefix + "TTL"); ttlStr != "" {
-		if ttl, err := strconv.Atoi(ttlStr); err == nil && ttl >= 1 {
+		ttl, err := strconv.Atoi(ttlStr)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Sprintf("%sTTL: invalid integer %q", prefix, ttlStr))
+		case ttl < 1:
+			errs = append(errs, fmt.Sprintf("%sTTL: must be at least 1", prefix))
+		default:
 			slog.Debug("env override applied to provider TTL",
 				slog.String("provider", cfg.Name),
 				slog.Int("ttl", ttl),
@@ -277,7 +444,10 @@ func mergeProviderEnvOverrides(cfg *ProviderInstanceConfig) {
 
 	// MODE override
 	if modeStr := getEnv(prefix + "MODE"); modeStr != "" {
-		if mode, err := provider.ParseOperationalMode(modeStr); err == nil {
+		mode, err := provider.ParseOperationalMode(modeStr)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%sMODE: %s", prefix, err.Error()))
+		} else {
 			slog.Debug("env override applied to provider mode",
 				slog.String("provider", cfg.Name),
 				slog.String("mode", modeStr),
@@ -285,6 +455,106 @@ func mergeProviderEnvOverrides(cfg *ProviderInstanceConfig) {
 			cfg.Mode = mode
 		}
 	}
+
+	// PRIORITY override
+	errs = append(errs, loadPriorityEnv(cfg, prefix)...)
+
+	// RATE_LIMIT / RATE_LIMIT_QUEUE overrides
+	if rateStr := getEnv(prefix + "RATE_LIMIT"); rateStr != "" {
+		interval, err := provider.ParseRateLimit(rateStr)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%sRATE_LIMIT: %s", prefix, err.Error()))
+		} else {
+			slog.Debug("env override applied to provider rate limit",
+				slog.String("provider", cfg.Name),
+				slog.String("rate_limit", rateStr),
+			)
+			cfg.RateLimit = interval
+		}
+	}
+	if queueStr := getEnv(prefix + "RATE_LIMIT_QUEUE"); queueStr != "" {
+		queue, err := strconv.Atoi(queueStr)
+		if err != nil || queue < 1 {
+			errs = append(errs, fmt.Sprintf("%sRATE_LIMIT_QUEUE: must be a positive integer", prefix))
+		} else {
+			cfg.RateLimitQueue = queue
+		}
+	}
+
+	// DOMAINS / DOMAINS_REGEX overrides replace both YAML pattern lists
+	domainsStr := getEnv(prefix + "DOMAINS")
+	domainsRegexStr := getEnv(prefix + "DOMAINS_REGEX")
+	switch {
+	case domainsStr != "" && domainsRegexStr != "":
+		errs = append(errs, fmt.Sprintf("%s: cannot set both DOMAINS and DOMAINS_REGEX", prefix[:len(prefix)-1]))
+	case domainsStr != "":
+		cfg.Domains = splitPatterns(domainsStr)
+		cfg.DomainsRegex = nil
+	case domainsRegexStr != "":
+		cfg.Domains = nil
+		cfg.DomainsRegex = splitPatterns(domainsRegexStr)
+	}
+
+	// EXCLUDE_DOMAINS / EXCLUDE_DOMAINS_REGEX overrides
+	excludeStr := getEnv(prefix + "EXCLUDE_DOMAINS")
+	excludeRegexStr := getEnv(prefix + "EXCLUDE_DOMAINS_REGEX")
+	switch {
+	case excludeStr != "" && excludeRegexStr != "":
+		errs = append(errs, fmt.Sprintf("%s: cannot set both EXCLUDE_DOMAINS and EXCLUDE_DOMAINS_REGEX", prefix[:len(prefix)-1]))
+	case excludeStr != "":
+		cfg.ExcludeDomains = splitPatterns(excludeStr)
+		cfg.ExcludeDomainsRegex = nil
+	case excludeRegexStr != "":
+		cfg.ExcludeDomains = nil
+		cfg.ExcludeDomainsRegex = splitPatterns(excludeRegexStr)
+	}
+
+	// CIRCUIT_BREAKER_THRESHOLD / CIRCUIT_BREAKER_COOLDOWN overrides
+	errs = append(errs, loadCircuitBreakerEnv(cfg, prefix)...)
+
+	return errs
+}
+
+// loadPriorityEnv applies PRIORITY (a non-negative integer, lower values
+// preferred) from the environment.
+func loadPriorityEnv(cfg *ProviderInstanceConfig, prefix string) []string {
+	priorityStr := getEnv(prefix + "PRIORITY")
+	if priorityStr == "" {
+		return nil
+	}
+
+	priority, err := strconv.Atoi(priorityStr)
+	if err != nil || priority < 0 {
+		return []string{fmt.Sprintf("%sPRIORITY: must be a non-negative integer", prefix)}
+	}
+	cfg.Priority = priority
+	return nil
+}
+
+// loadCircuitBreakerEnv applies CIRCUIT_BREAKER_THRESHOLD (0 disables the
+// breaker) and CIRCUIT_BREAKER_COOLDOWN (e.g. "60s") from the environment.
+func loadCircuitBreakerEnv(cfg *ProviderInstanceConfig, prefix string) []string {
+	var errs []string
+
+	if thresholdStr := getEnv(prefix + "CIRCUIT_BREAKER_THRESHOLD"); thresholdStr != "" {
+		threshold, err := strconv.Atoi(thresholdStr)
+		if err != nil || threshold < 0 {
+			errs = append(errs, fmt.Sprintf("%sCIRCUIT_BREAKER_THRESHOLD: must be a non-negative integer", prefix))
+		} else {
+			cfg.CircuitBreakerThreshold = threshold
+		}
+	}
+
+	if cooldownStr := getEnv(prefix + "CIRCUIT_BREAKER_COOLDOWN"); cooldownStr != "" {
+		cooldown, err := time.ParseDuration(cooldownStr)
+		if err != nil || cooldown <= 0 {
+			errs = append(errs, fmt.Sprintf("%sCIRCUIT_BREAKER_COOLDOWN: must be a positive duration", prefix))
+		} else {
+			cfg.CircuitBreakerCooldown = cooldown
+		}
+	}
+
+	return errs
 }
 
 // splitPatterns splits a comma-separated pattern string into individual patterns.
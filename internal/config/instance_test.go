@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 )
@@ -17,6 +18,12 @@ func clearInstanceEnv(t *testing.T, instanceName string) {
 		prefix + "TYPE",
 		prefix + "RECORD_TYPE",
 		prefix + "TARGET",
+		prefix + "TARGETS",
+		prefix + "RATE_LIMIT",
+		prefix + "RATE_LIMIT_QUEUE",
+		prefix + "CIRCUIT_BREAKER_THRESHOLD",
+		prefix + "CIRCUIT_BREAKER_COOLDOWN",
+		prefix + "PRIORITY",
 		prefix + "TTL",
 		prefix + "MODE",
 		prefix + "DOMAINS",
@@ -164,6 +171,189 @@ func TestLoadInstanceConfig_Minimal(t *testing.T) {
 	}
 }
 
+func TestLoadInstanceConfig_FileSuffix(t *testing.T) {
+	const instanceName = "secret-dns"
+	clearInstanceEnv(t, instanceName)
+	defer clearInstanceEnv(t, instanceName)
+
+	prefix := envPrefix(instanceName)
+	os.Setenv(prefix+"TYPE", "cloudflare")
+	os.Setenv(prefix+"TARGET", "10.0.0.100")
+	os.Setenv(prefix+"DOMAINS", "*.example.com")
+
+	// Any provider field supports _FILE, not only the well-known secrets
+	emailFile := filepath.Join(t.TempDir(), "email")
+	if err := os.WriteFile(emailFile, []byte("ops@example.com\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv(prefix+"API_EMAIL", "env@example.com")
+	t.Setenv(prefix+"API_EMAIL_FILE", emailFile)
+
+	cfg, errs := loadInstanceConfig(instanceName, 300)
+	if len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if got := cfg.ProviderConfig["API_EMAIL"]; got != "ops@example.com" {
+		t.Errorf("API_EMAIL = %q, want file value ops@example.com", got)
+	}
+
+	t.Setenv(prefix+"API_EMAIL_FILE", "/nonexistent/email")
+	_, errs = loadInstanceConfig(instanceName, 300)
+	if len(errs) != 1 || !strings.Contains(errs[0], prefix+"API_EMAIL_FILE") {
+		t.Errorf("errs = %v, want one error naming %sAPI_EMAIL_FILE", errs, prefix)
+	}
+}
+
+func TestLoadInstanceConfig_Targets(t *testing.T) {
+	const instanceName = "rr-dns"
+	clearInstanceEnv(t, instanceName)
+	defer clearInstanceEnv(t, instanceName)
+
+	prefix := envPrefix(instanceName)
+	os.Setenv(prefix+"TYPE", "technitium")
+	os.Setenv(prefix+"TARGETS", "10.0.0.1, 10.0.0.2")
+	os.Setenv(prefix+"DOMAINS", "*.example.com")
+
+	cfg, errs := loadInstanceConfig(instanceName, 300)
+
+	if len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if cfg.Target != "10.0.0.1" {
+		t.Errorf("Target = %q, want %q", cfg.Target, "10.0.0.1")
+	}
+	if len(cfg.Targets) != 2 || cfg.Targets[1] != "10.0.0.2" {
+		t.Errorf("Targets = %v, want [10.0.0.1 10.0.0.2]", cfg.Targets)
+	}
+	if got := cfg.ToProviderConfig().Targets; len(got) != 2 {
+		t.Errorf("ToProviderConfig().Targets = %v, want 2 targets", got)
+	}
+}
+
+func TestLoadInstanceConfig_TargetAndTargets(t *testing.T) {
+	const instanceName = "rr-dns"
+	clearInstanceEnv(t, instanceName)
+	defer clearInstanceEnv(t, instanceName)
+
+	prefix := envPrefix(instanceName)
+	os.Setenv(prefix+"TYPE", "technitium")
+	os.Setenv(prefix+"TARGET", "10.0.0.1")
+	os.Setenv(prefix+"TARGETS", "10.0.0.1,10.0.0.2")
+	os.Setenv(prefix+"DOMAINS", "*.example.com")
+
+	_, errs := loadInstanceConfig(instanceName, 300)
+
+	if len(errs) != 1 || !strings.Contains(errs[0], "cannot set both TARGET and TARGETS") {
+		t.Errorf("errs = %v, want single TARGET/TARGETS conflict", errs)
+	}
+}
+
+func TestLoadInstanceConfig_RateLimit(t *testing.T) {
+	const instanceName = "limited-dns"
+	clearInstanceEnv(t, instanceName)
+	defer clearInstanceEnv(t, instanceName)
+
+	prefix := envPrefix(instanceName)
+	os.Setenv(prefix+"TYPE", "technitium")
+	os.Setenv(prefix+"TARGET", "10.0.0.1")
+	os.Setenv(prefix+"DOMAINS", "*.example.com")
+	os.Setenv(prefix+"RATE_LIMIT", "10/s")
+	os.Setenv(prefix+"RATE_LIMIT_QUEUE", "25")
+
+	cfg, errs := loadInstanceConfig(instanceName, 300)
+
+	if len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if cfg.RateLimit != 100*time.Millisecond {
+		t.Errorf("RateLimit = %v, want 100ms", cfg.RateLimit)
+	}
+	if cfg.RateLimitQueue != 25 {
+		t.Errorf("RateLimitQueue = %d, want 25", cfg.RateLimitQueue)
+	}
+
+	os.Setenv(prefix+"RATE_LIMIT", "fast")
+	if _, errs := loadInstanceConfig(instanceName, 300); len(errs) != 1 || !strings.Contains(errs[0], "RATE_LIMIT") {
+		t.Errorf("errs = %v, want RATE_LIMIT error", errs)
+	}
+}
+
+func TestLoadInstanceConfig_CircuitBreaker(t *testing.T) {
+	const instanceName = "breaker-dns"
+	clearInstanceEnv(t, instanceName)
+	defer clearInstanceEnv(t, instanceName)
+
+	prefix := envPrefix(instanceName)
+	os.Setenv(prefix+"TYPE", "technitium")
+	os.Setenv(prefix+"TARGET", "10.0.0.1")
+	os.Setenv(prefix+"DOMAINS", "*.example.com")
+
+	cfg, errs := loadInstanceConfig(instanceName, 300)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.CircuitBreakerThreshold != provider.DefaultCircuitBreakerThreshold {
+		t.Errorf("CircuitBreakerThreshold = %d, want default %d", cfg.CircuitBreakerThreshold, provider.DefaultCircuitBreakerThreshold)
+	}
+	if cfg.CircuitBreakerCooldown != provider.DefaultCircuitBreakerCooldown {
+		t.Errorf("CircuitBreakerCooldown = %v, want default %v", cfg.CircuitBreakerCooldown, provider.DefaultCircuitBreakerCooldown)
+	}
+
+	os.Setenv(prefix+"CIRCUIT_BREAKER_THRESHOLD", "0")
+	os.Setenv(prefix+"CIRCUIT_BREAKER_COOLDOWN", "2m")
+	cfg, errs = loadInstanceConfig(instanceName, 300)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.CircuitBreakerThreshold != 0 {
+		t.Errorf("CircuitBreakerThreshold = %d, want 0 (disabled)", cfg.CircuitBreakerThreshold)
+	}
+	if cfg.CircuitBreakerCooldown != 2*time.Minute {
+		t.Errorf("CircuitBreakerCooldown = %v, want 2m", cfg.CircuitBreakerCooldown)
+	}
+
+	os.Setenv(prefix+"CIRCUIT_BREAKER_THRESHOLD", "-1")
+	if _, errs := loadInstanceConfig(instanceName, 300); len(errs) != 1 || !strings.Contains(errs[0], "CIRCUIT_BREAKER_THRESHOLD") {
+		t.Errorf("errs = %v, want CIRCUIT_BREAKER_THRESHOLD error", errs)
+	}
+}
+
+func TestLoadInstanceConfig_Priority(t *testing.T) {
+	const instanceName = "priority-dns"
+	clearInstanceEnv(t, instanceName)
+	defer clearInstanceEnv(t, instanceName)
+
+	prefix := envPrefix(instanceName)
+	os.Setenv(prefix+"TYPE", "technitium")
+	os.Setenv(prefix+"TARGET", "10.0.0.1")
+	os.Setenv(prefix+"DOMAINS", "*.example.com")
+
+	cfg, errs := loadInstanceConfig(instanceName, 300)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.Priority != provider.DefaultPriority {
+		t.Errorf("Priority = %d, want default %d", cfg.Priority, provider.DefaultPriority)
+	}
+
+	os.Setenv(prefix+"PRIORITY", "10")
+	cfg, errs = loadInstanceConfig(instanceName, 300)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.Priority != 10 {
+		t.Errorf("Priority = %d, want 10", cfg.Priority)
+	}
+	if got := cfg.ToProviderConfig().Priority; got != 10 {
+		t.Errorf("ToProviderConfig().Priority = %d, want 10", got)
+	}
+
+	os.Setenv(prefix+"PRIORITY", "-1")
+	if _, errs := loadInstanceConfig(instanceName, 300); len(errs) != 1 || !strings.Contains(errs[0], "PRIORITY") {
+		t.Errorf("errs = %v, want PRIORITY error", errs)
+	}
+}
+
 func TestLoadInstanceConfig_Complete(t *testing.T) {
 	const instanceName = "internal-dns"
 	clearInstanceEnv(t, instanceName)
@@ -249,6 +439,46 @@ func TestLoadInstanceConfig_CNAMERecord(t *testing.T) {
 	}
 }
 
+func TestLoadInstanceConfig_InferAAAA(t *testing.T) {
+	tests := []struct {
+		name       string
+		recordType string
+		target     string
+		want       provider.RecordType
+	}{
+		{"IPv6 target infers AAAA", "", "2001:db8::1", provider.RecordTypeAAAA},
+		{"IPv4 target stays A", "", "10.0.0.1", provider.RecordTypeA},
+		{"IPv4-mapped IPv6 stays A", "", "::ffff:10.0.0.1", provider.RecordTypeA},
+		{"hostname target stays A", "", "proxy.example.com", provider.RecordTypeA},
+		{"explicit AAAA", "AAAA", "2001:db8::1", provider.RecordTypeAAAA},
+		{"explicit A overrides inference", "A", "2001:db8::1", provider.RecordTypeA},
+	}
+
+	const instanceName = "infer-test"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearInstanceEnv(t, instanceName)
+			defer clearInstanceEnv(t, instanceName)
+
+			prefix := envPrefix(instanceName)
+			os.Setenv(prefix+"TYPE", "technitium")
+			os.Setenv(prefix+"TARGET", tt.target)
+			os.Setenv(prefix+"DOMAINS", "*.example.com")
+			if tt.recordType != "" {
+				os.Setenv(prefix+"RECORD_TYPE", tt.recordType)
+			}
+
+			cfg, errs := loadInstanceConfig(instanceName, 300)
+			if len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+			if cfg.RecordType != tt.want {
+				t.Errorf("RecordType = %q, want %q", cfg.RecordType, tt.want)
+			}
+		})
+	}
+}
+
 func TestLoadInstanceConfig_RegexDomains(t *testing.T) {
 	const instanceName = "regex-test"
 	clearInstanceEnv(t, instanceName)
@@ -759,4 +989,51 @@ func TestMergeProviderEnvOverrides(t *testing.T) {
 			t.Errorf("TOKEN = %q, want %q", cfg.ProviderConfig["TOKEN"], "new-token")
 		}
 	})
+
+	t.Run("overrides record type and domains", func(t *testing.T) {
+		prefix := envPrefix("test-domains-override")
+		t.Setenv(prefix+"RECORD_TYPE", "cname")
+		t.Setenv(prefix+"DOMAINS_REGEX", `^.*\.example\.com$`)
+
+		cfg := &ProviderInstanceConfig{
+			Name:       "test-domains-override",
+			TypeName:   "technitium",
+			RecordType: provider.RecordTypeA,
+			Target:     "10.0.0.1",
+			Domains:    []string{"*.example.com"},
+		}
+
+		if errs := mergeProviderEnvOverrides(cfg); len(errs) != 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		if cfg.RecordType != provider.RecordTypeCNAME {
+			t.Errorf("RecordType = %s, want CNAME", cfg.RecordType)
+		}
+		if cfg.Domains != nil || len(cfg.DomainsRegex) != 1 {
+			t.Errorf("Domains = %v, DomainsRegex = %v; want only the regex", cfg.Domains, cfg.DomainsRegex)
+		}
+	})
+
+	t.Run("reports invalid overrides", func(t *testing.T) {
+		prefix := envPrefix("test-invalid-override")
+		t.Setenv(prefix+"TTL", "soon")
+		t.Setenv(prefix+"MODE", "bogus")
+		t.Setenv(prefix+"RECORD_TYPE", "MX")
+
+		cfg := &ProviderInstanceConfig{
+			Name:     "test-invalid-override",
+			TypeName: "technitium",
+			Target:   "10.0.0.1",
+			TTL:      300,
+			Mode:     provider.ModeManaged,
+		}
+
+		errs := mergeProviderEnvOverrides(cfg)
+		if len(errs) != 3 {
+			t.Errorf("expected 3 errors, got %d: %v", len(errs), errs)
+		}
+		if cfg.TTL != 300 || cfg.Mode != provider.ModeManaged {
+			t.Errorf("invalid overrides should keep YAML values, got TTL=%d mode=%s", cfg.TTL, cfg.Mode)
+		}
+	})
 }
@@ -2,6 +2,7 @@
 package config
 
 import (
+	"fmt"
 	"log/slog"
 	"strings"
 	"time"
@@ -80,12 +81,28 @@ func convertFileProvider(fp FileProviderConfig, defaultTTL int) (*ProviderInstan
 		errs = append(errs, "provider "+cfg.Name+": invalid record_type "+fp.RecordType)
 	}
 
-	// Target
-	cfg.Target = fp.Target
-	if cfg.Target == "" {
+	// Target (or targets for round-robin records)
+	switch {
+	case fp.Target != "" && len(fp.Targets) > 0:
+		errs = append(errs, "provider "+cfg.Name+": cannot set both target and targets")
+	case len(fp.Targets) > 0:
+		cfg.setTargets(fp.Targets)
+	default:
+		cfg.Target = fp.Target
+	}
+	if cfg.Target == "" && len(fp.Targets) == 0 {
 		errs = append(errs, "provider "+cfg.Name+": target is required")
 	}
 
+	// Infer AAAA when record_type is not set and target is an IPv6 address
+	if recordTypeStr == "" && isIPv6Target(cfg.Target) {
+		cfg.RecordType = provider.RecordTypeAAAA
+		slog.Warn("record_type not set, inferred AAAA from IPv6 target",
+			slog.String("provider", cfg.Name),
+			slog.String("target", cfg.Target),
+		)
+	}
+
 	// TTL
 	if fp.TTL > 0 {
 		cfg.TTL = fp.TTL
@@ -105,6 +122,47 @@ func convertFileProvider(fp FileProviderConfig, defaultTTL int) (*ProviderInstan
 		cfg.Mode = provider.ModeManaged
 	}
 
+	// Priority
+	cfg.Priority = provider.DefaultPriority
+	if fp.Priority != nil {
+		if *fp.Priority < 0 {
+			errs = append(errs, "provider "+cfg.Name+": priority must not be negative")
+		}
+		cfg.Priority = *fp.Priority
+	}
+
+	// Rate limit
+	if fp.RateLimit != "" {
+		interval, err := provider.ParseRateLimit(fp.RateLimit)
+		if err != nil {
+			errs = append(errs, "provider "+cfg.Name+": "+err.Error())
+		} else {
+			cfg.RateLimit = interval
+		}
+	}
+	if fp.RateLimitQueue < 0 {
+		errs = append(errs, "provider "+cfg.Name+": rate_limit_queue must not be negative")
+	}
+	cfg.RateLimitQueue = fp.RateLimitQueue
+
+	// Circuit breaker
+	cfg.CircuitBreakerThreshold = provider.DefaultCircuitBreakerThreshold
+	if fp.CircuitBreakerThreshold != nil {
+		if *fp.CircuitBreakerThreshold < 0 {
+			errs = append(errs, "provider "+cfg.Name+": circuit_breaker_threshold must not be negative")
+		}
+		cfg.CircuitBreakerThreshold = *fp.CircuitBreakerThreshold
+	}
+	cfg.CircuitBreakerCooldown = provider.DefaultCircuitBreakerCooldown
+	if fp.CircuitBreakerCooldown != "" {
+		cooldown, err := time.ParseDuration(fp.CircuitBreakerCooldown)
+		if err != nil || cooldown <= 0 {
+			errs = append(errs, "provider "+cfg.Name+": circuit_breaker_cooldown must be a positive duration")
+		} else {
+			cfg.CircuitBreakerCooldown = cooldown
+		}
+	}
+
 	// Domains validation
 	if len(fp.Domains) == 0 && len(fp.DomainsRegex) == 0 {
 		errs = append(errs, "provider "+cfg.Name+": domains or domains_regex is required")
@@ -116,10 +174,12 @@ func convertFileProvider(fp FileProviderConfig, defaultTTL int) (*ProviderInstan
 		errs = append(errs, "provider "+cfg.Name+": cannot set both exclude_domains and exclude_domains_regex")
 	}
 
-	// Provider-specific config
-	for k, v := range fp.Config {
-		// Normalize keys to uppercase for consistency with env var loading
-		cfg.ProviderConfig[strings.ToUpper(k)] = v
+	// Provider-specific config; provider_config is an alias for config
+	for _, m := range []map[string]string{fp.Config, fp.ProviderConfig} {
+		for k, v := range m {
+			// Normalize keys to uppercase for consistency with env var loading
+			cfg.ProviderConfig[strings.ToUpper(k)] = v
+		}
 	}
 
 	return cfg, errs
@@ -159,6 +219,9 @@ func convertFileSources(fileSources []FileSourceConfig) *SourceConfig {
 			}
 		}
 
+		loadSourceAPIConfig(inst)
+		loadSourceEntrypoints(inst)
+
 		cfg.Instances = append(cfg.Instances, inst)
 	}
 
@@ -225,6 +288,10 @@ func mergeGlobalConfig(base *GlobalConfig) (*GlobalConfig, []string) {
 		cfg.CleanupOnStop = parseBool(v, cfg.CleanupOnStop)
 	}
 
+	if v := getEnv("DNSWEAVER_RECONCILE_ON_STARTUP"); v != "" {
+		cfg.ReconcileOnStart = parseBool(v, cfg.ReconcileOnStart)
+	}
+
 	if v := getEnv("DNSWEAVER_OWNERSHIP_TRACKING"); v != "" {
 		cfg.OwnershipTracking = parseBool(v, cfg.OwnershipTracking)
 	}
@@ -257,13 +324,214 @@ func mergeGlobalConfig(base *GlobalConfig) (*GlobalConfig, []string) {
 		}
 	}
 
+	if v := getEnv("DNSWEAVER_HEALTH_DEEP_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.HealthDeepTimeout = d
+		} else {
+			errs = append(errs, "DNSWEAVER_HEALTH_DEEP_TIMEOUT: invalid duration")
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_PROVIDER_MAX_PENDING"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.ProviderMaxPending = d
+		} else {
+			errs = append(errs, "DNSWEAVER_PROVIDER_MAX_PENDING: invalid duration")
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_RECORD_CACHE_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.RecordCacheTTL = d
+		} else {
+			errs = append(errs, "DNSWEAVER_RECORD_CACHE_TTL: invalid duration")
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_RETRY_ATTEMPTS"); v != "" {
+		if n, err := parseIntEnv(v); err == nil && n >= 1 {
+			cfg.RetryAttempts = n
+		} else {
+			errs = append(errs, "DNSWEAVER_RETRY_ATTEMPTS: must be a positive integer")
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_RETRY_BACKOFF"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.RetryBackoff = d
+		} else {
+			errs = append(errs, "DNSWEAVER_RETRY_BACKOFF: invalid duration")
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_DRAIN_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.DrainTimeout = d
+		} else {
+			errs = append(errs, "DNSWEAVER_DRAIN_TIMEOUT: invalid duration")
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_MAX_HOSTNAMES_PER_RECONCILE"); v != "" {
+		if n, err := parseIntEnv(v); err == nil && n >= 0 {
+			cfg.MaxHostnamesPerReconcile = n
+		} else {
+			errs = append(errs, "DNSWEAVER_MAX_HOSTNAMES_PER_RECONCILE: must be a non-negative integer")
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_DOCKER_LABEL_FILTER"); v != "" {
+		cfg.DockerLabelFilter = splitPatterns(v)
+	}
+
+	if v := getEnv("DNSWEAVER_IGNORE_HOSTNAMES"); v != "" {
+		cfg.IgnoreHostnames = splitPatterns(v)
+	}
+
+	if v := getEnv("DNSWEAVER_DOMAINS_ALLOWLIST"); v != "" {
+		cfg.DomainsAllowlist = splitPatterns(v)
+	}
+
+	if v := getEnv("DNSWEAVER_SOURCE_PRIORITIES"); v != "" {
+		if priorities, err := parseSourcePriorities(v); err == nil {
+			cfg.SourcePriorities = priorities
+		} else {
+			errs = append(errs, fmt.Sprintf("DNSWEAVER_SOURCE_PRIORITIES: %v", err))
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_PROVIDER_FALLBACK"); v != "" {
+		cfg.ProviderFallback = parseBool(v, cfg.ProviderFallback)
+	}
+
+	if v := getEnv("DNSWEAVER_RECONCILE_BACKOFF_THRESHOLD"); v != "" {
+		if n, err := parseIntEnv(v); err == nil && n >= 0 {
+			cfg.BackoffThreshold = n
+		} else {
+			errs = append(errs, "DNSWEAVER_RECONCILE_BACKOFF_THRESHOLD: must be a non-negative integer")
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_RECONCILE_BACKOFF"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.BackoffInitial = d
+		} else {
+			errs = append(errs, "DNSWEAVER_RECONCILE_BACKOFF: invalid duration")
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_RECONCILE_BACKOFF_MAX"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.BackoffMax = d
+		} else {
+			errs = append(errs, "DNSWEAVER_RECONCILE_BACKOFF_MAX: invalid duration")
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_AUDIT_LOG"); v != "" {
+		cfg.AuditLog = v
+	}
+
+	if v := getEnvOrFile("DNSWEAVER_NOTIFY_SLACK_WEBHOOK", "DNSWEAVER_NOTIFY_SLACK_WEBHOOK_FILE"); v != "" {
+		cfg.NotifySlackWebhook = v
+	}
+
+	if v := getEnvOrFile("DNSWEAVER_NOTIFY_DISCORD_WEBHOOK", "DNSWEAVER_NOTIFY_DISCORD_WEBHOOK_FILE"); v != "" {
+		cfg.NotifyDiscordWebhook = v
+	}
+
+	if v := getEnv("DNSWEAVER_NOTIFY_MIN_ACTIONS"); v != "" {
+		if n, err := parseIntEnv(v); err == nil && n >= 1 {
+			cfg.NotifyMinActions = n
+		} else {
+			errs = append(errs, "DNSWEAVER_NOTIFY_MIN_ACTIONS: must be a positive integer")
+		}
+	}
+
+	if v := getEnvOrFile("DNSWEAVER_CHANGE_WEBHOOK_URL", "DNSWEAVER_CHANGE_WEBHOOK_URL_FILE"); v != "" {
+		cfg.ChangeWebhookURL = v
+	}
+
+	if v := getEnv("DNSWEAVER_OPA_URL"); v != "" {
+		cfg.OPAURL = v
+	}
+
+	if v := getEnv("DNSWEAVER_OPA_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.OPATimeout = d
+		} else {
+			errs = append(errs, "DNSWEAVER_OPA_TIMEOUT: invalid duration")
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_OPA_FAIL_OPEN"); v != "" {
+		cfg.OPAFailOpen = parseBool(v, cfg.OPAFailOpen)
+	}
+
+	if v := getEnv("DNSWEAVER_METRICS_PUSHGATEWAY_URL"); v != "" {
+		cfg.MetricsPushGatewayURL = v
+	}
+
+	if v := getEnv("DNSWEAVER_METRICS_PUSH_INTERVAL"); v != "" {
+		if interval, err := time.ParseDuration(v); err == nil && interval >= 0 {
+			cfg.MetricsPushInterval = interval
+		} else {
+			errs = append(errs, "DNSWEAVER_METRICS_PUSH_INTERVAL: invalid duration")
+		}
+	}
+
 	if v := getEnv("DNSWEAVER_SOURCE"); v != "" {
 		cfg.Source = v
 	}
 
+	if v := getEnv("DNSWEAVER_API_ENABLED"); v != "" {
+		cfg.APIEnabled = parseBool(v, cfg.APIEnabled)
+	}
+
+	if v := getEnv("DNSWEAVER_API_PORT"); v != "" {
+		if port, err := parseIntEnv(v); err == nil && port >= 1 && port <= 65535 {
+			cfg.APIPort = port
+		} else {
+			errs = append(errs, "DNSWEAVER_API_PORT: invalid port number")
+		}
+	}
+
+	if v := getEnvOrFile("DNSWEAVER_API_TOKEN", "DNSWEAVER_API_TOKEN_FILE"); v != "" {
+		cfg.APIToken = v
+	}
+
 	return &cfg, errs
 }
 
+// LoadLogLevel re-resolves the log level without loading the rest of the
+// configuration. The config file's logging.level is used when DNSWEAVER_CONFIG
+// is set, and DNSWEAVER_LOG_LEVEL overrides it. Used to change verbosity at
+// runtime on SIGHUP.
+func LoadLogLevel() (string, error) {
+	level := DefaultLogLevel
+
+	if path := GetConfigFilePath(); path != "" {
+		fileCfg, err := LoadFile(path)
+		if err != nil {
+			return "", err
+		}
+		if fileCfg.Logging != nil && fileCfg.Logging.Level != "" {
+			level = strings.ToLower(fileCfg.Logging.Level)
+		}
+	}
+
+	if v := getEnv("DNSWEAVER_LOG_LEVEL"); v != "" {
+		level = strings.ToLower(v)
+	}
+
+	switch level {
+	case "debug", "info", "warn", "error":
+		return level, nil
+	default:
+		return "", fmt.Errorf("invalid log level %q (must be debug, info, warn, or error)", level)
+	}
+}
+
 // parseIntEnv parses an integer from string using strconv.
 func parseIntEnv(s string) (int, error) {
 	if s == "" {
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
@@ -291,3 +293,74 @@ func TestConvertFileSourcesWithFileDiscovery(t *testing.T) {
 		t.Errorf("WatchMethod = %q, want %q", fd.WatchMethod, "inotify")
 	}
 }
+
+func TestLoadLogLevel(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yml")
+	if err := os.WriteFile(path, []byte("logging:\n  level: DEBUG\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  string
+		env     string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", want: DefaultLogLevel},
+		{name: "from file", config: path, want: "debug"},
+		{name: "env overrides file", config: path, env: "warn", want: "warn"},
+		{name: "invalid env", env: "loud", wantErr: true},
+		{name: "missing file", config: filepath.Join(dir, "missing.yml"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DNSWEAVER_CONFIG", tt.config)
+			t.Setenv("DNSWEAVER_LOG_LEVEL", tt.env)
+
+			got, err := LoadLogLevel()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadLogLevel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LoadLogLevel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFromFile_ProviderConfigAlias(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	yml := `providers:
+  - name: internal
+    type: technitium
+    domains: ["*.example.com"]
+    target: 10.0.0.1
+    config:
+      url: http://dns:5380
+      token: from-config
+    provider_config:
+      token: from-provider-config
+      zone: example.com
+`
+	if err := os.WriteFile(path, []byte(yml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, providers, _, errs := loadFromFile(path)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(providers) != 1 {
+		t.Fatalf("expected 1 provider, got %d", len(providers))
+	}
+
+	want := map[string]string{"URL": "http://dns:5380", "TOKEN": "from-provider-config", "ZONE": "example.com"}
+	for k, v := range want {
+		if got := providers[0].ProviderConfig[k]; got != v {
+			t.Errorf("ProviderConfig[%s] = %q, want %q", k, got, v)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package config
+
+import "reflect"
+
+// ConfigDiff describes how the provider instances of two configurations differ.
+type ConfigDiff struct {
+	// AddedProviders are instances present only in the new configuration.
+	AddedProviders []*ProviderInstanceConfig
+
+	// RemovedProviders are the names of instances present only in the old configuration.
+	RemovedProviders []string
+
+	// ChangedProviders are instances present in both configurations whose
+	// settings differ. They hold the new settings.
+	ChangedProviders []*ProviderInstanceConfig
+}
+
+// Empty returns true if no provider instance was added, removed, or changed.
+func (d ConfigDiff) Empty() bool {
+	return len(d.AddedProviders) == 0 && len(d.RemovedProviders) == 0 && len(d.ChangedProviders) == 0
+}
+
+// Reload re-reads the config file and environment variables and returns the
+// new configuration together with its provider differences from current.
+// On error current remains in effect and the returned Config is nil.
+//
+// Only provider instances are compared; callers decide which other settings
+// can be applied without a restart.
+func Reload(current *Config) (*Config, ConfigDiff, error) {
+	next, err := Load()
+	if err != nil {
+		return nil, ConfigDiff{}, err
+	}
+	return next, Diff(current, next), nil
+}
+
+// Diff compares the provider instances of old and new.
+// Results follow the instance order of the configuration they come from.
+func Diff(old, new *Config) ConfigDiff {
+	var diff ConfigDiff
+
+	oldByName := make(map[string]*ProviderInstanceConfig, len(old.ProviderInstances))
+	for _, inst := range old.ProviderInstances {
+		oldByName[inst.Name] = inst
+	}
+
+	newNames := make(map[string]struct{}, len(new.ProviderInstances))
+	for _, inst := range new.ProviderInstances {
+		newNames[inst.Name] = struct{}{}
+		prev, ok := oldByName[inst.Name]
+		switch {
+		case !ok:
+			diff.AddedProviders = append(diff.AddedProviders, inst)
+		case !reflect.DeepEqual(prev, inst):
+			diff.ChangedProviders = append(diff.ChangedProviders, inst)
+		}
+	}
+
+	for _, inst := range old.ProviderInstances {
+		if _, ok := newNames[inst.Name]; !ok {
+			diff.RemovedProviders = append(diff.RemovedProviders, inst.Name)
+		}
+	}
+
+	return diff
+}
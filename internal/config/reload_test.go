@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestReload(t *testing.T) {
+	clearAllEnv(t)
+	defer clearAllEnv(t)
+
+	os.Setenv("DNSWEAVER_INSTANCES", "internal-dns,public-dns")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_TYPE", "technitium")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_TARGET", "10.0.0.100")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_DOMAINS", "*.example.com")
+	os.Setenv("DNSWEAVER_PUBLIC_DNS_TYPE", "technitium")
+	os.Setenv("DNSWEAVER_PUBLIC_DNS_TARGET", "203.0.113.1")
+	os.Setenv("DNSWEAVER_PUBLIC_DNS_DOMAINS", "*.example.org")
+
+	current, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	// Unchanged configuration produces an empty diff
+	_, diff, err := Reload(current)
+	if err != nil {
+		t.Fatalf("Reload() returned error: %v", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("Reload() diff = %+v, want empty", diff)
+	}
+
+	// Drop public-dns, change internal-dns, add lab-dns
+	os.Setenv("DNSWEAVER_INSTANCES", "internal-dns,lab-dns")
+	os.Setenv("DNSWEAVER_INTERNAL_DNS_TARGET", "10.0.0.200")
+	os.Setenv("DNSWEAVER_LAB_DNS_TYPE", "technitium")
+	os.Setenv("DNSWEAVER_LAB_DNS_TARGET", "10.1.0.1")
+	os.Setenv("DNSWEAVER_LAB_DNS_DOMAINS", "*.lab.example.com")
+
+	next, diff, err := Reload(current)
+	if err != nil {
+		t.Fatalf("Reload() returned error: %v", err)
+	}
+	if len(next.ProviderInstances) != 2 {
+		t.Errorf("new config has %d providers, want 2", len(next.ProviderInstances))
+	}
+	if len(diff.AddedProviders) != 1 || diff.AddedProviders[0].Name != "lab-dns" {
+		t.Errorf("AddedProviders = %+v, want [lab-dns]", diff.AddedProviders)
+	}
+	if len(diff.RemovedProviders) != 1 || diff.RemovedProviders[0] != "public-dns" {
+		t.Errorf("RemovedProviders = %v, want [public-dns]", diff.RemovedProviders)
+	}
+	if len(diff.ChangedProviders) != 1 || diff.ChangedProviders[0].Target != "10.0.0.200" {
+		t.Errorf("ChangedProviders = %+v, want [internal-dns with new target]", diff.ChangedProviders)
+	}
+
+	// Invalid configuration leaves the caller's config in effect
+	os.Unsetenv("DNSWEAVER_LAB_DNS_TARGET")
+	if next, _, err := Reload(current); err == nil || next != nil {
+		t.Errorf("Reload() with invalid config = (%v, %v), want (nil, error)", next, err)
+	}
+}
@@ -2,6 +2,8 @@
 package config
 
 import (
+	"fmt"
+	"log/slog"
 	"os"
 	"strings"
 )
@@ -11,25 +13,45 @@ func getEnv(key string) string {
 	return os.Getenv(key)
 }
 
-// getEnvOrFile retrieves a value from either a direct environment variable
+// readSecretOrFile retrieves a value from either a direct environment variable
 // or a file path specified by the file key (Docker secrets pattern).
 //
-// If both are set, the file takes precedence. This allows local development
-// with direct values while production uses Docker secrets.
-//
-// The file contents are trimmed of leading/trailing whitespace.
-func getEnvOrFile(directKey, fileKey string) string {
-	// Check for file-based secret first (Docker secrets pattern)
-	if filePath := os.Getenv(fileKey); filePath != "" {
-		content, err := os.ReadFile(filePath)
-		if err == nil {
-			return strings.TrimSpace(string(content))
-		}
-		// If file read fails, fall through to direct value
-		// This could be logged as a warning in the future
+// If both are set, the file takes precedence and a warning is logged. The file
+// contents are trimmed of leading/trailing whitespace. An error is returned if
+// the file key is set but the file cannot be read.
+func readSecretOrFile(key, fileKey string) (string, error) {
+	filePath := os.Getenv(fileKey)
+	if filePath == "" {
+		return os.Getenv(key), nil
 	}
 
-	return os.Getenv(directKey)
+	if os.Getenv(key) != "" {
+		slog.Warn("both value and _FILE variant are set, using file",
+			slog.String("key", key),
+			slog.String("file_key", fileKey),
+		)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", fileKey, err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// getEnvOrFile is like readSecretOrFile, but falls back to the direct value
+// when the file cannot be read. Used for global settings that don't report
+// configuration errors.
+func getEnvOrFile(directKey, fileKey string) string {
+	value, err := readSecretOrFile(directKey, fileKey)
+	if err != nil {
+		slog.Warn("failed to read secret file, using direct value",
+			slog.String("key", directKey),
+			slog.String("error", err.Error()),
+		)
+		return os.Getenv(directKey)
+	}
+	return value
 }
 
 // getEnvWithFileFallback retrieves a value supporting the _FILE suffix pattern.
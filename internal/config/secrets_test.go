@@ -96,6 +96,32 @@ func TestGetEnvOrFile_NonexistentFile(t *testing.T) {
 	}
 }
 
+func TestReadSecretOrFile(t *testing.T) {
+	const key = "TEST_DNSWEAVER_SECRET"
+	const fileKey = "TEST_DNSWEAVER_SECRET_FILE"
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("  file-value\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(key, "direct-value")
+	t.Setenv(fileKey, "")
+	if got, err := readSecretOrFile(key, fileKey); err != nil || got != "direct-value" {
+		t.Errorf("readSecretOrFile() = %q, %v; want direct-value", got, err)
+	}
+
+	t.Setenv(fileKey, secretFile)
+	if got, err := readSecretOrFile(key, fileKey); err != nil || got != "file-value" {
+		t.Errorf("readSecretOrFile() = %q, %v; want file-value (file takes precedence)", got, err)
+	}
+
+	t.Setenv(fileKey, "/nonexistent/path/to/secret")
+	if _, err := readSecretOrFile(key, fileKey); err == nil {
+		t.Error("expected error for unreadable file")
+	}
+}
+
 func TestParseBool(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -16,6 +16,18 @@ type SourceInstanceConfig struct {
 	// FileDiscovery contains file-based discovery configuration.
 	// Presence of FilePaths implies enablement (per design in #22).
 	FileDiscovery source.FileDiscoveryConfig
+
+	// APIEndpoint is the base URL of the source's HTTP API (e.g., the Traefik
+	// dashboard API). Empty disables API discovery.
+	APIEndpoint string
+
+	// APIPollInterval is how often the API is polled. Zero uses the
+	// source-specific default.
+	APIPollInterval time.Duration
+
+	// Entrypoints limits label-defined routers to those bound to one of these
+	// entrypoints (Traefik only). Empty disables filtering.
+	Entrypoints []string
 }
 
 // SourceConfig holds all source configuration.
@@ -118,9 +130,48 @@ func loadSourceInstanceConfig(name string) *SourceInstanceConfig {
 		cfg.FileDiscovery.WatchMethod = strings.ToLower(method)
 	}
 
+	loadSourceAPIConfig(cfg)
+	loadSourceEntrypoints(cfg)
+
 	return cfg
 }
 
+// loadSourceAPIConfig applies API discovery settings from environment variables.
+//
+// Environment variable patterns:
+//
+//	DNSWEAVER_TRAEFIK_API_URL=http://traefik:8080
+//	DNSWEAVER_TRAEFIK_API_POLL_INTERVAL=30s
+func loadSourceAPIConfig(cfg *SourceInstanceConfig) {
+	prefix := "DNSWEAVER_" + strings.ToUpper(cfg.Name) + "_"
+
+	if apiURL := getEnv(prefix + "API_URL"); apiURL != "" {
+		cfg.APIEndpoint = apiURL
+	}
+
+	// API_POLL_INTERVAL - how often to poll the API (default: source-specific)
+	if intervalStr := getEnv(prefix + "API_POLL_INTERVAL"); intervalStr != "" {
+		if interval, err := time.ParseDuration(intervalStr); err == nil && interval >= time.Second {
+			cfg.APIPollInterval = interval
+		}
+		// Silently use default for invalid values (per config design)
+	}
+}
+
+// loadSourceEntrypoints applies the entrypoint filter from the environment.
+//
+// Environment variable pattern:
+//
+//	DNSWEAVER_TRAEFIK_ENTRYPOINTS=websecure,internal
+func loadSourceEntrypoints(cfg *SourceInstanceConfig) {
+	value := getEnv("DNSWEAVER_" + strings.ToUpper(cfg.Name) + "_ENTRYPOINTS")
+	for _, ep := range strings.Split(value, ",") {
+		if ep = strings.TrimSpace(ep); ep != "" {
+			cfg.Entrypoints = append(cfg.Entrypoints, ep)
+		}
+	}
+}
+
 // GetSourceInstance returns the configuration for a specific source by name.
 func (c *SourceConfig) GetSourceInstance(name string) *SourceInstanceConfig {
 	for _, inst := range c.Instances {
@@ -140,3 +191,13 @@ func (c *SourceConfig) HasFileDiscovery() bool {
 	}
 	return false
 }
+
+// HasAPIDiscovery returns true if any source has API discovery configured.
+func (c *SourceConfig) HasAPIDiscovery() bool {
+	for _, inst := range c.Instances {
+		if inst.APIEndpoint != "" {
+			return true
+		}
+	}
+	return false
+}
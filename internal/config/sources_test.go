@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -315,3 +316,84 @@ func TestSourceEnvPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadSourceInstanceConfig_API(t *testing.T) {
+	tests := []struct {
+		name         string
+		envVars      map[string]string
+		wantEndpoint string
+		wantPoll     time.Duration
+	}{
+		{
+			name:         "no API configured",
+			envVars:      map[string]string{},
+			wantEndpoint: "",
+			wantPoll:     0,
+		},
+		{
+			name: "API URL uses default interval",
+			envVars: map[string]string{
+				"DNSWEAVER_TRAEFIK_API_URL": "http://traefik:8080",
+			},
+			wantEndpoint: "http://traefik:8080",
+			wantPoll:     0,
+		},
+		{
+			name: "API poll interval custom",
+			envVars: map[string]string{
+				"DNSWEAVER_TRAEFIK_API_URL":           "http://traefik:8080",
+				"DNSWEAVER_TRAEFIK_API_POLL_INTERVAL": "45s",
+			},
+			wantEndpoint: "http://traefik:8080",
+			wantPoll:     45 * time.Second,
+		},
+		{
+			name: "invalid API poll interval ignored",
+			envVars: map[string]string{
+				"DNSWEAVER_TRAEFIK_API_URL":           "http://traefik:8080",
+				"DNSWEAVER_TRAEFIK_API_POLL_INTERVAL": "soon",
+			},
+			wantEndpoint: "http://traefik:8080",
+			wantPoll:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
+			for k, v := range tt.envVars {
+				os.Setenv(k, v)
+			}
+
+			cfg := loadSourceInstanceConfig("traefik")
+
+			if cfg.APIEndpoint != tt.wantEndpoint {
+				t.Errorf("APIEndpoint = %q, want %q", cfg.APIEndpoint, tt.wantEndpoint)
+			}
+			if cfg.APIPollInterval != tt.wantPoll {
+				t.Errorf("APIPollInterval = %v, want %v", cfg.APIPollInterval, tt.wantPoll)
+			}
+
+			sc := &SourceConfig{Instances: []*SourceInstanceConfig{cfg}}
+			if got := sc.HasAPIDiscovery(); got != (tt.wantEndpoint != "") {
+				t.Errorf("HasAPIDiscovery() = %v", got)
+			}
+		})
+	}
+}
+
+func TestLoadSourceInstanceConfig_Entrypoints(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+
+	if cfg := loadSourceInstanceConfig("traefik"); cfg.Entrypoints != nil {
+		t.Errorf("Entrypoints = %v, want nil", cfg.Entrypoints)
+	}
+
+	os.Setenv("DNSWEAVER_TRAEFIK_ENTRYPOINTS", "websecure, internal,")
+	cfg := loadSourceInstanceConfig("traefik")
+	want := []string{"websecure", "internal"}
+	if !reflect.DeepEqual(cfg.Entrypoints, want) {
+		t.Errorf("Entrypoints = %v, want %v", cfg.Entrypoints, want)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"strings"
 
+	"gitlab.bluewillows.net/root/dnsweaver/internal/matcher"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 )
 
@@ -39,6 +40,37 @@ func validateConfig(cfg *Config) []string {
 		errs = append(errs, validateTargetRecordType(inst)...)
 	}
 
+	// Validate global hostname filters with the provider domain glob syntax
+	if cfg.Global != nil {
+		errs = append(errs, validateHostnamePatterns("DNSWEAVER_DOMAINS_ALLOWLIST", cfg.Global.DomainsAllowlist)...)
+		errs = append(errs, validateHostnamePatterns("DNSWEAVER_IGNORE_HOSTNAMES", cfg.Global.IgnoreHostnames)...)
+		errs = append(errs, validateLabelFilters("DNSWEAVER_DOCKER_LABEL_FILTER", cfg.Global.DockerLabelFilter)...)
+	}
+
+	return errs
+}
+
+// validateLabelFilters checks that each Docker label filter names a label.
+func validateLabelFilters(setting string, filters []string) []string {
+	var errs []string
+	for _, f := range filters {
+		key, _, _ := strings.Cut(f, "=")
+		if strings.TrimSpace(key) == "" {
+			errs = append(errs, fmt.Sprintf("%s: invalid filter %q: label name is required", setting, f))
+		}
+	}
+	return errs
+}
+
+// validateHostnamePatterns checks that each glob pattern compiles as a
+// provider domain pattern would.
+func validateHostnamePatterns(setting string, patterns []string) []string {
+	var errs []string
+	for _, p := range patterns {
+		if _, err := matcher.NewDomainMatcher(matcher.DomainMatcherConfig{Includes: []string{p}}); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: invalid pattern %q: %v", setting, p, err))
+		}
+	}
 	return errs
 }
 
@@ -47,30 +79,53 @@ func validateTargetRecordType(inst *ProviderInstanceConfig) []string {
 	var errs []string
 	prefix := envPrefix(inst.Name)
 
-	switch inst.RecordType {
-	case provider.RecordTypeA:
-		// A records must have an IP address as target
-		if net.ParseIP(inst.Target) == nil {
-			errs = append(errs, fmt.Sprintf("%sTARGET: A records must point to an IP address, got %q", prefix, inst.Target))
-		}
-	case provider.RecordTypeAAAA:
-		// AAAA records must have an IPv6 address as target
-		ip := net.ParseIP(inst.Target)
-		if ip == nil || ip.To4() != nil {
-			errs = append(errs, fmt.Sprintf("%sTARGET: AAAA records must point to an IPv6 address, got %q", prefix, inst.Target))
-		}
-	case provider.RecordTypeCNAME:
-		// CNAME records must have a hostname, not an IP
-		if net.ParseIP(inst.Target) != nil {
-			errs = append(errs, fmt.Sprintf("%sTARGET: CNAME records cannot point to IP addresses, got %q", prefix, inst.Target))
+	targets := inst.Targets
+	if len(targets) == 0 {
+		targets = []string{inst.Target}
+	}
+
+	if len(targets) > 1 && inst.RecordType == provider.RecordTypeCNAME {
+		errs = append(errs, fmt.Sprintf("%sTARGETS: CNAME records cannot have multiple targets", prefix))
+	}
+
+	for _, target := range targets {
+		switch inst.RecordType {
+		case provider.RecordTypeA:
+			// A records must have an IP address as target
+			if net.ParseIP(target) == nil {
+				errs = append(errs, fmt.Sprintf("%sTARGET: A records must point to an IP address, got %q", prefix, target))
+			}
+		case provider.RecordTypeAAAA:
+			// AAAA records must have an IPv6 address as target
+			ip := net.ParseIP(target)
+			if ip == nil || ip.To4() != nil {
+				errs = append(errs, fmt.Sprintf("%sTARGET: AAAA records must point to an IPv6 address, got %q", prefix, target))
+			}
+		case provider.RecordTypeCNAME:
+			// CNAME records must have a hostname, not an IP
+			if net.ParseIP(target) != nil {
+				errs = append(errs, fmt.Sprintf("%sTARGET: CNAME records cannot point to IP addresses, got %q", prefix, target))
+			}
+		case provider.RecordTypeTXT, provider.RecordTypeSRV:
+			// TXT and SRV records have flexible targets, no validation needed
 		}
-	case provider.RecordTypeTXT, provider.RecordTypeSRV:
-		// TXT and SRV records have flexible targets, no validation needed
 	}
 
 	return errs
 }
 
+// ValidateProviderTypes checks every provider instance's type against the
+// registered provider types. Returns one error message per unknown type.
+func (c *Config) ValidateProviderTypes(knownTypes []string) []string {
+	var errs []string
+	for _, inst := range c.ProviderInstances {
+		if err := validateProviderType(inst.TypeName, knownTypes); err != nil {
+			errs = append(errs, fmt.Sprintf("%sTYPE: %s", envPrefix(inst.Name), err))
+		}
+	}
+	return errs
+}
+
 // validateProviderType checks that the provider type is known.
 // This is called later when registering providers, not during config load.
 func validateProviderType(typeName string, knownTypes []string) error {
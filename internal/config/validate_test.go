@@ -117,6 +117,33 @@ func TestValidateConfig_DuplicateProviderNames(t *testing.T) {
 	}
 }
 
+func TestValidateConfig_HostnamePatterns(t *testing.T) {
+	cfg := &Config{
+		Global: &GlobalConfig{
+			DomainsAllowlist: []string{"*.internal.example.com", "[z-a].example.com"},
+			IgnoreHostnames:  []string{"admin.*"},
+		},
+	}
+
+	errs := validateConfig(cfg)
+	if len(errs) != 1 || !containsSubstring(errs[0], "DNSWEAVER_DOMAINS_ALLOWLIST") {
+		t.Errorf("validateConfig() = %v, want one DNSWEAVER_DOMAINS_ALLOWLIST error", errs)
+	}
+}
+
+func TestValidateConfig_DockerLabelFilter(t *testing.T) {
+	cfg := &Config{
+		Global: &GlobalConfig{
+			DockerLabelFilter: []string{"traefik.enable=true", "dnsweaver.managed", "=true"},
+		},
+	}
+
+	errs := validateConfig(cfg)
+	if len(errs) != 1 || !containsSubstring(errs[0], "DNSWEAVER_DOCKER_LABEL_FILTER") {
+		t.Errorf("validateConfig() = %v, want one DNSWEAVER_DOCKER_LABEL_FILTER error", errs)
+	}
+}
+
 func TestValidationError_SingleError(t *testing.T) {
 	err := &ValidationError{Errors: []string{"single error message"}}
 	got := err.Error()
@@ -171,3 +198,20 @@ func TestValidateProviderType(t *testing.T) {
 		}
 	}
 }
+
+func TestConfig_ValidateProviderTypes(t *testing.T) {
+	cfg := &Config{
+		ProviderInstances: []*ProviderInstanceConfig{
+			{Name: "internal", TypeName: "technitium"},
+			{Name: "cloud", TypeName: "route53"},
+		},
+	}
+
+	errs := cfg.ValidateProviderTypes([]string{"technitium", "cloudflare"})
+	if len(errs) != 1 {
+		t.Fatalf("ValidateProviderTypes() = %v, want 1 error", errs)
+	}
+	if !containsSubstring(errs[0], "DNSWEAVER_CLOUD_TYPE") {
+		t.Errorf("error should name the variable, got %q", errs[0])
+	}
+}
@@ -74,7 +74,8 @@ type Client struct {
 	detectedMode  Mode
 	logger        *slog.Logger
 	host          string
-	cleanupOnStop bool // If true, only list running containers; if false, include stopped
+	cleanupOnStop bool     // If true, only list running containers; if false, include stopped
+	labelFilters  []string // Docker label filters applied to list calls
 }
 
 // NewClient creates a new Docker client with the given options.
@@ -231,7 +232,9 @@ func (c *Client) ListServices(ctx context.Context) ([]Service, error) {
 		return nil, ErrNotSwarmMode
 	}
 
-	services, err := c.docker.ServiceList(ctx, swarm.ServiceListOptions{})
+	services, err := c.docker.ServiceList(ctx, swarm.ServiceListOptions{
+		Filters: c.addLabelFilters(filters.NewArgs()),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("listing services: %w", err)
 	}
@@ -247,6 +250,7 @@ func (c *Client) ListServices(ctx context.Context) ([]Service, error) {
 
 	c.logger.Debug("listed swarm services",
 		slog.Int("count", len(result)),
+		slog.Any("label_filters", c.labelFilters),
 	)
 
 	return result, nil
@@ -279,6 +283,7 @@ func (c *Client) ListContainers(ctx context.Context) ([]Container, error) {
 			filters.Arg("status", "created"),
 		)
 	}
+	c.addLabelFilters(listOpts.Filters)
 
 	containers, err := c.docker.ContainerList(ctx, listOpts)
 	if err != nil {
@@ -299,11 +304,25 @@ func (c *Client) ListContainers(ctx context.Context) ([]Container, error) {
 	c.logger.Debug("listed containers",
 		slog.Int("count", len(result)),
 		slog.Bool("include_stopped", !c.cleanupOnStop),
+		slog.Any("label_filters", c.labelFilters),
 	)
 
 	return result, nil
 }
 
+// LabelFilters returns the Docker label filters applied to list calls.
+func (c *Client) LabelFilters() []string {
+	return c.labelFilters
+}
+
+// addLabelFilters adds the configured label filters to args and returns it.
+func (c *Client) addLabelFilters(args filters.Args) filters.Args {
+	for _, label := range c.labelFilters {
+		args.Add("label", label)
+	}
+	return args
+}
+
 // normalizeContainerName extracts a clean container name from Docker's name list.
 // Container names from Docker start with "/" which we strip.
 func normalizeContainerName(names []string) string {
@@ -7,6 +7,8 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/docker/docker/api/types/filters"
 )
 
 // TestModeConstants verifies mode constants are correctly defined.
@@ -179,6 +181,31 @@ func TestWithHost(t *testing.T) {
 	}
 }
 
+// TestWithLabelFilter verifies label filters are copied and added to list filters.
+func TestWithLabelFilter(t *testing.T) {
+	labels := []string{"traefik.enable=true", "dnsweaver.managed"}
+	c := &Client{}
+	WithLabelFilter(labels...)(c)
+	labels[0] = "changed"
+
+	if got := c.LabelFilters(); len(got) != 2 || got[0] != "traefik.enable=true" {
+		t.Fatalf("LabelFilters() = %v, want copy of the configured filters", got)
+	}
+
+	args := c.addLabelFilters(filters.NewArgs(filters.Arg("status", "running")))
+	if got := args.Get("label"); len(got) != 2 {
+		t.Errorf("label filters = %v, want 2", got)
+	}
+	if !args.ExactMatch("status", "running") {
+		t.Error("existing status filter was lost")
+	}
+
+	empty := (&Client{}).addLabelFilters(filters.NewArgs())
+	if empty.Len() != 0 {
+		t.Errorf("filters without labels = %d keys, want 0", empty.Len())
+	}
+}
+
 // TestListServices_WrongMode tests that ListServices fails in standalone mode.
 func TestListServices_WrongMode(t *testing.T) {
 	c := &Client{
@@ -1,6 +1,9 @@
 package docker
 
-import "log/slog"
+import (
+	"log/slog"
+	"slices"
+)
 
 // Option is a functional option for configuring the Client.
 type Option func(*Client)
@@ -57,3 +60,15 @@ func WithCleanupOnStop(cleanup bool) Option {
 		c.cleanupOnStop = cleanup
 	}
 }
+
+// WithLabelFilter restricts listed containers and services to those matching
+// every filter. Filters use Docker's label filter syntax: "key" requires the
+// label to be present, "key=value" requires an exact value.
+//
+// Workloads excluded by the filter are never seen by sources, so their DNS
+// records are treated as orphans. No filter (default) lists all workloads.
+func WithLabelFilter(labels ...string) Option {
+	return func(c *Client) {
+		c.labelFilters = slices.Clone(labels)
+	}
+}
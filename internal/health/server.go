@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -20,6 +21,15 @@ const (
 	StatusNotReady = "not_ready"
 )
 
+// Deep check status values.
+const (
+	DeepStatusOK    = "ok"
+	DeepStatusError = "error"
+)
+
+// DefaultDeepTimeout is the default timeout for /health/deep probes.
+const DefaultDeepTimeout = 5 * time.Second
+
 // HealthChecker is a function that checks the health of a component.
 // Returns an error if the component is unhealthy.
 type HealthChecker func(ctx context.Context) error
@@ -42,11 +52,47 @@ type DegradedStatus struct {
 	Message string `json:"message"`
 }
 
+// ProviderStatus represents the status of a DNS provider instance.
+type ProviderStatus struct {
+	Name              string     `json:"name"`
+	Type              string     `json:"type"`
+	CircuitState      string     `json:"circuit_state"`
+	Healthy           bool       `json:"healthy"`
+	LastSuccess       *time.Time `json:"last_success,omitempty"`
+	LastError         string     `json:"last_error,omitempty"`
+	ConsecutiveErrors int        `json:"consecutive_errors"`
+	AvgLatencyMs      float64    `json:"avg_latency_ms"`
+
+	// When the provider last created, deleted, and listed records without
+	// error; omitted until it first has.
+	LastSuccessfulCreateAt *time.Time `json:"last_successful_create_at,omitempty"`
+	LastSuccessfulDeleteAt *time.Time `json:"last_successful_delete_at,omitempty"`
+	LastListAt             *time.Time `json:"last_list_at,omitempty"`
+}
+
+// ProviderStatusFunc returns the current status of each provider instance.
+type ProviderStatusFunc func() []ProviderStatus
+
+// DeepStatus is the result of probing one provider's backend.
+type DeepStatus struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// DeepResponse represents a /health/deep response.
+type DeepResponse struct {
+	Status    string       `json:"status"`
+	Providers []DeepStatus `json:"providers"`
+}
+
 // Response represents a health check response.
 type Response struct {
 	Status     string           `json:"status"`
 	Components []HealthStatus   `json:"components,omitempty"`
 	Degraded   []DegradedStatus `json:"degraded,omitempty"`
+	Providers  []ProviderStatus `json:"providers,omitempty"`
 }
 
 // Server provides /health, /ready, and /metrics endpoints.
@@ -57,9 +103,13 @@ type Server struct {
 	logger  *slog.Logger
 	timeout time.Duration
 
+	deepTimeout    time.Duration
+	providerStatus ProviderStatusFunc
+
 	mu               sync.RWMutex
 	checkers         map[string]HealthChecker
 	degradedCheckers map[string]DegradedChecker
+	deepCheckers     map[string]HealthChecker
 }
 
 // Option is a functional option for configuring the Server.
@@ -79,6 +129,23 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithDeepTimeout sets the timeout for /health/deep probes.
+func WithDeepTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		if timeout > 0 {
+			s.deepTimeout = timeout
+		}
+	}
+}
+
+// WithProviderStatus reports provider status, including circuit breaker
+// state, in the /health response.
+func WithProviderStatus(fn ProviderStatusFunc) Option {
+	return func(s *Server) {
+		s.providerStatus = fn
+	}
+}
+
 // New creates a new health server on the specified port.
 func New(port int, opts ...Option) *Server {
 	s := &Server{
@@ -86,8 +153,10 @@ func New(port int, opts ...Option) *Server {
 		mux:              http.NewServeMux(),
 		logger:           slog.Default(),
 		timeout:          5 * time.Second,
+		deepTimeout:      DefaultDeepTimeout,
 		checkers:         make(map[string]HealthChecker),
 		degradedCheckers: make(map[string]DegradedChecker),
+		deepCheckers:     make(map[string]HealthChecker),
 	}
 
 	for _, opt := range opts {
@@ -106,6 +175,15 @@ func (s *Server) RegisterChecker(name string, checker HealthChecker) {
 	s.logger.Debug("registered health checker", slog.String("name", name))
 }
 
+// UnregisterChecker removes the /ready health checker with the given name.
+// Unknown names are ignored.
+func (s *Server) UnregisterChecker(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.checkers, name)
+	s.logger.Debug("unregistered health checker", slog.String("name", name))
+}
+
 // RegisterDegradedChecker adds a degraded state checker for the /ready endpoint.
 // Degraded checkers report when the system is functional but not fully healthy.
 func (s *Server) RegisterDegradedChecker(name string, checker DegradedChecker) {
@@ -115,8 +193,27 @@ func (s *Server) RegisterDegradedChecker(name string, checker DegradedChecker) {
 	s.logger.Debug("registered degraded checker", slog.String("name", name))
 }
 
+// RegisterDeepChecker adds a probe of a provider's backend for the
+// /health/deep endpoint.
+func (s *Server) RegisterDeepChecker(name string, checker HealthChecker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deepCheckers[name] = checker
+	s.logger.Debug("registered deep health checker", slog.String("name", name))
+}
+
+// UnregisterDeepChecker removes the /health/deep probe with the given name.
+// Unknown names are ignored.
+func (s *Server) UnregisterDeepChecker(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.deepCheckers, name)
+	s.logger.Debug("unregistered deep health checker", slog.String("name", name))
+}
+
 func (s *Server) setupRoutes() {
 	s.mux.HandleFunc("/health", s.handleHealth)
+	s.mux.HandleFunc("/health/deep", s.handleDeep)
 	s.mux.HandleFunc("/ready", s.handleReady)
 	s.mux.Handle("/metrics", promhttp.Handler())
 }
@@ -125,6 +222,89 @@ func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	resp := Response{Status: "healthy"}
+	if s.providerStatus != nil {
+		resp.Providers = s.providerStatus()
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleDeep probes every registered backend concurrently and reports each
+// result with its latency. Any failed probe makes the response a 503.
+func (s *Server) handleDeep(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	checkers := make(map[string]HealthChecker, len(s.deepCheckers))
+	for name, checker := range s.deepCheckers {
+		checkers[name] = checker
+	}
+	s.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.deepTimeout)
+	defer cancel()
+
+	// Probes report on a buffered channel so one that ignores ctx cannot
+	// hold the response past the timeout.
+	done := make(chan DeepStatus, len(checkers))
+	start := time.Now()
+	for name, checker := range checkers {
+		go func() {
+			status := DeepStatus{Name: name, Status: DeepStatusOK}
+			err := checker(ctx)
+			status.LatencyMS = time.Since(start).Milliseconds()
+			if err != nil {
+				status.Status = DeepStatusError
+				status.Error = err.Error()
+			}
+			done <- status
+		}()
+	}
+
+	results := make([]DeepStatus, 0, len(checkers))
+	pending := make(map[string]struct{}, len(checkers))
+	for name := range checkers {
+		pending[name] = struct{}{}
+	}
+collect:
+	for len(pending) > 0 {
+		select {
+		case status := <-done:
+			delete(pending, status.Name)
+			results = append(results, status)
+		case <-ctx.Done():
+			break collect
+		}
+	}
+	for name := range pending {
+		results = append(results, DeepStatus{
+			Name:      name,
+			Status:    DeepStatusError,
+			LatencyMS: time.Since(start).Milliseconds(),
+			Error:     ctx.Err().Error(),
+		})
+	}
+
+	for _, result := range results {
+		if result.Status != DeepStatusOK {
+			s.logger.Warn("deep health check failed",
+				slog.String("component", result.Name),
+				slog.String("error", result.Error),
+			)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	resp := DeepResponse{Status: DeepStatusOK, Providers: results}
+	code := http.StatusOK
+	for _, result := range results {
+		if result.Status != DeepStatusOK {
+			resp.Status = DeepStatusError
+			code = http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
@@ -32,6 +32,48 @@ func TestServer_handleHealth(t *testing.T) {
 	}
 }
 
+func TestServer_handleHealth_Providers(t *testing.T) {
+	s := New(0, WithProviderStatus(func() []ProviderStatus {
+		return []ProviderStatus{{
+			Name:              "internal-dns",
+			Type:              "technitium",
+			CircuitState:      "open",
+			LastError:         "connection refused",
+			ConsecutiveErrors: 3,
+			AvgLatencyMs:      12.5,
+		}}
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	w := httptest.NewRecorder()
+
+	s.handleHealth(w, req)
+
+	var body struct {
+		Providers []map[string]any `json:"providers"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(body.Providers) != 1 {
+		t.Fatalf("expected 1 provider, got %d", len(body.Providers))
+	}
+	p := body.Providers[0]
+	if p["circuit_state"] != "open" {
+		t.Errorf("expected circuit_state 'open', got %v", p["circuit_state"])
+	}
+	if p["healthy"] != false || p["last_error"] != "connection refused" {
+		t.Errorf("expected unhealthy provider with last_error, got %v", p)
+	}
+	if p["consecutive_errors"] != float64(3) || p["avg_latency_ms"] != 12.5 {
+		t.Errorf("expected consecutive_errors 3 and avg_latency_ms 12.5, got %v", p)
+	}
+	if _, ok := p["last_success"]; ok {
+		t.Errorf("expected last_success to be omitted, got %v", p["last_success"])
+	}
+}
+
 func TestServer_handleReady_NoCheckers(t *testing.T) {
 	s := New(0)
 
@@ -186,6 +228,32 @@ func TestServer_RegisterChecker(t *testing.T) {
 	}
 }
 
+func TestServer_UnregisterChecker(t *testing.T) {
+	s := New(0)
+
+	s.RegisterChecker("provider:removed", func(ctx context.Context) error { return errors.New("closed") })
+	s.RegisterChecker("provider:kept", func(ctx context.Context) error { return nil })
+	s.RegisterDeepChecker("removed", func(ctx context.Context) error { return errors.New("closed") })
+
+	s.UnregisterChecker("provider:removed")
+	s.UnregisterDeepChecker("removed")
+	s.UnregisterChecker("unknown")
+
+	if _, ok := s.checkers["provider:removed"]; ok || len(s.checkers) != 1 {
+		t.Errorf("checkers after unregister = %v, want only provider:kept", s.checkers)
+	}
+	if len(s.deepCheckers) != 0 {
+		t.Errorf("expected no deep checkers, got %d", len(s.deepCheckers))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+	w := httptest.NewRecorder()
+	s.handleReady(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200 after removing the failing checker, got %d", w.Code)
+	}
+}
+
 func TestServer_handleReady_Degraded(t *testing.T) {
 	s := New(0)
 
@@ -297,3 +365,75 @@ func TestServer_RegisterDegradedChecker(t *testing.T) {
 		t.Error("expected degraded checker 'test-degraded' to be registered")
 	}
 }
+
+func TestServer_handleDeep(t *testing.T) {
+	s := New(0)
+	s.RegisterDeepChecker("internal-dns", func(ctx context.Context) error { return nil })
+	s.RegisterDeepChecker("public-dns", func(ctx context.Context) error { return nil })
+
+	req := httptest.NewRequest(http.MethodGet, "/health/deep", nil)
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+
+	var resp DeepResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != DeepStatusOK {
+		t.Errorf("expected status %q, got %q", DeepStatusOK, resp.Status)
+	}
+	if len(resp.Providers) != 2 || resp.Providers[0].Name != "internal-dns" || resp.Providers[1].Status != DeepStatusOK {
+		t.Errorf("unexpected providers: %+v", resp.Providers)
+	}
+}
+
+func TestServer_handleDeep_PartialFailure(t *testing.T) {
+	s := New(0, WithDeepTimeout(50*time.Millisecond))
+	s.RegisterDeepChecker("ok", func(ctx context.Context) error { return nil })
+	s.RegisterDeepChecker("down", func(ctx context.Context) error { return errors.New("connection refused") })
+	// A probe that ignores its context must not hold the response
+	block := make(chan struct{})
+	defer close(block)
+	s.RegisterDeepChecker("stuck", func(ctx context.Context) error {
+		<-block
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/health/deep", nil)
+	w := httptest.NewRecorder()
+	start := time.Now()
+	s.handleDeep(w, req)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("handleDeep took %v, want it bounded by the deep timeout", elapsed)
+	}
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+
+	var resp DeepResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != DeepStatusError {
+		t.Errorf("expected status %q, got %q", DeepStatusError, resp.Status)
+	}
+
+	got := make(map[string]DeepStatus)
+	for _, p := range resp.Providers {
+		got[p.Name] = p
+	}
+	if got["ok"].Status != DeepStatusOK {
+		t.Errorf("ok provider = %+v, want ok", got["ok"])
+	}
+	if got["down"].Status != DeepStatusError || got["down"].Error != "connection refused" {
+		t.Errorf("down provider = %+v, want error", got["down"])
+	}
+	if got["stuck"].Status != DeepStatusError {
+		t.Errorf("stuck provider = %+v, want timeout error", got["stuck"])
+	}
+}
@@ -60,6 +60,29 @@ var (
 			Help:      "Number of hostnames discovered in the last reconciliation.",
 		},
 	)
+
+	// ReconcilerInflight tracks reconciliations currently running.
+	ReconcilerInflight = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "reconciler_inflight",
+			Help:      "Number of reconciliations currently in progress.",
+		},
+	)
+
+	// HostnameInfo exposes source annotation keys of hostnames discovered in
+	// the last full reconciliation, one series per annotation (value always 1).
+	// Annotation values are not exported to keep label cardinality bounded.
+	// Hostnames without annotations have a single series with an empty
+	// annotation label.
+	HostnameInfo = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "hostname_info",
+			Help:      "Hostnames discovered in the last reconciliation, labeled with their source annotation keys.",
+		},
+		[]string{"hostname", "source", "annotation"},
+	)
 )
 
 // Record operation metrics.
@@ -71,7 +94,7 @@ var (
 			Name:      "records_created_total",
 			Help:      "Total number of DNS records created.",
 		},
-		[]string{"provider"},
+		[]string{"provider", "record_type", "zone"}, // zone: provider's configured zone, "" if none
 	)
 
 	// RecordsDeletedTotal counts DNS records deleted.
@@ -81,7 +104,7 @@ var (
 			Name:      "records_deleted_total",
 			Help:      "Total number of DNS records deleted.",
 		},
-		[]string{"provider"},
+		[]string{"provider", "record_type", "zone"},
 	)
 
 	// RecordsSkippedTotal counts skipped record operations.
@@ -101,7 +124,17 @@ var (
 			Name:      "records_failed_total",
 			Help:      "Total number of failed record operations.",
 		},
-		[]string{"provider", "operation"}, // operation: "create", "delete"
+		[]string{"provider", "operation", "code", "record_type", "zone"}, // operation: "create", "delete"; code: provider error code
+	)
+
+	// RecordsDeniedTotal counts record operations denied by the admission policy.
+	RecordsDeniedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "records_denied_total",
+			Help:      "Total number of record operations denied by the admission policy.",
+		},
+		[]string{"provider", "action"}, // action: "create", "delete"
 	)
 )
 
@@ -128,6 +161,26 @@ var (
 		[]string{"provider", "operation"},
 	)
 
+	// ProviderRateLimitQueuedTotal counts provider operations delayed by the rate limiter.
+	ProviderRateLimitQueuedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "provider_ratelimit_queued_total",
+			Help:      "Total number of provider operations queued by the rate limiter.",
+		},
+		[]string{"provider", "operation"}, // operation: "list", "create", "delete", "update"
+	)
+
+	// ProviderCircuitOpen tracks provider circuit breaker state (1=open or half-open, 0=closed).
+	ProviderCircuitOpen = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "provider_circuit_open",
+			Help:      "Provider circuit breaker state (1=open or half-open, 0=closed).",
+		},
+		[]string{"provider"},
+	)
+
 	// ProviderHealthy tracks provider health status (1=healthy, 0=unhealthy).
 	ProviderHealthy = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -138,6 +191,17 @@ var (
 		[]string{"provider"},
 	)
 
+	// ProviderLastSuccessTimestamp records when each kind of provider
+	// operation last succeeded, as a Unix timestamp.
+	ProviderLastSuccessTimestamp = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "provider_last_success_timestamp_seconds",
+			Help:      "Unix time of the last successful provider operation.",
+		},
+		[]string{"provider", "operation"}, // operation: "create", "delete", "list"
+	)
+
 	// ProviderAvailable tracks provider availability status (1=ready, 0=pending initialization).
 	// This is different from ProviderHealthy which tracks connectivity to ready providers.
 	ProviderAvailable = promauto.NewGaugeVec(
@@ -59,18 +59,18 @@ func TestRecordMetrics(t *testing.T) {
 	RecordsFailedTotal.Reset()
 
 	// Simulate recording record operations
-	RecordsCreatedTotal.WithLabelValues("internal-dns").Add(5)
-	RecordsDeletedTotal.WithLabelValues("internal-dns").Add(2)
+	RecordsCreatedTotal.WithLabelValues("internal-dns", "A", "example.com").Add(5)
+	RecordsDeletedTotal.WithLabelValues("internal-dns", "A", "example.com").Add(2)
 	RecordsSkippedTotal.WithLabelValues("no_provider").Add(3)
-	RecordsFailedTotal.WithLabelValues("internal-dns", "create").Inc()
+	RecordsFailedTotal.WithLabelValues("internal-dns", "create", "auth", "A", "example.com").Inc()
 
 	// Verify counts
-	created := testutil.ToFloat64(RecordsCreatedTotal.WithLabelValues("internal-dns"))
+	created := testutil.ToFloat64(RecordsCreatedTotal.WithLabelValues("internal-dns", "A", "example.com"))
 	if created != 5 {
 		t.Errorf("expected 5 created, got %f", created)
 	}
 
-	deleted := testutil.ToFloat64(RecordsDeletedTotal.WithLabelValues("internal-dns"))
+	deleted := testutil.ToFloat64(RecordsDeletedTotal.WithLabelValues("internal-dns", "A", "example.com"))
 	if deleted != 2 {
 		t.Errorf("expected 2 deleted, got %f", deleted)
 	}
@@ -80,7 +80,7 @@ func TestRecordMetrics(t *testing.T) {
 		t.Errorf("expected 3 skipped, got %f", skipped)
 	}
 
-	failed := testutil.ToFloat64(RecordsFailedTotal.WithLabelValues("internal-dns", "create"))
+	failed := testutil.ToFloat64(RecordsFailedTotal.WithLabelValues("internal-dns", "create", "auth", "A", "example.com"))
 	if failed != 1 {
 		t.Errorf("expected 1 failed, got %f", failed)
 	}
@@ -135,6 +135,7 @@ func TestMetricNames(t *testing.T) {
 		RecordsDeletedTotal,
 		RecordsSkippedTotal,
 		RecordsFailedTotal,
+		RecordsDeniedTotal,
 		ProviderAPIRequestsTotal,
 		ProviderAPIDuration,
 		ProviderHealthy,
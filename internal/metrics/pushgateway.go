@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// PushJob is the job label attached to pushed metrics.
+const PushJob = "dnsweaver"
+
+// PushGateway pushes all registered metrics to a Prometheus Pushgateway.
+// It complements the pull-based /metrics endpoint for environments where
+// Prometheus cannot scrape dnsweaver directly.
+type PushGateway struct {
+	url      string
+	instance string
+	interval time.Duration
+	gatherer prometheus.Gatherer
+	logger   *slog.Logger
+
+	mu       sync.Mutex
+	lastPush time.Time
+}
+
+// PushGatewayOption is a functional option for configuring the PushGateway.
+type PushGatewayOption func(*PushGateway)
+
+// WithPushInterval sets the minimum interval between pushes.
+// Zero pushes after every reconciliation.
+func WithPushInterval(d time.Duration) PushGatewayOption {
+	return func(p *PushGateway) {
+		p.interval = d
+	}
+}
+
+// WithPushInstance sets the instance label. Defaults to the hostname.
+func WithPushInstance(instance string) PushGatewayOption {
+	return func(p *PushGateway) {
+		p.instance = instance
+	}
+}
+
+// WithPushGatherer sets the metrics source. Defaults to prometheus.DefaultGatherer.
+func WithPushGatherer(g prometheus.Gatherer) PushGatewayOption {
+	return func(p *PushGateway) {
+		p.gatherer = g
+	}
+}
+
+// WithPushLogger sets a custom logger.
+func WithPushLogger(logger *slog.Logger) PushGatewayOption {
+	return func(p *PushGateway) {
+		p.logger = logger
+	}
+}
+
+// NewPushGateway creates a pusher for the Pushgateway at url.
+func NewPushGateway(url string, opts ...PushGatewayOption) *PushGateway {
+	p := &PushGateway{
+		url:      url,
+		gatherer: prometheus.DefaultGatherer,
+		logger:   slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.instance == "" {
+		if hostname, err := os.Hostname(); err == nil {
+			p.instance = hostname
+		} else {
+			p.instance = PushJob
+		}
+	}
+
+	return p
+}
+
+// Push sends the current metrics, replacing any previously pushed metrics
+// for this job and instance.
+func (p *PushGateway) Push(ctx context.Context) error {
+	err := push.New(p.url, PushJob).
+		Gatherer(p.gatherer).
+		Grouping("instance", p.instance).
+		PushContext(ctx)
+	if err != nil {
+		return fmt.Errorf("pushing metrics to %s: %w", p.url, err)
+	}
+
+	p.mu.Lock()
+	p.lastPush = time.Now()
+	p.mu.Unlock()
+	return nil
+}
+
+// PushIfDue pushes metrics if the push interval has elapsed since the last
+// successful push. Failures are logged as warnings and not returned, so a
+// Pushgateway outage never affects reconciliation.
+func (p *PushGateway) PushIfDue(ctx context.Context) {
+	p.mu.Lock()
+	due := p.lastPush.IsZero() || time.Since(p.lastPush) >= p.interval
+	p.mu.Unlock()
+	if !due {
+		return
+	}
+
+	if err := p.Push(ctx); err != nil {
+		p.logger.Warn("failed to push metrics",
+			slog.String("url", p.url),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	p.logger.Debug("pushed metrics", slog.String("url", p.url))
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func newTestRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Namespace: Namespace, Name: "push_test"})
+	gauge.Set(42)
+	reg.MustRegister(gauge)
+	return reg
+}
+
+func TestPushGateway_Push(t *testing.T) {
+	var gotPath, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		gotPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := NewPushGateway(server.URL,
+		WithPushGatherer(newTestRegistry()),
+		WithPushInstance("host-1"),
+	)
+	if err := p.Push(context.Background()); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	if gotPath != "/metrics/job/dnsweaver/instance/host-1" {
+		t.Errorf("path = %q, want job and instance grouping", gotPath)
+	}
+	if len(gotBody) == 0 {
+		t.Error("expected metrics in request body")
+	}
+}
+
+func TestPushGateway_PushIfDue(t *testing.T) {
+	var pushes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pushes.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := NewPushGateway(server.URL,
+		WithPushGatherer(newTestRegistry()),
+		WithPushInterval(time.Hour),
+	)
+
+	p.PushIfDue(context.Background())
+	p.PushIfDue(context.Background())
+
+	if got := pushes.Load(); got != 1 {
+		t.Errorf("pushes = %d, want 1 (second push within interval)", got)
+	}
+}
+
+func TestPushGateway_PushIfDue_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	p := NewPushGateway(server.URL, WithPushGatherer(newTestRegistry()))
+
+	if err := p.Push(context.Background()); err == nil {
+		t.Error("expected error from failing Pushgateway")
+	}
+
+	// PushIfDue must not panic or block on failure, and a failed push is retried next time
+	p.PushIfDue(context.Background())
+	if !p.lastPush.IsZero() {
+		t.Error("expected lastPush to remain unset after failures")
+	}
+}
@@ -0,0 +1,174 @@
+// Package notify sends reconciliation summaries to chat services.
+//
+// A Dispatcher fans a Summary out to any number of Notifiers (Slack,
+// Discord) and suppresses summaries with fewer record changes than its
+// threshold, so no-op reconciliations stay quiet.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// DefaultMinActions is the default number of record changes a summary needs
+// before it is sent.
+const DefaultMinActions = 1
+
+// maxErrorLines caps how many errors are listed in one message.
+const maxErrorLines = 10
+
+// Summary describes the record changes made by one reconciliation.
+type Summary struct {
+	// DryRun is true if the changes were only planned, not applied.
+	DryRun bool
+
+	// Providers holds per-provider action counts, in a stable order.
+	Providers []ProviderSummary
+
+	// Errors holds one line per failed action.
+	Errors []string
+}
+
+// ProviderSummary counts the record changes for one provider instance.
+type ProviderSummary struct {
+	Name    string
+	Created int
+	Updated int
+	Deleted int
+	Failed  int
+}
+
+// Actions returns the total number of record changes, including failed ones.
+func (s Summary) Actions() int {
+	total := 0
+	for _, p := range s.Providers {
+		total += p.Created + p.Updated + p.Deleted + p.Failed
+	}
+	return total
+}
+
+// Notifier sends a reconciliation summary to an external service.
+type Notifier interface {
+	// Name identifies the notifier in logs, e.g. "slack".
+	Name() string
+	// Notify sends the summary.
+	Notify(ctx context.Context, s Summary) error
+}
+
+// Dispatcher sends summaries to several notifiers.
+// It implements Notifier itself.
+type Dispatcher struct {
+	notifiers  []Notifier
+	minActions int
+	logger     *slog.Logger
+}
+
+// Option is a functional option for configuring the Dispatcher.
+type Option func(*Dispatcher)
+
+// WithMinActions suppresses summaries with fewer than n record changes.
+// Values below 1 are treated as 1, so empty reconciliations are never sent.
+func WithMinActions(n int) Option {
+	return func(d *Dispatcher) {
+		d.minActions = n
+	}
+}
+
+// WithLogger sets the logger used to report delivery failures.
+func WithLogger(logger *slog.Logger) Option {
+	return func(d *Dispatcher) {
+		if logger != nil {
+			d.logger = logger
+		}
+	}
+}
+
+// NewDispatcher creates a Dispatcher for the given notifiers.
+func NewDispatcher(notifiers []Notifier, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		notifiers:  notifiers,
+		minActions: DefaultMinActions,
+		logger:     slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Name returns "dispatcher".
+func (d *Dispatcher) Name() string {
+	return "dispatcher"
+}
+
+// Notify sends s to every notifier if it has at least the minimum number of
+// record changes. Delivery failures are logged and returned joined; one
+// failing notifier does not stop the others.
+func (d *Dispatcher) Notify(ctx context.Context, s Summary) error {
+	if s.Actions() < max(d.minActions, 1) {
+		return nil
+	}
+
+	var errs []error
+	for _, n := range d.notifiers {
+		if err := n.Notify(ctx, s); err != nil {
+			d.logger.Warn("failed to send reconciliation notification",
+				slog.String("notifier", n.Name()),
+				slog.String("error", err.Error()),
+			)
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// formatMessage renders s as a chat message. bold wraps text in the target
+// service's bold markup.
+func formatMessage(s Summary, bold func(string) string) string {
+	var b strings.Builder
+
+	title := "dnsweaver reconciliation"
+	if s.DryRun {
+		title += " (dry run)"
+	}
+	b.WriteString(bold(title))
+
+	for _, p := range s.Providers {
+		counts := make([]string, 0, 4)
+		for _, c := range []struct {
+			n    int
+			verb string
+		}{
+			{p.Created, "created"},
+			{p.Updated, "updated"},
+			{p.Deleted, "deleted"},
+			{p.Failed, "failed"},
+		} {
+			if c.n > 0 {
+				counts = append(counts, fmt.Sprintf("%d %s", c.n, c.verb))
+			}
+		}
+		if len(counts) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n• %s: %s", p.Name, strings.Join(counts, ", "))
+	}
+
+	if len(s.Errors) > 0 {
+		b.WriteString("\n" + bold("Errors"))
+		for i, line := range s.Errors {
+			if i == maxErrorLines {
+				fmt.Fprintf(&b, "\n…and %d more", len(s.Errors)-maxErrorLines)
+				break
+			}
+			b.WriteString("\n• " + line)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// recordingNotifier records the summaries it receives.
+type recordingNotifier struct {
+	name     string
+	received []Summary
+	err      error
+}
+
+func (n *recordingNotifier) Name() string { return n.name }
+
+func (n *recordingNotifier) Notify(_ context.Context, s Summary) error {
+	n.received = append(n.received, s)
+	return n.err
+}
+
+func TestDispatcher_MinActions(t *testing.T) {
+	empty := Summary{Providers: []ProviderSummary{{Name: "dns"}}}
+	one := Summary{Providers: []ProviderSummary{{Name: "dns", Created: 1}}}
+	three := Summary{Providers: []ProviderSummary{{Name: "dns", Created: 1, Deleted: 1, Failed: 1}}}
+
+	tests := []struct {
+		name       string
+		minActions int
+		summary    Summary
+		wantSent   bool
+	}{
+		{"empty never sent", 0, empty, false},
+		{"one change sent by default", DefaultMinActions, one, true},
+		{"below threshold", 3, one, false},
+		{"failures count toward threshold", 3, three, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &recordingNotifier{name: "test"}
+			d := NewDispatcher([]Notifier{n}, WithMinActions(tt.minActions))
+
+			if err := d.Notify(context.Background(), tt.summary); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+			if sent := len(n.received) == 1; sent != tt.wantSent {
+				t.Errorf("sent = %v, want %v", sent, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestDispatcher_ContinuesAfterFailure(t *testing.T) {
+	failing := &recordingNotifier{name: "slack", err: errors.New("boom")}
+	ok := &recordingNotifier{name: "discord"}
+	d := NewDispatcher([]Notifier{failing, ok})
+
+	err := d.Notify(context.Background(), Summary{Providers: []ProviderSummary{{Name: "dns", Updated: 1}}})
+	if err == nil || !strings.Contains(err.Error(), "slack: boom") {
+		t.Errorf("Notify() error = %v, want slack failure", err)
+	}
+	if len(ok.received) != 1 {
+		t.Error("second notifier should still be called")
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	s := Summary{
+		Providers: []ProviderSummary{
+			{Name: "internal", Created: 2, Failed: 1},
+			{Name: "external"},
+		},
+		Errors: []string{"create app.example.com (internal): unauthorized"},
+	}
+
+	got := formatMessage(s, func(t string) string { return "*" + t + "*" })
+	want := "*dnsweaver reconciliation*\n• internal: 2 created, 1 failed\n*Errors*\n• create app.example.com (internal): unauthorized"
+	if got != want {
+		t.Errorf("formatMessage() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatMessage_TruncatesErrors(t *testing.T) {
+	s := Summary{Providers: []ProviderSummary{{Name: "dns", Failed: 12}}}
+	for i := range 12 {
+		s.Errors = append(s.Errors, fmt.Sprintf("error %d", i))
+	}
+
+	got := formatMessage(s, func(t string) string { return t })
+	if !strings.Contains(got, "error 9") || strings.Contains(got, "error 10") {
+		t.Errorf("expected first %d errors only:\n%s", maxErrorLines, got)
+	}
+	if !strings.HasSuffix(got, "…and 2 more") {
+		t.Errorf("expected truncation note:\n%s", got)
+	}
+}
+
+func TestWebhookNotifiers(t *testing.T) {
+	summary := Summary{DryRun: true, Providers: []ProviderSummary{{Name: "dns", Deleted: 1}}}
+
+	tests := []struct {
+		name     string
+		notifier func(url string) Notifier
+		field    string
+		bold     string
+	}{
+		{"slack", func(url string) Notifier { return NewSlackNotifier(url) }, "text", "*dnsweaver reconciliation (dry run)*"},
+		{"discord", func(url string) Notifier { return NewDiscordNotifier(url) }, "content", "**dnsweaver reconciliation (dry run)**"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload map[string]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+				}
+				_ = json.NewDecoder(r.Body).Decode(&payload)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			if err := tt.notifier(server.URL).Notify(context.Background(), summary); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+			if !strings.HasPrefix(payload[tt.field], tt.bold) || !strings.Contains(payload[tt.field], "dns: 1 deleted") {
+				t.Errorf("payload %s = %q", tt.field, payload[tt.field])
+			}
+		})
+	}
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := NewSlackNotifier(server.URL).Notify(context.Background(), Summary{})
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("Notify() error = %v, want status 403 with body", err)
+	}
+}
+
+func TestDiscordNotifier_Truncates(t *testing.T) {
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer server.Close()
+
+	s := Summary{Providers: []ProviderSummary{{Name: strings.Repeat("x", 3000), Created: 1}}}
+	if err := NewDiscordNotifier(server.URL).Notify(context.Background(), s); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if n := len([]rune(payload["content"])); n != discordMaxLength {
+		t.Errorf("content length = %d, want %d", n, discordMaxLength)
+	}
+}
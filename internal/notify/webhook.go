@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+)
+
+// DefaultTimeout bounds each notification request.
+const DefaultTimeout = 10 * time.Second
+
+// discordMaxLength is Discord's limit on message content.
+const discordMaxLength = 2000
+
+// WebhookOption is a functional option for the Slack and Discord notifiers.
+type WebhookOption func(*webhook)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(client *http.Client) WebhookOption {
+	return func(w *webhook) {
+		if client != nil {
+			w.httpClient = client
+		}
+	}
+}
+
+// webhook posts JSON payloads to an incoming webhook URL.
+type webhook struct {
+	url        string
+	httpClient *http.Client
+}
+
+func newWebhook(url string, opts []WebhookOption) webhook {
+	w := webhook{
+		url:        url,
+		httpClient: httputil.NewClient(&httputil.ClientConfig{Timeout: DefaultTimeout}),
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	return w
+}
+
+// post sends payload as JSON and expects a 2xx response.
+func (w webhook) post(ctx context.Context, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return nil
+}
+
+// SlackNotifier posts summaries to a Slack incoming webhook.
+type SlackNotifier struct {
+	webhook
+}
+
+// NewSlackNotifier creates a notifier for the Slack incoming webhook URL.
+func NewSlackNotifier(webhookURL string, opts ...WebhookOption) *SlackNotifier {
+	return &SlackNotifier{webhook: newWebhook(webhookURL, opts)}
+}
+
+// Name returns "slack".
+func (n *SlackNotifier) Name() string {
+	return "slack"
+}
+
+// Notify posts the summary as a Slack message.
+func (n *SlackNotifier) Notify(ctx context.Context, s Summary) error {
+	text := formatMessage(s, func(t string) string { return "*" + t + "*" })
+	return n.post(ctx, map[string]string{"text": text})
+}
+
+// DiscordNotifier posts summaries to a Discord webhook.
+type DiscordNotifier struct {
+	webhook
+}
+
+// NewDiscordNotifier creates a notifier for the Discord webhook URL.
+func NewDiscordNotifier(webhookURL string, opts ...WebhookOption) *DiscordNotifier {
+	return &DiscordNotifier{webhook: newWebhook(webhookURL, opts)}
+}
+
+// Name returns "discord".
+func (n *DiscordNotifier) Name() string {
+	return "discord"
+}
+
+// Notify posts the summary as a Discord message, truncated to Discord's
+// 2000 character limit.
+func (n *DiscordNotifier) Notify(ctx context.Context, s Summary) error {
+	content := formatMessage(s, func(t string) string { return "**" + t + "**" })
+	if runes := []rune(content); len(runes) > discordMaxLength {
+		content = string(runes[:discordMaxLength-1]) + "…"
+	}
+	return n.post(ctx, map[string]string{"content": content})
+}
@@ -0,0 +1,141 @@
+// Package policy checks DNS record operations against an external admission
+// policy before the reconciler applies them.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+)
+
+// DefaultTimeout bounds each policy query.
+const DefaultTimeout = 2 * time.Second
+
+// Record operations sent as Input.Action.
+const (
+	ActionCreate = "create"
+	ActionDelete = "delete"
+)
+
+// Input describes the record operation a policy decides on.
+type Input struct {
+	Hostname string `json:"hostname"`
+	Provider string `json:"provider"`
+	Action   string `json:"action"`
+}
+
+// Checker decides whether a record operation is allowed.
+type Checker interface {
+	// Allow reports whether the operation may proceed. When the decision
+	// cannot be made, err is non-nil and allowed is the configured fallback.
+	Allow(ctx context.Context, in Input) (allowed bool, err error)
+}
+
+// Option is a functional option for the OPA checker.
+type Option func(*OPA)
+
+// WithTimeout bounds each policy query (default: DefaultTimeout).
+func WithTimeout(d time.Duration) Option {
+	return func(o *OPA) {
+		if d > 0 {
+			o.timeout = d
+		}
+	}
+}
+
+// WithFailOpen allows operations when the policy cannot be queried.
+// By default they are denied (fail closed).
+func WithFailOpen(failOpen bool) Option {
+	return func(o *OPA) {
+		o.failOpen = failOpen
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *OPA) {
+		if client != nil {
+			o.httpClient = client
+		}
+	}
+}
+
+// OPA queries an Open Policy Agent decision endpoint, such as
+// http://opa:8181/v1/data/dnsweaver/allow. The operation is posted as
+// {"input": {...}} and allowed if OPA answers {"result": true}.
+type OPA struct {
+	url        string
+	timeout    time.Duration
+	failOpen   bool
+	httpClient *http.Client
+}
+
+// NewOPA creates a checker for the OPA decision URL.
+func NewOPA(url string, opts ...Option) *OPA {
+	o := &OPA{
+		url:        url,
+		timeout:    DefaultTimeout,
+		httpClient: httputil.NewClient(nil),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Allow queries OPA for the operation. A non-boolean or undefined result is
+// an error, as is any transport or HTTP failure; the operation is then
+// allowed only if the checker fails open.
+func (o *OPA) Allow(ctx context.Context, in Input) (bool, error) {
+	allowed, err := o.query(ctx, in)
+	if err != nil {
+		return o.failOpen, err
+	}
+	return allowed, nil
+}
+
+// query posts the input and decodes the decision.
+func (o *OPA) query(ctx context.Context, in Input) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	data, err := json.Marshal(map[string]Input{"input": in})
+	if err != nil {
+		return false, fmt.Errorf("encoding input: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.url, bytes.NewReader(data))
+	if err != nil {
+		return false, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return false, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	var decision struct {
+		Result *bool `json:"result"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&decision); err != nil {
+		return false, fmt.Errorf("decoding decision: %w", err)
+	}
+	if decision.Result == nil {
+		return false, errors.New("policy decision is undefined or not a boolean")
+	}
+	return *decision.Result, nil
+}
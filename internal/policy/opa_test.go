@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOPA_Allow(t *testing.T) {
+	var got map[string]Input
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		allowed := got["input"].Hostname == "app.example.com"
+		_ = json.NewEncoder(w).Encode(map[string]bool{"result": allowed})
+	}))
+	defer server.Close()
+
+	opa := NewOPA(server.URL)
+	in := Input{Hostname: "app.example.com", Provider: "test-dns", Action: ActionCreate}
+
+	allowed, err := opa.Allow(context.Background(), in)
+	if err != nil || !allowed {
+		t.Fatalf("Allow() = %v, %v; want true, nil", allowed, err)
+	}
+	if got["input"] != in {
+		t.Errorf("posted input = %+v, want %+v", got["input"], in)
+	}
+
+	in.Hostname = "secret.example.com"
+	allowed, err = opa.Allow(context.Background(), in)
+	if err != nil || allowed {
+		t.Errorf("Allow() = %v, %v; want false, nil", allowed, err)
+	}
+}
+
+func TestOPA_Allow_FailMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"server error", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}},
+		{"undefined decision", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}},
+		{"non-boolean decision", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"result": {"allow": true}}`))
+		}},
+		{"timeout", func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			for _, failOpen := range []bool{false, true} {
+				opa := NewOPA(server.URL, WithTimeout(50*time.Millisecond), WithFailOpen(failOpen))
+				allowed, err := opa.Allow(context.Background(), Input{Hostname: "app.example.com", Action: ActionCreate})
+				if err == nil {
+					t.Fatal("Allow() expected error")
+				}
+				if allowed != failOpen {
+					t.Errorf("Allow() with fail open %v = %v, want %v", failOpen, allowed, failOpen)
+				}
+			}
+		})
+	}
+}
@@ -4,8 +4,10 @@ package reconciler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
@@ -16,7 +18,7 @@ import (
 // 1. Check if record exists for hostname
 // 2. If exists with same target → skip (idempotent)
 // 3. If exists with different target (same type) → delete old, create new
-// 4. If exists with different type → log warning, skip (don't delete manual records)
+// 4. If exists with a conflicting type (CNAME vs other) → log warning, skip (don't delete manual records)
 //
 // When hostname has RecordHints, they override provider defaults:
 // - RecordHints.Provider: route directly to named provider instead of domain matching
@@ -39,11 +41,11 @@ func (r *Reconciler) ensureRecord(ctx context.Context, hostname *source.Hostname
 				Hostname: hostname.Name,
 				Error:    fmt.Sprintf("explicit provider %q not found", targetProvider),
 			})
-			return actions
+			return annotateActions(actions, hostname)
 		}
 		// Route to explicit provider, bypassing domain matching
-		action := r.ensureRecordForProvider(ctx, hostname, inst, cache)
-		return append(actions, action)
+		actions = append(actions, r.ensureRecordsForProvider(ctx, hostname, inst, cache)...)
+		return annotateActions(actions, hostname)
 	}
 
 	// Standard domain-based matching
@@ -59,14 +61,52 @@ func (r *Reconciler) ensureRecord(ctx context.Context, hostname *source.Hostname
 			Hostname: hostname.Name,
 			Error:    "no matching provider",
 		})
-		return actions
+		return annotateActions(actions, hostname)
+	}
+
+	if r.config.ProviderFallback {
+		return annotateActions(r.ensureRecordWithFallback(ctx, hostname, cache), hostname)
 	}
 
 	for _, inst := range matchingProviders {
-		action := r.ensureRecordForProvider(ctx, hostname, inst, cache)
-		actions = append(actions, action)
+		actions = append(actions, r.ensureRecordsForProvider(ctx, hostname, inst, cache)...)
 	}
 
+	return annotateActions(actions, hostname)
+}
+
+// ensureRecordWithFallback ensures the hostname's records in the matching
+// provider instances one at a time, in priority order, until one instance
+// takes them. An instance is passed over when an action failed, was skipped
+// by reconcile backoff, or uses a record type the instance does not support.
+// The actions of the instances tried before it are kept in the result.
+func (r *Reconciler) ensureRecordWithFallback(ctx context.Context, hostname *source.Hostname, cache *recordCache) []Action {
+	var actions []Action
+	// Every instance failing is already reported by its actions
+	_, _ = r.providers.MatchingProvidersWithFallback(hostname.Name, func(inst *provider.ProviderInstance) error {
+		instActions := r.ensureRecordsForProvider(ctx, hostname, inst, cache)
+		actions = append(actions, instActions...)
+		for _, action := range instActions {
+			if action.Status == StatusFailed || action.BackedOff || action.unsupported {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				return errors.New(action.Error)
+			}
+		}
+		return nil
+	})
+	return actions
+}
+
+// annotateActions attaches the hostname's source annotations to its actions.
+func annotateActions(actions []Action, hostname *source.Hostname) []Action {
+	if len(hostname.Annotations) == 0 {
+		return actions
+	}
+	for i := range actions {
+		actions[i].Annotations = hostname.Annotations
+	}
 	return actions
 }
 
@@ -79,8 +119,11 @@ func (r *Reconciler) ensureRecordForProvider(ctx context.Context, hostname *sour
 	target := inst.Target
 	ttl := inst.TTL
 	var srvData *provider.SRVData
+	var providerHints map[string]string
+	weighted := r.weightedData(hostname, inst)
 
 	if hints := hostname.RecordHints; hints != nil {
+		providerHints = hints.ProviderHints
 		if hints.Type != "" {
 			recordType = provider.RecordType(hints.Type)
 		}
@@ -103,6 +146,7 @@ func (r *Reconciler) ensureRecordForProvider(ctx context.Context, hostname *sour
 	action := Action{
 		Type:       ActionCreate,
 		Provider:   inst.Name(),
+		Zone:       inst.Zone(),
 		Hostname:   hostname.Name,
 		RecordType: string(recordType),
 		Target:     target,
@@ -122,28 +166,7 @@ func (r *Reconciler) ensureRecordForProvider(ctx context.Context, hostname *sour
 	}
 
 	// Step 1: Get existing records from cache (or fetch if cache unavailable)
-	var existingRecords []provider.Record
-	if cache != nil {
-		var cached bool
-		existingRecords, cached = cache.getExistingRecords(inst.Name(), hostname.Name)
-		if !cached {
-			// Cache miss (provider failed to load) - fall back to direct query
-			r.logger.Debug("cache miss, querying provider directly",
-				slog.String("hostname", hostname.Name),
-				slog.String("provider", inst.Name()),
-			)
-			var err error
-			existingRecords, err = inst.GetExistingRecords(ctx, hostname.Name)
-			if err != nil {
-				r.logger.Warn("failed to list existing records, proceeding with create",
-					slog.String("hostname", hostname.Name),
-					slog.String("provider", inst.Name()),
-					slog.String("error", err.Error()),
-				)
-				existingRecords = nil
-			}
-		}
-	}
+	existingRecords := r.existingRecordsFor(ctx, hostname.Name, inst, cache)
 
 	// Step 2: Analyze existing records
 	var sameTypeRecords []provider.Record
@@ -152,7 +175,7 @@ func (r *Reconciler) ensureRecordForProvider(ctx context.Context, hostname *sour
 	for _, existing := range existingRecords {
 		if existing.Type == recordType {
 			sameTypeRecords = append(sameTypeRecords, existing)
-		} else {
+		} else if TypesConflict(existing.Type, recordType) {
 			conflictingTypeRecords = append(conflictingTypeRecords, existing)
 		}
 	}
@@ -207,7 +230,9 @@ func (r *Reconciler) ensureRecordForProvider(ctx context.Context, hostname *sour
 			slog.Int("old_priority", int(stale.SRV.Priority)),
 			slog.Int("old_port", int(stale.SRV.Port)),
 		)
-		if err := inst.DeleteSRVRecord(ctx, hostname.Name, stale.Target, stale.SRV); err != nil {
+		err := inst.DeleteSRVRecord(ctx, hostname.Name, stale.Target, stale.SRV)
+		r.auditRecord(ActionDelete, inst, stale, err)
+		if err != nil {
 			r.logger.Error("failed to delete stale SRV record",
 				slog.String("hostname", hostname.Name),
 				slog.String("provider", inst.Name()),
@@ -235,14 +260,14 @@ func (r *Reconciler) ensureRecordForProvider(ctx context.Context, hostname *sour
 				slog.String("provider", inst.Name()),
 				slog.String("target", target),
 			)
-			r.ensureOwnershipRecord(ctx, hostname.Name, inst)
+			r.ensureOwnershipRecord(ctx, hostname, inst, cache)
 		} else if r.config.AdoptExisting {
 			r.logger.Info("adopting existing record",
 				slog.String("hostname", hostname.Name),
 				slog.String("provider", inst.Name()),
 				slog.String("target", target),
 			)
-			r.ensureOwnershipRecord(ctx, hostname.Name, inst)
+			r.ensureOwnershipRecord(ctx, hostname, inst, cache)
 		} else {
 			r.logger.Info("existing record found, skipping adoption (set ADOPT_EXISTING=true to manage)",
 				slog.String("hostname", hostname.Name),
@@ -274,20 +299,21 @@ func (r *Reconciler) ensureRecordForProvider(ctx context.Context, hostname *sour
 			Target:   target,
 			TTL:      ttl,
 			SRV:      srvData,
+			Weighted: weighted,
+			Hints:    providerHints,
 		}
 
+		action.Type = ActionUpdate
+		action.OldTarget = existing.Target
 		if err := inst.UpdateRecord(ctx, existing, desired); err != nil {
-			action.Status = StatusFailed
-			action.Error = err.Error()
-			r.logger.Error("failed to update record",
+			action.fail(err)
+			r.logFailure("failed to update record", err,
 				slog.String("hostname", hostname.Name),
 				slog.String("provider", inst.Name()),
-				slog.String("error", err.Error()),
 			)
 			return action
 		}
 
-		action.Type = ActionUpdate
 		action.Status = StatusSuccess
 		r.logger.Info("updated record",
 			slog.String("hostname", hostname.Name),
@@ -295,13 +321,13 @@ func (r *Reconciler) ensureRecordForProvider(ctx context.Context, hostname *sour
 			slog.String("type", string(recordType)),
 			slog.String("target", target),
 		)
-		r.ensureOwnershipRecord(ctx, hostname.Name, inst)
+		r.ensureOwnershipRecord(ctx, hostname, inst, cache)
 		return action
 	}
 
 	// Step 6: Create the record (no existing records)
 	// Use CreateRecordWithValues to respect RecordHints overrides
-	if err := inst.CreateRecordWithValues(ctx, hostname.Name, recordType, target, ttl, srvData); err != nil {
+	if err := inst.CreateRecordWithValues(ctx, hostname.Name, recordType, target, ttl, srvData, providerHints, weighted); err != nil {
 		// Handle conflict error (shouldn't happen after our checks, but be safe)
 		if provider.IsConflict(err) {
 			action.Type = ActionSkip
@@ -311,7 +337,7 @@ func (r *Reconciler) ensureRecordForProvider(ctx context.Context, hostname *sour
 				slog.String("hostname", hostname.Name),
 				slog.String("provider", inst.Name()),
 			)
-			r.ensureOwnershipRecord(ctx, hostname.Name, inst)
+			r.ensureOwnershipRecord(ctx, hostname, inst, cache)
 		} else if provider.IsTypeConflict(err) {
 			action.Type = ActionSkip
 			action.Status = StatusSkipped
@@ -322,12 +348,10 @@ func (r *Reconciler) ensureRecordForProvider(ctx context.Context, hostname *sour
 				slog.String("type", string(recordType)),
 			)
 		} else {
-			action.Status = StatusFailed
-			action.Error = err.Error()
-			r.logger.Error("failed to create record",
+			action.fail(err)
+			r.logFailure("failed to create record", err,
 				slog.String("hostname", hostname.Name),
 				slog.String("provider", inst.Name()),
-				slog.String("error", err.Error()),
 			)
 		}
 	} else {
@@ -339,35 +363,194 @@ func (r *Reconciler) ensureRecordForProvider(ctx context.Context, hostname *sour
 			slog.String("target", target),
 		)
 		action.Status = StatusSuccess
-		r.ensureOwnershipRecord(ctx, hostname.Name, inst)
+		r.ensureOwnershipRecord(ctx, hostname, inst, cache)
 	}
 
 	return action
 }
 
+// ensureRecordWithRetry runs ensureRecordForProvider, retrying failed actions
+// as configured by WithRetry. Conflicts and open circuit breakers are not
+// retried. The returned action is from the last attempt; earlier failed
+// attempts are audited here, since they do not appear in the result.
+func (r *Reconciler) ensureRecordWithRetry(ctx context.Context, hostname *source.Hostname, inst *provider.ProviderInstance, cache *recordCache) Action {
+	backoff := r.retryBackoff
+	for attempt := 1; ; attempt++ {
+		action := r.ensureRecordForProvider(ctx, hostname, inst, cache)
+		action.Attempts = attempt
+
+		if action.Status != StatusFailed || attempt >= r.retryAttempts || !isRetryable(action.err) {
+			return action
+		}
+		if !r.waitRetry(ctx, hostname.Name, inst, attempt, backoff) {
+			return action
+		}
+		action.Annotations = hostname.Annotations
+		r.auditAction(action)
+		backoff *= 2
+	}
+}
+
+// retry runs the record operation of action, retrying it on retryable errors
+// as configured by WithRetry. It returns the number of attempts made and the
+// error of the last attempt. Failed attempts that are retried are audited.
+func (r *Reconciler) retry(ctx context.Context, action Action, inst *provider.ProviderInstance, op func() error) (int, error) {
+	backoff := r.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil || attempt >= r.retryAttempts || !isRetryable(err) {
+			return attempt, err
+		}
+		if !r.waitRetry(ctx, action.Hostname, inst, attempt, backoff) {
+			return attempt, err
+		}
+		failed := action
+		failed.fail(err)
+		r.auditAction(failed)
+		backoff *= 2
+	}
+}
+
+// waitRetry logs a failed attempt and waits for backoff before the next one.
+// It returns false if ctx is done first.
+func (r *Reconciler) waitRetry(ctx context.Context, hostname string, inst *provider.ProviderInstance, attempt int, backoff time.Duration) bool {
+	r.logger.Warn("record operation failed, retrying",
+		slog.String("hostname", hostname),
+		slog.String("provider", inst.Name()),
+		slog.Int("attempt", attempt),
+		slog.Int("max_attempts", r.retryAttempts),
+		slog.Duration("backoff", backoff),
+	)
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(backoff):
+		return true
+	}
+}
+
+// isRetryable reports whether a failed record operation may succeed if tried again.
+// Authentication failures and rejected records fail the same way on every attempt.
+func isRetryable(err error) bool {
+	return !provider.IsConflict(err) &&
+		!provider.IsTypeConflict(err) &&
+		!provider.IsCircuitOpen(err) &&
+		!provider.IsAuth(err) &&
+		!provider.IsInvalidRecord(err)
+}
+
+// logFailure logs a failed record operation. The message is refined by the
+// class of err, and its error code is attached as error_code.
+func (r *Reconciler) logFailure(msg string, err error, args ...any) {
+	args = append(args,
+		slog.String("error", err.Error()),
+		slog.String("error_code", provider.ErrorCode(err)),
+	)
+	r.logger.Error(failureMessage(msg, err), args...)
+}
+
+// failureMessage appends the failure class to msg so that authentication,
+// rate limit, network, and validation failures are distinguishable in logs.
+func failureMessage(msg string, err error) string {
+	switch {
+	case provider.IsAuth(err):
+		return msg + ": provider rejected credentials"
+	case provider.IsQuota(err):
+		return msg + ": provider rate limit exceeded"
+	case provider.IsNetwork(err):
+		return msg + ": provider unreachable"
+	case provider.IsInvalidRecord(err):
+		return msg + ": provider rejected record as invalid"
+	default:
+		return msg
+	}
+}
+
+// existingRecordsFor returns the records that currently exist for a hostname in a provider.
+// It reads from the cache and falls back to querying the provider on a cache miss.
+// Returns nil when no cache is available or the provider query fails.
+func (r *Reconciler) existingRecordsFor(ctx context.Context, hostname string, inst *provider.ProviderInstance, cache *recordCache) []provider.Record {
+	if cache == nil {
+		return nil
+	}
+
+	existingRecords, cached := cache.getExistingRecords(inst.Name(), hostname)
+	if cached {
+		return existingRecords
+	}
+
+	// Cache miss (provider failed to load) - fall back to direct query
+	r.logger.Debug("cache miss, querying provider directly",
+		slog.String("hostname", hostname),
+		slog.String("provider", inst.Name()),
+	)
+	existingRecords, err := inst.GetExistingRecords(ctx, hostname)
+	if err != nil {
+		r.logger.Warn("failed to list existing records, proceeding with create",
+			slog.String("hostname", hostname),
+			slog.String("provider", inst.Name()),
+			slog.String("error", err.Error()),
+		)
+		return nil
+	}
+	return existingRecords
+}
+
 // ensureOwnershipRecord creates the ownership TXT record if tracking is enabled.
-func (r *Reconciler) ensureOwnershipRecord(ctx context.Context, hostname string, inst *provider.ProviderInstance) {
+// An existing ownership record in any format is left as is; legacy records are
+// rewritten by ProviderInstance.MigrateOwnershipRecords instead.
+func (r *Reconciler) ensureOwnershipRecord(ctx context.Context, hostname *source.Hostname, inst *provider.ProviderInstance, cache *recordCache) {
 	if !r.config.OwnershipTracking {
 		return
 	}
+	if cache != nil && cache.hasOwnershipRecord(inst.Name(), hostname.Name) {
+		return
+	}
 
-	if err := inst.CreateOwnershipRecord(ctx, hostname); err != nil {
+	err := inst.CreateOwnershipRecord(ctx, hostname.Name, hostname.Source)
+	if !provider.IsConflict(err) {
+		r.auditRecord(ActionCreate, inst, provider.OwnershipRecord(hostname.Name, inst.TTL, hostname.Source), err)
+	}
+	if err != nil {
 		// Don't warn if ownership record already exists
 		if !provider.IsConflict(err) {
 			r.logger.Warn("failed to create ownership record",
-				slog.String("hostname", hostname),
+				slog.String("hostname", hostname.Name),
 				slog.String("provider", inst.Name()),
 				slog.String("error", err.Error()),
 			)
 		}
 	} else {
+		cache.invalidate(inst.Name(), hostname.Name)
 		r.logger.Debug("created ownership record",
-			slog.String("hostname", hostname),
+			slog.String("hostname", hostname.Name),
 			slog.String("provider", inst.Name()),
 		)
 	}
 }
 
+// weightedData returns the routing weight from the hostname's hints for
+// providers that support weighted records, or nil when no weight is set or
+// the provider would ignore it. A dropped weight is logged once per provider.
+func (r *Reconciler) weightedData(hostname *source.Hostname, inst *provider.ProviderInstance) *provider.WeightedData {
+	hints := hostname.RecordHints
+	if hints == nil || hints.Weight == 0 {
+		return nil
+	}
+	if !inst.Provider.Capabilities().SupportsWeightedRecords {
+		if _, warned := r.weightWarned.LoadOrStore(inst.Name(), struct{}{}); !warned {
+			r.logger.Warn("provider does not support weighted records, ignoring weight hints; further hostnames are not logged",
+				slog.String("hostname", hostname.Name),
+				slog.String("provider", inst.Name()),
+				slog.Int("weight", int(hints.Weight)),
+			)
+		}
+		return nil
+	}
+	return &provider.WeightedData{Weight: hints.Weight}
+}
+
 // srvDataEquals compares two SRVData structs for equality.
 // Returns true if both are nil or have identical priority, weight, and port.
 func srvDataEquals(a, b *provider.SRVData) bool {
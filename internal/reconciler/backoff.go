@@ -0,0 +1,130 @@
+package reconciler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// failureKey identifies a hostname in a single provider.
+type failureKey struct {
+	provider string
+	hostname string
+}
+
+// failureState is the consecutive failure count of a hostname in a provider
+// and, once the threshold is reached, when attempts may resume.
+type failureState struct {
+	failures int
+	backoff  time.Duration
+	until    time.Time
+}
+
+// failureTracker suppresses record operations for hostname+provider pairs
+// that keep failing. After threshold consecutive failed reconciliations the
+// pair is skipped for the initial backoff, which doubles with every further
+// failure up to max. A success clears the pair.
+type failureTracker struct {
+	threshold int
+	initial   time.Duration
+	max       time.Duration
+	now       func() time.Time
+
+	mu      sync.Mutex
+	entries map[failureKey]*failureState
+}
+
+func newFailureTracker(threshold int, initial, max time.Duration) *failureTracker {
+	if max < initial {
+		max = initial
+	}
+	return &failureTracker{
+		threshold: threshold,
+		initial:   initial,
+		max:       max,
+		now:       time.Now,
+		entries:   make(map[failureKey]*failureState),
+	}
+}
+
+// backedOff reports whether operations for hostname in provider are
+// currently suppressed, and if so until when.
+func (t *failureTracker) backedOff(provider, hostname string) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.entries[failureKey{provider, hostname}]
+	if !ok || state.until.IsZero() || !t.now().Before(state.until) {
+		return time.Time{}, false
+	}
+	return state.until, true
+}
+
+// record updates the failure state of each provider+hostname pair from the
+// outcome of its actions. Any failed action counts as one failure of the
+// pair; skipped actions leave the state unchanged.
+func (t *failureTracker) record(actions []Action) {
+	outcome := make(map[failureKey]bool) // true if any action failed
+	for _, a := range actions {
+		switch a.Status {
+		case StatusFailed:
+			outcome[failureKey{a.Provider, a.Hostname}] = true
+		case StatusSuccess:
+			key := failureKey{a.Provider, a.Hostname}
+			if _, seen := outcome[key]; !seen {
+				outcome[key] = false
+			}
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, failed := range outcome {
+		if !failed {
+			delete(t.entries, key)
+			continue
+		}
+		state, ok := t.entries[key]
+		if !ok {
+			state = &failureState{}
+			t.entries[key] = state
+		}
+		state.failures++
+		if state.failures < t.threshold {
+			continue
+		}
+		if state.backoff == 0 {
+			state.backoff = t.initial
+		} else {
+			state.backoff = min(state.backoff*2, t.max)
+		}
+		state.until = t.now().Add(state.backoff)
+	}
+}
+
+// reset clears all failure state and returns how many pairs were backed off.
+func (t *failureTracker) reset() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	count := 0
+	now := t.now()
+	for _, state := range t.entries {
+		if now.Before(state.until) {
+			count++
+		}
+	}
+	t.entries = make(map[failureKey]*failureState)
+	return count
+}
+
+// backedOffAction returns the skip action for a hostname whose operations in
+// a provider are suppressed until the given time.
+func backedOffAction(base Action, until time.Time) Action {
+	base.Type = ActionSkip
+	base.Status = StatusSkipped
+	base.BackedOff = true
+	base.Error = fmt.Sprintf("backed off after repeated failures until %s", until.UTC().Format(time.RFC3339))
+	return base
+}
@@ -0,0 +1,118 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+func TestFailureTracker(t *testing.T) {
+	tracker := newFailureTracker(2, time.Minute, 3*time.Minute)
+	now := time.Now()
+	tracker.now = func() time.Time { return now }
+
+	failed := []Action{{Provider: "dns", Hostname: "app.example.com", Status: StatusFailed}}
+	succeeded := []Action{{Provider: "dns", Hostname: "app.example.com", Status: StatusSuccess}}
+
+	backedOffFor := func() time.Duration {
+		until, ok := tracker.backedOff("dns", "app.example.com")
+		if !ok {
+			return 0
+		}
+		return until.Sub(now)
+	}
+
+	// Below the threshold nothing is suppressed
+	tracker.record(failed)
+	if d := backedOffFor(); d != 0 {
+		t.Fatalf("backed off after 1 failure for %v, want not backed off", d)
+	}
+
+	// Backoff starts at the threshold and doubles up to max
+	for _, want := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+		tracker.record(failed)
+		if d := backedOffFor(); d != want {
+			t.Errorf("backoff = %v, want %v", d, want)
+		}
+	}
+
+	// Other pairs are unaffected
+	if _, ok := tracker.backedOff("other", "app.example.com"); ok {
+		t.Error("unrelated provider is backed off")
+	}
+
+	// The backoff expires
+	now = now.Add(3 * time.Minute)
+	if _, ok := tracker.backedOff("dns", "app.example.com"); ok {
+		t.Error("still backed off after the backoff expired")
+	}
+
+	// A success clears the failure count
+	tracker.record(succeeded)
+	tracker.record(failed)
+	if d := backedOffFor(); d != 0 {
+		t.Errorf("backed off for %v after success and 1 failure, want not backed off", d)
+	}
+
+	// Reset clears backed off pairs
+	tracker.record(failed)
+	if n := tracker.reset(); n != 1 {
+		t.Errorf("reset() = %d, want 1", n)
+	}
+	if _, ok := tracker.backedOff("dns", "app.example.com"); ok {
+		t.Error("still backed off after reset")
+	}
+}
+
+func TestEnsureRecord_ReconcileBackoff(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+	creates := 0
+	mock.createFn = func(_ context.Context, _ provider.Record) error {
+		creates++
+		return provider.ErrInvalidRecord
+	}
+
+	logger := quietLogger()
+	providers := testProviderRegistry(logger, mock)
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	r := New(nil, nil, providers,
+		WithConfig(DefaultConfig()),
+		WithLogger(logger),
+		WithReconcileBackoff(2, time.Hour, time.Hour),
+	)
+
+	hostname := &source.Hostname{Name: "app.example.com", Source: "test"}
+	for i := 0; i < 2; i++ {
+		actions := r.ensureRecord(context.Background(), hostname, nil)
+		if len(actions) != 1 || actions[0].Status != StatusFailed {
+			t.Fatalf("attempt %d: expected 1 failed action, got %+v", i+1, actions)
+		}
+	}
+
+	actions := r.ensureRecord(context.Background(), hostname, nil)
+	if len(actions) != 1 || !actions[0].BackedOff || actions[0].Status != StatusSkipped {
+		t.Fatalf("expected 1 backed off action, got %+v", actions)
+	}
+	if creates != 2 {
+		t.Errorf("provider Create called %d times, want 2", creates)
+	}
+
+	if n := r.ResetBackoff(); n != 1 {
+		t.Errorf("ResetBackoff() = %d, want 1", n)
+	}
+	actions = r.ensureRecord(context.Background(), hostname, nil)
+	if len(actions) != 1 || actions[0].BackedOff {
+		t.Errorf("expected a new attempt after reset, got %+v", actions)
+	}
+}
@@ -0,0 +1,198 @@
+package reconciler
+
+import (
+	"context"
+	"log/slog"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/policy"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+// bulkBatch collects the records to create in one provider instance.
+type bulkBatch struct {
+	inst    *provider.ProviderInstance
+	records []provider.Record
+	// actions maps normalized hostname -> the create action for its record
+	actions map[string]Action
+}
+
+// bulkCreate creates the records for hostnames in a single BulkCreate call per
+// provider instance that implements provider.BulkCreator and held no records
+// when the cache was built, which means a fresh install. A hostname is only
+// included when every provider it routes to qualifies and it needs a single
+// record of a supported type.
+//
+// Returns the actions of the hostnames that were created, keyed by normalized
+// hostname. Hostnames left out, or routed to a provider whose bulk create
+// failed, are not in the map and go through ensureRecord as usual, which
+// creates whatever is still missing.
+func (r *Reconciler) bulkCreate(ctx context.Context, names []string, discovered map[string]*source.Hostname, cache *recordCache) map[string][]Action {
+	if r.config.DryRun || cache == nil {
+		return nil
+	}
+
+	batches := make(map[string]*bulkBatch)
+	var order []string
+	routed := make(map[string][]string)
+
+	for _, name := range names {
+		hostname := discovered[name]
+		instances, ok := r.bulkInstances(ctx, hostname, cache)
+		if !ok {
+			continue
+		}
+		for _, inst := range instances {
+			b, exists := batches[inst.Name()]
+			if !exists {
+				b = &bulkBatch{inst: inst, actions: make(map[string]Action)}
+				batches[inst.Name()] = b
+				order = append(order, inst.Name())
+			}
+
+			record := r.desiredRecord(hostname, inst)
+			b.records = append(b.records, record)
+			if r.config.OwnershipTracking && inst.Provider.Capabilities().SupportsOwnershipTXT {
+				b.records = append(b.records, provider.OwnershipRecord(hostname.Name, inst.TTL, hostname.Source))
+			}
+			b.actions[name] = Action{
+				Type:       ActionCreate,
+				Provider:   inst.Name(),
+				Zone:       inst.Zone(),
+				Hostname:   hostname.Name,
+				RecordType: string(record.Type),
+				Target:     record.Target,
+				Status:     StatusSuccess,
+			}
+			routed[name] = append(routed[name], inst.Name())
+		}
+	}
+
+	failed := make(map[string]bool)
+	for _, providerName := range order {
+		b := batches[providerName]
+		err := b.inst.BulkCreate(ctx, b.records)
+		// Records may exist now even if the call failed part way
+		for name := range b.actions {
+			cache.invalidate(providerName, name)
+		}
+		for _, rec := range b.records {
+			// Record actions are in the result on success; everything else
+			// in the batch is audited here
+			if err != nil || provider.IsOwnershipRecord(rec.Hostname) {
+				r.auditRecord(ActionCreate, b.inst, rec, err)
+			}
+		}
+		if err != nil {
+			failed[providerName] = true
+			r.logger.Warn("bulk create failed, creating records individually",
+				slog.String("provider", providerName),
+				slog.Int("hostnames", len(b.actions)),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		r.logger.Info("bulk created records",
+			slog.String("provider", providerName),
+			slog.Int("hostnames", len(b.actions)),
+			slog.Int("records", len(b.records)),
+		)
+	}
+
+	created := make(map[string][]Action, len(routed))
+	for name, providerNames := range routed {
+		actions := make([]Action, 0, len(providerNames))
+		for _, providerName := range providerNames {
+			if failed[providerName] {
+				actions = nil
+				break
+			}
+			actions = append(actions, batches[providerName].actions[name])
+		}
+		if actions != nil {
+			created[name] = annotateActions(actions, discovered[name])
+		}
+	}
+	return created
+}
+
+// bulkInstances returns the provider instances a hostname routes to, and
+// whether its records can be created in bulk in all of them.
+func (r *Reconciler) bulkInstances(ctx context.Context, hostname *source.Hostname, cache *recordCache) ([]*provider.ProviderInstance, bool) {
+	var instances []*provider.ProviderInstance
+	if hints := hostname.RecordHints; hints != nil && hints.Provider != "" {
+		inst, exists := r.providers.Get(hints.Provider)
+		if !exists {
+			return nil, false
+		}
+		instances = []*provider.ProviderInstance{inst}
+	} else {
+		instances = r.providers.MatchingProviders(hostname.Name)
+	}
+	if len(instances) == 0 {
+		return nil, false
+	}
+	// With fallback only one instance is written, decided by ensureRecord
+	if r.config.ProviderFallback && len(instances) > 1 {
+		return nil, false
+	}
+
+	for _, inst := range instances {
+		if !inst.SupportsBulkCreate() || !cache.empty(inst.Name()) {
+			return nil, false
+		}
+		record := r.desiredRecord(hostname, inst)
+		if !inst.Provider.Capabilities().SupportsRecordType(record.Type) {
+			return nil, false
+		}
+		// Round-robin records are synced as a set by ensureRecordSet
+		if len(inst.DefaultTargets()) > 1 || (hostname.RecordHints != nil && len(hostname.RecordHints.Targets) > 1) {
+			return nil, false
+		}
+		if r.failures != nil {
+			if _, backedOff := r.failures.backedOff(inst.Name(), hostname.Name); backedOff {
+				return nil, false
+			}
+		}
+		// Hostnames the policy may deny go through ensureRecord, which records the denial
+		if r.policy != nil {
+			if allowed, err := r.policy.Allow(ctx, policy.Input{Hostname: hostname.Name, Provider: inst.Name(), Action: policy.ActionCreate}); !allowed || err != nil {
+				return nil, false
+			}
+		}
+	}
+	return instances, true
+}
+
+// desiredRecord returns the record a hostname should have in a provider
+// instance. RecordHints override the instance defaults.
+func (r *Reconciler) desiredRecord(hostname *source.Hostname, inst *provider.ProviderInstance) provider.Record {
+	record := provider.Record{
+		Hostname: hostname.Name,
+		Type:     inst.RecordType,
+		Target:   inst.Target,
+		TTL:      inst.TTL,
+		Weighted: r.weightedData(hostname, inst),
+	}
+
+	if hints := hostname.RecordHints; hints != nil {
+		record.Hints = hints.ProviderHints
+		if hints.Type != "" {
+			record.Type = provider.RecordType(hints.Type)
+		}
+		if hints.Target != "" {
+			record.Target = hints.Target
+		}
+		if hints.TTL > 0 {
+			record.TTL = hints.TTL
+		}
+		if hints.SRV != nil {
+			record.SRV = &provider.SRVData{
+				Priority: hints.SRV.Priority,
+				Weight:   hints.SRV.Weight,
+				Port:     hints.SRV.Port,
+			}
+		}
+	}
+	return record
+}
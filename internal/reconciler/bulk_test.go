@@ -0,0 +1,132 @@
+package reconciler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/audit"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/docker"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+	"gitlab.bluewillows.net/root/dnsweaver/sources/traefik"
+)
+
+// bulkMockProvider is a testMockProvider that implements provider.BulkCreator.
+type bulkMockProvider struct {
+	*testMockProvider
+	batches [][]provider.Record
+	bulkErr error
+}
+
+func (b *bulkMockProvider) BulkCreate(ctx context.Context, records []provider.Record) error {
+	b.batches = append(b.batches, records)
+	if b.bulkErr != nil {
+		return b.bulkErr
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.created = append(b.created, records...)
+	b.records = append(b.records, records...)
+	return nil
+}
+
+func newBulkTestReconciler(t *testing.T, p *bulkMockProvider, names ...string) *Reconciler {
+	t.Helper()
+
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	for _, name := range names {
+		dockerMock.AddWorkload(name, map[string]string{
+			"traefik.http.routers." + name + ".rule": "Host(`" + name + ".example.com`)",
+		})
+	}
+
+	logger := quietLogger()
+	sources := source.NewRegistry(logger)
+	sources.Register(traefik.New(traefik.WithLogger(logger)))
+
+	providers := provider.NewRegistry(logger)
+	providers.RegisterFactory("mock", func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		return p, nil
+	})
+	if err := providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       p.name,
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	}); err != nil {
+		t.Fatalf("CreateInstance() error = %v", err)
+	}
+
+	return New(dockerMock, sources, providers, WithLogger(logger))
+}
+
+func TestReconcile_BulkCreate(t *testing.T) {
+	p := &bulkMockProvider{testMockProvider: newTestMockProvider("test-dns")}
+	r := newBulkTestReconciler(t, p, "a", "b")
+	var buf bytes.Buffer
+	r.audit = audit.New(&buf)
+
+	result, err := r.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(p.batches) != 1 || len(p.batches[0]) != 4 {
+		t.Fatalf("bulk batches = %v, want one batch of 2 records and 2 ownership records", p.batches)
+	}
+	if result.CreatedCount() != 2 {
+		t.Errorf("CreatedCount() = %d, want 2", result.CreatedCount())
+	}
+	entries := readAuditEntries(t, &buf)
+	if len(entries) != 4 {
+		t.Errorf("audited %d entries, want 2 records and 2 ownership records", len(entries))
+	}
+	if owner := findAuditEntry(entries, "_dnsweaver.a.example.com"); owner.Action != "create" || owner.Status != "success" {
+		t.Errorf("unexpected ownership audit entry: %+v", owner)
+	}
+
+	// The provider is no longer empty, so later hostnames are created one by one
+	r.docker.(*testMockWorkloadLister).AddWorkload("c", map[string]string{
+		"traefik.http.routers.c.rule": "Host(`c.example.com`)",
+	})
+	result, err = r.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(p.batches) != 1 {
+		t.Errorf("bulk batches = %d, want 1", len(p.batches))
+	}
+	if result.CreatedCount() != 1 || len(p.GetCreatedDNSRecords()) != 3 {
+		t.Errorf("CreatedCount() = %d with %d records, want 1 with 3", result.CreatedCount(), len(p.GetCreatedDNSRecords()))
+	}
+}
+
+func TestReconcile_BulkCreateFallback(t *testing.T) {
+	p := &bulkMockProvider{testMockProvider: newTestMockProvider("test-dns"), bulkErr: errors.New("batch rejected")}
+	r := newBulkTestReconciler(t, p, "a", "b")
+	var buf bytes.Buffer
+	r.audit = audit.New(&buf)
+
+	result, err := r.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(p.batches) != 1 {
+		t.Errorf("bulk batches = %d, want 1", len(p.batches))
+	}
+	var failed int
+	for _, entry := range readAuditEntries(t, &buf) {
+		if entry.Status == "failed" && entry.Error == "batch rejected" {
+			failed++
+		}
+	}
+	if failed != 4 {
+		t.Errorf("audited %d failed bulk records, want 4", failed)
+	}
+	if result.CreatedCount() != 2 || len(p.GetCreatedDNSRecords()) != 2 {
+		t.Errorf("CreatedCount() = %d with %d records, want 2 created individually", result.CreatedCount(), len(p.GetCreatedDNSRecords()))
+	}
+}
@@ -5,19 +5,33 @@ package reconciler
 import (
 	"context"
 	"log/slog"
+	"sync"
+	"time"
 
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
 )
 
 // recordCache holds a snapshot of DNS records from all providers.
-// It is built once at the start of each reconciliation cycle and used
-// to avoid repeated List() API calls when checking existing records.
+// By default it is built at the start of each reconciliation cycle and used
+// to avoid repeated List() API calls when checking existing records. With a
+// record cache TTL, one cache is shared across reconciliations (see
+// Reconciler.loadRecordCache).
 // All hostname keys are normalized to lowercase for case-insensitive lookups.
 type recordCache struct {
 	// records maps provider name -> normalized hostname -> list of records
 	records map[string]map[string][]provider.Record
 	logger  *slog.Logger
+
+	// builtAt is when the cache was built, for TTL expiry
+	builtAt time.Time
+
+	// mu protects records and stale; a shared cache may be used by
+	// concurrent reconciliations
+	mu sync.RWMutex
+	// stale maps provider name -> normalized hostnames changed since caching.
+	// Stale hostnames are treated as not cached.
+	stale map[string]map[string]struct{}
 }
 
 // newRecordCache creates a new record cache by querying all providers.
@@ -26,44 +40,109 @@ func newRecordCache(ctx context.Context, providers *provider.Registry, logger *s
 	cache := &recordCache{
 		records: make(map[string]map[string][]provider.Record),
 		logger:  logger,
+		builtAt: time.Now(),
 	}
 
 	for _, inst := range providers.All() {
-		providerRecords, err := inst.Provider.List(ctx)
-		if err != nil {
-			logger.Warn("failed to cache records for provider",
-				slog.String("provider", inst.Name()),
-				slog.String("error", err.Error()),
-			)
-			// Store empty map so we know we tried but failed
-			cache.records[inst.Name()] = nil
-			continue
-		}
+		cache.load(ctx, inst)
+	}
 
-		// Index records by normalized hostname for case-insensitive lookup (RFC 1035)
-		byHostname := make(map[string][]provider.Record)
-		for _, r := range providerRecords {
-			normalized := source.NormalizeHostname(r.Hostname)
-			byHostname[normalized] = append(byHostname[normalized], r)
-		}
+	return cache
+}
+
+// load lists one provider's records into the cache, replacing any previous
+// entry and clearing its stale hostnames.
+func (c *recordCache) load(ctx context.Context, inst *provider.ProviderInstance) {
+	providerRecords, err := inst.Provider.List(ctx)
 
-		cache.records[inst.Name()] = byHostname
-		logger.Debug("cached records for provider",
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.stale, inst.Name())
+
+	if err != nil {
+		c.logger.Warn("failed to cache records for provider",
 			slog.String("provider", inst.Name()),
-			slog.Int("total_records", len(providerRecords)),
-			slog.Int("unique_hostnames", len(byHostname)),
+			slog.String("error", err.Error()),
 		)
+		// Store empty map so we know we tried but failed
+		c.records[inst.Name()] = nil
+		return
 	}
 
-	return cache
+	// Index records by normalized hostname for case-insensitive lookup (RFC 1035)
+	byHostname := make(map[string][]provider.Record)
+	for _, r := range providerRecords {
+		normalized := source.NormalizeHostname(r.Hostname)
+		byHostname[normalized] = append(byHostname[normalized], r)
+	}
+
+	c.records[inst.Name()] = byHostname
+	c.logger.Debug("cached records for provider",
+		slog.String("provider", inst.Name()),
+		slog.Int("total_records", len(providerRecords)),
+		slog.Int("unique_hostnames", len(byHostname)),
+	)
 }
 
-// getExistingRecords returns cached DNS records for a hostname from a specific provider.
-// Returns A, AAAA, CNAME, and SRV records (excludes TXT ownership records).
-// Returns nil if the provider cache is unavailable (failed to load).
-// Returns empty slice if cached but no records exist for this hostname.
-// Hostname lookup is case-insensitive per RFC 1035.
-func (c *recordCache) getExistingRecords(providerName, hostname string) ([]provider.Record, bool) {
+// refresh re-lists providers that failed to load, have stale hostnames, or
+// were added since the cache was built. Used when a shared cache is reused.
+func (c *recordCache) refresh(ctx context.Context, providers *provider.Registry) {
+	for _, inst := range providers.All() {
+		c.mu.RLock()
+		byHostname, cached := c.records[inst.Name()]
+		stale := len(c.stale[inst.Name()]) > 0
+		c.mu.RUnlock()
+
+		if !cached || byHostname == nil || stale {
+			c.load(ctx, inst)
+		}
+	}
+}
+
+// empty reports whether a provider's records loaded and it held none, with
+// no hostnames changed since.
+func (c *recordCache) empty(providerName string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	byHostname, exists := c.records[providerName]
+	return exists && byHostname != nil && len(byHostname) == 0 && len(c.stale[providerName]) == 0
+}
+
+// invalidate marks a hostname as changed in a provider so lookups stop
+// trusting the cached records. Safe to call on a nil cache.
+func (c *recordCache) invalidate(providerName, hostname string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stale == nil {
+		c.stale = make(map[string]map[string]struct{})
+	}
+	if c.stale[providerName] == nil {
+		c.stale[providerName] = make(map[string]struct{})
+	}
+	c.stale[providerName][source.NormalizeHostname(hostname)] = struct{}{}
+}
+
+// invalidateActions invalidates every hostname an action may have changed.
+// Skipped actions leave the provider untouched and are ignored.
+func (c *recordCache) invalidateActions(actions []Action) {
+	for _, action := range actions {
+		if action.Type != ActionSkip && action.Provider != "" {
+			c.invalidate(action.Provider, action.Hostname)
+		}
+	}
+}
+
+// lookup returns the cached records for a hostname in a provider.
+// ok is false if the provider failed to load or the hostname is stale.
+func (c *recordCache) lookup(providerName, hostname string) ([]provider.Record, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	byHostname, exists := c.records[providerName]
 	if !exists || byHostname == nil {
 		// Provider not cached or failed to load
@@ -71,7 +150,23 @@ func (c *recordCache) getExistingRecords(providerName, hostname string) ([]provi
 	}
 
 	normalized := source.NormalizeHostname(hostname)
-	records := byHostname[normalized]
+	if _, stale := c.stale[providerName][normalized]; stale {
+		return nil, false
+	}
+	return byHostname[normalized], true
+}
+
+// getExistingRecords returns cached DNS records for a hostname from a specific provider.
+// Returns A, AAAA, CNAME, and SRV records (excludes TXT ownership records).
+// Returns nil if the provider cache is unavailable (failed to load) or the
+// hostname was invalidated.
+// Returns empty slice if cached but no records exist for this hostname.
+// Hostname lookup is case-insensitive per RFC 1035.
+func (c *recordCache) getExistingRecords(providerName, hostname string) ([]provider.Record, bool) {
+	records, ok := c.lookup(providerName, hostname)
+	if !ok {
+		return nil, false
+	}
 
 	// Filter to DNS data records (exclude TXT ownership markers)
 	var filtered []provider.Record
@@ -93,15 +188,11 @@ func (c *recordCache) getExistingRecords(providerName, hostname string) ([]provi
 // Returns empty slice if cached but no records exist for this hostname.
 // Hostname lookup is case-insensitive per RFC 1035.
 func (c *recordCache) getAllRecordsForHostname(providerName, hostname string) ([]provider.Record, bool) {
-	byHostname, exists := c.records[providerName]
-	if !exists || byHostname == nil {
-		// Provider not cached or failed to load
+	records, ok := c.lookup(providerName, hostname)
+	if !ok {
 		return nil, false
 	}
 
-	normalized := source.NormalizeHostname(hostname)
-	records := byHostname[normalized]
-
 	// Filter to data records (A, AAAA, CNAME, SRV) - exclude TXT ownership records
 	var filtered []provider.Record
 	for _, r := range records {
@@ -117,20 +208,16 @@ func (c *recordCache) getAllRecordsForHostname(providerName, hostname string) ([
 }
 
 // hasOwnershipRecord checks if an ownership TXT record exists for the given hostname.
-// Returns false if the provider cache is unavailable.
+// Returns false if the provider cache is unavailable or the hostname is stale.
 // Hostname lookup is case-insensitive per RFC 1035.
 func (c *recordCache) hasOwnershipRecord(providerName, hostname string) bool {
-	byHostname, exists := c.records[providerName]
-	if !exists || byHostname == nil {
+	if _, ok := c.lookup(providerName, hostname); !ok {
 		return false
 	}
-
-	ownershipName := provider.OwnershipRecordName(hostname)
-	normalized := source.NormalizeHostname(ownershipName)
-	records := byHostname[normalized]
+	records, _ := c.lookup(providerName, provider.OwnershipRecordName(hostname))
 
 	for _, r := range records {
-		if r.Type == provider.RecordTypeTXT && r.Target == provider.OwnershipValue {
+		if r.Type == provider.RecordTypeTXT && provider.IsOwnershipValue(r.Target) {
 			return true
 		}
 	}
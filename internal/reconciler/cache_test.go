@@ -1,10 +1,15 @@
 package reconciler
 
 import (
+	"context"
 	"log/slog"
 	"testing"
+	"time"
 
+	"gitlab.bluewillows.net/root/dnsweaver/internal/docker"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+	"gitlab.bluewillows.net/root/dnsweaver/sources/traefik"
 )
 
 func TestRecordCache_HasOwnershipRecord(t *testing.T) {
@@ -172,3 +177,128 @@ func TestRecordCache_GetExistingRecords(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordCache_Invalidate(t *testing.T) {
+	cache := &recordCache{
+		records: map[string]map[string][]provider.Record{
+			"test-provider": {
+				"app.example.com": {
+					{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"},
+				},
+				"_dnsweaver.app.example.com": {
+					{Hostname: "_dnsweaver.app.example.com", Type: provider.RecordTypeTXT, Target: "heritage=dnsweaver"},
+				},
+				"other.example.com": {
+					{Hostname: "other.example.com", Type: provider.RecordTypeA, Target: "10.0.0.2"},
+				},
+			},
+		},
+		logger: slog.Default(),
+	}
+
+	cache.invalidate("test-provider", "APP.example.com")
+
+	if _, cached := cache.getExistingRecords("test-provider", "app.example.com"); cached {
+		t.Error("expected invalidated hostname to be uncached")
+	}
+	if _, cached := cache.getAllRecordsForHostname("test-provider", "app.example.com"); cached {
+		t.Error("expected invalidated hostname to be uncached in getAllRecordsForHostname")
+	}
+	if cache.hasOwnershipRecord("test-provider", "app.example.com") {
+		t.Error("expected no cached ownership for invalidated hostname")
+	}
+	if records, cached := cache.getExistingRecords("test-provider", "other.example.com"); !cached || len(records) != 1 {
+		t.Errorf("other hostname should stay cached, got %v (cached=%v)", records, cached)
+	}
+
+	// Nil caches ignore invalidation
+	var nilCache *recordCache
+	nilCache.invalidate("test-provider", "app.example.com")
+}
+
+func TestReconcile_RecordCacheTTL(t *testing.T) {
+	newReconciler := func(opts ...Option) (*Reconciler, *testMockProvider) {
+		dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+		dockerMock.AddWorkload("my-app", map[string]string{
+			"traefik.http.routers.myapp.rule": "Host(`app.example.com`)",
+		})
+
+		logger := quietLogger()
+		sources := source.NewRegistry(logger)
+		sources.Register(traefik.New(traefik.WithLogger(logger)))
+
+		mockProvider := newTestMockProvider("test-dns")
+		providers := testProviderRegistry(logger, mockProvider)
+		_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+			Name:       "test-dns",
+			TypeName:   "mock",
+			RecordType: provider.RecordTypeA,
+			Target:     "10.0.0.1",
+			TTL:        300,
+			Domains:    []string{"*.example.com"},
+		})
+
+		opts = append(opts, WithConfig(DefaultConfig()), WithLogger(logger))
+		return New(dockerMock, sources, providers, opts...), mockProvider
+	}
+
+	listCalls := func(m *testMockProvider) int {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.listCalls
+	}
+
+	t.Run("shared cache is reused and refreshed after changes", func(t *testing.T) {
+		r, mockProvider := newReconciler(WithRecordCacheTTL(time.Minute))
+
+		for i := 0; i < 3; i++ {
+			if _, err := r.Reconcile(context.Background()); err != nil {
+				t.Fatalf("Reconcile %d failed: %v", i, err)
+			}
+		}
+
+		// First run lists and creates; the create invalidates the hostname so
+		// the second run re-lists; the third run reuses the cache as is
+		if got := listCalls(mockProvider); got != 2 {
+			t.Errorf("List() called %d times, want 2", got)
+		}
+		if got := len(mockProvider.GetCreatedDNSRecords()); got != 1 {
+			t.Errorf("created %d records, want 1", got)
+		}
+	})
+
+	t.Run("default builds a cache per reconciliation", func(t *testing.T) {
+		r, mockProvider := newReconciler()
+
+		for i := 0; i < 3; i++ {
+			if _, err := r.Reconcile(context.Background()); err != nil {
+				t.Fatalf("Reconcile %d failed: %v", i, err)
+			}
+		}
+		if got := listCalls(mockProvider); got != 3 {
+			t.Errorf("List() called %d times, want 3", got)
+		}
+	})
+
+	t.Run("expired cache is rebuilt", func(t *testing.T) {
+		r, mockProvider := newReconciler(WithRecordCacheTTL(time.Minute))
+
+		for i := 0; i < 2; i++ {
+			if _, err := r.Reconcile(context.Background()); err != nil {
+				t.Fatalf("Reconcile %d failed: %v", i, err)
+			}
+		}
+		before := listCalls(mockProvider)
+
+		r.cacheMu.Lock()
+		r.sharedCache.builtAt = time.Now().Add(-2 * time.Minute)
+		r.cacheMu.Unlock()
+
+		if _, err := r.Reconcile(context.Background()); err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+		if got := listCalls(mockProvider) - before; got != 1 {
+			t.Errorf("List() called %d times after expiry, want 1", got)
+		}
+	})
+}
@@ -0,0 +1,327 @@
+package reconciler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+)
+
+// ChangeHandler observes the DNS record changes made by the reconciler.
+// Methods are called synchronously after each reconciliation for every
+// attempted mutation, so implementations must return quickly.
+// Dry-run and skipped actions are not reported.
+type ChangeHandler interface {
+	// OnCreated is called after a record was created.
+	OnCreated(hostname, provider, recordType, target string)
+	// OnDeleted is called after a record was deleted.
+	OnDeleted(hostname, provider, recordType, target string)
+	// OnFailed is called when a create, update, or delete failed.
+	// action is the attempted action type.
+	OnFailed(hostname, provider, recordType, target, action string, err error)
+}
+
+// changeResult reports every attempted mutation in the result to the change
+// handlers. An update is reported as a delete of the old target followed by
+// a create of the new one.
+func (r *Reconciler) changeResult(result *Result) {
+	if len(r.changes) == 0 {
+		return
+	}
+
+	for _, action := range result.Actions {
+		if action.DryRun || action.Type == ActionSkip {
+			continue
+		}
+		for _, h := range r.changes {
+			switch {
+			case action.Status == StatusFailed:
+				h.OnFailed(action.Hostname, action.Provider, action.RecordType, action.Target,
+					string(action.Type), errors.New(action.Error))
+			case action.Status != StatusSuccess:
+				continue
+			case action.Type == ActionCreate:
+				h.OnCreated(action.Hostname, action.Provider, action.RecordType, action.Target)
+			case action.Type == ActionDelete:
+				h.OnDeleted(action.Hostname, action.Provider, action.RecordType, action.Target)
+			case action.Type == ActionUpdate:
+				h.OnDeleted(action.Hostname, action.Provider, action.RecordType, action.OldTarget)
+				h.OnCreated(action.Hostname, action.Provider, action.RecordType, action.Target)
+			}
+		}
+	}
+}
+
+// LogChangeHandler logs every record change at INFO level.
+type LogChangeHandler struct {
+	logger *slog.Logger
+}
+
+// NewLogChangeHandler creates a ChangeHandler that writes to logger
+// (slog.Default() when nil).
+func NewLogChangeHandler(logger *slog.Logger) *LogChangeHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogChangeHandler{logger: logger}
+}
+
+// OnCreated logs the created record.
+func (h *LogChangeHandler) OnCreated(hostname, provider, recordType, target string) {
+	h.logger.Info("record change: created",
+		slog.String("hostname", hostname),
+		slog.String("provider", provider),
+		slog.String("record_type", recordType),
+		slog.String("target", target),
+	)
+}
+
+// OnDeleted logs the deleted record.
+func (h *LogChangeHandler) OnDeleted(hostname, provider, recordType, target string) {
+	h.logger.Info("record change: deleted",
+		slog.String("hostname", hostname),
+		slog.String("provider", provider),
+		slog.String("record_type", recordType),
+		slog.String("target", target),
+	)
+}
+
+// OnFailed logs the failed change.
+func (h *LogChangeHandler) OnFailed(hostname, provider, recordType, target, action string, err error) {
+	h.logger.Info("record change: failed",
+		slog.String("action", action),
+		slog.String("hostname", hostname),
+		slog.String("provider", provider),
+		slog.String("record_type", recordType),
+		slog.String("target", target),
+		slog.String("error", err.Error()),
+	)
+}
+
+// Change event types sent by WebhookChangeHandler.
+const (
+	ChangeEventCreated = "created"
+	ChangeEventDeleted = "deleted"
+	ChangeEventFailed  = "failed"
+)
+
+// DefaultChangeWebhookTimeout bounds each change event request.
+const DefaultChangeWebhookTimeout = 10 * time.Second
+
+// defaultChangeQueueSize is the number of events buffered for delivery.
+const defaultChangeQueueSize = 256
+
+// ChangeEvent is the JSON body posted by WebhookChangeHandler.
+type ChangeEvent struct {
+	Event      string    `json:"event"`
+	Action     string    `json:"action,omitempty"`
+	Hostname   string    `json:"hostname"`
+	Provider   string    `json:"provider"`
+	RecordType string    `json:"record_type"`
+	Target     string    `json:"target,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// WebhookChangeOption is a functional option for WebhookChangeHandler.
+type WebhookChangeOption func(*WebhookChangeHandler)
+
+// WithChangeWebhookHTTPClient sets a custom HTTP client.
+func WithChangeWebhookHTTPClient(client *http.Client) WebhookChangeOption {
+	return func(h *WebhookChangeHandler) {
+		if client != nil {
+			h.httpClient = client
+		}
+	}
+}
+
+// WithChangeWebhookLogger sets the logger used to report delivery failures.
+func WithChangeWebhookLogger(logger *slog.Logger) WebhookChangeOption {
+	return func(h *WebhookChangeHandler) {
+		if logger != nil {
+			h.logger = logger
+		}
+	}
+}
+
+// WebhookChangeHandler posts each record change as a ChangeEvent to a URL.
+// Events are queued and delivered in order by a background worker so a slow
+// endpoint does not hold up reconciliation; events are dropped with a warning
+// when the queue is full. Call Close to flush pending events on shutdown.
+type WebhookChangeHandler struct {
+	url        string
+	httpClient *http.Client
+	logger     *slog.Logger
+
+	queue  chan ChangeEvent
+	done   chan struct{}
+	cancel context.CancelFunc // aborts delivery when Close gives up
+
+	// mu guards closed and sends on queue
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewWebhookChangeHandler creates a ChangeHandler that posts to url and starts
+// its delivery worker.
+func NewWebhookChangeHandler(url string, opts ...WebhookChangeOption) *WebhookChangeHandler {
+	h := &WebhookChangeHandler{
+		url:        url,
+		httpClient: httputil.NewClient(&httputil.ClientConfig{Timeout: DefaultChangeWebhookTimeout}),
+		logger:     slog.Default(),
+		queue:      make(chan ChangeEvent, defaultChangeQueueSize),
+		done:       make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	go h.run(ctx)
+	return h
+}
+
+// OnCreated queues a "created" event.
+func (h *WebhookChangeHandler) OnCreated(hostname, provider, recordType, target string) {
+	h.enqueue(ChangeEvent{
+		Event:      ChangeEventCreated,
+		Hostname:   hostname,
+		Provider:   provider,
+		RecordType: recordType,
+		Target:     target,
+	})
+}
+
+// OnDeleted queues a "deleted" event.
+func (h *WebhookChangeHandler) OnDeleted(hostname, provider, recordType, target string) {
+	h.enqueue(ChangeEvent{
+		Event:      ChangeEventDeleted,
+		Hostname:   hostname,
+		Provider:   provider,
+		RecordType: recordType,
+		Target:     target,
+	})
+}
+
+// OnFailed queues a "failed" event.
+func (h *WebhookChangeHandler) OnFailed(hostname, provider, recordType, target, action string, err error) {
+	event := ChangeEvent{
+		Event:      ChangeEventFailed,
+		Action:     action,
+		Hostname:   hostname,
+		Provider:   provider,
+		RecordType: recordType,
+		Target:     target,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	h.enqueue(event)
+}
+
+// Close stops accepting events and waits until queued events are delivered
+// or ctx is done. In the latter case the request in flight is aborted, the
+// remaining events are dropped, and ctx's error is returned. Changes received
+// after Close are dropped.
+func (h *WebhookChangeHandler) Close(ctx context.Context) error {
+	defer h.cancel()
+
+	h.mu.Lock()
+	if !h.closed {
+		h.closed = true
+		close(h.queue)
+	}
+	h.mu.Unlock()
+
+	select {
+	case <-h.done:
+		return nil
+	case <-ctx.Done():
+		h.cancel()
+		<-h.done
+		return ctx.Err()
+	}
+}
+
+func (h *WebhookChangeHandler) enqueue(event ChangeEvent) {
+	event.Timestamp = time.Now().UTC()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		h.logger.Debug("change webhook closed, dropping event",
+			slog.String("event", event.Event),
+			slog.String("hostname", event.Hostname),
+		)
+		return
+	}
+
+	select {
+	case h.queue <- event:
+	default:
+		h.logger.Warn("change webhook queue full, dropping event",
+			slog.String("event", event.Event),
+			slog.String("hostname", event.Hostname),
+		)
+	}
+}
+
+func (h *WebhookChangeHandler) run(ctx context.Context) {
+	defer close(h.done)
+
+	dropped := 0
+	for event := range h.queue {
+		if ctx.Err() != nil {
+			dropped++
+			continue
+		}
+		if err := h.post(ctx, event); err != nil {
+			h.logger.Warn("failed to deliver change event",
+				slog.String("event", event.Event),
+				slog.String("hostname", event.Hostname),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	if dropped > 0 {
+		h.logger.Warn("change webhook closed before delivering all events",
+			slog.Int("dropped", dropped),
+		)
+	}
+}
+
+// post sends event as JSON and expects a 2xx response.
+func (h *WebhookChangeHandler) post(ctx context.Context, event ChangeEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/docker"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+	"gitlab.bluewillows.net/root/dnsweaver/sources/traefik"
+)
+
+// recordingChangeHandler captures change callbacks as "event hostname target" strings.
+type recordingChangeHandler struct {
+	events []string
+}
+
+func (h *recordingChangeHandler) OnCreated(hostname, _, _, target string) {
+	h.events = append(h.events, "created "+hostname+" "+target)
+}
+
+func (h *recordingChangeHandler) OnDeleted(hostname, _, _, target string) {
+	h.events = append(h.events, "deleted "+hostname+" "+target)
+}
+
+func (h *recordingChangeHandler) OnFailed(hostname, _, _, target, action string, err error) {
+	h.events = append(h.events, "failed "+action+" "+hostname+" "+target+": "+err.Error())
+}
+
+func TestReconcile_ChangeHandler(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	dockerMock.AddWorkload("my-app", map[string]string{
+		"traefik.http.routers.myapp.rule": "Host(`app.example.com`)",
+	})
+
+	logger := quietLogger()
+
+	sources := source.NewRegistry(logger)
+	sources.Register(traefik.New(traefik.WithLogger(logger)))
+
+	mockProvider := newTestMockProvider("test-dns")
+	providers := testProviderRegistry(logger, mockProvider)
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	handler := &recordingChangeHandler{}
+	r := New(dockerMock, sources, providers,
+		WithConfig(DefaultConfig()),
+		WithLogger(logger),
+		WithChangeHandler(handler),
+	)
+
+	if _, err := r.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	if len(handler.events) != 1 || handler.events[0] != "created app.example.com 10.0.0.1" {
+		t.Errorf("events = %q, want one create", handler.events)
+	}
+}
+
+func TestChangeResult(t *testing.T) {
+	handler := &recordingChangeHandler{}
+	r := &Reconciler{changes: []ChangeHandler{handler}}
+
+	r.changeResult(&Result{Actions: []Action{
+		{Type: ActionCreate, Status: StatusSuccess, Hostname: "a.example.com", Target: "10.0.0.1"},
+		{Type: ActionUpdate, Status: StatusSuccess, Hostname: "b.example.com", OldTarget: "10.0.0.1", Target: "10.0.0.2"},
+		{Type: ActionDelete, Status: StatusSuccess, Hostname: "c.example.com", Target: "10.0.0.3"},
+		{Type: ActionCreate, Status: StatusFailed, Hostname: "d.example.com", Target: "10.0.0.4", Error: "boom"},
+		{Type: ActionCreate, Status: StatusSuccess, Hostname: "e.example.com", DryRun: true},
+		{Type: ActionSkip, Status: StatusSuccess, Hostname: "f.example.com"},
+	}})
+
+	want := []string{
+		"created a.example.com 10.0.0.1",
+		"deleted b.example.com 10.0.0.1",
+		"created b.example.com 10.0.0.2",
+		"deleted c.example.com 10.0.0.3",
+		"failed create d.example.com 10.0.0.4: boom",
+	}
+	if strings.Join(handler.events, "\n") != strings.Join(want, "\n") {
+		t.Errorf("events = %q, want %q", handler.events, want)
+	}
+}
+
+func TestWebhookChangeHandler(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []ChangeEvent
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		var event ChangeEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("decoding event: %v", err)
+		}
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	h := NewWebhookChangeHandler(server.URL, WithChangeWebhookLogger(quietLogger()))
+	h.OnCreated("app.example.com", "test-dns", "A", "10.0.0.1")
+	h.OnFailed("app.example.com", "test-dns", "A", "10.0.0.1", "delete", errors.New("boom"))
+	if err := h.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", events)
+	}
+	if e := events[0]; e.Event != ChangeEventCreated || e.Hostname != "app.example.com" ||
+		e.Provider != "test-dns" || e.RecordType != "A" || e.Target != "10.0.0.1" || e.Timestamp.IsZero() {
+		t.Errorf("unexpected created event: %+v", e)
+	}
+	if e := events[1]; e.Event != ChangeEventFailed || e.Action != "delete" || e.Error != "boom" {
+		t.Errorf("unexpected failed event: %+v", e)
+	}
+}
+
+func TestWebhookChangeHandler_CloseDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	h := NewWebhookChangeHandler(server.URL, WithChangeWebhookLogger(quietLogger()))
+	for i := 0; i < 3; i++ {
+		h.OnCreated("app.example.com", "test-dns", "A", "10.0.0.1")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := h.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Close() = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Close took %v with a hanging endpoint", elapsed)
+	}
+}
+
+func TestWebhookChangeHandler_DropsEventsAfterClose(t *testing.T) {
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts.Add(1)
+	}))
+	defer server.Close()
+
+	h := NewWebhookChangeHandler(server.URL, WithChangeWebhookLogger(quietLogger()))
+	if err := h.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Must not panic on the closed queue
+	h.OnCreated("app.example.com", "test-dns", "A", "10.0.0.1")
+	h.OnDeleted("app.example.com", "test-dns", "A", "10.0.0.1")
+
+	if err := h.Close(context.Background()); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+	if got := posts.Load(); got != 0 {
+		t.Errorf("expected no events delivered after Close, got %d", got)
+	}
+}
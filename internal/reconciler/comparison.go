@@ -163,6 +163,16 @@ func recordNeedsUpdate(existing, desired provider.Record) bool {
 	return false
 }
 
+// TypesConflict reports whether records of the two types cannot coexist at the
+// same name. A CNAME must be the only record at its name, so it conflicts with
+// every other type; other types (e.g. A and AAAA for dual-stack) coexist.
+func TypesConflict(existing, desired provider.RecordType) bool {
+	if existing == desired {
+		return false
+	}
+	return existing == provider.RecordTypeCNAME || desired == provider.RecordTypeCNAME
+}
+
 // CategorizeSameHostnameRecords groups records by whether they match the desired type.
 // Returns (sameType, differentType) slices.
 // This is used when checking for type conflicts before creating a record.
@@ -281,6 +281,25 @@ func TestCategorizeSameHostnameRecords(t *testing.T) {
 	}
 }
 
+func TestTypesConflict(t *testing.T) {
+	tests := []struct {
+		existing, desired provider.RecordType
+		want              bool
+	}{
+		{provider.RecordTypeA, provider.RecordTypeA, false},
+		{provider.RecordTypeA, provider.RecordTypeAAAA, false},
+		{provider.RecordTypeAAAA, provider.RecordTypeA, false},
+		{provider.RecordTypeA, provider.RecordTypeCNAME, true},
+		{provider.RecordTypeCNAME, provider.RecordTypeAAAA, true},
+		{provider.RecordTypeTXT, provider.RecordTypeA, false},
+	}
+	for _, tt := range tests {
+		if got := TypesConflict(tt.existing, tt.desired); got != tt.want {
+			t.Errorf("TypesConflict(%s, %s) = %v, want %v", tt.existing, tt.desired, got, tt.want)
+		}
+	}
+}
+
 func TestFindExactMatch(t *testing.T) {
 	records := []provider.Record{
 		{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"},
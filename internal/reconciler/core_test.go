@@ -1,10 +1,15 @@
 package reconciler
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log/slog"
+	"strings"
 	"testing"
+	"time"
 
+	"gitlab.bluewillows.net/root/dnsweaver/internal/audit"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
 )
@@ -232,6 +237,57 @@ func TestEnsureRecord_SkipsTypeConflict(t *testing.T) {
 	}
 }
 
+func TestEnsureRecord_AAAACoexistsWithA(t *testing.T) {
+	mock := newTestMockProvider("dns-v6")
+	// Existing A record from the IPv4 instance
+	mock.AddRecord(provider.Record{
+		Hostname: "app.example.com",
+		Type:     provider.RecordTypeA,
+		Target:   "10.0.0.1",
+		TTL:      300,
+	})
+
+	logger := quietLogger()
+	providers := provider.NewRegistry(logger)
+	providers.RegisterFactory("mock", func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		return mock, nil
+	})
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "dns-v6",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeAAAA,
+		Target:     "2001:db8::1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	cache := newRecordCache(context.Background(), providers, logger)
+
+	r := &Reconciler{
+		providers:      providers,
+		config:         DefaultConfig(),
+		logger:         logger,
+		knownHostnames: make(map[string]struct{}),
+	}
+
+	hostname := &source.Hostname{Name: "app.example.com", Source: "test"}
+	actions := r.ensureRecord(context.Background(), hostname, cache)
+
+	if len(actions) != 1 {
+		t.Fatalf("expected 1 action, got %d", len(actions))
+	}
+	if actions[0].Type != ActionCreate || actions[0].Status != StatusSuccess {
+		t.Errorf("expected successful create, got %v/%v (%s)", actions[0].Type, actions[0].Status, actions[0].Error)
+	}
+	created := mock.GetCreatedDNSRecords()
+	if len(created) != 1 || created[0].Type != provider.RecordTypeAAAA || created[0].Target != "2001:db8::1" {
+		t.Errorf("expected AAAA record to be created alongside the A record, got %+v", created)
+	}
+	if len(mock.GetDeleted()) != 0 {
+		t.Errorf("expected existing A record to be kept, got deletions %+v", mock.GetDeleted())
+	}
+}
+
 func TestEnsureRecord_NoMatchingProvider(t *testing.T) {
 	mock := newTestMockProvider("test-dns")
 
@@ -376,6 +432,65 @@ func TestEnsureRecord_WithRecordHints(t *testing.T) {
 	}
 }
 
+func TestEnsureRecord_WeightHint(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		weighted bool
+		want     *provider.WeightedData
+		warnings int
+	}{
+		{name: "supported", weighted: true, want: &provider.WeightedData{Weight: 20}},
+		{name: "unsupported", weighted: false, want: nil, warnings: 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newTestMockProvider("test-dns")
+			mock.weighted = tt.weighted
+
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelWarn}))
+			providers := testProviderRegistry(quietLogger(), mock)
+			_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+				Name:       "test-dns",
+				TypeName:   "mock",
+				RecordType: provider.RecordTypeA,
+				Target:     "10.0.0.1",
+				TTL:        300,
+				Domains:    []string{"*.example.com"},
+			})
+
+			r := &Reconciler{
+				providers:      providers,
+				config:         DefaultConfig(),
+				logger:         logger,
+				knownHostnames: make(map[string]struct{}),
+			}
+
+			// The dropped weight is only logged for the first hostname
+			for _, name := range []string{"app.example.com", "api.example.com"} {
+				hostname := &source.Hostname{
+					Name:        name,
+					Source:      "test",
+					RecordHints: &source.RecordHints{Weight: 20},
+				}
+				r.ensureRecord(context.Background(), hostname, nil)
+			}
+
+			var got *provider.WeightedData
+			for _, c := range mock.GetCreated() {
+				if c.Type == provider.RecordTypeA {
+					got = c.Weighted
+				}
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("Weighted = %+v, want %+v", got, tt.want)
+			}
+			if got := strings.Count(logs.String(), "does not support weighted records"); got != tt.warnings {
+				t.Errorf("got %d weight warnings, want %d:\n%s", got, tt.warnings, logs.String())
+			}
+		})
+	}
+}
+
 func TestEnsureRecord_ExplicitProviderHint(t *testing.T) {
 	mock1 := newTestMockProvider("internal-dns")
 	mock2 := newTestMockProvider("external-dns")
@@ -498,6 +613,9 @@ func TestEnsureRecord_ProviderCreateFails(t *testing.T) {
 	if !containsHelper(actions[0].Error, "network timeout") {
 		t.Errorf("expected 'network timeout' in error, got %q", actions[0].Error)
 	}
+	if actions[0].ErrorCode != provider.CodeUnknown {
+		t.Errorf("ErrorCode = %q, want %q for an unclassified error", actions[0].ErrorCode, provider.CodeUnknown)
+	}
 }
 
 // =============================================================================
@@ -725,6 +843,9 @@ func TestCleanupOrphans_DeletesRemovedHostnames(t *testing.T) {
 		"current.example.com": {Name: "current.example.com", Source: "test"},
 	}
 
+	var buf bytes.Buffer
+	r.audit = audit.New(&buf)
+
 	actions := r.cleanupOrphans(context.Background(), currentHostnames, cache)
 
 	// Should have actions for deleting old.example.com
@@ -741,6 +862,62 @@ func TestCleanupOrphans_DeletesRemovedHostnames(t *testing.T) {
 	if !foundDelete {
 		t.Error("expected delete action for old.example.com")
 	}
+
+	// The ownership record has no action and is audited directly
+	owner := findAuditEntry(readAuditEntries(t, &buf), "_dnsweaver.old.example.com")
+	if owner.Action != "delete" || owner.Status != "success" || owner.RecordType != "TXT" {
+		t.Errorf("unexpected ownership audit entry: %+v", owner)
+	}
+}
+
+func TestCleanupOrphans_OwnershipUsesRecordCache(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+	orphans := []string{"a.example.com", "b.example.com", "c.example.com"}
+	known := map[string]struct{}{}
+	for _, hostname := range orphans {
+		mock.AddRecord(provider.Record{Hostname: hostname, Type: provider.RecordTypeA, Target: "10.0.0.1"})
+		mock.AddRecord(provider.Record{
+			Hostname: provider.OwnershipRecordName(hostname),
+			Type:     provider.RecordTypeTXT,
+			Target:   "heritage=dnsweaver,version=1,source=traefik",
+		})
+		known[hostname] = struct{}{}
+	}
+
+	logger := quietLogger()
+	providers := testProviderRegistry(logger, mock)
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	cache := newRecordCache(context.Background(), providers, logger)
+	listed := mock.listCalls
+
+	r := &Reconciler{
+		providers:      providers,
+		config:         Config{CleanupOrphans: true, OwnershipTracking: true, Enabled: true},
+		logger:         logger,
+		knownHostnames: known,
+	}
+	r.cleanupOrphans(context.Background(), map[string]*source.Hostname{}, cache)
+
+	if extra := mock.listCalls - listed; extra != 0 {
+		t.Errorf("orphan cleanup listed the provider %d more times, want 0", extra)
+	}
+	var ownershipDeleted int
+	for _, rec := range mock.GetDeleted() {
+		if rec.Type == provider.RecordTypeTXT && rec.Target == "heritage=dnsweaver,version=1,source=traefik" {
+			ownershipDeleted++
+		}
+	}
+	if ownershipDeleted != len(orphans) {
+		t.Errorf("deleted %d versioned ownership records, want %d", ownershipDeleted, len(orphans))
+	}
 }
 
 func TestCleanupOrphans_SkipsUnownedRecords(t *testing.T) {
@@ -854,15 +1031,15 @@ func TestEnsureOwnershipRecord_CreatesWhenEnabled(t *testing.T) {
 	}
 
 	inst, _ := providers.Get("test-dns")
-	r.ensureOwnershipRecord(context.Background(), "app.example.com", inst)
+	r.ensureOwnershipRecord(context.Background(), &source.Hostname{Name: "app.example.com", Source: "traefik"}, inst, nil)
 
 	created := mock.GetCreated()
 	var foundOwnership bool
 	for _, c := range created {
 		if c.Hostname == "_dnsweaver.app.example.com" && c.Type == provider.RecordTypeTXT {
 			foundOwnership = true
-			if c.Target != "heritage=dnsweaver" {
-				t.Errorf("expected ownership value 'heritage=dnsweaver', got %q", c.Target)
+			if c.Target != "heritage=dnsweaver,version=1,source=traefik" {
+				t.Errorf("expected ownership value 'heritage=dnsweaver,version=1,source=traefik', got %q", c.Target)
 			}
 		}
 	}
@@ -896,7 +1073,7 @@ func TestEnsureOwnershipRecord_SkipsWhenDisabled(t *testing.T) {
 	}
 
 	inst, _ := providers.Get("test-dns")
-	r.ensureOwnershipRecord(context.Background(), "app.example.com", inst)
+	r.ensureOwnershipRecord(context.Background(), &source.Hostname{Name: "app.example.com"}, inst, nil)
 
 	created := mock.GetCreated()
 	for _, c := range created {
@@ -1201,3 +1378,86 @@ func TestCleanupOrphans_AuthoritativeMode_DeletesAll(t *testing.T) {
 		t.Error("unowned record should be deleted in authoritative mode (ignores ownership)")
 	}
 }
+
+func TestEnsureRecord_RetriesFailedCreate(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		err          error
+		wantStatus   ActionStatus
+		wantAttempts int
+	}{
+		{"succeeds after retries", 2, provider.ErrProviderUnavailable, StatusSuccess, 3},
+		{"gives up after attempts", 5, provider.ErrProviderUnavailable, StatusFailed, 3},
+		{"circuit open not retried", 5, provider.ErrCircuitOpen, StatusFailed, 1},
+		{"auth failure not retried", 5, provider.ErrAuth, StatusFailed, 1},
+		{"invalid record not retried", 5, provider.ErrInvalidRecord, StatusFailed, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newTestMockProvider("test-dns")
+			failures := tt.failures
+			mock.createFn = func(_ context.Context, rec provider.Record) error {
+				if rec.Type == provider.RecordTypeA && failures > 0 {
+					failures--
+					return tt.err
+				}
+				return nil
+			}
+
+			logger := quietLogger()
+			providers := provider.NewRegistry(logger)
+			providers.RegisterFactory("mock", func(cfg provider.FactoryConfig) (provider.Provider, error) {
+				return mock, nil
+			})
+			if err := providers.CreateInstance(provider.ProviderInstanceConfig{
+				Name:       "test-dns",
+				TypeName:   "mock",
+				RecordType: provider.RecordTypeA,
+				Target:     "10.0.0.1",
+				TTL:        300,
+				Domains:    []string{"*.example.com"},
+			}); err != nil {
+				t.Fatalf("CreateInstance failed: %v", err)
+			}
+
+			r := &Reconciler{
+				providers:      providers,
+				config:         DefaultConfig(),
+				logger:         logger,
+				knownHostnames: make(map[string]struct{}),
+			}
+			WithRetry(3, time.Millisecond)(r)
+			var buf bytes.Buffer
+			r.audit = audit.New(&buf)
+
+			hostname := &source.Hostname{Name: "app.example.com", Source: "test"}
+			actions := r.ensureRecord(context.Background(), hostname, nil)
+
+			if len(actions) != 1 {
+				t.Fatalf("expected 1 action, got %d", len(actions))
+			}
+			if actions[0].Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", actions[0].Status, tt.wantStatus)
+			}
+			if actions[0].Attempts != tt.wantAttempts {
+				t.Errorf("Attempts = %d, want %d", actions[0].Attempts, tt.wantAttempts)
+			}
+			if tt.wantStatus == StatusFailed && actions[0].ErrorCode != provider.ErrorCode(tt.err) {
+				t.Errorf("ErrorCode = %q, want %q", actions[0].ErrorCode, provider.ErrorCode(tt.err))
+			}
+
+			// Attempts that were retried are audited; the last one is in the result
+			var retried int
+			for _, entry := range readAuditEntries(t, &buf) {
+				if entry.Hostname == "app.example.com" && entry.Status == "failed" {
+					retried++
+				}
+			}
+			if retried != tt.wantAttempts-1 {
+				t.Errorf("audited %d failed attempts, want %d", retried, tt.wantAttempts-1)
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package reconciler
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"gitlab.bluewillows.net/root/dnsweaver/internal/audit"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
 )
@@ -164,6 +168,213 @@ func TestReconcileHostname_SkipsNoMatch(t *testing.T) {
 	}
 }
 
+func TestReconcileHostname_RejectsFiltered(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+
+	logger := quietLogger()
+	providers := testProviderRegistry(logger, mock)
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	cfg := DefaultConfig()
+	cfg.DomainsAllowlist = []string{"*.internal.example.com"}
+	cfg.IgnoreLabels = []string{"admin.internal.example.com"}
+	r := New(nil, nil, providers, WithConfig(cfg), WithLogger(logger))
+
+	for _, hostname := range []string{"app.example.com", "admin.internal.example.com"} {
+		result, err := r.ReconcileHostname(context.Background(), hostname)
+		if !errors.Is(err, ErrHostnameFiltered) {
+			t.Errorf("ReconcileHostname(%s) error = %v, want ErrHostnameFiltered", hostname, err)
+		}
+		if result != nil {
+			t.Errorf("ReconcileHostname(%s) result = %+v, want nil", hostname, result)
+		}
+	}
+	if created := mock.GetCreated(); len(created) != 0 {
+		t.Errorf("expected no records for filtered hostnames, got %+v", created)
+	}
+	if known := r.KnownHostnames(); len(known) != 0 {
+		t.Errorf("filtered hostnames should not be tracked, got %v", known)
+	}
+
+	if _, err := r.ReconcileHostname(context.Background(), "app.internal.example.com"); err != nil {
+		t.Fatalf("ReconcileHostname for allowed hostname failed: %v", err)
+	}
+	if created := mock.GetCreated(); len(created) == 0 {
+		t.Error("expected a record for the allowed hostname")
+	}
+}
+
+func TestReconcileHostname_NormalizesName(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+
+	logger := quietLogger()
+	providers := testProviderRegistry(logger, mock)
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	cfg := DefaultConfig()
+	cfg.OwnershipTracking = false
+	r := New(nil, nil, providers, WithConfig(cfg), WithLogger(logger))
+
+	if _, err := r.ReconcileHostname(context.Background(), "App.Example.COM."); err != nil {
+		t.Fatalf("ReconcileHostname failed: %v", err)
+	}
+	created := mock.GetCreated()
+	if len(created) != 1 || created[0].Hostname != "app.example.com" {
+		t.Errorf("created = %+v, want one record for app.example.com", created)
+	}
+	if known := r.KnownHostnames(); len(known) != 1 || known[0] != "app.example.com" {
+		t.Errorf("known hostnames = %v, want [app.example.com]", known)
+	}
+
+	if _, err := r.RemoveHostname(context.Background(), "APP.example.com."); err != nil {
+		t.Fatalf("RemoveHostname failed: %v", err)
+	}
+	deleted := mock.GetDeleted()
+	if len(deleted) != 1 || deleted[0].Hostname != "app.example.com" {
+		t.Errorf("deleted = %+v, want one record for app.example.com", deleted)
+	}
+	if known := r.KnownHostnames(); len(known) != 0 {
+		t.Errorf("known hostnames = %v, want none", known)
+	}
+}
+
+func TestReconcileHostname_ProviderFallback(t *testing.T) {
+	tests := []struct {
+		name          string
+		fallback      bool
+		primaryFails  bool
+		wantPrimary   int
+		wantSecondary int
+		primaryTypes  []provider.RecordType
+	}{
+		{"fallback uses primary when it succeeds", true, false, 1, 0, nil},
+		{"fallback uses secondary when primary fails", true, true, 0, 1, nil},
+		{"without fallback every provider is written", false, false, 1, 1, nil},
+		{"fallback skips primary without the record type", true, false, 0, 1, []provider.RecordType{provider.RecordTypeAAAA}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := newTestMockProvider("primary")
+			if tt.primaryFails {
+				primary.createFn = func(context.Context, provider.Record) error {
+					return errors.New("primary unavailable")
+				}
+			}
+			primary.recordTypes = tt.primaryTypes
+			secondary := newTestMockProvider("secondary")
+
+			logger := quietLogger()
+			providers := testProviderRegistry(logger, primary, secondary)
+			// Registered out of order; priority decides which is tried first
+			for _, p := range []struct {
+				name     string
+				priority int
+			}{{"secondary", 20}, {"primary", 10}} {
+				_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+					Name:       p.name,
+					TypeName:   "mock",
+					RecordType: provider.RecordTypeA,
+					Target:     "10.0.0.1",
+					TTL:        300,
+					Domains:    []string{"*.example.com"},
+					Priority:   p.priority,
+				})
+			}
+
+			cfg := DefaultConfig()
+			cfg.OwnershipTracking = false
+			cfg.ProviderFallback = tt.fallback
+			r := New(nil, nil, providers, WithConfig(cfg), WithLogger(logger))
+
+			result, err := r.ReconcileHostname(context.Background(), "app.example.com")
+			if err != nil {
+				t.Fatalf("ReconcileHostname failed: %v", err)
+			}
+
+			if got := len(primary.GetCreated()); got != tt.wantPrimary {
+				t.Errorf("primary records = %d, want %d", got, tt.wantPrimary)
+			}
+			if got := len(secondary.GetCreated()); got != tt.wantSecondary {
+				t.Errorf("secondary records = %d, want %d", got, tt.wantSecondary)
+			}
+			if tt.primaryFails && result.FailedCount() != 1 {
+				t.Errorf("FailedCount() = %d, want the primary failure reported", result.FailedCount())
+			}
+		})
+	}
+}
+
+func TestReconcileHostname_ProviderFallbackSkipsBackedOff(t *testing.T) {
+	primary := newTestMockProvider("primary")
+	primary.createFn = func(context.Context, provider.Record) error {
+		return errors.New("primary unavailable")
+	}
+	secondary := newTestMockProvider("secondary")
+
+	logger := quietLogger()
+	providers := testProviderRegistry(logger, primary, secondary)
+	for _, p := range []struct {
+		name     string
+		priority int
+	}{{"primary", 10}, {"secondary", 20}} {
+		_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+			Name:       p.name,
+			TypeName:   "mock",
+			RecordType: provider.RecordTypeA,
+			Target:     "10.0.0.1",
+			TTL:        300,
+			Domains:    []string{"*.example.com"},
+			Priority:   p.priority,
+		})
+	}
+
+	cfg := DefaultConfig()
+	cfg.OwnershipTracking = false
+	cfg.ProviderFallback = true
+	r := New(nil, nil, providers, WithConfig(cfg), WithLogger(logger),
+		WithReconcileBackoff(1, time.Hour, time.Hour))
+
+	// The first failure backs the primary off; the second pass must still
+	// reach the secondary instead of stopping at the backed-off primary.
+	for pass := 1; pass <= 2; pass++ {
+		result, err := r.ReconcileHostname(context.Background(), "app.example.com")
+		if err != nil {
+			t.Fatalf("pass %d: ReconcileHostname failed: %v", pass, err)
+		}
+
+		var primaryBackedOff, secondaryTried bool
+		for _, a := range result.Actions {
+			switch a.Provider {
+			case "primary":
+				primaryBackedOff = a.BackedOff
+			case "secondary":
+				secondaryTried = true
+			}
+		}
+		if !secondaryTried {
+			t.Errorf("pass %d: secondary was not tried: %v", pass, result.Actions)
+		}
+		if pass == 2 && !primaryBackedOff {
+			t.Errorf("pass %d: expected the primary to be backed off: %v", pass, result.Actions)
+		}
+	}
+}
+
 // =============================================================================
 // RemoveHostname Tests
 // =============================================================================
@@ -715,6 +926,9 @@ func TestEnsureRecord_SRVRecordCreatesWhenDifferentData(t *testing.T) {
 		knownHostnames: make(map[string]struct{}),
 	}
 
+	var buf bytes.Buffer
+	r.audit = audit.New(&buf)
+
 	// Build cache from the mock provider's existing records
 	cache := newRecordCache(context.Background(), providers, logger)
 
@@ -771,4 +985,10 @@ func TestEnsureRecord_SRVRecordCreatesWhenDifferentData(t *testing.T) {
 	if !foundCreatedSRV {
 		t.Error("expected new SRV record with port 25565 to be created")
 	}
+
+	// The stale record is deleted outside the update action and audited directly
+	stale := findAuditEntry(readAuditEntries(t, &buf), "_minecraft._tcp.mc.example.com")
+	if stale.Action != "delete" || stale.Status != "success" || stale.RecordType != "SRV" || stale.OldValue != "mc.example.com" {
+		t.Errorf("unexpected stale SRV audit entry: %+v", stale)
+	}
 }
@@ -0,0 +1,77 @@
+package reconciler
+
+import (
+	"errors"
+	"log/slog"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/matcher"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+// ErrHostnameFiltered is returned by ReconcileHostname for a hostname outside
+// Config.DomainsAllowlist or matching Config.IgnoreLabels.
+var ErrHostnameFiltered = errors.New("hostname excluded by domains allowlist or ignore list")
+
+// newPatternMatcher compiles the glob patterns of a hostname filter
+// (Config.IgnoreLabels or Config.DomainsAllowlist). It returns nil when no
+// patterns are configured.
+func newPatternMatcher(setting string, patterns []string, logger *slog.Logger) *matcher.DomainMatcher {
+	if len(patterns) == 0 {
+		return nil
+	}
+	m, err := matcher.NewDomainMatcher(matcher.DomainMatcherConfig{Includes: patterns})
+	if err != nil {
+		logger.Error("invalid hostname filter pattern, filter disabled",
+			slog.String("setting", setting),
+			slog.String("error", err.Error()),
+		)
+		return nil
+	}
+	return m
+}
+
+// filterHostnames removes hostnames outside Config.DomainsAllowlist and
+// hostnames matching Config.IgnoreLabels.
+func (r *Reconciler) filterHostnames(hostnames source.Hostnames) source.Hostnames {
+	if (r.allowed == nil && r.ignored == nil) || len(hostnames) == 0 {
+		return hostnames
+	}
+
+	kept := hostnames[:0]
+	for _, h := range hostnames {
+		if r.allowed != nil && !r.allowed.Matches(h.Name) {
+			r.logger.Debug("dropping hostname outside domains allowlist",
+				slog.String("hostname", h.Name),
+				slog.String("source", h.Source),
+			)
+			continue
+		}
+		if r.ignored != nil && r.ignored.Matches(h.Name) {
+			r.logger.Debug("ignoring hostname",
+				slog.String("hostname", h.Name),
+				slog.String("source", h.Source),
+			)
+			continue
+		}
+		kept = append(kept, h)
+	}
+	return kept
+}
+
+// outranks reports whether candidate comes from a source with a higher
+// Config.LabelPriorityMap priority than existing.
+func (r *Reconciler) outranks(candidate, existing *source.Hostname) bool {
+	priorities := r.config.LabelPriorityMap
+	return priorities[candidate.Source] > priorities[existing.Source]
+}
+
+// removeHostname returns names without hostname.
+func removeHostname(names []string, hostname string) []string {
+	kept := names[:0]
+	for _, name := range names {
+		if name != hostname {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
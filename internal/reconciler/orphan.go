@@ -4,8 +4,10 @@ package reconciler
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
+	"gitlab.bluewillows.net/root/dnsweaver/internal/policy"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
 )
@@ -36,6 +38,7 @@ func (r *Reconciler) cleanupOrphans(ctx context.Context, currentHostnames map[st
 			matchingProviders := r.providers.MatchingProviders(hostname)
 			for _, inst := range matchingProviders {
 				deleteActions := r.deleteOrphanForProvider(ctx, hostname, inst, cache)
+				cache.invalidateActions(deleteActions)
 				actions = append(actions, deleteActions...)
 			}
 		}
@@ -63,6 +66,7 @@ func (r *Reconciler) deleteOrphanForProvider(ctx context.Context, hostname strin
 		action := Action{
 			Type:       ActionSkip,
 			Provider:   inst.Name(),
+			Zone:       inst.Zone(),
 			Hostname:   hostname,
 			RecordType: string(inst.RecordType),
 			Target:     inst.Target,
@@ -72,6 +76,10 @@ func (r *Reconciler) deleteOrphanForProvider(ctx context.Context, hostname strin
 		return []Action{action}
 	}
 
+	if r.denied(ctx, hostname, inst, policy.ActionDelete) {
+		return []Action{deniedAction(hostname, inst, string(inst.RecordType))}
+	}
+
 	// Authoritative mode: delete without ownership check (but only supported types in scope)
 	if !mode.RequiresOwnership() {
 		return r.deleteAuthoritativeForProvider(ctx, hostname, inst, cache)
@@ -94,6 +102,7 @@ func (r *Reconciler) deleteAuthoritativeForProvider(ctx context.Context, hostnam
 		action := Action{
 			Type:       ActionDelete,
 			Provider:   inst.Name(),
+			Zone:       inst.Zone(),
 			Hostname:   hostname,
 			RecordType: string(inst.RecordType),
 			Target:     inst.Target,
@@ -130,11 +139,13 @@ func (r *Reconciler) deleteAuthoritativeForProvider(ctx context.Context, hostnam
 			return []Action{{
 				Type:       ActionDelete,
 				Provider:   inst.Name(),
+				Zone:       inst.Zone(),
 				Hostname:   hostname,
 				RecordType: string(inst.RecordType),
 				Target:     inst.Target,
 				Status:     StatusFailed,
 				Error:      "failed to list records: " + err.Error(),
+				ErrorCode:  provider.ErrorCode(err),
 			}}
 		}
 		for _, rec := range allRecords {
@@ -164,6 +175,7 @@ func (r *Reconciler) deleteAuthoritativeForProvider(ctx context.Context, hostnam
 		action := Action{
 			Type:       ActionDelete,
 			Provider:   inst.Name(),
+			Zone:       inst.Zone(),
 			Hostname:   hostname,
 			RecordType: string(record.Type),
 			Target:     record.Target,
@@ -177,13 +189,11 @@ func (r *Reconciler) deleteAuthoritativeForProvider(ctx context.Context, hostnam
 		}
 
 		if err != nil {
-			action.Status = StatusFailed
-			action.Error = err.Error()
-			r.logger.Error("failed to delete record in authoritative mode",
+			action.fail(err)
+			r.logFailure("failed to delete record in authoritative mode", err,
 				slog.String("hostname", hostname),
 				slog.String("provider", inst.Name()),
 				slog.String("type", string(record.Type)),
-				slog.String("error", err.Error()),
 			)
 		} else {
 			action.Status = StatusSuccess
@@ -199,7 +209,7 @@ func (r *Reconciler) deleteAuthoritativeForProvider(ctx context.Context, hostnam
 
 	// Also delete ownership TXT record if we have one
 	if r.config.OwnershipTracking {
-		if ownerErr := inst.DeleteOwnershipRecord(ctx, hostname); ownerErr != nil {
+		if ownerErr := r.deleteOwnership(ctx, inst, hostname, cache); ownerErr != nil {
 			r.logger.Debug("failed to delete ownership record (may not exist)",
 				slog.String("hostname", hostname),
 				slog.String("provider", inst.Name()),
@@ -217,6 +227,7 @@ func (r *Reconciler) deleteManagedForProvider(ctx context.Context, hostname stri
 		action := Action{
 			Type:       ActionDelete,
 			Provider:   inst.Name(),
+			Zone:       inst.Zone(),
 			Hostname:   hostname,
 			RecordType: string(inst.RecordType),
 			Target:     inst.Target,
@@ -245,6 +256,7 @@ func (r *Reconciler) deleteManagedForProvider(ctx context.Context, hostname stri
 			return []Action{{
 				Type:       ActionSkip,
 				Provider:   inst.Name(),
+				Zone:       inst.Zone(),
 				Hostname:   hostname,
 				RecordType: string(inst.RecordType),
 				Target:     inst.Target,
@@ -262,6 +274,7 @@ func (r *Reconciler) deleteManagedForProvider(ctx context.Context, hostname stri
 		return []Action{{
 			Type:       ActionSkip,
 			Provider:   inst.Name(),
+			Zone:       inst.Zone(),
 			Hostname:   hostname,
 			RecordType: string(inst.RecordType),
 			Target:     inst.Target,
@@ -291,11 +304,13 @@ func (r *Reconciler) deleteManagedForProvider(ctx context.Context, hostname stri
 			return []Action{{
 				Type:       ActionDelete,
 				Provider:   inst.Name(),
+				Zone:       inst.Zone(),
 				Hostname:   hostname,
 				RecordType: string(inst.RecordType),
 				Target:     inst.Target,
 				Status:     StatusFailed,
 				Error:      "failed to list records: " + err.Error(),
+				ErrorCode:  provider.ErrorCode(err),
 			}}
 		}
 		for _, rec := range allRecords {
@@ -312,9 +327,15 @@ func (r *Reconciler) deleteManagedForProvider(ctx context.Context, hostname stri
 
 	var actions []Action
 	for _, record := range recordsToDelete {
+		if skip, ok := r.skipUnsupportedRecord(hostname, inst, record); ok {
+			actions = append(actions, skip)
+			continue
+		}
+
 		action := Action{
 			Type:       ActionDelete,
 			Provider:   inst.Name(),
+			Zone:       inst.Zone(),
 			Hostname:   hostname,
 			RecordType: string(record.Type),
 			Target:     record.Target,
@@ -328,13 +349,11 @@ func (r *Reconciler) deleteManagedForProvider(ctx context.Context, hostname stri
 		}
 
 		if err != nil {
-			action.Status = StatusFailed
-			action.Error = err.Error()
-			r.logger.Error("failed to delete record",
+			action.fail(err)
+			r.logFailure("failed to delete record", err,
 				slog.String("hostname", hostname),
 				slog.String("provider", inst.Name()),
 				slog.String("type", string(record.Type)),
-				slog.String("error", err.Error()),
 			)
 		} else {
 			action.Status = StatusSuccess
@@ -349,7 +368,7 @@ func (r *Reconciler) deleteManagedForProvider(ctx context.Context, hostname stri
 	}
 
 	// Also delete ownership TXT record
-	if ownerErr := inst.DeleteOwnershipRecord(ctx, hostname); ownerErr != nil {
+	if ownerErr := r.deleteOwnership(ctx, inst, hostname, cache); ownerErr != nil {
 		r.logger.Warn("failed to delete ownership record",
 			slog.String("hostname", hostname),
 			slog.String("provider", inst.Name()),
@@ -365,6 +384,29 @@ func (r *Reconciler) deleteManagedForProvider(ctx context.Context, hostname stri
 	return actions
 }
 
+// skipUnsupportedRecord returns a skip action when the provider does not support
+// the record's type, so the reconciler never asks it to delete such a record.
+func (r *Reconciler) skipUnsupportedRecord(hostname string, inst *provider.ProviderInstance, record provider.Record) (Action, bool) {
+	if inst.Provider.Capabilities().SupportsRecordType(record.Type) {
+		return Action{}, false
+	}
+	r.logger.Warn("provider does not support record type, skipping deletion",
+		slog.String("hostname", hostname),
+		slog.String("provider", inst.Name()),
+		slog.String("type", string(record.Type)),
+	)
+	return Action{
+		Type:       ActionSkip,
+		Provider:   inst.Name(),
+		Zone:       inst.Zone(),
+		Hostname:   hostname,
+		RecordType: string(record.Type),
+		Target:     record.Target,
+		Status:     StatusSkipped,
+		Error:      fmt.Sprintf("record type %s not supported by provider", record.Type),
+	}, true
+}
+
 // deleteCacheOnlyForProvider deletes orphan records in managed mode without ownership tracking.
 // Uses the cache to determine what record types exist.
 func (r *Reconciler) deleteCacheOnlyForProvider(ctx context.Context, hostname string, inst *provider.ProviderInstance, cache *recordCache) []Action {
@@ -372,6 +414,7 @@ func (r *Reconciler) deleteCacheOnlyForProvider(ctx context.Context, hostname st
 		action := Action{
 			Type:       ActionDelete,
 			Provider:   inst.Name(),
+			Zone:       inst.Zone(),
 			Hostname:   hostname,
 			RecordType: string(inst.RecordType),
 			Target:     inst.Target,
@@ -405,11 +448,13 @@ func (r *Reconciler) deleteCacheOnlyForProvider(ctx context.Context, hostname st
 			return []Action{{
 				Type:       ActionDelete,
 				Provider:   inst.Name(),
+				Zone:       inst.Zone(),
 				Hostname:   hostname,
 				RecordType: string(inst.RecordType),
 				Target:     inst.Target,
 				Status:     StatusFailed,
 				Error:      "failed to list records: " + err.Error(),
+				ErrorCode:  provider.ErrorCode(err),
 			}}
 		}
 		for _, rec := range allRecords {
@@ -426,9 +471,15 @@ func (r *Reconciler) deleteCacheOnlyForProvider(ctx context.Context, hostname st
 
 	var actions []Action
 	for _, record := range recordsToDelete {
+		if skip, ok := r.skipUnsupportedRecord(hostname, inst, record); ok {
+			actions = append(actions, skip)
+			continue
+		}
+
 		action := Action{
 			Type:       ActionDelete,
 			Provider:   inst.Name(),
+			Zone:       inst.Zone(),
 			Hostname:   hostname,
 			RecordType: string(record.Type),
 			Target:     record.Target,
@@ -442,13 +493,11 @@ func (r *Reconciler) deleteCacheOnlyForProvider(ctx context.Context, hostname st
 		}
 
 		if err != nil {
-			action.Status = StatusFailed
-			action.Error = err.Error()
-			r.logger.Error("failed to delete record",
+			action.fail(err)
+			r.logFailure("failed to delete record", err,
 				slog.String("hostname", hostname),
 				slog.String("provider", inst.Name()),
 				slog.String("type", string(record.Type)),
-				slog.String("error", err.Error()),
 			)
 		} else {
 			action.Status = StatusSuccess
@@ -473,9 +522,15 @@ func (r *Reconciler) deleteRecord(ctx context.Context, hostname string) []Action
 	matchingProviders := r.providers.MatchingProviders(hostname)
 
 	for _, inst := range matchingProviders {
+		if r.denied(ctx, hostname, inst, policy.ActionDelete) {
+			actions = append(actions, deniedAction(hostname, inst, string(inst.RecordType)))
+			continue
+		}
+
 		action := Action{
 			Type:       ActionDelete,
 			Provider:   inst.Name(),
+			Zone:       inst.Zone(),
 			Hostname:   hostname,
 			RecordType: string(inst.RecordType),
 			Target:     inst.Target,
@@ -491,12 +546,10 @@ func (r *Reconciler) deleteRecord(ctx context.Context, hostname string) []Action
 		} else {
 			err := inst.DeleteRecord(ctx, hostname)
 			if err != nil {
-				action.Status = StatusFailed
-				action.Error = err.Error()
-				r.logger.Error("failed to delete record",
+				action.fail(err)
+				r.logFailure("failed to delete record", err,
 					slog.String("hostname", hostname),
 					slog.String("provider", inst.Name()),
-					slog.String("error", err.Error()),
 				)
 			} else {
 				action.Status = StatusSuccess
@@ -507,7 +560,7 @@ func (r *Reconciler) deleteRecord(ctx context.Context, hostname string) []Action
 
 				// Also delete ownership TXT record if tracking is enabled
 				if r.config.OwnershipTracking {
-					if ownerErr := inst.DeleteOwnershipRecord(ctx, hostname); ownerErr != nil {
+					if ownerErr := r.deleteOwnership(ctx, inst, hostname, nil); ownerErr != nil {
 						r.logger.Warn("failed to delete ownership record",
 							slog.String("hostname", hostname),
 							slog.String("provider", inst.Name()),
@@ -542,6 +595,7 @@ func (r *Reconciler) deleteFromCache(ctx context.Context, hostname string, cache
 			action := Action{
 				Type:       ActionDelete,
 				Provider:   inst.Name(),
+				Zone:       inst.Zone(),
 				Hostname:   hostname,
 				RecordType: string(inst.RecordType),
 				Target:     inst.Target,
@@ -576,11 +630,13 @@ func (r *Reconciler) deleteFromCache(ctx context.Context, hostname string, cache
 				action := Action{
 					Type:       ActionDelete,
 					Provider:   inst.Name(),
+					Zone:       inst.Zone(),
 					Hostname:   hostname,
 					RecordType: string(inst.RecordType),
 					Target:     inst.Target,
 					Status:     StatusFailed,
 					Error:      "failed to list records: " + err.Error(),
+					ErrorCode:  provider.ErrorCode(err),
 				}
 				actions = append(actions, action)
 				continue
@@ -602,6 +658,7 @@ func (r *Reconciler) deleteFromCache(ctx context.Context, hostname string, cache
 			action := Action{
 				Type:       ActionDelete,
 				Provider:   inst.Name(),
+				Zone:       inst.Zone(),
 				Hostname:   hostname,
 				RecordType: string(record.Type),
 				Target:     record.Target,
@@ -615,13 +672,11 @@ func (r *Reconciler) deleteFromCache(ctx context.Context, hostname string, cache
 			}
 
 			if err != nil {
-				action.Status = StatusFailed
-				action.Error = err.Error()
-				r.logger.Error("failed to delete record",
+				action.fail(err)
+				r.logFailure("failed to delete record", err,
 					slog.String("hostname", hostname),
 					slog.String("provider", inst.Name()),
 					slog.String("type", string(record.Type)),
-					slog.String("error", err.Error()),
 				)
 			} else {
 				action.Status = StatusSuccess
@@ -653,6 +708,7 @@ func (r *Reconciler) deleteWithOwnership(ctx context.Context, hostname string, c
 			action := Action{
 				Type:       ActionDelete,
 				Provider:   inst.Name(),
+				Zone:       inst.Zone(),
 				Hostname:   hostname,
 				RecordType: string(inst.RecordType),
 				Target:     inst.Target,
@@ -682,6 +738,7 @@ func (r *Reconciler) deleteWithOwnership(ctx context.Context, hostname string, c
 				action := Action{
 					Type:       ActionSkip,
 					Provider:   inst.Name(),
+					Zone:       inst.Zone(),
 					Hostname:   hostname,
 					RecordType: string(inst.RecordType),
 					Target:     inst.Target,
@@ -701,6 +758,7 @@ func (r *Reconciler) deleteWithOwnership(ctx context.Context, hostname string, c
 			action := Action{
 				Type:       ActionSkip,
 				Provider:   inst.Name(),
+				Zone:       inst.Zone(),
 				Hostname:   hostname,
 				RecordType: string(inst.RecordType),
 				Target:     inst.Target,
@@ -732,11 +790,13 @@ func (r *Reconciler) deleteWithOwnership(ctx context.Context, hostname string, c
 				action := Action{
 					Type:       ActionDelete,
 					Provider:   inst.Name(),
+					Zone:       inst.Zone(),
 					Hostname:   hostname,
 					RecordType: string(inst.RecordType),
 					Target:     inst.Target,
 					Status:     StatusFailed,
 					Error:      "failed to list records: " + err.Error(),
+					ErrorCode:  provider.ErrorCode(err),
 				}
 				actions = append(actions, action)
 				continue
@@ -758,6 +818,7 @@ func (r *Reconciler) deleteWithOwnership(ctx context.Context, hostname string, c
 			action := Action{
 				Type:       ActionDelete,
 				Provider:   inst.Name(),
+				Zone:       inst.Zone(),
 				Hostname:   hostname,
 				RecordType: string(record.Type),
 				Target:     record.Target,
@@ -771,13 +832,11 @@ func (r *Reconciler) deleteWithOwnership(ctx context.Context, hostname string, c
 			}
 
 			if err != nil {
-				action.Status = StatusFailed
-				action.Error = err.Error()
-				r.logger.Error("failed to delete owned record",
+				action.fail(err)
+				r.logFailure("failed to delete owned record", err,
 					slog.String("hostname", hostname),
 					slog.String("provider", inst.Name()),
 					slog.String("type", string(record.Type)),
-					slog.String("error", err.Error()),
 				)
 			} else {
 				action.Status = StatusSuccess
@@ -792,7 +851,7 @@ func (r *Reconciler) deleteWithOwnership(ctx context.Context, hostname string, c
 		}
 
 		// Delete ownership TXT record
-		if ownerErr := inst.DeleteOwnershipRecord(ctx, hostname); ownerErr != nil {
+		if ownerErr := r.deleteOwnership(ctx, inst, hostname, cache); ownerErr != nil {
 			r.logger.Warn("failed to delete ownership record",
 				slog.String("hostname", hostname),
 				slog.String("provider", inst.Name()),
@@ -808,3 +867,28 @@ func (r *Reconciler) deleteWithOwnership(ctx context.Context, hostname string, c
 
 	return actions
 }
+
+// deleteOwnership removes the ownership records of hostname in inst and
+// audits the deletion, as ownership records have no action of their own.
+func (r *Reconciler) deleteOwnership(ctx context.Context, inst *provider.ProviderInstance, hostname string, cache *recordCache) error {
+	err := deleteOwnershipRecords(ctx, inst, hostname, cache)
+	ownership := provider.Record{Hostname: provider.OwnershipRecordName(hostname), Type: provider.RecordTypeTXT}
+	r.auditRecord(ActionDelete, inst, ownership, err)
+	return err
+}
+
+// deleteOwnershipRecords removes the ownership records of hostname in inst.
+// The records listed in the reconciliation's record cache are deleted
+// directly, so orphan cleanup does not list the provider once per hostname;
+// the provider is only listed when the cache has no entry for the hostname.
+func deleteOwnershipRecords(ctx context.Context, inst *provider.ProviderInstance, hostname string, cache *recordCache) error {
+	// Changes to a hostname invalidate only its own cache entry, so an
+	// invalidated hostname also has outdated ownership records
+	if cache != nil {
+		if _, fresh := cache.lookup(inst.Name(), hostname); fresh {
+			records, _ := cache.lookup(inst.Name(), provider.OwnershipRecordName(hostname))
+			return inst.DeleteListedOwnershipRecords(ctx, hostname, records)
+		}
+	}
+	return inst.DeleteOwnershipRecord(ctx, hostname)
+}
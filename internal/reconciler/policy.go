@@ -0,0 +1,59 @@
+package reconciler
+
+import (
+	"context"
+	"log/slog"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/metrics"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/policy"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// denied reports whether the admission policy denies an operation on a
+// hostname in a provider. When the policy cannot be queried the checker's
+// fail mode decides and the error is logged. Denials are counted in the
+// records_denied_total metric.
+func (r *Reconciler) denied(ctx context.Context, hostname string, inst *provider.ProviderInstance, operation string) bool {
+	if r.policy == nil {
+		return false
+	}
+
+	allowed, err := r.policy.Allow(ctx, policy.Input{
+		Hostname: hostname,
+		Provider: inst.Name(),
+		Action:   operation,
+	})
+	if err != nil {
+		r.logger.Warn("admission policy check failed",
+			slog.String("hostname", hostname),
+			slog.String("provider", inst.Name()),
+			slog.String("action", operation),
+			slog.Bool("allowed", allowed),
+			slog.String("error", err.Error()),
+		)
+	}
+	if allowed {
+		return false
+	}
+
+	metrics.RecordsDeniedTotal.WithLabelValues(inst.Name(), operation).Inc()
+	r.logger.Info("record operation denied by policy",
+		slog.String("hostname", hostname),
+		slog.String("provider", inst.Name()),
+		slog.String("action", operation),
+	)
+	return true
+}
+
+// deniedAction returns the skip action for an operation denied by policy.
+func deniedAction(hostname string, inst *provider.ProviderInstance, recordType string) Action {
+	return Action{
+		Type:       ActionSkip,
+		Provider:   inst.Name(),
+		Zone:       inst.Zone(),
+		Hostname:   hostname,
+		RecordType: recordType,
+		Status:     StatusSkipped,
+		Error:      errPolicyDenied,
+	}
+}
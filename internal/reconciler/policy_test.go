@@ -0,0 +1,77 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/metrics"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/policy"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+// denyChecker denies operations on the listed hostnames.
+type denyChecker struct {
+	denied   map[string]bool
+	err      error
+	failOpen bool
+	inputs   []policy.Input
+}
+
+func (c *denyChecker) Allow(_ context.Context, in policy.Input) (bool, error) {
+	c.inputs = append(c.inputs, in)
+	if c.err != nil {
+		return c.failOpen, c.err
+	}
+	return !c.denied[in.Hostname], nil
+}
+
+func TestEnsureRecord_PolicyDenied(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+	r := newMultiTargetReconciler(t, mock, "10.0.0.1")
+	checker := &denyChecker{denied: map[string]bool{"secret.example.com": true}}
+	r.policy = checker
+	metrics.RecordsDeniedTotal.Reset()
+
+	cache := newRecordCache(context.Background(), r.providers, r.logger)
+	actions := r.ensureRecord(context.Background(), &source.Hostname{Name: "secret.example.com", Source: "test"}, cache)
+	if len(actions) != 1 || actions[0].Type != ActionSkip || actions[0].Error != errPolicyDenied {
+		t.Fatalf("actions = %+v, want one policy denied skip", actions)
+	}
+	if len(mock.GetCreated()) != 0 {
+		t.Errorf("created %v, want nothing", mock.GetCreated())
+	}
+	if got := testutil.ToFloat64(metrics.RecordsDeniedTotal.WithLabelValues("test-dns", policy.ActionCreate)); got != 1 {
+		t.Errorf("records_denied_total = %v, want 1", got)
+	}
+	want := policy.Input{Hostname: "secret.example.com", Provider: "test-dns", Action: policy.ActionCreate}
+	if len(checker.inputs) != 1 || checker.inputs[0] != want {
+		t.Errorf("policy inputs = %+v, want %+v", checker.inputs, want)
+	}
+
+	actions = r.ensureRecord(context.Background(), &source.Hostname{Name: "app.example.com", Source: "test"}, cache)
+	if len(actions) != 1 || actions[0].Type != ActionCreate || actions[0].Status != StatusSuccess {
+		t.Errorf("actions = %+v, want a successful create", actions)
+	}
+
+	actions = r.deleteRecord(context.Background(), "secret.example.com")
+	if len(actions) != 1 || actions[0].Error != errPolicyDenied || len(mock.GetDeleted()) != 0 {
+		t.Errorf("delete actions = %+v, want one policy denied skip", actions)
+	}
+}
+
+func TestEnsureRecord_PolicyError(t *testing.T) {
+	for _, failOpen := range []bool{false, true} {
+		mock := newTestMockProvider("test-dns")
+		r := newMultiTargetReconciler(t, mock, "10.0.0.1")
+		r.policy = &denyChecker{err: errors.New("opa unreachable"), failOpen: failOpen}
+
+		cache := newRecordCache(context.Background(), r.providers, r.logger)
+		actions := r.ensureRecord(context.Background(), &source.Hostname{Name: "app.example.com", Source: "test"}, cache)
+		if created := actions[0].Type == ActionCreate; created != failOpen {
+			t.Errorf("fail open %v: actions = %+v", failOpen, actions)
+		}
+	}
+}
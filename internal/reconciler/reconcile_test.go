@@ -1,13 +1,27 @@
 package reconciler
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/audit"
 	"gitlab.bluewillows.net/root/dnsweaver/internal/docker"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/metrics"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/notify"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+	dnsweaversource "gitlab.bluewillows.net/root/dnsweaver/sources/dnsweaver"
 	"gitlab.bluewillows.net/root/dnsweaver/sources/traefik"
 )
 
@@ -103,6 +117,244 @@ func TestReconcile_CreatesRecordsForWorkloads(t *testing.T) {
 	}
 }
 
+func TestReconcile_AuditLog(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	dockerMock.AddWorkload("my-app", map[string]string{
+		"traefik.http.routers.myapp.rule": "Host(`app.example.com`)",
+	})
+
+	logger := quietLogger()
+
+	sources := source.NewRegistry(logger)
+	sources.Register(traefik.New(traefik.WithLogger(logger)))
+
+	mockProvider := newTestMockProvider("test-dns")
+	providers := provider.NewRegistry(logger)
+	providers.RegisterFactory("mock", func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		return mockProvider, nil
+	})
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	var buf bytes.Buffer
+	r := New(dockerMock, sources, providers,
+		WithConfig(DefaultConfig()),
+		WithLogger(logger),
+		WithAuditLogger(audit.New(&buf)),
+	)
+
+	if _, err := r.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	entries := readAuditEntries(t, &buf)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d: %q", len(entries), buf.String())
+	}
+
+	entry := findAuditEntry(entries, "app.example.com")
+	if entry.Action != "create" || entry.Status != "success" || entry.RecordType != "A" ||
+		entry.NewValue != "10.0.0.1" || entry.Provider != "test-dns" || entry.Hash == "" {
+		t.Errorf("unexpected audit entry: %+v", entry)
+	}
+
+	// The ownership TXT record is audited as well
+	owner := findAuditEntry(entries, "_dnsweaver.app.example.com")
+	if owner.Action != "create" || owner.Status != "success" || owner.RecordType != "TXT" || owner.NewValue == "" {
+		t.Errorf("unexpected ownership audit entry: %+v", owner)
+	}
+}
+
+// readAuditEntries parses the audit log written to buf.
+func readAuditEntries(t *testing.T, buf *bytes.Buffer) []audit.Entry {
+	t.Helper()
+	var entries []audit.Entry
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry audit.Entry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid audit entry: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// findAuditEntry returns the first audit entry for hostname.
+func findAuditEntry(entries []audit.Entry, hostname string) audit.Entry {
+	for _, entry := range entries {
+		if entry.Hostname == hostname {
+			return entry
+		}
+	}
+	return audit.Entry{}
+}
+
+// recordingNotifier captures summaries passed to Notify.
+type recordingNotifier struct {
+	summaries []notify.Summary
+}
+
+func (n *recordingNotifier) Name() string { return "recording" }
+
+func (n *recordingNotifier) Notify(_ context.Context, s notify.Summary) error {
+	n.summaries = append(n.summaries, s)
+	return nil
+}
+
+func TestReconcile_Notifier(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	dockerMock.AddWorkload("my-app", map[string]string{
+		"traefik.http.routers.myapp.rule": "Host(`app.example.com`)",
+	})
+
+	logger := quietLogger()
+
+	sources := source.NewRegistry(logger)
+	sources.Register(traefik.New(traefik.WithLogger(logger)))
+
+	mockProvider := newTestMockProvider("test-dns")
+	providers := testProviderRegistry(logger, mockProvider)
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	notifier := &recordingNotifier{}
+	r := New(dockerMock, sources, providers,
+		WithConfig(DefaultConfig()),
+		WithLogger(logger),
+		WithNotifier(notifier),
+	)
+
+	if _, err := r.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	if len(notifier.summaries) != 1 {
+		t.Fatalf("expected 1 summary, got %d", len(notifier.summaries))
+	}
+	s := notifier.summaries[0]
+	if len(s.Providers) != 1 {
+		t.Fatalf("expected 1 provider summary, got %+v", s.Providers)
+	}
+	if ps := s.Providers[0]; ps.Name != "test-dns" || ps.Created != 1 || ps.Failed != 0 {
+		t.Errorf("unexpected provider summary: %+v", ps)
+	}
+	if s.Actions() != 1 || len(s.Errors) != 0 {
+		t.Errorf("Actions() = %d, errors = %v; want 1 action, no errors", s.Actions(), s.Errors)
+	}
+}
+
+func TestReconcile_ZoneLabels(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	dockerMock.AddWorkload("my-app", map[string]string{
+		"traefik.http.routers.myapp.rule": "Host(`zoned.example.com`)",
+	})
+
+	logger := quietLogger()
+
+	sources := source.NewRegistry(logger)
+	sources.Register(traefik.New(traefik.WithLogger(logger)))
+
+	mockProvider := newTestMockProvider("zoned-dns")
+	mockProvider.zone = "example.com"
+	providers := testProviderRegistry(logger, mockProvider)
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "zoned-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	r := New(dockerMock, sources, providers,
+		WithConfig(DefaultConfig()),
+		WithLogger(logger),
+	)
+
+	result, err := r.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if len(result.Actions) != 1 || result.Actions[0].Zone != "example.com" {
+		t.Fatalf("expected 1 action with zone example.com, got %+v", result.Actions)
+	}
+
+	created := metrics.RecordsCreatedTotal.WithLabelValues("zoned-dns", "A", "example.com")
+	if got := testutil.ToFloat64(created); got != 1 {
+		t.Errorf("dnsweaver_records_created_total{zone=\"example.com\"} = %v, want 1", got)
+	}
+}
+
+func TestReconcile_Annotations(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	dockerMock.AddWorkload("my-app", map[string]string{})
+
+	logger := quietLogger()
+
+	annotated := newTestMockSource("annotated", source.Hostname{
+		Name:        "annotated.example.com",
+		Source:      "annotated",
+		Annotations: map[string]string{"container_id": "abc123"},
+	})
+	sources := testSourceRegistry(logger, annotated)
+
+	mockProvider := newTestMockProvider("test-dns")
+	providers := testProviderRegistry(logger, mockProvider)
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	var buf bytes.Buffer
+	r := New(dockerMock, sources, providers,
+		WithConfig(DefaultConfig()),
+		WithLogger(logger),
+		WithAuditLogger(audit.New(&buf)),
+	)
+
+	result, err := r.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	// Annotations are observational: the record is created as usual
+	if result.CreatedCount() != 1 {
+		t.Fatalf("CreatedCount() = %d, want 1", result.CreatedCount())
+	}
+	if got := result.Actions[0].Annotations["container_id"]; got != "abc123" {
+		t.Errorf("action annotation container_id = %q, want abc123", got)
+	}
+
+	entry := findAuditEntry(readAuditEntries(t, &buf), "annotated.example.com")
+	if got := entry.Annotations["container_id"]; got != "abc123" {
+		t.Errorf("audit annotation container_id = %q, want abc123", got)
+	}
+
+	info := metrics.HostnameInfo.WithLabelValues("annotated.example.com", "annotated", "container_id")
+	if got := testutil.ToFloat64(info); got != 1 {
+		t.Errorf("dnsweaver_hostname_info = %v, want 1", got)
+	}
+}
+
 func TestReconcile_MultipleHostnamesFromOneWorkload(t *testing.T) {
 	// Workload with multiple Host() rules
 	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
@@ -478,6 +730,61 @@ func TestReconcile_OrphanCleanup(t *testing.T) {
 	}
 }
 
+func TestReconcile_APIOutageKeepsRecords(t *testing.T) {
+	var down atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"name": "app@docker", "rule": "Host(` + "`app.example.com`" + `)"}]`))
+	}))
+	defer server.Close()
+
+	logger := quietLogger()
+	sources := source.NewRegistry(logger)
+	sources.Register(traefik.New(traefik.WithLogger(logger), traefik.WithAPIEndpoint(server.URL, time.Nanosecond)))
+
+	mockProvider := newTestMockProvider("test-dns")
+	providers := testProviderRegistry(logger, mockProvider)
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	r := New(newTestMockWorkloadLister(docker.ModeSwarm), sources, providers,
+		WithConfig(Config{
+			Enabled:           true,
+			CleanupOrphans:    true,
+			OwnershipTracking: true,
+		}),
+		WithLogger(logger),
+	)
+
+	if _, err := r.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if len(mockProvider.GetCreatedDNSRecords()) != 1 {
+		t.Fatalf("expected the API hostname to be created, got %+v", mockProvider.GetCreated())
+	}
+
+	down.Store(true)
+	result, err := r.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if deleted := mockProvider.GetDeleted(); len(deleted) != 0 {
+		t.Errorf("expected no deletes during the API outage, got %+v", deleted)
+	}
+	if len(result.Deleted()) != 0 {
+		t.Errorf("expected no delete actions, got %+v", result.Deleted())
+	}
+}
+
 func TestReconcile_DisabledReturnsEmpty(t *testing.T) {
 	// This is already tested in reconciler_test.go but adding here for completeness
 	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
@@ -868,8 +1175,8 @@ func TestRecoverOwnership_MultipleProviders(t *testing.T) {
 // =============================================================================
 
 // TestReconcile_CaseSensitivity verifies that hostnames differing only in case
-// are treated as the same hostname (DNS is case-insensitive per RFC 1035).
-// BUG: Currently the code uses case-sensitive map keys, so this test FAILS.
+// are treated as the same hostname (DNS is case-insensitive per RFC 1035), and
+// that records are created with the normalized (lowercase) name.
 func TestReconcile_CaseSensitivity(t *testing.T) {
 	// Two workloads with same hostname in different cases
 	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
@@ -911,7 +1218,6 @@ func TestReconcile_CaseSensitivity(t *testing.T) {
 	}
 
 	// DNS is case-insensitive, so these should be treated as duplicates
-	// Currently FAILS because code uses case-sensitive comparison
 	if result.HostnamesDuplicate != 1 {
 		t.Errorf("HostnamesDuplicate = %d, want 1 (DNS is case-insensitive)", result.HostnamesDuplicate)
 	}
@@ -919,7 +1225,57 @@ func TestReconcile_CaseSensitivity(t *testing.T) {
 	// Should only create ONE DNS record
 	created := mockProvider.GetCreatedDNSRecords()
 	if len(created) != 1 {
-		t.Errorf("expected 1 DNS record (case-insensitive dedup), got %d", len(created))
+		t.Fatalf("expected 1 DNS record (case-insensitive dedup), got %d", len(created))
+	}
+	if created[0].Hostname != "app.example.com" {
+		t.Errorf("record hostname = %q, want normalized %q", created[0].Hostname, "app.example.com")
+	}
+}
+
+// TestReconcile_NormalizesHostnames verifies that mixed-case hostnames are
+// normalized before records are created and tracked.
+func TestReconcile_NormalizesHostnames(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	dockerMock.AddWorkload("my-app", map[string]string{
+		"traefik.http.routers.app.rule": "Host(`My-App.Example.COM`)",
+	})
+
+	logger := quietLogger()
+
+	sources := source.NewRegistry(logger)
+	sources.Register(traefik.New(traefik.WithLogger(logger)))
+
+	mockProvider := newTestMockProvider("test-dns")
+	providers := provider.NewRegistry(logger)
+	providers.RegisterFactory("mock", func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		return mockProvider, nil
+	})
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	r := New(dockerMock, sources, providers,
+		WithConfig(DefaultConfig()),
+		WithLogger(logger),
+	)
+
+	if _, err := r.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	for _, rec := range mockProvider.GetCreatedDNSRecords() {
+		if rec.Hostname != "my-app.example.com" {
+			t.Errorf("record hostname = %q, want %q", rec.Hostname, "my-app.example.com")
+		}
+	}
+	known := r.KnownHostnames()
+	if len(known) != 1 || known[0] != "my-app.example.com" {
+		t.Errorf("KnownHostnames() = %v, want [my-app.example.com]", known)
 	}
 }
 
@@ -1201,3 +1557,234 @@ func TestReconcile_AdoptExistingDisabled(t *testing.T) {
 		t.Errorf("expected 0 ownership TXT records (no adoption), got %d", len(ownershipRecords))
 	}
 }
+
+func TestReconcile_MaxHostnamesPerReconcile(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	for _, name := range []string{"a", "b", "c"} {
+		dockerMock.AddWorkload(name, map[string]string{
+			"traefik.http.routers." + name + ".rule": "Host(`" + name + ".example.com`)",
+		})
+	}
+
+	logger := quietLogger()
+
+	sources := source.NewRegistry(logger)
+	sources.Register(traefik.New(traefik.WithLogger(logger)))
+
+	mockProvider := newTestMockProvider("test-dns")
+	providers := testProviderRegistry(logger, mockProvider)
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	cfg := DefaultConfig()
+	cfg.OwnershipTracking = false
+	cfg.MaxHostnamesPerReconcile = 2
+	r := New(dockerMock, sources, providers,
+		WithConfig(cfg),
+		WithLogger(logger),
+	)
+
+	hostnamesOf := func(result *Result) []string {
+		var names []string
+		for _, a := range result.Actions {
+			names = append(names, a.Hostname)
+		}
+		return names
+	}
+
+	steps := []struct {
+		addWorkload string
+		want        []string
+	}{
+		// All new: the first two in order, c deferred
+		{want: []string{"a.example.com", "b.example.com"}},
+		// Deferred c first, then the known hostnames; b deferred
+		{want: []string{"c.example.com", "a.example.com"}},
+		// Newly discovered d first, then deferred b
+		{addWorkload: "d", want: []string{"d.example.com", "b.example.com"}},
+	}
+
+	for i, step := range steps {
+		if step.addWorkload != "" {
+			dockerMock.AddWorkload(step.addWorkload, map[string]string{
+				"traefik.http.routers." + step.addWorkload + ".rule": "Host(`" + step.addWorkload + ".example.com`)",
+			})
+		}
+		result, err := r.Reconcile(context.Background())
+		if err != nil {
+			t.Fatalf("reconcile %d: %v", i+1, err)
+		}
+		if got := hostnamesOf(result); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("reconcile %d processed %v, want %v", i+1, got, step.want)
+		}
+		if result.HostnamesDeferred == 0 {
+			t.Errorf("reconcile %d: HostnamesDeferred = 0, want > 0", i+1)
+		}
+	}
+
+	// Deferred hostnames are never treated as orphans
+	if len(mockProvider.deleted) != 0 {
+		t.Errorf("deleted %d records, want 0", len(mockProvider.deleted))
+	}
+}
+
+func TestReconcile_IgnoreLabels(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	for _, name := range []string{"app", "admin", "metrics1"} {
+		dockerMock.AddWorkload(name, map[string]string{
+			"traefik.http.routers." + name + ".rule": "Host(`" + name + ".example.com`)",
+		})
+	}
+
+	logger := quietLogger()
+
+	sources := source.NewRegistry(logger)
+	sources.Register(traefik.New(traefik.WithLogger(logger)))
+
+	mockProvider := newTestMockProvider("test-dns")
+	providers := testProviderRegistry(logger, mockProvider)
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	cfg := DefaultConfig()
+	cfg.OwnershipTracking = false
+	cfg.IgnoreLabels = []string{"Admin.example.com", "metrics?.*"}
+	r := New(dockerMock, sources, providers,
+		WithConfig(cfg),
+		WithLogger(logger),
+	)
+
+	result, err := r.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile() error: %v", err)
+	}
+	if result.HostnamesDiscovered != 1 {
+		t.Errorf("HostnamesDiscovered = %d, want 1", result.HostnamesDiscovered)
+	}
+	created := mockProvider.GetCreated()
+	if len(created) != 1 || created[0].Hostname != "app.example.com" {
+		t.Errorf("created %+v, want only app.example.com", created)
+	}
+}
+
+func TestReconcile_DomainsAllowlist(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	for _, host := range []string{"app.internal.example.com", "app.public.example.com", "stray.example.com"} {
+		dockerMock.AddWorkload(host, map[string]string{
+			"traefik.http.routers.r.rule": "Host(`" + host + "`)",
+		})
+	}
+
+	logger := quietLogger()
+
+	sources := source.NewRegistry(logger)
+	sources.Register(traefik.New(traefik.WithLogger(logger)))
+
+	mockProvider := newTestMockProvider("test-dns")
+	providers := testProviderRegistry(logger, mockProvider)
+	_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+
+	cfg := DefaultConfig()
+	cfg.OwnershipTracking = false
+	cfg.DomainsAllowlist = []string{"*.internal.example.com", "*.public.example.com"}
+	cfg.IgnoreLabels = []string{"app.public.example.com"}
+	r := New(dockerMock, sources, providers,
+		WithConfig(cfg),
+		WithLogger(logger),
+	)
+
+	if _, err := r.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile() error: %v", err)
+	}
+	created := mockProvider.GetCreated()
+	if len(created) != 1 || created[0].Hostname != "app.internal.example.com" {
+		t.Errorf("created %+v, want only app.internal.example.com", created)
+	}
+}
+
+func TestReconcile_LabelPriorityMap(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		priorities   map[string]int
+		wantTarget   string
+		wantWorkload string
+	}{
+		{name: "first wins", wantTarget: "10.0.0.1", wantWorkload: "a"},
+		{name: "equal priority", priorities: map[string]int{"traefik": 5, "dnsweaver": 5}, wantTarget: "10.0.0.1", wantWorkload: "a"},
+		{name: "higher priority wins", priorities: map[string]int{"traefik": 5, "dnsweaver": 10}, wantTarget: "10.0.0.9", wantWorkload: "b"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+			dockerMock.AddWorkload("a", map[string]string{
+				"traefik.http.routers.a.rule": "Host(`app.example.com`)",
+			})
+			dockerMock.AddWorkload("b", map[string]string{
+				"dnsweaver.enabled":  "true",
+				"dnsweaver.hostname": "app.example.com",
+				"dnsweaver.target":   "10.0.0.9",
+			})
+
+			logger := quietLogger()
+
+			sources := source.NewRegistry(logger)
+			sources.Register(traefik.New(traefik.WithLogger(logger)))
+			sources.Register(dnsweaversource.New(dnsweaversource.WithLogger(logger)))
+
+			mockProvider := newTestMockProvider("test-dns")
+			providers := testProviderRegistry(logger, mockProvider)
+			_ = providers.CreateInstance(provider.ProviderInstanceConfig{
+				Name:       "test-dns",
+				TypeName:   "mock",
+				RecordType: provider.RecordTypeA,
+				Target:     "10.0.0.1",
+				TTL:        300,
+				Domains:    []string{"*.example.com"},
+			})
+
+			cfg := DefaultConfig()
+			cfg.OwnershipTracking = false
+			cfg.LabelPriorityMap = tt.priorities
+			r := New(dockerMock, sources, providers,
+				WithConfig(cfg),
+				WithLogger(logger),
+			)
+
+			result, err := r.Reconcile(context.Background())
+			if err != nil {
+				t.Fatalf("Reconcile() error: %v", err)
+			}
+			if result.HostnamesDuplicate != 1 {
+				t.Errorf("HostnamesDuplicate = %d, want 1", result.HostnamesDuplicate)
+			}
+			created := mockProvider.GetCreated()
+			if len(created) != 1 || created[0].Target != tt.wantTarget {
+				t.Errorf("created %+v, want one record targeting %s", created, tt.wantTarget)
+			}
+			if got := r.workloadHostnames[tt.wantWorkload]; !reflect.DeepEqual(got, []string{"app.example.com"}) {
+				t.Errorf("workloadHostnames[%s] = %v, want [app.example.com]", tt.wantWorkload, got)
+			}
+			if len(r.workloadHostnames) != 1 {
+				t.Errorf("workloadHostnames = %v, want only %s", r.workloadHostnames, tt.wantWorkload)
+			}
+		})
+	}
+}
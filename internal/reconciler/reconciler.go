@@ -6,11 +6,16 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sort"
 	"sync"
 	"time"
 
+	"gitlab.bluewillows.net/root/dnsweaver/internal/audit"
 	"gitlab.bluewillows.net/root/dnsweaver/internal/docker"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/matcher"
 	"gitlab.bluewillows.net/root/dnsweaver/internal/metrics"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/notify"
+	"gitlab.bluewillows.net/root/dnsweaver/internal/policy"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
 )
@@ -19,6 +24,7 @@ import (
 const (
 	errRecordAlreadyExists = "record already exists"
 	errRecordTypeConflict  = "record type conflict"
+	errPolicyDenied        = "denied by policy"
 )
 
 // Config holds reconciler configuration options.
@@ -45,6 +51,33 @@ type Config struct {
 	// Enabled controls whether reconciliation is active.
 	// When false, Reconcile() returns immediately without doing anything.
 	Enabled bool
+
+	// MaxHostnamesPerReconcile caps how many hostnames one Reconcile() call
+	// ensures records for. Hostnames over the limit are deferred to the next
+	// reconciliation; orphan cleanup is not limited. Zero means unlimited.
+	MaxHostnamesPerReconcile int
+
+	// IgnoreLabels holds glob patterns (*, ?) for hostnames that dnsweaver
+	// must never manage. Hostnames extracted from workload labels or files
+	// that match a pattern are dropped before provider matching.
+	IgnoreLabels []string
+
+	// DomainsAllowlist holds glob patterns (*, ?) restricting which hostnames
+	// dnsweaver manages at all. Hostnames extracted from workload labels or
+	// files that match no pattern are dropped before provider matching.
+	// Empty allows every hostname.
+	DomainsAllowlist []string
+
+	// LabelPriorityMap resolves hostnames defined by more than one workload
+	// or file source: keys are source names (e.g. "traefik", "dnsweaver")
+	// and the hostname from the source with the highest value wins. Unlisted
+	// sources have priority 0; on equal priority the first definition wins.
+	LabelPriorityMap map[string]int
+
+	// ProviderFallback writes each hostname to the matching provider
+	// instances one at a time in priority order, stopping at the first that
+	// succeeds, instead of writing to every matching instance.
+	ProviderFallback bool
 }
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -82,12 +115,57 @@ type Reconciler struct {
 	providers *provider.Registry
 	config    Config
 	logger    *slog.Logger
+	audit     *audit.Logger
+	notifier  notify.Notifier
+	changes   []ChangeHandler
+	policy    policy.Checker
+
+	// allowed matches Config.DomainsAllowlist and ignored matches
+	// Config.IgnoreLabels (nil when no patterns are set)
+	allowed *matcher.DomainMatcher
+	ignored *matcher.DomainMatcher
+
+	// weightWarned holds the names of provider instances already warned
+	// about ignoring weight hints.
+	weightWarned sync.Map
 
-	// mu protects knownHostnames during concurrent access
+	// mu protects knownHostnames and workloadHostnames during concurrent access
 	mu sync.RWMutex
 	// knownHostnames tracks hostnames discovered in the last reconciliation.
 	// Used for orphan detection.
 	knownHostnames map[string]struct{}
+	// workloadHostnames maps workload name -> normalized hostnames it defined
+	// (first workload wins for duplicates). Used by ReconcileWorkload.
+	workloadHostnames map[string][]string
+	// deferredHostnames holds hostnames left over by MaxHostnamesPerReconcile,
+	// which the next reconciliation processes before other known hostnames.
+	deferredHostnames map[string]struct{}
+
+	// recordCacheTTL shares one record cache across reconciliations for this
+	// long. Zero builds a fresh cache for every reconciliation.
+	recordCacheTTL time.Duration
+	// cacheMu protects sharedCache
+	cacheMu     sync.Mutex
+	sharedCache *recordCache
+
+	// retryAttempts is how many times a failed record operation is tried
+	// (1 = no retries). retryBackoff is the delay before the first retry,
+	// doubled for each further retry.
+	retryAttempts int
+	retryBackoff  time.Duration
+
+	// failures suppresses hostname+provider pairs that keep failing
+	// (nil disables reconcile backoff).
+	failures *failureTracker
+
+	// inflightMu protects inflight, shuttingDown, and drained
+	inflightMu sync.Mutex
+	// inflight counts reconciliations currently running.
+	inflight int
+	// shuttingDown rejects new reconciliations once Shutdown is called.
+	shuttingDown bool
+	// drained is closed when inflight drops to zero during Shutdown.
+	drained chan struct{}
 }
 
 // Option is a functional option for configuring the Reconciler.
@@ -100,6 +178,72 @@ func WithLogger(logger *slog.Logger) Option {
 	}
 }
 
+// WithAuditLogger records every DNS record mutation in a tamper-evident audit log.
+func WithAuditLogger(l *audit.Logger) Option {
+	return func(r *Reconciler) {
+		r.audit = l
+	}
+}
+
+// WithNotifier sends a summary of each reconciliation's record changes to n.
+func WithNotifier(n notify.Notifier) Option {
+	return func(r *Reconciler) {
+		r.notifier = n
+	}
+}
+
+// WithChangeHandler reports each record create, delete, and failure to h.
+// It may be given more than once to register several handlers.
+func WithChangeHandler(h ChangeHandler) Option {
+	return func(r *Reconciler) {
+		if h != nil {
+			r.changes = append(r.changes, h)
+		}
+	}
+}
+
+// WithPolicy checks every record create and delete against an admission
+// policy. Denied operations are skipped.
+func WithPolicy(c policy.Checker) Option {
+	return func(r *Reconciler) {
+		r.policy = c
+	}
+}
+
+// WithRecordCacheTTL shares the provider record cache across reconciliations
+// for up to d. Records created or deleted by dnsweaver invalidate the affected
+// hostnames; changes made outside dnsweaver are only seen once the cache
+// expires. Zero (the default) lists every provider on each reconciliation.
+func WithRecordCacheTTL(d time.Duration) Option {
+	return func(r *Reconciler) {
+		r.recordCacheTTL = d
+	}
+}
+
+// WithRetry tries failed record operations up to attempts times in total,
+// waiting backoff before the first retry and doubling the wait after each
+// further failure. Conflicts are not retried. Values below 2 disable retries.
+func WithRetry(attempts int, backoff time.Duration) Option {
+	return func(r *Reconciler) {
+		r.retryAttempts = attempts
+		r.retryBackoff = backoff
+	}
+}
+
+// WithReconcileBackoff skips a hostname in a provider after threshold
+// consecutive failed reconciliations, first for initial and then for twice
+// as long after each further failure, up to max. A successful operation
+// clears the backoff. A threshold below 1 disables reconcile backoff.
+func WithReconcileBackoff(threshold int, initial, max time.Duration) Option {
+	return func(r *Reconciler) {
+		if threshold < 1 || initial <= 0 {
+			r.failures = nil
+			return
+		}
+		r.failures = newFailureTracker(threshold, initial, max)
+	}
+}
+
 // WithConfig sets the reconciler configuration.
 func WithConfig(cfg Config) Option {
 	return func(r *Reconciler) {
@@ -120,17 +264,20 @@ func New(
 	opts ...Option,
 ) *Reconciler {
 	r := &Reconciler{
-		docker:         dockerClient,
-		sources:        sources,
-		providers:      providers,
-		config:         DefaultConfig(),
-		logger:         slog.Default(),
-		knownHostnames: make(map[string]struct{}),
+		docker:            dockerClient,
+		sources:           sources,
+		providers:         providers,
+		config:            DefaultConfig(),
+		logger:            slog.Default(),
+		knownHostnames:    make(map[string]struct{}),
+		workloadHostnames: make(map[string][]string),
 	}
 
 	for _, opt := range opts {
 		opt(r)
 	}
+	r.allowed = newPatternMatcher("DomainsAllowlist", r.config.DomainsAllowlist, r.logger)
+	r.ignored = newPatternMatcher("IgnoreLabels", r.config.IgnoreLabels, r.logger)
 
 	return r
 }
@@ -153,6 +300,11 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*Result, error) {
 		return result, nil
 	}
 
+	if err := r.begin(); err != nil {
+		return nil, err
+	}
+	defer r.end()
+
 	r.logger.Info("starting reconciliation",
 		slog.Bool("dry_run", r.config.DryRun),
 		slog.Bool("cleanup_orphans", r.config.CleanupOrphans),
@@ -173,7 +325,7 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*Result, error) {
 	)
 
 	// Step 2: Extract hostnames from each workload
-	discoveredHostnames := r.extractHostnames(ctx, workloads, result)
+	discoveredHostnames, workloadHostnames := r.extractHostnames(ctx, workloads, result)
 
 	result.HostnamesDiscovered = len(discoveredHostnames)
 
@@ -185,12 +337,18 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*Result, error) {
 	// Step 3: Build record cache for all providers (single List() call per provider)
 	var cache *recordCache
 	if !r.config.DryRun {
-		cache = newRecordCache(ctx, r.providers, r.logger)
+		cache = r.loadRecordCache(ctx)
 	}
 
-	// Step 4: Ensure records exist for all discovered hostnames
-	for _, hostname := range discoveredHostnames {
-		actions := r.ensureRecord(ctx, hostname, cache)
+	// Step 4: Ensure records exist for discovered hostnames, up to the per-reconcile limit.
+	// Providers that start out empty get their records in one bulk create where supported.
+	names := r.limitHostnames(discoveredHostnames, result)
+	bulkCreated := r.bulkCreate(ctx, names, discoveredHostnames, cache)
+	for _, name := range names {
+		actions, created := bulkCreated[name]
+		if !created {
+			actions = r.ensureRecord(ctx, discoveredHostnames[name], cache)
+		}
 		for _, action := range actions {
 			result.AddAction(action)
 		}
@@ -210,12 +368,17 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*Result, error) {
 	for name := range discoveredHostnames {
 		r.knownHostnames[name] = struct{}{}
 	}
+	r.workloadHostnames = workloadHostnames
 	r.mu.Unlock()
 
 	result.Complete()
 
 	// Record metrics
 	r.recordMetrics(result)
+	recordHostnameInfo(discoveredHostnames)
+	r.auditResult(result)
+	r.changeResult(result)
+	r.notifyResult(ctx, result)
 
 	r.logger.Info("reconciliation complete",
 		slog.Int("created", result.CreatedCount()),
@@ -229,37 +392,63 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*Result, error) {
 	return result, nil
 }
 
+// limitHostnames returns the normalized hostnames to ensure records for in
+// this reconciliation. With MaxHostnamesPerReconcile set, newly discovered
+// hostnames come first, then those deferred by the previous reconciliation,
+// then the remaining known hostnames; hostnames over the limit are deferred.
+// Processed hostnames move to the back of the queue, so every hostname is
+// reached within a few reconciliations.
+func (r *Reconciler) limitHostnames(discovered map[string]*source.Hostname, result *Result) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var newNames, deferred, known []string
+	for name := range discovered {
+		if _, ok := r.knownHostnames[name]; !ok {
+			newNames = append(newNames, name)
+		} else if _, ok := r.deferredHostnames[name]; ok {
+			deferred = append(deferred, name)
+		} else {
+			known = append(known, name)
+		}
+	}
+	sort.Strings(newNames)
+	sort.Strings(deferred)
+	sort.Strings(known)
+	names := append(append(newNames, deferred...), known...)
+
+	limit := r.config.MaxHostnamesPerReconcile
+	r.deferredHostnames = nil
+	if limit <= 0 || len(names) <= limit {
+		return names
+	}
+
+	r.deferredHostnames = make(map[string]struct{}, len(names)-limit)
+	for _, name := range names[limit:] {
+		r.deferredHostnames[name] = struct{}{}
+	}
+	result.HostnamesDeferred = len(names) - limit
+
+	r.logger.Warn("hostname limit reached, deferring the rest to the next reconciliation",
+		slog.Int("limit", limit),
+		slog.Int("processed", limit),
+		slog.Int("deferred", len(names)-limit),
+	)
+	return names[:limit]
+}
+
 // extractHostnames extracts hostnames from workloads and file sources.
-// Returns a map of normalized hostname -> source.Hostname.
-func (r *Reconciler) extractHostnames(ctx context.Context, workloads []docker.Workload, result *Result) map[string]*source.Hostname {
+// Returns a map of normalized hostname -> source.Hostname, and a map of
+// workload name -> normalized hostnames that workload defined.
+func (r *Reconciler) extractHostnames(ctx context.Context, workloads []docker.Workload, result *Result) (map[string]*source.Hostname, map[string][]string) {
 	// Track hostname -> first workload that defined it (for duplicate detection)
 	// Use map to source.Hostname to preserve RecordHints from native labels
 	discoveredHostnames := make(map[string]*source.Hostname)
 	hostnameOrigins := make(map[string]string) // hostname -> workload name
+	workloadHostnames := make(map[string][]string)
 
 	for _, workload := range workloads {
-		hostnames := r.sources.ExtractAll(ctx, workload.Labels)
-
-		// Validate hostnames and log warnings for invalid ones
-		validation := hostnames.ValidateAll()
-		for _, inv := range validation.Invalid {
-			r.logger.Warn("skipping invalid hostname from workload",
-				slog.String("workload", workload.Name),
-				slog.String("hostname", inv.Hostname.Name),
-				slog.String("source", inv.Hostname.Source),
-				slog.String("error", inv.Error.Error()),
-			)
-			result.HostnamesInvalid++
-		}
-		hostnames = validation.Valid
-
-		if len(hostnames) > 0 {
-			r.logger.Debug("extracted hostnames from workload",
-				slog.String("workload", workload.Name),
-				slog.Int("count", len(hostnames)),
-				slog.Any("hostnames", hostnames.Names()),
-			)
-		}
+		hostnames := r.extractWorkloadHostnames(ctx, workload, result)
 
 		for i := range hostnames {
 			hostname := &hostnames[i]
@@ -273,16 +462,32 @@ func (r *Reconciler) extractHostnames(ctx context.Context, workloads []docker.Wo
 					slog.String("duplicate_workload", workload.Name),
 				)
 				result.HostnamesDuplicate++
-				// First workload wins - don't update hostnameOrigins
+				// First workload wins unless the duplicate comes from a higher-priority source
+				if r.outranks(hostname, discoveredHostnames[normalizedName]) {
+					r.logger.Info("duplicate hostname taken from higher-priority source",
+						slog.String("hostname", hostname.Name),
+						slog.String("source", hostname.Source),
+						slog.String("workload", workload.Name),
+					)
+					hostnameOrigins[normalizedName] = workload.Name
+					discoveredHostnames[normalizedName] = hostname
+					if names := removeHostname(workloadHostnames[existingWorkload], normalizedName); len(names) > 0 {
+						workloadHostnames[existingWorkload] = names
+					} else {
+						delete(workloadHostnames, existingWorkload)
+					}
+					workloadHostnames[workload.Name] = append(workloadHostnames[workload.Name], normalizedName)
+				}
 			} else {
 				hostnameOrigins[normalizedName] = workload.Name
 				discoveredHostnames[normalizedName] = hostname
+				workloadHostnames[workload.Name] = append(workloadHostnames[workload.Name], normalizedName)
 			}
 		}
 	}
 
 	// Discover hostnames from static config files (Traefik YAML, etc.)
-	fileHostnames := r.sources.DiscoverAll(ctx)
+	fileHostnames := r.sources.DiscoverAll(ctx).Normalize()
 	if len(fileHostnames) > 0 {
 		// Validate file-discovered hostnames
 		validation := fileHostnames.ValidateAll()
@@ -295,7 +500,7 @@ func (r *Reconciler) extractHostnames(ctx context.Context, workloads []docker.Wo
 			)
 			result.HostnamesInvalid++
 		}
-		fileHostnames = validation.Valid
+		fileHostnames = r.filterHostnames(validation.Valid)
 
 		r.logger.Debug("discovered hostnames from files",
 			slog.Int("count", len(fileHostnames)),
@@ -305,19 +510,53 @@ func (r *Reconciler) extractHostnames(ctx context.Context, workloads []docker.Wo
 			hostname := &fileHostnames[i]
 			// Use normalized (lowercase) name as key for case-insensitive comparison (RFC 1035)
 			normalizedName := hostname.NormalizedName()
-			if _, exists := discoveredHostnames[normalizedName]; !exists {
+			if existing, exists := discoveredHostnames[normalizedName]; !exists || r.outranks(hostname, existing) {
 				discoveredHostnames[normalizedName] = hostname
 			}
 		}
 	}
 
-	return discoveredHostnames
+	return discoveredHostnames, workloadHostnames
+}
+
+// extractWorkloadHostnames extracts and validates the hostnames from a single
+// workload's labels. Invalid hostnames are logged and counted in result.
+func (r *Reconciler) extractWorkloadHostnames(ctx context.Context, workload docker.Workload, result *Result) source.Hostnames {
+	// Normalize at entry so every map key, record, and log line uses the canonical name
+	hostnames := r.sources.ExtractAll(ctx, workload.Labels).Normalize()
+
+	// Validate hostnames and log warnings for invalid ones
+	validation := hostnames.ValidateAll()
+	for _, inv := range validation.Invalid {
+		r.logger.Warn("skipping invalid hostname from workload",
+			slog.String("workload", workload.Name),
+			slog.String("hostname", inv.Hostname.Name),
+			slog.String("source", inv.Hostname.Source),
+			slog.String("error", inv.Error.Error()),
+		)
+		result.HostnamesInvalid++
+	}
+	hostnames = r.filterHostnames(validation.Valid)
+
+	if len(hostnames) > 0 {
+		r.logger.Debug("extracted hostnames from workload",
+			slog.String("workload", workload.Name),
+			slog.Int("count", len(hostnames)),
+			slog.Any("hostnames", hostnames.Names()),
+		)
+	}
+
+	return hostnames
 }
 
 // ReconcileHostname performs reconciliation for a single hostname.
 // This is useful for event-driven updates when a specific workload changes.
 // Note: This does not use the record cache since it's a single hostname operation.
+// Hostnames removed by the allowlist or ignore list return ErrHostnameFiltered.
 func (r *Reconciler) ReconcileHostname(ctx context.Context, hostnameStr string) (*Result, error) {
+	// Records and knownHostnames use the normalized name, like discovered hostnames
+	hostnameStr = source.NormalizeHostname(hostnameStr)
+
 	if !r.config.Enabled {
 		r.logger.Debug("reconciliation disabled, skipping hostname",
 			slog.String("hostname", hostnameStr),
@@ -327,6 +566,16 @@ func (r *Reconciler) ReconcileHostname(ctx context.Context, hostnameStr string)
 		return result, nil
 	}
 
+	// Apply the same allowlist and ignore list as discovered hostnames
+	if len(r.filterHostnames(source.Hostnames{{Name: hostnameStr, Source: "api"}})) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrHostnameFiltered, hostnameStr)
+	}
+
+	if err := r.begin(); err != nil {
+		return nil, err
+	}
+	defer r.end()
+
 	r.logger.Debug("reconciling single hostname",
 		slog.String("hostname", hostnameStr),
 		slog.Bool("dry_run", r.config.DryRun),
@@ -342,26 +591,36 @@ func (r *Reconciler) ReconcileHostname(ctx context.Context, hostnameStr string)
 	for _, action := range actions {
 		result.AddAction(action)
 	}
+	r.cachedRecords().invalidateActions(actions)
 
-	// Track this hostname as known (normalized for case-insensitive comparison)
-	normalizedHostname := source.NormalizeHostname(hostnameStr)
+	// Track this hostname as known
 	r.mu.Lock()
-	r.knownHostnames[normalizedHostname] = struct{}{}
+	r.knownHostnames[hostnameStr] = struct{}{}
 	r.mu.Unlock()
 
 	result.Complete()
+	r.auditResult(result)
+	r.changeResult(result)
+	r.notifyResult(ctx, result)
 	return result, nil
 }
 
 // RemoveHostname removes DNS records for a hostname that is no longer needed.
 // This is useful for event-driven cleanup when a workload is removed.
 func (r *Reconciler) RemoveHostname(ctx context.Context, hostname string) (*Result, error) {
+	hostname = source.NormalizeHostname(hostname)
+
 	if !r.config.Enabled {
 		result := NewResult(r.config.DryRun)
 		result.Complete()
 		return result, nil
 	}
 
+	if err := r.begin(); err != nil {
+		return nil, err
+	}
+	defer r.end()
+
 	r.logger.Debug("removing hostname",
 		slog.String("hostname", hostname),
 		slog.Bool("dry_run", r.config.DryRun),
@@ -373,6 +632,7 @@ func (r *Reconciler) RemoveHostname(ctx context.Context, hostname string) (*Resu
 	for _, action := range actions {
 		result.AddAction(action)
 	}
+	r.cachedRecords().invalidateActions(actions)
 
 	// Remove from known hostnames
 	r.mu.Lock()
@@ -380,9 +640,45 @@ func (r *Reconciler) RemoveHostname(ctx context.Context, hostname string) (*Resu
 	r.mu.Unlock()
 
 	result.Complete()
+	r.auditResult(result)
+	r.changeResult(result)
+	r.notifyResult(ctx, result)
 	return result, nil
 }
 
+// loadRecordCache returns the record cache for a reconciliation. Without a
+// record cache TTL a new cache is built each time. Otherwise the shared cache
+// is reused until it expires, re-listing only providers that failed to load
+// or have invalidated hostnames.
+func (r *Reconciler) loadRecordCache(ctx context.Context) *recordCache {
+	if r.recordCacheTTL <= 0 {
+		return newRecordCache(ctx, r.providers, r.logger)
+	}
+
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	if r.sharedCache == nil || time.Since(r.sharedCache.builtAt) >= r.recordCacheTTL {
+		r.sharedCache = newRecordCache(ctx, r.providers, r.logger)
+		return r.sharedCache
+	}
+
+	r.logger.Debug("reusing record cache",
+		slog.Duration("age", time.Since(r.sharedCache.builtAt)),
+		slog.Duration("ttl", r.recordCacheTTL),
+	)
+	r.sharedCache.refresh(ctx, r.providers)
+	return r.sharedCache
+}
+
+// cachedRecords returns the shared record cache, or nil if there is none.
+// Used to invalidate hostnames changed outside a cached reconciliation.
+func (r *Reconciler) cachedRecords() *recordCache {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+	return r.sharedCache
+}
+
 // Config returns the current reconciler configuration.
 func (r *Reconciler) Config() Config {
 	return r.config
@@ -468,6 +764,184 @@ func (r *Reconciler) RecoverOwnership(ctx context.Context) error {
 	return nil
 }
 
+// MigrateOwnership rewrites legacy ownership TXT records ("heritage=dnsweaver")
+// to the current versioned format on every provider that supports them.
+// Failures are logged per provider and do not stop the migration.
+//
+// Skipped when ownership tracking is disabled or in dry-run mode.
+func (r *Reconciler) MigrateOwnership(ctx context.Context) {
+	if !r.config.OwnershipTracking || r.config.DryRun {
+		return
+	}
+
+	for _, inst := range r.providers.All() {
+		if !inst.Provider.Capabilities().SupportsOwnershipTXT {
+			continue
+		}
+		migrated, err := inst.MigrateOwnershipRecords(ctx)
+		if err != nil {
+			r.logger.Warn("failed to migrate ownership records",
+				slog.String("provider", inst.Name()),
+				slog.Int("migrated", migrated),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if migrated > 0 {
+			r.logger.Info("migrated legacy ownership records",
+				slog.String("provider", inst.Name()),
+				slog.Int("count", migrated),
+			)
+		}
+	}
+}
+
+// auditResult writes an audit entry for every attempted mutation in the result.
+// Skipped and dry-run actions made no changes and are not audited.
+func (r *Reconciler) auditResult(result *Result) {
+	if r.audit == nil {
+		return
+	}
+
+	for _, action := range result.Actions {
+		if action.DryRun || action.Type == ActionSkip {
+			continue
+		}
+		if action.Status != StatusSuccess && action.Status != StatusFailed {
+			continue
+		}
+		r.auditAction(action)
+	}
+}
+
+// auditAction writes an audit entry for a single attempted mutation.
+func (r *Reconciler) auditAction(action Action) {
+	if r.audit == nil {
+		return
+	}
+
+	entry := audit.Entry{
+		Action:      string(action.Type),
+		Status:      string(action.Status),
+		Hostname:    action.Hostname,
+		RecordType:  action.RecordType,
+		Provider:    action.Provider,
+		Error:       action.Error,
+		ErrorCode:   action.ErrorCode,
+		Annotations: action.Annotations,
+	}
+	switch action.Type {
+	case ActionDelete:
+		entry.OldValue = action.Target
+	case ActionUpdate:
+		entry.OldValue = action.OldTarget
+		entry.NewValue = action.Target
+	default:
+		entry.NewValue = action.Target
+	}
+
+	// Write failures are logged by the audit logger and must not fail reconciliation
+	_ = r.audit.Log(entry)
+}
+
+// auditRecord writes an audit entry for a mutation of rec that has no action
+// of its own in the result: ownership TXT records, stale SRV records, and the
+// records of a failed bulk create. A nil err is audited as a success.
+func (r *Reconciler) auditRecord(actionType ActionType, inst *provider.ProviderInstance, rec provider.Record, err error) {
+	action := Action{
+		Type:       actionType,
+		Status:     StatusSuccess,
+		Provider:   inst.Name(),
+		Zone:       inst.Zone(),
+		Hostname:   rec.Hostname,
+		RecordType: string(rec.Type),
+		Target:     rec.Target,
+	}
+	if err != nil {
+		action.fail(err)
+	}
+	r.auditAction(action)
+}
+
+// notifyResult sends a summary of the result's record changes to the notifier.
+// The notifier decides whether the summary has enough changes to be sent.
+func (r *Reconciler) notifyResult(ctx context.Context, result *Result) {
+	if r.notifier == nil {
+		return
+	}
+
+	summary := notify.Summary{DryRun: result.DryRun}
+	byProvider := make(map[string]*notify.ProviderSummary)
+	for _, action := range result.Actions {
+		if action.Type == ActionSkip || (action.Status != StatusSuccess && action.Status != StatusFailed) {
+			continue
+		}
+		ps, ok := byProvider[action.Provider]
+		if !ok {
+			ps = &notify.ProviderSummary{Name: action.Provider}
+			byProvider[action.Provider] = ps
+		}
+		if action.Status == StatusFailed {
+			ps.Failed++
+			summary.Errors = append(summary.Errors, fmt.Sprintf("%s %s (%s): %s",
+				action.Type, action.Hostname, action.Provider, action.Error))
+			continue
+		}
+		switch action.Type {
+		case ActionCreate:
+			ps.Created++
+		case ActionUpdate:
+			ps.Updated++
+		case ActionDelete:
+			ps.Deleted++
+		}
+	}
+	for _, ps := range byProvider {
+		summary.Providers = append(summary.Providers, *ps)
+	}
+	sort.Slice(summary.Providers, func(i, j int) bool {
+		return summary.Providers[i].Name < summary.Providers[j].Name
+	})
+
+	// Delivery failures are logged by the notifier and must not fail reconciliation
+	_ = r.notifier.Notify(ctx, summary)
+}
+
+// recordHostnameInfo replaces the dnsweaver_hostname_info series with the
+// hostnames discovered in a full reconciliation and their annotation keys.
+// Annotation values are arbitrary and left out to bound label cardinality;
+// they are available in audit entries.
+func recordHostnameInfo(hostnames map[string]*source.Hostname) {
+	metrics.HostnameInfo.Reset()
+	for name, hostname := range hostnames {
+		if len(hostname.Annotations) == 0 {
+			metrics.HostnameInfo.WithLabelValues(name, hostname.Source, "").Set(1)
+			continue
+		}
+		for key := range hostname.Annotations {
+			metrics.HostnameInfo.WithLabelValues(name, hostname.Source, key).Set(1)
+		}
+	}
+}
+
+// failureCode returns the error code label for a failed action.
+func failureCode(action Action) string {
+	if action.ErrorCode == "" {
+		return provider.CodeUnknown
+	}
+	return action.ErrorCode
+}
+
+// ResetBackoff clears the reconcile backoff state so that every hostname is
+// attempted again on the next reconciliation. Returns the number of
+// hostname+provider pairs that were backed off.
+func (r *Reconciler) ResetBackoff() int {
+	if r.failures == nil {
+		return 0
+	}
+	return r.failures.reset()
+}
+
 // recordMetrics records Prometheus metrics from a reconciliation result.
 func (r *Reconciler) recordMetrics(result *Result) {
 	// Record reconciliation outcome
@@ -489,20 +963,20 @@ func (r *Reconciler) recordMetrics(result *Result) {
 		switch action.Type {
 		case ActionCreate:
 			if action.Status == StatusSuccess {
-				metrics.RecordsCreatedTotal.WithLabelValues(action.Provider).Inc()
+				metrics.RecordsCreatedTotal.WithLabelValues(action.Provider, action.RecordType, action.Zone).Inc()
 			} else if action.Status == StatusFailed {
-				metrics.RecordsFailedTotal.WithLabelValues(action.Provider, "create").Inc()
+				metrics.RecordsFailedTotal.WithLabelValues(action.Provider, "create", failureCode(action), action.RecordType, action.Zone).Inc()
 			}
 		case ActionDelete:
 			if action.Status == StatusSuccess {
-				metrics.RecordsDeletedTotal.WithLabelValues(action.Provider).Inc()
+				metrics.RecordsDeletedTotal.WithLabelValues(action.Provider, action.RecordType, action.Zone).Inc()
 			} else if action.Status == StatusFailed {
-				metrics.RecordsFailedTotal.WithLabelValues(action.Provider, "delete").Inc()
+				metrics.RecordsFailedTotal.WithLabelValues(action.Provider, "delete", failureCode(action), action.RecordType, action.Zone).Inc()
 			}
 		case ActionUpdate:
 			// Update actions are currently not emitted, but handle for completeness
 			if action.Status == StatusFailed {
-				metrics.RecordsFailedTotal.WithLabelValues(action.Provider, "update").Inc()
+				metrics.RecordsFailedTotal.WithLabelValues(action.Provider, "update", failureCode(action), action.RecordType, action.Zone).Inc()
 			}
 		case ActionSkip:
 			reason := "unknown"
@@ -513,6 +987,12 @@ func (r *Reconciler) recordMetrics(result *Result) {
 			if reason == "no matching provider" {
 				reason = "no_provider"
 			}
+			if reason == errPolicyDenied {
+				reason = "policy_denied"
+			}
+			if action.BackedOff {
+				reason = "backed_off"
+			}
 			metrics.RecordsSkippedTotal.WithLabelValues(reason).Inc()
 		}
 	}
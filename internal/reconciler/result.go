@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 )
 
 // ActionType represents the type of reconciliation action.
@@ -39,28 +41,62 @@ const (
 // Action represents a single reconciliation action on a DNS record.
 type Action struct {
 	// Type is the action type (create, delete, skip).
-	Type ActionType
+	Type ActionType `json:"type"`
 
 	// Status is the outcome of the action.
-	Status ActionStatus
+	Status ActionStatus `json:"status"`
 
 	// Provider is the provider instance name that handles this record.
-	Provider string
+	Provider string `json:"provider"`
+
+	// Zone is the provider's configured zone, if it has one.
+	Zone string `json:"zone,omitempty"`
 
 	// Hostname is the DNS hostname being affected.
-	Hostname string
+	Hostname string `json:"hostname"`
 
 	// RecordType is "A" or "CNAME".
-	RecordType string
+	RecordType string `json:"record_type"`
 
 	// Target is the record value (IP or hostname).
-	Target string
+	Target string `json:"target"`
+
+	// OldTarget is the previous record value for update actions.
+	OldTarget string `json:"old_target,omitempty"`
 
 	// Error contains the error message if Status is StatusFailed.
-	Error string
+	Error string `json:"error,omitempty"`
+
+	// ErrorCode classifies Error (e.g. "auth", "quota", "network"); see provider.ErrorCode.
+	ErrorCode string `json:"error_code,omitempty"`
+
+	// BackedOff is true when the action was skipped because the hostname kept
+	// failing in this provider (see WithReconcileBackoff).
+	BackedOff bool `json:"backed_off,omitempty"`
+
+	// Attempts is how many times the operation was tried (more than 1 when retried).
+	Attempts int `json:"attempts,omitempty"`
+
+	// err is the provider error behind a failed action, used to decide on retries.
+	err error
+
+	// unsupported is true when the action was skipped because the provider
+	// does not support the record type.
+	unsupported bool
 
 	// DryRun indicates this action was not actually executed.
-	DryRun bool
+	DryRun bool `json:"dry_run"`
+
+	// Annotations are the source annotations of the hostname, if any.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// fail marks the action as failed with err.
+func (a *Action) fail(err error) {
+	a.Status = StatusFailed
+	a.Error = err.Error()
+	a.ErrorCode = provider.ErrorCode(err)
+	a.err = err
 }
 
 // String returns a human-readable representation of the action.
@@ -82,29 +118,33 @@ func (a Action) String() string {
 // Result holds the complete result of a reconciliation run.
 type Result struct {
 	// StartTime is when reconciliation started.
-	StartTime time.Time
+	StartTime time.Time `json:"start_time"`
 
 	// EndTime is when reconciliation completed.
-	EndTime time.Time
+	EndTime time.Time `json:"end_time"`
 
 	// WorkloadsScanned is the number of Docker workloads examined.
-	WorkloadsScanned int
+	WorkloadsScanned int `json:"workloads_scanned"`
 
 	// HostnamesDiscovered is the number of unique valid hostnames found in labels.
-	HostnamesDiscovered int
+	HostnamesDiscovered int `json:"hostnames_discovered"`
 
 	// HostnamesInvalid is the number of hostnames that failed validation.
-	HostnamesInvalid int
+	HostnamesInvalid int `json:"hostnames_invalid"`
 
 	// HostnamesDuplicate is the number of hostnames that appeared in multiple workloads.
 	// Only the first occurrence is processed; duplicates are logged and skipped.
-	HostnamesDuplicate int
+	HostnamesDuplicate int `json:"hostnames_duplicate"`
+
+	// HostnamesDeferred is the number of hostnames left for the next
+	// reconciliation by Config.MaxHostnamesPerReconcile.
+	HostnamesDeferred int `json:"hostnames_deferred,omitempty"`
 
 	// Actions contains all reconciliation actions taken (or planned in dry-run).
-	Actions []Action
+	Actions []Action `json:"actions"`
 
 	// DryRun indicates if this was a dry-run (no changes applied).
-	DryRun bool
+	DryRun bool `json:"dry_run"`
 }
 
 // NewResult creates a new Result with the start time set to now.
@@ -219,6 +259,9 @@ func (r *Result) Summary() string {
 	fmt.Fprintf(&sb, "Reconciliation complete (%s) in %s\n", mode, r.Duration().Round(time.Millisecond))
 	fmt.Fprintf(&sb, "  Workloads scanned: %d\n", r.WorkloadsScanned)
 	fmt.Fprintf(&sb, "  Hostnames discovered: %d\n", r.HostnamesDiscovered)
+	if r.HostnamesDeferred > 0 {
+		fmt.Fprintf(&sb, "  Hostnames deferred: %d\n", r.HostnamesDeferred)
+	}
 	fmt.Fprintf(&sb, "  Records created: %d\n", r.CreatedCount())
 	fmt.Fprintf(&sb, "  Records updated: %d\n", r.UpdatedCount())
 	fmt.Fprintf(&sb, "  Records deleted: %d\n", r.DeletedCount())
@@ -233,3 +276,28 @@ func (r *Result) Summary() string {
 
 	return sb.String()
 }
+
+// Diff returns one line per record change (or planned change in dry-run),
+// in the order the actions were taken. Lines start with "+" for creates,
+// "-" for deletes, and "~" for updates, for example
+// "~  changed.example.com (A 10.0.0.99 → 10.0.0.1) [internal-dns]".
+// Skipped and failed actions are not included. Returns "" if nothing changed.
+func (r *Result) Diff() string {
+	var sb strings.Builder
+
+	for _, a := range r.Actions {
+		if a.Status != StatusSuccess {
+			continue
+		}
+		switch a.Type {
+		case ActionCreate:
+			fmt.Fprintf(&sb, "+  %s (%s → %s) [%s]\n", a.Hostname, a.RecordType, a.Target, a.Provider)
+		case ActionDelete:
+			fmt.Fprintf(&sb, "-  %s (%s) [%s]\n", a.Hostname, a.RecordType, a.Provider)
+		case ActionUpdate:
+			fmt.Fprintf(&sb, "~  %s (%s %s → %s) [%s]\n", a.Hostname, a.RecordType, a.OldTarget, a.Target, a.Provider)
+		}
+	}
+
+	return sb.String()
+}
@@ -287,6 +287,26 @@ func TestResult_Summary_WithErrors(t *testing.T) {
 	}
 }
 
+func TestResult_Diff(t *testing.T) {
+	result := NewResult(false)
+	result.AddAction(Action{Type: ActionCreate, Status: StatusSuccess, Hostname: "app.example.com", RecordType: "A", Target: "10.0.0.1", Provider: "test-dns"})
+	result.AddAction(Action{Type: ActionDelete, Status: StatusSuccess, Hostname: "old.example.com", RecordType: "A", Target: "10.0.0.2", Provider: "test-dns"})
+	result.AddAction(Action{Type: ActionUpdate, Status: StatusSuccess, Hostname: "changed.example.com", RecordType: "A", Target: "10.0.0.1", OldTarget: "10.0.0.99", Provider: "test-dns"})
+	result.AddAction(Action{Type: ActionSkip, Status: StatusSkipped, Hostname: "skip.example.com", Provider: "test-dns"})
+	result.AddAction(Action{Type: ActionCreate, Status: StatusFailed, Hostname: "fail.example.com", Provider: "test-dns"})
+
+	want := "+  app.example.com (A → 10.0.0.1) [test-dns]\n" +
+		"-  old.example.com (A) [test-dns]\n" +
+		"~  changed.example.com (A 10.0.0.99 → 10.0.0.1) [test-dns]\n"
+	if got := result.Diff(); got != want {
+		t.Errorf("Diff() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := NewResult(false).Diff(); got != "" {
+		t.Errorf("Diff() with no changes = %q, want empty", got)
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
 }
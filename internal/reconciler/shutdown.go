@@ -0,0 +1,70 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/metrics"
+)
+
+// ErrShuttingDown is returned by reconciliation methods called after Shutdown.
+var ErrShuttingDown = errors.New("reconciler is shutting down")
+
+// begin registers a reconciliation as in flight.
+// Returns ErrShuttingDown once Shutdown has been called.
+func (r *Reconciler) begin() error {
+	r.inflightMu.Lock()
+	defer r.inflightMu.Unlock()
+
+	if r.shuttingDown {
+		return ErrShuttingDown
+	}
+	r.inflight++
+	metrics.ReconcilerInflight.Inc()
+	return nil
+}
+
+// end marks an in-flight reconciliation as finished.
+func (r *Reconciler) end() {
+	r.inflightMu.Lock()
+	defer r.inflightMu.Unlock()
+
+	r.inflight--
+	metrics.ReconcilerInflight.Dec()
+	if r.inflight == 0 && r.drained != nil {
+		close(r.drained)
+		r.drained = nil
+	}
+}
+
+// Shutdown stops accepting new reconciliations and waits for running ones
+// to finish, so DNS is not left partially updated. Reconciliations started
+// afterwards return ErrShuttingDown. If ctx ends first, Shutdown returns its
+// error and the remaining reconciliations keep running.
+func (r *Reconciler) Shutdown(ctx context.Context) error {
+	r.inflightMu.Lock()
+	r.shuttingDown = true
+	if r.inflight == 0 {
+		r.inflightMu.Unlock()
+		return nil
+	}
+	if r.drained == nil {
+		r.drained = make(chan struct{})
+	}
+	drained := r.drained
+	inflight := r.inflight
+	r.inflightMu.Unlock()
+
+	r.logger.Info("waiting for in-flight reconciliations",
+		slog.Int("inflight", inflight),
+	)
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for in-flight reconciliations: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,94 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/docker"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+// blockingWorkloadLister blocks ListWorkloads until released.
+type blockingWorkloadLister struct {
+	*testMockWorkloadLister
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingWorkloadLister) ListWorkloads(ctx context.Context) ([]docker.Workload, error) {
+	close(b.started)
+	<-b.release
+	return b.testMockWorkloadLister.ListWorkloads(ctx)
+}
+
+func newBlockingReconciler(t *testing.T) (*Reconciler, *blockingWorkloadLister) {
+	t.Helper()
+	lister := &blockingWorkloadLister{
+		testMockWorkloadLister: newTestMockWorkloadLister(docker.ModeStandalone),
+		started:                make(chan struct{}),
+		release:                make(chan struct{}),
+	}
+	logger := quietLogger()
+	rec := New(lister, source.NewRegistry(logger), provider.NewRegistry(logger), WithLogger(logger))
+	return rec, lister
+}
+
+func TestReconciler_Shutdown_WaitsForInflight(t *testing.T) {
+	rec, lister := newBlockingReconciler(t)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := rec.Reconcile(context.Background())
+		done <- err
+	}()
+	<-lister.started
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- rec.Shutdown(context.Background()) }()
+
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown() returned %v before the reconciliation finished", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(lister.release)
+	if err := <-done; err != nil {
+		t.Errorf("in-flight Reconcile() error = %v", err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+
+	if _, err := rec.Reconcile(context.Background()); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("Reconcile() after Shutdown() error = %v, want ErrShuttingDown", err)
+	}
+	if _, err := rec.ReconcileHostname(context.Background(), "app.example.com"); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("ReconcileHostname() after Shutdown() error = %v, want ErrShuttingDown", err)
+	}
+}
+
+func TestReconciler_Shutdown_Timeout(t *testing.T) {
+	rec, lister := newBlockingReconciler(t)
+	defer close(lister.release)
+
+	go func() { _, _ = rec.Reconcile(context.Background()) }()
+	<-lister.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := rec.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestReconciler_Shutdown_Idle(t *testing.T) {
+	rec := New(newTestMockWorkloadLister(docker.ModeStandalone), nil, nil, WithLogger(quietLogger()))
+
+	if err := rec.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() with nothing in flight error = %v", err)
+	}
+}
@@ -0,0 +1,301 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/policy"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+// ensureRecordsForProvider ensures the records for a hostname exist in a single provider.
+// Hostnames with one target are handled by ensureRecordForProvider. Hostnames with
+// several targets (round-robin A/AAAA records) are synced as a set by ensureRecordSet.
+// Record types the provider does not support are skipped with a warning.
+// Hostnames changed by the returned actions are invalidated in the cache.
+func (r *Reconciler) ensureRecordsForProvider(ctx context.Context, hostname *source.Hostname, inst *provider.ProviderInstance, cache *recordCache) []Action {
+	recordType := inst.RecordType
+	if hints := hostname.RecordHints; hints != nil && hints.Type != "" {
+		recordType = provider.RecordType(hints.Type)
+	}
+	if !inst.Provider.Capabilities().SupportsRecordType(recordType) {
+		r.logger.Warn("provider does not support record type, skipping",
+			slog.String("hostname", hostname.Name),
+			slog.String("provider", inst.Name()),
+			slog.String("type", string(recordType)),
+		)
+		action := Action{
+			Type:       ActionSkip,
+			Provider:   inst.Name(),
+			Zone:       inst.Zone(),
+			Hostname:   hostname.Name,
+			RecordType: string(recordType),
+			Status:     StatusSkipped,
+			Error:      fmt.Sprintf("record type %s not supported by provider", recordType),
+		}
+		action.unsupported = true
+		return []Action{action}
+	}
+
+	if r.denied(ctx, hostname.Name, inst, policy.ActionCreate) {
+		return []Action{deniedAction(hostname.Name, inst, string(recordType))}
+	}
+
+	if r.failures != nil && !r.config.DryRun {
+		if until, ok := r.failures.backedOff(inst.Name(), hostname.Name); ok {
+			r.logger.Debug("skipping hostname in reconcile backoff",
+				slog.String("hostname", hostname.Name),
+				slog.String("provider", inst.Name()),
+				slog.Time("until", until),
+			)
+			return []Action{backedOffAction(Action{
+				Provider:   inst.Name(),
+				Zone:       inst.Zone(),
+				Hostname:   hostname.Name,
+				RecordType: string(recordType),
+			}, until)}
+		}
+	}
+
+	var actions []Action
+	targets := r.effectiveTargets(hostname, inst)
+	if len(targets) <= 1 {
+		actions = []Action{r.ensureRecordWithRetry(ctx, hostname, inst, cache)}
+	} else {
+		actions = r.ensureRecordSet(ctx, hostname, inst, targets, cache)
+	}
+	cache.invalidateActions(actions)
+	if r.failures != nil && !r.config.DryRun {
+		r.failures.record(actions)
+	}
+	return actions
+}
+
+// effectiveTargets returns the targets to use for a hostname in a provider.
+// RecordHints override the provider defaults. Multiple targets are only returned
+// for A and AAAA records in providers that support them; otherwise only the first
+// target is used and a warning is logged.
+func (r *Reconciler) effectiveTargets(hostname *source.Hostname, inst *provider.ProviderInstance) []string {
+	recordType := inst.RecordType
+	targets := inst.DefaultTargets()
+
+	if hints := hostname.RecordHints; hints != nil {
+		if hints.Type != "" {
+			recordType = provider.RecordType(hints.Type)
+		}
+		if len(hints.Targets) > 0 {
+			targets = hints.Targets
+		} else if hints.Target != "" {
+			targets = []string{hints.Target}
+		}
+	}
+
+	if len(targets) <= 1 {
+		return targets
+	}
+
+	if recordType != provider.RecordTypeA && recordType != provider.RecordTypeAAAA {
+		r.logger.Warn("multiple targets are only supported for A and AAAA records, using first target",
+			slog.String("hostname", hostname.Name),
+			slog.String("provider", inst.Name()),
+			slog.String("type", string(recordType)),
+			slog.String("target", targets[0]),
+		)
+		return targets[:1]
+	}
+
+	if !inst.Provider.Capabilities().SupportsMultipleTargets {
+		r.logger.Warn("provider does not support multiple targets per hostname, using first target",
+			slog.String("hostname", hostname.Name),
+			slog.String("provider", inst.Name()),
+			slog.String("target", targets[0]),
+			slog.Int("ignored", len(targets)-1),
+		)
+		return targets[:1]
+	}
+
+	return targets
+}
+
+// ensureRecordSet syncs the records for a hostname with several targets.
+// Missing targets are created before extra records are deleted so the hostname
+// keeps resolving during the change. If any create fails, extras are left in
+// place and retried on the next reconciliation. With ownership tracking, a set
+// of existing records is only changed if it is owned or ADOPT_EXISTING is set.
+func (r *Reconciler) ensureRecordSet(ctx context.Context, hostname *source.Hostname, inst *provider.ProviderInstance, targets []string, cache *recordCache) []Action {
+	recordType := inst.RecordType
+	ttl := inst.TTL
+	var providerHints map[string]string
+	weighted := r.weightedData(hostname, inst)
+	if hints := hostname.RecordHints; hints != nil {
+		providerHints = hints.ProviderHints
+		if hints.Type != "" {
+			recordType = provider.RecordType(hints.Type)
+		}
+		if hints.TTL > 0 {
+			ttl = hints.TTL
+		}
+	}
+
+	newAction := func(actionType ActionType, target string) Action {
+		return Action{
+			Type:        actionType,
+			Provider:    inst.Name(),
+			Zone:        inst.Zone(),
+			Hostname:    hostname.Name,
+			RecordType:  string(recordType),
+			Target:      target,
+			Annotations: hostname.Annotations,
+		}
+	}
+
+	if r.config.DryRun {
+		actions := make([]Action, 0, len(targets))
+		for _, target := range targets {
+			action := newAction(ActionCreate, target)
+			action.Status = StatusSuccess
+			actions = append(actions, action)
+		}
+		r.logger.Info("would create record set (dry-run)",
+			slog.String("hostname", hostname.Name),
+			slog.String("provider", inst.Name()),
+			slog.String("type", string(recordType)),
+			slog.Any("targets", targets),
+		)
+		return actions
+	}
+
+	// Split existing records into same-type records and type conflicts
+	var sameType []provider.Record
+	var conflictTypes []string
+	for _, existing := range r.existingRecordsFor(ctx, hostname.Name, inst, cache) {
+		if existing.Type == recordType {
+			sameType = append(sameType, existing)
+		} else if TypesConflict(existing.Type, recordType) {
+			conflictTypes = append(conflictTypes, string(existing.Type))
+		}
+	}
+
+	if len(conflictTypes) > 0 {
+		action := newAction(ActionSkip, targets[0])
+		action.Status = StatusSkipped
+		action.Error = fmt.Sprintf("type conflict: existing %v record(s) conflict with %s",
+			conflictTypes, recordType)
+		r.logger.Warn("skipping due to record type conflict",
+			slog.String("hostname", hostname.Name),
+			slog.String("provider", inst.Name()),
+			slog.String("desired_type", string(recordType)),
+			slog.Any("existing_types", conflictTypes),
+		)
+		return []Action{action}
+	}
+
+	// Compute the difference between the desired and existing target sets
+	desired := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		desired[target] = true
+	}
+	existing := make(map[string]bool, len(sameType))
+	var extras []provider.Record
+	for _, rec := range sameType {
+		if desired[rec.Target] && !existing[rec.Target] {
+			existing[rec.Target] = true
+			continue
+		}
+		extras = append(extras, rec)
+	}
+	var missing []string
+	for _, target := range targets {
+		if !existing[target] {
+			missing = append(missing, target)
+		}
+	}
+
+	owned := cache != nil && cache.hasOwnershipRecord(inst.Name(), hostname.Name)
+	if len(sameType) > 0 && r.config.OwnershipTracking && !owned && !r.config.AdoptExisting {
+		action := newAction(ActionSkip, targets[0])
+		action.Status = StatusSkipped
+		action.Error = errRecordAlreadyExists
+		r.logger.Info("existing records found, skipping adoption (set ADOPT_EXISTING=true to manage)",
+			slog.String("hostname", hostname.Name),
+			slog.String("provider", inst.Name()),
+		)
+		return []Action{action}
+	}
+
+	if len(missing) == 0 && len(extras) == 0 {
+		action := newAction(ActionSkip, targets[0])
+		action.Status = StatusSkipped
+		action.Error = errRecordAlreadyExists
+		r.ensureOwnershipRecord(ctx, hostname, inst, cache)
+		return []Action{action}
+	}
+
+	var actions []Action
+	createFailed := false
+
+	for _, target := range missing {
+		action := newAction(ActionCreate, target)
+		attempts, err := r.retry(ctx, action, inst, func() error {
+			return inst.CreateRecordWithValues(ctx, hostname.Name, recordType, target, ttl, nil, providerHints, weighted)
+		})
+		action.Attempts = attempts
+		if err != nil && !provider.IsConflict(err) {
+			createFailed = true
+			action.fail(err)
+			r.logFailure("failed to create record", err,
+				slog.String("hostname", hostname.Name),
+				slog.String("provider", inst.Name()),
+				slog.String("target", target),
+			)
+		} else {
+			action.Status = StatusSuccess
+			r.logger.Info("created record",
+				slog.String("hostname", hostname.Name),
+				slog.String("provider", inst.Name()),
+				slog.String("type", string(recordType)),
+				slog.String("target", target),
+			)
+		}
+		actions = append(actions, action)
+	}
+
+	if createFailed && len(extras) > 0 {
+		r.logger.Warn("keeping extra records until all targets are created",
+			slog.String("hostname", hostname.Name),
+			slog.String("provider", inst.Name()),
+			slog.Int("extras", len(extras)),
+		)
+		return actions
+	}
+
+	for _, extra := range extras {
+		action := newAction(ActionDelete, extra.Target)
+		attempts, err := r.retry(ctx, action, inst, func() error {
+			return inst.DeleteRecordByTarget(ctx, hostname.Name, extra.Type, extra.Target)
+		})
+		action.Attempts = attempts
+		if err != nil {
+			action.fail(err)
+			r.logFailure("failed to delete extra record", err,
+				slog.String("hostname", hostname.Name),
+				slog.String("provider", inst.Name()),
+				slog.String("target", extra.Target),
+			)
+		} else {
+			action.Status = StatusSuccess
+			r.logger.Info("deleted extra record",
+				slog.String("hostname", hostname.Name),
+				slog.String("provider", inst.Name()),
+				slog.String("type", string(extra.Type)),
+				slog.String("target", extra.Target),
+			)
+		}
+		actions = append(actions, action)
+	}
+
+	r.ensureOwnershipRecord(ctx, hostname, inst, cache)
+	return actions
+}
@@ -0,0 +1,346 @@
+package reconciler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/audit"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+// newMultiTargetReconciler creates a reconciler with one mock provider instance
+// configured with the given targets.
+func newMultiTargetReconciler(t *testing.T, mock *testMockProvider, targets ...string) *Reconciler {
+	t.Helper()
+
+	logger := quietLogger()
+	providers := provider.NewRegistry(logger)
+	providers.RegisterFactory("mock", func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		return mock, nil
+	})
+	if err := providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       mock.name,
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     targets[0],
+		Targets:    targets,
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	}); err != nil {
+		t.Fatalf("CreateInstance() error = %v", err)
+	}
+
+	return &Reconciler{
+		providers:      providers,
+		config:         DefaultConfig(),
+		logger:         logger,
+		knownHostnames: make(map[string]struct{}),
+	}
+}
+
+func recordTargets(records []provider.Record) []string {
+	targets := make([]string, 0, len(records))
+	for _, r := range records {
+		targets = append(targets, r.Target)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+func TestEnsureRecord_MultipleTargets_CreatesAll(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+	r := newMultiTargetReconciler(t, mock, "10.0.0.1", "10.0.0.2", "10.0.0.3")
+
+	hostname := &source.Hostname{Name: "app.example.com", Source: "test"}
+	cache := newRecordCache(context.Background(), r.providers, r.logger)
+	actions := r.ensureRecord(context.Background(), hostname, cache)
+
+	if len(actions) != 3 {
+		t.Fatalf("expected 3 actions, got %d: %v", len(actions), actions)
+	}
+	for _, a := range actions {
+		if a.Type != ActionCreate || a.Status != StatusSuccess {
+			t.Errorf("expected successful create, got %v", a)
+		}
+	}
+
+	got := recordTargets(mock.GetCreatedDNSRecords())
+	want := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	if len(got) != len(want) {
+		t.Fatalf("created targets = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("created targets = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestEnsureRecord_MultipleTargets_SyncsSet(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+	mock.AddRecord(provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1", TTL: 300})
+	mock.AddRecord(provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.9", TTL: 300})
+	mock.AddRecord(provider.OwnershipRecord("app.example.com", 300, "test"))
+
+	r := newMultiTargetReconciler(t, mock, "10.0.0.1", "10.0.0.2")
+
+	hostname := &source.Hostname{Name: "app.example.com", Source: "test"}
+	cache := newRecordCache(context.Background(), r.providers, r.logger)
+	actions := r.ensureRecord(context.Background(), hostname, cache)
+
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 actions, got %d: %v", len(actions), actions)
+	}
+	if actions[0].Type != ActionCreate || actions[0].Target != "10.0.0.2" {
+		t.Errorf("expected create 10.0.0.2 first, got %v", actions[0])
+	}
+	if actions[1].Type != ActionDelete || actions[1].Target != "10.0.0.9" {
+		t.Errorf("expected delete 10.0.0.9 second, got %v", actions[1])
+	}
+
+	var remaining []provider.Record
+	records, _ := mock.List(context.Background())
+	for _, rec := range records {
+		if rec.Type == provider.RecordTypeA {
+			remaining = append(remaining, rec)
+		}
+	}
+	got := recordTargets(remaining)
+	if len(got) != 2 || got[0] != "10.0.0.1" || got[1] != "10.0.0.2" {
+		t.Errorf("remaining targets = %v, want [10.0.0.1 10.0.0.2]", got)
+	}
+}
+
+func TestEnsureRecord_MultipleTargets_InSync(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+	mock.AddRecord(provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.2", TTL: 300})
+	mock.AddRecord(provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1", TTL: 300})
+
+	r := newMultiTargetReconciler(t, mock, "10.0.0.1", "10.0.0.2")
+
+	hostname := &source.Hostname{Name: "app.example.com", Source: "test"}
+	cache := newRecordCache(context.Background(), r.providers, r.logger)
+	actions := r.ensureRecord(context.Background(), hostname, cache)
+
+	if len(actions) != 1 || actions[0].Type != ActionSkip {
+		t.Fatalf("expected single skip action, got %v", actions)
+	}
+	if len(mock.GetCreatedDNSRecords()) != 0 || len(mock.GetDeleted()) != 0 {
+		t.Error("expected no changes when record set matches")
+	}
+}
+
+func TestEnsureRecord_MultipleTargets_KeepsExtrasOnCreateFailure(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+	mock.AddRecord(provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.9", TTL: 300})
+	mock.AddRecord(provider.OwnershipRecord("app.example.com", 300, "test"))
+	mock.createFn = func(_ context.Context, rec provider.Record) error {
+		if rec.Target == "10.0.0.2" {
+			return errors.New("api error")
+		}
+		return nil
+	}
+
+	r := newMultiTargetReconciler(t, mock, "10.0.0.1", "10.0.0.2")
+
+	hostname := &source.Hostname{Name: "app.example.com", Source: "test"}
+	cache := newRecordCache(context.Background(), r.providers, r.logger)
+	actions := r.ensureRecord(context.Background(), hostname, cache)
+
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 create actions, got %d: %v", len(actions), actions)
+	}
+	if len(mock.GetDeleted()) != 0 {
+		t.Error("expected extra record to be kept after a failed create")
+	}
+}
+
+func TestEnsureRecord_MultipleTargets_UnownedSet(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		adopt bool
+	}{
+		{name: "left alone", adopt: false},
+		{name: "adopted", adopt: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newTestMockProvider("test-dns")
+			mock.AddRecord(provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1", TTL: 300})
+			mock.AddRecord(provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.9", TTL: 300})
+
+			r := newMultiTargetReconciler(t, mock, "10.0.0.1", "10.0.0.2")
+			r.config.AdoptExisting = tt.adopt
+
+			hostname := &source.Hostname{Name: "app.example.com", Source: "test"}
+			cache := newRecordCache(context.Background(), r.providers, r.logger)
+			actions := r.ensureRecord(context.Background(), hostname, cache)
+
+			var ownership int
+			for _, c := range mock.GetCreated() {
+				if c.Type == provider.RecordTypeTXT {
+					ownership++
+				}
+			}
+
+			if !tt.adopt {
+				if len(actions) != 1 || actions[0].Type != ActionSkip {
+					t.Fatalf("expected single skip action, got %v", actions)
+				}
+				if len(mock.GetCreated()) != 0 || len(mock.GetDeleted()) != 0 {
+					t.Errorf("expected unowned records to be left alone, created %v, deleted %v",
+						mock.GetCreated(), mock.GetDeleted())
+				}
+				return
+			}
+
+			if len(mock.GetDeleted()) != 1 || mock.GetDeleted()[0].Target != "10.0.0.9" {
+				t.Errorf("expected extra 10.0.0.9 to be deleted, got %v", mock.GetDeleted())
+			}
+			if ownership != 1 {
+				t.Errorf("expected ownership record to be created, got %d", ownership)
+			}
+		})
+	}
+}
+
+func TestEnsureRecord_MultipleTargets_RetriesFailedCreate(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+	var calls int
+	mock.createFn = func(_ context.Context, rec provider.Record) error {
+		if rec.Target == "10.0.0.2" {
+			calls++
+			if calls < 3 {
+				return provider.ErrNetwork
+			}
+		}
+		return nil
+	}
+
+	r := newMultiTargetReconciler(t, mock, "10.0.0.1", "10.0.0.2")
+	WithRetry(3, time.Millisecond)(r)
+	var buf bytes.Buffer
+	r.audit = audit.New(&buf)
+
+	hostname := &source.Hostname{Name: "app.example.com", Source: "test"}
+	cache := newRecordCache(context.Background(), r.providers, r.logger)
+	actions := r.ensureRecord(context.Background(), hostname, cache)
+
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 actions, got %v", actions)
+	}
+	for _, a := range actions {
+		if a.Status != StatusSuccess {
+			t.Errorf("expected successful create, got %v", a)
+		}
+		if a.Target == "10.0.0.2" && a.Attempts != 3 {
+			t.Errorf("Attempts = %d, want 3", a.Attempts)
+		}
+	}
+
+	var retried int
+	for _, entry := range readAuditEntries(t, &buf) {
+		if entry.Status == "failed" && entry.NewValue == "10.0.0.2" {
+			retried++
+		}
+	}
+	if retried != 2 {
+		t.Errorf("audited %d failed attempts, want 2", retried)
+	}
+}
+
+func TestEnsureRecord_MultipleTargets_UnsupportedProviderUsesFirst(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+	mock.singleTarget = true
+	r := newMultiTargetReconciler(t, mock, "10.0.0.1", "10.0.0.2")
+
+	hostname := &source.Hostname{Name: "app.example.com", Source: "test"}
+	actions := r.ensureRecord(context.Background(), hostname, nil)
+
+	if len(actions) != 1 {
+		t.Fatalf("expected 1 action, got %d", len(actions))
+	}
+	created := mock.GetCreatedDNSRecords()
+	if len(created) != 1 || created[0].Target != "10.0.0.1" {
+		t.Errorf("expected only first target created, got %v", created)
+	}
+}
+
+func TestEnsureRecord_MultipleTargets_FromHints(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+	r := newMultiTargetReconciler(t, mock, "10.0.0.1")
+
+	hostname := &source.Hostname{
+		Name:   "app.example.com",
+		Source: "test",
+		RecordHints: &source.RecordHints{
+			Target:  "192.0.2.1",
+			Targets: []string{"192.0.2.1", "192.0.2.2"},
+		},
+	}
+	actions := r.ensureRecord(context.Background(), hostname, nil)
+
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 actions, got %d", len(actions))
+	}
+	got := recordTargets(mock.GetCreatedDNSRecords())
+	if len(got) != 2 || got[0] != "192.0.2.1" || got[1] != "192.0.2.2" {
+		t.Errorf("created targets = %v, want [192.0.2.1 192.0.2.2]", got)
+	}
+}
+
+func TestEnsureRecord_UnsupportedRecordTypeSkipped(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+	mock.recordTypes = []provider.RecordType{provider.RecordTypeCNAME, provider.RecordTypeTXT}
+	r := newMultiTargetReconciler(t, mock, "10.0.0.1")
+
+	hostname := &source.Hostname{Name: "app.example.com", Source: "test"}
+	actions := r.ensureRecord(context.Background(), hostname, nil)
+
+	if len(actions) != 1 {
+		t.Fatalf("expected 1 action, got %d", len(actions))
+	}
+	if actions[0].Type != ActionSkip || actions[0].Status != StatusSkipped {
+		t.Errorf("expected skipped action, got %s/%s", actions[0].Type, actions[0].Status)
+	}
+	if len(mock.GetCreated()) != 0 {
+		t.Errorf("expected no records created, got %v", mock.GetCreated())
+	}
+}
+
+func TestDeleteOrphan_UnsupportedRecordTypeSkipped(t *testing.T) {
+	mock := newTestMockProvider("test-dns")
+	mock.recordTypes = []provider.RecordType{provider.RecordTypeA, provider.RecordTypeTXT}
+	mock.AddRecord(provider.Record{Hostname: "old.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"})
+	mock.AddRecord(provider.Record{Hostname: "old.example.com", Type: provider.RecordTypeCNAME, Target: "other.example.com"})
+	r := newMultiTargetReconciler(t, mock, "10.0.0.1")
+	r.config.OwnershipTracking = false
+
+	inst, _ := r.providers.Get("test-dns")
+	actions := r.deleteOrphanForProvider(context.Background(), "old.example.com", inst, nil)
+
+	var deleted, skipped int
+	for _, a := range actions {
+		switch a.Type {
+		case ActionDelete:
+			deleted++
+		case ActionSkip:
+			skipped++
+			if a.RecordType != string(provider.RecordTypeCNAME) {
+				t.Errorf("skipped record type = %s, want CNAME", a.RecordType)
+			}
+		}
+	}
+	if deleted != 1 || skipped != 1 {
+		t.Errorf("expected 1 delete and 1 skip, got %d and %d", deleted, skipped)
+	}
+	for _, rec := range mock.GetDeleted() {
+		if rec.Type == provider.RecordTypeCNAME {
+			t.Error("expected unsupported CNAME record not to be deleted")
+		}
+	}
+}
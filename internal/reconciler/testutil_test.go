@@ -60,14 +60,25 @@ type testMockProvider struct {
 	name     string
 	typeName string
 
-	mu       sync.Mutex
-	records  []provider.Record
-	created  []provider.Record
-	deleted  []provider.Record
-	pingErr  error
-	listErr  error
-	createFn func(ctx context.Context, r provider.Record) error
-	deleteFn func(ctx context.Context, r provider.Record) error
+	// singleTarget disables SupportsMultipleTargets in Capabilities.
+	singleTarget bool
+	// recordTypes overrides SupportedRecordTypes in Capabilities when set.
+	recordTypes []provider.RecordType
+	// weighted enables SupportsWeightedRecords in Capabilities.
+	weighted bool
+	// zone is returned by Zone.
+	zone string
+
+	mu      sync.Mutex
+	records []provider.Record
+	created []provider.Record
+	deleted []provider.Record
+	pingErr error
+	listErr error
+	// listCalls counts List() calls
+	listCalls int
+	createFn  func(ctx context.Context, r provider.Record) error
+	deleteFn  func(ctx context.Context, r provider.Record) error
 }
 
 func newTestMockProvider(name string) *testMockProvider {
@@ -82,18 +93,25 @@ func newTestMockProvider(name string) *testMockProvider {
 
 func (m *testMockProvider) Name() string { return m.name }
 func (m *testMockProvider) Type() string { return m.typeName }
+func (m *testMockProvider) Zone() string { return m.zone }
 
 func (m *testMockProvider) Capabilities() provider.Capabilities {
-	return provider.Capabilities{
-		SupportsOwnershipTXT: true,
-		SupportsNativeUpdate: true,
-		SupportedRecordTypes: []provider.RecordType{
+	recordTypes := m.recordTypes
+	if recordTypes == nil {
+		recordTypes = []provider.RecordType{
 			provider.RecordTypeA,
 			provider.RecordTypeAAAA,
 			provider.RecordTypeCNAME,
 			provider.RecordTypeSRV,
 			provider.RecordTypeTXT,
-		},
+		}
+	}
+	return provider.Capabilities{
+		SupportsOwnershipTXT:    true,
+		SupportsNativeUpdate:    true,
+		SupportsMultipleTargets: !m.singleTarget,
+		SupportsWeightedRecords: m.weighted,
+		SupportedRecordTypes:    recordTypes,
 	}
 }
 
@@ -104,6 +122,7 @@ func (m *testMockProvider) Ping(_ context.Context) error {
 func (m *testMockProvider) List(_ context.Context) ([]provider.Record, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.listCalls++
 	if m.listErr != nil {
 		return nil, m.listErr
 	}
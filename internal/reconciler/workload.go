@@ -0,0 +1,189 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+// ReconcileWorkload reconciles the hostnames of a single named workload
+// (a Swarm service or standalone container) instead of every workload.
+//
+// The workload's labels are re-read and its hostnames ensured in all matching
+// providers. Hostnames the workload defined in an earlier reconciliation but
+// no longer does are removed as orphans (when CleanupOrphans is enabled). If
+// the workload no longer exists, all of its hostnames are treated as removed.
+//
+// Hostnames already claimed by another workload are skipped as duplicates,
+// and removed hostnames are kept while another workload or a file source
+// still defines them.
+func (r *Reconciler) ReconcileWorkload(ctx context.Context, workloadName string) (*Result, error) {
+	if !r.config.Enabled {
+		r.logger.Debug("reconciliation disabled, skipping workload",
+			slog.String("workload", workloadName),
+		)
+		result := NewResult(r.config.DryRun)
+		result.Complete()
+		return result, nil
+	}
+
+	if err := r.begin(); err != nil {
+		return nil, err
+	}
+	defer r.end()
+
+	r.logger.Debug("reconciling single workload",
+		slog.String("workload", workloadName),
+		slog.Bool("dry_run", r.config.DryRun),
+	)
+
+	result := NewResult(r.config.DryRun)
+
+	workloads, err := r.docker.ListWorkloads(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing workloads: %w", err)
+	}
+
+	var hostnames source.Hostnames
+	for _, workload := range workloads {
+		if workload.Name == workloadName {
+			result.WorkloadsScanned = 1
+			hostnames = r.extractWorkloadHostnames(ctx, workload, result)
+			break
+		}
+	}
+	if result.WorkloadsScanned == 0 {
+		r.logger.Debug("workload not found, removing its hostnames",
+			slog.String("workload", workloadName),
+		)
+	}
+
+	// Hostnames claimed by other workloads, for duplicate detection
+	r.mu.RLock()
+	claimedBy := make(map[string]string)
+	for name, owned := range r.workloadHostnames {
+		if name == workloadName {
+			continue
+		}
+		for _, h := range owned {
+			claimedBy[h] = name
+		}
+	}
+	previous := slices.Clone(r.workloadHostnames[workloadName])
+	r.mu.RUnlock()
+
+	current := make(map[string]*source.Hostname, len(hostnames))
+	var owned []string
+	for i := range hostnames {
+		hostname := &hostnames[i]
+		normalizedName := hostname.NormalizedName()
+		if _, exists := current[normalizedName]; exists {
+			continue
+		}
+		if otherWorkload, exists := claimedBy[normalizedName]; exists {
+			r.logger.Warn("duplicate hostname found in multiple workloads",
+				slog.String("hostname", hostname.Name),
+				slog.String("first_workload", otherWorkload),
+				slog.String("duplicate_workload", workloadName),
+			)
+			result.HostnamesDuplicate++
+			continue
+		}
+		current[normalizedName] = hostname
+		owned = append(owned, normalizedName)
+	}
+	result.HostnamesDiscovered = len(current)
+
+	removed := r.removedWorkloadHostnames(ctx, previous, current, claimedBy)
+
+	// Same record cache as a full reconciliation, so unchanged hostnames are skipped
+	var cache *recordCache
+	if !r.config.DryRun && (len(owned) > 0 || (r.config.CleanupOrphans && len(removed) > 0)) {
+		cache = r.loadRecordCache(ctx)
+	}
+
+	for _, normalizedName := range owned {
+		for _, action := range r.ensureRecord(ctx, current[normalizedName], cache) {
+			result.AddAction(action)
+		}
+	}
+
+	if r.config.CleanupOrphans {
+		for _, hostname := range removed {
+			r.logger.Info("detected orphan hostname",
+				slog.String("hostname", hostname),
+				slog.String("workload", workloadName),
+			)
+			for _, inst := range r.providers.MatchingProviders(hostname) {
+				deleteActions := r.deleteOrphanForProvider(ctx, hostname, inst, cache)
+				cache.invalidateActions(deleteActions)
+				for _, action := range deleteActions {
+					result.AddAction(action)
+				}
+			}
+		}
+	}
+
+	r.mu.Lock()
+	if r.workloadHostnames == nil {
+		r.workloadHostnames = make(map[string][]string)
+	}
+	if len(owned) > 0 {
+		r.workloadHostnames[workloadName] = owned
+	} else {
+		delete(r.workloadHostnames, workloadName)
+	}
+	for _, hostname := range owned {
+		r.knownHostnames[hostname] = struct{}{}
+	}
+	for _, hostname := range removed {
+		delete(r.knownHostnames, hostname)
+	}
+	r.mu.Unlock()
+
+	result.Complete()
+	r.auditResult(result)
+	r.changeResult(result)
+	r.notifyResult(ctx, result)
+
+	r.logger.Info("workload reconciliation complete",
+		slog.String("workload", workloadName),
+		slog.Int("hostnames", len(owned)),
+		slog.Int("created", result.CreatedCount()),
+		slog.Int("deleted", result.DeletedCount()),
+		slog.Int("failed", result.FailedCount()),
+	)
+
+	return result, nil
+}
+
+// removedWorkloadHostnames returns the hostnames a workload previously defined
+// that it no longer does and that no other workload or file source defines.
+func (r *Reconciler) removedWorkloadHostnames(ctx context.Context, previous []string, current map[string]*source.Hostname, claimedBy map[string]string) []string {
+	var removed []string
+	for _, hostname := range previous {
+		if _, exists := current[hostname]; exists {
+			continue
+		}
+		if _, exists := claimedBy[hostname]; exists {
+			continue
+		}
+		removed = append(removed, hostname)
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	// File sources don't belong to a workload; keep anything they still define
+	discovered := make(map[string]struct{})
+	for _, hostname := range r.sources.DiscoverAll(ctx) {
+		discovered[hostname.NormalizedName()] = struct{}{}
+	}
+	return slices.DeleteFunc(removed, func(hostname string) bool {
+		_, exists := discovered[hostname]
+		return exists
+	})
+}
@@ -0,0 +1,218 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/docker"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+	"gitlab.bluewillows.net/root/dnsweaver/sources/traefik"
+)
+
+// newWorkloadTestReconciler builds a reconciler with a Traefik source and a
+// single mock provider matching *.example.com.
+func newWorkloadTestReconciler(t *testing.T, dockerMock *testMockWorkloadLister) (*Reconciler, *testMockProvider) {
+	t.Helper()
+	logger := quietLogger()
+
+	sources := source.NewRegistry(logger)
+	sources.Register(traefik.New(traefik.WithLogger(logger)))
+
+	mockProvider := newTestMockProvider("test-dns")
+	providers := testProviderRegistry(logger, mockProvider)
+	if err := providers.CreateInstance(provider.ProviderInstanceConfig{
+		Name:       "test-dns",
+		TypeName:   "mock",
+		RecordType: provider.RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	}); err != nil {
+		t.Fatalf("CreateInstance failed: %v", err)
+	}
+
+	r := New(dockerMock, sources, providers,
+		WithConfig(DefaultConfig()),
+		WithLogger(logger),
+	)
+	return r, mockProvider
+}
+
+// clearHistory forgets recorded Create/Delete calls but keeps the records.
+func clearHistory(m *testMockProvider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.created = nil
+	m.deleted = nil
+}
+
+// deletedDNSHostnames returns the hostnames of deleted non-TXT records.
+func deletedDNSHostnames(m *testMockProvider) []string {
+	var names []string
+	for _, rec := range m.GetDeleted() {
+		if rec.Type != provider.RecordTypeTXT {
+			names = append(names, rec.Hostname)
+		}
+	}
+	return names
+}
+
+func TestReconcileWorkload_AddsAndRemovesHostnames(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	dockerMock.AddWorkload("web", map[string]string{
+		"traefik.http.routers.web.rule": "Host(`app.example.com`) || Host(`www.example.com`)",
+	})
+	dockerMock.AddWorkload("api", map[string]string{
+		"traefik.http.routers.api.rule": "Host(`api.example.com`)",
+	})
+
+	r, mockProvider := newWorkloadTestReconciler(t, dockerMock)
+	if _, err := r.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	clearHistory(mockProvider)
+
+	// Service update: www is dropped, blog is added
+	dockerMock.workloads[0].Labels = map[string]string{
+		"traefik.http.routers.web.rule": "Host(`app.example.com`) || Host(`blog.example.com`)",
+	}
+
+	result, err := r.ReconcileWorkload(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("ReconcileWorkload failed: %v", err)
+	}
+	if result.WorkloadsScanned != 1 {
+		t.Errorf("WorkloadsScanned = %d, want 1", result.WorkloadsScanned)
+	}
+	if result.HostnamesDiscovered != 2 {
+		t.Errorf("HostnamesDiscovered = %d, want 2", result.HostnamesDiscovered)
+	}
+
+	created := mockProvider.GetCreatedDNSRecords()
+	if len(created) != 1 || created[0].Hostname != "blog.example.com" {
+		t.Errorf("created = %v, want only blog.example.com", created)
+	}
+	if deleted := deletedDNSHostnames(mockProvider); len(deleted) != 1 || deleted[0] != "www.example.com" {
+		t.Errorf("deleted = %v, want only www.example.com", deleted)
+	}
+
+	known := r.KnownHostnames()
+	want := map[string]bool{"app.example.com": true, "blog.example.com": true, "api.example.com": true}
+	if len(known) != len(want) {
+		t.Fatalf("KnownHostnames = %v, want %d entries", known, len(want))
+	}
+	for _, h := range known {
+		if !want[h] {
+			t.Errorf("unexpected known hostname %q", h)
+		}
+	}
+}
+
+func TestReconcileWorkload_RemovedWorkload(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	dockerMock.AddWorkload("web", map[string]string{
+		"traefik.http.routers.web.rule": "Host(`app.example.com`)",
+	})
+	dockerMock.AddWorkload("api", map[string]string{
+		"traefik.http.routers.api.rule": "Host(`api.example.com`)",
+	})
+
+	r, mockProvider := newWorkloadTestReconciler(t, dockerMock)
+	if _, err := r.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	clearHistory(mockProvider)
+
+	dockerMock.workloads = dockerMock.workloads[1:]
+
+	result, err := r.ReconcileWorkload(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("ReconcileWorkload failed: %v", err)
+	}
+	if result.WorkloadsScanned != 0 {
+		t.Errorf("WorkloadsScanned = %d, want 0", result.WorkloadsScanned)
+	}
+	if deleted := deletedDNSHostnames(mockProvider); len(deleted) != 1 || deleted[0] != "app.example.com" {
+		t.Errorf("deleted = %v, want only app.example.com", deleted)
+	}
+	if len(mockProvider.GetCreatedDNSRecords()) != 0 {
+		t.Error("expected no records to be created")
+	}
+
+	r.mu.RLock()
+	_, tracked := r.workloadHostnames["web"]
+	r.mu.RUnlock()
+	if tracked {
+		t.Error("expected removed workload to be untracked")
+	}
+}
+
+func TestReconcileWorkload_DuplicateFromOtherWorkload(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	dockerMock.AddWorkload("web", map[string]string{
+		"traefik.http.routers.web.rule": "Host(`app.example.com`)",
+	})
+	dockerMock.AddWorkload("canary", map[string]string{
+		"traefik.http.routers.canary.rule": "Host(`canary.example.com`)",
+	})
+
+	r, mockProvider := newWorkloadTestReconciler(t, dockerMock)
+	if _, err := r.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	clearHistory(mockProvider)
+
+	// canary switches to web's hostname: web keeps ownership
+	dockerMock.workloads[1].Labels = map[string]string{
+		"traefik.http.routers.canary.rule": "Host(`app.example.com`)",
+	}
+
+	result, err := r.ReconcileWorkload(context.Background(), "canary")
+	if err != nil {
+		t.Fatalf("ReconcileWorkload failed: %v", err)
+	}
+	if result.HostnamesDuplicate != 1 {
+		t.Errorf("HostnamesDuplicate = %d, want 1", result.HostnamesDuplicate)
+	}
+	if deleted := deletedDNSHostnames(mockProvider); len(deleted) != 1 || deleted[0] != "canary.example.com" {
+		t.Errorf("deleted = %v, want only canary.example.com", deleted)
+	}
+
+	// web going away must not be blocked by canary's duplicate claim
+	dockerMock.workloads = dockerMock.workloads[1:]
+	clearHistory(mockProvider)
+	if _, err := r.ReconcileWorkload(context.Background(), "web"); err != nil {
+		t.Fatalf("ReconcileWorkload failed: %v", err)
+	}
+	if deleted := deletedDNSHostnames(mockProvider); len(deleted) != 1 || deleted[0] != "app.example.com" {
+		t.Errorf("deleted = %v, want only app.example.com", deleted)
+	}
+}
+
+func TestReconcileWorkload_ListError(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	dockerMock.SetListError(errors.New("docker unavailable"))
+
+	r, _ := newWorkloadTestReconciler(t, dockerMock)
+	if _, err := r.ReconcileWorkload(context.Background(), "web"); err == nil {
+		t.Fatal("expected error when listing workloads fails")
+	}
+}
+
+func TestReconcileWorkload_Disabled(t *testing.T) {
+	dockerMock := newTestMockWorkloadLister(docker.ModeSwarm)
+	dockerMock.SetListError(errors.New("should not be called"))
+
+	r, _ := newWorkloadTestReconciler(t, dockerMock)
+	r.config.Enabled = false
+
+	result, err := r.ReconcileWorkload(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("ReconcileWorkload failed: %v", err)
+	}
+	if len(result.Actions) != 0 {
+		t.Errorf("expected no actions, got %d", len(result.Actions))
+	}
+}
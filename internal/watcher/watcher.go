@@ -174,8 +174,15 @@ func (w *Watcher) watch(ctx context.Context) error {
 	rawClient := w.dockerClient.RawClient()
 	isSwarm := w.dockerClient.IsSwarm()
 
-	// Build event filters based on mode
+	// Build event filters based on mode. Container events carry the
+	// container's labels, so the client's label filter applies to them too;
+	// service events do not, so every service event is still watched.
 	filterArgs := w.buildEventFilters(isSwarm)
+	if !isSwarm {
+		for _, label := range w.dockerClient.LabelFilters() {
+			filterArgs.Add("label", label)
+		}
+	}
 
 	w.logger.Debug("subscribing to docker events",
 		slog.Bool("swarm_mode", isSwarm),
@@ -3,9 +3,12 @@ package httputil
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"log/slog"
 	"net/http"
 	"time"
+
+	"golang.org/x/net/http2"
 )
 
 // Default HTTP client configuration values.
@@ -27,6 +30,22 @@ type ClientConfig struct {
 	// servers with self-signed certificates. It is insecure for production.
 	TLSSkipVerify bool
 
+	// Certificates are TLS client certificates presented during the handshake
+	// (mutual TLS). Empty means no client certificate is sent.
+	Certificates []tls.Certificate
+
+	// RootCAs overrides the system certificate pool used to verify servers.
+	// Nil means the system pool.
+	RootCAs *x509.CertPool
+
+	// HTTP2 configures the transport for HTTP/2 over TLS. The protocol is
+	// negotiated via ALPN, so servers without HTTP/2 keep using HTTP/1.1.
+	HTTP2 bool
+
+	// MaxIdleConns limits idle keep-alive connections, both in total and per
+	// host. Zero keeps the net/http defaults.
+	MaxIdleConns int
+
 	// UserAgent is the User-Agent header to set on requests.
 	// Defaults to "dnsweaver/1.0" if not specified.
 	UserAgent string
@@ -94,13 +113,30 @@ func NewClient(cfg *ClientConfig) *http.Client {
 	// Start with default transport
 	baseTransport := http.DefaultTransport
 
-	// Configure TLS if needed
-	if cfg.TLSSkipVerify {
-		baseTransport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true, //nolint:gosec // Intentional: user explicitly requested skip
-			},
+	// Build a dedicated transport if TLS or connection settings differ from defaults
+	customTLS := cfg.TLSSkipVerify || len(cfg.Certificates) > 0 || cfg.RootCAs != nil
+	if customTLS || cfg.HTTP2 || cfg.MaxIdleConns > 0 {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if customTLS {
+			transport.TLSClientConfig = &tls.Config{
+				InsecureSkipVerify: cfg.TLSSkipVerify, //nolint:gosec // Intentional: user explicitly requested skip
+				Certificates:       cfg.Certificates,
+				RootCAs:            cfg.RootCAs,
+			}
+		}
+		if cfg.MaxIdleConns > 0 {
+			transport.MaxIdleConns = cfg.MaxIdleConns
+			transport.MaxIdleConnsPerHost = cfg.MaxIdleConns
+		}
+		if cfg.HTTP2 {
+			// Clone may carry the default transport's protocol table; reset it
+			// so http2 can register itself
+			transport.TLSNextProto = nil
+			if err := http2.ConfigureTransport(transport); err != nil && cfg.Logger != nil {
+				cfg.Logger.Warn("failed to configure HTTP/2, using HTTP/1.1", slog.String("error", err.Error()))
+			}
 		}
+		baseTransport = transport
 	}
 
 	// Wrap with User-Agent and logging transport
@@ -104,6 +104,39 @@ func TestNewClient_TLSSkipVerify(t *testing.T) {
 	}
 }
 
+func TestNewClient_HTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	client := NewClient(&ClientConfig{TLSSkipVerify: true, HTTP2: true})
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.ProtoMajor != 2 {
+		t.Errorf("ProtoMajor = %d, want 2", resp.ProtoMajor)
+	}
+}
+
+func TestNewClient_MaxIdleConns(t *testing.T) {
+	client := NewClient(&ClientConfig{MaxIdleConns: 20})
+
+	transport, ok := client.Transport.(*userAgentTransport).base.(*http.Transport)
+	if !ok {
+		t.Fatal("expected base transport to be *http.Transport")
+	}
+	if transport.MaxIdleConns != 20 || transport.MaxIdleConnsPerHost != 20 {
+		t.Errorf("MaxIdleConns = %d, MaxIdleConnsPerHost = %d, want 20", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+}
+
 func TestNewClient_TLSSkipVerifyFalse(t *testing.T) {
 	cfg := &ClientConfig{
 		TLSSkipVerify: false,
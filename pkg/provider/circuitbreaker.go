@@ -0,0 +1,303 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/metrics"
+)
+
+// Circuit breaker defaults.
+const (
+	// DefaultCircuitBreakerThreshold is the number of consecutive failures
+	// that open a provider's circuit.
+	DefaultCircuitBreakerThreshold = 5
+
+	// DefaultCircuitBreakerCooldown is how long an open circuit fast-fails
+	// before a probe request is allowed through.
+	DefaultCircuitBreakerCooldown = 60 * time.Second
+)
+
+// CircuitState is the state of a provider's circuit breaker.
+type CircuitState string
+
+// Circuit breaker states.
+const (
+	// CircuitClosed passes operations through to the provider.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen fails operations with ErrCircuitOpen without contacting the provider.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen allows a single probe operation; its outcome closes or reopens the circuit.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// WithCircuitBreaker opens the instance's circuit after threshold consecutive
+// failures. While open, List, Create, Delete, Update, and Ping fail immediately
+// with ErrCircuitOpen; after cooldown one probe operation is let through, and
+// its success closes the circuit. A threshold of zero disables the breaker;
+// a cooldown of zero uses DefaultCircuitBreakerCooldown.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) InstanceOption {
+	return func(o *instanceOptions) {
+		o.circuitBreakerThreshold = threshold
+		o.circuitBreakerCooldown = cooldown
+	}
+}
+
+// circuitBreaker tracks consecutive provider failures.
+type circuitBreaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	logger    *slog.Logger
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// newCircuitBreaker creates a closed circuit breaker.
+func newCircuitBreaker(name string, threshold int, cooldown time.Duration, logger *slog.Logger) *circuitBreaker {
+	if cooldown <= 0 {
+		cooldown = DefaultCircuitBreakerCooldown
+	}
+	metrics.ProviderCircuitOpen.WithLabelValues(name).Set(0)
+	return &circuitBreaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+		logger:    logger,
+		now:       time.Now,
+		state:     CircuitClosed,
+	}
+}
+
+// State returns the current circuit state.
+func (b *circuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// allow reports whether an operation may reach the provider.
+// Returns ErrCircuitOpen while the circuit is open or a probe is in flight.
+func (b *circuitBreaker) allow(operation string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return fmt.Errorf("%s %s: %w", b.name, operation, ErrCircuitOpen)
+		}
+		b.setState(CircuitHalfOpen)
+		b.probing = true
+	case CircuitHalfOpen:
+		if b.probing {
+			return fmt.Errorf("%s %s: %w", b.name, operation, ErrCircuitOpen)
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of an operation allowed by allow.
+func (b *circuitBreaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	switch {
+	case ctx.Err() != nil || errors.Is(err, ErrRateLimitQueueFull):
+		// The caller gave up or the operation never reached the provider,
+		// so the outcome says nothing about provider health.
+		return
+	case !isProviderFailure(err):
+		b.failures = 0
+		if b.state != CircuitClosed {
+			b.setState(CircuitClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || (b.state == CircuitClosed && b.failures >= b.threshold) {
+		b.openedAt = b.now()
+		b.setState(CircuitOpen)
+	}
+}
+
+// setState transitions the breaker, logging and updating the gauge.
+// Caller must hold b.mu.
+func (b *circuitBreaker) setState(state CircuitState) {
+	previous := b.state
+	b.state = state
+
+	open := 1.0
+	if state == CircuitClosed {
+		open = 0
+	}
+	metrics.ProviderCircuitOpen.WithLabelValues(b.name).Set(open)
+
+	switch state {
+	case CircuitOpen:
+		b.logger.Warn("provider circuit breaker opened",
+			slog.String("provider", b.name),
+			slog.Int("consecutive_failures", b.failures),
+			slog.Duration("cooldown", b.cooldown),
+		)
+	case CircuitHalfOpen:
+		b.logger.Info("provider circuit breaker half-open, probing provider",
+			slog.String("provider", b.name),
+		)
+	case CircuitClosed:
+		b.logger.Info("provider circuit breaker closed",
+			slog.String("provider", b.name),
+			slog.String("previous_state", string(previous)),
+		)
+	}
+}
+
+// isProviderFailure reports whether err means the provider is failing.
+// Record-level errors show the provider answered and do not count.
+func isProviderFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	return !errors.Is(err, ErrNotFound) &&
+		!errors.Is(err, ErrConflict) &&
+		!errors.Is(err, ErrTypeConflict) &&
+		!errors.Is(err, ErrInvalidRecord)
+}
+
+// circuitBreakerProvider wraps a Provider with a circuit breaker.
+type circuitBreakerProvider struct {
+	Provider
+	breaker *circuitBreaker
+}
+
+// circuitBreakerUpdater is a circuitBreakerProvider for providers implementing Updater.
+type circuitBreakerUpdater struct {
+	*circuitBreakerProvider
+	updater Updater
+}
+
+// newCircuitBreakerProvider wraps p with a circuit breaker, preserving the Updater interface.
+func newCircuitBreakerProvider(p Provider, threshold int, cooldown time.Duration, logger *slog.Logger) Provider {
+	cb := &circuitBreakerProvider{
+		Provider: p,
+		breaker:  newCircuitBreaker(p.Name(), threshold, cooldown, logger),
+	}
+	if updater, ok := p.(Updater); ok {
+		return &circuitBreakerUpdater{circuitBreakerProvider: cb, updater: updater}
+	}
+	return cb
+}
+
+// Unwrap returns the provider wrapped by the circuit breaker.
+func (p *circuitBreakerProvider) Unwrap() Provider {
+	return p.Provider
+}
+
+// CircuitState returns the current state of the circuit breaker.
+func (p *circuitBreakerProvider) CircuitState() CircuitState {
+	return p.breaker.State()
+}
+
+// Ping checks connectivity unless the circuit is open.
+func (p *circuitBreakerProvider) Ping(ctx context.Context) error {
+	if err := p.breaker.allow("ping"); err != nil {
+		return err
+	}
+	err := p.Provider.Ping(ctx)
+	p.breaker.record(ctx, err)
+	return err
+}
+
+// List lists records unless the circuit is open.
+func (p *circuitBreakerProvider) List(ctx context.Context) ([]Record, error) {
+	if err := p.breaker.allow("list"); err != nil {
+		return nil, err
+	}
+	records, err := p.Provider.List(ctx)
+	p.breaker.record(ctx, err)
+	return records, err
+}
+
+// Create creates the record unless the circuit is open.
+func (p *circuitBreakerProvider) Create(ctx context.Context, record Record) error {
+	if err := p.breaker.allow("create"); err != nil {
+		return err
+	}
+	err := p.Provider.Create(ctx, record)
+	p.breaker.record(ctx, err)
+	return err
+}
+
+// Delete deletes the record unless the circuit is open.
+func (p *circuitBreakerProvider) Delete(ctx context.Context, record Record) error {
+	if err := p.breaker.allow("delete"); err != nil {
+		return err
+	}
+	err := p.Provider.Delete(ctx, record)
+	p.breaker.record(ctx, err)
+	return err
+}
+
+// Update updates the record in place unless the circuit is open.
+func (p *circuitBreakerUpdater) Update(ctx context.Context, existing, desired Record) error {
+	if err := p.breaker.allow("update"); err != nil {
+		return err
+	}
+	err := p.updater.Update(ctx, existing, desired)
+	p.breaker.record(ctx, err)
+	return err
+}
+
+// guard runs op unless the circuit is open, recording its outcome.
+// Used for operations outside the Provider interface, such as BulkCreate.
+func (p *circuitBreakerProvider) guard(ctx context.Context, operation string, op func() error) error {
+	if err := p.breaker.allow(operation); err != nil {
+		return err
+	}
+	err := op()
+	p.breaker.record(ctx, err)
+	return err
+}
+
+// guardedProvider is a provider wrapper that can apply its protection to
+// operations outside the Provider interface.
+type guardedProvider interface {
+	Unwrap() Provider
+	guard(ctx context.Context, operation string, op func() error) error
+}
+
+// runGuarded calls op with the innermost provider, through the guard of every
+// wrapper around p.
+func runGuarded(ctx context.Context, p Provider, operation string, op func(Provider) error) error {
+	g, ok := p.(guardedProvider)
+	if !ok {
+		return op(p)
+	}
+	return g.guard(ctx, operation, func() error {
+		return runGuarded(ctx, g.Unwrap(), operation, op)
+	})
+}
+
+// unwrapProvider strips rate limiter and circuit breaker wrappers from p.
+func unwrapProvider(p Provider) Provider {
+	for {
+		w, ok := p.(interface{ Unwrap() Provider })
+		if !ok {
+			return p
+		}
+		p = w.Unwrap()
+	}
+}
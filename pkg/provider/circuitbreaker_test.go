@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// failingProvider fails List with err and counts how often it was called.
+type failingProvider struct {
+	mockProvider
+	err   error
+	calls int
+}
+
+func (f *failingProvider) List(ctx context.Context) ([]Record, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func newTestBreaker(t *testing.T, p Provider, threshold int) (*circuitBreakerProvider, *time.Time) {
+	t.Helper()
+	cb, ok := newCircuitBreakerProvider(p, threshold, time.Minute, testLogger()).(*circuitBreakerProvider)
+	if !ok {
+		t.Fatalf("expected *circuitBreakerProvider")
+	}
+	now := time.Now()
+	cb.breaker.now = func() time.Time { return now }
+	return cb, &now
+}
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	p := &failingProvider{mockProvider: mockProvider{name: "flaky"}, err: ErrProviderUnavailable}
+	cb, _ := newTestBreaker(t, p, 3)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := cb.List(ctx); !errors.Is(err, ErrProviderUnavailable) {
+			t.Fatalf("List() #%d error = %v, want ErrProviderUnavailable", i, err)
+		}
+	}
+	if cb.CircuitState() != CircuitOpen {
+		t.Fatalf("state = %s, want open", cb.CircuitState())
+	}
+
+	if _, err := cb.List(ctx); !IsCircuitOpen(err) {
+		t.Errorf("List() error = %v, want ErrCircuitOpen", err)
+	}
+	if p.calls != 3 {
+		t.Errorf("provider calls = %d, want 3 (open circuit must not reach provider)", p.calls)
+	}
+}
+
+func TestCircuitBreaker_ProbeClosesCircuit(t *testing.T) {
+	p := &failingProvider{mockProvider: mockProvider{name: "flaky"}, err: ErrProviderUnavailable}
+	cb, now := newTestBreaker(t, p, 1)
+	ctx := context.Background()
+
+	_, _ = cb.List(ctx)
+	if cb.CircuitState() != CircuitOpen {
+		t.Fatalf("state = %s, want open", cb.CircuitState())
+	}
+
+	// A failed probe after the cooldown reopens the circuit
+	*now = now.Add(time.Minute)
+	if _, err := cb.List(ctx); !errors.Is(err, ErrProviderUnavailable) {
+		t.Fatalf("probe error = %v, want ErrProviderUnavailable", err)
+	}
+	if cb.CircuitState() != CircuitOpen {
+		t.Fatalf("state after failed probe = %s, want open", cb.CircuitState())
+	}
+	if _, err := cb.List(ctx); !IsCircuitOpen(err) {
+		t.Errorf("List() error = %v, want ErrCircuitOpen until next cooldown", err)
+	}
+
+	// A successful probe closes it
+	*now = now.Add(time.Minute)
+	p.err = nil
+	if _, err := cb.List(ctx); err != nil {
+		t.Fatalf("probe error = %v, want nil", err)
+	}
+	if cb.CircuitState() != CircuitClosed {
+		t.Errorf("state after successful probe = %s, want closed", cb.CircuitState())
+	}
+}
+
+func TestCircuitBreaker_HalfOpenAllowsSingleProbe(t *testing.T) {
+	p := &failingProvider{mockProvider: mockProvider{name: "flaky"}, err: ErrProviderUnavailable}
+	cb, now := newTestBreaker(t, p, 1)
+
+	_, _ = cb.List(context.Background())
+	*now = now.Add(time.Minute)
+
+	if err := cb.breaker.allow("list"); err != nil {
+		t.Fatalf("first allow() after cooldown = %v, want nil", err)
+	}
+	if cb.CircuitState() != CircuitHalfOpen {
+		t.Errorf("state = %s, want half-open", cb.CircuitState())
+	}
+	if err := cb.breaker.allow("list"); !IsCircuitOpen(err) {
+		t.Errorf("second allow() during probe = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestCircuitBreaker_IgnoresRecordErrors(t *testing.T) {
+	p := &failingProvider{mockProvider: mockProvider{name: "flaky"}, err: ErrNotFound}
+	cb, _ := newTestBreaker(t, p, 1)
+
+	_, _ = cb.List(context.Background())
+	if cb.CircuitState() != CircuitClosed {
+		t.Errorf("state after ErrNotFound = %s, want closed", cb.CircuitState())
+	}
+
+	// A provider rejecting malformed records is still answering
+	p.err = fmt.Errorf("HTTP 422: %w", ErrInvalidRecord)
+	for i := 0; i < 3; i++ {
+		_, _ = cb.List(context.Background())
+	}
+	if cb.CircuitState() != CircuitClosed {
+		t.Errorf("state after ErrInvalidRecord = %s, want closed", cb.CircuitState())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p.err = context.Canceled
+	_, _ = cb.List(ctx)
+	if cb.CircuitState() != CircuitClosed {
+		t.Errorf("state after cancelled context = %s, want closed", cb.CircuitState())
+	}
+}
+
+func TestRegistry_CreateInstance_CircuitBreaker(t *testing.T) {
+	r := NewRegistry(testLogger())
+	r.RegisterFactory("test", func(cfg FactoryConfig) (Provider, error) {
+		return &mockProvider{name: cfg.Name, typeName: "test"}, nil
+	})
+
+	err := r.CreateInstance(ProviderInstanceConfig{
+		Name:                    "guarded",
+		TypeName:                "test",
+		RecordType:              RecordTypeA,
+		Target:                  "10.0.0.1",
+		TTL:                     300,
+		Domains:                 []string{"*.example.com"},
+		RateLimit:               100 * time.Millisecond,
+		CircuitBreakerThreshold: 5,
+	})
+	if err != nil {
+		t.Fatalf("CreateInstance failed: %v", err)
+	}
+
+	inst, _ := r.Get("guarded")
+	if _, ok := inst.Provider.(*circuitBreakerProvider); !ok {
+		t.Fatalf("expected circuit breaker provider, got %T", inst.Provider)
+	}
+	if inst.CircuitState() != CircuitClosed {
+		t.Errorf("CircuitState() = %s, want closed", inst.CircuitState())
+	}
+	if _, ok := unwrapProvider(inst.Provider).(*mockProvider); !ok {
+		t.Errorf("unwrapProvider() = %T, want *mockProvider", unwrapProvider(inst.Provider))
+	}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import "strings"
+
+// redactedValue replaces secret values in a ProviderDescription.
+const redactedValue = "***"
+
+// ProviderDescription is the effective configuration of a provider instance,
+// as returned by ProviderInstance.Describe. It is JSON-serializable; secret
+// provider settings are redacted.
+type ProviderDescription struct {
+	Name             string            `json:"name"`
+	Type             string            `json:"type"`
+	Domains          []string          `json:"domains"`
+	ExcludeDomains   []string          `json:"exclude_domains,omitempty"`
+	RecordType       RecordType        `json:"record_type"`
+	Target           string            `json:"target"`
+	Targets          []string          `json:"targets,omitempty"`
+	TTL              int               `json:"ttl"`
+	Mode             OperationalMode   `json:"mode"`
+	Priority         int               `json:"priority"`
+	ProviderSpecific map[string]string `json:"provider_specific,omitempty"`
+}
+
+// Describe returns the instance's effective configuration. Domain patterns
+// are regexes when the instance was configured with DOMAINS_REGEX.
+func (pi *ProviderInstance) Describe() ProviderDescription {
+	desc := ProviderDescription{
+		Name:           pi.Name(),
+		Type:           pi.Type(),
+		Domains:        pi.domains,
+		ExcludeDomains: pi.excludeDomains,
+		RecordType:     pi.RecordType,
+		Target:         pi.Target,
+		Targets:        pi.Targets,
+		TTL:            pi.TTL,
+		Mode:           pi.Mode,
+		Priority:       pi.Priority,
+	}
+
+	if len(pi.settings) > 0 {
+		desc.ProviderSpecific = make(map[string]string, len(pi.settings))
+		for key, value := range pi.settings {
+			if value != "" && IsSecretSetting(key) {
+				value = redactedValue
+			}
+			desc.ProviderSpecific[key] = value
+		}
+	}
+
+	return desc
+}
+
+// Describe returns the effective configuration of all provider instances in
+// priority order.
+func (r *Registry) Describe() []ProviderDescription {
+	instances := r.All()
+	descs := make([]ProviderDescription, 0, len(instances))
+	for _, inst := range instances {
+		descs = append(descs, inst.Describe())
+	}
+	return descs
+}
+
+// IsSecretSetting reports whether a provider-specific setting holds a secret
+// value (tokens, passwords, secrets, API keys, and credentials).
+func IsSecretSetting(key string) bool {
+	key = strings.ToUpper(key)
+	return strings.Contains(key, "TOKEN") ||
+		strings.Contains(key, "PASSWORD") ||
+		strings.Contains(key, "SECRET") ||
+		strings.HasSuffix(key, "API_KEY") ||
+		strings.HasSuffix(key, "CREDENTIALS")
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegistry_Describe(t *testing.T) {
+	r := NewRegistry(testLogger())
+	r.RegisterFactory("test", func(cfg FactoryConfig) (Provider, error) {
+		return &mockProvider{name: cfg.Name, typeName: "test"}, nil
+	})
+
+	settings := map[string]string{
+		"URL":          "https://dns.example.com",
+		"API_TOKEN":    "s3cret",
+		"PASSWORD":     "",
+		"ZONE":         "example.com",
+		"TSIG_API_KEY": "key",
+	}
+	if err := r.CreateInstance(ProviderInstanceConfig{
+		Name:           "internal",
+		TypeName:       "test",
+		RecordType:     RecordTypeA,
+		Target:         "10.0.0.1",
+		TTL:            300,
+		Domains:        []string{"*.example.com"},
+		ExcludeDomains: []string{"admin.example.com"},
+		ProviderConfig: settings,
+	}); err != nil {
+		t.Fatalf("CreateInstance failed: %v", err)
+	}
+	if err := r.CreateInstance(ProviderInstanceConfig{
+		Name:         "regex",
+		TypeName:     "test",
+		RecordType:   RecordTypeCNAME,
+		Target:       "lb.example.com",
+		TTL:          60,
+		Mode:         ModeAdditive,
+		DomainsRegex: []string{`^.*\.example\.org$`},
+	}); err != nil {
+		t.Fatalf("CreateInstance failed: %v", err)
+	}
+
+	// Later changes to the config map must not leak into the description
+	settings["URL"] = "changed"
+
+	want := []ProviderDescription{
+		{
+			Name:           "internal",
+			Type:           "test",
+			Domains:        []string{"*.example.com"},
+			ExcludeDomains: []string{"admin.example.com"},
+			RecordType:     RecordTypeA,
+			Target:         "10.0.0.1",
+			TTL:            300,
+			Mode:           ModeManaged,
+			ProviderSpecific: map[string]string{
+				"URL":          "https://dns.example.com",
+				"API_TOKEN":    "***",
+				"PASSWORD":     "",
+				"ZONE":         "example.com",
+				"TSIG_API_KEY": "***",
+			},
+		},
+		{
+			Name:       "regex",
+			Type:       "test",
+			Domains:    []string{`^.*\.example\.org$`},
+			RecordType: RecordTypeCNAME,
+			Target:     "lb.example.com",
+			TTL:        60,
+			Mode:       ModeAdditive,
+		},
+	}
+	if got := r.Describe(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Describe() =\n%+v\nwant\n%+v", got, want)
+	}
+}
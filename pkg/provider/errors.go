@@ -1,29 +1,134 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
 )
 
+// Error codes classify provider failures. They are stable strings suitable
+// for log fields and metric labels.
+const (
+	CodeNotFound      = "not_found"
+	CodeConflict      = "conflict"
+	CodeTypeConflict  = "type_conflict"
+	CodeAuth          = "auth"
+	CodeQuota         = "quota"
+	CodeNetwork       = "network"
+	CodeInvalidRecord = "invalid_record"
+	CodeUnavailable   = "unavailable"
+	CodeQueueFull     = "rate_limit_queue_full"
+	CodeCircuitOpen   = "circuit_open"
+	CodeUnknown       = "unknown"
+)
+
+// CodedError is a provider error with a machine-readable code.
+// The sentinel errors below are CodedErrors; providers wrap them with
+// fmt.Errorf("...: %w", ...) to add detail while keeping the code.
+type CodedError struct {
+	code    string
+	message string
+}
+
+// NewCodedError creates a CodedError with the given code and message.
+func NewCodedError(code, message string) *CodedError {
+	return &CodedError{code: code, message: message}
+}
+
+func (e *CodedError) Error() string {
+	return e.message
+}
+
+// Code returns the error code, e.g. CodeAuth.
+func (e *CodedError) Code() string {
+	return e.code
+}
+
 // Common errors for provider operations.
 var (
 	// ErrNotFound indicates a record was not found.
-	ErrNotFound = errors.New("record not found")
+	ErrNotFound error = NewCodedError(CodeNotFound, "record not found")
 
 	// ErrConflict indicates a record already exists with the same hostname, type, and target.
-	ErrConflict = errors.New("record already exists")
+	ErrConflict error = NewCodedError(CodeConflict, "record already exists")
 
 	// ErrTypeConflict indicates a record exists with a different type that conflicts.
 	// For example, a CNAME cannot coexist with an A record at the same hostname.
-	ErrTypeConflict = errors.New("record type conflict")
+	ErrTypeConflict error = NewCodedError(CodeTypeConflict, "record type conflict")
+
+	// ErrAuth indicates authentication or authorization failed (HTTP 401/403).
+	ErrAuth error = NewCodedError(CodeAuth, "unauthorized")
+
+	// ErrUnauthorized is an alias for ErrAuth.
+	ErrUnauthorized = ErrAuth
+
+	// ErrQuota indicates the provider rejected the request because a rate
+	// limit or quota was exceeded (HTTP 429).
+	ErrQuota error = NewCodedError(CodeQuota, "rate limit or quota exceeded")
+
+	// ErrNetwork indicates the provider could not be reached or the request
+	// timed out. Errors from the net package are also classified as network
+	// errors without wrapping ErrNetwork.
+	ErrNetwork error = NewCodedError(CodeNetwork, "network error")
 
-	// ErrUnauthorized indicates authentication failed.
-	ErrUnauthorized = errors.New("unauthorized")
+	// ErrInvalidRecord indicates the provider rejected the record itself,
+	// for example a malformed target (HTTP 400/422).
+	ErrInvalidRecord error = NewCodedError(CodeInvalidRecord, "invalid record")
 
 	// ErrProviderUnavailable indicates the provider API is unreachable.
-	ErrProviderUnavailable = errors.New("provider unavailable")
+	ErrProviderUnavailable error = NewCodedError(CodeUnavailable, "provider unavailable")
+
+	// ErrRateLimitQueueFull indicates an operation was rejected because the
+	// provider's rate limit queue is full.
+	ErrRateLimitQueueFull error = NewCodedError(CodeQueueFull, "rate limit queue full")
+
+	// ErrCircuitOpen indicates an operation was rejected without contacting the
+	// provider because its circuit breaker is open after repeated failures.
+	ErrCircuitOpen error = NewCodedError(CodeCircuitOpen, "circuit breaker open")
 )
 
+// ErrorCode returns the code of the first coded error in err's chain.
+// Errors from the net package and context deadlines are reported as
+// CodeNetwork. Returns "" for a nil error and CodeUnknown otherwise.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	var coded interface{ Code() string }
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return CodeNetwork
+	}
+	return CodeUnknown
+}
+
+// ErrorForStatus returns the sentinel error for an HTTP status code that has
+// a specific meaning to dnsweaver, or nil for any other status. HTTP-based
+// providers wrap it so callers can classify the failure:
+//
+//	if base := provider.ErrorForStatus(resp.StatusCode); base != nil {
+//		return fmt.Errorf("%w: status %d", base, resp.StatusCode)
+//	}
+func ErrorForStatus(status int) error {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrAuth
+	case http.StatusTooManyRequests:
+		return ErrQuota
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrInvalidRecord
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrProviderUnavailable
+	default:
+		return nil
+	}
+}
+
 // ConfigError represents a configuration error.
 type ConfigError struct {
 	Field   string
@@ -104,7 +209,33 @@ func IsUnauthorized(err error) bool {
 	return errors.Is(err, ErrUnauthorized)
 }
 
+// IsAuth returns true if the error indicates authentication or authorization failed.
+func IsAuth(err error) bool {
+	return errors.Is(err, ErrAuth)
+}
+
+// IsQuota returns true if the error indicates a provider rate limit or quota was exceeded.
+func IsQuota(err error) bool {
+	return errors.Is(err, ErrQuota)
+}
+
+// IsNetwork returns true if the error indicates the provider could not be
+// reached, including errors from the net package and context deadlines.
+func IsNetwork(err error) bool {
+	return ErrorCode(err) == CodeNetwork
+}
+
+// IsInvalidRecord returns true if the error indicates the provider rejected the record.
+func IsInvalidRecord(err error) bool {
+	return errors.Is(err, ErrInvalidRecord)
+}
+
 // IsProviderUnavailable returns true if the error indicates the provider is unreachable.
 func IsProviderUnavailable(err error) bool {
 	return errors.Is(err, ErrProviderUnavailable)
 }
+
+// IsCircuitOpen returns true if the error indicates the provider's circuit breaker is open.
+func IsCircuitOpen(err error) bool {
+	return errors.Is(err, ErrCircuitOpen)
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"auth", ErrAuth, CodeAuth},
+		{"unauthorized alias", ErrUnauthorized, CodeAuth},
+		{"wrapped quota", fmt.Errorf("create: %w", ErrQuota), CodeQuota},
+		{"wrapped in ProviderError", WrapError("dns", "create", ErrInvalidRecord), CodeInvalidRecord},
+		{"conflict", ErrConflict, CodeConflict},
+		{"net error", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, CodeNetwork},
+		{"deadline", fmt.Errorf("list: %w", context.DeadlineExceeded), CodeNetwork},
+		{"plain", errors.New("boom"), CodeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorCode(tt.err); got != tt.want {
+				t.Errorf("ErrorCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorForStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, ErrAuth},
+		{http.StatusForbidden, ErrAuth},
+		{http.StatusTooManyRequests, ErrQuota},
+		{http.StatusBadRequest, ErrInvalidRecord},
+		{http.StatusUnprocessableEntity, ErrInvalidRecord},
+		{http.StatusServiceUnavailable, ErrProviderUnavailable},
+		{http.StatusInternalServerError, nil},
+		{http.StatusOK, nil},
+	}
+
+	for _, tt := range tests {
+		if got := ErrorForStatus(tt.status); got != tt.want {
+			t.Errorf("ErrorForStatus(%d) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestErrorClassHelpers(t *testing.T) {
+	netErr := fmt.Errorf("executing request: %w", &net.DNSError{Err: "no such host", Name: "dns.local"})
+
+	if !IsAuth(fmt.Errorf("%w: status 403", ErrAuth)) || IsAuth(ErrQuota) {
+		t.Error("IsAuth() misclassified")
+	}
+	if !IsQuota(fmt.Errorf("%w: status 429", ErrQuota)) || IsQuota(ErrAuth) {
+		t.Error("IsQuota() misclassified")
+	}
+	if !IsNetwork(netErr) || !IsNetwork(ErrNetwork) || IsNetwork(ErrAuth) {
+		t.Error("IsNetwork() misclassified")
+	}
+	if !IsInvalidRecord(fmt.Errorf("%w: bad target", ErrInvalidRecord)) || IsInvalidRecord(ErrConflict) {
+		t.Error("IsInvalidRecord() misclassified")
+	}
+}
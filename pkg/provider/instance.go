@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"time"
 
@@ -63,12 +64,38 @@ type ProviderInstance struct {
 	// - For CNAME records: a target hostname (e.g., "example.com")
 	Target string
 
+	// Targets holds every configured target when more than one is set
+	// (round-robin records). Target is always the first entry.
+	Targets []string
+
 	// TTL is the time-to-live for DNS records in seconds.
 	TTL int
 
 	// Mode is the operational mode for this instance.
 	// Defaults to ModeManaged if not set.
 	Mode OperationalMode
+
+	// Priority orders instances that match the same hostname; lower values
+	// are tried first.
+	Priority int
+
+	// status tracks API health from the operations performed through this instance.
+	status statusTracker
+
+	// domains, excludeDomains, and settings keep the configuration the
+	// instance was created from, for Describe.
+	domains        []string
+	excludeDomains []string
+	settings       map[string]string
+}
+
+// DefaultTargets returns the instance's configured targets.
+// Returns a single-element slice when only Target is set.
+func (pi *ProviderInstance) DefaultTargets() []string {
+	if len(pi.Targets) > 0 {
+		return pi.Targets
+	}
+	return []string{pi.Target}
 }
 
 // Name returns the provider instance name (delegates to Provider).
@@ -81,6 +108,24 @@ func (pi *ProviderInstance) Type() string {
 	return pi.Provider.Type()
 }
 
+// Zone returns the provider's configured zone, or "" if the provider does not
+// implement Zoned.
+func (pi *ProviderInstance) Zone() string {
+	if z, ok := unwrapProvider(pi.Provider).(Zoned); ok {
+		return z.Zone()
+	}
+	return ""
+}
+
+// CircuitState returns the state of the instance's circuit breaker.
+// Instances without a circuit breaker always report CircuitClosed.
+func (pi *ProviderInstance) CircuitState() CircuitState {
+	if cb, ok := pi.Provider.(interface{ CircuitState() CircuitState }); ok {
+		return cb.CircuitState()
+	}
+	return CircuitClosed
+}
+
 // Matches returns true if this instance should handle the given hostname.
 func (pi *ProviderInstance) Matches(hostname string) bool {
 	return pi.Matcher.Matches(hostname)
@@ -89,19 +134,23 @@ func (pi *ProviderInstance) Matches(hostname string) bool {
 // CreateRecord creates a DNS record for the given hostname using this instance's
 // record type and target configuration.
 func (pi *ProviderInstance) CreateRecord(ctx context.Context, hostname string) error {
-	return pi.CreateRecordWithValues(ctx, hostname, pi.RecordType, pi.Target, pi.TTL, nil)
+	return pi.CreateRecordWithValues(ctx, hostname, pi.RecordType, pi.Target, pi.TTL, nil, nil, nil)
 }
 
 // CreateRecordWithValues creates a DNS record with explicit type, target, TTL, and optional SRV data.
 // This is used when RecordHints override the provider instance defaults.
 // For SRV records, srvData must be provided with priority, weight, and port.
-func (pi *ProviderInstance) CreateRecordWithValues(ctx context.Context, hostname string, recordType RecordType, target string, ttl int, srvData *SRVData) error {
+// hints carries provider-specific settings (see Record.Hints) and may be nil.
+// weighted is the routing weight (see Record.Weighted) and may be nil.
+func (pi *ProviderInstance) CreateRecordWithValues(ctx context.Context, hostname string, recordType RecordType, target string, ttl int, srvData *SRVData, hints map[string]string, weighted *WeightedData) error {
 	record := Record{
 		Hostname: hostname,
 		Type:     recordType,
 		Target:   target,
 		TTL:      ttl,
 		SRV:      srvData,
+		Weighted: weighted,
+		Hints:    hints,
 	}
 
 	start := time.Now()
@@ -115,6 +164,7 @@ func (pi *ProviderInstance) CreateRecordWithValues(ctx context.Context, hostname
 
 	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "create", status).Inc()
 	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "create").Observe(duration)
+	pi.observe("create", duration, err)
 
 	return err
 }
@@ -138,6 +188,7 @@ func (pi *ProviderInstance) DeleteRecord(ctx context.Context, hostname string) e
 
 	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "delete", status).Inc()
 	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "delete").Observe(duration)
+	pi.observe("delete", duration, err)
 
 	return err
 }
@@ -162,6 +213,7 @@ func (pi *ProviderInstance) UpdateRecord(ctx context.Context, existing, desired
 
 		metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "update", status).Inc()
 		metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "update").Observe(duration)
+		pi.observe("update", duration, err)
 
 		return err
 	}
@@ -172,6 +224,7 @@ func (pi *ProviderInstance) UpdateRecord(ctx context.Context, existing, desired
 	if err := pi.Provider.Delete(ctx, existing); err != nil {
 		metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "delete", statusError).Inc()
 		metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "delete").Observe(time.Since(start).Seconds())
+		pi.observe("delete", time.Since(start).Seconds(), err)
 		// If delete fails with not found, continue to create (record may have been manually deleted)
 		if !errors.Is(err, ErrNotFound) {
 			return err
@@ -179,6 +232,7 @@ func (pi *ProviderInstance) UpdateRecord(ctx context.Context, existing, desired
 	} else {
 		metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "delete", statusSuccess).Inc()
 		metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "delete").Observe(time.Since(start).Seconds())
+		pi.observe("delete", time.Since(start).Seconds(), nil)
 	}
 
 	// Create the new record
@@ -193,6 +247,50 @@ func (pi *ProviderInstance) UpdateRecord(ctx context.Context, existing, desired
 
 	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "create", status).Inc()
 	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "create").Observe(duration)
+	pi.observe("create", duration, err)
+
+	return err
+}
+
+// SupportsBulkCreate reports whether the underlying provider implements BulkCreator.
+func (pi *ProviderInstance) SupportsBulkCreate() bool {
+	_, ok := unwrapProvider(pi.Provider).(BulkCreator)
+	return ok
+}
+
+// BulkCreate creates records in one operation if the provider implements
+// BulkCreator, going through the instance's rate limiter and circuit breaker.
+// Otherwise each record is created in turn and the returned error joins
+// every failure.
+func (pi *ProviderInstance) BulkCreate(ctx context.Context, records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	if !pi.SupportsBulkCreate() {
+		var errs []error
+		for _, record := range records {
+			if err := pi.CreateRecordWithValues(ctx, record.Hostname, record.Type, record.Target, record.TTL, record.SRV, record.Hints, record.Weighted); err != nil {
+				errs = append(errs, fmt.Errorf("creating %s %s: %w", record.Type, record.Hostname, err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+
+	start := time.Now()
+	err := runGuarded(ctx, pi.Provider, "bulk_create", func(p Provider) error {
+		return p.(BulkCreator).BulkCreate(ctx, records)
+	})
+	duration := time.Since(start).Seconds()
+
+	status := statusSuccess
+	if err != nil {
+		status = statusError
+	}
+
+	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "bulk_create", status).Inc()
+	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "bulk_create").Observe(duration)
+	pi.observe("bulk_create", duration, err)
 
 	return err
 }
@@ -210,11 +308,13 @@ func (pi *ProviderInstance) GetExistingRecords(ctx context.Context, hostname str
 		status = statusError
 		metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "list", status).Inc()
 		metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "list").Observe(duration)
+		pi.observe("list", duration, err)
 		return nil, err
 	}
 
 	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "list", status).Inc()
 	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "list").Observe(duration)
+	pi.observe("list", duration, err)
 
 	var matching []Record
 	for _, r := range allRecords {
@@ -253,6 +353,7 @@ func (pi *ProviderInstance) DeleteRecordByTarget(ctx context.Context, hostname s
 
 	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "delete", status).Inc()
 	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "delete").Observe(duration)
+	pi.observe("delete", duration, err)
 
 	return err
 }
@@ -279,14 +380,16 @@ func (pi *ProviderInstance) DeleteSRVRecord(ctx context.Context, hostname string
 
 	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "delete", status).Inc()
 	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "delete").Observe(duration)
+	pi.observe("delete", duration, err)
 
 	return err
 }
 
 // CreateOwnershipRecord creates a TXT record to mark ownership of a hostname.
-// The TXT record is named "_dnsweaver.{hostname}" with value "heritage=dnsweaver".
-func (pi *ProviderInstance) CreateOwnershipRecord(ctx context.Context, hostname string) error {
-	record := OwnershipRecord(hostname, pi.TTL)
+// The TXT record is named "_dnsweaver.{hostname}" with a value such as
+// "heritage=dnsweaver,version=1,source=traefik". sourceName may be empty.
+func (pi *ProviderInstance) CreateOwnershipRecord(ctx context.Context, hostname, sourceName string) error {
+	record := OwnershipRecord(hostname, pi.TTL, sourceName)
 
 	start := time.Now()
 	err := pi.Provider.Create(ctx, record)
@@ -303,27 +406,83 @@ func (pi *ProviderInstance) CreateOwnershipRecord(ctx context.Context, hostname
 
 	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "create_ownership", status).Inc()
 	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "create_ownership").Observe(duration)
+	pi.observe("create_ownership", duration, err)
 
 	return err
 }
 
-// DeleteOwnershipRecord removes the TXT ownership record for a hostname.
+// DeleteOwnershipRecord removes the TXT ownership records for a hostname.
+// Because the record value carries metadata, the existing records are looked
+// up first so both legacy and versioned records are removed. Callers that
+// already listed the provider should use DeleteListedOwnershipRecords.
 func (pi *ProviderInstance) DeleteOwnershipRecord(ctx context.Context, hostname string) error {
-	record := OwnershipRecord(hostname, pi.TTL)
-
 	start := time.Now()
-	err := pi.Provider.Delete(ctx, record)
+	allRecords, err := pi.Provider.List(ctx)
 	duration := time.Since(start).Seconds()
 
 	status := statusSuccess
 	if err != nil {
 		status = statusError
 	}
+	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "list", status).Inc()
+	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "list").Observe(duration)
+	pi.observe("list", duration, err)
+	if err != nil {
+		return err
+	}
 
-	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "delete_ownership", status).Inc()
-	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "delete_ownership").Observe(duration)
+	return pi.DeleteListedOwnershipRecords(ctx, hostname, allRecords)
+}
 
-	return err
+// DeleteListedOwnershipRecords removes the TXT ownership records for a
+// hostname found in listed, a previous List result of this provider (or any
+// subset containing the hostname's ownership records), without listing again.
+func (pi *ProviderInstance) DeleteListedOwnershipRecords(ctx context.Context, hostname string, listed []Record) error {
+	ownershipName := OwnershipRecordName(hostname)
+
+	var records []Record
+	foreign := false
+	for _, r := range listed {
+		if r.Hostname != ownershipName || r.Type != RecordTypeTXT {
+			continue
+		}
+		if IsOwnershipValue(r.Target) {
+			records = append(records, r)
+		} else {
+			foreign = true
+		}
+	}
+	if len(records) == 0 {
+		if foreign {
+			// Only another tool's marker is there; leave it alone, since some
+			// providers delete every TXT record with the name
+			return nil
+		}
+		// Nothing listed; try the current format so providers that cannot
+		// list TXT records still get a delete request.
+		records = append(records, OwnershipRecord(hostname, pi.TTL, ""))
+	}
+
+	for _, record := range records {
+		start := time.Now()
+		err := pi.Provider.Delete(ctx, record)
+		duration := time.Since(start).Seconds()
+
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+
+		metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "delete_ownership", status).Inc()
+		metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "delete_ownership").Observe(duration)
+		pi.observe("delete_ownership", duration, err)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // HasOwnershipRecord checks if an ownership TXT record exists for the given hostname.
@@ -339,14 +498,16 @@ func (pi *ProviderInstance) HasOwnershipRecord(ctx context.Context, hostname str
 		status = statusError
 		metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "list", status).Inc()
 		metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "list").Observe(duration)
+		pi.observe("list", duration, err)
 		return false, err
 	}
 
 	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "list", status).Inc()
 	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "list").Observe(duration)
+	pi.observe("list", duration, err)
 
 	for _, r := range records {
-		if r.Hostname == ownershipName && r.Type == RecordTypeTXT && r.Target == OwnershipValue {
+		if r.Hostname == ownershipName && r.Type == RecordTypeTXT && IsOwnershipValue(r.Target) {
 			return true, nil
 		}
 	}
@@ -367,16 +528,18 @@ func (pi *ProviderInstance) RecoverOwnedHostnames(ctx context.Context) ([]string
 		status = statusError
 		metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "list", status).Inc()
 		metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "list").Observe(duration)
+		pi.observe("list", duration, err)
 		return nil, err
 	}
 
 	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "list", status).Inc()
 	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "list").Observe(duration)
+	pi.observe("list", duration, err)
 
 	var hostnames []string
 	for _, r := range records {
 		// Look for ownership TXT records with the correct value
-		if r.Type == RecordTypeTXT && r.Target == OwnershipValue && IsOwnershipRecord(r.Hostname) {
+		if r.Type == RecordTypeTXT && IsOwnershipValue(r.Target) && IsOwnershipRecord(r.Hostname) {
 			hostname := ExtractHostnameFromOwnership(r.Hostname)
 			if hostname != "" {
 				hostnames = append(hostnames, hostname)
@@ -387,7 +550,28 @@ func (pi *ProviderInstance) RecoverOwnedHostnames(ctx context.Context) ([]string
 	return hostnames, nil
 }
 
-// Ping checks connectivity to the provider.
+// observe records the outcome of an operation in the instance's status and,
+// when it succeeded, in the dnsweaver_provider_last_success_timestamp_seconds
+// gauge. It is safe for concurrent use.
+func (pi *ProviderInstance) observe(operation string, seconds float64, err error) {
+	if category := pi.status.observe(operation, seconds, err); category != "" {
+		metrics.ProviderLastSuccessTimestamp.WithLabelValues(pi.Name(), category).SetToCurrentTime()
+	}
+}
+
+// Status returns the instance's API health as observed from the operations
+// it has performed, without contacting the provider. It includes when the
+// instance last created, deleted, and listed records successfully.
+func (pi *ProviderInstance) Status() ProviderStatus {
+	status := pi.status.snapshot()
+	status.Name = pi.Name()
+	status.Type = pi.Type()
+	status.Available = true
+	return status
+}
+
+// Ping checks connectivity to the provider. The result is also recorded in
+// the status returned by Status.
 func (pi *ProviderInstance) Ping(ctx context.Context) error {
 	start := time.Now()
 	err := pi.Provider.Ping(ctx)
@@ -402,11 +586,15 @@ func (pi *ProviderInstance) Ping(ctx context.Context) error {
 
 	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "ping", status).Inc()
 	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "ping").Observe(duration)
+	pi.observe("ping", duration, err)
 	metrics.ProviderHealthy.WithLabelValues(pi.Name()).Set(healthy)
 
 	return err
 }
 
+// DefaultPriority is the priority of instances that do not set one.
+const DefaultPriority = 100
+
 // ProviderInstanceConfig holds configuration for creating a ProviderInstance.
 type ProviderInstanceConfig struct {
 	// Name is the instance name (e.g., "internal-dns").
@@ -421,6 +609,10 @@ type ProviderInstanceConfig struct {
 	// Target is the IP or hostname target for records.
 	Target string
 
+	// Targets optionally lists several targets for round-robin records.
+	// When set, Target must equal Targets[0].
+	Targets []string
+
 	// TTL is the record TTL in seconds.
 	TTL int
 
@@ -442,6 +634,27 @@ type ProviderInstanceConfig struct {
 	// ExcludeDomainsRegex is an optional list of regex patterns to exclude.
 	ExcludeDomainsRegex []string
 
+	// Priority orders instances that match the same hostname; lower values
+	// are preferred and equal values keep registration order. Config loading
+	// defaults it to DefaultPriority.
+	Priority int
+
+	// RateLimit is the minimum interval between List/Create/Delete/Update calls.
+	// Zero disables rate limiting.
+	RateLimit time.Duration
+
+	// RateLimitQueue is the maximum number of operations waiting for the rate limiter.
+	// Zero uses DefaultRateLimitQueue.
+	RateLimitQueue int
+
+	// CircuitBreakerThreshold is the number of consecutive failures that open
+	// the provider's circuit. Zero disables the circuit breaker.
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is how long an open circuit fails fast before a
+	// probe is allowed. Zero uses DefaultCircuitBreakerCooldown.
+	CircuitBreakerCooldown time.Duration
+
 	// ProviderConfig holds provider-specific settings (URL, token, zone, etc.).
 	ProviderConfig map[string]string
 }
@@ -461,21 +674,35 @@ func (c *ProviderInstanceConfig) Validate() error {
 		return ErrConfigMissing("target")
 	}
 
-	// Validate target matches record type
-	if c.RecordType == RecordTypeCNAME && isIPAddress(c.Target) {
-		return ErrConfigInvalid("target", c.Target, "CNAME records cannot point to IP addresses; use record_type=A or AAAA for IP targets")
+	targets := c.Targets
+	if len(targets) == 0 {
+		targets = []string{c.Target}
 	}
-	if c.RecordType == RecordTypeA && !isIPv4Address(c.Target) {
-		return ErrConfigInvalid("target", c.Target, "A records must point to IPv4 addresses; use record_type=AAAA for IPv6 or CNAME for hostnames")
+	if len(targets) > 1 && c.RecordType == RecordTypeCNAME {
+		return ErrConfigInvalid("targets", "", "CNAME records cannot have multiple targets")
 	}
-	if c.RecordType == RecordTypeAAAA && !isIPv6Address(c.Target) {
-		return ErrConfigInvalid("target", c.Target, "AAAA records must point to IPv6 addresses; use record_type=A for IPv4 or CNAME for hostnames")
+
+	// Validate targets match record type
+	for _, target := range targets {
+		if c.RecordType == RecordTypeCNAME && isIPAddress(target) {
+			return ErrConfigInvalid("target", target, "CNAME records cannot point to IP addresses; use record_type=A or AAAA for IP targets")
+		}
+		if c.RecordType == RecordTypeA && !isIPv4Address(target) {
+			return ErrConfigInvalid("target", target, "A records must point to IPv4 addresses; use record_type=AAAA for IPv6 or CNAME for hostnames")
+		}
+		if c.RecordType == RecordTypeAAAA && !isIPv6Address(target) {
+			return ErrConfigInvalid("target", target, "AAAA records must point to IPv6 addresses; use record_type=A for IPv4 or CNAME for hostnames")
+		}
 	}
 
 	if c.TTL < 1 {
 		return ErrConfigInvalid("ttl", "", "must be at least 1")
 	}
 
+	if c.Priority < 0 {
+		return ErrConfigInvalid("priority", fmt.Sprintf("%d", c.Priority), "must not be negative")
+	}
+
 	// Domains validation: must have either Domains or DomainsRegex, but not both
 	hasGlob := len(c.Domains) > 0
 	hasRegex := len(c.DomainsRegex) > 0
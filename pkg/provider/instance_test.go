@@ -1,6 +1,11 @@
 package provider
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
 
 func TestIsIPAddress(t *testing.T) {
 	tests := []struct {
@@ -244,6 +249,35 @@ func TestProviderInstanceConfig_Validate_Complete(t *testing.T) {
 	}
 }
 
+func TestProviderInstanceConfig_Validate_Targets(t *testing.T) {
+	base := ProviderInstanceConfig{
+		Name:       "rr-dns",
+		TypeName:   "technitium",
+		RecordType: RecordTypeA,
+		Target:     "10.0.0.1",
+		Targets:    []string{"10.0.0.1", "10.0.0.2"},
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	}
+	if err := base.Validate(); err != nil {
+		t.Errorf("expected valid config, got error: %v", err)
+	}
+
+	invalid := base
+	invalid.Targets = []string{"10.0.0.1", "not-an-ip"}
+	if err := invalid.Validate(); err == nil {
+		t.Error("expected error for non-IPv4 target in A record targets")
+	}
+
+	cname := base
+	cname.RecordType = RecordTypeCNAME
+	cname.Target = "a.example.com"
+	cname.Targets = []string{"a.example.com", "b.example.com"}
+	if err := cname.Validate(); err == nil {
+		t.Error("expected error for CNAME with multiple targets")
+	}
+}
+
 func TestProviderInstanceConfig_Validate_CNAME_Complete(t *testing.T) {
 	// Test a complete valid CNAME configuration
 	cfg := ProviderInstanceConfig{
@@ -364,3 +398,103 @@ func containsString(s, substr string) bool {
 	}
 	return false
 }
+
+// zonedProvider is a mockProvider bound to a zone.
+type zonedProvider struct {
+	mockProvider
+	zone string
+}
+
+func (z *zonedProvider) Zone() string { return z.zone }
+
+func TestProviderInstance_Zone(t *testing.T) {
+	zoned := &zonedProvider{mockProvider: mockProvider{name: "zoned"}, zone: "example.com"}
+
+	tests := []struct {
+		name string
+		p    Provider
+		want string
+	}{
+		{"zoned", zoned, "example.com"},
+		{"wrapped", newCircuitBreakerProvider(zoned, 3, time.Minute, testLogger()), "example.com"},
+		{"no zone", &mockProvider{name: "plain"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pi := &ProviderInstance{Provider: tt.p}
+			if got := pi.Zone(); got != tt.want {
+				t.Errorf("Zone() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// bulkProvider is a memProvider that implements BulkCreator.
+type bulkProvider struct {
+	memProvider
+	bulkCalls int
+	bulkErr   error
+}
+
+func (b *bulkProvider) BulkCreate(ctx context.Context, records []Record) error {
+	b.bulkCalls++
+	if b.bulkErr != nil {
+		return b.bulkErr
+	}
+	b.records = append(b.records, records...)
+	return nil
+}
+
+func TestProviderInstance_BulkCreate(t *testing.T) {
+	records := []Record{
+		{Hostname: "a.example.com", Type: RecordTypeA, Target: "10.0.0.1", TTL: 300},
+		{Hostname: "b.example.com", Type: RecordTypeA, Target: "10.0.0.1", TTL: 300},
+	}
+	ctx := context.Background()
+
+	t.Run("bulk through wrappers", func(t *testing.T) {
+		p := &bulkProvider{memProvider: memProvider{mockProvider: mockProvider{name: "bulk"}}}
+		wrapped := newRateLimitedProvider(newCircuitBreakerProvider(p, 3, time.Minute, testLogger()), time.Millisecond, 10, testLogger())
+		pi := &ProviderInstance{Provider: wrapped}
+
+		if !pi.SupportsBulkCreate() {
+			t.Fatal("SupportsBulkCreate() = false, want true")
+		}
+		if err := pi.BulkCreate(ctx, records); err != nil {
+			t.Fatalf("BulkCreate() unexpected error: %v", err)
+		}
+		if p.bulkCalls != 1 || len(p.records) != 2 {
+			t.Errorf("BulkCreate() made %d bulk calls and %d records, want 1 and 2", p.bulkCalls, len(p.records))
+		}
+	})
+
+	t.Run("open circuit", func(t *testing.T) {
+		p := &bulkProvider{memProvider: memProvider{mockProvider: mockProvider{name: "bulk"}}, bulkErr: errors.New("unreachable")}
+		pi := &ProviderInstance{Provider: newCircuitBreakerProvider(p, 1, time.Minute, testLogger())}
+
+		if err := pi.BulkCreate(ctx, records); err == nil {
+			t.Fatal("BulkCreate() expected error")
+		}
+		if err := pi.BulkCreate(ctx, records); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("BulkCreate() error = %v, want %v", err, ErrCircuitOpen)
+		}
+		if p.bulkCalls != 1 {
+			t.Errorf("bulk calls = %d, want 1", p.bulkCalls)
+		}
+	})
+
+	t.Run("sequential fallback", func(t *testing.T) {
+		p := &memProvider{mockProvider: mockProvider{name: "plain"}}
+		pi := &ProviderInstance{Provider: p}
+
+		if pi.SupportsBulkCreate() {
+			t.Fatal("SupportsBulkCreate() = true, want false")
+		}
+		if err := pi.BulkCreate(ctx, records); err != nil {
+			t.Fatalf("BulkCreate() unexpected error: %v", err)
+		}
+		if len(p.records) != 2 {
+			t.Errorf("records = %d, want 2", len(p.records))
+		}
+	})
+}
@@ -3,8 +3,10 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
 	"sync"
 	"time"
 
@@ -24,6 +26,16 @@ type ManagerConfig struct {
 	// RetryBackoffMultiplier is the multiplier for exponential backoff.
 	// Default: 2.0.
 	RetryBackoffMultiplier float64
+
+	// RetryJitter randomizes each retry interval by up to this fraction (0.0-1.0)
+	// so that providers sharing a backend don't retry in lockstep.
+	// Default: 0.2.
+	RetryJitter float64
+
+	// MaxPendingDuration is how long a provider may stay pending before it is
+	// marked permanently failed and no longer retried. Zero retries forever.
+	// Default: 0.
+	MaxPendingDuration time.Duration
 }
 
 // DefaultManagerConfig returns a ManagerConfig with sensible defaults.
@@ -32,6 +44,7 @@ func DefaultManagerConfig() ManagerConfig {
 		InitialRetryInterval:   5 * time.Second,
 		MaxRetryInterval:       5 * time.Minute,
 		RetryBackoffMultiplier: 2.0,
+		RetryJitter:            0.2,
 	}
 }
 
@@ -43,6 +56,11 @@ type PendingProvider struct {
 	AttemptCount  int
 	NextRetryAt   time.Time
 	RetryInterval time.Duration
+	FirstFailure  time.Time
+
+	// PermanentlyFailed is set once the provider exceeds MaxPendingDuration.
+	// Permanently failed providers are not retried until ResetFailed is called.
+	PermanentlyFailed bool
 }
 
 // Manager handles graceful provider initialization with retry logic.
@@ -72,6 +90,25 @@ func WithManagerConfig(cfg ManagerConfig) ManagerOption {
 	}
 }
 
+// WithManagerRetryBackoff configures exponential backoff for provider retries.
+// The interval starts at initial, is multiplied by multiplier after each failure,
+// and is capped at max. Jitter from the manager config is applied to each interval.
+func WithManagerRetryBackoff(initial, max time.Duration, multiplier float64) ManagerOption {
+	return func(m *Manager) {
+		m.config.InitialRetryInterval = initial
+		m.config.MaxRetryInterval = max
+		m.config.RetryBackoffMultiplier = multiplier
+	}
+}
+
+// WithManagerMaxPendingDuration sets how long a provider may stay pending
+// before it is marked permanently failed. Zero retries forever.
+func WithManagerMaxPendingDuration(d time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.config.MaxPendingDuration = d
+	}
+}
+
 // WithManagerLogger sets a custom logger for the manager.
 func WithManagerLogger(logger *slog.Logger) ManagerOption {
 	return func(m *Manager) {
@@ -118,7 +155,7 @@ func (m *Manager) InitializeProvider(cfg ProviderInstanceConfig) error {
 
 			if pingErr != nil {
 				// Created but not reachable - remove from registry and queue for retry
-				m.registry.Remove(cfg.Name)
+				m.discardInstance(cfg.Name)
 				err = fmt.Errorf("connectivity check failed: %w", pingErr)
 				m.logger.Warn("provider created but connectivity check failed",
 					slog.String("provider", cfg.Name),
@@ -143,13 +180,16 @@ func (m *Manager) InitializeProvider(cfg ProviderInstanceConfig) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	now := time.Now()
+	retryIn := m.jitter(m.config.InitialRetryInterval)
 	m.pending[cfg.Name] = &PendingProvider{
 		Config:        cfg,
 		LastError:     err,
-		LastAttempt:   time.Now(),
+		LastAttempt:   now,
 		AttemptCount:  1,
-		NextRetryAt:   time.Now().Add(m.config.InitialRetryInterval),
+		NextRetryAt:   now.Add(retryIn),
 		RetryInterval: m.config.InitialRetryInterval,
+		FirstFailure:  now,
 	}
 
 	// Record metrics
@@ -161,12 +201,77 @@ func (m *Manager) InitializeProvider(cfg ProviderInstanceConfig) error {
 		slog.String("provider", cfg.Name),
 		slog.String("type", cfg.TypeName),
 		slog.String("error", err.Error()),
-		slog.Duration("retry_in", m.config.InitialRetryInterval),
+		slog.Duration("retry_in", retryIn),
 	)
 
 	return nil
 }
 
+// AddProvider initializes a provider that was not configured before, e.g. after
+// a configuration reload. Like InitializeProvider, a provider that cannot be
+// reached is queued for retry. Returns an error if the configuration is invalid
+// or an instance (ready or pending) with the same name already exists.
+func (m *Manager) AddProvider(cfg ProviderInstanceConfig) error {
+	m.mu.RLock()
+	_, pending := m.pending[cfg.Name]
+	m.mu.RUnlock()
+	if _, ok := m.registry.Get(cfg.Name); ok || pending {
+		return fmt.Errorf("provider %q already exists", cfg.Name)
+	}
+
+	return m.InitializeProvider(cfg)
+}
+
+// RemoveProvider removes a ready or pending provider so that it no longer
+// receives records or retries. A ready provider is closed; close failures are
+// logged. Returns false if no provider has the name.
+func (m *Manager) RemoveProvider(name string) bool {
+	typeName := ""
+	if inst, ok := m.registry.Get(name); ok {
+		typeName = inst.Type()
+	}
+	err := m.registry.RemoveInstance(name)
+	removed := !errors.Is(err, ErrInstanceNotFound)
+	if removed && err != nil {
+		m.logger.Warn("failed to close removed provider",
+			slog.String("provider", name),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if p, ok := m.pending[name]; ok {
+		typeName = p.Config.TypeName
+		delete(m.pending, name)
+		removed = true
+	}
+	if !removed {
+		return false
+	}
+
+	metrics.ProviderAvailable.DeleteLabelValues(name, typeName)
+	m.updateCountMetricsLocked()
+
+	m.logger.Info("provider removed",
+		slog.String("provider", name),
+		slog.String("type", typeName),
+	)
+	return true
+}
+
+// discardInstance removes an instance that failed its connectivity check and
+// closes its provider, so a retry does not leak connections.
+func (m *Manager) discardInstance(name string) {
+	if err := m.registry.RemoveInstance(name); err != nil {
+		m.logger.Debug("failed to close unreachable provider",
+			slog.String("provider", name),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
 // Start begins the background retry loop for pending providers.
 // Call this after initializing all providers.
 func (m *Manager) Start(ctx context.Context) error {
@@ -233,6 +338,9 @@ func (m *Manager) retryPendingProviders(ctx context.Context) {
 	var toRetry []*PendingProvider
 	now := time.Now()
 	for _, pending := range m.pending {
+		if pending.PermanentlyFailed {
+			continue
+		}
 		if now.After(pending.NextRetryAt) || now.Equal(pending.NextRetryAt) {
 			toRetry = append(toRetry, pending)
 		}
@@ -244,7 +352,10 @@ func (m *Manager) retryPendingProviders(ctx context.Context) {
 	}
 }
 
-// retryProvider attempts to initialize a single pending provider.
+// retryProvider attempts to initialize a single pending provider. The
+// instance is created without holding the lock, so if the provider was
+// removed or replaced (e.g. by a configuration reload) in the meantime, the
+// created instance is discarded and the pending state is left untouched.
 func (m *Manager) retryProvider(ctx context.Context, pending *PendingProvider) {
 	cfg := pending.Config
 
@@ -265,7 +376,7 @@ func (m *Manager) retryProvider(ctx context.Context, pending *PendingProvider) {
 
 			if pingErr != nil {
 				// Created but ping failed - remove from registry and continue retry
-				m.registry.Remove(cfg.Name)
+				m.discardInstance(cfg.Name)
 				err = fmt.Errorf("connectivity check failed: %w", pingErr)
 				m.logger.Debug("provider created but connectivity check failed during retry",
 					slog.String("provider", cfg.Name),
@@ -276,6 +387,16 @@ func (m *Manager) retryProvider(ctx context.Context, pending *PendingProvider) {
 	}
 
 	m.mu.Lock()
+	if m.pending[cfg.Name] != pending {
+		m.mu.Unlock()
+		if err == nil {
+			m.discardInstance(cfg.Name)
+		}
+		m.logger.Debug("provider removed during retry, discarding result",
+			slog.String("provider", cfg.Name),
+		)
+		return
+	}
 	defer m.mu.Unlock()
 
 	if err == nil {
@@ -296,29 +417,100 @@ func (m *Manager) retryProvider(ctx context.Context, pending *PendingProvider) {
 	}
 
 	// Still failing - update retry state with exponential backoff
+	now := time.Now()
 	pending.LastError = err
-	pending.LastAttempt = time.Now()
+	pending.LastAttempt = now
 	pending.AttemptCount++
 
+	// Record failed retry metric
+	metrics.ProviderInitRetries.WithLabelValues(cfg.Name, "failed").Inc()
+
+	// Give up once the provider has been pending for too long
+	if m.config.MaxPendingDuration > 0 && now.Sub(pending.FirstFailure) >= m.config.MaxPendingDuration {
+		pending.PermanentlyFailed = true
+		pending.NextRetryAt = time.Time{}
+		m.logger.Error("provider permanently failed, retries stopped until reset",
+			slog.String("provider", cfg.Name),
+			slog.String("error", err.Error()),
+			slog.Int("attempts", pending.AttemptCount),
+			slog.Duration("pending_for", now.Sub(pending.FirstFailure)),
+		)
+		return
+	}
+
 	// Calculate next retry interval with exponential backoff
 	newInterval := time.Duration(float64(pending.RetryInterval) * m.config.RetryBackoffMultiplier)
 	if newInterval > m.config.MaxRetryInterval {
 		newInterval = m.config.MaxRetryInterval
 	}
 	pending.RetryInterval = newInterval
-	pending.NextRetryAt = time.Now().Add(newInterval)
-
-	// Record failed retry metric
-	metrics.ProviderInitRetries.WithLabelValues(cfg.Name, "failed").Inc()
+	retryIn := m.jitter(newInterval)
+	pending.NextRetryAt = now.Add(retryIn)
 
 	m.logger.Warn("provider retry failed",
 		slog.String("provider", cfg.Name),
 		slog.String("error", err.Error()),
 		slog.Int("attempt", pending.AttemptCount),
-		slog.Duration("next_retry_in", newInterval),
+		slog.Duration("next_retry_in", retryIn),
 	)
 }
 
+// jitter randomizes d by up to ±RetryJitter, never exceeding MaxRetryInterval.
+func (m *Manager) jitter(d time.Duration) time.Duration {
+	if m.config.RetryJitter <= 0 || d <= 0 {
+		return d
+	}
+	delta := (rand.Float64()*2 - 1) * m.config.RetryJitter * float64(d)
+	jittered := time.Duration(float64(d) + delta)
+	if m.config.MaxRetryInterval > 0 && jittered > m.config.MaxRetryInterval {
+		jittered = m.config.MaxRetryInterval
+	}
+	if jittered < 0 {
+		return 0
+	}
+	return jittered
+}
+
+// ResetFailed clears the permanently failed state of all providers and
+// schedules them for an immediate retry with a fresh backoff.
+// Returns the number of providers that were reset.
+func (m *Manager) ResetFailed() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	count := 0
+	for _, pending := range m.pending {
+		if !pending.PermanentlyFailed {
+			continue
+		}
+		pending.PermanentlyFailed = false
+		pending.FirstFailure = now
+		pending.RetryInterval = m.config.InitialRetryInterval
+		pending.NextRetryAt = now
+		count++
+
+		m.logger.Info("permanently failed provider reset, retrying",
+			slog.String("provider", pending.Config.Name),
+		)
+	}
+	return count
+}
+
+// FailedCount returns the number of permanently failed providers.
+func (m *Manager) FailedCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	count := 0
+	for _, pending := range m.pending {
+		if pending.PermanentlyFailed {
+			count++
+		}
+	}
+	return count
+}
+
 // updateCountMetrics updates the providers_ready and providers_pending gauge metrics.
 // Must not hold the lock when calling this method.
 func (m *Manager) updateCountMetrics() {
@@ -369,12 +561,13 @@ func (m *Manager) PendingProviders() []PendingProviderStatus {
 	result := make([]PendingProviderStatus, 0, len(m.pending))
 	for _, p := range m.pending {
 		result = append(result, PendingProviderStatus{
-			Name:         p.Config.Name,
-			Type:         p.Config.TypeName,
-			LastError:    p.LastError.Error(),
-			LastAttempt:  p.LastAttempt,
-			AttemptCount: p.AttemptCount,
-			NextRetryAt:  p.NextRetryAt,
+			Name:              p.Config.Name,
+			Type:              p.Config.TypeName,
+			LastError:         p.LastError.Error(),
+			LastAttempt:       p.LastAttempt,
+			AttemptCount:      p.AttemptCount,
+			NextRetryAt:       p.NextRetryAt,
+			PermanentlyFailed: p.PermanentlyFailed,
 		})
 	}
 
@@ -389,14 +582,9 @@ type PendingProviderStatus struct {
 	LastAttempt  time.Time `json:"last_attempt"`
 	AttemptCount int       `json:"attempt_count"`
 	NextRetryAt  time.Time `json:"next_retry_at"`
-}
 
-// ProviderStatus represents the availability status of a provider for health checks.
-type ProviderStatus struct {
-	Name      string `json:"name"`
-	Type      string `json:"type"`
-	Available bool   `json:"available"`
-	Error     string `json:"error,omitempty"`
+	// PermanentlyFailed is true when retries have stopped; NextRetryAt is zero.
+	PermanentlyFailed bool `json:"permanently_failed"`
 }
 
 // AllProviderStatuses returns the status of all configured providers (ready and pending).
@@ -405,11 +593,7 @@ func (m *Manager) AllProviderStatuses() []ProviderStatus {
 
 	// Add ready providers
 	for _, inst := range m.registry.All() {
-		statuses = append(statuses, ProviderStatus{
-			Name:      inst.Name(),
-			Type:      inst.Type(),
-			Available: true,
-		})
+		statuses = append(statuses, inst.Status())
 	}
 
 	// Add pending providers
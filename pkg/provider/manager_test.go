@@ -487,3 +487,237 @@ func TestManager_RetryLoop_PingRecovery(t *testing.T) {
 		t.Errorf("expected 0 pending providers after recovery, got %d", manager.PendingCount())
 	}
 }
+
+func TestManager_WithManagerRetryBackoff(t *testing.T) {
+	manager := NewManager(NewRegistry(slog.Default()),
+		WithManagerRetryBackoff(time.Second, time.Minute, 3.0),
+	)
+
+	if manager.config.InitialRetryInterval != time.Second {
+		t.Errorf("InitialRetryInterval = %v, want 1s", manager.config.InitialRetryInterval)
+	}
+	if manager.config.MaxRetryInterval != time.Minute {
+		t.Errorf("MaxRetryInterval = %v, want 1m", manager.config.MaxRetryInterval)
+	}
+	if manager.config.RetryBackoffMultiplier != 3.0 {
+		t.Errorf("RetryBackoffMultiplier = %v, want 3.0", manager.config.RetryBackoffMultiplier)
+	}
+	if manager.config.RetryJitter != DefaultManagerConfig().RetryJitter {
+		t.Errorf("RetryJitter = %v, want default", manager.config.RetryJitter)
+	}
+}
+
+func TestManager_Jitter(t *testing.T) {
+	manager := NewManager(NewRegistry(slog.Default()),
+		WithManagerConfig(ManagerConfig{
+			InitialRetryInterval:   10 * time.Second,
+			MaxRetryInterval:       11 * time.Second,
+			RetryBackoffMultiplier: 2.0,
+			RetryJitter:            0.5,
+		}),
+	)
+
+	for i := 0; i < 100; i++ {
+		d := manager.jitter(10 * time.Second)
+		if d < 5*time.Second || d > 11*time.Second {
+			t.Fatalf("jitter(10s) = %v, want within [5s, 11s]", d)
+		}
+	}
+
+	manager.config.RetryJitter = 0
+	if d := manager.jitter(10 * time.Second); d != 10*time.Second {
+		t.Errorf("jitter with RetryJitter=0 = %v, want 10s", d)
+	}
+}
+
+func TestManager_MaxPendingDuration(t *testing.T) {
+	logger := slog.Default()
+	registry := NewRegistry(logger)
+	registry.RegisterFactory("mock", alwaysFailFactory())
+
+	manager := NewManager(registry,
+		WithManagerLogger(logger),
+		WithManagerConfig(ManagerConfig{
+			InitialRetryInterval:   10 * time.Millisecond,
+			MaxRetryInterval:       10 * time.Millisecond,
+			RetryBackoffMultiplier: 1.0,
+		}),
+		WithManagerMaxPendingDuration(time.Nanosecond),
+	)
+
+	cfg := ProviderInstanceConfig{
+		Name:       "failing-provider",
+		TypeName:   "mock",
+		RecordType: RecordTypeA,
+		Target:     "192.0.2.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	}
+	if err := manager.InitializeProvider(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// First retry exceeds the max pending duration
+	time.Sleep(20 * time.Millisecond)
+	manager.retryPendingProviders(context.Background())
+
+	if manager.FailedCount() != 1 {
+		t.Fatalf("expected 1 permanently failed provider, got %d", manager.FailedCount())
+	}
+	pending := manager.PendingProviders()
+	if len(pending) != 1 || !pending[0].PermanentlyFailed {
+		t.Fatalf("expected provider to be reported as permanently failed, got %+v", pending)
+	}
+	if !pending[0].NextRetryAt.IsZero() {
+		t.Errorf("expected zero NextRetryAt for permanently failed provider, got %v", pending[0].NextRetryAt)
+	}
+
+	// Permanently failed providers are not retried
+	attempts := pending[0].AttemptCount
+	time.Sleep(20 * time.Millisecond)
+	manager.retryPendingProviders(context.Background())
+	if got := manager.PendingProviders()[0].AttemptCount; got != attempts {
+		t.Errorf("expected no further attempts, got %d (was %d)", got, attempts)
+	}
+
+	// Reset schedules the provider for an immediate retry
+	if n := manager.ResetFailed(); n != 1 {
+		t.Errorf("ResetFailed() = %d, want 1", n)
+	}
+	if manager.FailedCount() != 0 {
+		t.Errorf("expected 0 permanently failed providers after reset, got %d", manager.FailedCount())
+	}
+	manager.retryPendingProviders(context.Background())
+	if got := manager.PendingProviders()[0].AttemptCount; got != attempts+1 {
+		t.Errorf("expected retry after reset, attempts = %d, want %d", got, attempts+1)
+	}
+}
+
+func TestManager_UnreachableProviderIsClosed(t *testing.T) {
+	logger := slog.Default()
+	registry := NewRegistry(logger)
+
+	cp := &closingProvider{mockProvider: mockProvider{
+		name:     "ssh-backed",
+		typeName: "mock",
+		pingErr:  errors.New("connection refused"),
+	}}
+	registry.RegisterFactory("mock", successFactory(cp))
+	manager := NewManager(registry, WithManagerLogger(logger))
+
+	err := manager.InitializeProvider(ProviderInstanceConfig{
+		Name:       "ssh-backed",
+		TypeName:   "mock",
+		RecordType: RecordTypeA,
+		Target:     "192.0.2.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("InitializeProvider() unexpected error: %v", err)
+	}
+	if cp.closed != 1 {
+		t.Errorf("provider closed %d times after failed init, want 1", cp.closed)
+	}
+
+	manager.mu.RLock()
+	pending := manager.pending["ssh-backed"]
+	manager.mu.RUnlock()
+	manager.retryProvider(context.Background(), pending)
+	if cp.closed != 2 {
+		t.Errorf("provider closed %d times after failed retry, want 2", cp.closed)
+	}
+}
+
+func TestManager_AddAndRemoveProvider(t *testing.T) {
+	logger := slog.Default()
+	registry := NewRegistry(logger)
+
+	registry.RegisterFactory("mock", successFactory(&managerTestProvider{name: "ready", typeName: "mock"}))
+	registry.RegisterFactory("down", alwaysFailFactory())
+
+	manager := NewManager(registry, WithManagerLogger(logger))
+
+	ready := ProviderInstanceConfig{
+		Name:       "ready",
+		TypeName:   "mock",
+		RecordType: RecordTypeA,
+		Target:     "192.0.2.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	}
+	pending := ready
+	pending.Name = "pending"
+	pending.TypeName = "down"
+
+	if err := manager.AddProvider(ready); err != nil {
+		t.Fatalf("AddProvider(ready) unexpected error: %v", err)
+	}
+	if err := manager.AddProvider(pending); err != nil {
+		t.Fatalf("AddProvider(pending) unexpected error: %v", err)
+	}
+	if manager.ReadyCount() != 1 || manager.PendingCount() != 1 {
+		t.Fatalf("ready/pending = %d/%d, want 1/1", manager.ReadyCount(), manager.PendingCount())
+	}
+
+	// Names must be unique across ready and pending providers
+	if err := manager.AddProvider(ready); err == nil {
+		t.Error("AddProvider() expected error for existing ready provider")
+	}
+	if err := manager.AddProvider(pending); err == nil {
+		t.Error("AddProvider() expected error for existing pending provider")
+	}
+
+	if !manager.RemoveProvider("ready") || !manager.RemoveProvider("pending") {
+		t.Fatal("RemoveProvider() returned false for a configured provider")
+	}
+	if manager.TotalCount() != 0 {
+		t.Errorf("TotalCount() = %d after removal, want 0", manager.TotalCount())
+	}
+	if manager.RemoveProvider("ready") {
+		t.Error("RemoveProvider() returned true for an unknown provider")
+	}
+}
+
+func TestManager_RetryDiscardsRemovedProvider(t *testing.T) {
+	logger := slog.Default()
+	registry := NewRegistry(logger)
+	// Fail the initial attempt and the re-add, succeed on the stale retry
+	registry.RegisterFactory("mock", failingFactory(2, &managerTestProvider{name: "reloaded", typeName: "mock"}))
+
+	manager := NewManager(registry, WithManagerLogger(logger))
+	cfg := ProviderInstanceConfig{
+		Name:       "reloaded",
+		TypeName:   "mock",
+		RecordType: RecordTypeA,
+		Target:     "192.0.2.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	}
+	_ = manager.InitializeProvider(cfg)
+	stale := manager.pending[cfg.Name]
+
+	// A reload removes and re-adds the provider after the retry loop picked
+	// up the old pending entry
+	manager.RemoveProvider(cfg.Name)
+	if err := manager.AddProvider(cfg); err != nil {
+		t.Fatalf("AddProvider() unexpected error: %v", err)
+	}
+	current := manager.pending[cfg.Name]
+
+	manager.retryProvider(context.Background(), stale)
+
+	if manager.ReadyCount() != 0 {
+		t.Errorf("ReadyCount() = %d, want 0: instance of replaced entry kept", manager.ReadyCount())
+	}
+	if manager.pending[cfg.Name] != current || current.AttemptCount != 1 {
+		t.Errorf("pending entry = %+v, want the re-added entry untouched", manager.pending[cfg.Name])
+	}
+
+	// Once removed for good, a retry in flight leaves nothing behind
+	manager.RemoveProvider(cfg.Name)
+	manager.retryProvider(context.Background(), current)
+	if manager.TotalCount() != 0 {
+		t.Errorf("TotalCount() = %d after removal, want 0", manager.TotalCount())
+	}
+}
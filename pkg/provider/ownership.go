@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/metrics"
+)
+
+// OwnershipVersion is the ownership record format version written by this release.
+// Version 0 is the legacy value consisting only of OwnershipValue.
+const OwnershipVersion = 1
+
+// Ownership is the metadata stored in an ownership TXT record.
+// The record value is a comma-separated list of key=value pairs that always
+// starts with OwnershipValue, e.g. "heritage=dnsweaver,version=1,source=traefik".
+// Fields other than heritage are optional so new ones can be added later.
+type Ownership struct {
+	// Version is the format version; 0 for legacy records.
+	Version int
+
+	// Source is the name of the source that discovered the hostname, if known.
+	Source string
+}
+
+// String encodes the ownership metadata as a TXT record value.
+func (o Ownership) String() string {
+	var sb strings.Builder
+	sb.WriteString(OwnershipValue)
+	if o.Version > 0 {
+		sb.WriteString(",version=")
+		sb.WriteString(strconv.Itoa(o.Version))
+	}
+	if o.Source != "" {
+		sb.WriteString(",source=")
+		sb.WriteString(o.Source)
+	}
+	return sb.String()
+}
+
+// IsLegacy returns true for records written before ownership versioning.
+func (o Ownership) IsLegacy() bool {
+	return o.Version == 0
+}
+
+// ParseOwnership parses an ownership TXT record value.
+// Returns false if the value was not written by dnsweaver: the value must
+// carry heritage=dnsweaver and no other heritage, so markers written by other
+// tools under the same name (e.g. ExternalDNS's "heritage=external-dns") are
+// never treated as owned. Surrounding quotes are ignored for providers that
+// return TXT data quoted. Unknown fields are ignored so newer records remain
+// readable.
+func ParseOwnership(value string) (Ownership, bool) {
+	var o Ownership
+	owned := false
+	value = strings.Trim(strings.TrimSpace(value), `"`)
+	for _, field := range strings.Split(value, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(field), "=")
+		switch key {
+		case "heritage":
+			if val != "dnsweaver" {
+				return Ownership{}, false
+			}
+			owned = true
+		case "version":
+			if v, err := strconv.Atoi(val); err == nil && v > 0 {
+				o.Version = v
+			}
+		case "source":
+			o.Source = val
+		}
+	}
+	if !owned {
+		return Ownership{}, false
+	}
+	return o, true
+}
+
+// IsOwnershipValue returns true if a TXT record value marks dnsweaver ownership,
+// in either the legacy or the versioned format.
+func IsOwnershipValue(value string) bool {
+	_, ok := ParseOwnership(value)
+	return ok
+}
+
+// MigrateOwnershipRecords rewrites legacy ownership records ("heritage=dnsweaver")
+// to the current versioned format. The new record is created before the old one
+// is deleted so ownership is never lost. Returns the number of records migrated.
+func (pi *ProviderInstance) MigrateOwnershipRecords(ctx context.Context) (int, error) {
+	start := time.Now()
+	records, err := pi.Provider.List(ctx)
+	duration := time.Since(start).Seconds()
+
+	status := statusSuccess
+	if err != nil {
+		status = statusError
+	}
+	metrics.ProviderAPIRequestsTotal.WithLabelValues(pi.Name(), "list", status).Inc()
+	metrics.ProviderAPIDuration.WithLabelValues(pi.Name(), "list").Observe(duration)
+	pi.observe("list", duration, err)
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for _, r := range records {
+		if r.Type != RecordTypeTXT || !IsOwnershipRecord(r.Hostname) {
+			continue
+		}
+		o, ok := ParseOwnership(r.Target)
+		if !ok || !o.IsLegacy() {
+			continue
+		}
+
+		desired := r
+		desired.ProviderID = ""
+		desired.Target = Ownership{Version: OwnershipVersion}.String()
+		if err := pi.Provider.Create(ctx, desired); err != nil && !IsConflict(err) {
+			return migrated, err
+		}
+		if err := pi.Provider.Delete(ctx, r); err != nil && !IsNotFound(err) {
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+// recordingProvider records Create and Delete calls for testing.
+type recordingProvider struct {
+	mockProvider
+	created []Record
+	deleted []Record
+}
+
+func (p *recordingProvider) Create(ctx context.Context, r Record) error {
+	p.created = append(p.created, r)
+	return nil
+}
+
+func (p *recordingProvider) Delete(ctx context.Context, r Record) error {
+	p.deleted = append(p.deleted, r)
+	return nil
+}
+
+func TestParseOwnership(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantOwned bool
+		want      Ownership
+	}{
+		{"heritage=dnsweaver", true, Ownership{}},
+		{"heritage=dnsweaver,version=1", true, Ownership{Version: 1}},
+		{"heritage=dnsweaver,version=1,source=traefik", true, Ownership{Version: 1, Source: "traefik"}},
+		{"heritage=dnsweaver,version=2,source=nomad,container=abc", true, Ownership{Version: 2, Source: "nomad"}},
+		{"heritage=external-dns,external-dns/owner=default", false, Ownership{}},
+		{"heritage=dnsweaver,heritage=external-dns", false, Ownership{}},
+		{"heritage=external-dns,heritage=dnsweaver", false, Ownership{}},
+		{"heritage=dnsweaverx", false, Ownership{}},
+		{"owner=default", false, Ownership{}},
+		{`"heritage=dnsweaver,version=1"`, true, Ownership{Version: 1}},
+		{"v=spf1 -all", false, Ownership{}},
+		{"", false, Ownership{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := ParseOwnership(tt.value)
+			if ok != tt.wantOwned {
+				t.Fatalf("ParseOwnership(%q) owned = %v, want %v", tt.value, ok, tt.wantOwned)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOwnership(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+			if IsOwnershipValue(tt.value) != tt.wantOwned {
+				t.Errorf("IsOwnershipValue(%q) = %v, want %v", tt.value, !tt.wantOwned, tt.wantOwned)
+			}
+		})
+	}
+}
+
+func TestOwnership_String(t *testing.T) {
+	tests := []struct {
+		ownership Ownership
+		want      string
+	}{
+		{Ownership{}, "heritage=dnsweaver"},
+		{Ownership{Version: 1}, "heritage=dnsweaver,version=1"},
+		{Ownership{Version: 1, Source: "traefik"}, "heritage=dnsweaver,version=1,source=traefik"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.ownership.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.ownership, got, tt.want)
+		}
+		if parsed, _ := ParseOwnership(tt.want); parsed != tt.ownership {
+			t.Errorf("round trip of %q = %+v, want %+v", tt.want, parsed, tt.ownership)
+		}
+	}
+}
+
+func TestOwnershipRecord_CurrentFormat(t *testing.T) {
+	r := OwnershipRecord("app.example.com", 300, "static")
+	if r.Hostname != "_dnsweaver.app.example.com" || r.Type != RecordTypeTXT {
+		t.Errorf("OwnershipRecord() = %+v", r)
+	}
+	if r.Target != "heritage=dnsweaver,version=1,source=static" {
+		t.Errorf("Target = %q", r.Target)
+	}
+}
+
+func TestProviderInstance_MigrateOwnershipRecords(t *testing.T) {
+	p := &recordingProvider{mockProvider: mockProvider{
+		name: "test",
+		records: []Record{
+			{Hostname: "_dnsweaver.old.example.com", Type: RecordTypeTXT, Target: "heritage=dnsweaver", TTL: 300, ProviderID: "id-1"},
+			{Hostname: "_dnsweaver.new.example.com", Type: RecordTypeTXT, Target: "heritage=dnsweaver,version=1,source=traefik", TTL: 300},
+			{Hostname: "old.example.com", Type: RecordTypeA, Target: "10.0.0.1", TTL: 300},
+			{Hostname: "spf.example.com", Type: RecordTypeTXT, Target: "heritage=dnsweaver", TTL: 300},
+		},
+	}}
+	inst := &ProviderInstance{Provider: p, TTL: 300}
+
+	migrated, err := inst.MigrateOwnershipRecords(context.Background())
+	if err != nil {
+		t.Fatalf("MigrateOwnershipRecords() error = %v", err)
+	}
+	if migrated != 1 {
+		t.Fatalf("migrated = %d, want 1", migrated)
+	}
+
+	if len(p.created) != 1 || p.created[0].Target != "heritage=dnsweaver,version=1" || p.created[0].ProviderID != "" {
+		t.Errorf("created = %+v", p.created)
+	}
+	if len(p.deleted) != 1 || p.deleted[0].Target != "heritage=dnsweaver" || p.deleted[0].ProviderID != "id-1" {
+		t.Errorf("deleted = %+v", p.deleted)
+	}
+}
+
+func TestProviderInstance_DeleteOwnershipRecord(t *testing.T) {
+	p := &recordingProvider{mockProvider: mockProvider{
+		name: "test",
+		records: []Record{
+			{Hostname: "_dnsweaver.app.example.com", Type: RecordTypeTXT, Target: "heritage=dnsweaver"},
+			{Hostname: "_dnsweaver.app.example.com", Type: RecordTypeTXT, Target: "heritage=dnsweaver,version=1,source=traefik"},
+			{Hostname: "_dnsweaver.other.example.com", Type: RecordTypeTXT, Target: "heritage=dnsweaver"},
+		},
+	}}
+	inst := &ProviderInstance{Provider: p, TTL: 300}
+
+	if err := inst.DeleteOwnershipRecord(context.Background(), "app.example.com"); err != nil {
+		t.Fatalf("DeleteOwnershipRecord() error = %v", err)
+	}
+	if len(p.deleted) != 2 {
+		t.Fatalf("deleted %d records, want 2: %+v", len(p.deleted), p.deleted)
+	}
+	for _, r := range p.deleted {
+		if r.Hostname != "_dnsweaver.app.example.com" {
+			t.Errorf("deleted unrelated record %+v", r)
+		}
+	}
+}
+
+func TestProviderInstance_ForeignOwnershipRecords(t *testing.T) {
+	p := &recordingProvider{mockProvider: mockProvider{
+		name: "test",
+		records: []Record{
+			{Hostname: "_dnsweaver.app.example.com", Type: RecordTypeTXT, Target: "heritage=external-dns,external-dns/owner=default"},
+			{Hostname: "_dnsweaver.api.example.com", Type: RecordTypeTXT, Target: "heritage=dnsweaver,version=1"},
+		},
+	}}
+	inst := &ProviderInstance{Provider: p, TTL: 300}
+	ctx := context.Background()
+
+	owned, err := inst.HasOwnershipRecord(ctx, "app.example.com")
+	if err != nil {
+		t.Fatalf("HasOwnershipRecord() error = %v", err)
+	}
+	if owned {
+		t.Error("HasOwnershipRecord() = true for a record written by another tool")
+	}
+
+	hostnames, err := inst.RecoverOwnedHostnames(ctx)
+	if err != nil {
+		t.Fatalf("RecoverOwnedHostnames() error = %v", err)
+	}
+	if len(hostnames) != 1 || hostnames[0] != "api.example.com" {
+		t.Errorf("RecoverOwnedHostnames() = %v, want only api.example.com", hostnames)
+	}
+
+	if err := inst.DeleteOwnershipRecord(ctx, "app.example.com"); err != nil {
+		t.Fatalf("DeleteOwnershipRecord() error = %v", err)
+	}
+	if len(p.deleted) != 0 {
+		t.Errorf("DeleteOwnershipRecord() deleted %+v, want the foreign marker left alone", p.deleted)
+	}
+}
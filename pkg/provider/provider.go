@@ -12,12 +12,14 @@ const (
 	RecordTypeCNAME RecordType = "CNAME"
 	RecordTypeTXT   RecordType = "TXT"
 	RecordTypeSRV   RecordType = "SRV"
+	RecordTypeMX    RecordType = "MX" // Target is "<preference> <exchange>"
 )
 
 // OwnershipPrefix is the default prefix for ownership TXT records.
 const OwnershipPrefix = "_dnsweaver"
 
-// OwnershipValue is the content of ownership TXT records.
+// OwnershipValue is the leading field of ownership TXT records, and the whole
+// value of legacy (unversioned) records. See Ownership for the full format.
 const OwnershipValue = "heritage=dnsweaver"
 
 // SRVData contains SRV record-specific fields.
@@ -28,6 +30,12 @@ type SRVData struct {
 	Port     uint16 // TCP/UDP port number (1-65535)
 }
 
+// WeightedData contains weighted routing fields for providers that can split
+// traffic between several records of the same name (e.g., Route53, NS1).
+type WeightedData struct {
+	Weight uint16 // Relative share of traffic among records for the hostname (0-65535)
+}
+
 // Record represents a DNS record to be managed.
 type Record struct {
 	Hostname   string
@@ -36,6 +44,15 @@ type Record struct {
 	TTL        int
 	ProviderID string   // Provider-specific record identifier
 	SRV        *SRVData // SRV-specific data (only set when Type is SRV)
+
+	// Weighted holds the routing weight for providers that support weighted
+	// records (see Capabilities.SupportsWeightedRecords). Nil means unweighted.
+	Weighted *WeightedData
+
+	// Hints holds provider-specific settings from source labels, keyed by
+	// "<provider type>.<setting>" (e.g., "cloudflare.proxied"). Only set on
+	// records being created or updated; providers ignore unknown keys.
+	Hints map[string]string
 }
 
 // Capabilities describes a provider's feature support.
@@ -50,6 +67,15 @@ type Capabilities struct {
 	// also implement the Updater interface.
 	SupportsNativeUpdate bool
 
+	// SupportsMultipleTargets indicates if the provider can hold several records
+	// of the same type for one hostname (e.g., round-robin A records). If false,
+	// the reconciler only uses the first of several configured targets.
+	SupportsMultipleTargets bool
+
+	// SupportsWeightedRecords indicates if the provider honors Record.Weighted
+	// for weighted routing. If false, the reconciler never sets it.
+	SupportsWeightedRecords bool
+
 	// SupportedRecordTypes lists the DNS record types this provider can manage.
 	// Used to filter operations in authoritative mode and validate requested records.
 	SupportedRecordTypes []RecordType
@@ -111,6 +137,27 @@ type Updater interface {
 	Update(ctx context.Context, existing, desired Record) error
 }
 
+// BulkCreator is an optional interface that providers can implement to create
+// many records in one operation, such as a single batch API request or file
+// rewrite. This makes the initial sync of a fresh install much faster than
+// one Create call per record.
+//
+// The reconciler uses BulkCreate when a provider holds no records at the start
+// of a reconciliation. If it returns an error, the reconciler falls back to
+// creating the records one at a time, so implementations need not roll back
+// records that were created before the failure.
+type BulkCreator interface {
+	// BulkCreate creates all the given records.
+	BulkCreate(ctx context.Context, records []Record) error
+}
+
+// Zoned is an optional interface for providers bound to a single DNS zone.
+// The zone is used to label record metrics and reconciliation actions.
+type Zoned interface {
+	// Zone returns the configured zone (e.g., "example.com"), or "" if none.
+	Zone() string
+}
+
 // RecordEquals returns true if two records are logically equal.
 // Provider-specific IDs are not compared.
 func RecordEquals(a, b Record) bool {
@@ -156,12 +203,13 @@ func ExtractHostnameFromOwnership(ownershipName string) string {
 	return ownershipName[len(OwnershipPrefix)+1:]
 }
 
-// OwnershipRecord creates a TXT record for ownership tracking.
-func OwnershipRecord(hostname string, ttl int) Record {
+// OwnershipRecord creates a TXT record for ownership tracking in the current
+// format. sourceName records which source discovered the hostname and may be empty.
+func OwnershipRecord(hostname string, ttl int, sourceName string) Record {
 	return Record{
 		Hostname: OwnershipRecordName(hostname),
 		Type:     RecordTypeTXT,
-		Target:   OwnershipValue,
+		Target:   Ownership{Version: OwnershipVersion, Source: sourceName}.String(),
 		TTL:      ttl,
 	}
 }
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/metrics"
+)
+
+// DefaultRateLimitQueue is the default number of operations that may wait
+// for the rate limiter before new operations are rejected.
+const DefaultRateLimitQueue = 100
+
+// InstanceOption is a functional option for Registry.CreateInstance.
+type InstanceOption func(*instanceOptions)
+
+// instanceOptions holds optional behavior applied when creating an instance.
+type instanceOptions struct {
+	rateLimit               time.Duration
+	rateLimitQueue          int
+	circuitBreakerThreshold int
+	circuitBreakerCooldown  time.Duration
+}
+
+// WithRateLimit limits List, Create, Delete, and Update calls to one operation
+// per interval (token bucket with a burst of one second's worth of operations).
+// Operations over the limit wait in a queue instead of failing.
+// An interval of zero disables rate limiting.
+func WithRateLimit(interval time.Duration) InstanceOption {
+	return func(o *instanceOptions) {
+		o.rateLimit = interval
+	}
+}
+
+// WithRateLimitQueue sets how many operations may wait for the rate limiter.
+// Operations beyond this depth fail with ErrRateLimitQueueFull.
+func WithRateLimitQueue(depth int) InstanceOption {
+	return func(o *instanceOptions) {
+		o.rateLimitQueue = depth
+	}
+}
+
+// ParseRateLimit parses a rate limit such as "10/s", "600/m", or "5" (per second)
+// and returns the interval between operations.
+func ParseRateLimit(s string) (time.Duration, error) {
+	count, unit, found := strings.Cut(strings.TrimSpace(s), "/")
+	per := time.Second
+	if found {
+		switch unit {
+		case "s":
+			per = time.Second
+		case "m":
+			per = time.Minute
+		case "h":
+			per = time.Hour
+		default:
+			return 0, fmt.Errorf("invalid rate limit %q: unit must be s, m, or h", s)
+		}
+	}
+
+	n, err := strconv.Atoi(count)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid rate limit %q: must be a positive number of operations", s)
+	}
+
+	return per / time.Duration(n), nil
+}
+
+// rateLimiter is a token bucket limiter with a bounded wait queue.
+type rateLimiter struct {
+	name     string
+	limiter  *rate.Limiter
+	maxQueue int64
+	queued   atomic.Int64
+	logger   *slog.Logger
+}
+
+// newRateLimiter creates a limiter allowing one operation per interval.
+func newRateLimiter(name string, interval time.Duration, maxQueue int, logger *slog.Logger) *rateLimiter {
+	burst := int(time.Second / interval)
+	if burst < 1 {
+		burst = 1
+	}
+	if maxQueue <= 0 {
+		maxQueue = DefaultRateLimitQueue
+	}
+	return &rateLimiter{
+		name:     name,
+		limiter:  rate.NewLimiter(rate.Every(interval), burst),
+		maxQueue: int64(maxQueue),
+		logger:   logger,
+	}
+}
+
+// wait blocks until the operation may proceed.
+// Returns ErrRateLimitQueueFull if too many operations are already waiting,
+// or the context error if ctx is cancelled while waiting.
+func (l *rateLimiter) wait(ctx context.Context, operation string) error {
+	if l.limiter.Allow() {
+		return nil
+	}
+
+	queued := l.queued.Add(1)
+	defer l.queued.Add(-1)
+
+	if queued > l.maxQueue {
+		return fmt.Errorf("%s %s: %w", l.name, operation, ErrRateLimitQueueFull)
+	}
+
+	metrics.ProviderRateLimitQueuedTotal.WithLabelValues(l.name, operation).Inc()
+	if queued == 1 {
+		l.logger.Warn("provider rate limit exceeded, queueing operations",
+			slog.String("provider", l.name),
+			slog.String("operation", operation),
+		)
+	}
+
+	return l.limiter.Wait(ctx)
+}
+
+// rateLimitedProvider wraps a Provider so List, Create, and Delete respect a rate limit.
+type rateLimitedProvider struct {
+	Provider
+	limiter *rateLimiter
+}
+
+// rateLimitedUpdater is a rateLimitedProvider for providers implementing Updater.
+type rateLimitedUpdater struct {
+	*rateLimitedProvider
+	updater Updater
+}
+
+// newRateLimitedProvider wraps p with a rate limiter, preserving the Updater interface.
+func newRateLimitedProvider(p Provider, interval time.Duration, maxQueue int, logger *slog.Logger) Provider {
+	rl := &rateLimitedProvider{
+		Provider: p,
+		limiter:  newRateLimiter(p.Name(), interval, maxQueue, logger),
+	}
+	if updater, ok := p.(Updater); ok {
+		return &rateLimitedUpdater{rateLimitedProvider: rl, updater: updater}
+	}
+	return rl
+}
+
+// Unwrap returns the provider wrapped by the rate limiter.
+func (p *rateLimitedProvider) Unwrap() Provider {
+	return p.Provider
+}
+
+// guard waits for the rate limiter, then runs op.
+// Used for operations outside the Provider interface, such as BulkCreate.
+func (p *rateLimitedProvider) guard(ctx context.Context, operation string, op func() error) error {
+	if err := p.limiter.wait(ctx, operation); err != nil {
+		return err
+	}
+	return op()
+}
+
+// List waits for the rate limiter, then lists records.
+func (p *rateLimitedProvider) List(ctx context.Context) ([]Record, error) {
+	if err := p.limiter.wait(ctx, "list"); err != nil {
+		return nil, err
+	}
+	return p.Provider.List(ctx)
+}
+
+// Create waits for the rate limiter, then creates the record.
+func (p *rateLimitedProvider) Create(ctx context.Context, record Record) error {
+	if err := p.limiter.wait(ctx, "create"); err != nil {
+		return err
+	}
+	return p.Provider.Create(ctx, record)
+}
+
+// Delete waits for the rate limiter, then deletes the record.
+func (p *rateLimitedProvider) Delete(ctx context.Context, record Record) error {
+	if err := p.limiter.wait(ctx, "delete"); err != nil {
+		return err
+	}
+	return p.Provider.Delete(ctx, record)
+}
+
+// Update waits for the rate limiter, then updates the record in place.
+func (p *rateLimitedUpdater) Update(ctx context.Context, existing, desired Record) error {
+	if err := p.limiter.wait(ctx, "update"); err != nil {
+		return err
+	}
+	return p.updater.Update(ctx, existing, desired)
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// updaterMockProvider is a mockProvider that also implements Updater.
+type updaterMockProvider struct {
+	mockProvider
+}
+
+func (m *updaterMockProvider) Update(ctx context.Context, existing, desired Record) error {
+	return nil
+}
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"10/s", 100 * time.Millisecond, false},
+		{"5", 200 * time.Millisecond, false},
+		{"60/m", time.Second, false},
+		{"3600/h", time.Second, false},
+		{"0/s", 0, true},
+		{"-1/s", 0, true},
+		{"ten/s", 0, true},
+		{"10/d", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRateLimit(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRateLimit(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRateLimit(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_QueuesOperations(t *testing.T) {
+	l := newRateLimiter("test", 20*time.Millisecond, 10, testLogger())
+	l.limiter.SetBurst(1)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.wait(ctx, "create"); err != nil {
+			t.Fatalf("wait() error = %v", err)
+		}
+	}
+
+	// With a burst of 1, three operations must wait ~20ms each
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected operations to be delayed, took %v", elapsed)
+	}
+}
+
+func TestRateLimiter_QueueFull(t *testing.T) {
+	l := newRateLimiter("test", time.Hour, 1, testLogger())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Consume the only token
+	if err := l.wait(ctx, "create"); err != nil {
+		t.Fatalf("wait() error = %v", err)
+	}
+
+	// First queued operation blocks until cancelled
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = l.wait(ctx, "create")
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for l.queued.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := l.wait(ctx, "delete"); !errors.Is(err, ErrRateLimitQueueFull) {
+		t.Errorf("wait() error = %v, want ErrRateLimitQueueFull", err)
+	}
+
+	cancel()
+	wg.Wait()
+}
+
+func TestNewRateLimitedProvider_PreservesUpdater(t *testing.T) {
+	plain := newRateLimitedProvider(&mockProvider{name: "plain"}, time.Millisecond, 0, testLogger())
+	if _, ok := plain.(Updater); ok {
+		t.Error("expected wrapper of non-Updater provider not to implement Updater")
+	}
+
+	updating := newRateLimitedProvider(&updaterMockProvider{mockProvider{name: "updater"}}, time.Millisecond, 0, testLogger())
+	updater, ok := updating.(Updater)
+	if !ok {
+		t.Fatal("expected wrapper of Updater provider to implement Updater")
+	}
+	if err := updater.Update(context.Background(), Record{}, Record{}); err != nil {
+		t.Errorf("Update() error = %v", err)
+	}
+	if updating.Name() != "updater" {
+		t.Errorf("Name() = %q, want %q", updating.Name(), "updater")
+	}
+}
+
+func TestRegistry_CreateInstance_RateLimit(t *testing.T) {
+	r := NewRegistry(testLogger())
+	r.RegisterFactory("test", func(cfg FactoryConfig) (Provider, error) {
+		return &mockProvider{name: cfg.Name, typeName: "test"}, nil
+	})
+
+	err := r.CreateInstance(ProviderInstanceConfig{
+		Name:       "limited",
+		TypeName:   "test",
+		RecordType: RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	}, WithRateLimit(100*time.Millisecond), WithRateLimitQueue(5))
+	if err != nil {
+		t.Fatalf("CreateInstance failed: %v", err)
+	}
+
+	inst, _ := r.Get("limited")
+	rl, ok := inst.Provider.(*rateLimitedProvider)
+	if !ok {
+		t.Fatalf("expected rate limited provider, got %T", inst.Provider)
+	}
+	if rl.limiter.maxQueue != 5 {
+		t.Errorf("maxQueue = %d, want 5", rl.limiter.maxQueue)
+	}
+}
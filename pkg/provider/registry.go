@@ -3,14 +3,26 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
+	"sort"
 	"sync"
 	"time"
 
 	"gitlab.bluewillows.net/root/dnsweaver/internal/matcher"
 )
 
+// ErrNoMatchingProvider is returned by MatchingProvidersWithFallback when no
+// instance matches the hostname.
+var ErrNoMatchingProvider = errors.New("no matching provider")
+
+// ErrInstanceNotFound is returned by RemoveInstance when no instance has the
+// given name.
+var ErrInstanceNotFound = errors.New("provider instance not found")
+
 // HTTPConfig contains HTTP client configuration passed from the framework to providers.
 // This allows centralized HTTP settings (timeouts, TLS, user-agent) to be applied
 // consistently across all HTTP-based providers.
@@ -38,8 +50,28 @@ type FactoryConfig struct {
 	// ProviderConfig contains provider-specific key-value configuration.
 	ProviderConfig map[string]string
 
+	// RecordType is the default record type the instance will create.
+	// Providers may use it to warn about unsupported combinations.
+	RecordType RecordType
+
+	// Domains contains the include patterns the instance is responsible for.
+	Domains []string
+
 	// HTTP contains shared HTTP client configuration.
 	HTTP HTTPConfig
+
+	// Lookup resolves another registered provider instance by name.
+	// Meta-providers use it to reference their backing instances. It must
+	// not be called before the factory returns; resolve lazily instead.
+	Lookup func(name string) (Provider, bool)
+}
+
+// Delegator is implemented by meta-providers that forward operations to
+// other registered instances. Delegate instances are excluded from hostname
+// matching so records are only written through the meta-provider.
+type Delegator interface {
+	// Delegates returns the names of the instances this provider forwards to.
+	Delegates() []string
 }
 
 // Factory is a function that creates a new provider instance from configuration.
@@ -75,11 +107,37 @@ func (r *Registry) RegisterFactory(typeName string, factory Factory) {
 	r.logger.Debug("registered provider factory", slog.String("type", typeName))
 }
 
+// Types returns the registered provider type names in sorted order.
+func (r *Registry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types
+}
+
 // CreateInstance creates and registers a provider instance from configuration.
-func (r *Registry) CreateInstance(cfg ProviderInstanceConfig) error {
+// Rate limit and circuit breaker settings in cfg are applied as if
+// WithRateLimit and WithCircuitBreaker had been passed; explicit options
+// take precedence.
+func (r *Registry) CreateInstance(cfg ProviderInstanceConfig, opts ...InstanceOption) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	options := instanceOptions{
+		rateLimit:               cfg.RateLimit,
+		rateLimitQueue:          cfg.RateLimitQueue,
+		circuitBreakerThreshold: cfg.CircuitBreakerThreshold,
+		circuitBreakerCooldown:  cfg.CircuitBreakerCooldown,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid configuration for provider %q: %w", cfg.Name, err)
@@ -100,10 +158,13 @@ func (r *Registry) CreateInstance(cfg ProviderInstanceConfig) error {
 	factoryCfg := FactoryConfig{
 		Name:           cfg.Name,
 		ProviderConfig: cfg.ProviderConfig,
+		RecordType:     cfg.RecordType,
+		Domains:        cfg.Domains,
 		HTTP: HTTPConfig{
 			// TODO: These will be populated from GlobalConfig in a future phase
 			Logger: r.logger,
 		},
+		Lookup: r.lookupProvider,
 	}
 
 	// Create the underlying provider
@@ -112,6 +173,20 @@ func (r *Registry) CreateInstance(cfg ProviderInstanceConfig) error {
 		return fmt.Errorf("creating provider %s: %w", cfg.Name, err)
 	}
 
+	if options.rateLimit > 0 {
+		provider = newRateLimitedProvider(provider, options.rateLimit, options.rateLimitQueue, r.logger)
+		r.logger.Info("provider rate limit enabled",
+			slog.String("name", cfg.Name),
+			slog.Duration("interval", options.rateLimit),
+		)
+	}
+
+	// The breaker wraps the rate limiter so an open circuit fails fast
+	// instead of waiting in the rate limit queue.
+	if options.circuitBreakerThreshold > 0 {
+		provider = newCircuitBreakerProvider(provider, options.circuitBreakerThreshold, options.circuitBreakerCooldown, r.logger)
+	}
+
 	// Create domain matcher
 	matcherCfg := matcher.DomainMatcherConfig{
 		Includes: cfg.GetIncludes(),
@@ -131,6 +206,14 @@ func (r *Registry) CreateInstance(cfg ProviderInstanceConfig) error {
 		Target:     cfg.Target,
 		TTL:        cfg.TTL,
 		Mode:       cfg.Mode,
+		Priority:   cfg.Priority,
+
+		domains:        cfg.GetIncludes(),
+		excludeDomains: cfg.GetExcludes(),
+		settings:       maps.Clone(cfg.ProviderConfig),
+	}
+	if len(cfg.Targets) > 1 {
+		instance.Targets = cfg.Targets
 	}
 
 	// Default to managed mode if not set
@@ -138,7 +221,7 @@ func (r *Registry) CreateInstance(cfg ProviderInstanceConfig) error {
 		instance.Mode = ModeManaged
 	}
 
-	r.instances = append(r.instances, instance)
+	r.insertByPriority(instance)
 	r.byName[cfg.Name] = instance
 
 	r.logger.Info("created provider instance",
@@ -147,11 +230,25 @@ func (r *Registry) CreateInstance(cfg ProviderInstanceConfig) error {
 		slog.String("record_type", string(cfg.RecordType)),
 		slog.String("target", cfg.Target),
 		slog.String("mode", string(instance.Mode)),
+		slog.Int("priority", instance.Priority),
 	)
 
 	return nil
 }
 
+// insertByPriority adds inst after every instance with the same or a lower
+// priority value, so equal priorities keep registration order.
+// Caller must hold r.mu.
+func (r *Registry) insertByPriority(inst *ProviderInstance) {
+	i := len(r.instances)
+	for i > 0 && r.instances[i-1].Priority > inst.Priority {
+		i--
+	}
+	r.instances = append(r.instances, nil)
+	copy(r.instances[i+1:], r.instances[i:])
+	r.instances[i] = inst
+}
+
 // Get returns a provider instance by name.
 func (r *Registry) Get(name string) (*ProviderInstance, bool) {
 	r.mu.RLock()
@@ -160,6 +257,34 @@ func (r *Registry) Get(name string) (*ProviderInstance, bool) {
 	return p, ok
 }
 
+// lookupProvider returns the underlying provider of a registered instance.
+func (r *Registry) lookupProvider(name string) (Provider, bool) {
+	inst, ok := r.Get(name)
+	if !ok {
+		return nil, false
+	}
+	return inst.Provider, true
+}
+
+// delegated returns the names of instances owned by a Delegator.
+// Caller must hold r.mu.
+func (r *Registry) delegated() map[string]struct{} {
+	var names map[string]struct{}
+	for _, inst := range r.instances {
+		d, ok := unwrapProvider(inst.Provider).(Delegator)
+		if !ok {
+			continue
+		}
+		if names == nil {
+			names = make(map[string]struct{})
+		}
+		for _, name := range d.Delegates() {
+			names[name] = struct{}{}
+		}
+	}
+	return names
+}
+
 // All returns all provider instances in priority order.
 func (r *Registry) All() []*ProviderInstance {
 	r.mu.RLock()
@@ -171,14 +296,19 @@ func (r *Registry) All() []*ProviderInstance {
 	return result
 }
 
-// MatchingProviders returns all provider instances that match the given hostname.
-// The order matches the priority order from DNSWEAVER_INSTANCES.
+// MatchingProviders returns all provider instances that match the given hostname,
+// sorted by Priority ascending. Instances with equal priority keep the order
+// from DNSWEAVER_INSTANCES. Instances that back a Delegator are never returned.
 func (r *Registry) MatchingProviders(hostname string) []*ProviderInstance {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	delegated := r.delegated()
 	var matches []*ProviderInstance
 	for _, inst := range r.instances {
+		if _, skip := delegated[inst.Name()]; skip {
+			continue
+		}
 		if inst.Matches(hostname) {
 			matches = append(matches, inst)
 		}
@@ -187,13 +317,17 @@ func (r *Registry) MatchingProviders(hostname string) []*ProviderInstance {
 	return matches
 }
 
-// FirstMatchingProvider returns the first provider instance that matches the hostname.
-// Returns nil if no provider matches.
+// FirstMatchingProvider returns the highest-priority provider instance that
+// matches the hostname. Returns nil if no provider matches.
 func (r *Registry) FirstMatchingProvider(hostname string) *ProviderInstance {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	delegated := r.delegated()
 	for _, inst := range r.instances {
+		if _, skip := delegated[inst.Name()]; skip {
+			continue
+		}
 		if inst.Matches(hostname) {
 			return inst
 		}
@@ -202,6 +336,45 @@ func (r *Registry) FirstMatchingProvider(hostname string) *ProviderInstance {
 	return nil
 }
 
+// MatchingProvidersWithFallback calls fn with the matching provider instances
+// in priority order until one succeeds, and returns that instance. Unlike
+// MatchingProviders, which hands every match to the caller for parallel
+// writes, lower-priority instances are only used when the ones before them
+// fail.
+//
+// When every attempt fails the returned error joins the error of each
+// instance. A context cancellation or deadline stops the fallback
+// immediately. ErrNoMatchingProvider is returned when no instance matches.
+func (r *Registry) MatchingProvidersWithFallback(hostname string, fn func(*ProviderInstance) error) (*ProviderInstance, error) {
+	matches := r.MatchingProviders(hostname)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatchingProvider, hostname)
+	}
+
+	var errs []error
+	for i, inst := range matches {
+		err := fn(inst)
+		if err == nil {
+			return inst, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", inst.Name(), err))
+
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			break
+		}
+		if i < len(matches)-1 {
+			r.logger.Warn("provider failed, falling back to next priority",
+				slog.String("hostname", hostname),
+				slog.String("provider", inst.Name()),
+				slog.String("next", matches[i+1].Name()),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil, errors.Join(errs...)
+}
+
 // PingAll checks connectivity to all provider instances.
 // Returns a map of instance name to error (nil if healthy).
 func (r *Registry) PingAll(ctx context.Context) map[string]error {
@@ -227,13 +400,38 @@ func (r *Registry) PingAll(ctx context.Context) map[string]error {
 
 // Remove removes a provider instance by name.
 // Returns true if the provider was found and removed, false otherwise.
+// Unlike RemoveInstance, the provider is not closed.
 func (r *Registry) Remove(name string) bool {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	_, ok := r.removeLocked(name)
+	return ok
+}
+
+// RemoveInstance removes a provider instance by name and closes its provider
+// if it implements io.Closer. The instance is removed even if closing fails.
+// Returns an error wrapping ErrInstanceNotFound if no instance has the name.
+func (r *Registry) RemoveInstance(name string) error {
+	r.mu.Lock()
+	inst, ok := r.removeLocked(name)
+	r.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrInstanceNotFound, name)
+	}
+
+	if err := closeProvider(inst.Provider); err != nil {
+		return fmt.Errorf("closing provider %s: %w", name, err)
+	}
+	return nil
+}
+
+// removeLocked removes the named instance from the registry and returns it.
+// Caller must hold r.mu.
+func (r *Registry) removeLocked(name string) (*ProviderInstance, bool) {
 	inst, ok := r.byName[name]
 	if !ok {
-		return false
+		return nil, false
 	}
 
 	// Remove from byName map
@@ -248,20 +446,30 @@ func (r *Registry) Remove(name string) bool {
 	}
 
 	r.logger.Debug("removed provider instance", slog.String("name", name))
-	return true
+	return inst, true
+}
+
+// closeProvider closes the provider beneath any rate limiter or circuit
+// breaker wrappers if it implements io.Closer.
+func closeProvider(p Provider) error {
+	if c, ok := unwrapProvider(p).(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
 }
 
-// Close cleanly shuts down all provider instances.
-// This allows providers to release resources if needed.
+// Close cleanly shuts down all provider instances, closing every provider
+// that implements io.Closer. Returns the first close error.
 func (r *Registry) Close() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	var firstErr error
 	for _, inst := range r.instances {
-		// Providers may implement a Close method in the future
-		// For now, just clear the registry
 		r.logger.Debug("closing provider instance", slog.String("name", inst.Name()))
+		if err := closeProvider(inst.Provider); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("closing provider %s: %w", inst.Name(), err)
+		}
 	}
 
 	r.instances = nil
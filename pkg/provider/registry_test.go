@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
+	"time"
 )
 
 // mockProvider implements Provider for testing.
@@ -62,6 +65,18 @@ func TestRegistry_RegisterFactory(t *testing.T) {
 	}
 }
 
+func TestRegistry_Types(t *testing.T) {
+	r := NewRegistry(testLogger())
+	factory := func(cfg FactoryConfig) (Provider, error) { return &mockProvider{name: cfg.Name}, nil }
+	r.RegisterFactory("webhook", factory)
+	r.RegisterFactory("cloudflare", factory)
+
+	got := r.Types()
+	if len(got) != 2 || got[0] != "cloudflare" || got[1] != "webhook" {
+		t.Errorf("Types() = %v, want [cloudflare webhook]", got)
+	}
+}
+
 func TestRegistry_CreateInstance_UnknownType(t *testing.T) {
 	r := NewRegistry(testLogger())
 
@@ -240,6 +255,62 @@ func TestRegistry_FirstMatchingProvider(t *testing.T) {
 	}
 }
 
+// delegatingProvider implements Delegator for testing.
+type delegatingProvider struct {
+	mockProvider
+	delegates []string
+}
+
+func (d *delegatingProvider) Delegates() []string { return d.delegates }
+
+func TestRegistry_DelegatesExcludedFromMatching(t *testing.T) {
+	r := NewRegistry(testLogger())
+	r.RegisterFactory("test", func(cfg FactoryConfig) (Provider, error) {
+		return &mockProvider{name: cfg.Name, typeName: "test"}, nil
+	})
+
+	var lookup func(string) (Provider, bool)
+	r.RegisterFactory("meta", func(cfg FactoryConfig) (Provider, error) {
+		lookup = cfg.Lookup
+		return &delegatingProvider{
+			mockProvider: mockProvider{name: cfg.Name, typeName: "meta"},
+			delegates:    []string{"backing"},
+		}, nil
+	})
+
+	for _, cfg := range []ProviderInstanceConfig{
+		{Name: "backing", TypeName: "test"},
+		{Name: "front", TypeName: "meta"},
+	} {
+		cfg.RecordType = RecordTypeA
+		cfg.Target = "10.0.0.1"
+		cfg.TTL = 300
+		cfg.Domains = []string{"*.example.com"}
+		if err := r.CreateInstance(cfg); err != nil {
+			t.Fatalf("create %s failed: %v", cfg.Name, err)
+		}
+	}
+
+	matches := r.MatchingProviders("app.example.com")
+	if len(matches) != 1 || matches[0].Name() != "front" {
+		t.Fatalf("MatchingProviders() = %v, want only front", matches)
+	}
+	if p := r.FirstMatchingProvider("app.example.com"); p == nil || p.Name() != "front" {
+		t.Errorf("FirstMatchingProvider() = %v, want front", p)
+	}
+	if r.Count() != 2 {
+		t.Errorf("Count() = %d, want 2", r.Count())
+	}
+
+	p, ok := lookup("backing")
+	if !ok || p.Name() != "backing" {
+		t.Errorf("Lookup(backing) = %v, %v", p, ok)
+	}
+	if _, ok := lookup("missing"); ok {
+		t.Error("Lookup(missing) should fail")
+	}
+}
+
 func TestRegistry_All_PreservesOrder(t *testing.T) {
 	r := NewRegistry(testLogger())
 	r.RegisterFactory("test", func(cfg FactoryConfig) (Provider, error) {
@@ -273,6 +344,162 @@ func TestRegistry_All_PreservesOrder(t *testing.T) {
 	}
 }
 
+func TestRegistry_MatchingProviders_Priority(t *testing.T) {
+	r := NewRegistry(testLogger())
+	r.RegisterFactory("test", func(cfg FactoryConfig) (Provider, error) {
+		return &mockProvider{name: cfg.Name, typeName: "test"}, nil
+	})
+
+	for _, inst := range []struct {
+		name     string
+		priority int
+	}{
+		{"secondary", 200},
+		{"default-a", DefaultPriority},
+		{"primary", 10},
+		{"default-b", DefaultPriority},
+	} {
+		err := r.CreateInstance(ProviderInstanceConfig{
+			Name:       inst.name,
+			TypeName:   "test",
+			RecordType: RecordTypeA,
+			Target:     "10.0.0.1",
+			TTL:        300,
+			Priority:   inst.priority,
+			Domains:    []string{"*.example.com"},
+		})
+		if err != nil {
+			t.Fatalf("create %s failed: %v", inst.name, err)
+		}
+	}
+
+	want := []string{"primary", "default-a", "default-b", "secondary"}
+	matches := r.MatchingProviders("app.example.com")
+	if len(matches) != len(want) {
+		t.Fatalf("MatchingProviders() = %d matches, want %d", len(matches), len(want))
+	}
+	for i, name := range want {
+		if matches[i].Name() != name {
+			t.Errorf("MatchingProviders()[%d] = %q, want %q", i, matches[i].Name(), name)
+		}
+	}
+
+	if first := r.FirstMatchingProvider("app.example.com"); first == nil || first.Name() != "primary" {
+		t.Errorf("FirstMatchingProvider() = %v, want primary", first)
+	}
+
+	if err := r.CreateInstance(ProviderInstanceConfig{
+		Name:       "negative",
+		TypeName:   "test",
+		RecordType: RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Priority:   -1,
+		Domains:    []string{"*.example.com"},
+	}); err == nil {
+		t.Error("expected error for negative priority")
+	}
+}
+
+func TestRegistry_MatchingProvidersWithFallback(t *testing.T) {
+	r := NewRegistry(testLogger())
+	r.RegisterFactory("test", func(cfg FactoryConfig) (Provider, error) {
+		return &mockProvider{name: cfg.Name, typeName: "test"}, nil
+	})
+	for _, inst := range []struct {
+		name     string
+		priority int
+	}{
+		{"secondary", 200},
+		{"primary", 10},
+		{"tertiary", 300},
+	} {
+		if err := r.CreateInstance(ProviderInstanceConfig{
+			Name:       inst.name,
+			TypeName:   "test",
+			RecordType: RecordTypeA,
+			Target:     "10.0.0.1",
+			TTL:        300,
+			Priority:   inst.priority,
+			Domains:    []string{"*.example.com"},
+		}); err != nil {
+			t.Fatalf("create %s failed: %v", inst.name, err)
+		}
+	}
+
+	t.Run("primary succeeds", func(t *testing.T) {
+		var tried []string
+		inst, err := r.MatchingProvidersWithFallback("app.example.com", func(pi *ProviderInstance) error {
+			tried = append(tried, pi.Name())
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if inst.Name() != "primary" || len(tried) != 1 {
+			t.Errorf("used %q after trying %v, want primary only", inst.Name(), tried)
+		}
+	})
+
+	t.Run("falls back in priority order", func(t *testing.T) {
+		var tried []string
+		inst, err := r.MatchingProvidersWithFallback("app.example.com", func(pi *ProviderInstance) error {
+			tried = append(tried, pi.Name())
+			if pi.Name() == "primary" {
+				return ErrProviderUnavailable
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if inst.Name() != "secondary" {
+			t.Errorf("used %q, want secondary", inst.Name())
+		}
+		if len(tried) != 2 || tried[0] != "primary" || tried[1] != "secondary" {
+			t.Errorf("tried %v, want [primary secondary]", tried)
+		}
+	})
+
+	t.Run("all fail", func(t *testing.T) {
+		inst, err := r.MatchingProvidersWithFallback("app.example.com", func(pi *ProviderInstance) error {
+			return ErrNetwork
+		})
+		if inst != nil {
+			t.Errorf("instance = %q, want nil", inst.Name())
+		}
+		if !IsNetwork(err) {
+			t.Errorf("error = %v, want network error", err)
+		}
+		for _, name := range []string{"primary", "secondary", "tertiary"} {
+			if !strings.Contains(err.Error(), name) {
+				t.Errorf("error %q does not mention %s", err, name)
+			}
+		}
+	})
+
+	t.Run("context cancellation stops fallback", func(t *testing.T) {
+		var tried int
+		_, err := r.MatchingProvidersWithFallback("app.example.com", func(pi *ProviderInstance) error {
+			tried++
+			return context.Canceled
+		})
+		if !errors.Is(err, context.Canceled) || tried != 1 {
+			t.Errorf("error = %v after %d attempts, want context.Canceled after 1", err, tried)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		_, err := r.MatchingProvidersWithFallback("unrelated.com", func(pi *ProviderInstance) error {
+			t.Error("fn called without a match")
+			return nil
+		})
+		if !errors.Is(err, ErrNoMatchingProvider) {
+			t.Errorf("error = %v, want ErrNoMatchingProvider", err)
+		}
+	})
+}
+
 func TestRegistry_Get(t *testing.T) {
 	r := NewRegistry(testLogger())
 	r.RegisterFactory("test", func(cfg FactoryConfig) (Provider, error) {
@@ -329,6 +556,68 @@ func TestRegistry_Count(t *testing.T) {
 	}
 }
 
+// closingProvider is a mockProvider that records Close calls.
+type closingProvider struct {
+	mockProvider
+	closed   int
+	closeErr error
+}
+
+func (p *closingProvider) Close() error {
+	p.closed++
+	return p.closeErr
+}
+
+func TestRegistry_RemoveInstance(t *testing.T) {
+	r := NewRegistry(testLogger())
+	providers := map[string]*closingProvider{}
+	r.RegisterFactory("closing", func(cfg FactoryConfig) (Provider, error) {
+		p := &closingProvider{mockProvider: mockProvider{name: cfg.Name, typeName: "closing"}}
+		if cfg.Name == "broken" {
+			p.closeErr = errors.New("connection reset")
+		}
+		providers[cfg.Name] = p
+		return p, nil
+	})
+
+	for _, name := range []string{"one", "broken"} {
+		// Wrappers must not hide the provider's Close method
+		err := r.CreateInstance(ProviderInstanceConfig{
+			Name:       name,
+			TypeName:   "closing",
+			RecordType: RecordTypeA,
+			Target:     "10.0.0.1",
+			TTL:        300,
+			Domains:    []string{"*.example.com"},
+		}, WithRateLimit(time.Millisecond), WithCircuitBreaker(3, time.Minute))
+		if err != nil {
+			t.Fatalf("CreateInstance(%s) error: %v", name, err)
+		}
+	}
+
+	if err := r.RemoveInstance("one"); err != nil {
+		t.Fatalf("RemoveInstance(one) error: %v", err)
+	}
+	if providers["one"].closed != 1 {
+		t.Errorf("provider closed %d times, want 1", providers["one"].closed)
+	}
+	if _, ok := r.Get("one"); ok {
+		t.Error("instance still registered after RemoveInstance")
+	}
+
+	// A close failure is reported, but the instance is still removed
+	if err := r.RemoveInstance("broken"); err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Errorf("RemoveInstance(broken) error = %v, want close error", err)
+	}
+	if r.Count() != 0 {
+		t.Errorf("Count() = %d, want 0", r.Count())
+	}
+
+	if err := r.RemoveInstance("one"); !errors.Is(err, ErrInstanceNotFound) {
+		t.Errorf("RemoveInstance(unknown) error = %v, want ErrInstanceNotFound", err)
+	}
+}
+
 func TestRegistry_Close(t *testing.T) {
 	r := NewRegistry(testLogger())
 	r.RegisterFactory("test", func(cfg FactoryConfig) (Provider, error) {
@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// Snapshot is the record state of provider instances at a point in time,
+// as returned by Registry.Snapshot. It is JSON-serializable.
+type Snapshot struct {
+	CreatedAt time.Time          `json:"created_at"`
+	Providers []ProviderSnapshot `json:"providers"`
+}
+
+// ProviderSnapshot holds the records listed from one provider instance.
+type ProviderSnapshot struct {
+	Name    string           `json:"name"`
+	Type    string           `json:"type"`
+	Records []SnapshotRecord `json:"records"`
+}
+
+// SnapshotRecord is the serialized form of a Record. Provider IDs and hints
+// are not kept; they are not needed to recreate the record.
+type SnapshotRecord struct {
+	Hostname string       `json:"hostname"`
+	Type     RecordType   `json:"type"`
+	Target   string       `json:"target"`
+	TTL      int          `json:"ttl"`
+	SRV      *SnapshotSRV `json:"srv,omitempty"`
+	Weight   *uint16      `json:"weight,omitempty"`
+}
+
+// SnapshotSRV is the serialized form of SRVData.
+type SnapshotSRV struct {
+	Priority uint16 `json:"priority"`
+	Weight   uint16 `json:"weight"`
+	Port     uint16 `json:"port"`
+}
+
+// RestoreOperation is the kind of change a restore makes.
+type RestoreOperation string
+
+const (
+	RestoreCreate RestoreOperation = "create"
+	RestoreDelete RestoreOperation = "delete"
+)
+
+// RestoreChange is a single record change needed to restore a snapshot.
+type RestoreChange struct {
+	Provider  string           `json:"provider"`
+	Operation RestoreOperation `json:"operation"`
+	Record    SnapshotRecord   `json:"record"`
+}
+
+// newSnapshotRecord converts a listed record to its serialized form.
+func newSnapshotRecord(rec Record) SnapshotRecord {
+	s := SnapshotRecord{
+		Hostname: rec.Hostname,
+		Type:     rec.Type,
+		Target:   rec.Target,
+		TTL:      rec.TTL,
+	}
+	if rec.SRV != nil {
+		s.SRV = &SnapshotSRV{Priority: rec.SRV.Priority, Weight: rec.SRV.Weight, Port: rec.SRV.Port}
+	}
+	if rec.Weighted != nil {
+		weight := rec.Weighted.Weight
+		s.Weight = &weight
+	}
+	return s
+}
+
+// key identifies the record for restore comparisons. TTL is not part of it
+// because several providers apply their configured TTL on create rather than
+// the one requested.
+func (s SnapshotRecord) key() string {
+	k := fmt.Sprintf("%s|%s|%s", s.Hostname, s.Type, s.Target)
+	if s.SRV != nil {
+		k += fmt.Sprintf("|%d|%d|%d", s.SRV.Priority, s.SRV.Weight, s.SRV.Port)
+	}
+	return k
+}
+
+// Snapshot lists the records of every provider instance, in priority order.
+// Instances that back a Delegator are left out; their records are captured
+// through the meta-provider. Any List failure fails the whole snapshot.
+func (r *Registry) Snapshot(ctx context.Context) (*Snapshot, error) {
+	r.mu.RLock()
+	delegated := r.delegated()
+	instances := make([]*ProviderInstance, 0, len(r.instances))
+	for _, inst := range r.instances {
+		if _, skip := delegated[inst.Name()]; !skip {
+			instances = append(instances, inst)
+		}
+	}
+	r.mu.RUnlock()
+
+	snap := &Snapshot{
+		CreatedAt: time.Now().UTC(),
+		Providers: make([]ProviderSnapshot, 0, len(instances)),
+	}
+	for _, inst := range instances {
+		records, err := inst.Provider.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing records for %s: %w", inst.Name(), err)
+		}
+
+		ps := ProviderSnapshot{
+			Name:    inst.Name(),
+			Type:    inst.Type(),
+			Records: make([]SnapshotRecord, 0, len(records)),
+		}
+		for _, rec := range records {
+			ps.Records = append(ps.Records, newSnapshotRecord(rec))
+		}
+		sort.Slice(ps.Records, func(i, j int) bool {
+			return ps.Records[i].key() < ps.Records[j].key()
+		})
+		snap.Providers = append(snap.Providers, ps)
+
+		r.logger.Debug("snapshotted provider records",
+			slog.String("provider", inst.Name()),
+			slog.Int("records", len(records)),
+		)
+	}
+
+	return snap, nil
+}
+
+// PlanRestore returns the changes Restore would make to bring each provider
+// in the snapshot back to its snapshotted records: current records missing
+// from the snapshot are deleted, and snapshotted records missing from the
+// provider are created. Records of types the provider does not support are
+// skipped. Nothing is changed.
+//
+// Every provider in the snapshot must be configured in the registry.
+func (r *Registry) PlanRestore(ctx context.Context, snap *Snapshot) ([]RestoreChange, error) {
+	if snap == nil {
+		return nil, errors.New("snapshot is required")
+	}
+
+	var changes []RestoreChange
+	for _, ps := range snap.Providers {
+		inst, ok := r.Get(ps.Name)
+		if !ok {
+			return nil, fmt.Errorf("provider %s from snapshot is not configured", ps.Name)
+		}
+		caps := inst.Provider.Capabilities()
+
+		current, err := inst.Provider.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing records for %s: %w", ps.Name, err)
+		}
+
+		wanted := make(map[string]struct{}, len(ps.Records))
+		for _, rec := range ps.Records {
+			wanted[rec.key()] = struct{}{}
+		}
+
+		existing := make(map[string]struct{}, len(current))
+		for _, rec := range current {
+			s := newSnapshotRecord(rec)
+			existing[s.key()] = struct{}{}
+			if _, keep := wanted[s.key()]; keep || !caps.SupportsRecordType(s.Type) {
+				continue
+			}
+			changes = append(changes, RestoreChange{Provider: ps.Name, Operation: RestoreDelete, Record: s})
+		}
+
+		for _, rec := range ps.Records {
+			if _, found := existing[rec.key()]; found {
+				continue
+			}
+			if !caps.SupportsRecordType(rec.Type) {
+				r.logger.Warn("skipping snapshot record of unsupported type",
+					slog.String("provider", ps.Name),
+					slog.String("hostname", rec.Hostname),
+					slog.String("type", string(rec.Type)),
+				)
+				continue
+			}
+			changes = append(changes, RestoreChange{Provider: ps.Name, Operation: RestoreCreate, Record: rec})
+		}
+	}
+
+	// Deletes go first so providers holding one record per name have room
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Operation == RestoreDelete && changes[j].Operation != RestoreDelete
+	})
+	return changes, nil
+}
+
+// Restore applies the changes from PlanRestore. Every change is attempted;
+// the returned error joins all failures.
+func (r *Registry) Restore(ctx context.Context, snap *Snapshot) error {
+	changes, err := r.PlanRestore(ctx, snap)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, change := range changes {
+		if err := r.applyRestoreChange(ctx, change); err != nil {
+			r.logger.Error("restore change failed",
+				slog.String("provider", change.Provider),
+				slog.String("operation", string(change.Operation)),
+				slog.String("hostname", change.Record.Hostname),
+				slog.String("type", string(change.Record.Type)),
+				slog.String("error", err.Error()),
+			)
+			errs = append(errs, fmt.Errorf("%s %s %s in %s: %w",
+				change.Operation, change.Record.Type, change.Record.Hostname, change.Provider, err))
+			continue
+		}
+		r.logger.Info("restored record",
+			slog.String("provider", change.Provider),
+			slog.String("operation", string(change.Operation)),
+			slog.String("hostname", change.Record.Hostname),
+			slog.String("type", string(change.Record.Type)),
+			slog.String("target", change.Record.Target),
+		)
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyRestoreChange performs a single restore change through its instance.
+func (r *Registry) applyRestoreChange(ctx context.Context, change RestoreChange) error {
+	inst, ok := r.Get(change.Provider)
+	if !ok {
+		return fmt.Errorf("provider %s is not configured", change.Provider)
+	}
+
+	rec := change.Record
+	var srv *SRVData
+	if rec.SRV != nil {
+		srv = &SRVData{Priority: rec.SRV.Priority, Weight: rec.SRV.Weight, Port: rec.SRV.Port}
+	}
+
+	if change.Operation == RestoreDelete {
+		if rec.Type == RecordTypeSRV {
+			return inst.DeleteSRVRecord(ctx, rec.Hostname, rec.Target, srv)
+		}
+		return inst.DeleteRecordByTarget(ctx, rec.Hostname, rec.Type, rec.Target)
+	}
+
+	var weighted *WeightedData
+	if rec.Weight != nil {
+		weighted = &WeightedData{Weight: *rec.Weight}
+	}
+	return inst.CreateRecordWithValues(ctx, rec.Hostname, rec.Type, rec.Target, rec.TTL, srv, nil, weighted)
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// memProvider is an in-memory Provider whose records change on Create and Delete.
+type memProvider struct {
+	mockProvider
+	types   []RecordType
+	listErr error
+}
+
+func (m *memProvider) List(ctx context.Context) ([]Record, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return append([]Record(nil), m.records...), nil
+}
+
+func (m *memProvider) Create(ctx context.Context, r Record) error {
+	m.records = append(m.records, r)
+	return nil
+}
+
+func (m *memProvider) Delete(ctx context.Context, r Record) error {
+	for i, rec := range m.records {
+		if rec.Hostname == r.Hostname && rec.Type == r.Type && rec.Target == r.Target {
+			m.records = append(m.records[:i], m.records[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *memProvider) Capabilities() Capabilities {
+	return Capabilities{SupportedRecordTypes: m.types}
+}
+
+func newSnapshotRegistry(t *testing.T, p *memProvider) *Registry {
+	t.Helper()
+	r := NewRegistry(testLogger())
+	r.RegisterFactory("mem", func(cfg FactoryConfig) (Provider, error) { return p, nil })
+	if err := r.CreateInstance(ProviderInstanceConfig{
+		Name:       p.name,
+		TypeName:   "mem",
+		RecordType: RecordTypeA,
+		Target:     "10.0.0.1",
+		TTL:        300,
+		Domains:    []string{"*.example.com"},
+	}); err != nil {
+		t.Fatalf("CreateInstance failed: %v", err)
+	}
+	return r
+}
+
+func TestRegistry_SnapshotRestore(t *testing.T) {
+	p := &memProvider{
+		mockProvider: mockProvider{name: "dns", records: []Record{
+			{Hostname: "app.example.com", Type: RecordTypeA, Target: "10.0.0.1", TTL: 300},
+			{Hostname: "_sip._tcp.example.com", Type: RecordTypeSRV, Target: "sip.example.com", TTL: 300,
+				SRV: &SRVData{Priority: 10, Weight: 5, Port: 5060}},
+		}},
+		types: []RecordType{RecordTypeA, RecordTypeSRV},
+	}
+	r := newSnapshotRegistry(t, p)
+	ctx := context.Background()
+
+	snap, err := r.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Snapshot() unexpected error: %v", err)
+	}
+	if len(snap.Providers) != 1 || len(snap.Providers[0].Records) != 2 {
+		t.Fatalf("Snapshot() = %+v, want 1 provider with 2 records", snap)
+	}
+
+	// The snapshot survives a JSON round trip
+	data, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Snapshot
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	// Drift: the SRV record is lost and an extra record appears
+	p.records = []Record{
+		{Hostname: "app.example.com", Type: RecordTypeA, Target: "10.0.0.1", TTL: 300},
+		{Hostname: "stray.example.com", Type: RecordTypeA, Target: "10.0.0.9", TTL: 300},
+	}
+
+	changes, err := r.PlanRestore(ctx, &decoded)
+	if err != nil {
+		t.Fatalf("PlanRestore() unexpected error: %v", err)
+	}
+	if len(changes) != 2 ||
+		changes[0].Operation != RestoreDelete || changes[0].Record.Hostname != "stray.example.com" ||
+		changes[1].Operation != RestoreCreate || changes[1].Record.SRV == nil || changes[1].Record.SRV.Port != 5060 {
+		t.Fatalf("PlanRestore() = %+v, want delete stray then create SRV", changes)
+	}
+	if len(p.records) != 2 {
+		t.Fatal("PlanRestore() changed records")
+	}
+
+	if err := r.Restore(ctx, &decoded); err != nil {
+		t.Fatalf("Restore() unexpected error: %v", err)
+	}
+	changes, err = r.PlanRestore(ctx, &decoded)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("PlanRestore() after Restore() = %+v, %v; want no changes", changes, err)
+	}
+}
+
+func TestRegistry_PlanRestore_Capabilities(t *testing.T) {
+	p := &memProvider{mockProvider: mockProvider{name: "dns"}, types: []RecordType{RecordTypeA}}
+	r := newSnapshotRegistry(t, p)
+
+	snap := &Snapshot{Providers: []ProviderSnapshot{{Name: "dns", Records: []SnapshotRecord{
+		{Hostname: "app.example.com", Type: RecordTypeA, Target: "10.0.0.1"},
+		{Hostname: "_dnsweaver.app.example.com", Type: RecordTypeTXT, Target: OwnershipValue},
+	}}}}
+
+	changes, err := r.PlanRestore(context.Background(), snap)
+	if err != nil {
+		t.Fatalf("PlanRestore() unexpected error: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Record.Type != RecordTypeA {
+		t.Errorf("PlanRestore() = %+v, want only the A record", changes)
+	}
+}
+
+func TestRegistry_SnapshotRestore_Errors(t *testing.T) {
+	listErr := errors.New("unreachable")
+	p := &memProvider{mockProvider: mockProvider{name: "dns"}, listErr: listErr}
+	r := newSnapshotRegistry(t, p)
+	ctx := context.Background()
+
+	if _, err := r.Snapshot(ctx); !errors.Is(err, listErr) {
+		t.Errorf("Snapshot() error = %v, want %v", err, listErr)
+	}
+
+	unknown := &Snapshot{Providers: []ProviderSnapshot{{Name: "other"}}}
+	if err := r.Restore(ctx, unknown); err == nil {
+		t.Error("Restore() with unconfigured provider expected error")
+	}
+	if err := r.Restore(ctx, nil); err == nil {
+		t.Error("Restore() with nil snapshot expected error")
+	}
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"sync"
+	"time"
+)
+
+// latencyWeight is the smoothing factor for AvgLatencyMs. Each observation
+// contributes 20%, so the average follows recent behaviour without jumping on
+// a single slow call.
+const latencyWeight = 0.2
+
+// ProviderStatus represents the status of a provider for health checks.
+// For ready instances it includes API health built from the operations the
+// instance has performed (see ProviderInstance.Status).
+type ProviderStatus struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Available bool   `json:"available"`
+	Error     string `json:"error,omitempty"`
+
+	// Healthy is true once an operation has succeeded and none has failed since.
+	Healthy bool `json:"healthy"`
+
+	// LastSuccess is when the last successful operation completed.
+	LastSuccess time.Time `json:"last_success,omitempty"`
+
+	// LastError is the message of the most recent failed operation, cleared
+	// when an operation succeeds.
+	LastError string `json:"last_error,omitempty"`
+
+	// ConsecutiveErrors counts failed operations since the last success.
+	ConsecutiveErrors int `json:"consecutive_errors"`
+
+	// AvgLatencyMs is an exponentially weighted moving average of operation
+	// latency in milliseconds.
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+
+	// LastSuccessfulCreateAt, LastSuccessfulDeleteAt, and LastListAt are
+	// when a create (including bulk and ownership creates), a delete, and a
+	// list last completed without error. Zero until the first one does.
+	LastSuccessfulCreateAt time.Time `json:"last_successful_create_at,omitzero"`
+	LastSuccessfulDeleteAt time.Time `json:"last_successful_delete_at,omitzero"`
+	LastListAt             time.Time `json:"last_list_at,omitzero"`
+}
+
+// Known reports whether any operation has been observed yet.
+func (s ProviderStatus) Known() bool {
+	return !s.LastSuccess.IsZero() || s.ConsecutiveErrors > 0
+}
+
+// statusTracker accumulates the health fields of ProviderStatus from
+// observed operations.
+// The zero value is ready to use.
+type statusTracker struct {
+	mu     sync.Mutex
+	status ProviderStatus
+}
+
+// observe records the outcome of one provider API call that took seconds.
+// Conflict and not-found errors mean the API answered, so they count as
+// successes for health. Only calls that returned no error update the
+// per-operation timestamps; operation is the metrics label (see
+// successCategory). Returns the category whose timestamp was updated, or "".
+func (t *statusTracker) observe(operation string, seconds float64, err error) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	category := ""
+	if err == nil {
+		category = successCategory(operation)
+		now := time.Now()
+		switch category {
+		case successCreate:
+			t.status.LastSuccessfulCreateAt = now
+		case successDelete:
+			t.status.LastSuccessfulDeleteAt = now
+		case successList:
+			t.status.LastListAt = now
+		}
+	}
+
+	ms := seconds * 1000
+	if t.status.AvgLatencyMs == 0 {
+		t.status.AvgLatencyMs = ms
+	} else {
+		t.status.AvgLatencyMs += latencyWeight * (ms - t.status.AvgLatencyMs)
+	}
+
+	if err != nil && !IsConflict(err) && !IsNotFound(err) && !IsTypeConflict(err) {
+		t.status.ConsecutiveErrors++
+		t.status.LastError = err.Error()
+		t.status.Healthy = false
+		return category
+	}
+
+	t.status.ConsecutiveErrors = 0
+	t.status.LastError = ""
+	t.status.LastSuccess = time.Now()
+	t.status.Healthy = true
+	return category
+}
+
+// Success categories for the per-operation timestamps in ProviderStatus.
+const (
+	successCreate = "create"
+	successDelete = "delete"
+	successList   = "list"
+)
+
+// successCategory maps an operation label to the timestamp it updates, or
+// "" for operations without one (ping, update).
+func successCategory(operation string) string {
+	switch operation {
+	case "create", "bulk_create", "create_ownership":
+		return successCreate
+	case "delete", "delete_ownership":
+		return successDelete
+	case "list":
+		return successList
+	default:
+		return ""
+	}
+}
+
+// snapshot returns a copy of the current status.
+func (t *statusTracker) snapshot() ProviderStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status
+}
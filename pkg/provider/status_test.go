@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/metrics"
+)
+
+func TestProviderInstance_Status(t *testing.T) {
+	p := &mockProvider{name: "test", typeName: "mock"}
+	inst := &ProviderInstance{Provider: p}
+	ctx := context.Background()
+
+	st := inst.Status()
+	if st.Known() || st.Healthy {
+		t.Fatalf("status before any operation = %+v, want unknown and unhealthy", st)
+	}
+	if st.Name != "test" || st.Type != "mock" || !st.Available {
+		t.Errorf("status identity = %+v, want name test, type mock, available", st)
+	}
+
+	if err := inst.Ping(ctx); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	st = inst.Status()
+	if !st.Known() || !st.Healthy || st.LastSuccess.IsZero() {
+		t.Errorf("status after successful ping = %+v, want healthy with LastSuccess", st)
+	}
+
+	p.pingErr = errors.New("connection refused")
+	_ = inst.Ping(ctx)
+	_ = inst.Ping(ctx)
+	st = inst.Status()
+	if st.Healthy || st.ConsecutiveErrors != 2 || st.LastError != "connection refused" {
+		t.Errorf("status after failed pings = %+v, want 2 consecutive errors", st)
+	}
+	if st.LastSuccess.IsZero() {
+		t.Error("LastSuccess should be kept after failures")
+	}
+
+	// Any successful operation resets the error streak
+	if _, err := inst.GetExistingRecords(ctx, "app.example.com"); err != nil {
+		t.Fatalf("GetExistingRecords() error = %v", err)
+	}
+	st = inst.Status()
+	if !st.Healthy || st.ConsecutiveErrors != 0 || st.LastError != "" {
+		t.Errorf("status after recovery = %+v, want healthy with errors cleared", st)
+	}
+}
+
+func TestStatusTracker_RecordErrorsAreHealthy(t *testing.T) {
+	var tracker statusTracker
+
+	tracker.observe("create", 0.01, fmt.Errorf("create: %w", ErrConflict))
+	tracker.observe("delete", 0.01, fmt.Errorf("delete: %w", ErrNotFound))
+
+	if st := tracker.snapshot(); !st.Healthy || st.ConsecutiveErrors != 0 {
+		t.Errorf("status = %+v, want conflict and not-found treated as successes", st)
+	}
+}
+
+func TestStatusTracker_AvgLatency(t *testing.T) {
+	var tracker statusTracker
+
+	tracker.observe("list", 0.100, nil)
+	if got := tracker.snapshot().AvgLatencyMs; got != 100 {
+		t.Fatalf("AvgLatencyMs after first observation = %v, want 100", got)
+	}
+
+	tracker.observe("list", 0.200, nil)
+	if got := tracker.snapshot().AvgLatencyMs; got < 119.9 || got > 120.1 {
+		t.Errorf("AvgLatencyMs = %v, want 120 (20%% weight on new sample)", got)
+	}
+}
+
+func TestProviderInstance_Status_OperationTimestamps(t *testing.T) {
+	inst := &ProviderInstance{Provider: &mockProvider{name: "timestamps", typeName: "mock"}, RecordType: RecordTypeA, Target: "10.0.0.1"}
+	ctx := context.Background()
+
+	if err := inst.Ping(ctx); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	st := inst.Status()
+	if !st.LastSuccessfulCreateAt.IsZero() || !st.LastSuccessfulDeleteAt.IsZero() || !st.LastListAt.IsZero() {
+		t.Fatalf("status after ping = %+v, want no operation timestamps", st)
+	}
+
+	before := time.Now()
+	if err := inst.CreateRecord(ctx, "app.example.com"); err != nil {
+		t.Fatalf("CreateRecord() error = %v", err)
+	}
+	if _, err := inst.GetExistingRecords(ctx, "app.example.com"); err != nil {
+		t.Fatalf("GetExistingRecords() error = %v", err)
+	}
+	st = inst.Status()
+	if st.LastSuccessfulCreateAt.Before(before) || st.LastListAt.Before(before) {
+		t.Errorf("status = %+v, want create and list timestamps set", st)
+	}
+	if !st.LastSuccessfulDeleteAt.IsZero() {
+		t.Errorf("LastSuccessfulDeleteAt = %v, want zero before any delete", st.LastSuccessfulDeleteAt)
+	}
+	if got := testutil.ToFloat64(metrics.ProviderLastSuccessTimestamp.WithLabelValues("timestamps", "create")); got < float64(before.Unix()) {
+		t.Errorf("last success gauge = %v, want at least %d", got, before.Unix())
+	}
+
+	if err := inst.DeleteRecord(ctx, "app.example.com"); err != nil {
+		t.Fatalf("DeleteRecord() error = %v", err)
+	}
+	if inst.Status().LastSuccessfulDeleteAt.Before(before) {
+		t.Error("LastSuccessfulDeleteAt not set after a successful delete")
+	}
+}
+
+func TestStatusTracker_FailedOperationKeepsTimestamp(t *testing.T) {
+	var tracker statusTracker
+
+	if got := tracker.observe("bulk_create", 0.01, nil); got != successCreate {
+		t.Errorf("observe(bulk_create) category = %q, want %q", got, successCreate)
+	}
+	created := tracker.snapshot().LastSuccessfulCreateAt
+
+	// Record-level errors keep the provider healthy but are not successful creates
+	if got := tracker.observe("create", 0.01, fmt.Errorf("create: %w", ErrConflict)); got != "" {
+		t.Errorf("observe(create, conflict) category = %q, want none", got)
+	}
+	if got := tracker.snapshot().LastSuccessfulCreateAt; !got.Equal(created) {
+		t.Errorf("LastSuccessfulCreateAt = %v, want unchanged %v", got, created)
+	}
+	if got := tracker.observe("update", 0.01, nil); got != "" {
+		t.Errorf("observe(update) category = %q, want none", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -14,6 +15,9 @@ const (
 
 	// MaxLabelLength is the maximum length of a single label (63 chars).
 	MaxLabelLength = 63
+
+	// MaxTTL is the maximum TTL accepted from record hints (one day).
+	MaxTTL = 86400
 )
 
 // Common hostname validation errors.
@@ -38,6 +42,9 @@ var (
 
 	// ErrInvalidLabelEnd indicates label ends with invalid character.
 	ErrInvalidLabelEnd = errors.New("hostname label must end with alphanumeric character")
+
+	// ErrInvalidTTL indicates a TTL hint that is not an integer between 1 and MaxTTL.
+	ErrInvalidTTL = errors.New("TTL must be an integer between 1 and 86400")
 )
 
 // labelRegex matches valid hostname labels (RFC 1123).
@@ -239,6 +246,16 @@ type SRVHints struct {
 	Port     uint16 // TCP/UDP port number (1-65535)
 }
 
+// ParseTTL parses a TTL value from a label.
+// Returns ErrInvalidTTL if the value is not an integer between 1 and MaxTTL.
+func ParseTTL(value string) (int, error) {
+	ttl, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || ttl < 1 || ttl > MaxTTL {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidTTL, value)
+	}
+	return ttl, nil
+}
+
 // RecordHints contains optional hints for DNS record creation.
 // These allow sources (particularly native dnsweaver labels) to specify
 // record details that override provider defaults.
@@ -253,6 +270,10 @@ type RecordHints struct {
 	// Empty means use provider default.
 	Target string
 
+	// Targets lists several targets for round-robin A/AAAA records.
+	// When set, one record is created per target and Target should equal Targets[0].
+	Targets []string
+
 	// TTL overrides the record TTL.
 	// Zero means use provider default.
 	TTL int
@@ -263,6 +284,48 @@ type RecordHints struct {
 
 	// SRV contains SRV-specific fields when Type is "SRV".
 	SRV *SRVHints
+
+	// Weight sets the routing weight for providers that support weighted
+	// records; providers without weighted routing ignore it.
+	// Zero means unweighted.
+	Weight uint16
+
+	// ProviderHints carries provider-specific settings keyed by
+	// "<provider type>.<setting>" (e.g., "cloudflare.proxied" -> "true").
+	// Providers ignore keys they do not recognize.
+	ProviderHints map[string]string
+}
+
+// providerHintLabelPrefix is the label prefix for provider-specific hints.
+const providerHintLabelPrefix = "dnsweaver."
+
+// providerHintNamespaces lists the provider types whose settings can be set
+// per hostname through labels such as dnsweaver.cloudflare.proxied=true.
+var providerHintNamespaces = []string{"cloudflare."}
+
+// ProviderHintsFromLabels collects provider-specific hints from labels of the
+// form <prefix>dnsweaver.<provider type>.<setting>, keyed by
+// "<provider type>.<setting>". Pass an empty prefix for workload-level labels
+// or a router prefix (e.g., "traefik.http.routers.myapp.") for router-level
+// ones. Returns nil if no hint labels are present.
+func ProviderHintsFromLabels(labels map[string]string, prefix string) map[string]string {
+	var hints map[string]string
+	for key, value := range labels {
+		rest, ok := strings.CutPrefix(key, prefix+providerHintLabelPrefix)
+		if !ok {
+			continue
+		}
+		for _, ns := range providerHintNamespaces {
+			if strings.HasPrefix(rest, ns) && len(rest) > len(ns) {
+				if hints == nil {
+					hints = make(map[string]string)
+				}
+				hints[strings.ToLower(rest)] = strings.TrimSpace(value)
+				break
+			}
+		}
+	}
+	return hints
 }
 
 // Hostname represents a hostname extracted from container labels.
@@ -288,6 +351,12 @@ type Hostname struct {
 	// These allow per-hostname overrides for record type, target, TTL, and provider.
 	// nil means use provider defaults for everything.
 	RecordHints *RecordHints
+
+	// Annotations carries arbitrary source metadata (e.g., container ID,
+	// Kubernetes namespace, Nomad job ID). Annotations are purely
+	// observational: they appear in the audit log and the
+	// dnsweaver_hostname_info metric but never affect reconciliation.
+	Annotations map[string]string
 }
 
 // HasRecordHints returns true if this hostname has any record hints set.
@@ -343,6 +412,17 @@ func (hs Hostnames) Names() []string {
 	return names
 }
 
+// Normalize returns a new slice with every Name in canonical form
+// (lowercase, no trailing dot). See NormalizeHostname.
+func (hs Hostnames) Normalize() Hostnames {
+	result := make(Hostnames, len(hs))
+	for i, h := range hs {
+		h.Name = h.NormalizedName()
+		result[i] = h
+	}
+	return result
+}
+
 // Deduplicate returns a new slice with duplicate hostnames removed.
 // The first occurrence of each hostname is kept.
 // Comparison is case-insensitive per DNS RFC 1035 Section 2.3.3.
@@ -361,6 +441,31 @@ func (hs Hostnames) Deduplicate() Hostnames {
 	return result
 }
 
+// DeduplicateByPriority returns a new slice with duplicate hostnames removed,
+// keeping the occurrence whose Source has the highest priority. Sources
+// missing from priorities have priority 0; on equal priority the first
+// occurrence is kept. Each kept hostname stays at the position of the first
+// occurrence. Comparison is case-insensitive per DNS RFC 1035 Section 2.3.3.
+func (hs Hostnames) DeduplicateByPriority(priorities map[string]int) Hostnames {
+	index := make(map[string]int, len(hs))
+	result := make(Hostnames, 0, len(hs))
+
+	for _, h := range hs {
+		normalized := h.NormalizedName()
+		i, exists := index[normalized]
+		if !exists {
+			index[normalized] = len(result)
+			result = append(result, h)
+			continue
+		}
+		if priorities[h.Source] > priorities[result[i].Source] {
+			result[i] = h
+		}
+	}
+
+	return result
+}
+
 // Filter returns a new slice containing only hostnames where the predicate returns true.
 func (hs Hostnames) Filter(predicate func(Hostname) bool) Hostnames {
 	result := make(Hostnames, 0)
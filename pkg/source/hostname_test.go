@@ -395,6 +395,35 @@ func TestHostnames_Deduplicate(t *testing.T) {
 	}
 }
 
+func TestHostnames_DeduplicateByPriority(t *testing.T) {
+	hostnames := Hostnames{
+		{Name: "app.example.com", Source: "traefik", Router: "app1"},
+		{Name: "other.example.com", Source: "traefik", Router: "other"},
+		{Name: "APP.example.com", Source: "dnsweaver", Router: "app2"},
+		{Name: "app.example.com", Source: "caddy", Router: "app3"},
+		{Name: "other.example.com", Source: "static", Router: "other2"},
+	}
+
+	deduped := hostnames.DeduplicateByPriority(map[string]int{"traefik": 5, "dnsweaver": 10, "caddy": 10})
+
+	// dnsweaver wins app (caddy ties and came later); static is unlisted (0)
+	// and loses other. Positions follow the first occurrences.
+	want := []string{"app2", "other"}
+	if len(deduped) != len(want) {
+		t.Fatalf("DeduplicateByPriority() returned %d items, want %d", len(deduped), len(want))
+	}
+	for i, router := range want {
+		if deduped[i].Router != router {
+			t.Errorf("deduped[%d].Router = %q, want %q", i, deduped[i].Router, router)
+		}
+	}
+
+	// Without priorities it behaves like Deduplicate
+	if got := hostnames.DeduplicateByPriority(nil); got[0].Router != "app1" {
+		t.Errorf("DeduplicateByPriority(nil)[0].Router = %q, want app1", got[0].Router)
+	}
+}
+
 func TestHostnames_Filter(t *testing.T) {
 	hostnames := Hostnames{
 		{Name: "app1.example.com", Source: "traefik"},
@@ -519,3 +548,89 @@ func TestHostnames_Deduplicate_CaseInsensitive(t *testing.T) {
 		t.Errorf("deduped[1].Name = %q, want %q", deduped[1].Name, "different.example.com")
 	}
 }
+
+func TestHostnames_Normalize(t *testing.T) {
+	hostnames := Hostnames{
+		{Name: "App.Example.COM.", Source: "traefik", Router: "first"},
+		{Name: "other.example.com", Source: "file"},
+	}
+
+	normalized := hostnames.Normalize()
+
+	if len(normalized) != 2 {
+		t.Fatalf("Normalize() returned %d items, want 2", len(normalized))
+	}
+	if normalized[0].Name != "app.example.com" {
+		t.Errorf("normalized[0].Name = %q, want %q", normalized[0].Name, "app.example.com")
+	}
+	if normalized[0].Router != "first" {
+		t.Errorf("normalized[0].Router = %q, want %q", normalized[0].Router, "first")
+	}
+	if normalized[1].Name != "other.example.com" {
+		t.Errorf("normalized[1].Name = %q, want %q", normalized[1].Name, "other.example.com")
+	}
+
+	// The original slice is not modified
+	if hostnames[0].Name != "App.Example.COM." {
+		t.Errorf("original Name = %q, want unchanged %q", hostnames[0].Name, "App.Example.COM.")
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"60", 60, false},
+		{" 300 ", 300, false},
+		{"86400", 86400, false},
+		{"1", 1, false},
+		{"0", 0, true},
+		{"-5", 0, true},
+		{"86401", 0, true},
+		{"abc", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseTTL(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTTL) {
+					t.Errorf("ParseTTL(%q) error = %v, want ErrInvalidTTL", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTTL(%q) unexpected error: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTTL(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderHintsFromLabels(t *testing.T) {
+	labels := map[string]string{
+		"dnsweaver.cloudflare.proxied":                          " true ",
+		"dnsweaver.ttl":                                         "60",
+		"dnsweaver.cloudflare.":                                 "ignored",
+		"traefik.http.routers.web.dnsweaver.cloudflare.proxied": "false",
+	}
+
+	got := ProviderHintsFromLabels(labels, "")
+	if len(got) != 1 || got["cloudflare.proxied"] != "true" {
+		t.Errorf("workload hints = %v, want map[cloudflare.proxied:true]", got)
+	}
+
+	got = ProviderHintsFromLabels(labels, "traefik.http.routers.web.")
+	if len(got) != 1 || got["cloudflare.proxied"] != "false" {
+		t.Errorf("router hints = %v, want map[cloudflare.proxied:false]", got)
+	}
+
+	if got := ProviderHintsFromLabels(map[string]string{"dnsweaver.hostname": "a.example.com"}, ""); got != nil {
+		t.Errorf("expected nil hints, got %v", got)
+	}
+}
@@ -17,18 +17,52 @@ type Registry struct {
 	sources []Source
 	byName  map[string]Source
 	logger  *slog.Logger
+
+	// dedup normalizes and deduplicates aggregated results.
+	dedup bool
+
+	// priorities decides which source's hostname deduplication keeps.
+	priorities map[string]int
+}
+
+// RegistryOption is a functional option for configuring a Registry.
+type RegistryOption func(*Registry)
+
+// WithCaseInsensitiveDedup makes ExtractAll and DiscoverAll return hostnames
+// in canonical lowercase form with duplicates removed, keeping the first
+// occurrence. DNS names are case-insensitive (RFC 1035 Section 2.3.3), so
+// "app.example.com" and "APP.EXAMPLE.COM" from different sources must not
+// produce separate records.
+func WithCaseInsensitiveDedup() RegistryOption {
+	return func(r *Registry) {
+		r.dedup = true
+	}
+}
+
+// WithSourcePriorities makes deduplication (see WithCaseInsensitiveDedup)
+// keep the hostname from the source with the highest priority instead of
+// the first occurrence. Keys are source names; unlisted sources have
+// priority 0, and equal priorities keep the first occurrence.
+func WithSourcePriorities(priorities map[string]int) RegistryOption {
+	return func(r *Registry) {
+		r.priorities = priorities
+	}
 }
 
 // NewRegistry creates a new source registry.
-func NewRegistry(logger *slog.Logger) *Registry {
+func NewRegistry(logger *slog.Logger, opts ...RegistryOption) *Registry {
 	if logger == nil {
 		logger = slog.Default()
 	}
-	return &Registry{
+	r := &Registry{
 		sources: make([]Source, 0),
 		byName:  make(map[string]Source),
 		logger:  logger,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Register adds a source to the registry.
@@ -82,7 +116,8 @@ func (r *Registry) Count() int {
 //
 // Each source is queried with the provided labels. Results are aggregated
 // in source registration order. Duplicate hostnames are NOT removed to
-// preserve source attribution - use Hostnames.Deduplicate() if needed.
+// preserve source attribution - use Hostnames.Deduplicate() if needed, or
+// WithCaseInsensitiveDedup to have the registry do it.
 //
 // If a source returns an error, extraction continues with remaining sources.
 // Errors are logged but not returned to allow partial results.
@@ -113,7 +148,7 @@ func (r *Registry) ExtractAll(ctx context.Context, labels map[string]string) Hos
 		}
 	}
 
-	return allHostnames
+	return r.finish(allHostnames)
 }
 
 // DiscoverAll queries all sources that support file-based discovery.
@@ -121,7 +156,7 @@ func (r *Registry) ExtractAll(ctx context.Context, labels map[string]string) Hos
 // Each source with SupportsDiscovery() == true is queried via Discover().
 // Results are aggregated in source registration order. Duplicate hostnames
 // are NOT removed to preserve source attribution - use Hostnames.Deduplicate()
-// if needed.
+// if needed, or WithCaseInsensitiveDedup to have the registry do it.
 //
 // If a source returns an error, discovery continues with remaining sources.
 // Errors are logged but not returned to allow partial results.
@@ -156,7 +191,21 @@ func (r *Registry) DiscoverAll(ctx context.Context) Hostnames {
 		}
 	}
 
-	return allHostnames
+	return r.finish(allHostnames)
+}
+
+// finish applies registry-wide post-processing to aggregated hostnames.
+func (r *Registry) finish(hostnames Hostnames) Hostnames {
+	if !r.dedup || len(hostnames) == 0 {
+		return hostnames
+	}
+	deduped := hostnames.Normalize().DeduplicateByPriority(r.priorities)
+	if dropped := len(hostnames) - len(deduped); dropped > 0 {
+		r.logger.Debug("removed duplicate hostnames",
+			slog.Int("count", dropped),
+		)
+	}
+	return deduped
 }
 
 // DiscoverableSources returns sources that have file discovery configured.
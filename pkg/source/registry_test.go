@@ -155,6 +155,44 @@ func TestRegistry_ExtractAll(t *testing.T) {
 	}
 }
 
+func TestRegistry_ExtractAll_CaseInsensitiveDedup(t *testing.T) {
+	r := NewRegistry(testLogger(), WithCaseInsensitiveDedup())
+
+	_ = r.Register(&mockSource{
+		name:      "source1",
+		hostnames: []Hostname{{Name: "app.example.com", Source: "source1", Router: "first"}},
+	})
+	_ = r.Register(&mockSource{
+		name: "source2",
+		hostnames: []Hostname{
+			{Name: "APP.EXAMPLE.COM", Source: "source2", Router: "second"},
+			{Name: "Other.Example.com.", Source: "source2", Router: "other"},
+		},
+	})
+
+	hostnames := r.ExtractAll(context.Background(), nil)
+
+	if len(hostnames) != 2 {
+		t.Fatalf("ExtractAll returned %d hostnames, want 2: %v", len(hostnames), hostnames.Names())
+	}
+	// First occurrence wins
+	if hostnames[0].Name != "app.example.com" || hostnames[0].Router != "first" {
+		t.Errorf("hostnames[0] = %+v, want app.example.com from router first", hostnames[0])
+	}
+	if hostnames[1].Name != "other.example.com" {
+		t.Errorf("hostnames[1].Name = %q, want normalized other.example.com", hostnames[1].Name)
+	}
+
+	// Without the option, duplicates and original case are preserved
+	plain := NewRegistry(testLogger())
+	for _, src := range r.All() {
+		_ = plain.Register(src)
+	}
+	if got := plain.ExtractAll(context.Background(), nil); len(got) != 3 {
+		t.Errorf("ExtractAll without dedup returned %d hostnames, want 3", len(got))
+	}
+}
+
 func TestRegistry_ExtractAll_WithErrors(t *testing.T) {
 	r := NewRegistry(testLogger())
 
@@ -277,6 +315,25 @@ func TestRegistry_DiscoverAll(t *testing.T) {
 	}
 }
 
+func TestRegistry_DiscoverAll_CaseInsensitiveDedup(t *testing.T) {
+	r := NewRegistry(testLogger(), WithCaseInsensitiveDedup())
+
+	_ = r.Register(&mockSource{
+		name:              "files",
+		supportsDiscovery: true,
+		discoverHostnames: []Hostname{
+			{Name: "File.Example.com", Source: "files"},
+			{Name: "file.example.com", Source: "files"},
+		},
+	})
+
+	hostnames := r.DiscoverAll(context.Background())
+
+	if len(hostnames) != 1 || hostnames[0].Name != "file.example.com" {
+		t.Errorf("DiscoverAll = %v, want [file.example.com]", hostnames.Names())
+	}
+}
+
 func TestRegistry_DiscoverAll_ErrorHandling(t *testing.T) {
 	r := NewRegistry(testLogger())
 
@@ -85,3 +85,14 @@ type Source interface {
 	// configured should return false.
 	SupportsDiscovery() bool
 }
+
+// Notifier is implemented by sources that push hostname changes (e.g., via
+// long-polling blocking queries) instead of relying on periodic discovery.
+//
+// Sources implementing Notifier still return their current hostnames from
+// Discover; Watch only signals when a new reconciliation is needed.
+type Notifier interface {
+	// Watch blocks until ctx is cancelled, calling onChange whenever the
+	// hostnames returned by Discover have changed.
+	Watch(ctx context.Context, onChange func()) error
+}
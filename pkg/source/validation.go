@@ -0,0 +1,93 @@
+package source
+
+import (
+	"context"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/docker"
+)
+
+// ValidationReport is the outcome of Registry.ValidateAll: every hostname the
+// registered sources would produce, sorted into valid, invalid, and duplicate.
+type ValidationReport struct {
+	// Valid holds the hostnames a reconciliation would manage, normalized,
+	// in workload order followed by file-discovered hostnames.
+	Valid Hostnames
+
+	// Invalid holds hostnames that failed validation.
+	Invalid []InvalidHostname
+
+	// Duplicates holds hostnames defined by more than one workload. Only the
+	// first workload's definition is kept in Valid.
+	Duplicates []DuplicateHostname
+}
+
+// InvalidHostname is a hostname that failed validation.
+type InvalidHostname struct {
+	Hostname Hostname
+	Workload string // Empty for file-discovered hostnames
+	Error    error
+}
+
+// DuplicateHostname is a hostname already defined by an earlier workload.
+type DuplicateHostname struct {
+	Hostname      Hostname
+	Workload      string // Workload whose definition was ignored
+	FirstWorkload string // Workload whose definition is used
+}
+
+// HasProblems returns true if any hostname was invalid or duplicated.
+func (r ValidationReport) HasProblems() bool {
+	return len(r.Invalid) > 0 || len(r.Duplicates) > 0
+}
+
+// ValidateAll runs extraction and validation for every registered source
+// against the given workloads, plus file and API discovery, without changing
+// anything. It applies the same rules as a reconciliation: hostnames are
+// normalized, the first workload to define a hostname wins, and discovered
+// hostnames already defined by a workload are ignored.
+func (r *Registry) ValidateAll(ctx context.Context, workloads []docker.Workload) ValidationReport {
+	report := ValidationReport{Valid: make(Hostnames, 0)}
+	origins := make(map[string]string) // normalized hostname -> first workload
+
+	for _, workload := range workloads {
+		validation := r.ExtractAll(ctx, workload.Labels).Normalize().ValidateAll()
+		for _, inv := range validation.Invalid {
+			report.Invalid = append(report.Invalid, InvalidHostname{
+				Hostname: inv.Hostname,
+				Workload: workload.Name,
+				Error:    inv.Error,
+			})
+		}
+		for _, h := range validation.Valid {
+			name := h.NormalizedName()
+			if first, exists := origins[name]; exists {
+				report.Duplicates = append(report.Duplicates, DuplicateHostname{
+					Hostname:      h,
+					Workload:      workload.Name,
+					FirstWorkload: first,
+				})
+				continue
+			}
+			origins[name] = workload.Name
+			report.Valid = append(report.Valid, h)
+		}
+	}
+
+	validation := r.DiscoverAll(ctx).Normalize().ValidateAll()
+	for _, inv := range validation.Invalid {
+		report.Invalid = append(report.Invalid, InvalidHostname{
+			Hostname: inv.Hostname,
+			Error:    inv.Error,
+		})
+	}
+	for _, h := range validation.Valid {
+		name := h.NormalizedName()
+		if _, exists := origins[name]; exists {
+			continue
+		}
+		origins[name] = ""
+		report.Valid = append(report.Valid, h)
+	}
+
+	return report
+}
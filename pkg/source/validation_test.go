@@ -0,0 +1,88 @@
+package source
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/internal/docker"
+)
+
+// labelSource returns one hostname per comma-separated entry of the "hosts" label.
+type labelSource struct {
+	mockSource
+}
+
+func (s *labelSource) Extract(_ context.Context, labels map[string]string) ([]Hostname, error) {
+	var hostnames []Hostname
+	for _, name := range strings.Split(labels["hosts"], ",") {
+		if name != "" {
+			hostnames = append(hostnames, Hostname{Name: name, Source: s.name})
+		}
+	}
+	return hostnames, nil
+}
+
+func TestRegistry_ValidateAll(t *testing.T) {
+	r := NewRegistry(testLogger())
+	_ = r.Register(&labelSource{mockSource{
+		name:              "labels",
+		supportsDiscovery: true,
+		discoverHostnames: []Hostname{
+			{Name: "app.example.com", Source: "labels"},
+			{Name: "file.example.com", Source: "labels"},
+			{Name: "bad_file..example.com", Source: "labels"},
+		},
+	}})
+
+	workloads := []docker.Workload{
+		{Name: "web", Labels: map[string]string{"hosts": "App.Example.com,-bad.example.com"}},
+		{Name: "api", Labels: map[string]string{"hosts": "api.example.com,app.example.com"}},
+		{Name: "none", Labels: map[string]string{}},
+	}
+
+	report := r.ValidateAll(context.Background(), workloads)
+
+	wantValid := []string{"app.example.com", "api.example.com", "file.example.com"}
+	if got := report.Valid.Names(); strings.Join(got, " ") != strings.Join(wantValid, " ") {
+		t.Errorf("Valid = %v, want %v", got, wantValid)
+	}
+
+	if len(report.Invalid) != 2 {
+		t.Fatalf("expected 2 invalid hostnames, got %d: %v", len(report.Invalid), report.Invalid)
+	}
+	if report.Invalid[0].Workload != "web" || report.Invalid[0].Error == nil {
+		t.Errorf("Invalid[0] = %+v, want workload web with an error", report.Invalid[0])
+	}
+	if report.Invalid[1].Workload != "" {
+		t.Errorf("Invalid[1].Workload = %q, want empty for discovered hostname", report.Invalid[1].Workload)
+	}
+
+	if len(report.Duplicates) != 1 {
+		t.Fatalf("expected 1 duplicate, got %d", len(report.Duplicates))
+	}
+	dup := report.Duplicates[0]
+	if dup.Hostname.Name != "app.example.com" || dup.Workload != "api" || dup.FirstWorkload != "web" {
+		t.Errorf("Duplicates[0] = %+v, want app.example.com from api (first: web)", dup)
+	}
+
+	if !report.HasProblems() {
+		t.Error("expected HasProblems() to be true")
+	}
+}
+
+func TestRegistry_ValidateAll_Clean(t *testing.T) {
+	r := NewRegistry(testLogger())
+	_ = r.Register(&labelSource{mockSource{name: "labels"}})
+
+	report := r.ValidateAll(context.Background(), []docker.Workload{
+		{Name: "web", Labels: map[string]string{"hosts": "app.example.com"}},
+	})
+
+	if report.HasProblems() {
+		t.Errorf("expected no problems, got invalid=%v duplicates=%v", report.Invalid, report.Duplicates)
+	}
+	if len(report.Valid) != 1 {
+		t.Errorf("expected 1 valid hostname, got %d", len(report.Valid))
+	}
+}
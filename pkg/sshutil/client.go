@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 // Sentinel errors for SSH operations.
@@ -37,6 +38,7 @@ type Client struct {
 
 	mu      sync.RWMutex
 	conn    *ssh.Client
+	jump    *ssh.Client        // jump host connection when ProxyJump is set
 	connCtx context.Context    //nolint:containedctx // connection lifetime context
 	cancel  context.CancelFunc // cancel function for connection context
 }
@@ -98,35 +100,26 @@ func (c *Client) Connect(ctx context.Context) error {
 	)
 
 	// Create a context for the connection attempt with timeout
-	timeout := c.config.GetTimeout()
-	dialCtx, dialCancel := context.WithTimeout(ctx, timeout)
+	dialCtx, dialCancel := context.WithTimeout(ctx, c.config.GetTimeout())
 	defer dialCancel()
 
-	// Dial with context-aware connection
-	dialer := &net.Dialer{
-		Timeout: timeout,
-	}
-
-	netConn, err := dialer.DialContext(dialCtx, "tcp", c.config.Address())
-	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			return ErrConnectionTimeout
+	// Dial the server directly, or through the jump host's connection
+	dial := c.dialTCP
+	if c.config.ProxyJump != "" {
+		jump, err := c.connectJump(dialCtx, sshConfig)
+		if err != nil {
+			return err
 		}
-		return fmt.Errorf("dialing %s: %w", c.config.Address(), err)
+		c.jump = jump
+		dial = jump.DialContext
 	}
 
-	// Perform SSH handshake
-	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, c.config.Address(), sshConfig)
+	conn, err := c.dialSSH(dialCtx, dial, c.config.Address(), sshConfig)
 	if err != nil {
-		_ = netConn.Close() // Best effort cleanup
-		// Check for authentication failures
-		if isAuthError(err) {
-			return fmt.Errorf("%w: %w", ErrAuthenticationFailed, err)
-		}
-		return fmt.Errorf("SSH handshake failed: %w", err)
+		c.closeJump()
+		return err
 	}
-
-	c.conn = ssh.NewClient(sshConn, chans, reqs)
+	c.conn = conn
 
 	// Create connection context for keepalive management
 	c.connCtx, c.cancel = context.WithCancel(context.Background())
@@ -161,6 +154,7 @@ func (c *Client) Close() error {
 
 	err := c.conn.Close()
 	c.conn = nil
+	c.closeJump()
 
 	c.logger.Debug("SSH connection closed",
 		slog.String("host", c.config.Host),
@@ -190,6 +184,74 @@ func (c *Client) GetConnection() (*ssh.Client, error) {
 	return c.conn, nil
 }
 
+// connectJump connects to the ProxyJump host with the same credentials and
+// host key verification as the target server.
+func (c *Client) connectJump(ctx context.Context, sshConfig *ssh.ClientConfig) (*ssh.Client, error) {
+	user, addr, err := c.config.jumpHost()
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Debug("connecting to SSH jump host",
+		slog.String("jump_host", addr),
+		slog.String("user", user),
+	)
+
+	jumpConfig := *sshConfig
+	jumpConfig.User = user
+
+	jump, err := c.dialSSH(ctx, c.dialTCP, addr, &jumpConfig)
+	if err != nil {
+		return nil, fmt.Errorf("jump host %s: %w", addr, err)
+	}
+	return jump, nil
+}
+
+// closeJump closes the jump host connection, if any.
+func (c *Client) closeJump() {
+	if c.jump != nil {
+		_ = c.jump.Close() // Best effort cleanup
+		c.jump = nil
+	}
+}
+
+// dialTCP opens a TCP connection to addr.
+func (c *Client) dialTCP(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout: c.config.GetTimeout(),
+	}
+	return dialer.DialContext(ctx, network, addr)
+}
+
+// dialSSH opens a connection to addr with dial and performs the SSH handshake.
+func (c *Client) dialSSH(
+	ctx context.Context,
+	dial func(ctx context.Context, network, addr string) (net.Conn, error),
+	addr string,
+	sshConfig *ssh.ClientConfig,
+) (*ssh.Client, error) {
+	netConn, err := dial(ctx, "tcp", addr)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, ErrConnectionTimeout
+		}
+		return nil, fmt.Errorf("dialing %s: %w", addr, err)
+	}
+
+	// Perform SSH handshake
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, addr, sshConfig)
+	if err != nil {
+		_ = netConn.Close() // Best effort cleanup
+		// Check for authentication failures
+		if isAuthError(err) {
+			return nil, fmt.Errorf("%w: %w", ErrAuthenticationFailed, err)
+		}
+		return nil, fmt.Errorf("SSH handshake failed: %w", err)
+	}
+
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
 // Reconnect closes any existing connection and establishes a new one.
 func (c *Client) Reconnect(ctx context.Context) error {
 	if err := c.Close(); err != nil {
@@ -276,18 +338,23 @@ func (c *Client) parsePrivateKey(keyData []byte) (ssh.Signer, error) {
 }
 
 // buildHostKeyCallback creates the host key callback based on config.
+// A configured known_hosts file always enables verification; strict checking
+// without one is refused.
 func (c *Client) buildHostKeyCallback() (ssh.HostKeyCallback, error) {
-	// If strict host key checking is enabled, require a valid host key callback configuration
-	if c.config.StrictHostKeyChecking {
-		if c.config.HostKeyCallback == "" {
-			// TODO: Add support for loading from known_hosts file
-			return nil, errors.New("strict host key checking enabled but no known_hosts file configured - set HOST_KEY_CALLBACK to a known_hosts file path")
-		}
-		if c.config.HostKeyCallback == "ignore" {
-			return nil, errors.New("strict host key checking enabled but HOST_KEY_CALLBACK is set to 'ignore' - these settings conflict")
+	if c.config.StrictHostKeyChecking && c.config.HostKeyCallback == "ignore" {
+		return nil, errors.New("strict host key checking enabled but HOST_KEY_CALLBACK is set to 'ignore' - these settings conflict")
+	}
+
+	if c.config.KnownHostsFile != "" {
+		callback, err := knownhosts.New(c.config.KnownHostsFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading known_hosts file %s: %w", c.config.KnownHostsFile, err)
 		}
-		// TODO: Load from known_hosts file at c.config.HostKeyCallback path
-		return nil, errors.New("strict host key checking enabled but known_hosts loading not yet implemented")
+		return callback, nil
+	}
+
+	if c.config.StrictHostKeyChecking {
+		return nil, errors.New("strict host key checking enabled but no known_hosts file configured - set KNOWN_HOSTS_FILE")
 	}
 
 	// Strict checking disabled - use insecure mode
@@ -2,11 +2,20 @@ package sshutil
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
+	"io"
 	"log/slog"
+	"net"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 // containsIgnoreCase is a test helper for case-insensitive substring check.
@@ -306,6 +315,90 @@ func TestClient_buildHostKeyCallback(t *testing.T) {
 			t.Error("buildHostKeyCallback() expected error when strict checking enabled without known_hosts")
 		}
 	})
+
+	t.Run("known_hosts file verifies keys", func(t *testing.T) {
+		hostKey := newTestPublicKey(t)
+		otherKey := newTestPublicKey(t)
+
+		path := filepath.Join(t.TempDir(), "known_hosts")
+		line := knownhosts.Line([]string{knownhosts.Normalize("example.com:22")}, hostKey)
+		if err := os.WriteFile(path, []byte(line+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		config := &Config{
+			Host:                  "example.com",
+			User:                  "admin",
+			Password:              "secret",
+			KnownHostsFile:        path,
+			StrictHostKeyChecking: true,
+		}
+
+		client, _ := NewClient(config)
+		callback, err := client.buildHostKeyCallback()
+		if err != nil {
+			t.Fatalf("buildHostKeyCallback() error = %v", err)
+		}
+
+		addr := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 22}
+		if err := callback("example.com:22", addr, hostKey); err != nil {
+			t.Errorf("callback() rejected known key: %v", err)
+		}
+		if err := callback("example.com:22", addr, otherKey); err == nil {
+			t.Error("callback() accepted a mismatched key")
+		}
+	})
+
+	t.Run("missing known_hosts file", func(t *testing.T) {
+		config := &Config{
+			Host:           "example.com",
+			User:           "admin",
+			Password:       "secret",
+			KnownHostsFile: filepath.Join(t.TempDir(), "missing"),
+		}
+
+		client, _ := NewClient(config)
+		if _, err := client.buildHostKeyCallback(); err == nil {
+			t.Error("buildHostKeyCallback() expected error for missing known_hosts file")
+		}
+	})
+}
+
+func TestClient_Connect_StrictWithoutKnownHosts(t *testing.T) {
+	config := &Config{
+		Host:                  "127.0.0.1",
+		Port:                  1,
+		User:                  "admin",
+		Password:              "secret",
+		StrictHostKeyChecking: true,
+	}
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	err = client.Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "KNOWN_HOSTS_FILE") {
+		t.Errorf("Connect() error = %v, want known_hosts error", err)
+	}
+	if client.IsConnected() {
+		t.Error("client should not be connected")
+	}
+}
+
+// newTestPublicKey generates a throwaway ed25519 public key.
+func newTestPublicKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
 }
 
 func TestIsAuthError(t *testing.T) {
@@ -433,3 +526,153 @@ func TestClient_Connect_InvalidHost(t *testing.T) {
 	// The error could be a DNS resolution failure or connection timeout
 	// Either is acceptable for this test
 }
+
+// startTestSSHServer runs an SSH server on localhost that accepts password
+// "secret" and forwards direct-tcpip channels, recording their targets.
+func startTestSSHServer(t *testing.T) (addr string, forwarded chan string) {
+	t.Helper()
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverConfig := &ssh.ServerConfig{
+		PasswordCallback: func(_ ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if string(password) != "secret" {
+				return nil, errors.New("wrong password")
+			}
+			return nil, nil
+		},
+	}
+	serverConfig.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	forwarded = make(chan string, 4)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSHConn(conn, serverConfig, forwarded)
+		}
+	}()
+
+	return listener.Addr().String(), forwarded
+}
+
+func serveTestSSHConn(conn net.Conn, config *ssh.ServerConfig, forwarded chan<- string) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newChan := range chans {
+		if newChan.ChannelType() != "direct-tcpip" {
+			_ = newChan.Reject(ssh.UnknownChannelType, "unsupported")
+			continue
+		}
+		var target struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(newChan.ExtraData(), &target); err != nil {
+			_ = newChan.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		addr := net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port)))
+		upstream, err := net.Dial("tcp", addr)
+		if err != nil {
+			_ = newChan.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, chanReqs, err := newChan.Accept()
+		if err != nil {
+			_ = upstream.Close()
+			continue
+		}
+		go ssh.DiscardRequests(chanReqs)
+		forwarded <- addr
+		go func() {
+			_, _ = io.Copy(channel, upstream)
+			_ = channel.Close()
+		}()
+		go func() {
+			_, _ = io.Copy(upstream, channel)
+			_ = upstream.Close()
+		}()
+	}
+}
+
+func TestClient_Connect_ProxyJump(t *testing.T) {
+	jumpAddr, forwarded := startTestSSHServer(t)
+	targetAddr, _ := startTestSSHServer(t)
+
+	host, portStr, _ := net.SplitHostPort(targetAddr)
+	port, _ := strconv.Atoi(portStr)
+
+	client, err := NewClient(&Config{
+		Host:      host,
+		Port:      port,
+		User:      "admin",
+		Password:  "secret",
+		ProxyJump: "bastion@" + jumpAddr,
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	select {
+	case addr := <-forwarded:
+		if addr != targetAddr {
+			t.Errorf("jump host forwarded to %s, want %s", addr, targetAddr)
+		}
+	default:
+		t.Error("connection did not go through the jump host")
+	}
+
+	if err := client.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if client.jump != nil {
+		t.Error("Close() did not close the jump host connection")
+	}
+}
+
+func TestClient_Connect_ProxyJumpUnreachable(t *testing.T) {
+	client, err := NewClient(&Config{
+		Host:      "10.0.0.1",
+		User:      "admin",
+		Password:  "secret",
+		ProxyJump: "127.0.0.1:1",
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	err = client.Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "jump host 127.0.0.1:1") {
+		t.Errorf("Connect() error = %v, want jump host error", err)
+	}
+	if client.IsConnected() {
+		t.Error("IsConnected() = true after failed jump")
+	}
+}
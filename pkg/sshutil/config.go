@@ -14,6 +14,7 @@ package sshutil
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strconv"
 	"strings"
@@ -70,13 +71,25 @@ type Config struct {
 	// HostKeyCallback controls host key verification.
 	// If empty, host keys are not verified (InsecureIgnoreHostKey).
 	// Supported values: "ignore" (insecure), or path to known_hosts file.
+	// A path is treated as KnownHostsFile when that is not set.
 	HostKeyCallback string
 
+	// KnownHostsFile is the path to an OpenSSH known_hosts file.
+	// When set, host keys are always verified against it.
+	KnownHostsFile string
+
 	// StrictHostKeyChecking controls whether to verify host keys.
-	// If false (default when HostKeyCallback is empty), host keys are not verified.
+	// If true, KnownHostsFile is required and Connect fails without it.
+	// If false and KnownHostsFile is empty, host keys are not verified.
 	// WARNING: Disabling host key checking is insecure and should only be used
 	// for testing or when connecting to trusted internal networks.
 	StrictHostKeyChecking bool
+
+	// ProxyJump is a bastion host to connect through, in [user@]host[:port]
+	// format, like ssh -J (optional). The jump host is authenticated with the
+	// same credentials and host key verification as Host; user defaults to
+	// User and port to 22.
+	ProxyJump string
 }
 
 // Validate checks that all required configuration is present and valid.
@@ -108,6 +121,12 @@ func (c *Config) Validate() error {
 		errs = append(errs, "keepalive_interval must be non-negative")
 	}
 
+	if c.ProxyJump != "" {
+		if _, _, err := c.jumpHost(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("ssh config validation failed: %s", strings.Join(errs, "; "))
 	}
@@ -115,6 +134,14 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// applyLegacyHostKeyCallback uses a HostKeyCallback path as the known_hosts
+// file when KnownHostsFile is not set.
+func (c *Config) applyLegacyHostKeyCallback() {
+	if c.KnownHostsFile == "" && c.HostKeyCallback != "" && c.HostKeyCallback != "ignore" {
+		c.KnownHostsFile = c.HostKeyCallback
+	}
+}
+
 // Address returns the SSH server address in host:port format.
 func (c *Config) Address() string {
 	port := c.Port
@@ -124,6 +151,29 @@ func (c *Config) Address() string {
 	return fmt.Sprintf("%s:%d", c.Host, port)
 }
 
+// jumpHost parses ProxyJump into the jump host user and host:port address.
+func (c *Config) jumpHost() (user, addr string, err error) {
+	user, hostPort := c.User, c.ProxyJump
+	if i := strings.LastIndex(hostPort, "@"); i >= 0 {
+		user, hostPort = hostPort[:i], hostPort[i+1:]
+	}
+
+	host, port := hostPort, strconv.Itoa(DefaultSSHPort)
+	if h, p, err := net.SplitHostPort(hostPort); err == nil {
+		host, port = h, p
+	}
+	host = strings.Trim(host, "[]")
+
+	if user == "" || host == "" {
+		return "", "", fmt.Errorf("proxy_jump %q must be in [user@]host[:port] format", c.ProxyJump)
+	}
+	if n, convErr := strconv.Atoi(port); convErr != nil || n < 1 || n > 65535 {
+		return "", "", fmt.Errorf("proxy_jump %q: port must be between 1 and 65535", c.ProxyJump)
+	}
+
+	return user, net.JoinHostPort(host, port), nil
+}
+
 // GetTimeout returns the configured timeout or the default.
 func (c *Config) GetTimeout() time.Duration {
 	if c.Timeout > 0 {
@@ -154,7 +204,9 @@ func (c *Config) GetKeepaliveInterval() time.Duration {
 //   - TIMEOUT: Connection timeout in seconds (default: 30)
 //   - KEEPALIVE_INTERVAL: Keepalive interval in seconds (default: 15, 0 to disable)
 //   - HOST_KEY_CALLBACK: "ignore" or path to known_hosts file
+//   - KNOWN_HOSTS_FILE: Path to known_hosts file; enables host key verification
 //   - STRICT_HOST_KEY_CHECKING: "true" or "false" (default: false)
+//   - PROXY_JUMP: Bastion host to connect through, in [user@]host[:port] format
 func LoadConfig(prefix string) (*Config, error) {
 	config := &Config{
 		Host:                  getEnv(prefix + "HOST"),
@@ -164,8 +216,11 @@ func LoadConfig(prefix string) (*Config, error) {
 		KeyPassphrase:         getEnvOrFile(prefix+"KEY_PASSPHRASE", prefix+"KEY_PASSPHRASE_FILE"),
 		Password:              getEnvOrFile(prefix+"PASSWORD", prefix+"PASSWORD_FILE"),
 		HostKeyCallback:       getEnv(prefix + "HOST_KEY_CALLBACK"),
+		KnownHostsFile:        getEnv(prefix + "KNOWN_HOSTS_FILE"),
+		ProxyJump:             getEnv(prefix + "PROXY_JUMP"),
 		StrictHostKeyChecking: false,
 	}
+	config.applyLegacyHostKeyCallback()
 
 	// Parse port
 	if portStr := getEnv(prefix + "PORT"); portStr != "" {
@@ -213,7 +268,8 @@ func LoadConfig(prefix string) (*Config, error) {
 // configuration that was already parsed from environment variables.
 //
 // Required keys: HOST, USER, and at least one of KEY_FILE/KEY_DATA/PASSWORD
-// Optional keys: PORT, TIMEOUT, KEEPALIVE_INTERVAL, KEY_PASSPHRASE, HOST_KEY_CALLBACK, STRICT_HOST_KEY_CHECKING
+// Optional keys: PORT, TIMEOUT, KEEPALIVE_INTERVAL, KEY_PASSPHRASE, HOST_KEY_CALLBACK, KNOWN_HOSTS_FILE,
+// STRICT_HOST_KEY_CHECKING, PROXY_JUMP
 func LoadConfigFromMap(configMap map[string]string) (*Config, error) {
 	config := &Config{
 		Host:                  configMap["HOST"],
@@ -223,9 +279,12 @@ func LoadConfigFromMap(configMap map[string]string) (*Config, error) {
 		KeyPassphrase:         configMap["KEY_PASSPHRASE"],
 		Password:              configMap["PASSWORD"],
 		HostKeyCallback:       configMap["HOST_KEY_CALLBACK"],
+		KnownHostsFile:        configMap["KNOWN_HOSTS_FILE"],
+		ProxyJump:             configMap["PROXY_JUMP"],
 		StrictHostKeyChecking: false,
 		Port:                  DefaultSSHPort,
 	}
+	config.applyLegacyHostKeyCallback()
 
 	// Parse port
 	if portStr, ok := configMap["PORT"]; ok && portStr != "" {
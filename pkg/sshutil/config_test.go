@@ -285,6 +285,37 @@ func TestLoadConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("known_hosts file from env", func(t *testing.T) {
+		t.Setenv(prefix+"HOST", "test.example.com")
+		t.Setenv(prefix+"USER", "testuser")
+		t.Setenv(prefix+"PASSWORD", "testpass")
+		t.Setenv(prefix+"KNOWN_HOSTS_FILE", "/etc/ssh/ssh_known_hosts")
+
+		config, err := LoadConfig(prefix)
+		if err != nil {
+			t.Fatalf("LoadConfig() error = %v", err)
+		}
+		if config.KnownHostsFile != "/etc/ssh/ssh_known_hosts" {
+			t.Errorf("KnownHostsFile = %v, want %v", config.KnownHostsFile, "/etc/ssh/ssh_known_hosts")
+		}
+	})
+
+	t.Run("host key callback path used as known_hosts file", func(t *testing.T) {
+		t.Setenv(prefix+"HOST", "test.example.com")
+		t.Setenv(prefix+"USER", "testuser")
+		t.Setenv(prefix+"PASSWORD", "testpass")
+		t.Setenv(prefix+"KNOWN_HOSTS_FILE", "")
+		t.Setenv(prefix+"HOST_KEY_CALLBACK", "/home/user/.ssh/known_hosts")
+
+		config, err := LoadConfig(prefix)
+		if err != nil {
+			t.Fatalf("LoadConfig() error = %v", err)
+		}
+		if config.KnownHostsFile != "/home/user/.ssh/known_hosts" {
+			t.Errorf("KnownHostsFile = %v, want %v", config.KnownHostsFile, "/home/user/.ssh/known_hosts")
+		}
+	})
+
 	t.Run("default port when not set", func(t *testing.T) {
 		os.Unsetenv(prefix + "PORT")
 		os.Setenv(prefix+"HOST", "test.example.com")
@@ -339,6 +370,7 @@ func TestLoadConfigFromMap(t *testing.T) {
 			"TIMEOUT":                  "45",
 			"KEEPALIVE_INTERVAL":       "20",
 			"STRICT_HOST_KEY_CHECKING": "true",
+			"PROXY_JUMP":               "jump@bastion.example.com",
 		}
 
 		config, err := LoadConfigFromMap(configMap)
@@ -367,6 +399,9 @@ func TestLoadConfigFromMap(t *testing.T) {
 		if !config.StrictHostKeyChecking {
 			t.Errorf("StrictHostKeyChecking = %v, want %v", config.StrictHostKeyChecking, true)
 		}
+		if config.ProxyJump != "jump@bastion.example.com" {
+			t.Errorf("ProxyJump = %v, want %v", config.ProxyJump, "jump@bastion.example.com")
+		}
 	})
 
 	t.Run("defaults when optional fields missing", func(t *testing.T) {
@@ -467,3 +502,39 @@ func TestGetEnvOrFile(t *testing.T) {
 		}
 	})
 }
+
+func TestConfig_jumpHost(t *testing.T) {
+	tests := []struct {
+		proxyJump string
+		wantUser  string
+		wantAddr  string
+		wantErr   bool
+	}{
+		{proxyJump: "bastion.example.com", wantUser: "admin", wantAddr: "bastion.example.com:22"},
+		{proxyJump: "jump@bastion.example.com:2222", wantUser: "jump", wantAddr: "bastion.example.com:2222"},
+		{proxyJump: "jump@[2001:db8::1]:2222", wantUser: "jump", wantAddr: "[2001:db8::1]:2222"},
+		{proxyJump: "@bastion.example.com", wantErr: true},
+		{proxyJump: "jump@", wantErr: true},
+		{proxyJump: "bastion.example.com:ssh", wantErr: true},
+		{proxyJump: "bastion.example.com:70000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.proxyJump, func(t *testing.T) {
+			c := &Config{Host: "ns1.internal", User: "admin", Password: "secret", ProxyJump: tt.proxyJump}
+			user, addr, err := c.jumpHost()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("jumpHost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if c.Validate() == nil {
+					t.Error("Validate() expected error for invalid proxy_jump")
+				}
+				return
+			}
+			if user != tt.wantUser || addr != tt.wantAddr {
+				t.Errorf("jumpHost() = %q, %q, want %q, %q", user, addr, tt.wantUser, tt.wantAddr)
+			}
+		})
+	}
+}
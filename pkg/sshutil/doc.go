@@ -84,8 +84,9 @@
 //
 // By default, the package disables strict host key checking for ease of use
 // in internal networks. For production environments with stricter security
-// requirements, enable host key verification by setting StrictHostKeyChecking
-// to true and providing a known_hosts file path.
+// requirements, set KnownHostsFile (KNOWN_HOSTS_FILE) to an OpenSSH known_hosts
+// file, and set StrictHostKeyChecking so that Connect refuses to proceed if the
+// file is ever left unconfigured.
 //
 // SSH key-based authentication is strongly recommended over password authentication.
 // When using Docker secrets, store keys in mounted secret files rather than
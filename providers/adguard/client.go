@@ -0,0 +1,158 @@
+package adguard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// rewrite is a single AdGuard Home DNS rewrite rule.
+type rewrite struct {
+	Domain string `json:"domain"`
+	Answer string `json:"answer"`
+}
+
+// Client is an AdGuard Home API client.
+type Client struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// ClientOption is a functional option for configuring the Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// NewClient creates a new AdGuard Home API client.
+func NewClient(baseURL, username, password string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		username:   username,
+		password:   password,
+		httpClient: httputil.DefaultClient(),
+		logger:     slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// doRequest performs an HTTP request against the AdGuard Home API and decodes
+// a JSON response into out (if non-nil).
+func (c *Client) doRequest(ctx context.Context, method, path string, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	c.logger.Debug("making API request",
+		slog.String("method", method),
+		slog.String("path", path),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail := strings.TrimSpace(string(respBody))
+		if base := provider.ErrorForStatus(resp.StatusCode); base != nil {
+			return fmt.Errorf("%w: status %d: %s", base, resp.StatusCode, detail)
+		}
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, detail)
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("parsing response JSON: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Ping checks connectivity and credentials by fetching the server status.
+func (c *Client) Ping(ctx context.Context) error {
+	if err := c.doRequest(ctx, http.MethodGet, "/control/status", nil, nil); err != nil {
+		return fmt.Errorf("ping failed: %w", err)
+	}
+	return nil
+}
+
+// ListRewrites returns all DNS rewrites.
+func (c *Client) ListRewrites(ctx context.Context) ([]rewrite, error) {
+	var rewrites []rewrite
+	if err := c.doRequest(ctx, http.MethodGet, "/control/rewrite/list", nil, &rewrites); err != nil {
+		return nil, fmt.Errorf("listing rewrites: %w", err)
+	}
+	return rewrites, nil
+}
+
+// AddRewrite adds a DNS rewrite.
+func (c *Client) AddRewrite(ctx context.Context, r rewrite) error {
+	if err := c.doRequest(ctx, http.MethodPost, "/control/rewrite/add", r, nil); err != nil {
+		return fmt.Errorf("adding rewrite for %s: %w", r.Domain, err)
+	}
+	return nil
+}
+
+// DeleteRewrite removes a DNS rewrite. AdGuard Home's API takes the rewrite
+// to delete as a POST body rather than using the DELETE method.
+func (c *Client) DeleteRewrite(ctx context.Context, r rewrite) error {
+	if err := c.doRequest(ctx, http.MethodPost, "/control/rewrite/delete", r, nil); err != nil {
+		return fmt.Errorf("deleting rewrite for %s: %w", r.Domain, err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Package adguard implements the DNSWeaver provider interface for AdGuard Home
+// DNS rewrites.
+package adguard
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Config holds AdGuard Home-specific configuration.
+type Config struct {
+	URL      string // AdGuard Home base URL (e.g., http://adguard:3000)
+	Username string // Admin username for HTTP basic auth
+	Password string // Admin password for HTTP basic auth
+
+	InsecureSkipVerify bool // Skip TLS certificate verification (use with caution)
+}
+
+// Validate checks that all required configuration is present.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.URL == "" {
+		errs = append(errs, "URL is required")
+	} else if !strings.HasPrefix(c.URL, "http://") && !strings.HasPrefix(c.URL, "https://") {
+		errs = append(errs, "URL must start with http:// or https://")
+	}
+	if c.Username == "" {
+		errs = append(errs, "USERNAME is required")
+	}
+	if c.Password == "" {
+		errs = append(errs, "PASSWORD is required")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("adguard config validation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// LoadConfig loads AdGuard Home configuration from environment variables.
+// Environment variable pattern: DNSWEAVER_{INSTANCE_NAME}_{SETTING}
+//
+// Instance names are normalized: lowercase with hyphens becomes uppercase with underscores.
+// Example: "adguard" looks for DNSWEAVER_ADGUARD_*
+//
+// Supported settings:
+//   - URL: AdGuard Home base URL (required)
+//   - USERNAME: Admin username (required)
+//   - PASSWORD: Admin password (required, supports _FILE suffix for Docker secrets)
+//   - INSECURE_SKIP_VERIFY (alias SKIP_TLS_VERIFY): Skip TLS verification (optional)
+func LoadConfig(instanceName string) (*Config, error) {
+	prefix := envPrefix(instanceName)
+
+	return LoadConfigFromMap(instanceName, map[string]string{
+		"URL":                  getEnv(prefix + "URL"),
+		"USERNAME":             getEnv(prefix + "USERNAME"),
+		"PASSWORD":             getEnvOrFile(prefix+"PASSWORD", prefix+"PASSWORD_FILE"),
+		"INSECURE_SKIP_VERIFY": getEnv(prefix + "INSECURE_SKIP_VERIFY"),
+		"SKIP_TLS_VERIFY":      getEnv(prefix + "SKIP_TLS_VERIFY"),
+	})
+}
+
+// LoadConfigFromMap creates a Config from a map of key-value pairs.
+// This is used by the provider registry to create instances from
+// configuration that was already parsed from environment variables.
+//
+// Required keys: URL, USERNAME, PASSWORD
+// Optional keys: INSECURE_SKIP_VERIFY (alias SKIP_TLS_VERIFY)
+func LoadConfigFromMap(instanceName string, configMap map[string]string) (*Config, error) {
+	config := &Config{
+		URL:      strings.TrimSuffix(configMap["URL"], "/"),
+		Username: configMap["USERNAME"],
+		Password: configMap["PASSWORD"],
+	}
+
+	// SKIP_TLS_VERIFY is accepted as an alias for INSECURE_SKIP_VERIFY
+	for _, key := range []string{"INSECURE_SKIP_VERIFY", "SKIP_TLS_VERIFY"} {
+		if v := configMap[key]; v != "" {
+			config.InsecureSkipVerify = strings.EqualFold(v, "true") || v == "1"
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
+	}
+
+	return config, nil
+}
+
+// envPrefix converts an instance name to an environment variable prefix.
+// Example: "adguard" → "DNSWEAVER_ADGUARD_"
+func envPrefix(instanceName string) string {
+	normalized := strings.ToUpper(instanceName)
+	normalized = strings.ReplaceAll(normalized, "-", "_")
+	return "DNSWEAVER_" + normalized + "_"
+}
+
+// getEnv retrieves an environment variable value.
+func getEnv(key string) string {
+	return os.Getenv(key)
+}
+
+// getEnvOrFile retrieves a value from either a direct environment variable
+// or a file path specified by the file key (Docker secrets pattern).
+//
+// If both are set, the file takes precedence.
+// The file contents are trimmed of leading/trailing whitespace.
+func getEnvOrFile(directKey, fileKey string) string {
+	// Check for file-based secret first (Docker secrets pattern)
+	if filePath := os.Getenv(fileKey); filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+		// If file read fails, fall through to direct value
+	}
+
+	return os.Getenv(directKey)
+}
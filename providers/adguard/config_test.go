@@ -0,0 +1,71 @@
+package adguard
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigFromMap(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		c, err := LoadConfigFromMap("adguard", map[string]string{
+			"URL":             "http://adguard:3000/",
+			"USERNAME":        "admin",
+			"PASSWORD":        "secret",
+			"SKIP_TLS_VERIFY": "true",
+		})
+		if err != nil {
+			t.Fatalf("LoadConfigFromMap() unexpected error: %v", err)
+		}
+		if c.URL != "http://adguard:3000" {
+			t.Errorf("URL = %q, want trailing slash trimmed", c.URL)
+		}
+		if !c.InsecureSkipVerify {
+			t.Error("InsecureSkipVerify = false, want true from SKIP_TLS_VERIFY alias")
+		}
+	})
+
+	t.Run("missing required", func(t *testing.T) {
+		_, err := LoadConfigFromMap("adguard", map[string]string{})
+		if err == nil {
+			t.Fatal("LoadConfigFromMap() expected error")
+		}
+		for _, want := range []string{"URL is required", "USERNAME is required", "PASSWORD is required"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error %q missing %q", err, want)
+			}
+		}
+	})
+
+	t.Run("invalid URL scheme", func(t *testing.T) {
+		_, err := LoadConfigFromMap("adguard", map[string]string{
+			"URL": "adguard:3000", "USERNAME": "admin", "PASSWORD": "secret",
+		})
+		if err == nil || !strings.Contains(err.Error(), "http://") {
+			t.Errorf("LoadConfigFromMap() error = %v, want URL scheme error", err)
+		}
+	})
+}
+
+func TestLoadConfig_FromEnv(t *testing.T) {
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("DNSWEAVER_HOME_ADGUARD_URL", "https://adguard.example.com")
+	t.Setenv("DNSWEAVER_HOME_ADGUARD_USERNAME", "admin")
+	t.Setenv("DNSWEAVER_HOME_ADGUARD_PASSWORD_FILE", passwordFile)
+
+	c, err := LoadConfig("home-adguard")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	if c.Password != "from-file" {
+		t.Errorf("Password = %q, want from-file", c.Password)
+	}
+	if c.Username != "admin" {
+		t.Errorf("Username = %q, want admin", c.Username)
+	}
+}
@@ -0,0 +1,43 @@
+package adguard
+
+import (
+	"log/slog"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Factory returns a provider.Factory for creating AdGuard Home provider instances.
+// This is the recommended way to register the AdGuard Home provider with the registry.
+func Factory() provider.Factory {
+	return func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		// Parse provider-specific configuration from the map
+		providerCfg, err := LoadConfigFromMap(cfg.Name, cfg.ProviderConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		// Merge TLS skip verify: HTTP config from registry OR per-provider setting
+		tlsSkipVerify := cfg.HTTP.TLSSkipVerify || providerCfg.InsecureSkipVerify
+
+		httpClient := httputil.NewClient(&httputil.ClientConfig{
+			Timeout:       cfg.HTTP.Timeout,
+			TLSSkipVerify: tlsSkipVerify,
+			UserAgent:     cfg.HTTP.UserAgent,
+			Logger:        cfg.HTTP.Logger,
+		})
+
+		// Log warning if TLS verification is disabled
+		if tlsSkipVerify && cfg.HTTP.Logger != nil {
+			cfg.HTTP.Logger.Warn("TLS certificate verification disabled for AdGuard Home provider",
+				slog.String("provider", cfg.Name),
+				slog.String("url", providerCfg.URL),
+			)
+		}
+
+		return New(cfg.Name, providerCfg,
+			WithProviderHTTPClient(httpClient),
+			WithProviderLogger(cfg.HTTP.Logger),
+		)
+	}
+}
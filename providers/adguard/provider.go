@@ -0,0 +1,255 @@
+package adguard
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Provider implements provider.Provider for AdGuard Home DNS rewrites.
+//
+// A rewrite maps a domain to an answer: an IP address (served as A or AAAA)
+// or another domain (served as CNAME). Rewrites carry no record type or TTL,
+// so the type is inferred from the answer and TTLs are ignored.
+type Provider struct {
+	name       string
+	client     *Client
+	httpClient *http.Client // Custom HTTP client (optional)
+	logger     *slog.Logger
+}
+
+// ProviderOption is a functional option for configuring the Provider.
+type ProviderOption func(*Provider)
+
+// WithProviderLogger sets a custom logger for the provider.
+func WithProviderLogger(logger *slog.Logger) ProviderOption {
+	return func(p *Provider) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// WithProviderHTTPClient sets a custom HTTP client for API requests.
+func WithProviderHTTPClient(client *http.Client) ProviderOption {
+	return func(p *Provider) {
+		p.httpClient = client
+	}
+}
+
+// New creates a new AdGuard Home provider instance.
+func New(name string, config *Config, opts ...ProviderOption) (*Provider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		name:   name,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.client = NewClient(config.URL, config.Username, config.Password,
+		WithHTTPClient(p.httpClient),
+		WithLogger(p.logger),
+	)
+
+	return p, nil
+}
+
+// NewFromEnv creates a new AdGuard Home provider from environment variables.
+// This is a convenience function for use with the provider registry.
+func NewFromEnv(instanceName string, opts ...ProviderOption) (*Provider, error) {
+	config, err := LoadConfig(instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(instanceName, config, opts...)
+}
+
+// Name returns the provider instance name.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Type returns "adguard".
+func (p *Provider) Type() string {
+	return "adguard"
+}
+
+// Capabilities returns the provider's feature support.
+// AdGuard Home rewrites only answer A, AAAA, and CNAME queries, so ownership
+// TXT records cannot be stored. A domain may have several rewrites.
+func (p *Provider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SupportsOwnershipTXT:    false,
+		SupportsNativeUpdate:    false,
+		SupportsMultipleTargets: true,
+		SupportedRecordTypes: []provider.RecordType{
+			provider.RecordTypeA,
+			provider.RecordTypeAAAA,
+			provider.RecordTypeCNAME,
+		},
+	}
+}
+
+// Ping checks connectivity to the AdGuard Home API.
+func (p *Provider) Ping(ctx context.Context) error {
+	return p.client.Ping(ctx)
+}
+
+// List returns all rewrites as A, AAAA, or CNAME records.
+func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
+	rewrites, err := p.client.ListRewrites(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing records: %w", err)
+	}
+
+	records := make([]provider.Record, 0, len(rewrites))
+	for _, rw := range rewrites {
+		rrType, ok := answerType(rw.Answer)
+		if !ok {
+			// "A" and "AAAA" answers keep the upstream response; nothing to manage
+			continue
+		}
+		hostname := strings.ToLower(strings.TrimSuffix(rw.Domain, "."))
+		target := strings.TrimSuffix(rw.Answer, ".")
+		records = append(records, provider.Record{
+			Hostname:   hostname,
+			Type:       rrType,
+			Target:     target,
+			ProviderID: hostname + ":" + target,
+		})
+	}
+
+	p.logger.Debug("listed records",
+		slog.String("provider", p.name),
+		slog.Int("count", len(records)),
+	)
+
+	return records, nil
+}
+
+// Create adds a rewrite for the record. Returns provider.ErrConflict if an
+// identical rewrite already exists.
+func (p *Provider) Create(ctx context.Context, record provider.Record) error {
+	if record.Type == provider.RecordTypeTXT {
+		// Rewrites cannot hold TXT records; skip silently
+		p.logger.Debug("skipping TXT record (not supported by AdGuard Home provider)",
+			slog.String("provider", p.name),
+			slog.String("hostname", record.Hostname),
+		)
+		return nil
+	}
+	if !p.Capabilities().SupportsRecordType(record.Type) {
+		return fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+
+	rw := rewrite{Domain: record.Hostname, Answer: record.Target}
+	exists, err := p.hasRewrite(ctx, rw)
+	if err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+	if exists {
+		return provider.ErrConflict
+	}
+
+	if err := p.client.AddRewrite(ctx, rw); err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("created record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+	)
+
+	return nil
+}
+
+// Delete removes the rewrite for the record. Deleting a rewrite that does not
+// exist is not an error.
+func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
+	if record.Type == provider.RecordTypeTXT {
+		p.logger.Debug("skipping TXT record deletion (not supported by AdGuard Home provider)",
+			slog.String("provider", p.name),
+			slog.String("hostname", record.Hostname),
+		)
+		return nil
+	}
+
+	rw := rewrite{Domain: record.Hostname, Answer: record.Target}
+	exists, err := p.hasRewrite(ctx, rw)
+	if err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+	if !exists {
+		return nil
+	}
+
+	if err := p.client.DeleteRewrite(ctx, rw); err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("deleted record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+	)
+
+	return nil
+}
+
+// hasRewrite reports whether an identical rewrite exists. Domains and
+// answers compare case-insensitively and ignore a trailing dot.
+func (p *Provider) hasRewrite(ctx context.Context, want rewrite) (bool, error) {
+	rewrites, err := p.client.ListRewrites(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, rw := range rewrites {
+		if sameName(rw.Domain, want.Domain) && sameName(rw.Answer, want.Answer) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// answerType infers the record type served for a rewrite answer.
+// Returns false for the "A" and "AAAA" keywords, which keep upstream answers.
+func answerType(answer string) (provider.RecordType, bool) {
+	if answer == "A" || answer == "AAAA" || answer == "" {
+		return "", false
+	}
+	if ip := net.ParseIP(answer); ip != nil {
+		if ip.To4() != nil {
+			return provider.RecordTypeA, true
+		}
+		return provider.RecordTypeAAAA, true
+	}
+	return provider.RecordTypeCNAME, true
+}
+
+// sameName compares two domain names or answers case-insensitively,
+// ignoring a trailing dot.
+func sameName(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
+
+// Ensure Provider implements provider.Provider at compile time.
+var _ provider.Provider = (*Provider)(nil)
@@ -0,0 +1,186 @@
+package adguard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// fakeServer is an in-memory AdGuard Home rewrite API.
+type fakeServer struct {
+	mu       sync.Mutex
+	rewrites []rewrite
+	deletes  int
+}
+
+func (f *fakeServer) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/control/status":
+			_ = json.NewEncoder(w).Encode(map[string]any{"running": true})
+		case r.Method == http.MethodGet && r.URL.Path == "/control/rewrite/list":
+			_ = json.NewEncoder(w).Encode(f.rewrites)
+		case r.Method == http.MethodPost && r.URL.Path == "/control/rewrite/add":
+			var rw rewrite
+			if err := json.NewDecoder(r.Body).Decode(&rw); err != nil {
+				t.Errorf("decoding add body: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			f.rewrites = append(f.rewrites, rw)
+		case r.Method == http.MethodPost && r.URL.Path == "/control/rewrite/delete":
+			var rw rewrite
+			if err := json.NewDecoder(r.Body).Decode(&rw); err != nil {
+				t.Errorf("decoding delete body: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			f.deletes++
+			kept := f.rewrites[:0]
+			for _, existing := range f.rewrites {
+				if existing != rw {
+					kept = append(kept, existing)
+				}
+			}
+			f.rewrites = kept
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func (f *fakeServer) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.rewrites)
+}
+
+func newTestProvider(t *testing.T, fake *fakeServer) *Provider {
+	t.Helper()
+	server := httptest.NewServer(fake.handler(t))
+	t.Cleanup(server.Close)
+
+	p, err := New("adguard", &Config{
+		URL:      server.URL,
+		Username: "admin",
+		Password: "secret",
+	})
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestProvider_Ping(t *testing.T) {
+	p := newTestProvider(t, &fakeServer{})
+	if err := p.Ping(context.Background()); err != nil {
+		t.Errorf("Ping() unexpected error: %v", err)
+	}
+
+	p.client.password = "wrong"
+	if err := p.Ping(context.Background()); !errors.Is(err, provider.ErrUnauthorized) {
+		t.Errorf("Ping() with bad password error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestProvider_List(t *testing.T) {
+	fake := &fakeServer{rewrites: []rewrite{
+		{Domain: "App.example.com", Answer: "10.0.0.1"},
+		{Domain: "app.example.com", Answer: "fd00::1"},
+		{Domain: "www.example.com", Answer: "app.example.com."},
+		{Domain: "upstream.example.com", Answer: "A"},
+	}}
+	p := newTestProvider(t, fake)
+
+	records, err := p.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("List() returned %d records, want 3 (keyword answers skipped): %+v", len(records), records)
+	}
+
+	byType := map[provider.RecordType]provider.Record{}
+	for _, r := range records {
+		byType[r.Type] = r
+	}
+	if a := byType[provider.RecordTypeA]; a.Hostname != "app.example.com" || a.Target != "10.0.0.1" {
+		t.Errorf("unexpected A record: %+v", a)
+	}
+	if aaaa := byType[provider.RecordTypeAAAA]; aaaa.Target != "fd00::1" {
+		t.Errorf("unexpected AAAA record: %+v", aaaa)
+	}
+	if cname := byType[provider.RecordTypeCNAME]; cname.Hostname != "www.example.com" || cname.Target != "app.example.com" {
+		t.Errorf("unexpected CNAME record: %+v", cname)
+	}
+}
+
+func TestProvider_CreateAndDelete(t *testing.T) {
+	fake := &fakeServer{}
+	p := newTestProvider(t, fake)
+	ctx := context.Background()
+
+	record := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"}
+
+	if err := p.Create(ctx, record); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if fake.count() != 1 {
+		t.Fatalf("rewrites after create = %d, want 1", fake.count())
+	}
+
+	if err := p.Create(ctx, record); !errors.Is(err, provider.ErrConflict) {
+		t.Errorf("duplicate Create() error = %v, want ErrConflict", err)
+	}
+
+	if err := p.Delete(ctx, record); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if fake.count() != 0 {
+		t.Fatalf("rewrites after delete = %d, want 0", fake.count())
+	}
+
+	if err := p.Delete(ctx, record); err != nil {
+		t.Errorf("Delete() of missing record unexpected error: %v", err)
+	}
+	if fake.deletes != 1 {
+		t.Errorf("delete requests = %d, want 1 (missing record should not be deleted)", fake.deletes)
+	}
+}
+
+func TestProvider_UnsupportedTypes(t *testing.T) {
+	fake := &fakeServer{}
+	p := newTestProvider(t, fake)
+	ctx := context.Background()
+
+	txt := provider.Record{Hostname: "_dnsweaver.app.example.com", Type: provider.RecordTypeTXT, Target: "heritage=dnsweaver"}
+	if err := p.Create(ctx, txt); err != nil {
+		t.Errorf("Create() TXT error = %v, want nil (skipped)", err)
+	}
+	if err := p.Delete(ctx, txt); err != nil {
+		t.Errorf("Delete() TXT error = %v, want nil (skipped)", err)
+	}
+
+	srv := provider.Record{Hostname: "_sip._tcp.example.com", Type: provider.RecordTypeSRV, Target: "sip.example.com"}
+	if err := p.Create(ctx, srv); err == nil {
+		t.Error("Create() SRV expected error")
+	}
+
+	if fake.count() != 0 {
+		t.Errorf("rewrites = %d, want 0", fake.count())
+	}
+}
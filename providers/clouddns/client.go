@@ -0,0 +1,301 @@
+package clouddns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	dns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// maxChangeSets is the most record set additions, and separately the most
+// deletions, Cloud DNS accepts in a single change.
+const maxChangeSets = 1000
+
+// cloudDNSAPI is the subset of the Cloud DNS API used by the client.
+// It is satisfied by serviceAPI and replaced by a fake in tests.
+type cloudDNSAPI interface {
+	GetManagedZone(ctx context.Context, project, zone string) (*dns.ManagedZone, error)
+	ListResourceRecordSets(ctx context.Context, project, zone, name, rrType, pageToken string) (*dns.ResourceRecordSetsListResponse, error)
+	CreateChange(ctx context.Context, project, zone string, change *dns.Change) (*dns.Change, error)
+}
+
+// serviceAPI adapts the generated Cloud DNS service to cloudDNSAPI.
+type serviceAPI struct {
+	svc *dns.Service
+}
+
+func (s *serviceAPI) GetManagedZone(ctx context.Context, project, zone string) (*dns.ManagedZone, error) {
+	return s.svc.ManagedZones.Get(project, zone).Context(ctx).Do()
+}
+
+func (s *serviceAPI) ListResourceRecordSets(ctx context.Context, project, zone, name, rrType, pageToken string) (*dns.ResourceRecordSetsListResponse, error) {
+	call := s.svc.ResourceRecordSets.List(project, zone).Context(ctx)
+	if name != "" {
+		call = call.Name(name)
+	}
+	if rrType != "" {
+		call = call.Type(rrType)
+	}
+	if pageToken != "" {
+		call = call.PageToken(pageToken)
+	}
+	return call.Do()
+}
+
+func (s *serviceAPI) CreateChange(ctx context.Context, project, zone string, change *dns.Change) (*dns.Change, error) {
+	return s.svc.Changes.Create(project, zone, change).Context(ctx).Do()
+}
+
+// rrset is a simple (no routing policy) Cloud DNS record set: all values
+// sharing a name and type, with one TTL.
+type rrset struct {
+	Name   string // Hostname without trailing dot
+	Type   string
+	TTL    int64
+	Values []string
+}
+
+// rrsetChange replaces one record set within a change. Del is the set as
+// it currently exists (nil if there is none) and Add is the set to write
+// (nil to delete it).
+type rrsetChange struct {
+	Del *rrset
+	Add *rrset
+}
+
+// Client wraps the Cloud DNS API for a single managed zone.
+type Client struct {
+	api     cloudDNSAPI
+	project string
+	zone    string
+	logger  *slog.Logger
+}
+
+// ClientOption is a functional option for configuring the Client.
+type ClientOption func(*Client)
+
+// WithLogger sets a custom logger for the client.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// NewClient creates a client for the managed zone using the given API.
+func NewClient(api cloudDNSAPI, project, zone string, opts ...ClientOption) *Client {
+	c := &Client{
+		api:     api,
+		project: project,
+		zone:    zone,
+		logger:  slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// newAPI creates a Cloud DNS API client. A configured service account key
+// is used when present; otherwise Application Default Credentials are
+// resolved. httpClient may be nil; when set, its transport and timeout are
+// kept and authentication is layered on top.
+func newAPI(ctx context.Context, config *Config, httpClient *http.Client) (*serviceAPI, error) {
+	authOpts := []option.ClientOption{option.WithScopes(dns.NdevClouddnsReadwriteScope)}
+	if config.HasCredentials() {
+		authOpts = append(authOpts, option.WithCredentialsJSON([]byte(config.Credentials)))
+	}
+
+	svcOpts := authOpts
+	if httpClient != nil {
+		base := httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		transport, err := htransport.NewTransport(ctx, base, authOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("loading Google Cloud credentials: %w", err)
+		}
+		svcOpts = []option.ClientOption{option.WithHTTPClient(&http.Client{
+			Transport: transport,
+			Timeout:   httpClient.Timeout,
+		})}
+	}
+
+	svc, err := dns.NewService(ctx, svcOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating Cloud DNS client: %w", err)
+	}
+	return &serviceAPI{svc: svc}, nil
+}
+
+// ZoneName returns the managed zone's DNS name without trailing dot.
+// It also serves as a connectivity and credentials check.
+func (c *Client) ZoneName(ctx context.Context) (string, error) {
+	zone, err := c.api.GetManagedZone(ctx, c.project, c.zone)
+	if err != nil {
+		return "", fmt.Errorf("getting managed zone %s: %w", c.zone, classify(err))
+	}
+	return decodeName(zone.DnsName), nil
+}
+
+// ListRRsets returns all simple record sets in the zone, following pagination.
+func (c *Client) ListRRsets(ctx context.Context) ([]rrset, error) {
+	sets, err := c.list(ctx, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("listing record sets: %w", err)
+	}
+
+	c.logger.Debug("listed record sets",
+		slog.String("zone", c.zone),
+		slog.Int("count", len(sets)),
+	)
+
+	return sets, nil
+}
+
+// GetRRset returns the simple record set for a name and type, or nil if
+// there is none.
+func (c *Client) GetRRset(ctx context.Context, name, rrType string) (*rrset, error) {
+	sets, err := c.list(ctx, fqdn(name), rrType)
+	if err != nil {
+		return nil, fmt.Errorf("getting %s record set for %s: %w", rrType, name, err)
+	}
+	for _, set := range sets {
+		if set.Name == decodeName(name) && set.Type == rrType {
+			return &set, nil
+		}
+	}
+	return nil, nil
+}
+
+// list returns the simple record sets matching an optional name and type.
+func (c *Client) list(ctx context.Context, name, rrType string) ([]rrset, error) {
+	var sets []rrset
+	pageToken := ""
+	for {
+		out, err := c.api.ListResourceRecordSets(ctx, c.project, c.zone, name, rrType, pageToken)
+		if err != nil {
+			return nil, classify(err)
+		}
+		for _, set := range out.Rrsets {
+			if s, ok := fromAPI(set); ok {
+				sets = append(sets, s)
+			}
+		}
+		if out.NextPageToken == "" {
+			return sets, nil
+		}
+		pageToken = out.NextPageToken
+	}
+}
+
+// ApplyChanges submits changes as Cloud DNS changes, splitting them only
+// where the per-change limits require it. Each change is applied
+// atomically, and deletions must match the current record sets exactly,
+// so a concurrent modification makes the change fail rather than be lost.
+func (c *Client) ApplyChanges(ctx context.Context, changes []rrsetChange) error {
+	for _, batch := range splitChanges(changes) {
+		change := &dns.Change{}
+		for _, rc := range batch {
+			if rc.Del != nil {
+				change.Deletions = append(change.Deletions, toAPI(*rc.Del))
+			}
+			if rc.Add != nil {
+				change.Additions = append(change.Additions, toAPI(*rc.Add))
+			}
+		}
+
+		out, err := c.api.CreateChange(ctx, c.project, c.zone, change)
+		if err != nil {
+			return fmt.Errorf("changing record sets: %w", classify(err))
+		}
+
+		c.logger.Debug("submitted change",
+			slog.String("zone", c.zone),
+			slog.String("change_id", out.Id),
+			slog.String("status", out.Status),
+			slog.Int("additions", len(change.Additions)),
+			slog.Int("deletions", len(change.Deletions)),
+		)
+	}
+	return nil
+}
+
+// fromAPI converts a Cloud DNS record set. Record sets with a routing
+// policy (geo, weighted round robin, failover) are not managed by dnsweaver
+// and are skipped.
+func fromAPI(set *dns.ResourceRecordSet) (rrset, bool) {
+	if set == nil || set.RoutingPolicy != nil {
+		return rrset{}, false
+	}
+	return rrset{
+		Name:   decodeName(set.Name),
+		Type:   set.Type,
+		TTL:    set.Ttl,
+		Values: append([]string{}, set.Rrdatas...),
+	}, true
+}
+
+// toAPI converts a record set to its Cloud DNS representation.
+func toAPI(set rrset) *dns.ResourceRecordSet {
+	return &dns.ResourceRecordSet{
+		Name:    fqdn(set.Name),
+		Type:    set.Type,
+		Ttl:     set.TTL,
+		Rrdatas: append([]string{}, set.Values...),
+	}
+}
+
+// splitChanges splits changes into batches within the Cloud DNS limits on
+// additions and deletions per change, keeping each record set's deletion
+// and addition together.
+func splitChanges(changes []rrsetChange) [][]rrsetChange {
+	var batches [][]rrsetChange
+	var current []rrsetChange
+	adds, dels := 0, 0
+	for _, change := range changes {
+		a, d := 0, 0
+		if change.Add != nil {
+			a = 1
+		}
+		if change.Del != nil {
+			d = 1
+		}
+		if len(current) > 0 && (adds+a > maxChangeSets || dels+d > maxChangeSets) {
+			batches = append(batches, current)
+			current, adds, dels = nil, 0, 0
+		}
+		current = append(current, change)
+		adds += a
+		dels += d
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// classify wraps Google API errors with the matching provider error so
+// callers can tell throttling, authentication and invalid input failures
+// apart.
+func classify(err error) error {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		if base := provider.ErrorForStatus(apiErr.Code); base != nil {
+			return fmt.Errorf("%w: %w", base, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+// Package clouddns implements the DNSWeaver provider interface for Google
+// Cloud DNS managed zones, using the Cloud DNS v1 API.
+package clouddns
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultTTL is the default TTL for Cloud DNS records.
+const DefaultTTL = 300
+
+// Config holds Cloud DNS-specific configuration.
+type Config struct {
+	Project  string // GCP project ID (e.g., "my-project")
+	ZoneName string // Managed zone name (e.g., "example-com"), not the DNS name
+	TTL      int    // Default record TTL
+
+	// Credentials is a service account key in JSON form (optional). When
+	// empty, Application Default Credentials are used: the
+	// GOOGLE_APPLICATION_CREDENTIALS file, gcloud user credentials or the
+	// metadata server on GCE, GKE and Cloud Run.
+	Credentials string
+}
+
+// Validate checks that all required configuration is present.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.Project == "" {
+		errs = append(errs, "PROJECT is required")
+	}
+	if c.ZoneName == "" {
+		errs = append(errs, "ZONE_NAME is required")
+	}
+	if c.TTL < 0 {
+		errs = append(errs, "TTL must be non-negative")
+	}
+	if c.Credentials != "" && !json.Valid([]byte(c.Credentials)) {
+		errs = append(errs, "CREDENTIALS must be a JSON service account key")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("clouddns config validation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// HasCredentials returns true if a service account key is configured.
+func (c *Config) HasCredentials() bool {
+	return c.Credentials != ""
+}
+
+// LoadConfig loads Cloud DNS configuration from environment variables.
+// Environment variable pattern: DNSWEAVER_{INSTANCE_NAME}_{SETTING}
+//
+// Instance names are normalized: lowercase with hyphens becomes uppercase with underscores.
+// Example: "clouddns" looks for DNSWEAVER_CLOUDDNS_*
+//
+// Supported settings:
+//   - PROJECT: GCP project ID (required)
+//   - ZONE_NAME: Managed zone name (required)
+//   - TTL: Record TTL (optional, default: 300)
+//   - CREDENTIALS: Service account key JSON (optional, supports _FILE suffix for a key file or Docker secret)
+func LoadConfig(instanceName string) (*Config, error) {
+	prefix := envPrefix(instanceName)
+
+	return LoadConfigFromMap(instanceName, map[string]string{
+		"PROJECT":     getEnv(prefix + "PROJECT"),
+		"ZONE_NAME":   getEnv(prefix + "ZONE_NAME"),
+		"TTL":         getEnv(prefix + "TTL"),
+		"CREDENTIALS": getEnvOrFile(prefix+"CREDENTIALS", prefix+"CREDENTIALS_FILE"),
+	})
+}
+
+// LoadConfigFromMap creates a Config from a map of key-value pairs.
+// This is used by the provider registry to create instances from
+// configuration that was already parsed from environment variables.
+//
+// Required keys: PROJECT, ZONE_NAME
+// Optional keys: TTL, CREDENTIALS
+func LoadConfigFromMap(instanceName string, configMap map[string]string) (*Config, error) {
+	config := &Config{
+		Project:     configMap["PROJECT"],
+		ZoneName:    configMap["ZONE_NAME"],
+		TTL:         DefaultTTL,
+		Credentials: strings.TrimSpace(configMap["CREDENTIALS"]),
+	}
+
+	// Parse optional TTL
+	if ttlStr, ok := configMap["TTL"]; ok && ttlStr != "" {
+		ttl, err := strconv.Atoi(ttlStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TTL value %q: %w", ttlStr, err)
+		}
+		config.TTL = ttl
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
+	}
+
+	return config, nil
+}
+
+// envPrefix converts an instance name to an environment variable prefix.
+// Example: "clouddns" → "DNSWEAVER_CLOUDDNS_"
+func envPrefix(instanceName string) string {
+	normalized := strings.ToUpper(instanceName)
+	normalized = strings.ReplaceAll(normalized, "-", "_")
+	return "DNSWEAVER_" + normalized + "_"
+}
+
+// getEnv retrieves an environment variable value.
+func getEnv(key string) string {
+	return os.Getenv(key)
+}
+
+// getEnvOrFile retrieves a value from either a direct environment variable
+// or a file path specified by the file key (Docker secrets pattern).
+//
+// If both are set, the file takes precedence.
+// The file contents are trimmed of leading/trailing whitespace.
+func getEnvOrFile(directKey, fileKey string) string {
+	if filePath := os.Getenv(fileKey); filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+		// If file read fails, fall through to direct value
+	}
+
+	return os.Getenv(directKey)
+}
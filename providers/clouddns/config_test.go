@@ -0,0 +1,74 @@
+package clouddns
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigFromMap(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		c, err := LoadConfigFromMap("clouddns", map[string]string{
+			"PROJECT":   "my-project",
+			"ZONE_NAME": "example-com",
+		})
+		if err != nil {
+			t.Fatalf("LoadConfigFromMap() unexpected error: %v", err)
+		}
+		if c.TTL != DefaultTTL {
+			t.Errorf("TTL = %d, want %d", c.TTL, DefaultTTL)
+		}
+		if c.HasCredentials() {
+			t.Error("HasCredentials() = true, want Application Default Credentials")
+		}
+	})
+
+	t.Run("missing required", func(t *testing.T) {
+		_, err := LoadConfigFromMap("clouddns", map[string]string{})
+		if err == nil || !strings.Contains(err.Error(), "PROJECT is required") || !strings.Contains(err.Error(), "ZONE_NAME is required") {
+			t.Errorf("LoadConfigFromMap() error = %v, want PROJECT and ZONE_NAME required", err)
+		}
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		_, err := LoadConfigFromMap("clouddns", map[string]string{
+			"PROJECT": "my-project", "ZONE_NAME": "example-com", "CREDENTIALS": "/path/to/key.json",
+		})
+		if err == nil || !strings.Contains(err.Error(), "CREDENTIALS must be a JSON") {
+			t.Errorf("LoadConfigFromMap() error = %v, want credentials error", err)
+		}
+	})
+
+	t.Run("invalid TTL", func(t *testing.T) {
+		_, err := LoadConfigFromMap("clouddns", map[string]string{
+			"PROJECT": "my-project", "ZONE_NAME": "example-com", "TTL": "soon",
+		})
+		if err == nil {
+			t.Fatal("LoadConfigFromMap() expected error for invalid TTL")
+		}
+	})
+}
+
+func TestLoadConfig_FromEnv(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(keyFile, []byte(`{"type": "service_account"}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("DNSWEAVER_GCP_DNS_PROJECT", "my-project")
+	t.Setenv("DNSWEAVER_GCP_DNS_ZONE_NAME", "example-com")
+	t.Setenv("DNSWEAVER_GCP_DNS_TTL", "60")
+	t.Setenv("DNSWEAVER_GCP_DNS_CREDENTIALS_FILE", keyFile)
+
+	c, err := LoadConfig("gcp-dns")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	if c.Project != "my-project" || c.ZoneName != "example-com" || c.TTL != 60 {
+		t.Errorf("LoadConfig() = %+v", c)
+	}
+	if c.Credentials != `{"type": "service_account"}` {
+		t.Errorf("Credentials = %q, want key file contents", c.Credentials)
+	}
+}
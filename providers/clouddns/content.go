@@ -0,0 +1,137 @@
+package clouddns
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// maxTXTString is the longest character-string allowed in a TXT record.
+const maxTXTString = 255
+
+// encodeValue converts a record to Cloud DNS rrdata in zone file format.
+func encodeValue(record provider.Record) (string, error) {
+	switch record.Type {
+	case provider.RecordTypeA, provider.RecordTypeAAAA:
+		ip := net.ParseIP(record.Target)
+		if ip == nil {
+			return "", fmt.Errorf("invalid IP address %q", record.Target)
+		}
+		return ip.String(), nil
+	case provider.RecordTypeCNAME:
+		return fqdn(record.Target), nil
+	case provider.RecordTypeTXT:
+		return quoteTXT(record.Target), nil
+	case provider.RecordTypeMX:
+		pref, exchange, ok := strings.Cut(strings.TrimSpace(record.Target), " ")
+		if !ok {
+			return "", fmt.Errorf("MX target must be \"<preference> <exchange>\", got %q", record.Target)
+		}
+		if _, err := strconv.ParseUint(pref, 10, 16); err != nil {
+			return "", fmt.Errorf("invalid MX preference %q", pref)
+		}
+		return pref + " " + fqdn(strings.TrimSpace(exchange)), nil
+	default:
+		return "", fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+}
+
+// decodeValue fills the Target of record from Cloud DNS rrdata.
+func decodeValue(record *provider.Record, value string) error {
+	switch record.Type {
+	case provider.RecordTypeA, provider.RecordTypeAAAA:
+		record.Target = value
+	case provider.RecordTypeCNAME:
+		record.Target = strings.TrimSuffix(value, ".")
+	case provider.RecordTypeTXT:
+		record.Target = unquoteTXT(value)
+	case provider.RecordTypeMX:
+		fields := strings.Fields(value)
+		if len(fields) != 2 {
+			return fmt.Errorf("invalid MX value %q", value)
+		}
+		record.Target = fields[0] + " " + strings.TrimSuffix(fields[1], ".")
+	default:
+		return fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+	return nil
+}
+
+// sameValue reports whether two rrdata values of the given type are equal.
+// Cloud DNS may return TXT data split or escaped differently than it was
+// written.
+func sameValue(rrType, a, b string) bool {
+	if rrType == string(provider.RecordTypeTXT) {
+		return unquoteTXT(a) == unquoteTXT(b)
+	}
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
+
+// decodeName converts a record name as returned by Cloud DNS to a hostname.
+func decodeName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+// fqdn returns hostname as a fully qualified name, which Cloud DNS requires
+// for record names and for hostnames in rrdata.
+func fqdn(hostname string) string {
+	return strings.TrimSuffix(strings.ToLower(hostname), ".") + "."
+}
+
+// quoteTXT encodes text as one or more quoted character-strings,
+// splitting at maxTXTString bytes.
+func quoteTXT(text string) string {
+	var parts []string
+	for {
+		chunk := text
+		if len(chunk) > maxTXTString {
+			chunk = chunk[:maxTXTString]
+		}
+		escaped := strings.ReplaceAll(chunk, `\`, `\\`)
+		escaped = strings.ReplaceAll(escaped, `"`, `\"`)
+		parts = append(parts, `"`+escaped+`"`)
+		text = text[len(chunk):]
+		if text == "" {
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// unquoteTXT decodes quoted character-strings and concatenates them.
+// Escapes follow RFC 1035: \X for a literal character and \DDD for a
+// three-digit decimal byte. Values without quotes are returned as is.
+func unquoteTXT(value string) string {
+	if !strings.HasPrefix(value, `"`) {
+		return value
+	}
+
+	var b strings.Builder
+	inQuote := false
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case ch == '"':
+			inQuote = !inQuote
+		case ch == '\\' && inQuote && i+3 < len(value) && isDecimalByte(value[i+1:i+4]):
+			n, _ := strconv.ParseUint(value[i+1:i+4], 10, 8)
+			b.WriteByte(byte(n))
+			i += 3
+		case ch == '\\' && inQuote && i+1 < len(value):
+			i++
+			b.WriteByte(value[i])
+		case inQuote:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+// isDecimalByte reports whether s is a three-digit decimal escape code.
+func isDecimalByte(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 8)
+	return err == nil
+}
@@ -0,0 +1,31 @@
+package clouddns
+
+import (
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Factory returns a provider.Factory for creating Cloud DNS provider instances.
+// This is the recommended way to register the Cloud DNS provider with the registry.
+func Factory() provider.Factory {
+	return func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		// Parse provider-specific configuration from the map
+		providerCfg, err := LoadConfigFromMap(cfg.Name, cfg.ProviderConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		// The Google API client authenticates and retries requests and sets
+		// its own user agent; the shared client adds the timeout and debug
+		// logging
+		httpClient := httputil.NewClient(&httputil.ClientConfig{
+			Timeout: cfg.HTTP.Timeout,
+			Logger:  cfg.HTTP.Logger,
+		})
+
+		return New(cfg.Name, providerCfg,
+			WithProviderHTTPClient(httpClient),
+			WithProviderLogger(cfg.HTTP.Logger),
+		)
+	}
+}
@@ -0,0 +1,452 @@
+package clouddns
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// rrsetKey identifies a record set by hostname and type.
+type rrsetKey struct {
+	name   string
+	rrType string
+}
+
+// Provider implements provider.Provider for Google Cloud DNS.
+//
+// Cloud DNS manages records as record sets (all values sharing a name and
+// type), and modifies them only through changes: a list of record sets to
+// delete, which must match exactly, and a list to add, applied atomically.
+// Create, Delete and Update read the current set and replace it in one
+// change; BulkCreate writes every affected set in a single change. The TTL
+// is shared by every value in a set.
+type Provider struct {
+	name       string
+	ttl        int
+	config     *Config
+	client     *Client
+	api        cloudDNSAPI  // Cloud DNS API (optional, created from config if nil)
+	httpClient *http.Client // Custom HTTP client (optional)
+	logger     *slog.Logger
+
+	// writeMu serializes read-modify-write cycles on record sets
+	writeMu sync.Mutex
+
+	// mu guards zone
+	mu   sync.Mutex
+	zone string
+}
+
+// ProviderOption is a functional option for configuring the Provider.
+type ProviderOption func(*Provider)
+
+// WithProviderLogger sets a custom logger for the provider.
+func WithProviderLogger(logger *slog.Logger) ProviderOption {
+	return func(p *Provider) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// WithProviderHTTPClient sets a custom HTTP client for Cloud DNS API
+// requests. Authentication is added on top of its transport.
+func WithProviderHTTPClient(client *http.Client) ProviderOption {
+	return func(p *Provider) {
+		if client != nil {
+			p.httpClient = client
+		}
+	}
+}
+
+// withAPI sets the Cloud DNS API implementation, replacing the API client.
+func withAPI(api cloudDNSAPI) ProviderOption {
+	return func(p *Provider) {
+		p.api = api
+	}
+}
+
+// New creates a new Cloud DNS provider instance.
+func New(name string, config *Config, opts ...ProviderOption) (*Provider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		name:   name,
+		ttl:    config.TTL,
+		config: config,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.api == nil {
+		api, err := newAPI(context.Background(), config, p.httpClient)
+		if err != nil {
+			return nil, err
+		}
+		p.api = api
+	}
+	p.client = NewClient(p.api, config.Project, config.ZoneName, WithLogger(p.logger))
+
+	if !config.HasCredentials() {
+		p.logger.Debug("no credentials configured, using Application Default Credentials",
+			slog.String("provider", name),
+		)
+	}
+
+	return p, nil
+}
+
+// NewFromEnv creates a new Cloud DNS provider from environment variables.
+// This is a convenience function for use with the provider registry.
+func NewFromEnv(instanceName string, opts ...ProviderOption) (*Provider, error) {
+	config, err := LoadConfig(instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(instanceName, config, opts...)
+}
+
+// Name returns the provider instance name.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Type returns "clouddns".
+func (p *Provider) Type() string {
+	return "clouddns"
+}
+
+// Capabilities returns the provider's feature support.
+func (p *Provider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SupportsOwnershipTXT:    true,
+		SupportsNativeUpdate:    true, // A change swaps values atomically
+		SupportsMultipleTargets: true,
+		SupportedRecordTypes: []provider.RecordType{
+			provider.RecordTypeA,
+			provider.RecordTypeAAAA,
+			provider.RecordTypeCNAME,
+			provider.RecordTypeTXT,
+			provider.RecordTypeMX,
+		},
+	}
+}
+
+// Zone returns the managed zone's DNS name, known once Ping succeeds.
+func (p *Provider) Zone() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.zone
+}
+
+// Ping checks connectivity and credentials by fetching the managed zone,
+// and records its DNS name.
+func (p *Provider) Ping(ctx context.Context) error {
+	zone, err := p.client.ZoneName(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.zone = zone
+	p.mu.Unlock()
+	return nil
+}
+
+// List returns all records of supported types in simple record sets of the
+// zone.
+func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
+	sets, err := p.client.ListRRsets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing records: %w", err)
+	}
+
+	caps := p.Capabilities()
+	var records []provider.Record
+	for _, set := range sets {
+		rrType := provider.RecordType(set.Type)
+		if !caps.SupportsRecordType(rrType) {
+			continue
+		}
+		for _, value := range set.Values {
+			rec := provider.Record{
+				Hostname: set.Name,
+				Type:     rrType,
+				TTL:      int(set.TTL),
+			}
+			if err := decodeValue(&rec, value); err != nil {
+				p.logger.Warn("skipping unparseable record",
+					slog.String("provider", p.name),
+					slog.String("hostname", set.Name),
+					slog.String("type", set.Type),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			rec.ProviderID = fmt.Sprintf("%s:%s:%s", set.Name, set.Type, value)
+			records = append(records, rec)
+		}
+	}
+
+	p.logger.Debug("listed records",
+		slog.String("provider", p.name),
+		slog.Int("count", len(records)),
+	)
+
+	return records, nil
+}
+
+// Create adds a value to its record set. Returns provider.ErrConflict if an
+// identical record already exists.
+func (p *Provider) Create(ctx context.Context, record provider.Record) error {
+	value, err := encodeValue(record)
+	if err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	set, err := p.client.GetRRset(ctx, record.Hostname, string(record.Type))
+	if err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	ttl := p.recordTTL(record)
+	updated := rrset{Name: decodeName(record.Hostname), Type: string(record.Type), TTL: int64(ttl)}
+	if set != nil {
+		if indexOf(set, value) >= 0 {
+			return provider.ErrConflict
+		}
+		updated.Values = append(updated.Values, set.Values...)
+	}
+	updated.Values = append(updated.Values, value)
+
+	if err := p.client.ApplyChanges(ctx, []rrsetChange{{Del: set, Add: &updated}}); err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("created record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+		slog.Int("ttl", ttl),
+	)
+
+	return nil
+}
+
+// Delete removes a value from its record set, deleting the set when it
+// becomes empty. Deleting a record that does not exist is not an error.
+func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
+	value, err := encodeValue(record)
+	if err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	set, err := p.client.GetRRset(ctx, record.Hostname, string(record.Type))
+	if err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+	if set == nil {
+		return nil
+	}
+	idx := indexOf(set, value)
+	if idx < 0 {
+		return nil
+	}
+
+	change := rrsetChange{Del: set}
+	if len(set.Values) > 1 {
+		remaining := *set
+		remaining.Values = append(append([]string{}, set.Values[:idx]...), set.Values[idx+1:]...)
+		change.Add = &remaining
+	}
+
+	if err := p.client.ApplyChanges(ctx, []rrsetChange{change}); err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("deleted record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+	)
+
+	return nil
+}
+
+// Update replaces an existing record's value and TTL in a single change.
+// This implements the provider.Updater interface for native update support.
+func (p *Provider) Update(ctx context.Context, existing, desired provider.Record) error {
+	if !strings.EqualFold(existing.Hostname, desired.Hostname) || existing.Type != desired.Type {
+		return fmt.Errorf("updating record: hostname and type must not change")
+	}
+
+	oldValue, err := encodeValue(existing)
+	if err != nil {
+		return fmt.Errorf("updating %s record: %w", existing.Type, err)
+	}
+	newValue, err := encodeValue(desired)
+	if err != nil {
+		return fmt.Errorf("updating %s record: %w", desired.Type, err)
+	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	set, err := p.client.GetRRset(ctx, existing.Hostname, string(existing.Type))
+	if err != nil {
+		return fmt.Errorf("updating %s record: %w", existing.Type, err)
+	}
+	if set == nil {
+		return provider.ErrNotFound
+	}
+	idx := indexOf(set, oldValue)
+	if idx < 0 {
+		return provider.ErrNotFound
+	}
+
+	ttl := p.recordTTL(desired)
+	if sameValue(set.Type, set.Values[idx], newValue) && set.TTL == int64(ttl) {
+		return nil
+	}
+
+	updated := rrset{Name: set.Name, Type: set.Type, TTL: int64(ttl)}
+	for i, value := range set.Values {
+		switch {
+		case i == idx:
+			updated.Values = append(updated.Values, newValue)
+		case !sameValue(set.Type, value, newValue):
+			// Drop a duplicate if the new value already existed elsewhere in the set
+			updated.Values = append(updated.Values, value)
+		}
+	}
+
+	if err := p.client.ApplyChanges(ctx, []rrsetChange{{Del: set, Add: &updated}}); err != nil {
+		return fmt.Errorf("updating %s record: %w", desired.Type, err)
+	}
+
+	p.logger.Info("updated record",
+		slog.String("provider", p.name),
+		slog.String("hostname", desired.Hostname),
+		slog.String("type", string(desired.Type)),
+		slog.String("old_target", existing.Target),
+		slog.String("new_target", desired.Target),
+		slog.Int("ttl", ttl),
+	)
+
+	return nil
+}
+
+// BulkCreate adds all records in a single change, split only if it exceeds
+// the Cloud DNS per-change limits. Records that already exist are skipped.
+// This implements the provider.BulkCreator interface.
+func (p *Provider) BulkCreate(ctx context.Context, records []provider.Record) error {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	current, err := p.client.ListRRsets(ctx)
+	if err != nil {
+		return fmt.Errorf("bulk creating records: %w", err)
+	}
+	existing := make(map[rrsetKey]*rrset, len(current))
+	for i := range current {
+		existing[rrsetKey{current[i].Name, current[i].Type}] = &current[i]
+	}
+
+	updated := make(map[rrsetKey]*rrset)
+	var order []rrsetKey
+	created := 0
+	for _, record := range records {
+		value, err := encodeValue(record)
+		if err != nil {
+			return fmt.Errorf("bulk creating %s record for %s: %w", record.Type, record.Hostname, err)
+		}
+
+		key := rrsetKey{decodeName(record.Hostname), string(record.Type)}
+		set, ok := updated[key]
+		if !ok {
+			set = &rrset{Name: key.name, Type: key.rrType}
+			if prev := existing[key]; prev != nil {
+				set.TTL = prev.TTL
+				set.Values = append(set.Values, prev.Values...)
+			}
+			updated[key] = set
+			order = append(order, key)
+		}
+		if indexOf(set, value) >= 0 {
+			continue
+		}
+		set.TTL = int64(p.recordTTL(record))
+		set.Values = append(set.Values, value)
+		created++
+	}
+
+	var changes []rrsetChange
+	for _, key := range order {
+		set, prev := updated[key], existing[key]
+		if prev != nil && len(set.Values) == len(prev.Values) && set.TTL == prev.TTL {
+			continue // nothing new in this set
+		}
+		changes = append(changes, rrsetChange{Del: prev, Add: set})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := p.client.ApplyChanges(ctx, changes); err != nil {
+		return fmt.Errorf("bulk creating records: %w", err)
+	}
+
+	p.logger.Info("bulk created records",
+		slog.String("provider", p.name),
+		slog.Int("records", created),
+		slog.Int("record_sets", len(changes)),
+	)
+
+	return nil
+}
+
+// recordTTL returns the record's TTL, or the provider default if unset.
+func (p *Provider) recordTTL(record provider.Record) int {
+	if record.TTL > 0 {
+		return record.TTL
+	}
+	return p.ttl
+}
+
+// indexOf returns the index of the value in the record set, or -1.
+func indexOf(set *rrset, value string) int {
+	for i, v := range set.Values {
+		if sameValue(set.Type, v, value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Ensure Provider implements the provider interfaces at compile time.
+var (
+	_ provider.Provider    = (*Provider)(nil)
+	_ provider.Updater     = (*Provider)(nil)
+	_ provider.BulkCreator = (*Provider)(nil)
+)
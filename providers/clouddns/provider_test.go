@@ -0,0 +1,313 @@
+package clouddns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	dns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// fakeAPI is an in-memory Cloud DNS API for a single managed zone. Like
+// Cloud DNS, it rejects a change whose deletions do not match the current
+// record sets exactly or whose additions already exist.
+type fakeAPI struct {
+	mu       sync.Mutex
+	sets     []*dns.ResourceRecordSet
+	changes  []*dns.Change
+	pageSize int
+	err      error
+}
+
+func (f *fakeAPI) GetManagedZone(_ context.Context, _, zone string) (*dns.ManagedZone, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &dns.ManagedZone{Name: zone, DnsName: "example.com."}, nil
+}
+
+func (f *fakeAPI) ListResourceRecordSets(_ context.Context, _, _, name, rrType, pageToken string) (*dns.ResourceRecordSetsListResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	var matched []*dns.ResourceRecordSet
+	for _, set := range f.sets {
+		if (name == "" || set.Name == name) && (rrType == "" || set.Type == rrType) {
+			matched = append(matched, set)
+		}
+	}
+
+	start := 0
+	if pageToken != "" {
+		fmt.Sscan(pageToken, &start)
+	}
+	out := &dns.ResourceRecordSetsListResponse{}
+	for i := start; i < len(matched); i++ {
+		if f.pageSize > 0 && len(out.Rrsets) == f.pageSize {
+			out.NextPageToken = fmt.Sprint(i)
+			break
+		}
+		out.Rrsets = append(out.Rrsets, matched[i])
+	}
+	return out, nil
+}
+
+func (f *fakeAPI) CreateChange(_ context.Context, _, _ string, change *dns.Change) (*dns.Change, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	sets := slices.Clone(f.sets)
+	for _, del := range change.Deletions {
+		idx := slices.IndexFunc(sets, func(s *dns.ResourceRecordSet) bool {
+			return s.Name == del.Name && s.Type == del.Type && s.Ttl == del.Ttl && slices.Equal(s.Rrdatas, del.Rrdatas)
+		})
+		if idx < 0 {
+			return nil, &googleapi.Error{Code: http.StatusPreconditionFailed, Message: "conditionNotMet"}
+		}
+		sets = slices.Delete(sets, idx, idx+1)
+	}
+	for _, add := range change.Additions {
+		if slices.ContainsFunc(sets, func(s *dns.ResourceRecordSet) bool { return s.Name == add.Name && s.Type == add.Type }) {
+			return nil, &googleapi.Error{Code: http.StatusConflict, Message: "alreadyExists"}
+		}
+		sets = append(sets, add)
+	}
+
+	f.sets = sets
+	f.changes = append(f.changes, change)
+	return &dns.Change{Id: fmt.Sprint(len(f.changes)), Status: "pending"}, nil
+}
+
+// find returns the values and TTL of a record set, or nil values if absent.
+func (f *fakeAPI) find(name, rrType string) ([]string, int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, set := range f.sets {
+		if set.Name == name && set.Type == rrType {
+			return set.Rrdatas, set.Ttl
+		}
+	}
+	return nil, 0
+}
+
+func simpleSet(name, rrType string, ttl int64, values ...string) *dns.ResourceRecordSet {
+	return &dns.ResourceRecordSet{Name: name, Type: rrType, Ttl: ttl, Rrdatas: values}
+}
+
+func newTestProvider(t *testing.T, fake *fakeAPI) *Provider {
+	t.Helper()
+	p, err := New("clouddns", &Config{Project: "my-project", ZoneName: "example-com", TTL: 300}, withAPI(fake))
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestProvider_Ping(t *testing.T) {
+	fake := &fakeAPI{}
+	p := newTestProvider(t, fake)
+	if err := p.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() unexpected error: %v", err)
+	}
+	if p.Zone() != "example.com" {
+		t.Errorf("Zone() = %q, want example.com", p.Zone())
+	}
+
+	fake.err = &googleapi.Error{Code: http.StatusForbidden, Message: "Forbidden"}
+	if err := p.Ping(context.Background()); !errors.Is(err, provider.ErrAuth) {
+		t.Errorf("Ping() error = %v, want ErrAuth", err)
+	}
+}
+
+func TestProvider_List(t *testing.T) {
+	geo := simpleSet("api.example.com.", "A", 60)
+	geo.RoutingPolicy = &dns.RRSetRoutingPolicy{}
+
+	fake := &fakeAPI{pageSize: 2, sets: []*dns.ResourceRecordSet{
+		simpleSet("example.com.", "SOA", 21600, "ns-cloud-a1.googledomains.com. cloud-dns-hostmaster.google.com. 1 21600 3600 259200 300"),
+		simpleSet("app.example.com.", "A", 300, "10.0.0.1", "10.0.0.2"),
+		simpleSet("_dnsweaver.app.example.com.", "TXT", 300, `"heritage=dnsweaver"`),
+		simpleSet("*.dev.example.com.", "CNAME", 300, "app.example.com."),
+		simpleSet("example.com.", "MX", 3600, "10 mail.example.com."),
+		geo,
+	}}
+	p := newTestProvider(t, fake)
+
+	records, err := p.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, r := range records {
+		got = append(got, fmt.Sprintf("%s %s %s", r.Hostname, r.Type, r.Target))
+	}
+	sort.Strings(got)
+	want := []string{
+		"*.dev.example.com CNAME app.example.com",
+		"_dnsweaver.app.example.com TXT heritage=dnsweaver",
+		"app.example.com A 10.0.0.1",
+		"app.example.com A 10.0.0.2",
+		"example.com MX 10 mail.example.com",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+}
+
+func TestProvider_CreateAndDelete(t *testing.T) {
+	fake := &fakeAPI{}
+	p := newTestProvider(t, fake)
+	ctx := context.Background()
+
+	first := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"}
+	second := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.2", TTL: 60}
+
+	if err := p.Create(ctx, first); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if err := p.Create(ctx, second); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	values, ttl := fake.find("app.example.com.", "A")
+	if !slices.Equal(values, []string{"10.0.0.1", "10.0.0.2"}) || ttl != 60 {
+		t.Fatalf("record set after two creates = %v (TTL %d), want both values with TTL 60", values, ttl)
+	}
+
+	if err := p.Create(ctx, first); !errors.Is(err, provider.ErrConflict) {
+		t.Errorf("duplicate Create() error = %v, want ErrConflict", err)
+	}
+
+	if err := p.Delete(ctx, first); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if values, _ := fake.find("app.example.com.", "A"); !slices.Equal(values, []string{"10.0.0.2"}) {
+		t.Fatalf("record set after delete = %v, want only 10.0.0.2", values)
+	}
+
+	if err := p.Delete(ctx, second); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if values, _ := fake.find("app.example.com.", "A"); values != nil {
+		t.Errorf("record set should be removed when empty, got %v", values)
+	}
+
+	changes := len(fake.changes)
+	if err := p.Delete(ctx, second); err != nil {
+		t.Errorf("Delete() of missing record unexpected error: %v", err)
+	}
+	if len(fake.changes) != changes {
+		t.Error("Delete() of missing record should not submit a change")
+	}
+}
+
+func TestProvider_Update(t *testing.T) {
+	fake := &fakeAPI{sets: []*dns.ResourceRecordSet{
+		simpleSet("app.example.com.", "CNAME", 300, "old.example.com."),
+	}}
+	p := newTestProvider(t, fake)
+	ctx := context.Background()
+
+	existing := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeCNAME, Target: "old.example.com"}
+	desired := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeCNAME, Target: "new.example.com", TTL: 120}
+
+	if err := p.Update(ctx, existing, desired); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	values, ttl := fake.find("app.example.com.", "CNAME")
+	if !slices.Equal(values, []string{"new.example.com."}) || ttl != 120 {
+		t.Errorf("record set after update = %v (TTL %d)", values, ttl)
+	}
+	if len(fake.changes) != 1 || len(fake.changes[0].Deletions) != 1 || len(fake.changes[0].Additions) != 1 {
+		t.Errorf("Update() should submit one change replacing the record set, got %d changes", len(fake.changes))
+	}
+
+	if err := p.Update(ctx, existing, desired); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("Update() of missing record error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestProvider_BulkCreate(t *testing.T) {
+	fake := &fakeAPI{sets: []*dns.ResourceRecordSet{
+		simpleSet("app.example.com.", "A", 300, "10.0.0.1"),
+		simpleSet("www.example.com.", "A", 300, "10.0.0.1"),
+	}}
+	p := newTestProvider(t, fake)
+
+	var records []provider.Record
+	for i := range 600 {
+		name := fmt.Sprintf("host%d.example.com", i)
+		records = append(records,
+			provider.Record{Hostname: name, Type: provider.RecordTypeA, Target: "10.0.1.1"},
+			provider.Record{Hostname: "_dnsweaver." + name, Type: provider.RecordTypeTXT, Target: "heritage=dnsweaver"},
+		)
+	}
+	records = append(records,
+		provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"},
+		provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.2"},
+		provider.Record{Hostname: "www.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"},
+	)
+
+	if err := p.BulkCreate(context.Background(), records); err != nil {
+		t.Fatalf("BulkCreate() unexpected error: %v", err)
+	}
+	// 1201 record set additions exceed the 1000 per change limit
+	if len(fake.changes) != 2 {
+		t.Errorf("BulkCreate() submitted %d changes, want 2", len(fake.changes))
+	}
+	if values, _ := fake.find("app.example.com.", "A"); !slices.Equal(values, []string{"10.0.0.1", "10.0.0.2"}) {
+		t.Errorf("app.example.com values = %v, want existing value kept", values)
+	}
+	if values, _ := fake.find("_dnsweaver.host599.example.com.", "TXT"); !slices.Equal(values, []string{`"heritage=dnsweaver"`}) {
+		t.Errorf("ownership TXT values = %v", values)
+	}
+	for _, change := range fake.changes {
+		for _, del := range change.Deletions {
+			if del.Name == "www.example.com." {
+				t.Error("BulkCreate() should not rewrite a record set with nothing new")
+			}
+		}
+	}
+
+	err := p.BulkCreate(context.Background(), []provider.Record{{Hostname: "bad.example.com", Type: provider.RecordTypeA, Target: "nope"}})
+	if err == nil || !strings.Contains(err.Error(), "bad.example.com") {
+		t.Errorf("BulkCreate() error = %v, want invalid record error", err)
+	}
+}
+
+func TestProvider_ConcurrentModification(t *testing.T) {
+	fake := &fakeAPI{sets: []*dns.ResourceRecordSet{
+		simpleSet("app.example.com.", "A", 300, "10.0.0.1"),
+	}}
+	p := newTestProvider(t, fake)
+
+	// A deletion that no longer matches is rejected instead of losing the
+	// other writer's change
+	err := p.client.ApplyChanges(context.Background(), []rrsetChange{{
+		Del: &rrset{Name: "app.example.com", Type: "A", TTL: 300, Values: []string{"10.0.0.9"}},
+		Add: &rrset{Name: "app.example.com", Type: "A", TTL: 300, Values: []string{"10.0.0.2"}},
+	}})
+	if err == nil {
+		t.Fatal("ApplyChanges() expected error for stale deletion")
+	}
+	if values, _ := fake.find("app.example.com.", "A"); !slices.Equal(values, []string{"10.0.0.1"}) {
+		t.Errorf("record set = %v, want unchanged", values)
+	}
+}
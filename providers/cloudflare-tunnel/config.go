@@ -0,0 +1,150 @@
+// Package cloudflaretunnel implements the DNSWeaver provider interface for
+// Cloudflare Tunnel public hostnames. Each hostname gets a proxied CNAME to
+// the tunnel and an ingress rule routing it to an origin service.
+package cloudflaretunnel
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultTTL is the default TTL for ownership TXT records.
+// Tunnel CNAMEs are always proxied and use Cloudflare's automatic TTL.
+const DefaultTTL = 300
+
+// Config holds Cloudflare Tunnel-specific configuration.
+type Config struct {
+	AccountID string // Cloudflare account ID that owns the tunnel
+	APIToken  string // API token with Tunnel and DNS edit permissions
+	TunnelID  string // Tunnel UUID
+	Zone      string // Zone name the public hostnames belong to
+	ZoneID    string // Zone ID (optional, looked up from Zone if empty)
+	TTL       int    // TTL for ownership TXT records
+}
+
+// Validate checks that all required configuration is present.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.AccountID == "" {
+		errs = append(errs, "ACCOUNT_ID is required")
+	}
+	if c.APIToken == "" {
+		errs = append(errs, "API_TOKEN is required")
+	}
+	if c.TunnelID == "" {
+		errs = append(errs, "TUNNEL_ID is required")
+	}
+	if c.Zone == "" {
+		errs = append(errs, "ZONE is required")
+	}
+	if c.TTL < 0 {
+		errs = append(errs, "TTL must be non-negative")
+	}
+	if c.TTL > 0 && c.TTL < 60 && c.TTL != 1 {
+		errs = append(errs, "TTL must be at least 60 seconds (or 1 for automatic)")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("cloudflare-tunnel config validation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// TunnelHostname returns the hostname public hostname CNAMEs point to.
+func (c *Config) TunnelHostname() string {
+	return c.TunnelID + ".cfargotunnel.com"
+}
+
+// LoadConfig loads Cloudflare Tunnel configuration from environment variables.
+// Environment variable pattern: DNSWEAVER_{INSTANCE_NAME}_{SETTING}
+//
+// Instance names are normalized: lowercase with hyphens becomes uppercase with underscores.
+// Example: "cftunnel" looks for DNSWEAVER_CFTUNNEL_*
+//
+// Supported settings:
+//   - ACCOUNT_ID: Cloudflare account ID (required)
+//   - API_TOKEN: API token (required, supports _FILE suffix for Docker secrets)
+//   - TUNNEL_ID: Tunnel UUID (required)
+//   - ZONE: Zone name (required)
+//   - ZONE_ID: Zone ID (optional, looked up from ZONE if not set)
+//   - TTL: Ownership TXT record TTL (optional, defaults to 300)
+func LoadConfig(instanceName string) (*Config, error) {
+	prefix := envPrefix(instanceName)
+
+	return LoadConfigFromMap(instanceName, map[string]string{
+		"ACCOUNT_ID": getEnv(prefix + "ACCOUNT_ID"),
+		"API_TOKEN":  getEnvOrFile(prefix+"API_TOKEN", prefix+"API_TOKEN_FILE"),
+		"TUNNEL_ID":  getEnv(prefix + "TUNNEL_ID"),
+		"ZONE":       getEnv(prefix + "ZONE"),
+		"ZONE_ID":    getEnv(prefix + "ZONE_ID"),
+		"TTL":        getEnv(prefix + "TTL"),
+	})
+}
+
+// LoadConfigFromMap creates a Config from a map of key-value pairs.
+// This is used by the provider registry to create instances from
+// configuration that was already parsed from environment variables.
+//
+// Required keys: ACCOUNT_ID, API_TOKEN, TUNNEL_ID, ZONE
+// Optional keys: ZONE_ID, TTL
+func LoadConfigFromMap(instanceName string, configMap map[string]string) (*Config, error) {
+	config := &Config{
+		AccountID: strings.TrimSpace(configMap["ACCOUNT_ID"]),
+		APIToken:  configMap["API_TOKEN"],
+		TunnelID:  strings.ToLower(strings.TrimSpace(configMap["TUNNEL_ID"])),
+		Zone:      normalizeName(configMap["ZONE"]),
+		ZoneID:    strings.TrimSpace(configMap["ZONE_ID"]),
+		TTL:       DefaultTTL,
+	}
+
+	// Parse optional TTL
+	if ttlStr, ok := configMap["TTL"]; ok && ttlStr != "" {
+		ttl, err := strconv.Atoi(ttlStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TTL value %q: %w", ttlStr, err)
+		}
+		config.TTL = ttl
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
+	}
+
+	return config, nil
+}
+
+// envPrefix converts an instance name to an environment variable prefix.
+// Example: "cftunnel" → "DNSWEAVER_CFTUNNEL_"
+func envPrefix(instanceName string) string {
+	normalized := strings.ToUpper(instanceName)
+	normalized = strings.ReplaceAll(normalized, "-", "_")
+	return "DNSWEAVER_" + normalized + "_"
+}
+
+// getEnv retrieves an environment variable value.
+func getEnv(key string) string {
+	return os.Getenv(key)
+}
+
+// getEnvOrFile retrieves a value from either a direct environment variable
+// or a file path specified by the file key (Docker secrets pattern).
+// If both are set, the file takes precedence.
+func getEnvOrFile(directKey, fileKey string) string {
+	if filePath := os.Getenv(fileKey); filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+	}
+
+	return os.Getenv(directKey)
+}
+
+// normalizeName lowercases a DNS name and strips any trailing dot.
+func normalizeName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
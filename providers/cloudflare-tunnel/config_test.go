@@ -0,0 +1,79 @@
+package cloudflaretunnel
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigFromMap(t *testing.T) {
+	valid := func() map[string]string {
+		return map[string]string{
+			"ACCOUNT_ID": "acc",
+			"API_TOKEN":  "token",
+			"TUNNEL_ID":  "6FF42AE2-765D-4ADF-8112-31C55C1551EF",
+			"ZONE":       "Example.COM.",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(m map[string]string)
+		wantErr string
+	}{
+		{name: "valid", modify: func(map[string]string) {}},
+		{name: "missing account", modify: func(m map[string]string) { delete(m, "ACCOUNT_ID") }, wantErr: "ACCOUNT_ID is required"},
+		{name: "missing token", modify: func(m map[string]string) { delete(m, "API_TOKEN") }, wantErr: "API_TOKEN is required"},
+		{name: "missing tunnel", modify: func(m map[string]string) { delete(m, "TUNNEL_ID") }, wantErr: "TUNNEL_ID is required"},
+		{name: "missing zone", modify: func(m map[string]string) { delete(m, "ZONE") }, wantErr: "ZONE is required"},
+		{name: "invalid TTL", modify: func(m map[string]string) { m["TTL"] = "soon" }, wantErr: "invalid TTL"},
+		{name: "TTL too low", modify: func(m map[string]string) { m["TTL"] = "30" }, wantErr: "at least 60 seconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid()
+			tt.modify(m)
+			c, err := LoadConfigFromMap("cftunnel", m)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfigFromMap() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfigFromMap() unexpected error: %v", err)
+			}
+			if c.Zone != "example.com" {
+				t.Errorf("Zone = %q, want example.com", c.Zone)
+			}
+			if c.TTL != DefaultTTL {
+				t.Errorf("TTL = %d, want %d", c.TTL, DefaultTTL)
+			}
+			if got := c.TunnelHostname(); got != "6ff42ae2-765d-4adf-8112-31c55c1551ef.cfargotunnel.com" {
+				t.Errorf("TunnelHostname() = %q", got)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Env(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("DNSWEAVER_CFTUNNEL_ACCOUNT_ID", "acc")
+	t.Setenv("DNSWEAVER_CFTUNNEL_API_TOKEN_FILE", tokenFile)
+	t.Setenv("DNSWEAVER_CFTUNNEL_TUNNEL_ID", "tun")
+	t.Setenv("DNSWEAVER_CFTUNNEL_ZONE", "example.com")
+
+	c, err := LoadConfig("cftunnel")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	if c.AccountID != "acc" || c.APIToken != "file-token" || c.TunnelID != "tun" {
+		t.Errorf("config = %+v, want acc, file-token, tun", c)
+	}
+}
@@ -0,0 +1,40 @@
+package cloudflaretunnel
+
+import (
+	"log/slog"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Factory returns a provider.Factory for creating Cloudflare Tunnel provider instances.
+// This is the recommended way to register the cloudflare-tunnel provider with the registry.
+func Factory() provider.Factory {
+	return func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		providerCfg, err := LoadConfigFromMap(cfg.Name, cfg.ProviderConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		// Public hostnames are CNAMEs whose target is the origin service
+		if cfg.RecordType != "" && cfg.RecordType != provider.RecordTypeCNAME && cfg.HTTP.Logger != nil {
+			cfg.HTTP.Logger.Warn("cloudflare-tunnel only publishes CNAME records; set RECORD_TYPE=CNAME and TARGET to the origin service",
+				slog.String("provider", cfg.Name),
+				slog.String("record_type", string(cfg.RecordType)),
+			)
+		}
+
+		// Create HTTP client with the factory's HTTP configuration
+		httpClient := httputil.NewClient(&httputil.ClientConfig{
+			Timeout:       cfg.HTTP.Timeout,
+			TLSSkipVerify: cfg.HTTP.TLSSkipVerify,
+			UserAgent:     cfg.HTTP.UserAgent,
+			Logger:        cfg.HTTP.Logger,
+		})
+
+		return New(cfg.Name, providerCfg,
+			WithProviderHTTPClient(httpClient),
+			WithProviderLogger(cfg.HTTP.Logger),
+		)
+	}
+}
@@ -0,0 +1,464 @@
+package cloudflaretunnel
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/cloudflare"
+)
+
+// catchAllService is the service of the catch-all rule added when a tunnel
+// has no ingress configuration yet.
+const catchAllService = "http_status:404"
+
+// Provider implements provider.Provider for Cloudflare Tunnel public hostnames.
+//
+// A managed hostname is a CNAME record whose target is the origin service
+// (e.g. "http://traefik:80"). Create publishes it as a proxied DNS CNAME to
+// <tunnel-id>.cfargotunnel.com plus an ingress rule routing the hostname to
+// the service. Ownership TXT records are plain DNS records in the zone.
+type Provider struct {
+	name       string
+	config     *Config
+	client     *cloudflare.Client
+	httpClient *http.Client
+	logger     *slog.Logger
+
+	// zoneMu guards the lazily resolved zone ID.
+	zoneMu sync.Mutex
+	zoneID string
+
+	// ingressMu serializes read-modify-write cycles on the tunnel configuration.
+	ingressMu sync.Mutex
+}
+
+// ProviderOption is a functional option for configuring the Provider.
+type ProviderOption func(*Provider)
+
+// WithProviderLogger sets a custom logger for the provider.
+func WithProviderLogger(logger *slog.Logger) ProviderOption {
+	return func(p *Provider) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// WithProviderHTTPClient sets a custom HTTP client for the provider.
+func WithProviderHTTPClient(client *http.Client) ProviderOption {
+	return func(p *Provider) {
+		if client != nil {
+			p.httpClient = client
+		}
+	}
+}
+
+// WithClient sets a custom Cloudflare API client (for testing).
+func WithClient(client *cloudflare.Client) ProviderOption {
+	return func(p *Provider) {
+		p.client = client
+	}
+}
+
+// New creates a new Cloudflare Tunnel provider instance.
+func New(name string, config *Config, opts ...ProviderOption) (*Provider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		name:   name,
+		config: config,
+		zoneID: config.ZoneID,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// Create client if not provided via options (testing)
+	if p.client == nil {
+		clientOpts := []cloudflare.ClientOption{cloudflare.WithLogger(p.logger)}
+		if p.httpClient != nil {
+			clientOpts = append(clientOpts, cloudflare.WithHTTPClient(p.httpClient))
+		}
+		p.client = cloudflare.NewClient(config.APIToken, clientOpts...)
+	}
+
+	return p, nil
+}
+
+// NewFromEnv creates a new Cloudflare Tunnel provider from environment variables.
+// This is a convenience function for use with the provider registry.
+func NewFromEnv(instanceName string, opts ...ProviderOption) (*Provider, error) {
+	config, err := LoadConfig(instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(instanceName, config, opts...)
+}
+
+// NewFromMap creates a new Cloudflare Tunnel provider from a configuration map.
+// This is used by the provider registry Factory pattern.
+func NewFromMap(name string, configMap map[string]string, opts ...ProviderOption) (*Provider, error) {
+	config, err := LoadConfigFromMap(name, configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(name, config, opts...)
+}
+
+// Name returns the provider instance name.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Type returns "cloudflare-tunnel".
+func (p *Provider) Type() string {
+	return "cloudflare-tunnel"
+}
+
+// Capabilities returns the provider's feature support.
+// Public hostnames are CNAME records targeting an origin service; TXT records
+// are supported for ownership tracking.
+func (p *Provider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SupportsOwnershipTXT:    true,
+		SupportsNativeUpdate:    true,
+		SupportsMultipleTargets: false,
+		SupportedRecordTypes: []provider.RecordType{
+			provider.RecordTypeCNAME,
+			provider.RecordTypeTXT,
+		},
+	}
+}
+
+// Zone returns the configured DNS zone name.
+func (p *Provider) Zone() string {
+	return p.config.Zone
+}
+
+// ZoneID returns the zone ID, looking it up from the zone name if necessary.
+func (p *Provider) ZoneID(ctx context.Context) (string, error) {
+	p.zoneMu.Lock()
+	defer p.zoneMu.Unlock()
+
+	if p.zoneID != "" {
+		return p.zoneID, nil
+	}
+
+	zoneID, err := p.client.GetZoneID(ctx, p.config.Zone)
+	if err != nil {
+		return "", err
+	}
+	p.zoneID = zoneID
+	return zoneID, nil
+}
+
+// Ping checks that the tunnel configuration can be read with the configured
+// account, tunnel, and token.
+func (p *Provider) Ping(ctx context.Context) error {
+	if _, err := p.client.GetTunnelConfig(ctx, p.config.AccountID, p.config.TunnelID); err != nil {
+		return fmt.Errorf("ping failed: %w", err)
+	}
+	return nil
+}
+
+// List returns the tunnel's public hostnames in the zone as CNAME records
+// targeting their origin service, plus the zone's TXT records.
+// Ingress rules with a path are not listed, since they cannot be expressed
+// as a single record.
+func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
+	tunnelConfig, err := p.client.GetTunnelConfig(ctx, p.config.AccountID, p.config.TunnelID)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []provider.Record
+	for _, rule := range tunnelConfig.Ingress {
+		if rule.Hostname == "" || rule.Path != "" || !p.inZone(rule.Hostname) {
+			continue
+		}
+		records = append(records, provider.Record{
+			Hostname: normalizeName(rule.Hostname),
+			Type:     provider.RecordTypeCNAME,
+			Target:   rule.Service,
+		})
+	}
+
+	zoneID, err := p.ZoneID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting zone ID: %w", err)
+	}
+
+	txtRecords, err := p.client.ListRecords(ctx, zoneID, "TXT")
+	if err != nil {
+		return nil, fmt.Errorf("listing TXT records: %w", err)
+	}
+	for _, r := range txtRecords {
+		records = append(records, provider.Record{
+			Hostname:   r.Name,
+			Type:       provider.RecordTypeTXT,
+			Target:     r.Content,
+			TTL:        r.TTL,
+			ProviderID: r.ID,
+		})
+	}
+
+	p.logger.Debug("listed records",
+		slog.String("provider", p.name),
+		slog.String("tunnel_id", p.config.TunnelID),
+		slog.Int("count", len(records)),
+	)
+
+	return records, nil
+}
+
+// Create publishes a record. For CNAME records it creates the DNS CNAME to
+// the tunnel and routes the hostname to the record's target service.
+func (p *Provider) Create(ctx context.Context, record provider.Record) error {
+	zoneID, err := p.ZoneID(ctx)
+	if err != nil {
+		return fmt.Errorf("getting zone ID: %w", err)
+	}
+
+	switch record.Type {
+	case provider.RecordTypeCNAME:
+		if err := p.ensureTunnelCNAME(ctx, zoneID, record.Hostname); err != nil {
+			return err
+		}
+		if err := p.setIngress(ctx, record.Hostname, record.Target); err != nil {
+			return err
+		}
+	case provider.RecordTypeTXT:
+		if err := p.client.CreateRecord(ctx, zoneID, "TXT", record.Hostname, record.Target, p.ttlFor(record), false); err != nil {
+			return fmt.Errorf("creating TXT record: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+
+	p.logger.Info("created record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+	)
+
+	return nil
+}
+
+// Delete removes a record. For CNAME records it removes the hostname's
+// ingress rule and the DNS CNAME if it still points to the tunnel.
+func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
+	zoneID, err := p.ZoneID(ctx)
+	if err != nil {
+		return fmt.Errorf("getting zone ID: %w", err)
+	}
+
+	switch record.Type {
+	case provider.RecordTypeCNAME:
+		if err := p.removeIngress(ctx, record.Hostname); err != nil {
+			return err
+		}
+		existing, err := p.client.FindRecord(ctx, zoneID, "CNAME", record.Hostname, "")
+		if err != nil {
+			return fmt.Errorf("finding CNAME record: %w", err)
+		}
+		if existing != nil && p.isTunnelTarget(existing.Content) {
+			if err := p.client.DeleteRecord(ctx, zoneID, existing.ID); err != nil {
+				return fmt.Errorf("deleting CNAME record: %w", err)
+			}
+		}
+	case provider.RecordTypeTXT:
+		if err := p.deleteTXT(ctx, zoneID, record); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+
+	p.logger.Info("deleted record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+	)
+
+	return nil
+}
+
+// Update changes a record in place. For CNAME records only the ingress
+// rule's service changes; the DNS CNAME already points to the tunnel.
+// This implements the provider.Updater interface for native update support.
+func (p *Provider) Update(ctx context.Context, existing, desired provider.Record) error {
+	if existing.Type != desired.Type || normalizeName(existing.Hostname) != normalizeName(desired.Hostname) {
+		if err := p.Delete(ctx, existing); err != nil {
+			return err
+		}
+		return p.Create(ctx, desired)
+	}
+
+	switch desired.Type {
+	case provider.RecordTypeCNAME:
+		if err := p.setIngress(ctx, desired.Hostname, desired.Target); err != nil {
+			return err
+		}
+	case provider.RecordTypeTXT:
+		if err := p.Delete(ctx, existing); err != nil {
+			return err
+		}
+		return p.Create(ctx, desired)
+	default:
+		return fmt.Errorf("unsupported record type: %s", desired.Type)
+	}
+
+	p.logger.Info("updated record",
+		slog.String("provider", p.name),
+		slog.String("hostname", desired.Hostname),
+		slog.String("type", string(desired.Type)),
+		slog.String("old_target", existing.Target),
+		slog.String("new_target", desired.Target),
+	)
+
+	return nil
+}
+
+// ensureTunnelCNAME creates the proxied CNAME from hostname to the tunnel.
+// An existing CNAME to the tunnel is reused; a CNAME elsewhere is a conflict.
+func (p *Provider) ensureTunnelCNAME(ctx context.Context, zoneID, hostname string) error {
+	existing, err := p.client.FindRecord(ctx, zoneID, "CNAME", hostname, "")
+	if err != nil {
+		return fmt.Errorf("finding CNAME record: %w", err)
+	}
+	if existing != nil {
+		if p.isTunnelTarget(existing.Content) {
+			return nil
+		}
+		return fmt.Errorf("CNAME %s points to %s, not the tunnel: %w", hostname, existing.Content, provider.ErrConflict)
+	}
+
+	// Proxied records use Cloudflare's automatic TTL
+	if err := p.client.CreateRecord(ctx, zoneID, "CNAME", hostname, p.config.TunnelHostname(), 1, true); err != nil {
+		return fmt.Errorf("creating CNAME record: %w", err)
+	}
+	return nil
+}
+
+// setIngress routes hostname to service, replacing any existing rule for
+// the hostname. New rules are inserted before the catch-all rule.
+func (p *Provider) setIngress(ctx context.Context, hostname, service string) error {
+	p.ingressMu.Lock()
+	defer p.ingressMu.Unlock()
+
+	tunnelConfig, err := p.client.GetTunnelConfig(ctx, p.config.AccountID, p.config.TunnelID)
+	if err != nil {
+		return err
+	}
+
+	hostname = normalizeName(hostname)
+	for i, rule := range tunnelConfig.Ingress {
+		if rule.Path == "" && normalizeName(rule.Hostname) == hostname {
+			if rule.Service == service {
+				return nil
+			}
+			tunnelConfig.Ingress[i].Service = service
+			return p.client.UpdateTunnelConfig(ctx, p.config.AccountID, p.config.TunnelID, tunnelConfig)
+		}
+	}
+
+	rule := cloudflare.TunnelIngressRule{Hostname: hostname, Service: service}
+	rules := tunnelConfig.Ingress
+	if n := len(rules); n > 0 && rules[n-1].Hostname == "" && rules[n-1].Path == "" {
+		rules = append(rules[:n-1:n-1], rule, rules[n-1])
+	} else {
+		rules = append(rules, rule, cloudflare.TunnelIngressRule{Service: catchAllService})
+	}
+	tunnelConfig.Ingress = rules
+
+	return p.client.UpdateTunnelConfig(ctx, p.config.AccountID, p.config.TunnelID, tunnelConfig)
+}
+
+// removeIngress removes the rule routing hostname, if any.
+func (p *Provider) removeIngress(ctx context.Context, hostname string) error {
+	p.ingressMu.Lock()
+	defer p.ingressMu.Unlock()
+
+	tunnelConfig, err := p.client.GetTunnelConfig(ctx, p.config.AccountID, p.config.TunnelID)
+	if err != nil {
+		return err
+	}
+
+	hostname = normalizeName(hostname)
+	rules := make([]cloudflare.TunnelIngressRule, 0, len(tunnelConfig.Ingress))
+	for _, rule := range tunnelConfig.Ingress {
+		if rule.Path == "" && normalizeName(rule.Hostname) == hostname {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if len(rules) == len(tunnelConfig.Ingress) {
+		return nil
+	}
+	tunnelConfig.Ingress = rules
+
+	return p.client.UpdateTunnelConfig(ctx, p.config.AccountID, p.config.TunnelID, tunnelConfig)
+}
+
+// deleteTXT deletes a TXT record by its ID, or by hostname when the record
+// did not come from List.
+func (p *Provider) deleteTXT(ctx context.Context, zoneID string, record provider.Record) error {
+	recordID := record.ProviderID
+	if recordID == "" {
+		existing, err := p.client.FindRecord(ctx, zoneID, "TXT", record.Hostname, "")
+		if err != nil {
+			return fmt.Errorf("finding TXT record: %w", err)
+		}
+		if existing == nil {
+			return nil // Record doesn't exist, nothing to delete
+		}
+		recordID = existing.ID
+	}
+
+	if err := p.client.DeleteRecord(ctx, zoneID, recordID); err != nil {
+		return fmt.Errorf("deleting TXT record: %w", err)
+	}
+	return nil
+}
+
+// ttlFor returns the record's TTL, or the configured default.
+func (p *Provider) ttlFor(record provider.Record) int {
+	if record.TTL > 0 {
+		return record.TTL
+	}
+	return p.config.TTL
+}
+
+// isTunnelTarget reports whether a CNAME target is this provider's tunnel.
+func (p *Provider) isTunnelTarget(target string) bool {
+	return normalizeName(target) == p.config.TunnelHostname()
+}
+
+// inZone reports whether hostname is the zone apex or a name below it.
+func (p *Provider) inZone(hostname string) bool {
+	hostname = normalizeName(hostname)
+	return hostname == p.config.Zone || strings.HasSuffix(hostname, "."+p.config.Zone)
+}
+
+// Ensure Provider implements provider.Provider at compile time.
+var _ provider.Provider = (*Provider)(nil)
+
+// Ensure Provider implements provider.Updater at compile time.
+var _ provider.Updater = (*Provider)(nil)
@@ -0,0 +1,234 @@
+package cloudflaretunnel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/providers/cloudflare"
+)
+
+const (
+	testTunnelID   = "tun"
+	testTunnelHost = "tun.cfargotunnel.com"
+	tunnelPath     = "/accounts/acc/cfd_tunnel/tun/configurations"
+	recordsPath    = "/zones/zone1/dns_records"
+)
+
+type fakeRecord struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl"`
+	Proxied bool   `json:"proxied"`
+}
+
+// fakeCloudflare is an in-memory Cloudflare API serving DNS records and a
+// tunnel configuration.
+type fakeCloudflare struct {
+	mu      sync.Mutex
+	records []fakeRecord
+	config  json.RawMessage
+	puts    int
+	nextID  int
+}
+
+func (f *fakeCloudflare) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	respond := func(result any) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"success": true, "errors": []any{}, "result": result})
+	}
+
+	switch {
+	case r.URL.Path == tunnelPath && r.Method == http.MethodGet:
+		respond(map[string]any{"tunnel_id": testTunnelID, "config": f.config})
+	case r.URL.Path == tunnelPath && r.Method == http.MethodPut:
+		var body struct {
+			Config json.RawMessage `json:"config"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.config = body.Config
+		f.puts++
+		respond(map[string]any{})
+	case r.URL.Path == recordsPath && r.Method == http.MethodGet:
+		var matched []fakeRecord
+		for _, rec := range f.records {
+			if rec.Type == r.URL.Query().Get("type") && (r.URL.Query().Get("name") == "" || rec.Name == r.URL.Query().Get("name")) {
+				matched = append(matched, rec)
+			}
+		}
+		respond(matched)
+	case r.URL.Path == recordsPath && r.Method == http.MethodPost:
+		var rec fakeRecord
+		_ = json.NewDecoder(r.Body).Decode(&rec)
+		f.nextID++
+		rec.ID = fmt.Sprintf("rec%d", f.nextID)
+		f.records = append(f.records, rec)
+		respond(rec)
+	case strings.HasPrefix(r.URL.Path, recordsPath+"/") && r.Method == http.MethodDelete:
+		id := strings.TrimPrefix(r.URL.Path, recordsPath+"/")
+		for i, rec := range f.records {
+			if rec.ID == id {
+				f.records = append(f.records[:i], f.records[i+1:]...)
+				break
+			}
+		}
+		respond(map[string]string{"id": id})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// ingress returns the current ingress rules as hostname=service pairs.
+func (f *fakeCloudflare) ingress(t *testing.T) []string {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var config cloudflare.TunnelConfig
+	if err := json.Unmarshal(f.config, &config); err != nil {
+		t.Fatalf("parsing tunnel config: %v", err)
+	}
+	var rules []string
+	for _, rule := range config.Ingress {
+		rules = append(rules, rule.Hostname+"="+rule.Service)
+	}
+	return rules
+}
+
+func newTestProvider(t *testing.T, cf *fakeCloudflare) *Provider {
+	t.Helper()
+	server := httptest.NewServer(cf)
+	t.Cleanup(server.Close)
+
+	p, err := New("cftunnel", &Config{
+		AccountID: "acc",
+		APIToken:  "token",
+		TunnelID:  testTunnelID,
+		Zone:      "example.com",
+		ZoneID:    "zone1",
+		TTL:       DefaultTTL,
+	}, WithClient(cloudflare.NewClient("token", cloudflare.WithAPIEndpoint(server.URL))))
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("cftunnel", nil); err == nil {
+		t.Error("New() with nil config expected error")
+	}
+	if _, err := New("cftunnel", &Config{AccountID: "acc"}); err == nil {
+		t.Error("New() with invalid config expected error")
+	}
+}
+
+func TestProvider_CreateListDelete(t *testing.T) {
+	cf := &fakeCloudflare{config: json.RawMessage(`{"ingress":[{"hostname":"manual.example.com","service":"http://manual:80"},{"service":"http_status:404"}],"warp-routing":{"enabled":true}}`)}
+	p := newTestProvider(t, cf)
+	ctx := context.Background()
+
+	if err := p.Ping(ctx); err != nil {
+		t.Fatalf("Ping() unexpected error: %v", err)
+	}
+
+	for _, rec := range []provider.Record{
+		{Hostname: "app.example.com", Type: provider.RecordTypeCNAME, Target: "http://traefik:80"},
+		{Hostname: "_dnsweaver.app.example.com", Type: provider.RecordTypeTXT, Target: "heritage=dnsweaver"},
+	} {
+		if err := p.Create(ctx, rec); err != nil {
+			t.Fatalf("Create(%s) unexpected error: %v", rec.Type, err)
+		}
+	}
+
+	want := "manual.example.com=http://manual:80,app.example.com=http://traefik:80,=http_status:404"
+	if got := strings.Join(cf.ingress(t), ","); got != want {
+		t.Errorf("ingress = %s, want %s", got, want)
+	}
+	if !strings.Contains(string(cf.config), `"warp-routing"`) {
+		t.Error("tunnel settings other than ingress were not preserved")
+	}
+	if len(cf.records) != 2 || cf.records[0].Content != testTunnelHost || !cf.records[0].Proxied {
+		t.Errorf("DNS records = %+v, want proxied CNAME to tunnel and TXT", cf.records)
+	}
+
+	records, err := p.List(ctx)
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("List() returned %d records, want 3: %+v", len(records), records)
+	}
+	if records[1].Hostname != "app.example.com" || records[1].Type != provider.RecordTypeCNAME || records[1].Target != "http://traefik:80" {
+		t.Errorf("List()[1] = %+v, want app.example.com CNAME http://traefik:80", records[1])
+	}
+
+	// Update changes only the ingress service
+	if err := p.Update(ctx, records[1], provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeCNAME, Target: "http://traefik:8080"}); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	if got := cf.ingress(t)[1]; got != "app.example.com=http://traefik:8080" {
+		t.Errorf("ingress after Update() = %s", got)
+	}
+
+	if err := p.Delete(ctx, provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeCNAME, Target: "http://traefik:8080"}); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if err := p.Delete(ctx, records[2]); err != nil {
+		t.Fatalf("Delete(TXT) unexpected error: %v", err)
+	}
+	if got := strings.Join(cf.ingress(t), ","); got != "manual.example.com=http://manual:80,=http_status:404" {
+		t.Errorf("ingress after Delete() = %s", got)
+	}
+	if len(cf.records) != 0 {
+		t.Errorf("DNS records after Delete() = %+v, want none", cf.records)
+	}
+
+	// Deleting again is a no-op
+	puts := cf.puts
+	if err := p.Delete(ctx, provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeCNAME}); err != nil {
+		t.Errorf("Delete() of missing record should succeed, got %v", err)
+	}
+	if cf.puts != puts {
+		t.Error("Delete() of missing record rewrote the tunnel configuration")
+	}
+}
+
+func TestProvider_Create_EmptyTunnelConfig(t *testing.T) {
+	cf := &fakeCloudflare{config: json.RawMessage(`null`)}
+	p := newTestProvider(t, cf)
+
+	if err := p.Create(context.Background(), provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeCNAME, Target: "http://traefik:80"}); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if got := strings.Join(cf.ingress(t), ","); got != "app.example.com=http://traefik:80,="+catchAllService {
+		t.Errorf("ingress = %s, want rule plus catch-all", got)
+	}
+}
+
+func TestProvider_Create_ForeignCNAME(t *testing.T) {
+	cf := &fakeCloudflare{
+		config:  json.RawMessage(`{"ingress":[{"service":"http_status:404"}]}`),
+		records: []fakeRecord{{ID: "x", Type: "CNAME", Name: "app.example.com", Content: "elsewhere.example.net"}},
+	}
+	p := newTestProvider(t, cf)
+
+	err := p.Create(context.Background(), provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeCNAME, Target: "http://traefik:80"})
+	if !errors.Is(err, provider.ErrConflict) {
+		t.Errorf("Create() error = %v, want ErrConflict", err)
+	}
+	if cf.puts != 0 {
+		t.Error("Create() with conflicting CNAME changed the tunnel configuration")
+	}
+}
@@ -4,6 +4,7 @@ package cloudflare
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -20,6 +21,10 @@ const (
 	DefaultAPIEndpoint = "https://api.cloudflare.com/client/v4"
 )
 
+// ErrInvalidZone is returned when Cloudflare rejects a zone ID, for example
+// because the zone was deleted or moved to another account.
+var ErrInvalidZone = errors.New("invalid zone identifier")
+
 // apiError represents an error from the Cloudflare API.
 type apiError struct {
 	Code    int    `json:"code"`
@@ -164,6 +169,10 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 
 	// Handle non-2xx status codes
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Classify by status (auth, rate limit, invalid record) when no
+		// Cloudflare error code is more specific
+		base := provider.ErrorForStatus(resp.StatusCode)
+
 		// Try to parse as API response for error details
 		var apiResp apiResponse
 		if err := json.Unmarshal(respBody, &apiResp); err == nil && len(apiResp.Errors) > 0 {
@@ -174,13 +183,23 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 			if errCode == 81053 || errCode == 81058 {
 				return nil, provider.ErrConflict
 			}
+			// Error code 7003 = "Could not route to /zones/..., perhaps your object identifier is invalid?"
+			if errCode == 7003 {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidZone, errMsg)
+			}
 			// Error code 81057 = "CNAME and the record type cannot be used together"
 			// Also check message for CNAME conflicts (defensive)
 			if errCode == 81057 || strings.Contains(strings.ToLower(errMsg), "cname") && strings.Contains(strings.ToLower(errMsg), "cannot") {
 				return nil, provider.ErrTypeConflict
 			}
+			if base != nil {
+				return nil, fmt.Errorf("%w: %s (code: %d)", base, errMsg, errCode)
+			}
 			return nil, fmt.Errorf("API error: %s (code: %d)", errMsg, errCode)
 		}
+		if base != nil {
+			return nil, fmt.Errorf("%w: status %d: %s", base, resp.StatusCode, string(respBody))
+		}
 		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
 	}
 
@@ -213,6 +232,7 @@ func (c *Client) Ping(ctx context.Context) error {
 // It looks up the zone by name using the Cloudflare API.
 func (c *Client) GetZoneID(ctx context.Context, domain string) (string, error) {
 	// Find the root zone for this domain by progressively stripping subdomains
+	var lastErr error
 	parts := strings.Split(domain, ".")
 	for i := 0; i < len(parts)-1; i++ {
 		zoneName := strings.Join(parts[i:], ".")
@@ -222,6 +242,7 @@ func (c *Client) GetZoneID(ctx context.Context, domain string) (string, error) {
 
 		resp, err := c.doRequest(ctx, http.MethodGet, "/zones?"+params.Encode(), nil)
 		if err != nil {
+			lastErr = err
 			continue // Try next level
 		}
 
@@ -240,7 +261,10 @@ func (c *Client) GetZoneID(ctx context.Context, domain string) (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("no zone found for domain %s", domain)
+	if lastErr != nil {
+		return "", fmt.Errorf("no zone found for domain %s: %w", domain, lastErr)
+	}
+	return "", fmt.Errorf("no active zone found for domain %s (check the zone name and that the API token has Zone:Read access)", domain)
 }
 
 // ListRecords returns all DNS records of the specified type in the given zone.
@@ -392,9 +416,11 @@ func (c *Client) UpdateRecord(ctx context.Context, zoneID, recordID, recordType,
 	return nil
 }
 
-// FindRecord finds a DNS record by name and type in the given zone.
+// FindRecord finds a DNS record by name, type, and content in the given zone.
+// A name can hold several records of one type (one per target), so content
+// selects among them; an empty content matches the first record.
 // Returns the record if found, nil otherwise.
-func (c *Client) FindRecord(ctx context.Context, zoneID, recordType, name string) (*dnsRecord, error) {
+func (c *Client) FindRecord(ctx context.Context, zoneID, recordType, name, content string) (*dnsRecord, error) {
 	params := url.Values{}
 	params.Set("type", recordType)
 	params.Set("name", name)
@@ -410,9 +436,16 @@ func (c *Client) FindRecord(ctx context.Context, zoneID, recordType, name string
 		return nil, fmt.Errorf("parsing records response: %w", err)
 	}
 
-	if len(records.Result) == 0 {
-		return nil, nil // Not found
+	for i := range records.Result {
+		if content == "" || sameContent(records.Result[i].Content, content) {
+			return &records.Result[i], nil
+		}
 	}
+	return nil, nil // Not found
+}
 
-	return &records.Result[0], nil
+// sameContent reports whether two record contents are equal, ignoring case
+// and a trailing dot on hostname targets.
+func sameContent(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
 }
@@ -280,7 +280,7 @@ func TestClient_FindRecord_Found(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-token", WithAPIEndpoint(server.URL))
-	record, err := client.FindRecord(context.Background(), "zone-123", "A", "app.example.com")
+	record, err := client.FindRecord(context.Background(), "zone-123", "A", "app.example.com", "10.0.0.1")
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -301,7 +301,7 @@ func TestClient_FindRecord_NotFound(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-token", WithAPIEndpoint(server.URL))
-	record, err := client.FindRecord(context.Background(), "zone-123", "A", "nonexistent.example.com")
+	record, err := client.FindRecord(context.Background(), "zone-123", "A", "nonexistent.example.com", "")
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -311,6 +311,35 @@ func TestClient_FindRecord_NotFound(t *testing.T) {
 	}
 }
 
+func TestClient_FindRecord_MatchesContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(successResponse([]map[string]interface{}{
+			{"id": "rec-1", "type": "A", "name": "app.example.com", "content": "10.0.0.1", "ttl": 300},
+			{"id": "rec-2", "type": "A", "name": "app.example.com", "content": "10.0.0.2", "ttl": 300},
+		}))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithAPIEndpoint(server.URL))
+
+	record, err := client.FindRecord(context.Background(), "zone-123", "A", "app.example.com", "10.0.0.2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record == nil || record.ID != "rec-2" {
+		t.Errorf("expected record rec-2, got %+v", record)
+	}
+
+	record, err = client.FindRecord(context.Background(), "zone-123", "A", "app.example.com", "10.0.0.3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record != nil {
+		t.Errorf("expected nil record for unknown target, got %+v", record)
+	}
+}
+
 func TestClient_RateLimiting(t *testing.T) {
 	callCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -18,6 +18,11 @@ type Config struct {
 	Zone    string // Zone name for lookup (used if ZoneID is empty)
 	TTL     int    // Record TTL (defaults to DefaultTTL)
 	Proxied bool   // Whether to proxy records through Cloudflare (default: false)
+
+	// ApexFlatten enables explicit handling of CNAME records at the zone apex.
+	// Apex CNAMEs are created unproxied and rely on Cloudflare's automatic
+	// CNAME flattening (default: false).
+	ApexFlatten bool
 }
 
 // Validate checks that all required configuration is present.
@@ -58,6 +63,7 @@ func (c *Config) Validate() error {
 //   - ZONE: Zone name for lookup (optional if ZONE_ID is set)
 //   - TTL: Record TTL (optional, defaults to 300)
 //   - PROXIED: Enable Cloudflare proxy (optional, defaults to false)
+//   - CLOUDFLARE_APEX_FLATTEN: Flatten apex CNAME records (optional, defaults to false)
 func LoadConfig(instanceName string) (*Config, error) {
 	prefix := envPrefix(instanceName)
 
@@ -83,6 +89,11 @@ func LoadConfig(instanceName string) (*Config, error) {
 		config.Proxied = parseBool(proxiedStr)
 	}
 
+	// Parse optional CLOUDFLARE_APEX_FLATTEN flag
+	if flattenStr := getEnv(prefix + "CLOUDFLARE_APEX_FLATTEN"); flattenStr != "" {
+		config.ApexFlatten = parseBool(flattenStr)
+	}
+
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
 	}
@@ -142,6 +153,7 @@ func parseBool(s string) bool {
 //   - ZONE: Zone name for lookup (optional if ZONE_ID is set)
 //   - TTL: Record TTL (optional, defaults to 300)
 //   - PROXIED: Enable Cloudflare proxy (optional, defaults to false)
+//   - CLOUDFLARE_APEX_FLATTEN: Flatten apex CNAME records (optional, defaults to false)
 func LoadConfigFromMap(instanceName string, config map[string]string) (*Config, error) {
 	cfg := &Config{
 		Token:   config["TOKEN"],
@@ -165,9 +177,29 @@ func LoadConfigFromMap(instanceName string, config map[string]string) (*Config,
 		cfg.Proxied = parseBool(proxiedStr)
 	}
 
+	// Parse optional CLOUDFLARE_APEX_FLATTEN flag
+	if flattenStr := config["CLOUDFLARE_APEX_FLATTEN"]; flattenStr != "" {
+		cfg.ApexFlatten = parseBool(flattenStr)
+	}
+
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
 	}
 
 	return cfg, nil
 }
+
+// IsApex reports whether hostname is the apex of the configured zone.
+// Always returns false when only ZONE_ID is configured, since the zone
+// name is needed for the comparison.
+func (c *Config) IsApex(hostname string) bool {
+	if c.Zone == "" {
+		return false
+	}
+	return normalizeName(hostname) == normalizeName(c.Zone)
+}
+
+// normalizeName lowercases a DNS name and strips any trailing dot.
+func normalizeName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
@@ -300,3 +300,37 @@ func TestParseBool(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_IsApex(t *testing.T) {
+	tests := []struct {
+		zone     string
+		hostname string
+		want     bool
+	}{
+		{"example.com", "example.com", true},
+		{"example.com", "EXAMPLE.com.", true},
+		{"example.com", "www.example.com", false},
+		{"", "example.com", false},
+	}
+
+	for _, tt := range tests {
+		cfg := &Config{Zone: tt.zone}
+		if got := cfg.IsApex(tt.hostname); got != tt.want {
+			t.Errorf("IsApex(%q) with zone %q = %v, want %v", tt.hostname, tt.zone, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigFromMap_ApexFlatten(t *testing.T) {
+	cfg, err := LoadConfigFromMap("test", map[string]string{
+		"TOKEN":                   "test-token",
+		"ZONE":                    "example.com",
+		"CLOUDFLARE_APEX_FLATTEN": "true",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.ApexFlatten {
+		t.Error("expected ApexFlatten to be true")
+	}
+}
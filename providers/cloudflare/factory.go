@@ -17,6 +17,19 @@ func Factory() provider.Factory {
 			return nil, err
 		}
 
+		// CNAME at the zone apex relies on Cloudflare flattening; make that explicit
+		if cfg.RecordType == provider.RecordTypeCNAME && !providerCfg.ApexFlatten && cfg.HTTP.Logger != nil {
+			for _, domain := range cfg.Domains {
+				if providerCfg.IsApex(domain) {
+					cfg.HTTP.Logger.Warn("CNAME record type matches the zone apex without CLOUDFLARE_APEX_FLATTEN set",
+						slog.String("provider", cfg.Name),
+						slog.String("zone", providerCfg.Zone),
+					)
+					break
+				}
+			}
+		}
+
 		// Create HTTP client with the factory's HTTP configuration
 		httpClient := httputil.NewClient(&httputil.ClientConfig{
 			Timeout:       cfg.HTTP.Timeout,
@@ -3,9 +3,11 @@ package cloudflare
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"sync"
 
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
@@ -13,18 +15,19 @@ import (
 
 // Provider implements provider.Provider for Cloudflare DNS.
 type Provider struct {
-	name       string
-	zone       string // Zone name (for display/logging)
-	zoneID     string // Resolved zone ID
-	ttl        int
-	proxied    bool
-	client     *Client
-	httpClient *http.Client // Custom HTTP client (optional)
-	logger     *slog.Logger
-
-	// zoneIDOnce ensures zone ID lookup happens only once
-	zoneIDOnce sync.Once
-	zoneIDErr  error
+	name        string
+	zone        string // Zone name (for display/logging)
+	zoneID      string // Resolved zone ID (configured or discovered)
+	ttl         int
+	proxied     bool
+	apexFlatten bool
+	config      *Config
+	client      *Client
+	httpClient  *http.Client // Custom HTTP client (optional)
+	logger      *slog.Logger
+
+	// zoneMu guards zoneID when it is discovered from the zone name
+	zoneMu sync.Mutex
 }
 
 // ProviderOption is a functional option for configuring the Provider.
@@ -61,12 +64,14 @@ func New(name string, config *Config, opts ...ProviderOption) (*Provider, error)
 	}
 
 	p := &Provider{
-		name:    name,
-		zone:    config.Zone,
-		zoneID:  config.ZoneID,
-		ttl:     config.TTL,
-		proxied: config.Proxied,
-		logger:  slog.Default(),
+		name:        name,
+		zone:        config.Zone,
+		zoneID:      config.ZoneID,
+		ttl:         config.TTL,
+		proxied:     config.Proxied,
+		apexFlatten: config.ApexFlatten,
+		config:      config,
+		logger:      slog.Default(),
 	}
 
 	for _, opt := range opts {
@@ -118,6 +123,11 @@ func NewFromMap(name string, config map[string]string) (*Provider, error) {
 		cfg.Proxied = parseBool(proxiedStr)
 	}
 
+	// Parse CLOUDFLARE_APEX_FLATTEN if provided
+	if flattenStr, ok := config["CLOUDFLARE_APEX_FLATTEN"]; ok && flattenStr != "" {
+		cfg.ApexFlatten = parseBool(flattenStr)
+	}
+
 	return New(name, cfg)
 }
 
@@ -135,8 +145,9 @@ func (p *Provider) Type() string {
 // Cloudflare supports all features: TXT ownership, native update, and all record types.
 func (p *Provider) Capabilities() provider.Capabilities {
 	return provider.Capabilities{
-		SupportsOwnershipTXT: true,
-		SupportsNativeUpdate: true,
+		SupportsOwnershipTXT:    true,
+		SupportsNativeUpdate:    true,
+		SupportsMultipleTargets: true,
 		SupportedRecordTypes: []provider.RecordType{
 			provider.RecordTypeA,
 			provider.RecordTypeAAAA,
@@ -153,27 +164,66 @@ func (p *Provider) Zone() string {
 }
 
 // ZoneID returns the resolved zone ID, looking it up if necessary.
+// A configured ZONE_ID is used as-is. Otherwise the ID is discovered from the
+// zone name and cached until Cloudflare rejects it (see forgetZoneID).
 func (p *Provider) ZoneID(ctx context.Context) (string, error) {
 	// If zone ID was explicitly configured, use it
+	if p.config.ZoneID != "" {
+		return p.config.ZoneID, nil
+	}
+
+	p.zoneMu.Lock()
+	defer p.zoneMu.Unlock()
+
 	if p.zoneID != "" {
 		return p.zoneID, nil
 	}
 
-	// Lazy lookup zone ID from zone name
-	p.zoneIDOnce.Do(func() {
-		p.zoneID, p.zoneIDErr = p.client.GetZoneID(ctx, p.zone)
-	})
+	zoneID, err := p.client.GetZoneID(ctx, p.zone)
+	if err != nil {
+		return "", fmt.Errorf("discovering zone ID for %s: %w", p.zone, err)
+	}
+	p.zoneID = zoneID
+
+	p.logger.Info("discovered zone ID",
+		slog.String("provider", p.name),
+		slog.String("zone", p.zone),
+		slog.String("zone_id", zoneID),
+	)
 
-	if p.zoneIDErr != nil {
-		return "", p.zoneIDErr
+	return zoneID, nil
+}
+
+// forgetZoneID drops a discovered zone ID when Cloudflare reports it as
+// invalid, so the next operation looks it up again. Configured zone IDs are
+// kept. Returns err unchanged for use in return statements.
+func (p *Provider) forgetZoneID(err error) error {
+	if !errors.Is(err, ErrInvalidZone) || p.config.ZoneID != "" {
+		return err
 	}
 
-	return p.zoneID, nil
+	p.zoneMu.Lock()
+	defer p.zoneMu.Unlock()
+	if p.zoneID != "" {
+		p.logger.Warn("discovered zone ID is no longer valid, will look it up again",
+			slog.String("provider", p.name),
+			slog.String("zone", p.zone),
+			slog.String("zone_id", p.zoneID),
+		)
+		p.zoneID = ""
+	}
+	return err
 }
 
-// Ping checks connectivity to the Cloudflare API.
+// Ping checks connectivity to the Cloudflare API. When no ZONE_ID is
+// configured, it also discovers the zone ID so a missing or inaccessible zone
+// is reported at startup.
 func (p *Provider) Ping(ctx context.Context) error {
-	return p.client.Ping(ctx)
+	if err := p.client.Ping(ctx); err != nil {
+		return err
+	}
+	_, err := p.ZoneID(ctx)
+	return err
 }
 
 // List returns all managed records in the zone.
@@ -189,7 +239,7 @@ func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
 	// Fetch A records
 	aRecords, err := p.client.ListRecords(ctx, zoneID, "A")
 	if err != nil {
-		return nil, fmt.Errorf("listing A records: %w", err)
+		return nil, fmt.Errorf("listing A records: %w", p.forgetZoneID(err))
 	}
 	for _, r := range aRecords {
 		records = append(records, provider.Record{
@@ -204,7 +254,7 @@ func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
 	// Fetch AAAA records
 	aaaaRecords, err := p.client.ListRecords(ctx, zoneID, "AAAA")
 	if err != nil {
-		return nil, fmt.Errorf("listing AAAA records: %w", err)
+		return nil, fmt.Errorf("listing AAAA records: %w", p.forgetZoneID(err))
 	}
 	for _, r := range aaaaRecords {
 		records = append(records, provider.Record{
@@ -219,7 +269,7 @@ func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
 	// Fetch CNAME records
 	cnameRecords, err := p.client.ListRecords(ctx, zoneID, "CNAME")
 	if err != nil {
-		return nil, fmt.Errorf("listing CNAME records: %w", err)
+		return nil, fmt.Errorf("listing CNAME records: %w", p.forgetZoneID(err))
 	}
 	for _, r := range cnameRecords {
 		records = append(records, provider.Record{
@@ -234,7 +284,7 @@ func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
 	// Fetch TXT records
 	txtRecords, err := p.client.ListRecords(ctx, zoneID, "TXT")
 	if err != nil {
-		return nil, fmt.Errorf("listing TXT records: %w", err)
+		return nil, fmt.Errorf("listing TXT records: %w", p.forgetZoneID(err))
 	}
 	for _, r := range txtRecords {
 		records = append(records, provider.Record{
@@ -249,7 +299,7 @@ func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
 	// Fetch SRV records
 	srvRecords, err := p.client.ListRecords(ctx, zoneID, "SRV")
 	if err != nil {
-		return nil, fmt.Errorf("listing SRV records: %w", err)
+		return nil, fmt.Errorf("listing SRV records: %w", p.forgetZoneID(err))
 	}
 	for _, r := range srvRecords {
 		rec := provider.Record{
@@ -294,12 +344,7 @@ func (p *Provider) Create(ctx context.Context, record provider.Record) error {
 		ttl = p.ttl
 	}
 
-	// Determine if record should be proxied
-	// TXT and SRV records cannot be proxied by Cloudflare
-	proxied := p.proxied
-	if record.Type == provider.RecordTypeTXT || record.Type == provider.RecordTypeSRV {
-		proxied = false
-	}
+	proxied := p.proxiedFor(record)
 
 	// Cloudflare uses TTL=1 for "automatic" (when proxied)
 	if proxied && ttl < 60 {
@@ -313,13 +358,13 @@ func (p *Provider) Create(ctx context.Context, record provider.Record) error {
 		}
 		err = p.client.CreateSRVRecord(ctx, zoneID, record.Hostname, record.SRV.Priority, record.SRV.Weight, record.SRV.Port, record.Target, ttl)
 		if err != nil {
-			return fmt.Errorf("creating SRV record: %w", err)
+			return fmt.Errorf("creating SRV record: %w", p.forgetZoneID(err))
 		}
 	} else {
 		recordType := string(record.Type)
 		err = p.client.CreateRecord(ctx, zoneID, recordType, record.Hostname, record.Target, ttl, proxied)
 		if err != nil {
-			return fmt.Errorf("creating %s record: %w", recordType, err)
+			return fmt.Errorf("creating %s record: %w", recordType, p.forgetZoneID(err))
 		}
 	}
 
@@ -335,6 +380,46 @@ func (p *Provider) Create(ctx context.Context, record provider.Record) error {
 	return nil
 }
 
+// ProxiedHint is the record hint key that overrides PROXIED for one hostname.
+// Set from the dnsweaver.cloudflare.proxied label.
+const ProxiedHint = "cloudflare.proxied"
+
+// proxiedFor determines whether a record should be proxied through Cloudflare.
+// TXT and SRV records cannot be proxied. A cloudflare.proxied hint from the
+// hostname's labels overrides the provider default. When apex flattening is
+// enabled, CNAME records at the zone apex are otherwise created unproxied so
+// that Cloudflare's automatic CNAME flattening applies.
+func (p *Provider) proxiedFor(record provider.Record) bool {
+	switch record.Type {
+	case provider.RecordTypeTXT, provider.RecordTypeSRV:
+		return false
+	}
+
+	if value, ok := record.Hints[ProxiedHint]; ok {
+		proxied, err := strconv.ParseBool(value)
+		if err == nil {
+			return proxied
+		}
+		p.logger.Warn("invalid cloudflare.proxied label, using provider default",
+			slog.String("provider", p.name),
+			slog.String("hostname", record.Hostname),
+			slog.String("value", value),
+		)
+	}
+
+	switch record.Type {
+	case provider.RecordTypeCNAME:
+		if p.apexFlatten && p.config.IsApex(record.Hostname) {
+			p.logger.Debug("using CNAME flattening for apex record",
+				slog.String("provider", p.name),
+				slog.String("hostname", record.Hostname),
+			)
+			return false
+		}
+	}
+	return p.proxied
+}
+
 // Delete removes a DNS record.
 func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
 	zoneID, err := p.ZoneID(ctx)
@@ -343,9 +428,9 @@ func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
 	}
 
 	// Find the record to get its ID
-	apiRecord, err := p.client.FindRecord(ctx, zoneID, string(record.Type), record.Hostname)
+	apiRecord, err := p.client.FindRecord(ctx, zoneID, string(record.Type), record.Hostname, record.Target)
 	if err != nil {
-		return fmt.Errorf("finding record: %w", err)
+		return fmt.Errorf("finding record: %w", p.forgetZoneID(err))
 	}
 	if apiRecord == nil {
 		p.logger.Warn("record not found for deletion",
@@ -357,7 +442,7 @@ func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
 
 	err = p.client.DeleteRecord(ctx, zoneID, apiRecord.ID)
 	if err != nil {
-		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+		return fmt.Errorf("deleting %s record: %w", record.Type, p.forgetZoneID(err))
 	}
 
 	p.logger.Info("deleted record",
@@ -379,9 +464,9 @@ func (p *Provider) Update(ctx context.Context, existing, desired provider.Record
 	}
 
 	// Find the existing record to get its ID
-	apiRecord, err := p.client.FindRecord(ctx, zoneID, string(existing.Type), existing.Hostname)
+	apiRecord, err := p.client.FindRecord(ctx, zoneID, string(existing.Type), existing.Hostname, existing.Target)
 	if err != nil {
-		return fmt.Errorf("finding record: %w", err)
+		return fmt.Errorf("finding record: %w", p.forgetZoneID(err))
 	}
 	if apiRecord == nil {
 		return provider.ErrNotFound
@@ -395,9 +480,9 @@ func (p *Provider) Update(ctx context.Context, existing, desired provider.Record
 	// Cloudflare's update API takes the new values
 	switch desired.Type {
 	case provider.RecordTypeA, provider.RecordTypeAAAA, provider.RecordTypeCNAME, provider.RecordTypeTXT:
-		err = p.client.UpdateRecord(ctx, zoneID, apiRecord.ID, string(desired.Type), desired.Hostname, desired.Target, ttl, p.proxied)
+		err = p.client.UpdateRecord(ctx, zoneID, apiRecord.ID, string(desired.Type), desired.Hostname, desired.Target, ttl, p.proxiedFor(desired))
 		if err != nil {
-			return fmt.Errorf("updating %s record: %w", desired.Type, err)
+			return fmt.Errorf("updating %s record: %w", desired.Type, p.forgetZoneID(err))
 		}
 	case provider.RecordTypeSRV:
 		// SRV records need special handling - for now, fall back to delete+create
@@ -407,11 +492,11 @@ func (p *Provider) Update(ctx context.Context, existing, desired provider.Record
 		}
 		// Delete old record
 		if err := p.client.DeleteRecord(ctx, zoneID, apiRecord.ID); err != nil {
-			return fmt.Errorf("deleting old SRV record for update: %w", err)
+			return fmt.Errorf("deleting old SRV record for update: %w", p.forgetZoneID(err))
 		}
 		// Create new record
 		if err := p.client.CreateSRVRecord(ctx, zoneID, desired.Hostname, desired.SRV.Priority, desired.SRV.Weight, desired.SRV.Port, desired.Target, ttl); err != nil {
-			return fmt.Errorf("creating new SRV record for update: %w", err)
+			return fmt.Errorf("creating new SRV record for update: %w", p.forgetZoneID(err))
 		}
 	default:
 		return fmt.Errorf("unsupported record type: %s", desired.Type)
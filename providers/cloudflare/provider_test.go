@@ -3,8 +3,10 @@ package cloudflare
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
@@ -161,6 +163,71 @@ func TestProvider_ZoneID_Lookup(t *testing.T) {
 	}
 }
 
+func TestProvider_ZoneID_RediscoveredWhenInvalid(t *testing.T) {
+	var lookups int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/zones":
+			lookups++
+			id := "old-zone-id"
+			if lookups > 1 {
+				id = "new-zone-id"
+			}
+			_ = json.NewEncoder(w).Encode(successProviderResponse([]map[string]interface{}{
+				{"id": id, "name": "example.com", "status": "active"},
+			}))
+		case "/zones/old-zone-id/dns_records":
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"errors": []map[string]interface{}{
+					{"code": 7003, "message": "Could not route to /zones/old-zone-id/dns_records, perhaps your object identifier is invalid?"},
+				},
+			})
+		default:
+			_ = json.NewEncoder(w).Encode(successProviderResponse([]map[string]interface{}{}))
+		}
+	}))
+	defer server.Close()
+
+	p, _ := New("test", &Config{Token: "token", Zone: "example.com", TTL: 300})
+	p.client.apiEndpoint = server.URL
+
+	_, err := p.List(context.Background())
+	if !errors.Is(err, ErrInvalidZone) {
+		t.Fatalf("expected ErrInvalidZone, got %v", err)
+	}
+
+	if _, err := p.List(context.Background()); err != nil {
+		t.Fatalf("expected List to succeed after rediscovery, got %v", err)
+	}
+	zoneID, _ := p.ZoneID(context.Background())
+	if zoneID != "new-zone-id" || lookups != 2 {
+		t.Errorf("zone ID = %s after %d lookups, want new-zone-id after 2", zoneID, lookups)
+	}
+}
+
+func TestProvider_Ping_ZoneNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/zones" {
+			_ = json.NewEncoder(w).Encode(successProviderResponse([]map[string]interface{}{}))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(successProviderResponse(map[string]interface{}{"status": "active"}))
+	}))
+	defer server.Close()
+
+	p, _ := New("test", &Config{Token: "token", Zone: "missing.example", TTL: 300})
+	p.client.apiEndpoint = server.URL
+
+	err := p.Ping(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "missing.example") {
+		t.Errorf("expected error naming the zone, got %v", err)
+	}
+}
+
 func TestProvider_List_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		query := r.URL.Query()
@@ -260,6 +327,34 @@ func TestProvider_Create_ARecord(t *testing.T) {
 	}
 }
 
+func TestProvider_ProxiedFor_Hint(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxied bool
+		record  provider.Record
+		want    bool
+	}{
+		{"default off", false, provider.Record{Type: provider.RecordTypeA}, false},
+		{"default on", true, provider.Record{Type: provider.RecordTypeA}, true},
+		{"hint enables", false, provider.Record{Type: provider.RecordTypeCNAME, Hints: map[string]string{ProxiedHint: "true"}}, true},
+		{"hint disables", true, provider.Record{Type: provider.RecordTypeA, Hints: map[string]string{ProxiedHint: "false"}}, false},
+		{"invalid hint uses default", true, provider.Record{Type: provider.RecordTypeA, Hints: map[string]string{ProxiedHint: "maybe"}}, true},
+		{"TXT never proxied", false, provider.Record{Type: provider.RecordTypeTXT, Hints: map[string]string{ProxiedHint: "true"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New("test", &Config{Token: "token", ZoneID: "zone-123", TTL: 300, Proxied: tt.proxied})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if got := p.proxiedFor(tt.record); got != tt.want {
+				t.Errorf("proxiedFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestProvider_Create_CNAMERecord(t *testing.T) {
 	var receivedBody map[string]interface{}
 
@@ -334,6 +429,103 @@ func TestProvider_Create_WithProxied(t *testing.T) {
 	}
 }
 
+func TestProvider_Create_ApexCNAMEFlatten(t *testing.T) {
+	tests := []struct {
+		name        string
+		hostname    string
+		apexFlatten bool
+		wantProxied bool
+	}{
+		{"apex with flatten is unproxied", "Example.com.", true, false},
+		{"apex without flatten keeps proxied", "example.com", false, true},
+		{"subdomain with flatten keeps proxied", "www.example.com", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedBody map[string]interface{}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					_ = json.NewDecoder(r.Body).Decode(&receivedBody)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(successProviderResponse(map[string]interface{}{
+					"id": "new-rec",
+				}))
+			}))
+			defer server.Close()
+
+			config := &Config{
+				Token:       "test-token",
+				ZoneID:      "zone-123",
+				Zone:        "example.com",
+				TTL:         300,
+				Proxied:     true,
+				ApexFlatten: tt.apexFlatten,
+			}
+			p, _ := New("apex-provider", config)
+			p.client.apiEndpoint = server.URL
+
+			record := provider.Record{
+				Hostname: tt.hostname,
+				Type:     provider.RecordTypeCNAME,
+				Target:   "lb.example.net",
+			}
+
+			if err := p.Create(context.Background(), record); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if receivedBody["type"] != "CNAME" {
+				t.Errorf("expected type CNAME, got %v", receivedBody["type"])
+			}
+			if receivedBody["proxied"] != tt.wantProxied {
+				t.Errorf("expected proxied %v, got %v", tt.wantProxied, receivedBody["proxied"])
+			}
+		})
+	}
+}
+
+func TestProvider_Delete_MultipleTargets(t *testing.T) {
+	var deleted []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet && r.URL.Path == "/zones/zone-123/dns_records" {
+			_ = json.NewEncoder(w).Encode(successProviderResponse([]map[string]interface{}{
+				{"id": "rec-keep", "type": "A", "name": "app.example.com", "content": "10.0.0.1"},
+				{"id": "rec-drop", "type": "A", "name": "app.example.com", "content": "10.0.0.2"},
+			}))
+			return
+		}
+
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/zones/zone-123/dns_records/"))
+			_ = json.NewEncoder(w).Encode(successProviderResponse(map[string]interface{}{}))
+			return
+		}
+
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	p := newTestProvider(t, server.URL)
+	err := p.Delete(context.Background(), provider.Record{
+		Hostname: "app.example.com",
+		Type:     provider.RecordTypeA,
+		Target:   "10.0.0.2",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(deleted) != 1 || deleted[0] != "rec-drop" {
+		t.Errorf("deleted = %v, want [rec-drop]", deleted)
+	}
+}
+
 func TestProvider_Delete_Success(t *testing.T) {
 	deleteCalled := false
 
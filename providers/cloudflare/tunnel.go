@@ -0,0 +1,117 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// TunnelIngressRule is one entry of a Cloudflare Tunnel's ingress configuration.
+// A rule without a hostname is a catch-all and must be the last rule.
+type TunnelIngressRule struct {
+	Hostname      string          `json:"hostname,omitempty"`
+	Path          string          `json:"path,omitempty"`
+	Service       string          `json:"service"`
+	OriginRequest json.RawMessage `json:"originRequest,omitempty"`
+}
+
+// TunnelConfig is the remotely-managed configuration of a Cloudflare Tunnel.
+// Settings other than the ingress rules are preserved as-is when the
+// configuration is written back.
+type TunnelConfig struct {
+	Ingress []TunnelIngressRule
+
+	other map[string]json.RawMessage
+}
+
+// UnmarshalJSON decodes the ingress rules and keeps all other settings.
+func (c *TunnelConfig) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	c.Ingress = nil
+	if raw, ok := fields["ingress"]; ok {
+		if err := json.Unmarshal(raw, &c.Ingress); err != nil {
+			return fmt.Errorf("parsing ingress rules: %w", err)
+		}
+		delete(fields, "ingress")
+	}
+	c.other = fields
+	return nil
+}
+
+// MarshalJSON encodes the ingress rules together with the preserved settings.
+func (c TunnelConfig) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]any, len(c.other)+1)
+	for k, v := range c.other {
+		fields[k] = v
+	}
+	ingress := c.Ingress
+	if ingress == nil {
+		ingress = []TunnelIngressRule{}
+	}
+	fields["ingress"] = ingress
+	return json.Marshal(fields)
+}
+
+// tunnelConfigResult wraps the tunnel configuration response.
+type tunnelConfigResult struct {
+	TunnelID string        `json:"tunnel_id"`
+	Version  int           `json:"version"`
+	Config   *TunnelConfig `json:"config"`
+}
+
+// tunnelConfigPath returns the configuration endpoint for a tunnel.
+func tunnelConfigPath(accountID, tunnelID string) string {
+	return fmt.Sprintf("/accounts/%s/cfd_tunnel/%s/configurations", accountID, tunnelID)
+}
+
+// GetTunnelConfig returns the remotely-managed configuration of a tunnel.
+// A tunnel that has no configuration yet returns an empty TunnelConfig.
+func (c *Client) GetTunnelConfig(ctx context.Context, accountID, tunnelID string) (*TunnelConfig, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, tunnelConfigPath(accountID, tunnelID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting tunnel configuration: %w", err)
+	}
+
+	var result tunnelConfigResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return nil, fmt.Errorf("parsing tunnel configuration: %w", err)
+	}
+	if result.Config == nil {
+		return &TunnelConfig{}, nil
+	}
+
+	c.logger.Debug("got tunnel configuration",
+		slog.String("tunnel_id", tunnelID),
+		slog.Int("version", result.Version),
+		slog.Int("ingress_rules", len(result.Config.Ingress)),
+	)
+
+	return result.Config, nil
+}
+
+// UpdateTunnelConfig replaces the remotely-managed configuration of a tunnel.
+func (c *Client) UpdateTunnelConfig(ctx context.Context, accountID, tunnelID string, config *TunnelConfig) error {
+	bodyBytes, err := json.Marshal(map[string]*TunnelConfig{"config": config})
+	if err != nil {
+		return fmt.Errorf("marshaling request: %w", err)
+	}
+
+	_, err = c.doRequest(ctx, http.MethodPut, tunnelConfigPath(accountID, tunnelID), strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return fmt.Errorf("updating tunnel configuration: %w", err)
+	}
+
+	c.logger.Info("updated tunnel configuration",
+		slog.String("tunnel_id", tunnelID),
+		slog.Int("ingress_rules", len(config.Ingress)),
+	)
+
+	return nil
+}
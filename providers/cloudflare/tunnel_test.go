@@ -0,0 +1,61 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_TunnelConfig_RoundTrip(t *testing.T) {
+	var putBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/accounts/acc/cfd_tunnel/tun/configurations" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(successResponse(map[string]interface{}{
+				"tunnel_id": "tun",
+				"version":   3,
+				"config": map[string]interface{}{
+					"ingress": []map[string]interface{}{
+						{"hostname": "app.example.com", "service": "http://app:80", "originRequest": map[string]interface{}{"noTLSVerify": true}},
+						{"service": "http_status:404"},
+					},
+					"warp-routing": map[string]interface{}{"enabled": true},
+				},
+			}))
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			putBody = string(body)
+			_ = json.NewEncoder(w).Encode(successResponse(map[string]interface{}{}))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithAPIEndpoint(server.URL))
+	ctx := context.Background()
+
+	config, err := client.GetTunnelConfig(ctx, "acc", "tun")
+	if err != nil {
+		t.Fatalf("GetTunnelConfig() unexpected error: %v", err)
+	}
+	if len(config.Ingress) != 2 || config.Ingress[0].Service != "http://app:80" {
+		t.Fatalf("Ingress = %+v, want app rule and catch-all", config.Ingress)
+	}
+
+	config.Ingress[0].Service = "http://app:8080"
+	if err := client.UpdateTunnelConfig(ctx, "acc", "tun", config); err != nil {
+		t.Fatalf("UpdateTunnelConfig() unexpected error: %v", err)
+	}
+
+	for _, want := range []string{`"warp-routing":{"enabled":true}`, `"originRequest":{"noTLSVerify":true}`, `"service":"http://app:8080"`} {
+		if !strings.Contains(putBody, want) {
+			t.Errorf("PUT body %s missing %s", putBody, want)
+		}
+	}
+}
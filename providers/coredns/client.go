@@ -0,0 +1,238 @@
+package coredns
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+)
+
+// KeyValue is a key and value from etcd.
+type KeyValue struct {
+	Key   string
+	Value []byte
+}
+
+// Client is a minimal client for the etcd v3 JSON gateway (/v3/kv/range,
+// /v3/kv/put, and /v3/kv/deleterange). Endpoints are tried in order; the
+// last one that answered is used first for the next request.
+type Client struct {
+	endpoints  []string
+	httpClient *http.Client
+	logger     *slog.Logger
+
+	mu      sync.Mutex
+	current int
+}
+
+// ClientOption is a functional option for configuring the Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// NewClient creates a new etcd gateway client for the given endpoints.
+func NewClient(endpoints []string, opts ...ClientOption) *Client {
+	c := &Client{
+		endpoints:  endpoints,
+		httpClient: httputil.NewClient(nil),
+		logger:     slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// rangeRequest is the body of POST /v3/kv/range and /v3/kv/deleterange.
+type rangeRequest struct {
+	Key      string `json:"key"`
+	RangeEnd string `json:"range_end,omitempty"`
+}
+
+// rangeResponse is the subset of the /v3/kv/range response used here.
+// The gateway encodes bytes as base64.
+type rangeResponse struct {
+	KVs []struct {
+		Key   []byte `json:"key"`
+		Value []byte `json:"value"`
+	} `json:"kvs"`
+}
+
+// putRequest is the body of POST /v3/kv/put.
+type putRequest struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// deleteResponse is the subset of the /v3/kv/deleterange response used here.
+// The gateway encodes 64-bit integers as strings.
+type deleteResponse struct {
+	Deleted int64 `json:"deleted,string"`
+}
+
+// Status checks that an etcd endpoint is reachable.
+func (c *Client) Status(ctx context.Context) error {
+	resp, err := c.post(ctx, "/v3/maintenance/status", []byte("{}"))
+	if err != nil {
+		return fmt.Errorf("checking etcd status: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Range returns every key under prefix.
+func (c *Client) Range(ctx context.Context, prefix string) ([]KeyValue, error) {
+	body, err := json.Marshal(rangeRequest{
+		Key:      encodeKey(prefix),
+		RangeEnd: base64.StdEncoding.EncodeToString(prefixEnd(prefix)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding range request: %w", err)
+	}
+
+	resp, err := c.post(ctx, "/v3/kv/range", body)
+	if err != nil {
+		return nil, fmt.Errorf("listing keys: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result rangeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("parsing response JSON: %w", err)
+	}
+
+	kvs := make([]KeyValue, 0, len(result.KVs))
+	for _, kv := range result.KVs {
+		kvs = append(kvs, KeyValue{Key: string(kv.Key), Value: kv.Value})
+	}
+	return kvs, nil
+}
+
+// Put writes value to key.
+func (c *Client) Put(ctx context.Context, key string, value []byte) error {
+	body, err := json.Marshal(putRequest{
+		Key:   encodeKey(key),
+		Value: base64.StdEncoding.EncodeToString(value),
+	})
+	if err != nil {
+		return fmt.Errorf("encoding put request: %w", err)
+	}
+
+	resp, err := c.post(ctx, "/v3/kv/put", body)
+	if err != nil {
+		return fmt.Errorf("writing key %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Delete removes key and reports whether it existed.
+func (c *Client) Delete(ctx context.Context, key string) (bool, error) {
+	body, err := json.Marshal(rangeRequest{Key: encodeKey(key)})
+	if err != nil {
+		return false, fmt.Errorf("encoding delete request: %w", err)
+	}
+
+	resp, err := c.post(ctx, "/v3/kv/deleterange", body)
+	if err != nil {
+		return false, fmt.Errorf("deleting key %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	var result deleteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("parsing response JSON: %w", err)
+	}
+	return result.Deleted > 0, nil
+}
+
+// post sends a JSON request to the first endpoint that answers.
+// The caller must close the response body.
+func (c *Client) post(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	c.mu.Lock()
+	start := c.current
+	c.mu.Unlock()
+
+	var lastErr error
+	for i := range c.endpoints {
+		idx := (start + i) % len(c.endpoints)
+		endpoint := c.endpoints[idx]
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+path, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			c.logger.Debug("etcd endpoint unavailable",
+				slog.String("endpoint", endpoint),
+				slog.String("error", err.Error()),
+			)
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+			lastErr = fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, endpoint, strings.TrimSpace(string(msg)))
+			continue
+		}
+
+		c.mu.Lock()
+		c.current = idx
+		c.mu.Unlock()
+		return resp, nil
+	}
+
+	return nil, lastErr
+}
+
+// encodeKey base64-encodes a key for the JSON gateway.
+func encodeKey(key string) string {
+	return base64.StdEncoding.EncodeToString([]byte(key))
+}
+
+// prefixEnd returns the range end that selects every key starting with prefix.
+func prefixEnd(prefix string) []byte {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// Prefix is all 0xff bytes: range to the end of the keyspace
+	return []byte{0}
+}
@@ -0,0 +1,133 @@
+// Package coredns implements the DNSWeaver provider interface for CoreDNS
+// backed by the etcd plugin, writing records in SkyDNS format.
+package coredns
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultTTL is the default TTL for CoreDNS records.
+const DefaultTTL = 300
+
+// DefaultEndpoint is the default etcd client URL.
+const DefaultEndpoint = "http://127.0.0.1:2379"
+
+// DefaultPrefix is the default etcd key prefix used by the CoreDNS etcd plugin.
+const DefaultPrefix = "/skydns"
+
+// Config holds CoreDNS etcd-specific configuration.
+type Config struct {
+	Endpoints []string // etcd client URLs, tried in order
+	Prefix    string   // etcd key prefix (the etcd plugin's "path")
+	Zone      string   // DNS zone to manage (e.g., "example.com")
+	TTL       int      // Default record TTL
+}
+
+// Validate checks that all required configuration is present.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if len(c.Endpoints) == 0 {
+		errs = append(errs, "ETCD_ENDPOINTS is required")
+	}
+	for _, ep := range c.Endpoints {
+		if !strings.HasPrefix(ep, "http://") && !strings.HasPrefix(ep, "https://") {
+			errs = append(errs, fmt.Sprintf("endpoint %q must start with http:// or https://", ep))
+		}
+	}
+	if c.Zone == "" {
+		errs = append(errs, "ZONE is required")
+	}
+	if !strings.HasPrefix(c.Prefix, "/") {
+		errs = append(errs, "ETCD_PREFIX must start with /")
+	}
+	if c.TTL < 0 {
+		errs = append(errs, "TTL must be non-negative")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("coredns config validation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// LoadConfig loads CoreDNS etcd configuration from environment variables.
+// Environment variable pattern: DNSWEAVER_{INSTANCE_NAME}_{SETTING}
+//
+// Instance names are normalized: lowercase with hyphens becomes uppercase with underscores.
+// Example: "coredns" looks for DNSWEAVER_COREDNS_*
+//
+// Supported settings:
+//   - ZONE: DNS zone to manage (required)
+//   - ETCD_ENDPOINTS: Comma-separated etcd client URLs (optional, default: http://127.0.0.1:2379)
+//   - ETCD_PREFIX: Key prefix configured in the CoreDNS etcd plugin (optional, default: /skydns)
+//   - TTL: Default record TTL (optional, default: 300)
+func LoadConfig(instanceName string) (*Config, error) {
+	prefix := envPrefix(instanceName)
+
+	return LoadConfigFromMap(instanceName, map[string]string{
+		"ZONE":           getEnv(prefix + "ZONE"),
+		"ETCD_ENDPOINTS": getEnv(prefix + "ETCD_ENDPOINTS"),
+		"ETCD_PREFIX":    getEnv(prefix + "ETCD_PREFIX"),
+		"TTL":            getEnv(prefix + "TTL"),
+	})
+}
+
+// LoadConfigFromMap creates a Config from a map of key-value pairs.
+// This is used by the provider registry to create instances from
+// configuration that was already parsed from environment variables.
+//
+// Required keys: ZONE
+// Optional keys: ETCD_ENDPOINTS, ETCD_PREFIX, TTL
+func LoadConfigFromMap(instanceName string, configMap map[string]string) (*Config, error) {
+	config := &Config{
+		Zone:   strings.TrimSuffix(strings.ToLower(configMap["ZONE"]), "."),
+		Prefix: DefaultPrefix,
+		TTL:    DefaultTTL,
+	}
+
+	for _, ep := range strings.Split(configMap["ETCD_ENDPOINTS"], ",") {
+		if ep = strings.TrimSpace(ep); ep != "" {
+			config.Endpoints = append(config.Endpoints, strings.TrimSuffix(ep, "/"))
+		}
+	}
+	if len(config.Endpoints) == 0 {
+		config.Endpoints = []string{DefaultEndpoint}
+	}
+
+	if p := strings.TrimSpace(configMap["ETCD_PREFIX"]); p != "" {
+		config.Prefix = strings.TrimSuffix(p, "/")
+	}
+
+	// Parse optional TTL
+	if ttlStr, ok := configMap["TTL"]; ok && ttlStr != "" {
+		ttl, err := strconv.Atoi(ttlStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TTL value %q: %w", ttlStr, err)
+		}
+		config.TTL = ttl
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
+	}
+
+	return config, nil
+}
+
+// envPrefix converts an instance name to an environment variable prefix.
+// Example: "coredns" → "DNSWEAVER_COREDNS_"
+func envPrefix(instanceName string) string {
+	normalized := strings.ToUpper(instanceName)
+	normalized = strings.ReplaceAll(normalized, "-", "_")
+	return "DNSWEAVER_" + normalized + "_"
+}
+
+// getEnv retrieves an environment variable value.
+func getEnv(key string) string {
+	return os.Getenv(key)
+}
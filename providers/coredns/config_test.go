@@ -0,0 +1,104 @@
+package coredns
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigFromMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		configMap map[string]string
+		wantErr   string
+		check     func(t *testing.T, c *Config)
+	}{
+		{
+			name:      "defaults",
+			configMap: map[string]string{"ZONE": "Example.COM."},
+			check: func(t *testing.T, c *Config) {
+				if c.Zone != "example.com" {
+					t.Errorf("Zone = %q, want example.com", c.Zone)
+				}
+				if len(c.Endpoints) != 1 || c.Endpoints[0] != DefaultEndpoint {
+					t.Errorf("Endpoints = %v, want [%s]", c.Endpoints, DefaultEndpoint)
+				}
+				if c.Prefix != DefaultPrefix {
+					t.Errorf("Prefix = %q, want %q", c.Prefix, DefaultPrefix)
+				}
+				if c.TTL != DefaultTTL {
+					t.Errorf("TTL = %d, want %d", c.TTL, DefaultTTL)
+				}
+			},
+		},
+		{
+			name: "custom endpoints and prefix",
+			configMap: map[string]string{
+				"ZONE":           "example.com",
+				"ETCD_ENDPOINTS": "http://etcd1:2379/, https://etcd2:2379",
+				"ETCD_PREFIX":    "/coredns/",
+				"TTL":            "60",
+			},
+			check: func(t *testing.T, c *Config) {
+				if got := strings.Join(c.Endpoints, ","); got != "http://etcd1:2379,https://etcd2:2379" {
+					t.Errorf("Endpoints = %s, want trimmed URLs", got)
+				}
+				if c.Prefix != "/coredns" {
+					t.Errorf("Prefix = %q, want /coredns", c.Prefix)
+				}
+				if c.TTL != 60 {
+					t.Errorf("TTL = %d, want 60", c.TTL)
+				}
+			},
+		},
+		{
+			name:      "missing zone",
+			configMap: map[string]string{},
+			wantErr:   "ZONE is required",
+		},
+		{
+			name:      "invalid endpoint",
+			configMap: map[string]string{"ZONE": "example.com", "ETCD_ENDPOINTS": "etcd:2379"},
+			wantErr:   "must start with http:// or https://",
+		},
+		{
+			name:      "relative prefix",
+			configMap: map[string]string{"ZONE": "example.com", "ETCD_PREFIX": "skydns"},
+			wantErr:   "ETCD_PREFIX must start with /",
+		},
+		{
+			name:      "invalid TTL",
+			configMap: map[string]string{"ZONE": "example.com", "TTL": "soon"},
+			wantErr:   "invalid TTL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := LoadConfigFromMap("coredns", tt.configMap)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfigFromMap() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfigFromMap() unexpected error: %v", err)
+			}
+			tt.check(t, c)
+		})
+	}
+}
+
+func TestLoadConfig_Env(t *testing.T) {
+	t.Setenv("DNSWEAVER_COREDNS_ZONE", "internal.example.com")
+	t.Setenv("DNSWEAVER_COREDNS_ETCD_ENDPOINTS", "http://etcd:2379")
+	t.Setenv("DNSWEAVER_COREDNS_ETCD_PREFIX", "/dns")
+
+	c, err := LoadConfig("coredns")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	if c.Zone != "internal.example.com" || c.Prefix != "/dns" || c.Endpoints[0] != "http://etcd:2379" {
+		t.Errorf("config = %+v, want internal.example.com, /dns, http://etcd:2379", c)
+	}
+}
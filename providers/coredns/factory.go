@@ -0,0 +1,13 @@
+package coredns
+
+import (
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Factory returns a provider.Factory for creating CoreDNS etcd provider instances.
+// This is the recommended way to register the coredns provider with the registry.
+func Factory() provider.Factory {
+	return func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		return NewFromMap(cfg.Name, cfg.ProviderConfig, WithProviderLogger(cfg.HTTP.Logger))
+	}
+}
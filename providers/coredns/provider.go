@@ -0,0 +1,246 @@
+package coredns
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Provider implements provider.Provider for CoreDNS with the etcd plugin.
+type Provider struct {
+	name   string
+	zone   string
+	prefix string
+	ttl    int
+	client *Client
+	logger *slog.Logger
+}
+
+// ProviderOption is a functional option for configuring the Provider.
+type ProviderOption func(*Provider)
+
+// WithProviderLogger sets a custom logger for the provider.
+func WithProviderLogger(logger *slog.Logger) ProviderOption {
+	return func(p *Provider) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// WithClient sets a custom client (for testing).
+func WithClient(client *Client) ProviderOption {
+	return func(p *Provider) {
+		p.client = client
+	}
+}
+
+// New creates a new CoreDNS etcd provider instance.
+func New(name string, config *Config, opts ...ProviderOption) (*Provider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		name:   name,
+		zone:   config.Zone,
+		prefix: config.Prefix,
+		ttl:    config.TTL,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// Create client if not provided via options (testing)
+	if p.client == nil {
+		p.client = NewClient(config.Endpoints, WithLogger(p.logger))
+	}
+
+	return p, nil
+}
+
+// NewFromEnv creates a new CoreDNS etcd provider from environment variables.
+// This is a convenience function for use with the provider registry.
+func NewFromEnv(instanceName string, opts ...ProviderOption) (*Provider, error) {
+	config, err := LoadConfig(instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(instanceName, config, opts...)
+}
+
+// NewFromMap creates a new CoreDNS etcd provider from a configuration map.
+// This is used by the provider registry Factory pattern.
+func NewFromMap(name string, config map[string]string, opts ...ProviderOption) (*Provider, error) {
+	cfg, err := LoadConfigFromMap(name, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(name, cfg, opts...)
+}
+
+// Name returns the provider instance name.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Type returns "coredns".
+func (p *Provider) Type() string {
+	return "coredns"
+}
+
+// Capabilities returns the provider's feature support.
+// Each record is its own etcd key, so a name can hold several targets and
+// TXT values; there is no in-place update.
+func (p *Provider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SupportsOwnershipTXT:    true,
+		SupportsNativeUpdate:    false,
+		SupportsMultipleTargets: true,
+		SupportedRecordTypes: []provider.RecordType{
+			provider.RecordTypeA,
+			provider.RecordTypeAAAA,
+			provider.RecordTypeCNAME,
+			provider.RecordTypeTXT,
+		},
+	}
+}
+
+// Zone returns the configured DNS zone.
+func (p *Provider) Zone() string {
+	return p.zone
+}
+
+// Ping checks that an etcd endpoint is reachable.
+func (p *Provider) Ping(ctx context.Context) error {
+	return p.client.Status(ctx)
+}
+
+// List returns all records stored under the zone's etcd prefix.
+func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
+	zoneKey := nameKey(p.prefix, p.zone)
+	kvs, err := p.client.Range(ctx, zoneKey)
+	if err != nil {
+		return nil, fmt.Errorf("listing records: %w", err)
+	}
+
+	records := make([]provider.Record, 0, len(kvs))
+	for _, kv := range kvs {
+		// The range also matches sibling keys such as /skydns/com/examples
+		if kv.Key != zoneKey && !strings.HasPrefix(kv.Key, zoneKey+"/") {
+			continue
+		}
+
+		recordType, target, ttl, err := decodeMessage(kv.Value)
+		if err != nil {
+			p.logger.Warn("skipping unparseable record",
+				slog.String("provider", p.name),
+				slog.String("key", kv.Key),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		records = append(records, provider.Record{
+			Hostname:   hostnameFromKey(p.prefix, kv.Key),
+			Type:       recordType,
+			Target:     target,
+			TTL:        ttl,
+			ProviderID: kv.Key,
+		})
+	}
+
+	p.logger.Debug("listed records",
+		slog.String("provider", p.name),
+		slog.Int("count", len(records)),
+	)
+
+	return records, nil
+}
+
+// Create writes a record to etcd. Creating an existing record overwrites it.
+func (p *Provider) Create(ctx context.Context, record provider.Record) error {
+	if err := p.checkZone(record.Hostname); err != nil {
+		return err
+	}
+
+	ttl := record.TTL
+	if ttl <= 0 {
+		ttl = p.ttl
+	}
+
+	value, err := encodeMessage(record, ttl)
+	if err != nil {
+		return err
+	}
+
+	if err := p.client.Put(ctx, recordKey(p.prefix, record), value); err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("created record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+		slog.Int("ttl", ttl),
+	)
+
+	return nil
+}
+
+// Delete removes a record from etcd. Records returned by List are deleted by
+// their key, so entries not written by dnsweaver can be removed too.
+func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
+	if err := p.checkZone(record.Hostname); err != nil {
+		return err
+	}
+
+	key := record.ProviderID
+	if !strings.HasPrefix(key, p.prefix+"/") {
+		key = recordKey(p.prefix, record)
+	}
+
+	existed, err := p.client.Delete(ctx, key)
+	if err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+	if !existed {
+		p.logger.Debug("record already absent",
+			slog.String("provider", p.name),
+			slog.String("key", key),
+		)
+		return nil
+	}
+
+	p.logger.Info("deleted record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+	)
+
+	return nil
+}
+
+// checkZone returns an error when hostname is not in the configured zone.
+func (p *Provider) checkZone(hostname string) error {
+	host := strings.TrimSuffix(strings.ToLower(hostname), ".")
+	if host == p.zone || strings.HasSuffix(host, "."+p.zone) {
+		return nil
+	}
+	return fmt.Errorf("hostname %s is not in zone %s", hostname, p.zone)
+}
+
+// Ensure Provider implements provider.Provider at compile time.
+var _ provider.Provider = (*Provider)(nil)
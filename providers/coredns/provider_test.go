@@ -0,0 +1,178 @@
+package coredns
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// fakeEtcd is an in-memory etcd v3 JSON gateway.
+type fakeEtcd struct {
+	mu   sync.Mutex
+	keys map[string]string
+}
+
+func (f *fakeEtcd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req struct {
+		Key      []byte `json:"key"`
+		RangeEnd []byte `json:"range_end"`
+		Value    []byte `json:"value"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	key := string(req.Key)
+
+	switch r.URL.Path {
+	case "/v3/maintenance/status":
+		_, _ = w.Write([]byte(`{"version":"3.5.0"}`))
+	case "/v3/kv/put":
+		f.keys[key] = string(req.Value)
+		_, _ = w.Write([]byte(`{}`))
+	case "/v3/kv/deleterange":
+		// The gateway omits "deleted" when nothing was removed
+		if _, ok := f.keys[key]; !ok {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		delete(f.keys, key)
+		_, _ = w.Write([]byte(`{"deleted":"1"}`))
+	case "/v3/kv/range":
+		var names []string
+		for k := range f.keys {
+			if k >= key && k < string(req.RangeEnd) {
+				names = append(names, k)
+			}
+		}
+		sort.Strings(names)
+		kvs := make([]map[string]string, 0, len(names))
+		for _, k := range names {
+			kvs = append(kvs, map[string]string{
+				"key":   base64.StdEncoding.EncodeToString([]byte(k)),
+				"value": base64.StdEncoding.EncodeToString([]byte(f.keys[k])),
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"kvs": kvs})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestProvider(t *testing.T, etcd *fakeEtcd) *Provider {
+	t.Helper()
+	server := httptest.NewServer(etcd)
+	t.Cleanup(server.Close)
+
+	p, err := New("coredns", &Config{
+		Endpoints: []string{server.URL},
+		Prefix:    DefaultPrefix,
+		Zone:      "example.com",
+		TTL:       DefaultTTL,
+	})
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("coredns", nil); err == nil {
+		t.Error("New() with nil config expected error")
+	}
+	if _, err := New("coredns", &Config{Endpoints: []string{DefaultEndpoint}, Prefix: DefaultPrefix}); err == nil {
+		t.Error("New() with invalid config expected error")
+	}
+}
+
+func TestProvider_CreateListDelete(t *testing.T) {
+	etcd := &fakeEtcd{keys: map[string]string{
+		"/skydns/com/example/legacy":   `{"host":"10.0.0.5","ttl":60}`,
+		"/skydns/com/examples/sibling": `{"host":"10.0.0.6"}`,
+	}}
+	p := newTestProvider(t, etcd)
+	ctx := context.Background()
+
+	if err := p.Ping(ctx); err != nil {
+		t.Fatalf("Ping() unexpected error: %v", err)
+	}
+
+	for _, rec := range []provider.Record{
+		{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"},
+		{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.2"},
+		{Hostname: "_dnsweaver.app.example.com", Type: provider.RecordTypeTXT, Target: "heritage=dnsweaver"},
+	} {
+		if err := p.Create(ctx, rec); err != nil {
+			t.Fatalf("Create(%s %s) unexpected error: %v", rec.Type, rec.Target, err)
+		}
+	}
+
+	key := recordKey(DefaultPrefix, provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"})
+	if got := etcd.keys[key]; got != `{"host":"10.0.0.1","ttl":300}` {
+		t.Errorf("etcd value = %s, want SkyDNS JSON", got)
+	}
+
+	records, err := p.List(ctx)
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("List() returned %d records, want 4 (sibling zone skipped): %+v", len(records), records)
+	}
+
+	byTarget := make(map[string]provider.Record)
+	for _, r := range records {
+		byTarget[r.Target] = r
+	}
+	if r := byTarget["10.0.0.5"]; r.Hostname != "legacy.example.com" || r.TTL != 60 {
+		t.Errorf("legacy record = %+v, want legacy.example.com TTL 60", r)
+	}
+	if r := byTarget["heritage=dnsweaver"]; r.Hostname != "_dnsweaver.app.example.com" || r.Type != provider.RecordTypeTXT {
+		t.Errorf("TXT record = %+v, want _dnsweaver.app.example.com TXT", r)
+	}
+
+	// Records from List are deleted by key, including foreign entries
+	if err := p.Delete(ctx, byTarget["10.0.0.5"]); err != nil {
+		t.Fatalf("Delete(legacy) unexpected error: %v", err)
+	}
+	if err := p.Delete(ctx, provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"}); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if err := p.Delete(ctx, provider.Record{Hostname: "gone.example.com", Type: provider.RecordTypeA, Target: "10.0.0.9"}); err != nil {
+		t.Errorf("Delete() of missing record should succeed, got %v", err)
+	}
+	if _, ok := etcd.keys["/skydns/com/example/legacy"]; ok {
+		t.Error("legacy key still present after Delete()")
+	}
+	if _, ok := etcd.keys[key]; ok {
+		t.Error("record key still present after Delete()")
+	}
+}
+
+func TestProvider_Create_OutsideZone(t *testing.T) {
+	etcd := &fakeEtcd{keys: map[string]string{}}
+	p := newTestProvider(t, etcd)
+
+	err := p.Create(context.Background(), provider.Record{
+		Hostname: "app.other.com",
+		Type:     provider.RecordTypeA,
+		Target:   "10.0.0.1",
+	})
+	if err == nil || !strings.Contains(err.Error(), "not in zone") {
+		t.Errorf("Create() error = %v, want 'not in zone'", err)
+	}
+	if len(etcd.keys) != 0 {
+		t.Errorf("expected no keys written, got %d", len(etcd.keys))
+	}
+}
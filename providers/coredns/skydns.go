@@ -0,0 +1,117 @@
+package coredns
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// recordIDPrefix marks the leaf key segment dnsweaver adds under a name so
+// several records (round-robin targets, TXT values) can share one hostname.
+const recordIDPrefix = "dnsweaver-"
+
+// skydnsMessage is the JSON value the CoreDNS etcd plugin reads for a record.
+// It is the subset of CoreDNS's msg.Service used for A, AAAA, CNAME, and TXT.
+type skydnsMessage struct {
+	Host string `json:"host,omitempty"`
+	Text string `json:"text,omitempty"`
+	TTL  uint32 `json:"ttl,omitempty"`
+}
+
+// nameKey returns the etcd key for hostname: the labels reversed under prefix.
+// Example: "app.example.com" with prefix "/skydns" → "/skydns/com/example/app"
+func nameKey(prefix, hostname string) string {
+	labels := strings.Split(strings.TrimSuffix(strings.ToLower(hostname), "."), ".")
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+	return prefix + "/" + strings.Join(labels, "/")
+}
+
+// recordKey returns the etcd key dnsweaver writes for record.
+// The leaf segment is derived from the record type and target, so creating
+// the same record twice overwrites a single key.
+func recordKey(prefix string, record provider.Record) string {
+	h := fnv.New32a()
+	h.Write([]byte(string(record.Type) + "|" + strings.ToLower(record.Target)))
+	return fmt.Sprintf("%s/%s%08x", nameKey(prefix, record.Hostname), recordIDPrefix, h.Sum32())
+}
+
+// hostnameFromKey converts an etcd key under prefix back to a hostname,
+// dropping the leaf segment added by recordKey.
+func hostnameFromKey(prefix, key string) string {
+	segments := strings.Split(strings.TrimPrefix(key, prefix+"/"), "/")
+	if n := len(segments); n > 1 && strings.HasPrefix(segments[n-1], recordIDPrefix) {
+		segments = segments[:n-1]
+	}
+
+	labels := make([]string, 0, len(segments))
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i] != "" {
+			labels = append(labels, segments[i])
+		}
+	}
+	return strings.Join(labels, ".")
+}
+
+// encodeMessage returns the SkyDNS JSON value for record.
+func encodeMessage(record provider.Record, ttl int) ([]byte, error) {
+	msg := skydnsMessage{TTL: uint32(ttl)}
+
+	switch record.Type {
+	case provider.RecordTypeA:
+		ip := net.ParseIP(record.Target)
+		if ip == nil || ip.To4() == nil {
+			return nil, fmt.Errorf("invalid IPv4 address %q for A record", record.Target)
+		}
+		msg.Host = ip.String()
+	case provider.RecordTypeAAAA:
+		ip := net.ParseIP(record.Target)
+		if ip == nil || ip.To4() != nil {
+			return nil, fmt.Errorf("invalid IPv6 address %q for AAAA record", record.Target)
+		}
+		msg.Host = ip.String()
+	case provider.RecordTypeCNAME:
+		target := strings.TrimSuffix(strings.ToLower(record.Target), ".")
+		if net.ParseIP(target) != nil {
+			return nil, fmt.Errorf("CNAME target %q must be a hostname", record.Target)
+		}
+		msg.Host = target
+	case provider.RecordTypeTXT:
+		msg.Text = record.Target
+	default:
+		return nil, fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+
+	return json.Marshal(msg)
+}
+
+// decodeMessage parses a SkyDNS JSON value into record type, target, and TTL.
+// The type is inferred the way CoreDNS does: an IP host is A or AAAA, any
+// other host is a CNAME, and a text-only entry is TXT.
+func decodeMessage(value []byte) (provider.RecordType, string, int, error) {
+	var msg skydnsMessage
+	if err := json.Unmarshal(value, &msg); err != nil {
+		return "", "", 0, fmt.Errorf("parsing SkyDNS JSON: %w", err)
+	}
+
+	ttl := int(msg.TTL)
+	switch {
+	case msg.Host == "" && msg.Text != "":
+		return provider.RecordTypeTXT, msg.Text, ttl, nil
+	case msg.Host == "":
+		return "", "", 0, fmt.Errorf("entry has neither host nor text")
+	}
+
+	if ip := net.ParseIP(msg.Host); ip != nil {
+		if ip.To4() != nil {
+			return provider.RecordTypeA, ip.String(), ttl, nil
+		}
+		return provider.RecordTypeAAAA, ip.String(), ttl, nil
+	}
+	return provider.RecordTypeCNAME, strings.TrimSuffix(msg.Host, "."), ttl, nil
+}
@@ -0,0 +1,88 @@
+package coredns
+
+import (
+	"strings"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+func TestNameKey(t *testing.T) {
+	if got := nameKey("/skydns", "App.Example.com."); got != "/skydns/com/example/app" {
+		t.Errorf("nameKey() = %q, want /skydns/com/example/app", got)
+	}
+}
+
+func TestRecordKey_RoundTrip(t *testing.T) {
+	record := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"}
+	key := recordKey("/skydns", record)
+
+	if !strings.HasPrefix(key, "/skydns/com/example/app/"+recordIDPrefix) {
+		t.Errorf("recordKey() = %q, want leaf under /skydns/com/example/app", key)
+	}
+	if other := recordKey("/skydns", provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.2"}); other == key {
+		t.Error("recordKey() should differ for different targets")
+	}
+	if got := hostnameFromKey("/skydns", key); got != "app.example.com" {
+		t.Errorf("hostnameFromKey() = %q, want app.example.com", got)
+	}
+	if got := hostnameFromKey("/skydns", "/skydns/com/example/x1"); got != "x1.example.com" {
+		t.Errorf("hostnameFromKey() for foreign key = %q, want x1.example.com", got)
+	}
+}
+
+func TestEncodeMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  provider.Record
+		want    string
+		wantErr bool
+	}{
+		{"A", provider.Record{Type: provider.RecordTypeA, Target: "10.0.0.1"}, `{"host":"10.0.0.1","ttl":300}`, false},
+		{"AAAA", provider.Record{Type: provider.RecordTypeAAAA, Target: "2001:DB8::1"}, `{"host":"2001:db8::1","ttl":300}`, false},
+		{"A with IPv6", provider.Record{Type: provider.RecordTypeA, Target: "2001:db8::1"}, "", true},
+		{"CNAME", provider.Record{Type: provider.RecordTypeCNAME, Target: "Proxy.example.com."}, `{"host":"proxy.example.com","ttl":300}`, false},
+		{"TXT", provider.Record{Type: provider.RecordTypeTXT, Target: "heritage=dnsweaver"}, `{"text":"heritage=dnsweaver","ttl":300}`, false},
+		{"unsupported", provider.Record{Type: provider.RecordTypeSRV, Target: "web.example.com"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeMessage(tt.record, 300)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("encodeMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("encodeMessage() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeMessage(t *testing.T) {
+	tests := []struct {
+		value      string
+		wantType   provider.RecordType
+		wantTarget string
+	}{
+		{`{"host":"10.0.0.1","ttl":60}`, provider.RecordTypeA, "10.0.0.1"},
+		{`{"host":"2001:db8::1"}`, provider.RecordTypeAAAA, "2001:db8::1"},
+		{`{"host":"proxy.example.com."}`, provider.RecordTypeCNAME, "proxy.example.com"},
+		{`{"text":"hello"}`, provider.RecordTypeTXT, "hello"},
+	}
+
+	for _, tt := range tests {
+		gotType, gotTarget, _, err := decodeMessage([]byte(tt.value))
+		if err != nil {
+			t.Errorf("decodeMessage(%s) unexpected error: %v", tt.value, err)
+			continue
+		}
+		if gotType != tt.wantType || gotTarget != tt.wantTarget {
+			t.Errorf("decodeMessage(%s) = %s %s, want %s %s", tt.value, gotType, gotTarget, tt.wantType, tt.wantTarget)
+		}
+	}
+
+	if _, _, _, err := decodeMessage([]byte(`{"ttl":60}`)); err == nil {
+		t.Error("decodeMessage() with empty entry expected error")
+	}
+}
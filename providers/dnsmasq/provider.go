@@ -121,6 +121,8 @@ func (p *Provider) Capabilities() provider.Capabilities {
 	return provider.Capabilities{
 		SupportsOwnershipTXT: false, // File-based, can't store ownership TXT
 		SupportsNativeUpdate: false, // Requires file rewrite (delete+create)
+		// One address per hostname line; round-robin targets are not supported
+		SupportsMultipleTargets: false,
 		SupportedRecordTypes: []provider.RecordType{
 			provider.RecordTypeA,
 			provider.RecordTypeCNAME,
@@ -0,0 +1,71 @@
+// Package failover implements a meta-provider that forwards DNS operations to a
+// primary provider instance and fails over to a secondary one when the primary
+// stops answering health checks.
+package failover
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultHealthCheckInterval is how often the primary is pinged.
+const DefaultHealthCheckInterval = 30 * time.Second
+
+// Config holds failover-specific configuration.
+type Config struct {
+	Primary             string        // Instance name of the preferred provider
+	Secondary           string        // Instance name used while the primary is down
+	HealthCheckInterval time.Duration // Minimum time between primary health checks
+}
+
+// Validate checks that all required configuration is present.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.Primary == "" {
+		errs = append(errs, "PRIMARY is required")
+	}
+	if c.Secondary == "" {
+		errs = append(errs, "SECONDARY is required")
+	}
+	if c.Primary != "" && strings.EqualFold(c.Primary, c.Secondary) {
+		errs = append(errs, "PRIMARY and SECONDARY must be different instances")
+	}
+	if c.HealthCheckInterval <= 0 {
+		errs = append(errs, "HEALTH_CHECK_INTERVAL must be positive")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failover config validation failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// LoadConfigFromMap creates a Config from a map of key-value pairs.
+//
+// Supported keys:
+//   - PRIMARY: Instance name of the preferred provider (required)
+//   - SECONDARY: Instance name used while the primary is unhealthy (required)
+//   - HEALTH_CHECK_INTERVAL: Time between primary health checks (optional, default: 30s)
+func LoadConfigFromMap(config map[string]string) (*Config, error) {
+	cfg := &Config{
+		Primary:             strings.TrimSpace(config["PRIMARY"]),
+		Secondary:           strings.TrimSpace(config["SECONDARY"]),
+		HealthCheckInterval: DefaultHealthCheckInterval,
+	}
+
+	if v := config["HEALTH_CHECK_INTERVAL"]; v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HEALTH_CHECK_INTERVAL %q: %w", v, err)
+		}
+		cfg.HealthCheckInterval = interval
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
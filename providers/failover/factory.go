@@ -0,0 +1,30 @@
+package failover
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Factory returns a provider.Factory for creating failover provider instances.
+//
+// The PRIMARY and SECONDARY instances are resolved through the registry on
+// each health check, so they must be declared as their own instances but may
+// become available after the failover instance is created.
+func Factory() provider.Factory {
+	return func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		config, err := LoadConfigFromMap(cfg.ProviderConfig)
+		if err != nil {
+			return nil, fmt.Errorf("configuration for %s: %w", cfg.Name, err)
+		}
+		if strings.EqualFold(config.Primary, cfg.Name) || strings.EqualFold(config.Secondary, cfg.Name) {
+			return nil, fmt.Errorf("configuration for %s: failover instance cannot reference itself", cfg.Name)
+		}
+		if cfg.Lookup == nil {
+			return nil, fmt.Errorf("configuration for %s: failover provider requires an instance lookup", cfg.Name)
+		}
+
+		return New(cfg.Name, config, cfg.Lookup, WithLogger(cfg.HTTP.Logger))
+	}
+}
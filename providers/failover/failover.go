@@ -0,0 +1,331 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Lookup resolves a registered provider instance by name.
+type Lookup func(name string) (provider.Provider, bool)
+
+// Provider implements provider.Provider by forwarding every operation to the
+// primary instance while it is healthy and to the secondary while it is not.
+//
+// Health is checked lazily: an operation triggers a primary Ping when the
+// last check is older than the configured interval. When the primary comes
+// back, deletes made on the secondary are replayed and records missing from
+// the primary are copied over before it takes writes again.
+type Provider struct {
+	name      string
+	primary   string
+	secondary string
+	interval  time.Duration
+	lookup    Lookup
+	logger    *slog.Logger
+	now       func() time.Time
+
+	mu          sync.Mutex
+	onSecondary bool
+	lastCheck   time.Time
+	deleted     []provider.Record // deletes applied to the secondary while failed over
+}
+
+// ProviderOption is a functional option for configuring the Provider.
+type ProviderOption func(*Provider)
+
+// WithLogger sets a custom logger for the provider.
+func WithLogger(logger *slog.Logger) ProviderOption {
+	return func(p *Provider) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// New creates a new failover provider. The lookup resolves the primary and
+// secondary instance names at health-check time.
+func New(name string, config *Config, lookup Lookup, opts ...ProviderOption) (*Provider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if lookup == nil {
+		return nil, fmt.Errorf("lookup is required")
+	}
+
+	p := &Provider{
+		name:      name,
+		primary:   config.Primary,
+		secondary: config.Secondary,
+		interval:  config.HealthCheckInterval,
+		lookup:    lookup,
+		logger:    slog.Default(),
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.logger = p.logger.With(slog.String("provider", name))
+	return p, nil
+}
+
+// Name returns the provider instance name.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Type returns the provider type.
+func (p *Provider) Type() string {
+	return "failover"
+}
+
+// Delegates returns the primary and secondary instance names. The registry
+// uses this to keep them out of direct hostname matching.
+func (p *Provider) Delegates() []string {
+	return []string{p.primary, p.secondary}
+}
+
+// Active returns the name of the instance currently receiving operations.
+func (p *Provider) Active() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.onSecondary {
+		return p.secondary
+	}
+	return p.primary
+}
+
+// Ping checks connectivity to the active provider.
+func (p *Provider) Ping(ctx context.Context) error {
+	target, err := p.active(ctx)
+	if err != nil {
+		return err
+	}
+	return target.Ping(ctx)
+}
+
+// Capabilities returns the features supported by both backing providers,
+// so reconciler behavior does not change when a failover happens.
+func (p *Provider) Capabilities() provider.Capabilities {
+	var caps []provider.Capabilities
+	for _, name := range p.Delegates() {
+		if prov, ok := p.lookup(name); ok {
+			caps = append(caps, prov.Capabilities())
+		}
+	}
+	if len(caps) == 0 {
+		return provider.Capabilities{}
+	}
+
+	result := caps[0]
+	for _, c := range caps[1:] {
+		result.SupportsOwnershipTXT = result.SupportsOwnershipTXT && c.SupportsOwnershipTXT
+		result.SupportsNativeUpdate = result.SupportsNativeUpdate && c.SupportsNativeUpdate
+		result.SupportsMultipleTargets = result.SupportsMultipleTargets && c.SupportsMultipleTargets
+
+		var types []provider.RecordType
+		for _, rt := range result.SupportedRecordTypes {
+			if c.SupportsRecordType(rt) {
+				types = append(types, rt)
+			}
+		}
+		result.SupportedRecordTypes = types
+	}
+	return result
+}
+
+// List returns records from the active provider.
+func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
+	target, err := p.active(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return target.List(ctx)
+}
+
+// Create adds a record on the active provider.
+func (p *Provider) Create(ctx context.Context, record provider.Record) error {
+	target, err := p.active(ctx)
+	if err != nil {
+		return err
+	}
+	return target.Create(ctx, record)
+}
+
+// Delete removes a record from the active provider. Deletes made while failed
+// over are remembered and replayed on the primary when it recovers.
+func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
+	target, err := p.active(ctx)
+	if err != nil {
+		return err
+	}
+	if err := target.Delete(ctx, record); err != nil {
+		return err
+	}
+	p.journalDelete(target, record)
+	return nil
+}
+
+// Update modifies a record on the active provider, using its native update
+// when available and delete+create otherwise.
+func (p *Provider) Update(ctx context.Context, existing, desired provider.Record) error {
+	target, err := p.active(ctx)
+	if err != nil {
+		return err
+	}
+
+	if updater, ok := target.(provider.Updater); ok {
+		err = updater.Update(ctx, existing, desired)
+	} else {
+		err = target.Delete(ctx, existing)
+		if err == nil || errors.Is(err, provider.ErrNotFound) {
+			err = target.Create(ctx, desired)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	p.journalDelete(target, existing)
+	return nil
+}
+
+// journalDelete records a delete applied to the secondary while failed over.
+func (p *Provider) journalDelete(target provider.Provider, record provider.Record) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.onSecondary || target.Name() != p.secondary {
+		return
+	}
+	record.ProviderID = ""
+	p.deleted = append(p.deleted, record)
+}
+
+// active returns the provider that should receive the next operation,
+// running a primary health check first when one is due.
+func (p *Provider) active(ctx context.Context) (provider.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.lastCheck.IsZero() || now.Sub(p.lastCheck) >= p.interval {
+		p.lastCheck = now
+		p.checkPrimary(ctx)
+	}
+
+	name := p.primary
+	if p.onSecondary {
+		name = p.secondary
+	}
+	target, ok := p.lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: failover target %q is not registered", provider.ErrProviderUnavailable, name)
+	}
+	return target, nil
+}
+
+// checkPrimary pings the primary and switches between primary and secondary.
+// Caller must hold p.mu.
+func (p *Provider) checkPrimary(ctx context.Context) {
+	var pingErr error
+	primary, ok := p.lookup(p.primary)
+	if !ok {
+		pingErr = fmt.Errorf("instance %q is not registered", p.primary)
+	} else {
+		pingErr = primary.Ping(ctx)
+	}
+
+	switch {
+	case pingErr != nil && !p.onSecondary:
+		p.onSecondary = true
+		p.logger.Warn("primary provider unhealthy, failing over to secondary",
+			slog.String("primary", p.primary),
+			slog.String("secondary", p.secondary),
+			slog.String("error", pingErr.Error()),
+		)
+
+	case pingErr == nil && p.onSecondary:
+		secondary, ok := p.lookup(p.secondary)
+		if ok {
+			if err := p.resync(ctx, primary, secondary); err != nil {
+				p.logger.Warn("primary provider recovered but resync failed, staying on secondary",
+					slog.String("primary", p.primary),
+					slog.String("error", err.Error()),
+				)
+				return
+			}
+		}
+		p.onSecondary = false
+		p.deleted = nil
+		p.logger.Info("primary provider recovered, resuming writes to primary",
+			slog.String("primary", p.primary),
+		)
+	}
+}
+
+// resync brings the primary up to date with changes made on the secondary:
+// deletes made during the outage are replayed, then records present on the
+// secondary but missing from the primary are created. Caller must hold p.mu.
+func (p *Provider) resync(ctx context.Context, primary, secondary provider.Provider) error {
+	for _, record := range p.deleted {
+		if err := primary.Delete(ctx, record); err != nil && !errors.Is(err, provider.ErrNotFound) {
+			return fmt.Errorf("replaying delete of %s %s: %w", record.Type, record.Hostname, err)
+		}
+	}
+
+	want, err := secondary.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing secondary records: %w", err)
+	}
+	have, err := primary.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing primary records: %w", err)
+	}
+
+	present := make(map[string]struct{}, len(have))
+	for _, record := range have {
+		present[recordKey(record)] = struct{}{}
+	}
+
+	created := 0
+	for _, record := range want {
+		if _, ok := present[recordKey(record)]; ok {
+			continue
+		}
+		record.ProviderID = ""
+		if err := primary.Create(ctx, record); err != nil && !errors.Is(err, provider.ErrConflict) {
+			return fmt.Errorf("copying %s %s to primary: %w", record.Type, record.Hostname, err)
+		}
+		created++
+	}
+
+	p.logger.Info("resynced primary provider",
+		slog.String("primary", p.primary),
+		slog.Int("deletes_replayed", len(p.deleted)),
+		slog.Int("records_created", created),
+	)
+	return nil
+}
+
+// recordKey identifies a record by hostname, type, and target.
+func recordKey(r provider.Record) string {
+	return strings.ToLower(strings.TrimSuffix(r.Hostname, ".")) + "|" + string(r.Type) + "|" + r.Target
+}
+
+// Ensure Provider implements the provider interfaces.
+var (
+	_ provider.Provider  = (*Provider)(nil)
+	_ provider.Updater   = (*Provider)(nil)
+	_ provider.Delegator = (*Provider)(nil)
+)
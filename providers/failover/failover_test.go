@@ -0,0 +1,237 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// fakeProvider is an in-memory provider.Provider for testing.
+type fakeProvider struct {
+	name    string
+	pingErr error
+	records []provider.Record
+}
+
+func (f *fakeProvider) Name() string                   { return f.name }
+func (f *fakeProvider) Type() string                   { return "fake" }
+func (f *fakeProvider) Ping(ctx context.Context) error { return f.pingErr }
+func (f *fakeProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SupportsOwnershipTXT: true,
+		SupportedRecordTypes: []provider.RecordType{provider.RecordTypeA, provider.RecordTypeTXT},
+	}
+}
+
+func (f *fakeProvider) List(ctx context.Context) ([]provider.Record, error) {
+	return append([]provider.Record(nil), f.records...), nil
+}
+
+func (f *fakeProvider) Create(ctx context.Context, r provider.Record) error {
+	f.records = append(f.records, r)
+	return nil
+}
+
+func (f *fakeProvider) Delete(ctx context.Context, r provider.Record) error {
+	for i, existing := range f.records {
+		if recordKey(existing) == recordKey(r) {
+			f.records = append(f.records[:i], f.records[i+1:]...)
+			return nil
+		}
+	}
+	return provider.ErrNotFound
+}
+
+func (f *fakeProvider) has(hostname, target string) bool {
+	for _, r := range f.records {
+		if r.Hostname == hostname && r.Target == target {
+			return true
+		}
+	}
+	return false
+}
+
+// newTestFailover returns a failover provider that checks health on every call.
+func newTestFailover(t *testing.T, primary, secondary *fakeProvider) *Provider {
+	t.Helper()
+	instances := map[string]provider.Provider{}
+	if primary != nil {
+		instances["primary"] = primary
+	}
+	if secondary != nil {
+		instances["secondary"] = secondary
+	}
+	lookup := func(name string) (provider.Provider, bool) {
+		p, ok := instances[name]
+		return p, ok
+	}
+
+	p, err := New("ha", &Config{Primary: "primary", Secondary: "secondary", HealthCheckInterval: time.Nanosecond}, lookup)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return p
+}
+
+func TestProvider_UsesPrimaryWhenHealthy(t *testing.T) {
+	primary := &fakeProvider{name: "primary"}
+	secondary := &fakeProvider{name: "secondary"}
+	p := newTestFailover(t, primary, secondary)
+
+	if err := p.Create(context.Background(), provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if !primary.has("app.example.com", "10.0.0.1") {
+		t.Error("record not created on primary")
+	}
+	if len(secondary.records) != 0 {
+		t.Errorf("secondary records = %v, want none", secondary.records)
+	}
+	if p.Active() != "primary" {
+		t.Errorf("Active() = %q, want primary", p.Active())
+	}
+}
+
+func TestProvider_FailoverAndResync(t *testing.T) {
+	ctx := context.Background()
+	stale := provider.Record{Hostname: "old.example.com", Type: provider.RecordTypeA, Target: "10.0.0.9"}
+	primary := &fakeProvider{name: "primary", records: []provider.Record{stale}}
+	secondary := &fakeProvider{name: "secondary", records: []provider.Record{stale}}
+	p := newTestFailover(t, primary, secondary)
+
+	primary.pingErr = errors.New("connection refused")
+
+	added := provider.Record{Hostname: "new.example.com", Type: provider.RecordTypeA, Target: "10.0.0.2"}
+	if err := p.Create(ctx, added); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := p.Delete(ctx, stale); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if p.Active() != "secondary" {
+		t.Fatalf("Active() = %q, want secondary", p.Active())
+	}
+	if primary.has("new.example.com", "10.0.0.2") {
+		t.Error("record written to unhealthy primary")
+	}
+
+	// Primary recovers: the next operation resyncs it before switching back
+	primary.pingErr = nil
+	if _, err := p.List(ctx); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if p.Active() != "primary" {
+		t.Errorf("Active() = %q, want primary after recovery", p.Active())
+	}
+	if !primary.has("new.example.com", "10.0.0.2") {
+		t.Error("record created during outage not copied to primary")
+	}
+	if primary.has("old.example.com", "10.0.0.9") {
+		t.Error("record deleted during outage still on primary")
+	}
+}
+
+func TestProvider_MissingPrimaryUsesSecondary(t *testing.T) {
+	secondary := &fakeProvider{name: "secondary"}
+	p := newTestFailover(t, nil, secondary)
+
+	if err := p.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if p.Active() != "secondary" {
+		t.Errorf("Active() = %q, want secondary", p.Active())
+	}
+}
+
+func TestProvider_NoTargets(t *testing.T) {
+	p := newTestFailover(t, nil, nil)
+
+	err := p.Ping(context.Background())
+	if !errors.Is(err, provider.ErrProviderUnavailable) {
+		t.Errorf("Ping() error = %v, want ErrProviderUnavailable", err)
+	}
+}
+
+func TestProvider_HealthCheckInterval(t *testing.T) {
+	primary := &fakeProvider{name: "primary"}
+	secondary := &fakeProvider{name: "secondary"}
+	p := newTestFailover(t, primary, secondary)
+	p.interval = time.Minute
+
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if err := p.Ping(ctx); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	// Primary fails, but the next check is not due yet
+	primary.pingErr = errors.New("timeout")
+	if _, err := p.List(ctx); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if p.Active() != "primary" {
+		t.Errorf("Active() = %q, want primary before interval elapses", p.Active())
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := p.List(ctx); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if p.Active() != "secondary" {
+		t.Errorf("Active() = %q, want secondary after interval", p.Active())
+	}
+}
+
+func TestLoadConfigFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]string
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			name:   "defaults",
+			config: map[string]string{"PRIMARY": "dns1", "SECONDARY": "dns2"},
+			want:   DefaultHealthCheckInterval,
+		},
+		{
+			name:   "custom interval",
+			config: map[string]string{"PRIMARY": "dns1", "SECONDARY": "dns2", "HEALTH_CHECK_INTERVAL": "10s"},
+			want:   10 * time.Second,
+		},
+		{
+			name:    "missing secondary",
+			config:  map[string]string{"PRIMARY": "dns1"},
+			wantErr: true,
+		},
+		{
+			name:    "same instance",
+			config:  map[string]string{"PRIMARY": "dns1", "SECONDARY": "dns1"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid interval",
+			config:  map[string]string{"PRIMARY": "dns1", "SECONDARY": "dns2", "HEALTH_CHECK_INTERVAL": "soon"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := LoadConfigFromMap(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadConfigFromMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.HealthCheckInterval != tt.want {
+				t.Errorf("HealthCheckInterval = %v, want %v", cfg.HealthCheckInterval, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,416 @@
+package hosts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/sshutil"
+)
+
+// ManagedMarker is the trailing comment that identifies hosts file lines
+// owned by dnsweaver. Lines without it are never modified.
+const ManagedMarker = "# managed by dnsweaver"
+
+// hostsEntry is a single IP-to-hostname mapping in a hosts file.
+type hostsEntry struct {
+	IP       string
+	Hostname string
+}
+
+// FileSystem abstracts file operations for testing and remote access.
+// It is satisfied by sshutil.SFTPFileSystem.
+type FileSystem interface {
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path string, data []byte, perm os.FileMode) error
+	Stat(path string) (os.FileInfo, error)
+}
+
+// osFileSystem implements FileSystem using the real OS.
+type osFileSystem struct{}
+
+func (osFileSystem) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func (osFileSystem) WriteFile(path string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(path, data, perm)
+}
+
+func (osFileSystem) Stat(path string) (os.FileInfo, error) {
+	return os.Stat(path)
+}
+
+// Client reads and rewrites a hosts file, touching only managed lines.
+type Client struct {
+	path   string
+	zone   string
+	fs     FileSystem
+	logger *slog.Logger
+
+	// mu serializes read-modify-write cycles on the file
+	mu sync.Mutex
+}
+
+// ClientOption is a functional option for configuring the Client.
+type ClientOption func(*Client)
+
+// WithLogger sets a custom logger for the client.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithFileSystem sets a custom file system (for testing).
+func WithFileSystem(fs FileSystem) ClientOption {
+	return func(c *Client) {
+		if fs != nil {
+			c.fs = fs
+		}
+	}
+}
+
+// NewClient creates a new hosts file client. Unless a file system is supplied
+// via WithFileSystem, the file is accessed over SFTP when SSH is configured
+// and locally otherwise.
+func NewClient(config *Config, opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		path:   config.File,
+		zone:   config.Zone,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.fs == nil {
+		if config.IsSSHEnabled() {
+			sshFS, err := newSSHFileSystem(config, c.logger)
+			if err != nil {
+				return nil, err
+			}
+			c.fs = sshFS
+		} else {
+			c.fs = osFileSystem{}
+		}
+	}
+
+	return c, nil
+}
+
+// Close releases the underlying transport, if it holds one.
+func (c *Client) Close() error {
+	if closer, ok := c.fs.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// connect opens the underlying transport, if it needs one.
+func (c *Client) connect(ctx context.Context) error {
+	if connector, ok := c.fs.(interface{ Connect(context.Context) error }); ok {
+		return connector.Connect(ctx)
+	}
+	return nil
+}
+
+// Ping checks that the hosts file, or the directory it would be created in, exists.
+func (c *Client) Ping(ctx context.Context) error {
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+
+	if _, err := c.fs.Stat(c.path); err == nil {
+		return nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("checking hosts file: %w", err)
+	}
+
+	dir := path.Dir(c.path)
+	info, err := c.fs.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("checking hosts file directory %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("hosts file directory is not a directory: %s", dir)
+	}
+	return nil
+}
+
+// List returns the managed entries of the hosts file, filtered by zone if configured.
+func (c *Client) List(ctx context.Context) ([]hostsEntry, error) {
+	if err := c.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lines, err := c.readLines()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []hostsEntry
+	for _, line := range lines {
+		ip, hostnames, ok := parseManagedLine(line)
+		if !ok {
+			continue
+		}
+		for _, hostname := range hostnames {
+			if c.zone != "" && !inZone(hostname, c.zone) {
+				continue
+			}
+			entries = append(entries, hostsEntry{IP: ip, Hostname: hostname})
+		}
+	}
+
+	return entries, nil
+}
+
+// Create appends a managed line for each entry unless one already exists.
+// All entries are written in a single file update.
+func (c *Client) Create(ctx context.Context, entries ...hostsEntry) error {
+	for _, entry := range entries {
+		if net.ParseIP(entry.IP) == nil {
+			return fmt.Errorf("invalid IP address: %s", entry.IP)
+		}
+	}
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lines, err := c.readLines()
+	if err != nil {
+		return err
+	}
+
+	added := 0
+	for _, entry := range entries {
+		if hasEntry(lines, entry) {
+			c.logger.Debug("entry already exists, skipping",
+				slog.String("hostname", entry.Hostname))
+			continue
+		}
+		lines = append(lines, formatEntry(entry))
+		added++
+	}
+	if added == 0 {
+		return nil
+	}
+
+	if err := c.writeLines(lines); err != nil {
+		return err
+	}
+
+	c.logger.Debug("created entries",
+		slog.Int("count", added))
+
+	return nil
+}
+
+// Delete removes the entry's hostname from managed lines with its IP.
+// Lines left without hostnames are dropped. Deleting an entry that does not
+// exist succeeds.
+func (c *Client) Delete(ctx context.Context, entry hostsEntry) error {
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lines, err := c.readLines()
+	if err != nil {
+		return err
+	}
+
+	kept := make([]string, 0, len(lines))
+	removed := false
+	for _, line := range lines {
+		ip, hostnames, ok := parseManagedLine(line)
+		if !ok || ip != entry.IP || !containsHostname(hostnames, entry.Hostname) {
+			kept = append(kept, line)
+			continue
+		}
+		removed = true
+
+		var remaining []string
+		for _, hostname := range hostnames {
+			if !strings.EqualFold(hostname, entry.Hostname) {
+				remaining = append(remaining, hostname)
+			}
+		}
+		if len(remaining) > 0 {
+			kept = append(kept, ip+"\t"+strings.Join(remaining, " ")+"\t"+ManagedMarker)
+		}
+	}
+
+	if !removed {
+		c.logger.Debug("entry not found, nothing to delete",
+			slog.String("hostname", entry.Hostname))
+		return nil
+	}
+
+	if err := c.writeLines(kept); err != nil {
+		return err
+	}
+
+	c.logger.Debug("deleted entry",
+		slog.String("hostname", entry.Hostname),
+		slog.String("ip", entry.IP))
+
+	return nil
+}
+
+// readLines returns the lines of the hosts file, or none if it does not exist.
+func (c *Client) readLines() ([]string, error) {
+	content, err := c.fs.ReadFile(c.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			c.logger.Debug("hosts file does not exist, treating as empty",
+				slog.String("path", c.path))
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading hosts file: %w", err)
+	}
+
+	text := strings.TrimSuffix(string(content), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
+// writeLines rewrites the hosts file in place. The file is truncated rather
+// than replaced so that bind-mounted files such as /etc/hosts keep working.
+func (c *Client) writeLines(lines []string) error {
+	content := strings.Join(lines, "\n")
+	if len(lines) > 0 {
+		content += "\n"
+	}
+	if err := c.fs.WriteFile(c.path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("writing hosts file: %w", err)
+	}
+	return nil
+}
+
+// parseManagedLine splits a managed hosts file line into its IP and hostnames.
+// It returns false for blank lines, comments, and lines without ManagedMarker.
+func parseManagedLine(line string) (string, []string, bool) {
+	data, comment, found := strings.Cut(line, "#")
+	if !found || strings.TrimSpace("#"+comment) != ManagedMarker {
+		return "", nil, false
+	}
+
+	fields := strings.Fields(data)
+	if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+		return "", nil, false
+	}
+	return fields[0], fields[1:], true
+}
+
+// hasEntry reports whether a managed line maps the entry's hostname to its IP.
+func hasEntry(lines []string, entry hostsEntry) bool {
+	for _, line := range lines {
+		if ip, hostnames, ok := parseManagedLine(line); ok && ip == entry.IP && containsHostname(hostnames, entry.Hostname) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatEntry formats an entry as a managed hosts file line.
+func formatEntry(entry hostsEntry) string {
+	return entry.IP + "\t" + entry.Hostname + "\t" + ManagedMarker
+}
+
+// containsHostname reports whether hostnames contains hostname, ignoring case.
+func containsHostname(hostnames []string, hostname string) bool {
+	for _, h := range hostnames {
+		if strings.EqualFold(h, hostname) {
+			return true
+		}
+	}
+	return false
+}
+
+// inZone reports whether hostname is zone or a name within it.
+func inZone(hostname, zone string) bool {
+	hostname = strings.ToLower(hostname)
+	return hostname == zone || strings.HasSuffix(hostname, "."+zone)
+}
+
+// sshFileSystem accesses the hosts file over SFTP, connecting on first use.
+type sshFileSystem struct {
+	*sshutil.SFTPFileSystem
+
+	addr   string
+	client *sshutil.Client
+
+	mu sync.Mutex
+}
+
+// newSSHFileSystem creates an sshFileSystem for the configured SSH host.
+func newSSHFileSystem(config *Config, logger *slog.Logger) (*sshFileSystem, error) {
+	client, err := sshutil.NewClient(&sshutil.Config{
+		Host:           config.SSHHost,
+		Port:           config.SSHPort,
+		User:           config.SSHUser,
+		KeyFile:        config.SSHKeyFile,
+		Password:       config.SSHPassword,
+		KnownHostsFile: config.KnownHostsFile,
+		ProxyJump:      config.SSHProxyJump,
+	}, sshutil.WithLogger(logger))
+	if err != nil {
+		return nil, fmt.Errorf("creating SSH client: %w", err)
+	}
+
+	return &sshFileSystem{
+		SFTPFileSystem: sshutil.NewSFTPFileSystem(client, sshutil.WithSFTPLogger(logger)),
+		addr:           net.JoinHostPort(config.SSHHost, strconv.Itoa(config.SSHPort)),
+		client:         client,
+	}, nil
+}
+
+// Connect opens the SSH connection and SFTP session if they are not open.
+func (s *sshFileSystem) Connect(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.client.IsConnected() {
+		// A dropped connection leaves a stale SFTP session behind
+		_ = s.SFTPFileSystem.Close()
+		if err := s.client.Connect(ctx); err != nil && !errors.Is(err, sshutil.ErrAlreadyConnected) {
+			return fmt.Errorf("connecting to %s: %w", s.addr, err)
+		}
+	}
+	if err := s.SFTPFileSystem.Connect(ctx); err != nil {
+		return fmt.Errorf("opening SFTP session on %s: %w", s.addr, err)
+	}
+	return nil
+}
+
+// Close closes the SFTP session and the SSH connection.
+func (s *sshFileSystem) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sftpErr := s.SFTPFileSystem.Close()
+	return errors.Join(sftpErr, s.client.Close())
+}
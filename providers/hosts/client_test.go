@@ -0,0 +1,166 @@
+package hosts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testHostsFile = `127.0.0.1	localhost
+::1	localhost ip6-localhost
+# 10.0.0.9 commented.home.lab # managed by dnsweaver
+10.0.0.5	nas.home.lab
+10.0.0.10	app.home.lab	# managed by dnsweaver
+10.0.0.11	a.home.lab b.home.lab	# managed by dnsweaver
+10.0.0.12	other.example.com	# managed by dnsweaver
+`
+
+func newTestClient(t *testing.T, content, zone string) (*Client, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hosts")
+	if content != "" {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client, err := NewClient(&Config{File: path, Zone: zone})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	return client, path
+}
+
+func TestParseManagedLine(t *testing.T) {
+	tests := []struct {
+		line      string
+		wantIP    string
+		wantHosts int
+		wantOK    bool
+	}{
+		{"10.0.0.10\tapp.home.lab\t# managed by dnsweaver", "10.0.0.10", 1, true},
+		{"fd00::1 a.home.lab b.home.lab #managed by dnsweaver", "", 0, false},
+		{"fd00::1 a.home.lab b.home.lab # managed by dnsweaver", "fd00::1", 2, true},
+		{"10.0.0.5 nas.home.lab", "", 0, false},
+		{"10.0.0.5 nas.home.lab # static", "", 0, false},
+		{"# 10.0.0.9 x.home.lab # managed by dnsweaver", "", 0, false},
+		{"not-an-ip x.home.lab # managed by dnsweaver", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		ip, hostnames, ok := parseManagedLine(tt.line)
+		if ok != tt.wantOK || ip != tt.wantIP || len(hostnames) != tt.wantHosts {
+			t.Errorf("parseManagedLine(%q) = %q, %v, %v; want %q, %d hostnames, %v",
+				tt.line, ip, hostnames, ok, tt.wantIP, tt.wantHosts, tt.wantOK)
+		}
+	}
+}
+
+func TestClient_List(t *testing.T) {
+	client, _ := newTestClient(t, testHostsFile, "")
+
+	entries, err := client.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	want := []hostsEntry{
+		{IP: "10.0.0.10", Hostname: "app.home.lab"},
+		{IP: "10.0.0.11", Hostname: "a.home.lab"},
+		{IP: "10.0.0.11", Hostname: "b.home.lab"},
+		{IP: "10.0.0.12", Hostname: "other.example.com"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("List() = %+v, want %+v", entries, want)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+
+	zoned, _ := newTestClient(t, testHostsFile, "home.lab")
+	entries, err = zoned.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("List() with zone returned %d entries, want 3", len(entries))
+	}
+}
+
+func TestClient_List_MissingFile(t *testing.T) {
+	client, _ := newTestClient(t, "", "")
+
+	entries, err := client.List(context.Background())
+	if err != nil || len(entries) != 0 {
+		t.Errorf("List() = %v, %v; want no entries and no error", entries, err)
+	}
+}
+
+func TestClient_CreateAndDelete(t *testing.T) {
+	client, path := newTestClient(t, testHostsFile, "")
+	ctx := context.Background()
+
+	entry := hostsEntry{IP: "10.0.0.20", Hostname: "new.home.lab"}
+	if err := client.Create(ctx, entry); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	// Creating the same entry again is a no-op
+	if err := client.Create(ctx, entry); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if want := testHostsFile + "10.0.0.20\tnew.home.lab\t# managed by dnsweaver\n"; string(content) != want {
+		t.Errorf("file after Create() =\n%s\nwant\n%s", content, want)
+	}
+
+	if err := client.Create(ctx, hostsEntry{IP: "app.home.lab", Hostname: "x.home.lab"}); err == nil {
+		t.Error("Create() with non-IP target expected error")
+	}
+
+	// Deleting one hostname of a shared line keeps the other
+	if err := client.Delete(ctx, hostsEntry{IP: "10.0.0.11", Hostname: "a.home.lab"}); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if err := client.Delete(ctx, entry); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	// Unmanaged lines are never removed
+	if err := client.Delete(ctx, hostsEntry{IP: "10.0.0.5", Hostname: "nas.home.lab"}); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+
+	content, _ = os.ReadFile(path)
+	want := `127.0.0.1	localhost
+::1	localhost ip6-localhost
+# 10.0.0.9 commented.home.lab # managed by dnsweaver
+10.0.0.5	nas.home.lab
+10.0.0.10	app.home.lab	# managed by dnsweaver
+10.0.0.11	b.home.lab	# managed by dnsweaver
+10.0.0.12	other.example.com	# managed by dnsweaver
+`
+	if string(content) != want {
+		t.Errorf("file after Delete() =\n%s\nwant\n%s", content, want)
+	}
+}
+
+func TestClient_Ping(t *testing.T) {
+	client, _ := newTestClient(t, testHostsFile, "")
+	if err := client.Ping(context.Background()); err != nil {
+		t.Errorf("Ping() unexpected error: %v", err)
+	}
+
+	// A missing file is fine as long as its directory exists
+	missing, _ := newTestClient(t, "", "")
+	if err := missing.Ping(context.Background()); err != nil {
+		t.Errorf("Ping() with missing file unexpected error: %v", err)
+	}
+
+	noDir, err := NewClient(&Config{File: filepath.Join(t.TempDir(), "missing", "hosts")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := noDir.Ping(context.Background()); err == nil {
+		t.Error("Ping() with missing directory expected error")
+	}
+}
@@ -0,0 +1,190 @@
+// Package hosts implements the DNSWeaver provider interface for /etc/hosts
+// style files, on the local filesystem or on a remote system over SSH.
+package hosts
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultTTL is the default TTL for hosts file records.
+// Note: hosts files have no TTL, but we track it for consistency.
+const DefaultTTL = 300
+
+// DefaultFile is the default hosts file path.
+const DefaultFile = "/etc/hosts"
+
+// Config holds hosts file-specific configuration.
+type Config struct {
+	File string // Hosts file path (e.g., /etc/hosts)
+	Zone string // DNS zone for record filtering (optional)
+	TTL  int    // Record TTL (for consistency with other providers)
+
+	// SSH configuration for managing a hosts file on a remote system (optional)
+	SSHHost        string // SSH host (e.g., "gateway.local" or "192.168.1.1")
+	SSHPort        int    // SSH port (default: 22)
+	SSHUser        string // SSH username
+	SSHKeyFile     string // Path to SSH private key file
+	SSHPassword    string // SSH password (alternative to key, not recommended)
+	KnownHostsFile string // known_hosts file verifying the SSH host (optional)
+	SSHProxyJump   string // Bastion host to reach SSHHost through, [user@]host[:port] (optional)
+}
+
+// Validate checks that all required configuration is present.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.File == "" {
+		errs = append(errs, "FILE is required")
+	}
+	if c.TTL < 0 {
+		errs = append(errs, "TTL must be non-negative")
+	}
+
+	// SSH validation: if any SSH option is set, host and user are required
+	if c.IsSSHEnabled() {
+		if c.SSHHost == "" {
+			errs = append(errs, "SSH_HOST is required when SSH is enabled")
+		}
+		if c.SSHUser == "" {
+			errs = append(errs, "SSH_USER is required when SSH is enabled")
+		}
+		if c.SSHKeyFile == "" && c.SSHPassword == "" {
+			errs = append(errs, "SSH_KEY_FILE or SSH_PASSWORD is required when SSH is enabled")
+		}
+		if c.SSHPort < 1 || c.SSHPort > 65535 {
+			errs = append(errs, "SSH_PORT must be between 1 and 65535")
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("hosts config validation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// IsSSHEnabled returns true if SSH configuration is provided.
+func (c *Config) IsSSHEnabled() bool {
+	return c.SSHHost != "" || c.SSHUser != "" || c.SSHKeyFile != "" || c.SSHPassword != "" || c.SSHProxyJump != ""
+}
+
+// LoadConfig loads hosts file configuration from environment variables.
+// Environment variable pattern: DNSWEAVER_{INSTANCE_NAME}_{SETTING}
+//
+// Instance names are normalized: lowercase with hyphens becomes uppercase with underscores.
+// Example: "hosts" looks for DNSWEAVER_HOSTS_*
+//
+// Supported settings:
+//   - FILE: Hosts file path (optional, default: /etc/hosts)
+//   - ZONE: DNS zone for record filtering (optional)
+//   - TTL: Record TTL (optional, default: 300)
+//   - SSH_HOST: Remote host whose hosts file is managed (optional)
+//   - SSH_PORT: SSH port (optional, default: 22)
+//   - SSH_USER: SSH username (required if SSH_HOST set)
+//   - SSH_KEY_FILE: Path to SSH private key (supports _FILE suffix for Docker secrets)
+//   - SSH_PASSWORD: SSH password (not recommended, use SSH_KEY_FILE)
+//   - KNOWN_HOSTS_FILE: known_hosts file for verifying the SSH host (optional)
+//   - SSH_PROXY_JUMP: Bastion host to connect through, [user@]host[:port] (optional)
+func LoadConfig(instanceName string) (*Config, error) {
+	prefix := envPrefix(instanceName)
+
+	return LoadConfigFromMap(instanceName, map[string]string{
+		"FILE":             getEnv(prefix + "FILE"),
+		"ZONE":             getEnv(prefix + "ZONE"),
+		"TTL":              getEnv(prefix + "TTL"),
+		"SSH_HOST":         getEnv(prefix + "SSH_HOST"),
+		"SSH_PORT":         getEnv(prefix + "SSH_PORT"),
+		"SSH_USER":         getEnv(prefix + "SSH_USER"),
+		"SSH_KEY_FILE":     getEnvOrFile(prefix+"SSH_KEY_FILE", prefix+"SSH_KEY_FILE_FILE"),
+		"SSH_PASSWORD":     getEnvOrFile(prefix+"SSH_PASSWORD", prefix+"SSH_PASSWORD_FILE"),
+		"KNOWN_HOSTS_FILE": getEnv(prefix + "KNOWN_HOSTS_FILE"),
+		"SSH_PROXY_JUMP":   getEnv(prefix + "SSH_PROXY_JUMP"),
+	})
+}
+
+// LoadConfigFromMap creates a Config from a map of key-value pairs.
+// This is used by the provider registry to create instances from
+// configuration that was already parsed from environment variables.
+//
+// Optional keys: FILE, ZONE, TTL, SSH_HOST, SSH_PORT, SSH_USER, SSH_KEY_FILE,
+// SSH_PASSWORD, KNOWN_HOSTS_FILE, SSH_PROXY_JUMP
+func LoadConfigFromMap(instanceName string, configMap map[string]string) (*Config, error) {
+	config := &Config{
+		File:           getMapWithDefault(configMap, "FILE", DefaultFile),
+		Zone:           strings.TrimSuffix(strings.ToLower(configMap["ZONE"]), "."),
+		TTL:            DefaultTTL,
+		SSHHost:        configMap["SSH_HOST"],
+		SSHUser:        configMap["SSH_USER"],
+		SSHKeyFile:     configMap["SSH_KEY_FILE"],
+		SSHPassword:    configMap["SSH_PASSWORD"],
+		KnownHostsFile: configMap["KNOWN_HOSTS_FILE"],
+		SSHProxyJump:   configMap["SSH_PROXY_JUMP"],
+	}
+
+	// Parse optional TTL
+	if ttlStr, ok := configMap["TTL"]; ok && ttlStr != "" {
+		ttl, err := strconv.Atoi(ttlStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TTL value %q: %w", ttlStr, err)
+		}
+		config.TTL = ttl
+	}
+
+	// Parse optional SSH port
+	if portStr, ok := configMap["SSH_PORT"]; ok && portStr != "" {
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SSH_PORT value %q: %w", portStr, err)
+		}
+		config.SSHPort = port
+	} else if config.IsSSHEnabled() {
+		config.SSHPort = 22
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
+	}
+
+	return config, nil
+}
+
+// envPrefix converts an instance name to an environment variable prefix.
+// Example: "hosts" → "DNSWEAVER_HOSTS_"
+func envPrefix(instanceName string) string {
+	normalized := strings.ToUpper(instanceName)
+	normalized = strings.ReplaceAll(normalized, "-", "_")
+	return "DNSWEAVER_" + normalized + "_"
+}
+
+// getEnv retrieves an environment variable value.
+func getEnv(key string) string {
+	return os.Getenv(key)
+}
+
+// getEnvOrFile retrieves a value from either a direct environment variable
+// or a file path specified by the file key (Docker secrets pattern).
+//
+// If both are set, the file takes precedence.
+// The file contents are trimmed of leading/trailing whitespace.
+func getEnvOrFile(directKey, fileKey string) string {
+	if filePath := os.Getenv(fileKey); filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+		// If file read fails, fall through to direct value
+	}
+
+	return os.Getenv(directKey)
+}
+
+// getMapWithDefault returns the value for key, or defaultValue when it is empty.
+func getMapWithDefault(configMap map[string]string, key, defaultValue string) string {
+	if value := configMap[key]; value != "" {
+		return value
+	}
+	return defaultValue
+}
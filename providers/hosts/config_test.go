@@ -0,0 +1,97 @@
+package hosts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigFromMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		configMap map[string]string
+		wantErr   string
+		check     func(t *testing.T, c *Config)
+	}{
+		{
+			name:      "defaults",
+			configMap: map[string]string{},
+			check: func(t *testing.T, c *Config) {
+				if c.File != DefaultFile {
+					t.Errorf("File = %q, want %q", c.File, DefaultFile)
+				}
+				if c.TTL != DefaultTTL {
+					t.Errorf("TTL = %d, want %d", c.TTL, DefaultTTL)
+				}
+				if c.IsSSHEnabled() {
+					t.Error("IsSSHEnabled() = true, want false")
+				}
+			},
+		},
+		{
+			name: "ssh with default port",
+			configMap: map[string]string{
+				"FILE":         "/etc/hosts.dnsweaver",
+				"ZONE":         "Home.Lab.",
+				"SSH_HOST":     "gateway.local",
+				"SSH_USER":     "root",
+				"SSH_KEY_FILE": "/run/secrets/hosts_key",
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.File != "/etc/hosts.dnsweaver" {
+					t.Errorf("File = %q, want /etc/hosts.dnsweaver", c.File)
+				}
+				if c.Zone != "home.lab" {
+					t.Errorf("Zone = %q, want home.lab", c.Zone)
+				}
+				if !c.IsSSHEnabled() || c.SSHPort != 22 {
+					t.Errorf("SSH enabled/port = %v/%d, want true/22", c.IsSSHEnabled(), c.SSHPort)
+				}
+			},
+		},
+		{
+			name:      "invalid TTL",
+			configMap: map[string]string{"TTL": "soon"},
+			wantErr:   "invalid TTL",
+		},
+		{
+			name:      "ssh without user or credentials",
+			configMap: map[string]string{"SSH_HOST": "gateway"},
+			wantErr:   "SSH_USER is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := LoadConfigFromMap("hosts", tt.configMap)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfigFromMap() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfigFromMap() unexpected error: %v", err)
+			}
+			tt.check(t, c)
+		})
+	}
+}
+
+func TestLoadConfig_Env(t *testing.T) {
+	t.Setenv("DNSWEAVER_HOSTS_FILE", "/data/hosts")
+	t.Setenv("DNSWEAVER_HOSTS_SSH_HOST", "gateway")
+	t.Setenv("DNSWEAVER_HOSTS_SSH_PORT", "2222")
+	t.Setenv("DNSWEAVER_HOSTS_SSH_USER", "admin")
+	t.Setenv("DNSWEAVER_HOSTS_SSH_PASSWORD", "secret")
+
+	c, err := LoadConfig("hosts")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	if c.File != "/data/hosts" {
+		t.Errorf("File = %q, want /data/hosts", c.File)
+	}
+	if c.SSHPort != 2222 || c.SSHUser != "admin" || c.SSHPassword != "secret" {
+		t.Errorf("SSH config = %d/%q/%q, want 2222/admin/secret", c.SSHPort, c.SSHUser, c.SSHPassword)
+	}
+}
@@ -0,0 +1,16 @@
+package hosts
+
+import (
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Factory returns a provider.Factory for creating hosts file provider instances.
+// This is the recommended way to register the hosts provider with the registry.
+//
+// Note: hosts is a file-based provider and does not use HTTP clients,
+// so the HTTP configuration from FactoryConfig is not used.
+func Factory() provider.Factory {
+	return func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		return NewFromMap(cfg.Name, cfg.ProviderConfig)
+	}
+}
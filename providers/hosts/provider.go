@@ -0,0 +1,252 @@
+package hosts
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Provider implements provider.Provider for /etc/hosts style files.
+type Provider struct {
+	name   string
+	zone   string
+	ttl    int
+	client *Client
+	logger *slog.Logger
+}
+
+// ProviderOption is a functional option for configuring the Provider.
+type ProviderOption func(*Provider)
+
+// WithProviderLogger sets a custom logger for the provider.
+func WithProviderLogger(logger *slog.Logger) ProviderOption {
+	return func(p *Provider) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// WithClient sets a custom client (for testing).
+func WithClient(client *Client) ProviderOption {
+	return func(p *Provider) {
+		p.client = client
+	}
+}
+
+// New creates a new hosts file provider instance.
+func New(name string, config *Config, opts ...ProviderOption) (*Provider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		name:   name,
+		zone:   config.Zone,
+		ttl:    config.TTL,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// Create client if not provided via options (testing)
+	if p.client == nil {
+		client, err := NewClient(config, WithLogger(p.logger))
+		if err != nil {
+			return nil, err
+		}
+		p.client = client
+	}
+
+	return p, nil
+}
+
+// NewFromEnv creates a new hosts file provider from environment variables.
+// This is a convenience function for use with the provider registry.
+func NewFromEnv(instanceName string, opts ...ProviderOption) (*Provider, error) {
+	config, err := LoadConfig(instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(instanceName, config, opts...)
+}
+
+// NewFromMap creates a new hosts file provider from a configuration map.
+// This is used by the provider registry Factory pattern.
+func NewFromMap(name string, config map[string]string) (*Provider, error) {
+	cfg, err := LoadConfigFromMap(name, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(name, cfg)
+}
+
+// Name returns the provider instance name.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Type returns "hosts".
+func (p *Provider) Type() string {
+	return "hosts"
+}
+
+// Capabilities returns the provider's feature support.
+// Hosts files map addresses to names only: no TXT ownership (the managed
+// marker identifies owned lines instead), no native update, A and AAAA only.
+func (p *Provider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SupportsOwnershipTXT: false, // Ownership is the managed marker comment
+		SupportsNativeUpdate: false, // Requires file rewrite (delete+create)
+		// Resolvers disagree on whether repeated names return every address
+		SupportsMultipleTargets: false,
+		SupportedRecordTypes: []provider.RecordType{
+			provider.RecordTypeA,
+			provider.RecordTypeAAAA,
+		},
+	}
+}
+
+// Zone returns the configured DNS zone.
+func (p *Provider) Zone() string {
+	return p.zone
+}
+
+// Ping checks that the hosts file is reachable.
+func (p *Provider) Ping(ctx context.Context) error {
+	return p.client.Ping(ctx)
+}
+
+// Close closes the SSH connection to the remote system, if any.
+func (p *Provider) Close() error {
+	return p.client.Close()
+}
+
+// List returns the dnsweaver-managed entries of the hosts file.
+func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
+	entries, err := p.client.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing records: %w", err)
+	}
+
+	records := make([]provider.Record, 0, len(entries))
+	for _, e := range entries {
+		recordType := provider.RecordTypeA
+		if isIPv6(e.IP) {
+			recordType = provider.RecordTypeAAAA
+		}
+		records = append(records, provider.Record{
+			Hostname:   e.Hostname,
+			Type:       recordType,
+			Target:     e.IP,
+			TTL:        p.ttl, // hosts files have no TTL, but we track it for consistency
+			ProviderID: fmt.Sprintf("%s:%s:%s", e.Hostname, recordType, e.IP),
+		})
+	}
+
+	p.logger.Debug("listed records",
+		slog.String("provider", p.name),
+		slog.Int("count", len(records)),
+	)
+
+	return records, nil
+}
+
+// Create adds a managed entry to the hosts file.
+func (p *Provider) Create(ctx context.Context, record provider.Record) error {
+	switch record.Type {
+	case provider.RecordTypeA, provider.RecordTypeAAAA:
+		// Supported
+	case provider.RecordTypeTXT:
+		// Ownership is tracked with the managed marker instead
+		p.logger.Debug("skipping TXT record (not supported by hosts provider)",
+			slog.String("hostname", record.Hostname))
+		return nil
+	default:
+		return fmt.Errorf("unsupported record type for hosts provider: %s", record.Type)
+	}
+
+	if err := p.client.Create(ctx, hostsEntry{IP: record.Target, Hostname: record.Hostname}); err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("created record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+	)
+
+	return nil
+}
+
+// BulkCreate adds managed entries for all records in a single file rewrite.
+// TXT records are skipped as in Create.
+func (p *Provider) BulkCreate(ctx context.Context, records []provider.Record) error {
+	entries := make([]hostsEntry, 0, len(records))
+	for _, record := range records {
+		switch record.Type {
+		case provider.RecordTypeA, provider.RecordTypeAAAA:
+			entries = append(entries, hostsEntry{IP: record.Target, Hostname: record.Hostname})
+		case provider.RecordTypeTXT:
+			// Ownership is tracked with the managed marker instead
+		default:
+			return fmt.Errorf("unsupported record type for hosts provider: %s", record.Type)
+		}
+	}
+
+	if err := p.client.Create(ctx, entries...); err != nil {
+		return fmt.Errorf("creating records: %w", err)
+	}
+
+	p.logger.Info("created records",
+		slog.String("provider", p.name),
+		slog.Int("count", len(entries)),
+	)
+
+	return nil
+}
+
+// Delete removes a managed entry from the hosts file.
+func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
+	if record.Type == provider.RecordTypeTXT {
+		p.logger.Debug("skipping TXT record deletion (not supported by hosts provider)",
+			slog.String("hostname", record.Hostname))
+		return nil
+	}
+
+	if err := p.client.Delete(ctx, hostsEntry{IP: record.Target, Hostname: record.Hostname}); err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("deleted record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+	)
+
+	return nil
+}
+
+// isIPv6 reports whether ip is an IPv6 address.
+func isIPv6(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && parsed.To4() == nil
+}
+
+// Ensure Provider implements provider.Provider and provider.BulkCreator at compile time.
+var (
+	_ provider.Provider    = (*Provider)(nil)
+	_ provider.BulkCreator = (*Provider)(nil)
+)
@@ -0,0 +1,98 @@
+package hosts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+func newTestProvider(t *testing.T, content string) (*Provider, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hosts")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := New("hosts", &Config{File: path, TTL: DefaultTTL})
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	return p, path
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("hosts", nil); err == nil {
+		t.Error("New() with nil config expected error")
+	}
+	if _, err := New("hosts", &Config{}); err == nil {
+		t.Error("New() with invalid config expected error")
+	}
+}
+
+func TestProvider_List(t *testing.T) {
+	p, _ := newTestProvider(t, "127.0.0.1 localhost\n"+
+		"10.0.0.10 app.home.lab # managed by dnsweaver\n"+
+		"fd00::10 app.home.lab # managed by dnsweaver\n")
+
+	records, err := p.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("List() returned %d records, want 2", len(records))
+	}
+	if records[0].Type != provider.RecordTypeA || records[0].Target != "10.0.0.10" {
+		t.Errorf("records[0] = %+v, want A 10.0.0.10", records[0])
+	}
+	if records[1].Type != provider.RecordTypeAAAA || records[1].Target != "fd00::10" {
+		t.Errorf("records[1] = %+v, want AAAA fd00::10", records[1])
+	}
+}
+
+func TestProvider_CreateDelete(t *testing.T) {
+	p, path := newTestProvider(t, "127.0.0.1 localhost\n")
+	ctx := context.Background()
+
+	record := provider.Record{Hostname: "app.home.lab", Type: provider.RecordTypeA, Target: "10.0.0.10"}
+	if err := p.Create(ctx, record); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), "10.0.0.10\tapp.home.lab\t"+ManagedMarker) {
+		t.Errorf("file after Create() = %q, want managed line", content)
+	}
+
+	if err := p.Create(ctx, provider.Record{Hostname: "app.home.lab", Type: provider.RecordTypeCNAME, Target: "other.home.lab"}); err == nil {
+		t.Error("Create() of CNAME record expected error")
+	}
+
+	if err := p.Delete(ctx, record); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	content, _ = os.ReadFile(path)
+	if string(content) != "127.0.0.1 localhost\n" {
+		t.Errorf("file after Delete() = %q, want original content", content)
+	}
+}
+
+func TestProvider_BulkCreate(t *testing.T) {
+	p, path := newTestProvider(t, "127.0.0.1 localhost\n")
+
+	err := p.BulkCreate(context.Background(), []provider.Record{
+		{Hostname: "app.home.lab", Type: provider.RecordTypeA, Target: "10.0.0.10"},
+		{Hostname: "_dnsweaver.app.home.lab", Type: provider.RecordTypeTXT, Target: provider.OwnershipValue},
+		{Hostname: "app.home.lab", Type: provider.RecordTypeAAAA, Target: "fd00::10"},
+	})
+	if err != nil {
+		t.Fatalf("BulkCreate() unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	want := "127.0.0.1 localhost\n10.0.0.10\tapp.home.lab\t" + ManagedMarker + "\nfd00::10\tapp.home.lab\t" + ManagedMarker + "\n"
+	if string(content) != want {
+		t.Errorf("file after BulkCreate() = %q, want %q", content, want)
+	}
+}
@@ -0,0 +1,191 @@
+package httpprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// RecordPayload is the JSON body sent with create and delete requests whose
+// method carries a body (POST, PUT, PATCH), and the element type expected in
+// the list response. List responses may use "value" instead of "target".
+type RecordPayload struct {
+	Hostname string `json:"hostname"`
+	Type     string `json:"type"`
+	Target   string `json:"target"`
+	Value    string `json:"value,omitempty"`
+	TTL      int    `json:"ttl,omitempty"`
+}
+
+// target returns Target, falling back to Value.
+func (r RecordPayload) target() string {
+	if r.Target != "" {
+		return r.Target
+	}
+	return r.Value
+}
+
+// Client calls the configured URL templates.
+type Client struct {
+	config     *Config
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// ClientOption is a functional option for configuring the Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// NewClient creates a new HTTP provider client.
+func NewClient(config *Config, opts ...ClientOption) *Client {
+	c := &Client{
+		config:     config,
+		httpClient: httputil.DefaultClient(),
+		logger:     slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// expandURL replaces the {hostname}, {type}, {target}, and {ttl} placeholders
+// in tmpl. Values are escaped so they are safe in both paths and query strings.
+func expandURL(tmpl string, r RecordPayload) string {
+	return strings.NewReplacer(
+		"{hostname}", escape(r.Hostname),
+		"{type}", escape(r.Type),
+		"{target}", escape(r.Target),
+		"{ttl}", strconv.Itoa(r.TTL),
+	).Replace(tmpl)
+}
+
+// escape percent-encodes s, using %20 rather than + for spaces.
+func escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// hasBody reports whether requests with method carry a JSON body.
+func hasBody(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch
+}
+
+// do performs a request and returns the response body. Non-2xx responses are
+// returned as errors wrapping provider.ErrorForStatus; in addition, 404 wraps
+// provider.ErrNotFound and 409 wraps provider.ErrConflict.
+func (c *Client) do(ctx context.Context, method, reqURL string, payload *RecordPayload) ([]byte, error) {
+	var body io.Reader
+	if payload != nil && hasBody(method) {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("encoding request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	c.logger.Debug("making HTTP provider request",
+		slog.String("method", method),
+		slog.String("url", reqURL),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.config.AuthHeader != "" && c.config.AuthToken != "" {
+		req.Header.Set(c.config.AuthHeader, c.config.AuthToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail := strings.TrimSpace(string(respBody))
+		base := provider.ErrorForStatus(resp.StatusCode)
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			base = provider.ErrNotFound
+		case http.StatusConflict:
+			base = provider.ErrConflict
+		}
+		if base != nil {
+			return nil, fmt.Errorf("%w: status %d: %s", base, resp.StatusCode, detail)
+		}
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, detail)
+	}
+
+	return respBody, nil
+}
+
+// List fetches LIST_URL and decodes a JSON array of RecordPayload.
+func (c *Client) List(ctx context.Context) ([]RecordPayload, error) {
+	body, err := c.do(ctx, DefaultListMethod, c.config.ListURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list failed: %w", err)
+	}
+
+	var records []RecordPayload
+	if err := json.Unmarshal(body, &records); err != nil {
+		return nil, fmt.Errorf("parsing list response: %w", err)
+	}
+
+	return records, nil
+}
+
+// Create calls CREATE_URL with CREATE_METHOD for the record.
+func (c *Client) Create(ctx context.Context, r RecordPayload) error {
+	if _, err := c.do(ctx, c.config.CreateMethod, expandURL(c.config.CreateURL, r), &r); err != nil {
+		return fmt.Errorf("create failed: %w", err)
+	}
+	return nil
+}
+
+// Delete calls DELETE_URL with DELETE_METHOD for the record.
+func (c *Client) Delete(ctx context.Context, r RecordPayload) error {
+	if _, err := c.do(ctx, c.config.DeleteMethod, expandURL(c.config.DeleteURL, r), &r); err != nil {
+		return fmt.Errorf("delete failed: %w", err)
+	}
+	return nil
+}
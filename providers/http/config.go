@@ -0,0 +1,182 @@
+// Package httpprovider implements the DNSWeaver provider interface for
+// arbitrary HTTP APIs. Each operation calls a user-defined URL template, so
+// records can be managed through APIs that do not speak the webhook contract.
+package httpprovider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Default HTTP methods for each operation.
+const (
+	DefaultCreateMethod = http.MethodPost
+	DefaultDeleteMethod = http.MethodDelete
+	DefaultListMethod   = http.MethodGet
+)
+
+// Config holds HTTP provider configuration.
+//
+// URL templates may contain the placeholders {hostname}, {type}, {target},
+// and {ttl}, which are replaced with the URL-escaped record fields.
+type Config struct {
+	CreateURL    string // URL template for creating a record (required)
+	CreateMethod string // HTTP method for create (default: POST)
+	DeleteURL    string // URL template for deleting a record (required)
+	DeleteMethod string // HTTP method for delete (default: DELETE)
+	ListURL      string // URL returning all records as a JSON array (required)
+
+	AuthHeader string // Custom authentication header name (optional)
+	AuthToken  string // Authentication token value (optional)
+
+	InsecureSkipVerify bool // Skip TLS certificate verification (use with caution)
+}
+
+// Validate checks that all required configuration is present.
+func (c *Config) Validate() error {
+	var errs []string
+
+	for _, u := range []struct{ key, value string }{
+		{"CREATE_URL", c.CreateURL},
+		{"DELETE_URL", c.DeleteURL},
+		{"LIST_URL", c.ListURL},
+	} {
+		if u.value == "" {
+			errs = append(errs, u.key+" is required")
+		} else if !strings.HasPrefix(u.value, "http://") && !strings.HasPrefix(u.value, "https://") {
+			errs = append(errs, u.key+" must start with http:// or https://")
+		}
+	}
+
+	for _, m := range []struct{ key, value string }{
+		{"CREATE_METHOD", c.CreateMethod},
+		{"DELETE_METHOD", c.DeleteMethod},
+	} {
+		if !validMethod(m.value) {
+			errs = append(errs, fmt.Sprintf("%s %q is not a supported HTTP method", m.key, m.value))
+		}
+	}
+
+	if c.AuthHeader != "" && c.AuthToken == "" {
+		errs = append(errs, "AUTH_TOKEN is required when AUTH_HEADER is set")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("http config validation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// validMethod reports whether method is an HTTP method usable for record changes.
+func validMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// LoadConfig loads HTTP provider configuration from environment variables.
+// Environment variable pattern: DNSWEAVER_{INSTANCE_NAME}_{SETTING}
+//
+// Instance names are normalized: lowercase with hyphens becomes uppercase with underscores.
+// Example: "pfsense" looks for DNSWEAVER_PFSENSE_*
+//
+// Supported settings:
+//   - CREATE_URL: URL template for create (required)
+//   - CREATE_METHOD: HTTP method for create (optional, default: POST)
+//   - DELETE_URL: URL template for delete (required)
+//   - DELETE_METHOD: HTTP method for delete (optional, default: DELETE)
+//   - LIST_URL: URL returning all records (required)
+//   - AUTH_HEADER: Custom auth header name (optional, e.g., "X-API-Key")
+//   - AUTH_TOKEN: Auth token value (required if AUTH_HEADER set, supports _FILE)
+//   - INSECURE_SKIP_VERIFY (alias SKIP_TLS_VERIFY): Skip TLS verification (optional)
+func LoadConfig(instanceName string) (*Config, error) {
+	prefix := envPrefix(instanceName)
+
+	return LoadConfigFromMap(instanceName, map[string]string{
+		"CREATE_URL":           getEnv(prefix + "CREATE_URL"),
+		"CREATE_METHOD":        getEnv(prefix + "CREATE_METHOD"),
+		"DELETE_URL":           getEnv(prefix + "DELETE_URL"),
+		"DELETE_METHOD":        getEnv(prefix + "DELETE_METHOD"),
+		"LIST_URL":             getEnv(prefix + "LIST_URL"),
+		"AUTH_HEADER":          getEnv(prefix + "AUTH_HEADER"),
+		"AUTH_TOKEN":           getEnvOrFile(prefix+"AUTH_TOKEN", prefix+"AUTH_TOKEN_FILE"),
+		"INSECURE_SKIP_VERIFY": getEnv(prefix + "INSECURE_SKIP_VERIFY"),
+		"SKIP_TLS_VERIFY":      getEnv(prefix + "SKIP_TLS_VERIFY"),
+	})
+}
+
+// LoadConfigFromMap creates a Config from a map of key-value pairs.
+// This is used by the provider registry to create instances from
+// configuration that was already parsed from environment variables.
+//
+// Required keys: CREATE_URL, DELETE_URL, LIST_URL
+// Optional keys: CREATE_METHOD, DELETE_METHOD, AUTH_HEADER, AUTH_TOKEN,
+// INSECURE_SKIP_VERIFY (alias SKIP_TLS_VERIFY)
+func LoadConfigFromMap(instanceName string, configMap map[string]string) (*Config, error) {
+	config := &Config{
+		CreateURL:    configMap["CREATE_URL"],
+		CreateMethod: DefaultCreateMethod,
+		DeleteURL:    configMap["DELETE_URL"],
+		DeleteMethod: DefaultDeleteMethod,
+		ListURL:      configMap["LIST_URL"],
+		AuthHeader:   configMap["AUTH_HEADER"],
+		AuthToken:    configMap["AUTH_TOKEN"],
+	}
+
+	if v := configMap["CREATE_METHOD"]; v != "" {
+		config.CreateMethod = strings.ToUpper(v)
+	}
+	if v := configMap["DELETE_METHOD"]; v != "" {
+		config.DeleteMethod = strings.ToUpper(v)
+	}
+
+	// SKIP_TLS_VERIFY is accepted as an alias for INSECURE_SKIP_VERIFY
+	for _, key := range []string{"INSECURE_SKIP_VERIFY", "SKIP_TLS_VERIFY"} {
+		if v := configMap[key]; v != "" {
+			config.InsecureSkipVerify = strings.EqualFold(v, "true") || v == "1"
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
+	}
+
+	return config, nil
+}
+
+// envPrefix converts an instance name to an environment variable prefix.
+// Example: "pfsense" → "DNSWEAVER_PFSENSE_"
+func envPrefix(instanceName string) string {
+	normalized := strings.ToUpper(instanceName)
+	normalized = strings.ReplaceAll(normalized, "-", "_")
+	return "DNSWEAVER_" + normalized + "_"
+}
+
+// getEnv retrieves an environment variable value.
+func getEnv(key string) string {
+	return os.Getenv(key)
+}
+
+// getEnvOrFile retrieves a value from either a direct environment variable
+// or a file path specified by the file key (Docker secrets pattern).
+//
+// If both are set, the file takes precedence.
+// The file contents are trimmed of leading/trailing whitespace.
+func getEnvOrFile(directKey, fileKey string) string {
+	// Check for file-based secret first (Docker secrets pattern)
+	if filePath := os.Getenv(fileKey); filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+		// If file read fails, fall through to direct value
+	}
+
+	return os.Getenv(directKey)
+}
@@ -0,0 +1,101 @@
+package httpprovider
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigFromMap(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		c, err := LoadConfigFromMap("pfsense", map[string]string{
+			"CREATE_URL": "https://fw/api/hosts?name={hostname}&ip={target}",
+			"DELETE_URL": "https://fw/api/hosts/{hostname}",
+			"LIST_URL":   "https://fw/api/hosts",
+		})
+		if err != nil {
+			t.Fatalf("LoadConfigFromMap() unexpected error: %v", err)
+		}
+		if c.CreateMethod != http.MethodPost {
+			t.Errorf("CreateMethod = %q, want POST", c.CreateMethod)
+		}
+		if c.DeleteMethod != http.MethodDelete {
+			t.Errorf("DeleteMethod = %q, want DELETE", c.DeleteMethod)
+		}
+	})
+
+	t.Run("methods are case-insensitive", func(t *testing.T) {
+		c, err := LoadConfigFromMap("pfsense", map[string]string{
+			"CREATE_URL":    "https://fw/create",
+			"CREATE_METHOD": "put",
+			"DELETE_URL":    "https://fw/delete",
+			"DELETE_METHOD": "post",
+			"LIST_URL":      "https://fw/list",
+		})
+		if err != nil {
+			t.Fatalf("LoadConfigFromMap() unexpected error: %v", err)
+		}
+		if c.CreateMethod != http.MethodPut || c.DeleteMethod != http.MethodPost {
+			t.Errorf("methods = %q/%q, want PUT/POST", c.CreateMethod, c.DeleteMethod)
+		}
+	})
+
+	t.Run("missing required", func(t *testing.T) {
+		_, err := LoadConfigFromMap("pfsense", map[string]string{})
+		if err == nil {
+			t.Fatal("LoadConfigFromMap() expected error")
+		}
+		for _, want := range []string{"CREATE_URL is required", "DELETE_URL is required", "LIST_URL is required"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error %q missing %q", err, want)
+			}
+		}
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		_, err := LoadConfigFromMap("pfsense", map[string]string{
+			"CREATE_URL":    "https://fw/create",
+			"CREATE_METHOD": "FETCH",
+			"DELETE_URL":    "https://fw/delete",
+			"LIST_URL":      "https://fw/list",
+		})
+		if err == nil || !strings.Contains(err.Error(), "CREATE_METHOD") {
+			t.Errorf("LoadConfigFromMap() error = %v, want CREATE_METHOD error", err)
+		}
+	})
+
+	t.Run("auth header requires token", func(t *testing.T) {
+		_, err := LoadConfigFromMap("pfsense", map[string]string{
+			"CREATE_URL":  "https://fw/create",
+			"DELETE_URL":  "https://fw/delete",
+			"LIST_URL":    "https://fw/list",
+			"AUTH_HEADER": "X-API-Key",
+		})
+		if err == nil || !strings.Contains(err.Error(), "AUTH_TOKEN") {
+			t.Errorf("LoadConfigFromMap() error = %v, want AUTH_TOKEN error", err)
+		}
+	})
+}
+
+func TestLoadConfig_FromEnv(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("DNSWEAVER_PFSENSE_CREATE_URL", "https://fw/create")
+	t.Setenv("DNSWEAVER_PFSENSE_DELETE_URL", "https://fw/delete")
+	t.Setenv("DNSWEAVER_PFSENSE_LIST_URL", "https://fw/list")
+	t.Setenv("DNSWEAVER_PFSENSE_AUTH_HEADER", "Authorization")
+	t.Setenv("DNSWEAVER_PFSENSE_AUTH_TOKEN_FILE", tokenFile)
+
+	c, err := LoadConfig("pfsense")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	if c.AuthToken != "from-file" {
+		t.Errorf("AuthToken = %q, want from-file", c.AuthToken)
+	}
+}
@@ -0,0 +1,196 @@
+package httpprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Provider implements provider.Provider for user-defined HTTP APIs.
+type Provider struct {
+	name       string
+	client     *Client
+	httpClient *http.Client // Custom HTTP client (optional)
+	logger     *slog.Logger
+}
+
+// ProviderOption is a functional option for configuring the Provider.
+type ProviderOption func(*Provider)
+
+// WithProviderLogger sets a custom logger for the provider.
+func WithProviderLogger(logger *slog.Logger) ProviderOption {
+	return func(p *Provider) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// WithProviderHTTPClient sets a custom HTTP client for API requests.
+func WithProviderHTTPClient(client *http.Client) ProviderOption {
+	return func(p *Provider) {
+		p.httpClient = client
+	}
+}
+
+// New creates a new HTTP provider instance.
+func New(name string, config *Config, opts ...ProviderOption) (*Provider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		name:   name,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.client = NewClient(config,
+		WithHTTPClient(p.httpClient),
+		WithLogger(p.logger),
+	)
+
+	return p, nil
+}
+
+// NewFromEnv creates a new HTTP provider from environment variables.
+// This is a convenience function for use with the provider registry.
+func NewFromEnv(instanceName string, opts ...ProviderOption) (*Provider, error) {
+	config, err := LoadConfig(instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(instanceName, config, opts...)
+}
+
+// Name returns the provider instance name.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Type returns "http".
+func (p *Provider) Type() string {
+	return "http"
+}
+
+// Capabilities returns the provider's feature support.
+// The URL placeholders cannot express SRV fields, so SRV is not supported.
+func (p *Provider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SupportsOwnershipTXT:    true,
+		SupportsNativeUpdate:    false,
+		SupportsMultipleTargets: true,
+		SupportedRecordTypes: []provider.RecordType{
+			provider.RecordTypeA,
+			provider.RecordTypeAAAA,
+			provider.RecordTypeCNAME,
+			provider.RecordTypeTXT,
+		},
+	}
+}
+
+// Ping checks connectivity by fetching LIST_URL.
+func (p *Provider) Ping(ctx context.Context) error {
+	if _, err := p.client.List(ctx); err != nil {
+		return fmt.Errorf("ping failed: %w", err)
+	}
+	return nil
+}
+
+// List returns all records from LIST_URL. Records of unsupported types are skipped.
+func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
+	payloads, err := p.client.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing records: %w", err)
+	}
+
+	caps := p.Capabilities()
+	records := make([]provider.Record, 0, len(payloads))
+	for _, r := range payloads {
+		recordType := provider.RecordType(strings.ToUpper(r.Type))
+		if !caps.SupportsRecordType(recordType) {
+			continue
+		}
+		records = append(records, provider.Record{
+			Hostname: strings.ToLower(strings.TrimSuffix(r.Hostname, ".")),
+			Type:     recordType,
+			Target:   r.target(),
+			TTL:      r.TTL,
+		})
+	}
+
+	p.logger.Debug("listed records",
+		slog.String("provider", p.name),
+		slog.Int("count", len(records)),
+	)
+
+	return records, nil
+}
+
+// Create adds a DNS record by calling CREATE_URL.
+func (p *Provider) Create(ctx context.Context, record provider.Record) error {
+	if !p.Capabilities().SupportsRecordType(record.Type) {
+		return fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+
+	if err := p.client.Create(ctx, payloadFor(record)); err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("created record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+		slog.Int("ttl", record.TTL),
+	)
+
+	return nil
+}
+
+// Delete removes a DNS record by calling DELETE_URL.
+// A 404 response is treated as the record already being gone.
+func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
+	err := p.client.Delete(ctx, payloadFor(record))
+	if errors.Is(err, provider.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("deleted record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+	)
+
+	return nil
+}
+
+// payloadFor converts a record to the request payload.
+func payloadFor(record provider.Record) RecordPayload {
+	return RecordPayload{
+		Hostname: record.Hostname,
+		Type:     string(record.Type),
+		Target:   record.Target,
+		TTL:      record.TTL,
+	}
+}
+
+// Ensure Provider implements provider.Provider at compile time.
+var _ provider.Provider = (*Provider)(nil)
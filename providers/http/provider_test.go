@@ -0,0 +1,182 @@
+package httpprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+func TestExpandURL(t *testing.T) {
+	r := RecordPayload{Hostname: "app.example.com", Type: "TXT", Target: "heritage=dnsweaver,owner=a b", TTL: 300}
+
+	got := expandURL("https://fw/api?host={hostname}&type={type}&value={target}&ttl={ttl}", r)
+	want := "https://fw/api?host=app.example.com&type=TXT&value=heritage%3Ddnsweaver%2Cowner%3Da%20b&ttl=300"
+	if got != want {
+		t.Errorf("expandURL() = %q, want %q", got, want)
+	}
+}
+
+// request is a single call received by the fake server.
+type request struct {
+	method string
+	path   string
+	query  string
+	body   RecordPayload
+}
+
+// fakeServer records requests and serves a fixed list response.
+type fakeServer struct {
+	mu       sync.Mutex
+	list     []RecordPayload
+	requests []request
+	status   int // status for create/delete requests (default 200)
+}
+
+func (f *fakeServer) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if r.URL.Path == "/list" {
+			_ = json.NewEncoder(w).Encode(f.list)
+			return
+		}
+
+		req := request{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery}
+		if r.ContentLength > 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req.body); err != nil {
+				t.Errorf("decoding body: %v", err)
+			}
+		}
+		f.requests = append(f.requests, req)
+		if f.status != 0 {
+			w.WriteHeader(f.status)
+		}
+	})
+}
+
+func newTestProvider(t *testing.T, fake *fakeServer) *Provider {
+	t.Helper()
+	server := httptest.NewServer(fake.handler(t))
+	t.Cleanup(server.Close)
+
+	p, err := New("custom", &Config{
+		CreateURL:    server.URL + "/hosts?name={hostname}&ip={target}",
+		CreateMethod: http.MethodPost,
+		DeleteURL:    server.URL + "/hosts/{hostname}/{type}",
+		DeleteMethod: http.MethodDelete,
+		ListURL:      server.URL + "/list",
+		AuthHeader:   "X-API-Key",
+		AuthToken:    "test-key",
+	})
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestProvider_Ping(t *testing.T) {
+	p := newTestProvider(t, &fakeServer{})
+	if err := p.Ping(context.Background()); err != nil {
+		t.Errorf("Ping() unexpected error: %v", err)
+	}
+
+	p.client.config.AuthToken = "wrong"
+	if err := p.Ping(context.Background()); !errors.Is(err, provider.ErrUnauthorized) {
+		t.Errorf("Ping() with bad token error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestProvider_List(t *testing.T) {
+	fake := &fakeServer{list: []RecordPayload{
+		{Hostname: "App.example.com.", Type: "a", Target: "10.0.0.1", TTL: 60},
+		{Hostname: "_dnsweaver.app.example.com", Type: "TXT", Value: "heritage=dnsweaver"},
+		{Hostname: "mail.example.com", Type: "MX", Target: "10 mx.example.com"},
+	}}
+	p := newTestProvider(t, fake)
+
+	records, err := p.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("List() returned %d records, want 2 (MX skipped): %+v", len(records), records)
+	}
+	if a := records[0]; a.Hostname != "app.example.com" || a.Type != provider.RecordTypeA || a.Target != "10.0.0.1" || a.TTL != 60 {
+		t.Errorf("unexpected A record: %+v", a)
+	}
+	if txt := records[1]; txt.Target != "heritage=dnsweaver" {
+		t.Errorf("TXT target = %q, want value fallback", txt.Target)
+	}
+}
+
+func TestProvider_CreateAndDelete(t *testing.T) {
+	fake := &fakeServer{}
+	p := newTestProvider(t, fake)
+	ctx := context.Background()
+
+	record := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1", TTL: 300}
+
+	if err := p.Create(ctx, record); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if err := p.Delete(ctx, record); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+
+	if len(fake.requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(fake.requests))
+	}
+	create := fake.requests[0]
+	if create.method != http.MethodPost || create.path != "/hosts" || create.query != "name=app.example.com&ip=10.0.0.1" {
+		t.Errorf("create request = %+v", create)
+	}
+	if create.body.Hostname != "app.example.com" || create.body.Target != "10.0.0.1" || create.body.TTL != 300 {
+		t.Errorf("create body = %+v", create.body)
+	}
+	del := fake.requests[1]
+	if del.method != http.MethodDelete || del.path != "/hosts/app.example.com/A" {
+		t.Errorf("delete request = %+v", del)
+	}
+	if del.body != (RecordPayload{}) {
+		t.Errorf("DELETE request should have no body, got %+v", del.body)
+	}
+}
+
+func TestProvider_StatusMapping(t *testing.T) {
+	fake := &fakeServer{}
+	p := newTestProvider(t, fake)
+	ctx := context.Background()
+	record := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"}
+
+	fake.status = http.StatusConflict
+	if err := p.Create(ctx, record); !errors.Is(err, provider.ErrConflict) {
+		t.Errorf("Create() on 409 error = %v, want ErrConflict", err)
+	}
+
+	fake.status = http.StatusNotFound
+	if err := p.Delete(ctx, record); err != nil {
+		t.Errorf("Delete() on 404 error = %v, want nil", err)
+	}
+
+	fake.status = http.StatusInternalServerError
+	if err := p.Delete(ctx, record); err == nil {
+		t.Error("Delete() on 500 expected error")
+	}
+
+	srv := provider.Record{Hostname: "_sip._tcp.example.com", Type: provider.RecordTypeSRV, Target: "sip.example.com"}
+	if err := p.Create(ctx, srv); err == nil {
+		t.Error("Create() SRV expected error")
+	}
+}
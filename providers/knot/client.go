@@ -0,0 +1,324 @@
+package knot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/sshutil"
+)
+
+// CommandRunner executes a command given as its argument list and returns
+// its output. A non-zero exit code is reported in the result, not as an error.
+type CommandRunner interface {
+	Run(ctx context.Context, args []string) (*sshutil.CommandResult, error)
+}
+
+// knotRecord is a resource record as printed by knotc zone-read.
+type knotRecord struct {
+	Owner string
+	TTL   int
+	Type  string
+	RData string
+}
+
+// Client manages records in a Knot DNS zone by running knotc against the
+// server's control socket.
+type Client struct {
+	zone   string
+	socket string
+	knotc  string
+	runner CommandRunner
+	logger *slog.Logger
+
+	// mu serializes zone transactions; Knot allows one open transaction per zone
+	mu sync.Mutex
+}
+
+// ClientOption is a functional option for configuring the Client.
+type ClientOption func(*Client)
+
+// WithLogger sets a custom logger for the client.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithRunner sets the command runner (for testing).
+func WithRunner(runner CommandRunner) ClientOption {
+	return func(c *Client) {
+		c.runner = runner
+	}
+}
+
+// NewClient creates a new Knot DNS client. Unless a runner is supplied via
+// WithRunner, knotc runs over SSH when SSH is configured and locally otherwise.
+func NewClient(config *Config, opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		zone:   config.Zone,
+		socket: config.Socket,
+		knotc:  config.Knotc,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.runner == nil {
+		if config.IsSSHEnabled() {
+			runner, err := newSSHRunner(config, c.logger)
+			if err != nil {
+				return nil, err
+			}
+			c.runner = runner
+		} else {
+			c.runner = localRunner{}
+		}
+	}
+
+	return c, nil
+}
+
+// Close releases the underlying transport, if it holds one.
+func (c *Client) Close() error {
+	if closer, ok := c.runner.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Ping checks that the zone is loaded by the Knot server.
+func (c *Client) Ping(ctx context.Context) error {
+	if _, err := c.knotcRun(ctx, "zone-status", c.zoneName()); err != nil {
+		return fmt.Errorf("checking zone %s: %w", c.zone, err)
+	}
+	return nil
+}
+
+// List returns all records in the zone as printed by knotc zone-read.
+func (c *Client) List(ctx context.Context) ([]knotRecord, error) {
+	out, err := c.knotcRun(ctx, "zone-read", c.zoneName())
+	if err != nil {
+		return nil, err
+	}
+	return parseZoneRead(out)
+}
+
+// Set adds a record to the zone in its own transaction.
+// Adding a record that already exists returns provider.ErrConflict.
+func (c *Client) Set(ctx context.Context, owner string, ttl int, rrType provider.RecordType, rdata string) error {
+	return c.transaction(ctx, func() error {
+		_, err := c.knotcRun(ctx, "zone-set", c.zoneName(), canonical(owner), strconv.Itoa(ttl), string(rrType), rdata)
+		return err
+	})
+}
+
+// Unset removes a record from the zone in its own transaction.
+// Removing a record that does not exist is not an error.
+func (c *Client) Unset(ctx context.Context, owner string, rrType provider.RecordType, rdata string) error {
+	err := c.transaction(ctx, func() error {
+		_, err := c.knotcRun(ctx, "zone-unset", c.zoneName(), canonical(owner), string(rrType), rdata)
+		return err
+	})
+	if errors.Is(err, provider.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// transaction runs fn inside a zone-begin/zone-commit transaction and
+// aborts the transaction when fn or the commit fails.
+func (c *Client) transaction(ctx context.Context, fn func() error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.knotcRun(ctx, "zone-begin", c.zoneName()); err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	err := fn()
+	if err == nil {
+		if _, err = c.knotcRun(ctx, "zone-commit", c.zoneName()); err != nil {
+			err = fmt.Errorf("committing transaction: %w", err)
+		}
+	}
+	if err != nil {
+		if _, abortErr := c.knotcRun(ctx, "zone-abort", c.zoneName()); abortErr != nil {
+			c.logger.Warn("failed to abort knot transaction",
+				slog.String("zone", c.zone),
+				slog.String("error", abortErr.Error()),
+			)
+		}
+		return err
+	}
+	return nil
+}
+
+// knotcRun runs a knotc command against the control socket and returns its
+// stdout. Knot's "no such record" and "already exists" errors are mapped to
+// provider.ErrNotFound and provider.ErrConflict.
+func (c *Client) knotcRun(ctx context.Context, args ...string) (string, error) {
+	argv := append([]string{c.knotc, "-s", c.socket}, args...)
+
+	result, err := c.runner.Run(ctx, argv)
+	if err != nil {
+		return "", err
+	}
+
+	if result.ExitCode != 0 {
+		msg := strings.TrimSpace(result.Stderr)
+		if msg == "" {
+			msg = strings.TrimSpace(result.Stdout)
+		}
+		switch lower := strings.ToLower(msg); {
+		case strings.Contains(lower, "no such record"):
+			return "", fmt.Errorf("knotc %s: %s: %w", args[0], msg, provider.ErrNotFound)
+		case strings.Contains(lower, "already exists"):
+			return "", fmt.Errorf("knotc %s: %s: %w", args[0], msg, provider.ErrConflict)
+		}
+		return "", fmt.Errorf("knotc %s exited with code %d: %s", args[0], result.ExitCode, msg)
+	}
+	return result.Stdout, nil
+}
+
+// zoneName returns the zone as an absolute name for knotc.
+func (c *Client) zoneName() string {
+	return c.zone + "."
+}
+
+// parseZoneRead parses knotc zone-read output. Each line has the form
+//
+//	[example.com.] www.example.com. 300 A 192.0.2.1
+func parseZoneRead(out string) ([]knotRecord, error) {
+	var records []knotRecord
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if _, rest, ok := strings.Cut(line, "] "); ok {
+				line = rest
+			}
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("unexpected zone-read line %q", line)
+		}
+		ttl, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid TTL in zone-read line %q", line)
+		}
+
+		// Keep the rdata as printed; TXT strings may contain repeated spaces
+		rdata := line
+		for range 3 {
+			rdata = strings.TrimLeft(rdata, " \t")
+			rdata = rdata[strings.IndexAny(rdata, " \t"):]
+		}
+
+		records = append(records, knotRecord{
+			Owner: strings.TrimSuffix(strings.ToLower(fields[0]), "."),
+			TTL:   ttl,
+			Type:  strings.ToUpper(fields[2]),
+			RData: strings.TrimSpace(rdata),
+		})
+	}
+	return records, nil
+}
+
+// shellQuote returns args as a POSIX shell command line.
+func shellQuote(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'"'"'`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// sshRunner runs knotc on the Knot host over SSH, connecting on first use.
+type sshRunner struct {
+	addr   string
+	client *sshutil.Client
+	runner *sshutil.SSHCommandRunner
+
+	mu sync.Mutex
+}
+
+// newSSHRunner creates an sshRunner for the configured SSH host.
+func newSSHRunner(config *Config, logger *slog.Logger) (*sshRunner, error) {
+	client, err := sshutil.NewClient(&sshutil.Config{
+		Host:           config.SSHHost,
+		Port:           config.SSHPort,
+		User:           config.SSHUser,
+		KeyFile:        config.SSHKeyFile,
+		Password:       config.SSHPassword,
+		KnownHostsFile: config.KnownHostsFile,
+		ProxyJump:      config.SSHProxyJump,
+	}, sshutil.WithLogger(logger))
+	if err != nil {
+		return nil, fmt.Errorf("creating SSH client: %w", err)
+	}
+
+	return &sshRunner{
+		addr:   net.JoinHostPort(config.SSHHost, strconv.Itoa(config.SSHPort)),
+		client: client,
+		runner: sshutil.NewSSHCommandRunner(client, sshutil.WithCommandLogger(logger)),
+	}, nil
+}
+
+// Run connects if needed and runs args on the Knot host.
+func (r *sshRunner) Run(ctx context.Context, args []string) (*sshutil.CommandResult, error) {
+	r.mu.Lock()
+	if !r.client.IsConnected() {
+		if err := r.client.Connect(ctx); err != nil && !errors.Is(err, sshutil.ErrAlreadyConnected) {
+			r.mu.Unlock()
+			return nil, fmt.Errorf("connecting to %s: %w", r.addr, err)
+		}
+	}
+	r.mu.Unlock()
+
+	return r.runner.RunWithOutput(ctx, shellQuote(args))
+}
+
+// Close closes the SSH connection.
+func (r *sshRunner) Close() error {
+	return r.client.Close()
+}
+
+// localRunner runs knotc on the local machine.
+type localRunner struct{}
+
+// Run runs args and returns the result.
+func (localRunner) Run(ctx context.Context, args []string) (*sshutil.CommandResult, error) {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	result := &sshutil.CommandResult{}
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("running %s: %w", args[0], err)
+		}
+		result.ExitCode = exitErr.ExitCode()
+	}
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+	return result, nil
+}
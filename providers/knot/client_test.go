@@ -0,0 +1,146 @@
+package knot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/sshutil"
+)
+
+// fakeRunner records the knotc commands it is asked to run. Results are
+// looked up by knotc subcommand; unknown subcommands succeed with no output.
+type fakeRunner struct {
+	commands [][]string
+	results  map[string]*sshutil.CommandResult
+	err      error
+}
+
+func (f *fakeRunner) Run(_ context.Context, args []string) (*sshutil.CommandResult, error) {
+	f.commands = append(f.commands, args)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if len(args) > 3 {
+		if result, ok := f.results[args[3]]; ok {
+			return result, nil
+		}
+	}
+	return &sshutil.CommandResult{}, nil
+}
+
+// subcommands returns the knotc subcommands that were run, in order.
+func (f *fakeRunner) subcommands() []string {
+	var subs []string
+	for _, args := range f.commands {
+		subs = append(subs, args[3])
+	}
+	return subs
+}
+
+func newTestClient(t *testing.T, runner *fakeRunner) *Client {
+	t.Helper()
+	c, err := NewClient(&Config{
+		Zone:   "example.com",
+		Socket: "/run/knot/knot.sock",
+		Knotc:  "knotc",
+	}, WithRunner(runner))
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	return c
+}
+
+func TestParseZoneRead(t *testing.T) {
+	out := `[example.com.] example.com. 3600 SOA ns1.example.com. hostmaster.example.com. 1 3600 900 604800 300
+[example.com.] App.Example.com. 300 A 192.0.2.10
+[example.com.] txt.example.com. 60 TXT "hello  world" "again"
+`
+	records, err := parseZoneRead(out)
+	if err != nil {
+		t.Fatalf("parseZoneRead() unexpected error: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("parseZoneRead() returned %d records, want 3", len(records))
+	}
+
+	want := knotRecord{Owner: "app.example.com", TTL: 300, Type: "A", RData: "192.0.2.10"}
+	if records[1] != want {
+		t.Errorf("records[1] = %+v, want %+v", records[1], want)
+	}
+	if records[2].RData != `"hello  world" "again"` {
+		t.Errorf("TXT rdata = %q, want repeated spaces preserved", records[2].RData)
+	}
+
+	if _, err := parseZoneRead("[example.com.] broken 300\n"); err == nil {
+		t.Error("parseZoneRead() with short line expected error")
+	}
+}
+
+func TestClient_Set(t *testing.T) {
+	runner := &fakeRunner{}
+	c := newTestClient(t, runner)
+
+	if err := c.Set(context.Background(), "App.example.com", 300, provider.RecordTypeA, "192.0.2.10"); err != nil {
+		t.Fatalf("Set() unexpected error: %v", err)
+	}
+
+	if got := strings.Join(runner.subcommands(), ","); got != "zone-begin,zone-set,zone-commit" {
+		t.Errorf("subcommands = %s, want zone-begin,zone-set,zone-commit", got)
+	}
+	want := "knotc -s /run/knot/knot.sock zone-set example.com. app.example.com. 300 A 192.0.2.10"
+	if got := strings.Join(runner.commands[1], " "); got != want {
+		t.Errorf("zone-set command = %q, want %q", got, want)
+	}
+}
+
+func TestClient_Set_ConflictAborts(t *testing.T) {
+	runner := &fakeRunner{results: map[string]*sshutil.CommandResult{
+		"zone-set": {ExitCode: 1, Stderr: "error: (such record already exists in zone)"},
+	}}
+	c := newTestClient(t, runner)
+
+	err := c.Set(context.Background(), "app.example.com", 300, provider.RecordTypeA, "192.0.2.10")
+	if !errors.Is(err, provider.ErrConflict) {
+		t.Fatalf("Set() error = %v, want ErrConflict", err)
+	}
+	if got := strings.Join(runner.subcommands(), ","); got != "zone-begin,zone-set,zone-abort" {
+		t.Errorf("subcommands = %s, want zone-begin,zone-set,zone-abort", got)
+	}
+}
+
+func TestClient_Unset_NotFound(t *testing.T) {
+	runner := &fakeRunner{results: map[string]*sshutil.CommandResult{
+		"zone-unset": {ExitCode: 1, Stderr: "error: (no such record in zone found)"},
+	}}
+	c := newTestClient(t, runner)
+
+	if err := c.Unset(context.Background(), "gone.example.com", provider.RecordTypeA, "192.0.2.10"); err != nil {
+		t.Errorf("Unset() of missing record should succeed, got %v", err)
+	}
+	if got := strings.Join(runner.subcommands(), ","); got != "zone-begin,zone-unset,zone-abort" {
+		t.Errorf("subcommands = %s, want zone-begin,zone-unset,zone-abort", got)
+	}
+}
+
+func TestClient_Ping_Error(t *testing.T) {
+	runner := &fakeRunner{results: map[string]*sshutil.CommandResult{
+		"zone-status": {ExitCode: 1, Stderr: "error: [example.com.] (no such zone found)"},
+	}}
+	c := newTestClient(t, runner)
+
+	err := c.Ping(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "no such zone") {
+		t.Errorf("Ping() error = %v, want containing 'no such zone'", err)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	got := shellQuote([]string{"knotc", "zone-set", `"it's"`})
+	want := `'knotc' 'zone-set' '"it'"'"'s"'`
+	if got != want {
+		t.Errorf("shellQuote() = %s, want %s", got, want)
+	}
+}
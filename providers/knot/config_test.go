@@ -0,0 +1,124 @@
+package knot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigFromMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		configMap map[string]string
+		wantErr   string
+		check     func(t *testing.T, c *Config)
+	}{
+		{
+			name:      "defaults",
+			configMap: map[string]string{"ZONE": "Example.COM."},
+			check: func(t *testing.T, c *Config) {
+				if c.Zone != "example.com" {
+					t.Errorf("Zone = %q, want example.com", c.Zone)
+				}
+				if c.Socket != DefaultSocket {
+					t.Errorf("Socket = %q, want %q", c.Socket, DefaultSocket)
+				}
+				if c.Knotc != DefaultKnotc {
+					t.Errorf("Knotc = %q, want %q", c.Knotc, DefaultKnotc)
+				}
+				if c.TTL != DefaultTTL {
+					t.Errorf("TTL = %d, want %d", c.TTL, DefaultTTL)
+				}
+				if c.IsSSHEnabled() {
+					t.Error("IsSSHEnabled() = true, want false")
+				}
+			},
+		},
+		{
+			name: "ssh with default port",
+			configMap: map[string]string{
+				"ZONE":         "example.com",
+				"SOCKET":       "/var/run/knot/knot.sock",
+				"TTL":          "60",
+				"SSH_HOST":     "ns1.example.com",
+				"SSH_USER":     "knot",
+				"SSH_KEY_FILE": "/run/secrets/knot_key",
+			},
+			check: func(t *testing.T, c *Config) {
+				if !c.IsSSHEnabled() {
+					t.Error("IsSSHEnabled() = false, want true")
+				}
+				if c.SSHPort != 22 {
+					t.Errorf("SSHPort = %d, want 22", c.SSHPort)
+				}
+				if c.Socket != "/var/run/knot/knot.sock" {
+					t.Errorf("Socket = %q, want /var/run/knot/knot.sock", c.Socket)
+				}
+				if c.TTL != 60 {
+					t.Errorf("TTL = %d, want 60", c.TTL)
+				}
+			},
+		},
+		{
+			name:      "missing zone",
+			configMap: map[string]string{},
+			wantErr:   "ZONE is required",
+		},
+		{
+			name:      "invalid TTL",
+			configMap: map[string]string{"ZONE": "example.com", "TTL": "soon"},
+			wantErr:   "invalid TTL",
+		},
+		{
+			name:      "ssh without user or credentials",
+			configMap: map[string]string{"ZONE": "example.com", "SSH_HOST": "ns1"},
+			wantErr:   "SSH_USER is required",
+		},
+		{
+			name: "invalid ssh port",
+			configMap: map[string]string{
+				"ZONE":         "example.com",
+				"SSH_HOST":     "ns1",
+				"SSH_USER":     "knot",
+				"SSH_PASSWORD": "secret",
+				"SSH_PORT":     "70000",
+			},
+			wantErr: "SSH_PORT must be between 1 and 65535",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := LoadConfigFromMap("knot", tt.configMap)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfigFromMap() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfigFromMap() unexpected error: %v", err)
+			}
+			tt.check(t, c)
+		})
+	}
+}
+
+func TestLoadConfig_Env(t *testing.T) {
+	t.Setenv("DNSWEAVER_KNOT_INTERNAL_ZONE", "internal.example.com")
+	t.Setenv("DNSWEAVER_KNOT_INTERNAL_SOCKET", "/tmp/knot.sock")
+	t.Setenv("DNSWEAVER_KNOT_INTERNAL_SSH_HOST", "ns1")
+	t.Setenv("DNSWEAVER_KNOT_INTERNAL_SSH_PORT", "2222")
+	t.Setenv("DNSWEAVER_KNOT_INTERNAL_SSH_USER", "knot")
+	t.Setenv("DNSWEAVER_KNOT_INTERNAL_SSH_PASSWORD", "secret")
+
+	c, err := LoadConfig("knot-internal")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	if c.Zone != "internal.example.com" || c.Socket != "/tmp/knot.sock" {
+		t.Errorf("Zone/Socket = %q/%q, want internal.example.com//tmp/knot.sock", c.Zone, c.Socket)
+	}
+	if c.SSHPort != 2222 || c.SSHUser != "knot" || c.SSHPassword != "secret" {
+		t.Errorf("SSH config = %d/%q/%q, want 2222/knot/secret", c.SSHPort, c.SSHUser, c.SSHPassword)
+	}
+}
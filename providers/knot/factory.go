@@ -0,0 +1,16 @@
+package knot
+
+import (
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Factory returns a provider.Factory for creating Knot DNS provider instances.
+// This is the recommended way to register the knot provider with the registry.
+//
+// Note: knot runs knotc locally or over SSH and does not use HTTP clients,
+// so the HTTP configuration from FactoryConfig is not used.
+func Factory() provider.Factory {
+	return func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		return NewFromMap(cfg.Name, cfg.ProviderConfig)
+	}
+}
@@ -0,0 +1,242 @@
+// Package knot implements the DNSWeaver provider interface for Knot DNS
+// using the knotc control utility.
+package knot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Provider implements provider.Provider for Knot DNS.
+type Provider struct {
+	name   string
+	zone   string
+	ttl    int
+	client *Client
+	logger *slog.Logger
+}
+
+// ProviderOption is a functional option for configuring the Provider.
+type ProviderOption func(*Provider)
+
+// WithProviderLogger sets a custom logger for the provider.
+func WithProviderLogger(logger *slog.Logger) ProviderOption {
+	return func(p *Provider) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// WithClient sets a custom client (for testing).
+func WithClient(client *Client) ProviderOption {
+	return func(p *Provider) {
+		p.client = client
+	}
+}
+
+// New creates a new Knot DNS provider instance.
+func New(name string, config *Config, opts ...ProviderOption) (*Provider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		name:   name,
+		zone:   config.Zone,
+		ttl:    config.TTL,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// Create client if not provided via options (testing)
+	if p.client == nil {
+		client, err := NewClient(config, WithLogger(p.logger))
+		if err != nil {
+			return nil, err
+		}
+		p.client = client
+	}
+
+	return p, nil
+}
+
+// NewFromEnv creates a new Knot DNS provider from environment variables.
+// This is a convenience function for use with the provider registry.
+func NewFromEnv(instanceName string, opts ...ProviderOption) (*Provider, error) {
+	config, err := LoadConfig(instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(instanceName, config, opts...)
+}
+
+// NewFromMap creates a new Knot DNS provider from a configuration map.
+// This is used by the provider registry Factory pattern.
+func NewFromMap(name string, config map[string]string) (*Provider, error) {
+	cfg, err := LoadConfigFromMap(name, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(name, cfg)
+}
+
+// Name returns the provider instance name.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Type returns "knot".
+func (p *Provider) Type() string {
+	return "knot"
+}
+
+// Capabilities returns the provider's feature support.
+// Knot stores TXT records and several records per name; changes are made
+// with zone-set/zone-unset, so there is no in-place update.
+func (p *Provider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SupportsOwnershipTXT:    true,
+		SupportsNativeUpdate:    false,
+		SupportsMultipleTargets: true,
+		SupportedRecordTypes: []provider.RecordType{
+			provider.RecordTypeA,
+			provider.RecordTypeAAAA,
+			provider.RecordTypeCNAME,
+			provider.RecordTypeTXT,
+			provider.RecordTypeSRV,
+		},
+	}
+}
+
+// Zone returns the configured DNS zone.
+func (p *Provider) Zone() string {
+	return p.zone
+}
+
+// Ping checks that the zone is loaded by the Knot server.
+func (p *Provider) Ping(ctx context.Context) error {
+	return p.client.Ping(ctx)
+}
+
+// Close closes the SSH connection to the Knot host, if any.
+func (p *Provider) Close() error {
+	return p.client.Close()
+}
+
+// List returns all A, AAAA, CNAME, TXT, and SRV records in the zone.
+func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
+	knotRecords, err := p.client.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing records: %w", err)
+	}
+
+	records := make([]provider.Record, 0, len(knotRecords))
+	for _, r := range knotRecords {
+		rec := provider.Record{
+			Hostname: r.Owner,
+			Type:     provider.RecordType(r.Type),
+			TTL:      r.TTL,
+		}
+		if !p.Capabilities().SupportsRecordType(rec.Type) {
+			continue
+		}
+		if err := decodeRData(&rec, r.RData); err != nil {
+			p.logger.Warn("skipping unparseable record",
+				slog.String("provider", p.name),
+				slog.String("hostname", r.Owner),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		rec.ProviderID = fmt.Sprintf("%s:%s:%s", rec.Hostname, rec.Type, rec.Target)
+		records = append(records, rec)
+	}
+
+	p.logger.Debug("listed records",
+		slog.String("provider", p.name),
+		slog.Int("count", len(records)),
+	)
+
+	return records, nil
+}
+
+// Create adds a new DNS record to the zone.
+func (p *Provider) Create(ctx context.Context, record provider.Record) error {
+	if err := p.checkZone(record.Hostname); err != nil {
+		return err
+	}
+
+	rdata, err := encodeRData(record)
+	if err != nil {
+		return err
+	}
+
+	ttl := record.TTL
+	if ttl <= 0 {
+		ttl = p.ttl
+	}
+
+	if err := p.client.Set(ctx, record.Hostname, ttl, record.Type, rdata); err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("created record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+		slog.Int("ttl", ttl),
+	)
+
+	return nil
+}
+
+// Delete removes a DNS record from the zone.
+func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
+	if err := p.checkZone(record.Hostname); err != nil {
+		return err
+	}
+
+	rdata, err := encodeRData(record)
+	if err != nil {
+		return err
+	}
+
+	if err := p.client.Unset(ctx, record.Hostname, record.Type, rdata); err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("deleted record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+	)
+
+	return nil
+}
+
+// checkZone returns an error when hostname is not in the configured zone.
+func (p *Provider) checkZone(hostname string) error {
+	host := strings.TrimSuffix(strings.ToLower(hostname), ".")
+	if host == p.zone || strings.HasSuffix(host, "."+p.zone) {
+		return nil
+	}
+	return fmt.Errorf("hostname %s is not in zone %s", hostname, p.zone)
+}
+
+// Ensure Provider implements provider.Provider at compile time.
+var _ provider.Provider = (*Provider)(nil)
@@ -0,0 +1,127 @@
+package knot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/sshutil"
+)
+
+func newTestProvider(t *testing.T, runner *fakeRunner) *Provider {
+	t.Helper()
+	config := &Config{
+		Zone:   "example.com",
+		Socket: DefaultSocket,
+		Knotc:  DefaultKnotc,
+		TTL:    300,
+	}
+	client, err := NewClient(config, WithRunner(runner))
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	p, err := New("knot", config, WithClient(client))
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("knot", nil); err == nil {
+		t.Error("New() with nil config expected error")
+	}
+	if _, err := New("knot", &Config{Socket: DefaultSocket, Knotc: DefaultKnotc}); err == nil {
+		t.Error("New() with invalid config expected error")
+	}
+}
+
+func TestProvider_List(t *testing.T) {
+	runner := &fakeRunner{results: map[string]*sshutil.CommandResult{
+		"zone-read": {Stdout: `[example.com.] example.com. 3600 SOA ns1.example.com. hostmaster.example.com. 1 3600 900 604800 300
+[example.com.] example.com. 3600 NS ns1.example.com.
+[example.com.] app.example.com. 300 A 192.0.2.10
+[example.com.] alias.example.com. 300 CNAME app.example.com.
+[example.com.] _http._tcp.example.com. 60 SRV 10 5 80 app.example.com.
+[example.com.] _dnsweaver.app.example.com. 300 TXT "heritage=dnsweaver"
+`},
+	}}
+	p := newTestProvider(t, runner)
+
+	records, err := p.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("List() returned %d records, want 4 (SOA and NS skipped)", len(records))
+	}
+
+	if records[0].Hostname != "app.example.com" || records[0].Target != "192.0.2.10" {
+		t.Errorf("records[0] = %+v, want app.example.com A 192.0.2.10", records[0])
+	}
+	if records[1].Target != "app.example.com" {
+		t.Errorf("CNAME target = %q, want trailing dot trimmed", records[1].Target)
+	}
+	if records[2].SRV == nil || records[2].SRV.Port != 80 {
+		t.Errorf("SRV data = %+v, want port 80", records[2].SRV)
+	}
+	if records[3].Target != "heritage=dnsweaver" {
+		t.Errorf("TXT target = %q, want unquoted", records[3].Target)
+	}
+}
+
+func TestProvider_Create(t *testing.T) {
+	runner := &fakeRunner{}
+	p := newTestProvider(t, runner)
+
+	err := p.Create(context.Background(), provider.Record{
+		Hostname: "app.example.com",
+		Type:     provider.RecordTypeTXT,
+		Target:   "heritage=dnsweaver",
+	})
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+
+	want := `knotc -s /run/knot/knot.sock zone-set example.com. app.example.com. 300 TXT "heritage=dnsweaver"`
+	if got := strings.Join(runner.commands[1], " "); got != want {
+		t.Errorf("zone-set command = %q, want %q", got, want)
+	}
+}
+
+func TestProvider_Create_OutsideZone(t *testing.T) {
+	runner := &fakeRunner{}
+	p := newTestProvider(t, runner)
+
+	err := p.Create(context.Background(), provider.Record{
+		Hostname: "app.other.com",
+		Type:     provider.RecordTypeA,
+		Target:   "192.0.2.10",
+	})
+	if err == nil || !strings.Contains(err.Error(), "not in zone") {
+		t.Errorf("Create() error = %v, want 'not in zone'", err)
+	}
+	if len(runner.commands) != 0 {
+		t.Errorf("expected no knotc commands, got %d", len(runner.commands))
+	}
+}
+
+func TestProvider_Delete(t *testing.T) {
+	runner := &fakeRunner{}
+	p := newTestProvider(t, runner)
+
+	err := p.Delete(context.Background(), provider.Record{
+		Hostname: "alias.example.com",
+		Type:     provider.RecordTypeCNAME,
+		Target:   "app.example.com",
+	})
+	if err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+
+	want := "knotc -s /run/knot/knot.sock zone-unset example.com. alias.example.com. CNAME app.example.com."
+	if got := strings.Join(runner.commands[1], " "); got != want {
+		t.Errorf("zone-unset command = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,130 @@
+package knot
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// maxTXTString is the longest character-string allowed in a TXT record.
+const maxTXTString = 255
+
+// encodeRData converts a record to the zone-file rdata passed to knotc.
+func encodeRData(record provider.Record) (string, error) {
+	switch record.Type {
+	case provider.RecordTypeA, provider.RecordTypeAAAA:
+		ip := net.ParseIP(record.Target)
+		if ip == nil {
+			return "", fmt.Errorf("invalid IP address %q", record.Target)
+		}
+		return ip.String(), nil
+	case provider.RecordTypeCNAME:
+		return canonical(record.Target), nil
+	case provider.RecordTypeTXT:
+		return quoteTXT(record.Target), nil
+	case provider.RecordTypeSRV:
+		if record.SRV == nil {
+			return "", fmt.Errorf("SRV data is required")
+		}
+		return fmt.Sprintf("%d %d %d %s", record.SRV.Priority, record.SRV.Weight, record.SRV.Port, canonical(record.Target)), nil
+	default:
+		return "", fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+}
+
+// decodeRData fills Target (and SRV) of record from rdata printed by knotc.
+func decodeRData(record *provider.Record, rdata string) error {
+	switch record.Type {
+	case provider.RecordTypeA, provider.RecordTypeAAAA:
+		record.Target = rdata
+	case provider.RecordTypeCNAME:
+		record.Target = strings.TrimSuffix(rdata, ".")
+	case provider.RecordTypeTXT:
+		record.Target = unquoteTXT(rdata)
+	case provider.RecordTypeSRV:
+		fields := strings.Fields(rdata)
+		if len(fields) != 4 {
+			return fmt.Errorf("invalid SRV rdata %q", rdata)
+		}
+		var nums [3]uint16
+		for i := range nums {
+			n, err := strconv.ParseUint(fields[i], 10, 16)
+			if err != nil {
+				return fmt.Errorf("invalid SRV rdata %q", rdata)
+			}
+			nums[i] = uint16(n)
+		}
+		record.SRV = &provider.SRVData{Priority: nums[0], Weight: nums[1], Port: nums[2]}
+		record.Target = strings.TrimSuffix(fields[3], ".")
+	default:
+		return fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+	return nil
+}
+
+// canonical returns name as an absolute domain name with a trailing dot.
+func canonical(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".") + "."
+}
+
+// quoteTXT encodes text as one or more quoted character-strings,
+// splitting at maxTXTString bytes.
+func quoteTXT(text string) string {
+	var parts []string
+	for {
+		chunk := text
+		if len(chunk) > maxTXTString {
+			chunk = chunk[:maxTXTString]
+		}
+		escaped := strings.ReplaceAll(chunk, `\`, `\\`)
+		escaped = strings.ReplaceAll(escaped, `"`, `\"`)
+		parts = append(parts, `"`+escaped+`"`)
+		text = text[len(chunk):]
+		if text == "" {
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// unquoteTXT decodes quoted character-strings and concatenates them.
+// Knot prints non-printable bytes as \DDD decimal escapes, which are decoded.
+// Content without quotes is returned as is.
+func unquoteTXT(content string) string {
+	if !strings.HasPrefix(content, `"`) {
+		return content
+	}
+
+	var b strings.Builder
+	inQuote := false
+	for i := 0; i < len(content); i++ {
+		ch := content[i]
+		switch {
+		case ch == '"':
+			inQuote = !inQuote
+		case ch == '\\' && inQuote && i+3 < len(content) && isDigits(content[i+1:i+4]):
+			n, _ := strconv.Atoi(content[i+1 : i+4])
+			b.WriteByte(byte(n))
+			i += 3
+		case ch == '\\' && inQuote && i+1 < len(content):
+			i++
+			b.WriteByte(content[i])
+		case inQuote:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+// isDigits reports whether s consists only of ASCII digits.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package knot
+
+import (
+	"strings"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+func TestEncodeRData(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  provider.Record
+		want    string
+		wantErr bool
+	}{
+		{"A", provider.Record{Type: provider.RecordTypeA, Target: "192.0.2.1"}, "192.0.2.1", false},
+		{"AAAA", provider.Record{Type: provider.RecordTypeAAAA, Target: "2001:DB8::1"}, "2001:db8::1", false},
+		{"invalid IP", provider.Record{Type: provider.RecordTypeA, Target: "nope"}, "", true},
+		{"CNAME", provider.Record{Type: provider.RecordTypeCNAME, Target: "Proxy.example.com"}, "proxy.example.com.", false},
+		{"TXT", provider.Record{Type: provider.RecordTypeTXT, Target: `say "hi"`}, `"say \"hi\""`, false},
+		{
+			"SRV",
+			provider.Record{Type: provider.RecordTypeSRV, Target: "web.example.com", SRV: &provider.SRVData{Priority: 10, Weight: 5, Port: 443}},
+			"10 5 443 web.example.com.", false,
+		},
+		{"SRV without data", provider.Record{Type: provider.RecordTypeSRV, Target: "web.example.com"}, "", true},
+		{"unsupported", provider.Record{Type: provider.RecordTypeMX, Target: "10 mail.example.com"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeRData(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("encodeRData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("encodeRData() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeRData_SRV(t *testing.T) {
+	rec := provider.Record{Type: provider.RecordTypeSRV}
+	if err := decodeRData(&rec, "10 5 443 web.example.com."); err != nil {
+		t.Fatalf("decodeRData() unexpected error: %v", err)
+	}
+	if rec.Target != "web.example.com" {
+		t.Errorf("Target = %q, want web.example.com", rec.Target)
+	}
+	if rec.SRV == nil || rec.SRV.Priority != 10 || rec.SRV.Weight != 5 || rec.SRV.Port != 443 {
+		t.Errorf("SRV = %+v, want 10/5/443", rec.SRV)
+	}
+
+	if err := decodeRData(&rec, "10 5 web.example.com."); err == nil {
+		t.Error("decodeRData() with short SRV rdata expected error")
+	}
+}
+
+func TestTXTRoundTrip(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	quoted := quoteTXT(long)
+	if !strings.HasPrefix(quoted, `"`+strings.Repeat("a", 255)+`" "`) {
+		t.Errorf("quoteTXT() did not split at 255 bytes: %s", quoted)
+	}
+	if got := unquoteTXT(quoted); got != long {
+		t.Errorf("unquoteTXT(quoteTXT()) length = %d, want %d", len(got), len(long))
+	}
+
+	if got := unquoteTXT(`"caf\195\169 \"ok\""`); got != `café "ok"` {
+		t.Errorf("unquoteTXT() = %q, want %q", got, `café "ok"`)
+	}
+}
@@ -122,6 +122,8 @@ func (c *Config) ConfigFilePath() string {
 // API mode settings:
 //   - URL: Pi-hole admin URL (e.g., "http://pihole.local")
 //   - PASSWORD: Admin password (supports _FILE suffix for Docker secrets)
+//   - API_ENDPOINT: Pi-hole v6 URL; when set, forces API mode with the v6 API
+//   - API_PASSWORD: Password for API_ENDPOINT (supports _FILE suffix)
 //
 // File mode settings:
 //   - CONFIG_DIR: Directory for config files (default: /etc/pihole)
@@ -158,6 +160,12 @@ func LoadConfig(instanceName string) (*Config, error) {
 		config.TTL = ttl
 	}
 
+	apiEndpoint := getEnv(prefix + "API_ENDPOINT")
+	apiPassword := getEnvOrFile(prefix+"API_PASSWORD", prefix+"API_PASSWORD_FILE")
+	if err := config.applyAPIEndpoint(apiEndpoint, apiPassword, getEnv(prefix+"MODE")); err != nil {
+		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
+	}
+
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
 	}
@@ -173,6 +181,8 @@ func LoadConfig(instanceName string) (*Config, error) {
 //   - mode: "api" or "file"
 //   - url: Pi-hole admin URL (API mode)
 //   - password: Admin password (API mode)
+//   - api_endpoint: Pi-hole v6 URL (forces API mode with the v6 API)
+//   - api_password: Password for api_endpoint
 //   - config_dir: Config directory (file mode)
 //   - config_file: Config filename (file mode)
 //   - reload_command: Reload command (file mode)
@@ -203,6 +213,12 @@ func LoadConfigFromMap(name string, m map[string]string) (*Config, error) {
 		config.TTL = ttl
 	}
 
+	apiEndpoint := getMapValue(m, "api_endpoint")
+	apiPassword := getMapValue(m, "api_password")
+	if err := config.applyAPIEndpoint(apiEndpoint, apiPassword, getMapValue(m, "mode")); err != nil {
+		return nil, fmt.Errorf("configuration for %s: %w", name, err)
+	}
+
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration for %s: %w", name, err)
 	}
@@ -210,6 +226,27 @@ func LoadConfigFromMap(name string, m map[string]string) (*Config, error) {
 	return config, nil
 }
 
+// applyAPIEndpoint switches the config to the Pi-hole v6 REST API when an
+// API endpoint is configured. The endpoint and password take precedence over
+// URL and PASSWORD. Without an endpoint the config is left unchanged.
+// Returns an error if MODE was explicitly set to "file".
+func (c *Config) applyAPIEndpoint(endpoint, password, explicitMode string) error {
+	if endpoint == "" {
+		return nil
+	}
+	if Mode(strings.ToLower(explicitMode)) == ModeFile {
+		return fmt.Errorf("API_ENDPOINT cannot be used with MODE=file")
+	}
+
+	c.Mode = ModeAPI
+	c.URL = endpoint
+	if password != "" {
+		c.Password = password
+	}
+	c.APIVersion = "v6"
+	return nil
+}
+
 // Helper functions for loading configuration
 
 // envPrefix returns the environment variable prefix for a provider instance.
@@ -166,6 +166,80 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestLoadConfigFromMap_APIEndpoint(t *testing.T) {
+	cfg, err := LoadConfigFromMap("test", map[string]string{
+		"API_ENDPOINT": "http://pihole.local",
+		"API_PASSWORD": "secret",
+	})
+	if err != nil {
+		t.Fatalf("LoadConfigFromMap() error = %v", err)
+	}
+	if cfg.Mode != ModeAPI {
+		t.Errorf("Mode = %v, want %v", cfg.Mode, ModeAPI)
+	}
+	if cfg.URL != "http://pihole.local" || cfg.Password != "secret" {
+		t.Errorf("URL/Password = %q/%q, want endpoint and API password", cfg.URL, cfg.Password)
+	}
+	if cfg.APIVersion != "v6" {
+		t.Errorf("APIVersion = %q, want v6", cfg.APIVersion)
+	}
+
+	_, err = LoadConfigFromMap("test", map[string]string{
+		"mode":         "file",
+		"api_endpoint": "http://pihole.local",
+		"api_password": "secret",
+	})
+	if err == nil {
+		t.Error("expected error when API_ENDPOINT is combined with MODE=file")
+	}
+}
+
+func TestLoadConfig_APIEndpoint(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := dir + "/password"
+	if err := os.WriteFile(passwordFile, []byte("secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("DNSWEAVER_TEST_API_ENDPOINT", "http://pihole.local")
+	t.Setenv("DNSWEAVER_TEST_API_PASSWORD_FILE", passwordFile)
+
+	cfg, err := LoadConfig("test")
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Mode != ModeAPI || cfg.URL != "http://pihole.local" || cfg.Password != "secret" {
+		t.Errorf("unexpected config: mode=%v url=%q password=%q", cfg.Mode, cfg.URL, cfg.Password)
+	}
+}
+
+func TestNew_WithAPIMode(t *testing.T) {
+	fileCfg := &Config{
+		Mode:          ModeFile,
+		ConfigDir:     t.TempDir(),
+		ConfigFile:    DefaultConfigFile,
+		ReloadCommand: DefaultReloadCommand,
+		TTL:           DefaultTTL,
+	}
+
+	p, err := New("test", fileCfg, WithAPIMode("http://pihole.local", "secret"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if p.mode != ModeAPI {
+		t.Errorf("mode = %v, want %v", p.mode, ModeAPI)
+	}
+	if p.apiVersion != APIVersionV6 {
+		t.Errorf("apiVersion = %v, want v6", p.apiVersion)
+	}
+	if _, ok := p.dnsClient.(*V6APIClient); !ok {
+		t.Errorf("dnsClient = %T, want *V6APIClient", p.dnsClient)
+	}
+	if fileCfg.Mode != ModeFile {
+		t.Error("WithAPIMode must not modify the caller's config")
+	}
+}
+
 func TestEnvPrefix(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -30,6 +30,10 @@ type Provider struct {
 
 	// File mode provider (wraps dnsmasq)
 	fileProvider *dnsmasq.Provider
+
+	// API endpoint override set by WithAPIMode
+	apiEndpoint string
+	apiPassword string
 }
 
 // ProviderOption is a functional option for configuring the Provider.
@@ -56,6 +60,15 @@ func WithProviderHTTPClient(client *http.Client) ProviderOption {
 	}
 }
 
+// WithAPIMode uses the Pi-hole v6 REST API at endpoint instead of the
+// configured mode, regardless of the MODE, URL, and PASSWORD settings.
+func WithAPIMode(endpoint, password string) ProviderOption {
+	return func(p *Provider) {
+		p.apiEndpoint = endpoint
+		p.apiPassword = password
+	}
+}
+
 // WithAPIClient sets a custom API client (for testing).
 // The client must implement the DNSClient interface.
 func WithAPIClient(client DNSClient) ProviderOption {
@@ -77,15 +90,10 @@ func New(name string, config *Config, opts ...ProviderOption) (*Provider, error)
 		return nil, fmt.Errorf("config is required")
 	}
 
-	if err := config.Validate(); err != nil {
-		return nil, err
-	}
-
 	p := &Provider{
 		name:   name,
 		zone:   config.Zone,
 		ttl:    config.TTL,
-		mode:   config.Mode,
 		logger: slog.Default(),
 	}
 
@@ -93,6 +101,21 @@ func New(name string, config *Config, opts ...ProviderOption) (*Provider, error)
 		opt(p)
 	}
 
+	// WithAPIMode overrides the configured mode without mutating the caller's config
+	if p.apiEndpoint != "" {
+		override := *config
+		override.Mode = ModeAPI
+		override.URL = p.apiEndpoint
+		override.Password = p.apiPassword
+		override.APIVersion = "v6"
+		config = &override
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	p.mode = config.Mode
+
 	// Initialize the appropriate client based on mode
 	switch config.Mode {
 	case ModeAPI:
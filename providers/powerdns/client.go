@@ -0,0 +1,235 @@
+package powerdns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Changetypes for PATCH requests.
+const (
+	changeReplace = "REPLACE"
+	changeDelete  = "DELETE"
+)
+
+// rrset is a PowerDNS resource record set: all records sharing a name and type.
+type rrset struct {
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	TTL        int         `json:"ttl,omitempty"`
+	ChangeType string      `json:"changetype,omitempty"`
+	Records    []rrContent `json:"records"`
+}
+
+// rrContent is a single record within an RRset, in zone-file presentation format.
+type rrContent struct {
+	Content  string `json:"content"`
+	Disabled bool   `json:"disabled"`
+}
+
+// zoneResponse is the subset of the zone object returned by GET .../zones/{zone}.
+type zoneResponse struct {
+	Name   string  `json:"name"`
+	RRsets []rrset `json:"rrsets"`
+}
+
+// errorResponse is the error body returned by the PowerDNS API.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Client is a PowerDNS Authoritative Server API client.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	serverID   string
+	zone       string // Canonical zone name with trailing dot
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// ClientOption is a functional option for configuring the Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// NewClient creates a new PowerDNS API client for a single zone.
+func NewClient(baseURL, apiKey, serverID, zone string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     apiKey,
+		serverID:   serverID,
+		zone:       canonical(zone),
+		httpClient: httputil.DefaultClient(),
+		logger:     slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// serverPath returns the API path of the configured server.
+func (c *Client) serverPath() string {
+	return "/api/v1/servers/" + url.PathEscape(c.serverID)
+}
+
+// zonePath returns the API path of the configured zone.
+func (c *Client) zonePath() string {
+	return c.serverPath() + "/zones/" + url.PathEscape(c.zone)
+}
+
+// doRequest performs an HTTP request against the PowerDNS API and decodes
+// a JSON response into out (if non-nil).
+func (c *Client) doRequest(ctx context.Context, method, path string, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	c.logger.Debug("making API request",
+		slog.String("method", method),
+		slog.String("path", path),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("X-API-Key", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail := strings.TrimSpace(string(respBody))
+		var apiErr errorResponse
+		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Error != "" {
+			detail = apiErr.Error
+		}
+		if base := provider.ErrorForStatus(resp.StatusCode); base != nil {
+			return fmt.Errorf("%w: status %d: %s", base, resp.StatusCode, detail)
+		}
+		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, detail)
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("parsing response JSON: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Ping checks connectivity and credentials by fetching the server object.
+func (c *Client) Ping(ctx context.Context) error {
+	if err := c.doRequest(ctx, http.MethodGet, c.serverPath(), nil, nil); err != nil {
+		return fmt.Errorf("ping failed: %w", err)
+	}
+	return nil
+}
+
+// ListRRsets returns all RRsets in the zone.
+func (c *Client) ListRRsets(ctx context.Context) ([]rrset, error) {
+	var zone zoneResponse
+	if err := c.doRequest(ctx, http.MethodGet, c.zonePath(), nil, &zone); err != nil {
+		return nil, fmt.Errorf("getting zone %s: %w", c.zone, err)
+	}
+	return zone.RRsets, nil
+}
+
+// GetRRset returns the RRset with the given name and type, or nil if it does not exist.
+func (c *Client) GetRRset(ctx context.Context, name, rrType string) (*rrset, error) {
+	sets, err := c.ListRRsets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name = canonical(name)
+	for i := range sets {
+		if strings.EqualFold(sets[i].Name, name) && sets[i].Type == rrType {
+			return &sets[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// PatchRRsets applies RRset changes to the zone in a single request.
+// Each RRset must have ChangeType set.
+func (c *Client) PatchRRsets(ctx context.Context, sets ...rrset) error {
+	body := struct {
+		RRsets []rrset `json:"rrsets"`
+	}{RRsets: sets}
+
+	if err := c.doRequest(ctx, http.MethodPatch, c.zonePath(), body, nil); err != nil {
+		return fmt.Errorf("patching zone %s: %w", c.zone, err)
+	}
+	return nil
+}
+
+// ReplaceRRset replaces the RRset name/type with the given records and TTL.
+// If records is empty, the RRset is deleted.
+func (c *Client) ReplaceRRset(ctx context.Context, name, rrType string, ttl int, records []rrContent) error {
+	set := rrset{
+		Name:    canonical(name),
+		Type:    rrType,
+		Records: records,
+	}
+	if len(records) == 0 {
+		set.ChangeType = changeDelete
+		set.Records = []rrContent{}
+	} else {
+		set.ChangeType = changeReplace
+		set.TTL = ttl
+	}
+	return c.PatchRRsets(ctx, set)
+}
+
+// canonical returns name in lowercase with a trailing dot, as PowerDNS expects.
+func canonical(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+}
@@ -0,0 +1,147 @@
+// Package powerdns implements the DNSWeaver provider interface for the
+// PowerDNS Authoritative Server HTTP API.
+package powerdns
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultTTL is the default TTL for PowerDNS records.
+const DefaultTTL = 300
+
+// DefaultServerID is the server ID used by a standalone PowerDNS server.
+const DefaultServerID = "localhost"
+
+// Config holds PowerDNS-specific configuration.
+type Config struct {
+	URL      string // API base URL (e.g., http://pdns:8081)
+	APIKey   string // API key sent as X-API-Key
+	ServerID string // Server ID in /api/v1/servers/{server_id} (default: localhost)
+	Zone     string // Zone to manage (e.g., "example.com")
+	TTL      int    // Default record TTL
+
+	InsecureSkipVerify bool // Skip TLS certificate verification
+}
+
+// Validate checks that all required configuration is present.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.URL == "" {
+		errs = append(errs, "URL is required")
+	}
+	if c.APIKey == "" {
+		errs = append(errs, "API_KEY is required")
+	}
+	if c.Zone == "" {
+		errs = append(errs, "ZONE is required")
+	}
+	if c.TTL < 0 {
+		errs = append(errs, "TTL must be non-negative")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("powerdns config validation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// LoadConfig loads PowerDNS configuration from environment variables.
+// Environment variable pattern: DNSWEAVER_{INSTANCE_NAME}_{SETTING}
+//
+// Instance names are normalized: lowercase with hyphens becomes uppercase with underscores.
+// Example: "pdns" looks for DNSWEAVER_PDNS_*
+//
+// Supported settings:
+//   - URL: API base URL (required)
+//   - API_KEY: API key (required, supports _FILE suffix for Docker secrets)
+//   - SERVER_ID: Server ID (optional, default: localhost)
+//   - ZONE: Zone to manage (required)
+//   - TTL: Record TTL (optional, default: 300)
+//   - INSECURE_SKIP_VERIFY: Skip TLS verification (optional)
+func LoadConfig(instanceName string) (*Config, error) {
+	prefix := envPrefix(instanceName)
+
+	return LoadConfigFromMap(instanceName, map[string]string{
+		"URL":                  getEnv(prefix + "URL"),
+		"API_KEY":              getEnvOrFile(prefix+"API_KEY", prefix+"API_KEY_FILE"),
+		"SERVER_ID":            getEnv(prefix + "SERVER_ID"),
+		"ZONE":                 getEnv(prefix + "ZONE"),
+		"TTL":                  getEnv(prefix + "TTL"),
+		"INSECURE_SKIP_VERIFY": getEnv(prefix + "INSECURE_SKIP_VERIFY"),
+	})
+}
+
+// LoadConfigFromMap creates a Config from a map of key-value pairs.
+// This is used by the provider registry to create instances from
+// configuration that was already parsed from environment variables.
+//
+// Required keys: URL, API_KEY, ZONE
+// Optional keys: SERVER_ID, TTL, INSECURE_SKIP_VERIFY
+func LoadConfigFromMap(instanceName string, configMap map[string]string) (*Config, error) {
+	config := &Config{
+		URL:      strings.TrimSuffix(configMap["URL"], "/"),
+		APIKey:   configMap["API_KEY"],
+		ServerID: configMap["SERVER_ID"],
+		Zone:     strings.TrimSuffix(strings.ToLower(configMap["ZONE"]), "."),
+		TTL:      DefaultTTL,
+	}
+	if config.ServerID == "" {
+		config.ServerID = DefaultServerID
+	}
+
+	// Parse optional TTL
+	if ttlStr, ok := configMap["TTL"]; ok && ttlStr != "" {
+		ttl, err := strconv.Atoi(ttlStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TTL value %q: %w", ttlStr, err)
+		}
+		config.TTL = ttl
+	}
+
+	// Parse optional InsecureSkipVerify
+	if skipStr, ok := configMap["INSECURE_SKIP_VERIFY"]; ok && skipStr != "" {
+		config.InsecureSkipVerify = strings.EqualFold(skipStr, "true") || skipStr == "1"
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
+	}
+
+	return config, nil
+}
+
+// envPrefix converts an instance name to an environment variable prefix.
+// Example: "pdns" → "DNSWEAVER_PDNS_"
+func envPrefix(instanceName string) string {
+	normalized := strings.ToUpper(instanceName)
+	normalized = strings.ReplaceAll(normalized, "-", "_")
+	return "DNSWEAVER_" + normalized + "_"
+}
+
+// getEnv retrieves an environment variable value.
+func getEnv(key string) string {
+	return os.Getenv(key)
+}
+
+// getEnvOrFile retrieves a value from either a direct environment variable
+// or a file path specified by the file key (Docker secrets pattern).
+//
+// If both are set, the file takes precedence.
+// The file contents are trimmed of leading/trailing whitespace.
+func getEnvOrFile(directKey, fileKey string) string {
+	// Check for file-based secret first (Docker secrets pattern)
+	if filePath := os.Getenv(fileKey); filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+		// If file read fails, fall through to direct value
+	}
+
+	return os.Getenv(directKey)
+}
@@ -0,0 +1,77 @@
+package powerdns
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigFromMap(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		c, err := LoadConfigFromMap("pdns", map[string]string{
+			"URL":     "http://pdns:8081/",
+			"API_KEY": "key",
+			"ZONE":    "Example.com.",
+		})
+		if err != nil {
+			t.Fatalf("LoadConfigFromMap() unexpected error: %v", err)
+		}
+		if c.ServerID != DefaultServerID {
+			t.Errorf("ServerID = %q, want %q", c.ServerID, DefaultServerID)
+		}
+		if c.URL != "http://pdns:8081" {
+			t.Errorf("URL = %q, want trailing slash trimmed", c.URL)
+		}
+		if c.Zone != "example.com" {
+			t.Errorf("Zone = %q, want example.com", c.Zone)
+		}
+		if c.TTL != DefaultTTL {
+			t.Errorf("TTL = %d, want %d", c.TTL, DefaultTTL)
+		}
+	})
+
+	t.Run("missing required", func(t *testing.T) {
+		_, err := LoadConfigFromMap("pdns", map[string]string{})
+		if err == nil {
+			t.Fatal("LoadConfigFromMap() expected error")
+		}
+		for _, want := range []string{"URL is required", "API_KEY is required", "ZONE is required"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error %q missing %q", err, want)
+			}
+		}
+	})
+
+	t.Run("invalid TTL", func(t *testing.T) {
+		_, err := LoadConfigFromMap("pdns", map[string]string{
+			"URL": "http://pdns:8081", "API_KEY": "key", "ZONE": "example.com", "TTL": "soon",
+		})
+		if err == nil {
+			t.Fatal("LoadConfigFromMap() expected error for invalid TTL")
+		}
+	})
+}
+
+func TestLoadConfig_FromEnv(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "api_key")
+	if err := os.WriteFile(keyFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("DNSWEAVER_PDNS_URL", "https://pdns.example.com")
+	t.Setenv("DNSWEAVER_PDNS_API_KEY_FILE", keyFile)
+	t.Setenv("DNSWEAVER_PDNS_SERVER_ID", "primary")
+	t.Setenv("DNSWEAVER_PDNS_ZONE", "example.com")
+
+	c, err := LoadConfig("pdns")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	if c.APIKey != "from-file" {
+		t.Errorf("APIKey = %q, want from-file", c.APIKey)
+	}
+	if c.ServerID != "primary" {
+		t.Errorf("ServerID = %q, want primary", c.ServerID)
+	}
+}
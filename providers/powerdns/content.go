@@ -0,0 +1,125 @@
+package powerdns
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// maxTXTString is the longest character-string allowed in a TXT record.
+const maxTXTString = 255
+
+// encodeContent converts a record to PowerDNS presentation-format content.
+func encodeContent(record provider.Record) (string, error) {
+	switch record.Type {
+	case provider.RecordTypeA, provider.RecordTypeAAAA:
+		ip := net.ParseIP(record.Target)
+		if ip == nil {
+			return "", fmt.Errorf("invalid IP address %q", record.Target)
+		}
+		return ip.String(), nil
+	case provider.RecordTypeCNAME:
+		return canonical(record.Target), nil
+	case provider.RecordTypeTXT:
+		return quoteTXT(record.Target), nil
+	case provider.RecordTypeSRV:
+		if record.SRV == nil {
+			return "", fmt.Errorf("SRV data is required")
+		}
+		return fmt.Sprintf("%d %d %d %s", record.SRV.Priority, record.SRV.Weight, record.SRV.Port, canonical(record.Target)), nil
+	case provider.RecordTypeMX:
+		pref, exchange, ok := strings.Cut(strings.TrimSpace(record.Target), " ")
+		if !ok {
+			return "", fmt.Errorf("MX target must be \"<preference> <exchange>\", got %q", record.Target)
+		}
+		if _, err := strconv.ParseUint(pref, 10, 16); err != nil {
+			return "", fmt.Errorf("invalid MX preference %q", pref)
+		}
+		return pref + " " + canonical(strings.TrimSpace(exchange)), nil
+	default:
+		return "", fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+}
+
+// decodeContent fills Target (and SRV) of record from PowerDNS content.
+func decodeContent(record *provider.Record, content string) error {
+	switch record.Type {
+	case provider.RecordTypeA, provider.RecordTypeAAAA:
+		record.Target = content
+	case provider.RecordTypeCNAME:
+		record.Target = strings.TrimSuffix(content, ".")
+	case provider.RecordTypeTXT:
+		record.Target = unquoteTXT(content)
+	case provider.RecordTypeSRV:
+		fields := strings.Fields(content)
+		if len(fields) != 4 {
+			return fmt.Errorf("invalid SRV content %q", content)
+		}
+		var nums [3]uint16
+		for i := range nums {
+			n, err := strconv.ParseUint(fields[i], 10, 16)
+			if err != nil {
+				return fmt.Errorf("invalid SRV content %q", content)
+			}
+			nums[i] = uint16(n)
+		}
+		record.SRV = &provider.SRVData{Priority: nums[0], Weight: nums[1], Port: nums[2]}
+		record.Target = strings.TrimSuffix(fields[3], ".")
+	case provider.RecordTypeMX:
+		fields := strings.Fields(content)
+		if len(fields) != 2 {
+			return fmt.Errorf("invalid MX content %q", content)
+		}
+		record.Target = fields[0] + " " + strings.TrimSuffix(fields[1], ".")
+	default:
+		return fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+	return nil
+}
+
+// quoteTXT encodes text as one or more quoted character-strings,
+// splitting at maxTXTString bytes.
+func quoteTXT(text string) string {
+	var parts []string
+	for {
+		chunk := text
+		if len(chunk) > maxTXTString {
+			chunk = chunk[:maxTXTString]
+		}
+		escaped := strings.ReplaceAll(chunk, `\`, `\\`)
+		escaped = strings.ReplaceAll(escaped, `"`, `\"`)
+		parts = append(parts, `"`+escaped+`"`)
+		text = text[len(chunk):]
+		if text == "" {
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// unquoteTXT decodes quoted character-strings and concatenates them.
+// Content without quotes is returned as is.
+func unquoteTXT(content string) string {
+	if !strings.HasPrefix(content, `"`) {
+		return content
+	}
+
+	var b strings.Builder
+	inQuote := false
+	for i := 0; i < len(content); i++ {
+		ch := content[i]
+		switch {
+		case ch == '"':
+			inQuote = !inQuote
+		case ch == '\\' && inQuote && i+1 < len(content):
+			i++
+			b.WriteByte(content[i])
+		case inQuote:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package powerdns
+
+import (
+	"strings"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+func TestContent_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  provider.Record
+		content string
+	}{
+		{
+			name:    "A",
+			record:  provider.Record{Type: provider.RecordTypeA, Target: "10.0.0.1"},
+			content: "10.0.0.1",
+		},
+		{
+			name:    "AAAA normalized",
+			record:  provider.Record{Type: provider.RecordTypeAAAA, Target: "2001:db8::1"},
+			content: "2001:db8::1",
+		},
+		{
+			name:    "CNAME",
+			record:  provider.Record{Type: provider.RecordTypeCNAME, Target: "Target.Example.com"},
+			content: "target.example.com.",
+		},
+		{
+			name:    "TXT",
+			record:  provider.Record{Type: provider.RecordTypeTXT, Target: `a "quoted" \ value`},
+			content: `"a \"quoted\" \\ value"`,
+		},
+		{
+			name: "SRV",
+			record: provider.Record{Type: provider.RecordTypeSRV, Target: "sip.example.com",
+				SRV: &provider.SRVData{Priority: 10, Weight: 5, Port: 5060}},
+			content: "10 5 5060 sip.example.com.",
+		},
+		{
+			name:    "MX",
+			record:  provider.Record{Type: provider.RecordTypeMX, Target: "10 mail.example.com"},
+			content: "10 mail.example.com.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeContent(tt.record)
+			if err != nil {
+				t.Fatalf("encodeContent() unexpected error: %v", err)
+			}
+			if got != tt.content {
+				t.Errorf("encodeContent() = %q, want %q", got, tt.content)
+			}
+
+			decoded := provider.Record{Type: tt.record.Type}
+			if err := decodeContent(&decoded, got); err != nil {
+				t.Fatalf("decodeContent() unexpected error: %v", err)
+			}
+			if !strings.EqualFold(decoded.Target, tt.record.Target) {
+				t.Errorf("decodeContent() target = %q, want %q", decoded.Target, tt.record.Target)
+			}
+		})
+	}
+}
+
+func TestEncodeContent_Invalid(t *testing.T) {
+	invalid := []provider.Record{
+		{Type: provider.RecordTypeA, Target: "not-an-ip"},
+		{Type: provider.RecordTypeSRV, Target: "sip.example.com"},
+		{Type: provider.RecordTypeMX, Target: "mail.example.com"},
+		{Type: provider.RecordTypeMX, Target: "high mail.example.com"},
+		{Type: "PTR", Target: "host.example.com"},
+	}
+	for _, r := range invalid {
+		if _, err := encodeContent(r); err == nil {
+			t.Errorf("encodeContent(%+v) expected error", r)
+		}
+	}
+}
+
+func TestQuoteTXT_SplitsLongValues(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	quoted := quoteTXT(long)
+	if strings.Count(quoted, `"`) != 4 {
+		t.Errorf("quoteTXT() should split into two strings, got %q", quoted)
+	}
+	if got := unquoteTXT(quoted); got != long {
+		t.Errorf("unquoteTXT() did not restore the value (len %d)", len(got))
+	}
+}
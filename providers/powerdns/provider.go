@@ -0,0 +1,325 @@
+// Package powerdns implements the DNSWeaver provider interface for the
+// PowerDNS Authoritative Server HTTP API.
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Provider implements provider.Provider for PowerDNS.
+//
+// PowerDNS manages records as RRsets (all records sharing a name and type),
+// so Create and Delete read the current RRset and write it back with the
+// record added or removed. The TTL is shared by every record in an RRset.
+type Provider struct {
+	name       string
+	zone       string
+	ttl        int
+	client     *Client
+	httpClient *http.Client // Custom HTTP client (optional)
+	logger     *slog.Logger
+}
+
+// ProviderOption is a functional option for configuring the Provider.
+type ProviderOption func(*Provider)
+
+// WithProviderLogger sets a custom logger for the provider.
+func WithProviderLogger(logger *slog.Logger) ProviderOption {
+	return func(p *Provider) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// WithProviderHTTPClient sets a custom HTTP client for API requests.
+func WithProviderHTTPClient(client *http.Client) ProviderOption {
+	return func(p *Provider) {
+		p.httpClient = client
+	}
+}
+
+// New creates a new PowerDNS provider instance.
+func New(name string, config *Config, opts ...ProviderOption) (*Provider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		name:   name,
+		zone:   strings.TrimSuffix(strings.ToLower(config.Zone), "."),
+		ttl:    config.TTL,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.client = NewClient(config.URL, config.APIKey, config.ServerID, config.Zone,
+		WithHTTPClient(p.httpClient),
+		WithLogger(p.logger),
+	)
+
+	return p, nil
+}
+
+// NewFromEnv creates a new PowerDNS provider from environment variables.
+// This is a convenience function for use with the provider registry.
+func NewFromEnv(instanceName string, opts ...ProviderOption) (*Provider, error) {
+	config, err := LoadConfig(instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(instanceName, config, opts...)
+}
+
+// Name returns the provider instance name.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Type returns "powerdns".
+func (p *Provider) Type() string {
+	return "powerdns"
+}
+
+// Capabilities returns the provider's feature support.
+func (p *Provider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SupportsOwnershipTXT:    true,
+		SupportsNativeUpdate:    true, // RRset REPLACE swaps content atomically
+		SupportsMultipleTargets: true,
+		SupportedRecordTypes: []provider.RecordType{
+			provider.RecordTypeA,
+			provider.RecordTypeAAAA,
+			provider.RecordTypeCNAME,
+			provider.RecordTypeTXT,
+			provider.RecordTypeSRV,
+			provider.RecordTypeMX,
+		},
+	}
+}
+
+// Zone returns the configured DNS zone.
+func (p *Provider) Zone() string {
+	return p.zone
+}
+
+// Ping checks connectivity to the PowerDNS API.
+func (p *Provider) Ping(ctx context.Context) error {
+	return p.client.Ping(ctx)
+}
+
+// List returns all enabled records of supported types in the zone.
+func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
+	sets, err := p.client.ListRRsets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing records: %w", err)
+	}
+
+	caps := p.Capabilities()
+	var records []provider.Record
+	for _, set := range sets {
+		rrType := provider.RecordType(set.Type)
+		if !caps.SupportsRecordType(rrType) {
+			continue
+		}
+		hostname := strings.TrimSuffix(strings.ToLower(set.Name), ".")
+
+		for _, rr := range set.Records {
+			if rr.Disabled {
+				continue
+			}
+			rec := provider.Record{
+				Hostname: hostname,
+				Type:     rrType,
+				TTL:      set.TTL,
+			}
+			if err := decodeContent(&rec, rr.Content); err != nil {
+				p.logger.Warn("skipping unparseable record",
+					slog.String("provider", p.name),
+					slog.String("hostname", hostname),
+					slog.String("type", set.Type),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			rec.ProviderID = fmt.Sprintf("%s:%s:%s", hostname, set.Type, rr.Content)
+			records = append(records, rec)
+		}
+	}
+
+	p.logger.Debug("listed records",
+		slog.String("provider", p.name),
+		slog.Int("count", len(records)),
+	)
+
+	return records, nil
+}
+
+// Create adds a record to its RRset. Returns provider.ErrConflict if an
+// identical record already exists.
+func (p *Provider) Create(ctx context.Context, record provider.Record) error {
+	content, err := encodeContent(record)
+	if err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	ttl := p.recordTTL(record)
+
+	set, err := p.client.GetRRset(ctx, record.Hostname, string(record.Type))
+	if err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	var records []rrContent
+	if set != nil {
+		if indexOf(set.Records, content) >= 0 {
+			return provider.ErrConflict
+		}
+		records = set.Records
+	}
+	records = append(records, rrContent{Content: content})
+
+	if err := p.client.ReplaceRRset(ctx, record.Hostname, string(record.Type), ttl, records); err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("created record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+		slog.Int("ttl", ttl),
+	)
+
+	return nil
+}
+
+// Delete removes a record from its RRset, deleting the RRset when it
+// becomes empty. Deleting a record that does not exist is not an error.
+func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
+	content, err := encodeContent(record)
+	if err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+
+	set, err := p.client.GetRRset(ctx, record.Hostname, string(record.Type))
+	if err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+	if set == nil {
+		return nil
+	}
+	idx := indexOf(set.Records, content)
+	if idx < 0 {
+		return nil
+	}
+
+	records := append(append([]rrContent{}, set.Records[:idx]...), set.Records[idx+1:]...)
+	if err := p.client.ReplaceRRset(ctx, record.Hostname, string(record.Type), set.TTL, records); err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("deleted record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+	)
+
+	return nil
+}
+
+// Update replaces an existing record's content and TTL in a single PATCH.
+// This implements the provider.Updater interface for native update support.
+func (p *Provider) Update(ctx context.Context, existing, desired provider.Record) error {
+	if !strings.EqualFold(existing.Hostname, desired.Hostname) || existing.Type != desired.Type {
+		return fmt.Errorf("updating record: hostname and type must not change")
+	}
+
+	oldContent, err := encodeContent(existing)
+	if err != nil {
+		return fmt.Errorf("updating %s record: %w", existing.Type, err)
+	}
+	newContent, err := encodeContent(desired)
+	if err != nil {
+		return fmt.Errorf("updating %s record: %w", desired.Type, err)
+	}
+
+	set, err := p.client.GetRRset(ctx, existing.Hostname, string(existing.Type))
+	if err != nil {
+		return fmt.Errorf("updating %s record: %w", existing.Type, err)
+	}
+	if set == nil {
+		return provider.ErrNotFound
+	}
+	idx := indexOf(set.Records, oldContent)
+	if idx < 0 {
+		return provider.ErrNotFound
+	}
+
+	ttl := p.recordTTL(desired)
+	if oldContent == newContent && set.TTL == ttl {
+		return nil
+	}
+
+	records := append([]rrContent{}, set.Records...)
+	records[idx] = rrContent{Content: newContent}
+	if newContent != oldContent {
+		// Drop a duplicate if the new content already existed elsewhere in the set
+		if dup := indexOf(set.Records, newContent); dup >= 0 {
+			records = append(records[:dup], records[dup+1:]...)
+		}
+	}
+
+	if err := p.client.ReplaceRRset(ctx, desired.Hostname, string(desired.Type), ttl, records); err != nil {
+		return fmt.Errorf("updating %s record: %w", desired.Type, err)
+	}
+
+	p.logger.Info("updated record",
+		slog.String("provider", p.name),
+		slog.String("hostname", desired.Hostname),
+		slog.String("type", string(desired.Type)),
+		slog.String("old_target", existing.Target),
+		slog.String("new_target", desired.Target),
+		slog.Int("ttl", ttl),
+	)
+
+	return nil
+}
+
+// recordTTL returns the record's TTL, or the provider default if unset.
+func (p *Provider) recordTTL(record provider.Record) int {
+	if record.TTL > 0 {
+		return record.TTL
+	}
+	return p.ttl
+}
+
+// indexOf returns the index of the record with the given content, or -1.
+func indexOf(records []rrContent, content string) int {
+	for i, r := range records {
+		if strings.EqualFold(r.Content, content) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Ensure Provider implements provider.Provider and provider.Updater at compile time.
+var (
+	_ provider.Provider = (*Provider)(nil)
+	_ provider.Updater  = (*Provider)(nil)
+)
@@ -0,0 +1,250 @@
+package powerdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// fakeServer is an in-memory PowerDNS API for a single zone.
+type fakeServer struct {
+	mu      sync.Mutex
+	rrsets  []rrset
+	patches int
+}
+
+func (f *fakeServer) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/servers/localhost":
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "localhost"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/servers/localhost/zones/example.com.":
+			_ = json.NewEncoder(w).Encode(zoneResponse{Name: "example.com.", RRsets: f.rrsets})
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/servers/localhost/zones/example.com.":
+			var body struct {
+				RRsets []rrset `json:"rrsets"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decoding PATCH body: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			f.patches++
+			for _, change := range body.RRsets {
+				f.apply(change)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(errorResponse{Error: "Not Found"})
+		}
+	})
+}
+
+func (f *fakeServer) apply(change rrset) {
+	kept := f.rrsets[:0]
+	for _, set := range f.rrsets {
+		if set.Name != change.Name || set.Type != change.Type {
+			kept = append(kept, set)
+		}
+	}
+	f.rrsets = kept
+	if change.ChangeType == changeReplace {
+		change.ChangeType = ""
+		f.rrsets = append(f.rrsets, change)
+	}
+}
+
+func (f *fakeServer) find(name, rrType string) *rrset {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.rrsets {
+		if f.rrsets[i].Name == name && f.rrsets[i].Type == rrType {
+			return &f.rrsets[i]
+		}
+	}
+	return nil
+}
+
+func newTestProvider(t *testing.T, fake *fakeServer) *Provider {
+	t.Helper()
+	server := httptest.NewServer(fake.handler(t))
+	t.Cleanup(server.Close)
+
+	p, err := New("pdns", &Config{
+		URL:      server.URL,
+		APIKey:   "test-key",
+		ServerID: DefaultServerID,
+		Zone:     "example.com",
+		TTL:      300,
+	})
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestProvider_Ping(t *testing.T) {
+	p := newTestProvider(t, &fakeServer{})
+	if err := p.Ping(context.Background()); err != nil {
+		t.Errorf("Ping() unexpected error: %v", err)
+	}
+
+	p.client.apiKey = "wrong"
+	if err := p.Ping(context.Background()); !errors.Is(err, provider.ErrUnauthorized) {
+		t.Errorf("Ping() with bad key error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestProvider_List(t *testing.T) {
+	fake := &fakeServer{rrsets: []rrset{
+		{Name: "example.com.", Type: "SOA", TTL: 3600, Records: []rrContent{{Content: "ns1.example.com. admin.example.com. 1 10800 3600 604800 3600"}}},
+		{Name: "app.example.com.", Type: "A", TTL: 300, Records: []rrContent{{Content: "10.0.0.1"}, {Content: "10.0.0.2", Disabled: true}}},
+		{Name: "_dnsweaver.app.example.com.", Type: "TXT", TTL: 300, Records: []rrContent{{Content: `"heritage=dnsweaver"`}}},
+		{Name: "_sip._tcp.example.com.", Type: "SRV", TTL: 60, Records: []rrContent{{Content: "10 5 5060 sip.example.com."}}},
+		{Name: "example.com.", Type: "MX", TTL: 3600, Records: []rrContent{{Content: "10 mail.example.com."}}},
+	}}
+	p := newTestProvider(t, fake)
+
+	records, err := p.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("List() returned %d records, want 4 (SOA and disabled records skipped): %+v", len(records), records)
+	}
+
+	byType := map[provider.RecordType]provider.Record{}
+	for _, r := range records {
+		byType[r.Type] = r
+	}
+	if a := byType[provider.RecordTypeA]; a.Hostname != "app.example.com" || a.Target != "10.0.0.1" {
+		t.Errorf("unexpected A record: %+v", a)
+	}
+	if txt := byType[provider.RecordTypeTXT]; txt.Target != "heritage=dnsweaver" {
+		t.Errorf("TXT target = %q, want unquoted value", txt.Target)
+	}
+	if srv := byType[provider.RecordTypeSRV]; srv.SRV == nil || srv.SRV.Port != 5060 || srv.Target != "sip.example.com" {
+		t.Errorf("unexpected SRV record: %+v", srv)
+	}
+	if mx := byType[provider.RecordTypeMX]; mx.Target != "10 mail.example.com" {
+		t.Errorf("MX target = %q, want %q", mx.Target, "10 mail.example.com")
+	}
+}
+
+func TestProvider_CreateAndDelete(t *testing.T) {
+	fake := &fakeServer{}
+	p := newTestProvider(t, fake)
+	ctx := context.Background()
+
+	first := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"}
+	second := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.2", TTL: 60}
+
+	if err := p.Create(ctx, first); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if err := p.Create(ctx, second); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+
+	set := fake.find("app.example.com.", "A")
+	if set == nil || len(set.Records) != 2 {
+		t.Fatalf("RRset after two creates = %+v, want 2 records", set)
+	}
+	if set.TTL != 60 {
+		t.Errorf("RRset TTL = %d, want 60", set.TTL)
+	}
+
+	if err := p.Create(ctx, first); !errors.Is(err, provider.ErrConflict) {
+		t.Errorf("duplicate Create() error = %v, want ErrConflict", err)
+	}
+
+	if err := p.Delete(ctx, first); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	set = fake.find("app.example.com.", "A")
+	if set == nil || len(set.Records) != 1 || set.Records[0].Content != "10.0.0.2" {
+		t.Fatalf("RRset after delete = %+v, want only 10.0.0.2", set)
+	}
+
+	if err := p.Delete(ctx, second); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if set := fake.find("app.example.com.", "A"); set != nil {
+		t.Errorf("RRset should be removed when empty, got %+v", set)
+	}
+
+	patches := fake.patches
+	if err := p.Delete(ctx, second); err != nil {
+		t.Errorf("Delete() of missing record unexpected error: %v", err)
+	}
+	if fake.patches != patches {
+		t.Error("Delete() of missing record should not PATCH")
+	}
+}
+
+func TestProvider_CreateTXT(t *testing.T) {
+	fake := &fakeServer{}
+	p := newTestProvider(t, fake)
+
+	err := p.Create(context.Background(), provider.Record{
+		Hostname: "_dnsweaver.app.example.com",
+		Type:     provider.RecordTypeTXT,
+		Target:   `heritage=dnsweaver,note="x"`,
+	})
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+
+	set := fake.find("_dnsweaver.app.example.com.", "TXT")
+	if set == nil || set.Records[0].Content != `"heritage=dnsweaver,note=\"x\""` {
+		t.Errorf("TXT content not quoted correctly: %+v", set)
+	}
+}
+
+func TestProvider_Update(t *testing.T) {
+	fake := &fakeServer{rrsets: []rrset{
+		{Name: "app.example.com.", Type: "CNAME", TTL: 300, Records: []rrContent{{Content: "old.example.com."}}},
+	}}
+	p := newTestProvider(t, fake)
+	ctx := context.Background()
+
+	existing := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeCNAME, Target: "old.example.com"}
+	desired := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeCNAME, Target: "new.example.com", TTL: 120}
+
+	if err := p.Update(ctx, existing, desired); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	set := fake.find("app.example.com.", "CNAME")
+	if set == nil || len(set.Records) != 1 || set.Records[0].Content != "new.example.com." || set.TTL != 120 {
+		t.Errorf("RRset after update = %+v", set)
+	}
+	if fake.patches != 1 {
+		t.Errorf("Update() made %d PATCH requests, want 1", fake.patches)
+	}
+
+	if err := p.Update(ctx, existing, desired); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("Update() of missing record error = %v, want ErrNotFound", err)
+	}
+
+	if err := p.Update(ctx, desired, desired); err != nil {
+		t.Errorf("Update() with identical records unexpected error: %v", err)
+	}
+	if fake.patches != 1 {
+		t.Error("Update() with identical records should not PATCH")
+	}
+}
@@ -0,0 +1,301 @@
+package route53
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/smithy-go"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Route53 change batch limits. UPSERT values count twice toward both.
+const (
+	maxBatchValues = 1000
+	maxBatchChars  = 32000
+)
+
+// route53API is the subset of the Route53 API used by the client.
+// It is satisfied by *route53.Client and replaced by a fake in tests.
+type route53API interface {
+	GetHostedZone(ctx context.Context, in *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error)
+	ListResourceRecordSets(ctx context.Context, in *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error)
+	ChangeResourceRecordSets(ctx context.Context, in *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error)
+	GetChange(ctx context.Context, in *route53.GetChangeInput, optFns ...func(*route53.Options)) (*route53.GetChangeOutput, error)
+}
+
+// rrset is a simple (non-alias, non-routing-policy) Route53 record set:
+// all values sharing a name and type, with one TTL.
+type rrset struct {
+	Name   string // Hostname without trailing dot
+	Type   string
+	TTL    int64
+	Values []string
+}
+
+// rrsetChange is one change in a change batch.
+type rrsetChange struct {
+	Action types.ChangeAction
+	Set    rrset
+}
+
+// Client wraps the Route53 API for a single hosted zone.
+type Client struct {
+	api    route53API
+	zoneID string
+	logger *slog.Logger
+}
+
+// ClientOption is a functional option for configuring the Client.
+type ClientOption func(*Client)
+
+// WithLogger sets a custom logger for the client.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// NewClient creates a client for the hosted zone using the given API.
+func NewClient(api route53API, zoneID string, opts ...ClientOption) *Client {
+	c := &Client{
+		api:    api,
+		zoneID: zoneID,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// newAPI creates a Route53 API client. Static credentials are used when
+// configured; otherwise the SDK's default credential chain resolves them,
+// which supports IAM roles for EC2, ECS and EKS. httpClient may be nil.
+func newAPI(ctx context.Context, config *Config, httpClient *http.Client) (*route53.Client, error) {
+	loadOpts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(config.Region),
+	}
+	if config.HasStaticCredentials() {
+		loadOpts = append(loadOpts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(config.AccessKeyID, config.SecretAccessKey, ""),
+		))
+	}
+	if httpClient != nil {
+		loadOpts = append(loadOpts, awsconfig.WithHTTPClient(httpClient))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS configuration: %w", err)
+	}
+	return route53.NewFromConfig(awsCfg), nil
+}
+
+// ZoneName returns the hosted zone's domain name without trailing dot.
+// It also serves as a connectivity and credentials check.
+func (c *Client) ZoneName(ctx context.Context) (string, error) {
+	out, err := c.api.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String(c.zoneID)})
+	if err != nil {
+		return "", fmt.Errorf("getting hosted zone %s: %w", c.zoneID, classify(err))
+	}
+	if out.HostedZone == nil {
+		return "", fmt.Errorf("getting hosted zone %s: empty response", c.zoneID)
+	}
+	return decodeName(aws.ToString(out.HostedZone.Name)), nil
+}
+
+// ListRRsets returns all simple record sets in the zone, following pagination.
+func (c *Client) ListRRsets(ctx context.Context) ([]rrset, error) {
+	in := &route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(c.zoneID)}
+
+	var sets []rrset
+	for {
+		out, err := c.api.ListResourceRecordSets(ctx, in)
+		if err != nil {
+			return nil, fmt.Errorf("listing record sets: %w", classify(err))
+		}
+		for _, set := range out.ResourceRecordSets {
+			if s, ok := fromAPI(set); ok {
+				sets = append(sets, s)
+			}
+		}
+		if !out.IsTruncated {
+			break
+		}
+		in.StartRecordName = out.NextRecordName
+		in.StartRecordType = out.NextRecordType
+		in.StartRecordIdentifier = out.NextRecordIdentifier
+	}
+
+	c.logger.Debug("listed record sets",
+		slog.String("zone_id", c.zoneID),
+		slog.Int("count", len(sets)),
+	)
+
+	return sets, nil
+}
+
+// GetRRset returns the simple record set for a name and type, or nil if
+// there is none.
+func (c *Client) GetRRset(ctx context.Context, name, rrType string) (*rrset, error) {
+	out, err := c.api.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(c.zoneID),
+		StartRecordName: aws.String(fqdn(name)),
+		StartRecordType: types.RRType(rrType),
+		MaxItems:        aws.Int32(1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting %s record set for %s: %w", rrType, name, classify(err))
+	}
+
+	// The listing starts at the name and type, so the first set is a match
+	// only if it exists
+	for _, set := range out.ResourceRecordSets {
+		s, ok := fromAPI(set)
+		if ok && s.Name == decodeName(name) && s.Type == rrType {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+// ChangeRRsets submits changes in a single change batch and returns the
+// change ID, which GetChange reports as INSYNC once every Route53 name
+// server has applied it.
+func (c *Client) ChangeRRsets(ctx context.Context, changes []rrsetChange) (string, error) {
+	batch := &types.ChangeBatch{Comment: aws.String("dnsweaver")}
+	for _, change := range changes {
+		batch.Changes = append(batch.Changes, types.Change{
+			Action:            change.Action,
+			ResourceRecordSet: toAPI(change.Set),
+		})
+	}
+
+	out, err := c.api.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(c.zoneID),
+		ChangeBatch:  batch,
+	})
+	if err != nil {
+		return "", fmt.Errorf("changing record sets: %w", classify(err))
+	}
+	if out.ChangeInfo == nil {
+		return "", nil
+	}
+
+	c.logger.Debug("submitted change batch",
+		slog.String("zone_id", c.zoneID),
+		slog.String("change_id", aws.ToString(out.ChangeInfo.Id)),
+		slog.Int("changes", len(changes)),
+	)
+
+	return aws.ToString(out.ChangeInfo.Id), nil
+}
+
+// InSync reports whether a submitted change has propagated to all Route53
+// name servers.
+func (c *Client) InSync(ctx context.Context, changeID string) (bool, error) {
+	out, err := c.api.GetChange(ctx, &route53.GetChangeInput{Id: aws.String(changeID)})
+	if err != nil {
+		return false, fmt.Errorf("getting change %s: %w", changeID, classify(err))
+	}
+	return out.ChangeInfo != nil && out.ChangeInfo.Status == types.ChangeStatusInsync, nil
+}
+
+// fromAPI converts a Route53 record set. Alias records and record sets with
+// a routing policy (weighted, latency, failover, ...) are not managed by
+// dnsweaver and are skipped.
+func fromAPI(set types.ResourceRecordSet) (rrset, bool) {
+	if set.AliasTarget != nil || set.SetIdentifier != nil {
+		return rrset{}, false
+	}
+
+	s := rrset{
+		Name: decodeName(aws.ToString(set.Name)),
+		Type: string(set.Type),
+		TTL:  aws.ToInt64(set.TTL),
+	}
+	for _, rr := range set.ResourceRecords {
+		s.Values = append(s.Values, aws.ToString(rr.Value))
+	}
+	return s, true
+}
+
+// toAPI converts a record set to its Route53 representation.
+func toAPI(set rrset) *types.ResourceRecordSet {
+	out := &types.ResourceRecordSet{
+		Name: aws.String(fqdn(set.Name)),
+		Type: types.RRType(set.Type),
+		TTL:  aws.Int64(set.TTL),
+	}
+	for _, value := range set.Values {
+		out.ResourceRecords = append(out.ResourceRecords, types.ResourceRecord{Value: aws.String(value)})
+	}
+	return out
+}
+
+// splitBatches splits changes into change batches within the Route53 limits
+// on the number and total length of values.
+func splitBatches(changes []rrsetChange) [][]rrsetChange {
+	var batches [][]rrsetChange
+	var current []rrsetChange
+	values, chars := 0, 0
+	for _, change := range changes {
+		weight := 1
+		if change.Action == types.ChangeActionUpsert {
+			weight = 2
+		}
+		n, size := 0, 0
+		for _, value := range change.Set.Values {
+			n += weight
+			size += weight * len(value)
+		}
+		if len(current) > 0 && (values+n > maxBatchValues || chars+size > maxBatchChars) {
+			batches = append(batches, current)
+			current, values, chars = nil, 0, 0
+		}
+		current = append(current, change)
+		values += n
+		chars += size
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// classify wraps AWS API errors with the matching provider error so callers
+// can tell throttling, authentication and invalid input failures apart.
+func classify(err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		code := apiErr.ErrorCode()
+		switch {
+		case code == "Throttling" || code == "PriorRequestNotComplete" || strings.HasSuffix(code, "ThrottlingException"):
+			return fmt.Errorf("%w: %w", provider.ErrQuota, err)
+		case code == "InvalidChangeBatch" || code == "InvalidInput":
+			return fmt.Errorf("%w: %w", provider.ErrInvalidRecord, err)
+		}
+	}
+
+	var respErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &respErr) {
+		if base := provider.ErrorForStatus(respErr.HTTPStatusCode()); base != nil {
+			return fmt.Errorf("%w: %w", base, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,149 @@
+// Package route53 implements the DNSWeaver provider interface for AWS Route53
+// hosted zones, using the AWS SDK for Go v2.
+package route53
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultTTL is the default TTL for Route53 records.
+const DefaultTTL = 300
+
+// DefaultRegion is the AWS region used for the Route53 API when none is set.
+// Route53 is a global service; the region only selects the API endpoint and
+// signing region.
+const DefaultRegion = "us-east-1"
+
+// Config holds Route53-specific configuration.
+type Config struct {
+	ZoneID string // Hosted zone ID (e.g., "Z0123456789ABCDEFGHIJ")
+	Region string // AWS region for the API (default: us-east-1)
+	TTL    int    // Default record TTL
+
+	// Static credentials (optional). When both are empty, the SDK's default
+	// credential chain is used: environment, shared config, web identity,
+	// ECS task role or EC2 instance profile.
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// Validate checks that all required configuration is present.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.ZoneID == "" {
+		errs = append(errs, "ZONE_ID is required")
+	}
+	if c.Region == "" {
+		errs = append(errs, "REGION is required")
+	}
+	if c.TTL < 0 {
+		errs = append(errs, "TTL must be non-negative")
+	}
+	if (c.AccessKeyID == "") != (c.SecretAccessKey == "") {
+		errs = append(errs, "ACCESS_KEY_ID and SECRET_ACCESS_KEY must be set together")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("route53 config validation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// HasStaticCredentials returns true if an access key pair is configured.
+func (c *Config) HasStaticCredentials() bool {
+	return c.AccessKeyID != "" && c.SecretAccessKey != ""
+}
+
+// LoadConfig loads Route53 configuration from environment variables.
+// Environment variable pattern: DNSWEAVER_{INSTANCE_NAME}_{SETTING}
+//
+// Instance names are normalized: lowercase with hyphens becomes uppercase with underscores.
+// Example: "route53" looks for DNSWEAVER_ROUTE53_*
+//
+// Supported settings:
+//   - ZONE_ID: Hosted zone ID (required)
+//   - REGION: AWS region (optional, default: us-east-1)
+//   - TTL: Record TTL (optional, default: 300)
+//   - ACCESS_KEY_ID: AWS access key ID (optional, supports _FILE suffix for Docker secrets)
+//   - SECRET_ACCESS_KEY: AWS secret access key (optional, supports _FILE suffix for Docker secrets)
+func LoadConfig(instanceName string) (*Config, error) {
+	prefix := envPrefix(instanceName)
+
+	return LoadConfigFromMap(instanceName, map[string]string{
+		"ZONE_ID":           getEnv(prefix + "ZONE_ID"),
+		"REGION":            getEnv(prefix + "REGION"),
+		"TTL":               getEnv(prefix + "TTL"),
+		"ACCESS_KEY_ID":     getEnvOrFile(prefix+"ACCESS_KEY_ID", prefix+"ACCESS_KEY_ID_FILE"),
+		"SECRET_ACCESS_KEY": getEnvOrFile(prefix+"SECRET_ACCESS_KEY", prefix+"SECRET_ACCESS_KEY_FILE"),
+	})
+}
+
+// LoadConfigFromMap creates a Config from a map of key-value pairs.
+// This is used by the provider registry to create instances from
+// configuration that was already parsed from environment variables.
+//
+// Required keys: ZONE_ID
+// Optional keys: REGION, TTL, ACCESS_KEY_ID, SECRET_ACCESS_KEY
+func LoadConfigFromMap(instanceName string, configMap map[string]string) (*Config, error) {
+	config := &Config{
+		ZoneID:          strings.TrimPrefix(configMap["ZONE_ID"], "/hostedzone/"),
+		Region:          DefaultRegion,
+		TTL:             DefaultTTL,
+		AccessKeyID:     configMap["ACCESS_KEY_ID"],
+		SecretAccessKey: configMap["SECRET_ACCESS_KEY"],
+	}
+
+	if region := configMap["REGION"]; region != "" {
+		config.Region = region
+	}
+
+	// Parse optional TTL
+	if ttlStr, ok := configMap["TTL"]; ok && ttlStr != "" {
+		ttl, err := strconv.Atoi(ttlStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TTL value %q: %w", ttlStr, err)
+		}
+		config.TTL = ttl
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
+	}
+
+	return config, nil
+}
+
+// envPrefix converts an instance name to an environment variable prefix.
+// Example: "route53" → "DNSWEAVER_ROUTE53_"
+func envPrefix(instanceName string) string {
+	normalized := strings.ToUpper(instanceName)
+	normalized = strings.ReplaceAll(normalized, "-", "_")
+	return "DNSWEAVER_" + normalized + "_"
+}
+
+// getEnv retrieves an environment variable value.
+func getEnv(key string) string {
+	return os.Getenv(key)
+}
+
+// getEnvOrFile retrieves a value from either a direct environment variable
+// or a file path specified by the file key (Docker secrets pattern).
+//
+// If both are set, the file takes precedence.
+// The file contents are trimmed of leading/trailing whitespace.
+func getEnvOrFile(directKey, fileKey string) string {
+	if filePath := os.Getenv(fileKey); filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+		// If file read fails, fall through to direct value
+	}
+
+	return os.Getenv(directKey)
+}
@@ -0,0 +1,80 @@
+package route53
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigFromMap(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		c, err := LoadConfigFromMap("route53", map[string]string{
+			"ZONE_ID": "/hostedzone/Z123",
+		})
+		if err != nil {
+			t.Fatalf("LoadConfigFromMap() unexpected error: %v", err)
+		}
+		if c.ZoneID != "Z123" {
+			t.Errorf("ZoneID = %q, want /hostedzone/ prefix trimmed", c.ZoneID)
+		}
+		if c.Region != DefaultRegion {
+			t.Errorf("Region = %q, want %q", c.Region, DefaultRegion)
+		}
+		if c.TTL != DefaultTTL {
+			t.Errorf("TTL = %d, want %d", c.TTL, DefaultTTL)
+		}
+		if c.HasStaticCredentials() {
+			t.Error("HasStaticCredentials() = true, want default credential chain")
+		}
+	})
+
+	t.Run("missing required", func(t *testing.T) {
+		_, err := LoadConfigFromMap("route53", map[string]string{})
+		if err == nil || !strings.Contains(err.Error(), "ZONE_ID is required") {
+			t.Errorf("LoadConfigFromMap() error = %v, want ZONE_ID is required", err)
+		}
+	})
+
+	t.Run("partial credentials", func(t *testing.T) {
+		_, err := LoadConfigFromMap("route53", map[string]string{
+			"ZONE_ID": "Z123", "ACCESS_KEY_ID": "AKIA",
+		})
+		if err == nil || !strings.Contains(err.Error(), "must be set together") {
+			t.Errorf("LoadConfigFromMap() error = %v, want credentials error", err)
+		}
+	})
+
+	t.Run("invalid TTL", func(t *testing.T) {
+		_, err := LoadConfigFromMap("route53", map[string]string{"ZONE_ID": "Z123", "TTL": "soon"})
+		if err == nil {
+			t.Fatal("LoadConfigFromMap() expected error for invalid TTL")
+		}
+	})
+}
+
+func TestLoadConfig_FromEnv(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("DNSWEAVER_AWS_DNS_ZONE_ID", "Z123")
+	t.Setenv("DNSWEAVER_AWS_DNS_REGION", "eu-west-1")
+	t.Setenv("DNSWEAVER_AWS_DNS_ACCESS_KEY_ID", "AKIA")
+	t.Setenv("DNSWEAVER_AWS_DNS_SECRET_ACCESS_KEY_FILE", secretFile)
+
+	c, err := LoadConfig("aws-dns")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	if c.Region != "eu-west-1" {
+		t.Errorf("Region = %q, want eu-west-1", c.Region)
+	}
+	if c.SecretAccessKey != "from-file" {
+		t.Errorf("SecretAccessKey = %q, want from-file", c.SecretAccessKey)
+	}
+	if !c.HasStaticCredentials() {
+		t.Error("HasStaticCredentials() = false, want true")
+	}
+}
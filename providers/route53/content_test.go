@@ -0,0 +1,67 @@
+package route53
+
+import (
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+func TestValue_RoundTrip(t *testing.T) {
+	tests := []struct {
+		record provider.Record
+		value  string
+	}{
+		{provider.Record{Type: provider.RecordTypeA, Target: "10.0.0.1"}, "10.0.0.1"},
+		{provider.Record{Type: provider.RecordTypeAAAA, Target: "2001:db8::1"}, "2001:db8::1"},
+		{provider.Record{Type: provider.RecordTypeCNAME, Target: "app.example.com"}, "app.example.com"},
+		{provider.Record{Type: provider.RecordTypeTXT, Target: `heritage=dnsweaver,note="x"`}, `"heritage=dnsweaver,note=\"x\""`},
+		{provider.Record{Type: provider.RecordTypeMX, Target: "10 mail.example.com"}, "10 mail.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.record.Type), func(t *testing.T) {
+			value, err := encodeValue(tt.record)
+			if err != nil {
+				t.Fatalf("encodeValue() unexpected error: %v", err)
+			}
+			if value != tt.value {
+				t.Errorf("encodeValue() = %q, want %q", value, tt.value)
+			}
+
+			decoded := provider.Record{Type: tt.record.Type}
+			if err := decodeValue(&decoded, value); err != nil {
+				t.Fatalf("decodeValue() unexpected error: %v", err)
+			}
+			if decoded.Target != tt.record.Target {
+				t.Errorf("decodeValue() Target = %q, want %q", decoded.Target, tt.record.Target)
+			}
+		})
+	}
+}
+
+func TestEncodeValue_Invalid(t *testing.T) {
+	for _, record := range []provider.Record{
+		{Type: provider.RecordTypeA, Target: "not-an-ip"},
+		{Type: provider.RecordTypeMX, Target: "mail.example.com"},
+		{Type: provider.RecordTypeSRV, Target: "sip.example.com"},
+	} {
+		if _, err := encodeValue(record); err == nil {
+			t.Errorf("encodeValue(%+v) expected error", record)
+		}
+	}
+}
+
+func TestDecodeRoute53Escapes(t *testing.T) {
+	if got := decodeName(`\052.Example.com.`); got != "*.example.com" {
+		t.Errorf("decodeName() = %q, want *.example.com", got)
+	}
+	if got := unquoteTXT(`"caf\303\251" "2"`); got != "café2" {
+		t.Errorf("unquoteTXT() = %q, want café2", got)
+	}
+	if !sameValue("TXT", `"caf\303\251"`, `"café"`) {
+		t.Error("sameValue() should compare decoded TXT data")
+	}
+	if !sameValue("CNAME", "App.example.com.", "app.example.com") {
+		t.Error("sameValue() should ignore case and trailing dot of hostnames")
+	}
+}
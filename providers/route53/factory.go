@@ -0,0 +1,30 @@
+package route53
+
+import (
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Factory returns a provider.Factory for creating Route53 provider instances.
+// This is the recommended way to register the Route53 provider with the registry.
+func Factory() provider.Factory {
+	return func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		// Parse provider-specific configuration from the map
+		providerCfg, err := LoadConfigFromMap(cfg.Name, cfg.ProviderConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		// The AWS SDK signs and retries requests and sets its own user agent;
+		// the shared client adds the timeout and debug logging
+		httpClient := httputil.NewClient(&httputil.ClientConfig{
+			Timeout: cfg.HTTP.Timeout,
+			Logger:  cfg.HTTP.Logger,
+		})
+
+		return New(cfg.Name, providerCfg,
+			WithProviderHTTPClient(httpClient),
+			WithProviderLogger(cfg.HTTP.Logger),
+		)
+	}
+}
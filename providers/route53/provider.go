@@ -0,0 +1,600 @@
+package route53
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// maxPendingAge bounds how long a submitted change is overlaid on listings
+// when its status cannot be confirmed. Route53 changes usually propagate
+// within 60 seconds.
+const maxPendingAge = 5 * time.Minute
+
+// rrsetKey identifies a record set by hostname and type.
+type rrsetKey struct {
+	name   string
+	rrType string
+}
+
+// pendingChange is a submitted change batch that Route53 has not reported
+// as INSYNC yet. sets holds the record sets as written; nil means deleted.
+type pendingChange struct {
+	id        string
+	sets      map[rrsetKey]*rrset
+	submitted time.Time
+}
+
+// Provider implements provider.Provider for AWS Route53.
+//
+// Route53 manages records as record sets (all values sharing a name and
+// type), so Create and Delete read the current set and UPSERT it with the
+// value added or removed. The TTL is shared by every value in a set.
+//
+// Route53 is eventually consistent: a change is PENDING until all of its
+// name servers apply it, and listings may not reflect it until then. The
+// provider remembers its pending changes and overlays them on List results
+// and record set reads until GetChange reports them INSYNC.
+type Provider struct {
+	name       string
+	ttl        int
+	config     *Config
+	client     *Client
+	api        route53API   // Route53 API (optional, created from config if nil)
+	httpClient *http.Client // Custom HTTP client (optional)
+	logger     *slog.Logger
+
+	// writeMu serializes read-modify-write cycles on record sets
+	writeMu sync.Mutex
+
+	// mu guards zone and pending
+	mu      sync.Mutex
+	zone    string
+	pending []*pendingChange
+}
+
+// ProviderOption is a functional option for configuring the Provider.
+type ProviderOption func(*Provider)
+
+// WithProviderLogger sets a custom logger for the provider.
+func WithProviderLogger(logger *slog.Logger) ProviderOption {
+	return func(p *Provider) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// WithProviderHTTPClient sets a custom HTTP client for AWS API requests.
+func WithProviderHTTPClient(client *http.Client) ProviderOption {
+	return func(p *Provider) {
+		if client != nil {
+			p.httpClient = client
+		}
+	}
+}
+
+// withAPI sets the Route53 API implementation, replacing the SDK client.
+func withAPI(api route53API) ProviderOption {
+	return func(p *Provider) {
+		p.api = api
+	}
+}
+
+// New creates a new Route53 provider instance.
+func New(name string, config *Config, opts ...ProviderOption) (*Provider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		name:   name,
+		ttl:    config.TTL,
+		config: config,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.api == nil {
+		api, err := newAPI(context.Background(), config, p.httpClient)
+		if err != nil {
+			return nil, err
+		}
+		p.api = api
+	}
+	p.client = NewClient(p.api, config.ZoneID, WithLogger(p.logger))
+
+	if !config.HasStaticCredentials() {
+		p.logger.Debug("no static credentials configured, using the AWS default credential chain",
+			slog.String("provider", name),
+		)
+	}
+
+	return p, nil
+}
+
+// NewFromEnv creates a new Route53 provider from environment variables.
+// This is a convenience function for use with the provider registry.
+func NewFromEnv(instanceName string, opts ...ProviderOption) (*Provider, error) {
+	config, err := LoadConfig(instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(instanceName, config, opts...)
+}
+
+// Name returns the provider instance name.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Type returns "route53".
+func (p *Provider) Type() string {
+	return "route53"
+}
+
+// Capabilities returns the provider's feature support. Weighted records are
+// not supported: only simple record sets are written, so weight hints are
+// dropped by the reconciler.
+func (p *Provider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SupportsOwnershipTXT:    true,
+		SupportsNativeUpdate:    true, // UPSERT swaps values atomically
+		SupportsMultipleTargets: true,
+		SupportedRecordTypes: []provider.RecordType{
+			provider.RecordTypeA,
+			provider.RecordTypeAAAA,
+			provider.RecordTypeCNAME,
+			provider.RecordTypeTXT,
+			provider.RecordTypeMX,
+		},
+	}
+}
+
+// Zone returns the hosted zone's domain name, known once Ping succeeds.
+func (p *Provider) Zone() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.zone
+}
+
+// Ping checks connectivity and credentials by fetching the hosted zone,
+// and records its domain name.
+func (p *Provider) Ping(ctx context.Context) error {
+	zone, err := p.client.ZoneName(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.zone = zone
+	p.mu.Unlock()
+	return nil
+}
+
+// List returns all records of supported types in simple record sets of the
+// zone. Changes submitted by this provider that are still propagating are
+// reflected even if Route53 does not list them yet.
+func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
+	sets, err := p.client.ListRRsets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing records: %w", err)
+	}
+	sets = applyOverlay(sets, p.pendingSets(ctx))
+
+	caps := p.Capabilities()
+	var records []provider.Record
+	for _, set := range sets {
+		rrType := provider.RecordType(set.Type)
+		if !caps.SupportsRecordType(rrType) {
+			continue
+		}
+		for _, value := range set.Values {
+			rec := provider.Record{
+				Hostname: set.Name,
+				Type:     rrType,
+				TTL:      int(set.TTL),
+			}
+			if err := decodeValue(&rec, value); err != nil {
+				p.logger.Warn("skipping unparseable record",
+					slog.String("provider", p.name),
+					slog.String("hostname", set.Name),
+					slog.String("type", set.Type),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			rec.ProviderID = fmt.Sprintf("%s:%s:%s", set.Name, set.Type, value)
+			records = append(records, rec)
+		}
+	}
+
+	p.logger.Debug("listed records",
+		slog.String("provider", p.name),
+		slog.Int("count", len(records)),
+	)
+
+	return records, nil
+}
+
+// Create adds a value to its record set. Returns provider.ErrConflict if an
+// identical record already exists.
+func (p *Provider) Create(ctx context.Context, record provider.Record) error {
+	value, err := encodeValue(record)
+	if err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	set, err := p.getRRset(ctx, record.Hostname, string(record.Type))
+	if err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	ttl := p.recordTTL(record)
+	updated := rrset{Name: decodeName(record.Hostname), Type: string(record.Type), TTL: int64(ttl)}
+	if set != nil {
+		if indexOf(set, value) >= 0 {
+			return provider.ErrConflict
+		}
+		updated.Values = append(updated.Values, set.Values...)
+	}
+	updated.Values = append(updated.Values, value)
+
+	if err := p.submit(ctx, []rrsetChange{{Action: types.ChangeActionUpsert, Set: updated}}); err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("created record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+		slog.Int("ttl", ttl),
+	)
+
+	return nil
+}
+
+// Delete removes a value from its record set, deleting the set when it
+// becomes empty. Deleting a record that does not exist is not an error.
+func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
+	value, err := encodeValue(record)
+	if err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	set, err := p.getRRset(ctx, record.Hostname, string(record.Type))
+	if err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+	if set == nil {
+		return nil
+	}
+	idx := indexOf(set, value)
+	if idx < 0 {
+		return nil
+	}
+
+	// DELETE must match the set exactly, so send it as Route53 returned it
+	change := rrsetChange{Action: types.ChangeActionDelete, Set: *set}
+	if len(set.Values) > 1 {
+		remaining := *set
+		remaining.Values = append(append([]string{}, set.Values[:idx]...), set.Values[idx+1:]...)
+		change = rrsetChange{Action: types.ChangeActionUpsert, Set: remaining}
+	}
+
+	if err := p.submit(ctx, []rrsetChange{change}); err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("deleted record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+	)
+
+	return nil
+}
+
+// Update replaces an existing record's value and TTL in a single UPSERT.
+// This implements the provider.Updater interface for native update support.
+func (p *Provider) Update(ctx context.Context, existing, desired provider.Record) error {
+	if !strings.EqualFold(existing.Hostname, desired.Hostname) || existing.Type != desired.Type {
+		return fmt.Errorf("updating record: hostname and type must not change")
+	}
+
+	oldValue, err := encodeValue(existing)
+	if err != nil {
+		return fmt.Errorf("updating %s record: %w", existing.Type, err)
+	}
+	newValue, err := encodeValue(desired)
+	if err != nil {
+		return fmt.Errorf("updating %s record: %w", desired.Type, err)
+	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	set, err := p.getRRset(ctx, existing.Hostname, string(existing.Type))
+	if err != nil {
+		return fmt.Errorf("updating %s record: %w", existing.Type, err)
+	}
+	if set == nil {
+		return provider.ErrNotFound
+	}
+	idx := indexOf(set, oldValue)
+	if idx < 0 {
+		return provider.ErrNotFound
+	}
+
+	ttl := p.recordTTL(desired)
+	if sameValue(set.Type, set.Values[idx], newValue) && set.TTL == int64(ttl) {
+		return nil
+	}
+
+	updated := rrset{Name: set.Name, Type: set.Type, TTL: int64(ttl)}
+	for i, value := range set.Values {
+		switch {
+		case i == idx:
+			updated.Values = append(updated.Values, newValue)
+		case !sameValue(set.Type, value, newValue):
+			// Drop a duplicate if the new value already existed elsewhere in the set
+			updated.Values = append(updated.Values, value)
+		}
+	}
+
+	if err := p.submit(ctx, []rrsetChange{{Action: types.ChangeActionUpsert, Set: updated}}); err != nil {
+		return fmt.Errorf("updating %s record: %w", desired.Type, err)
+	}
+
+	p.logger.Info("updated record",
+		slog.String("provider", p.name),
+		slog.String("hostname", desired.Hostname),
+		slog.String("type", string(desired.Type)),
+		slog.String("old_target", existing.Target),
+		slog.String("new_target", desired.Target),
+		slog.Int("ttl", ttl),
+	)
+
+	return nil
+}
+
+// BulkCreate adds all records with as few change batches as Route53's limits
+// allow, one UPSERT per record set. Records that already exist are skipped.
+// This implements the provider.BulkCreator interface.
+func (p *Provider) BulkCreate(ctx context.Context, records []provider.Record) error {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	current, err := p.client.ListRRsets(ctx)
+	if err != nil {
+		return fmt.Errorf("bulk creating records: %w", err)
+	}
+	existing := make(map[rrsetKey]rrset)
+	for _, set := range applyOverlay(current, p.pendingSets(ctx)) {
+		existing[rrsetKey{set.Name, set.Type}] = set
+	}
+
+	updated := make(map[rrsetKey]*rrset)
+	var order []rrsetKey
+	created := 0
+	for _, record := range records {
+		value, err := encodeValue(record)
+		if err != nil {
+			return fmt.Errorf("bulk creating %s record for %s: %w", record.Type, record.Hostname, err)
+		}
+
+		key := rrsetKey{decodeName(record.Hostname), string(record.Type)}
+		set, ok := updated[key]
+		if !ok {
+			set = &rrset{Name: key.name, Type: key.rrType}
+			if prev, exists := existing[key]; exists {
+				set.Values = append(set.Values, prev.Values...)
+			}
+			updated[key] = set
+			order = append(order, key)
+		}
+		set.TTL = int64(p.recordTTL(record))
+		if indexOf(set, value) >= 0 {
+			continue
+		}
+		set.Values = append(set.Values, value)
+		created++
+	}
+
+	changes := make([]rrsetChange, 0, len(order))
+	for _, key := range order {
+		changes = append(changes, rrsetChange{Action: types.ChangeActionUpsert, Set: *updated[key]})
+	}
+	if err := p.submit(ctx, changes); err != nil {
+		return fmt.Errorf("bulk creating records: %w", err)
+	}
+
+	p.logger.Info("bulk created records",
+		slog.String("provider", p.name),
+		slog.Int("records", created),
+		slog.Int("record_sets", len(changes)),
+	)
+
+	return nil
+}
+
+// getRRset returns the current record set for a name and type, preferring
+// the latest pending change so that a read right after a write sees it.
+func (p *Provider) getRRset(ctx context.Context, name, rrType string) (*rrset, error) {
+	key := rrsetKey{decodeName(name), rrType}
+
+	p.mu.Lock()
+	for i := len(p.pending) - 1; i >= 0; i-- {
+		change := p.pending[i]
+		if time.Since(change.submitted) > maxPendingAge {
+			continue
+		}
+		if set, ok := change.sets[key]; ok {
+			p.mu.Unlock()
+			if set == nil {
+				return nil, nil
+			}
+			copied := *set
+			copied.Values = append([]string{}, set.Values...)
+			return &copied, nil
+		}
+	}
+	p.mu.Unlock()
+
+	return p.client.GetRRset(ctx, name, rrType)
+}
+
+// submit sends changes in as many change batches as needed and tracks each
+// batch until it is INSYNC.
+func (p *Provider) submit(ctx context.Context, changes []rrsetChange) error {
+	for _, batch := range splitBatches(changes) {
+		id, err := p.client.ChangeRRsets(ctx, batch)
+		if err != nil {
+			return err
+		}
+
+		pending := &pendingChange{id: id, sets: make(map[rrsetKey]*rrset, len(batch)), submitted: time.Now()}
+		for _, change := range batch {
+			key := rrsetKey{change.Set.Name, change.Set.Type}
+			if change.Action == types.ChangeActionDelete {
+				pending.sets[key] = nil
+				continue
+			}
+			set := change.Set
+			pending.sets[key] = &set
+		}
+
+		p.mu.Lock()
+		p.pending = append(p.pending, pending)
+		p.mu.Unlock()
+	}
+	return nil
+}
+
+// pendingSets checks the status of pending changes, forgets those that are
+// INSYNC or too old, and returns the record sets written by the rest, later
+// changes taking precedence.
+func (p *Provider) pendingSets(ctx context.Context) map[rrsetKey]*rrset {
+	p.mu.Lock()
+	pending := p.pending
+	p.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	done := make(map[*pendingChange]bool)
+	for _, change := range pending {
+		if change.id == "" || time.Since(change.submitted) > maxPendingAge {
+			done[change] = true
+			continue
+		}
+		inSync, err := p.client.InSync(ctx, change.id)
+		if err != nil {
+			p.logger.Warn("checking change status failed",
+				slog.String("provider", p.name),
+				slog.String("change_id", change.id),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if inSync {
+			done[change] = true
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	kept := p.pending[:0]
+	for _, change := range p.pending {
+		if !done[change] {
+			kept = append(kept, change)
+		}
+	}
+	p.pending = kept
+
+	sets := make(map[rrsetKey]*rrset)
+	for _, change := range p.pending {
+		for key, set := range change.sets {
+			sets[key] = set
+		}
+	}
+	return sets
+}
+
+// applyOverlay replaces listed record sets with pending ones, adding sets
+// that are not listed yet and dropping sets pending deletion.
+func applyOverlay(sets []rrset, overlay map[rrsetKey]*rrset) []rrset {
+	if len(overlay) == 0 {
+		return sets
+	}
+
+	result := make([]rrset, 0, len(sets)+len(overlay))
+	seen := make(map[rrsetKey]bool)
+	for _, set := range sets {
+		key := rrsetKey{set.Name, set.Type}
+		pending, ok := overlay[key]
+		if !ok {
+			result = append(result, set)
+			continue
+		}
+		seen[key] = true
+		if pending != nil {
+			result = append(result, *pending)
+		}
+	}
+	for key, pending := range overlay {
+		if !seen[key] && pending != nil {
+			result = append(result, *pending)
+		}
+	}
+	return result
+}
+
+// recordTTL returns the record's TTL, or the provider default if unset.
+func (p *Provider) recordTTL(record provider.Record) int {
+	if record.TTL > 0 {
+		return record.TTL
+	}
+	return p.ttl
+}
+
+// indexOf returns the index of the value in the record set, or -1.
+func indexOf(set *rrset, value string) int {
+	for i, v := range set.Values {
+		if sameValue(set.Type, v, value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Ensure Provider implements the provider interfaces at compile time.
+var (
+	_ provider.Provider    = (*Provider)(nil)
+	_ provider.Updater     = (*Provider)(nil)
+	_ provider.BulkCreator = (*Provider)(nil)
+)
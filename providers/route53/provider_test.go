@@ -0,0 +1,368 @@
+package route53
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/smithy-go"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// fakeAPI is an in-memory Route53 API for a single hosted zone. With lag
+// set, changes are only listed after sync, like a change that is PENDING.
+type fakeAPI struct {
+	mu       sync.Mutex
+	sets     []types.ResourceRecordSet
+	lag      bool
+	queued   [][]types.Change
+	status   map[string]types.ChangeStatus
+	batches  int
+	pageSize int32
+	err      error
+}
+
+func (f *fakeAPI) GetHostedZone(_ context.Context, in *route53.GetHostedZoneInput, _ ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &route53.GetHostedZoneOutput{HostedZone: &types.HostedZone{Id: in.Id, Name: aws.String("example.com.")}}, nil
+}
+
+func (f *fakeAPI) ListResourceRecordSets(_ context.Context, in *route53.ListResourceRecordSetsInput, _ ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sort.Slice(f.sets, func(i, j int) bool { return setOrder(f.sets[i]) < setOrder(f.sets[j]) })
+	start := aws.ToString(in.StartRecordName) + " " + string(in.StartRecordType)
+	limit := f.pageSize
+	if in.MaxItems != nil {
+		limit = *in.MaxItems
+	}
+
+	out := &route53.ListResourceRecordSetsOutput{}
+	for _, set := range f.sets {
+		if in.StartRecordName != nil && setOrder(set) < start {
+			continue
+		}
+		if limit > 0 && int32(len(out.ResourceRecordSets)) == limit {
+			out.IsTruncated = true
+			out.NextRecordName = set.Name
+			out.NextRecordType = set.Type
+			break
+		}
+		out.ResourceRecordSets = append(out.ResourceRecordSets, set)
+	}
+	return out, nil
+}
+
+func (f *fakeAPI) ChangeResourceRecordSets(_ context.Context, in *route53.ChangeResourceRecordSetsInput, _ ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.status == nil {
+		f.status = make(map[string]types.ChangeStatus)
+	}
+	f.batches++
+	id := fmt.Sprintf("/change/C%d", f.batches)
+	if f.lag {
+		f.queued = append(f.queued, in.ChangeBatch.Changes)
+		f.status[id] = types.ChangeStatusPending
+	} else {
+		f.apply(in.ChangeBatch.Changes)
+		f.status[id] = types.ChangeStatusInsync
+	}
+	return &route53.ChangeResourceRecordSetsOutput{ChangeInfo: &types.ChangeInfo{Id: aws.String(id), Status: f.status[id]}}, nil
+}
+
+func (f *fakeAPI) GetChange(_ context.Context, in *route53.GetChangeInput, _ ...func(*route53.Options)) (*route53.GetChangeOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &route53.GetChangeOutput{ChangeInfo: &types.ChangeInfo{Id: in.Id, Status: f.status[aws.ToString(in.Id)]}}, nil
+}
+
+// sync applies queued changes and reports every change as INSYNC.
+func (f *fakeAPI) sync() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, changes := range f.queued {
+		f.apply(changes)
+	}
+	f.queued = nil
+	for id := range f.status {
+		f.status[id] = types.ChangeStatusInsync
+	}
+}
+
+func (f *fakeAPI) apply(changes []types.Change) {
+	for _, change := range changes {
+		set := *change.ResourceRecordSet
+		f.sets = slices.DeleteFunc(f.sets, func(s types.ResourceRecordSet) bool {
+			return aws.ToString(s.Name) == aws.ToString(set.Name) && s.Type == set.Type
+		})
+		if change.Action != types.ChangeActionDelete {
+			f.sets = append(f.sets, set)
+		}
+	}
+}
+
+// find returns the values and TTL of a record set, or nil values if absent.
+func (f *fakeAPI) find(name, rrType string) ([]string, int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, set := range f.sets {
+		if aws.ToString(set.Name) == name && string(set.Type) == rrType {
+			var values []string
+			for _, rr := range set.ResourceRecords {
+				values = append(values, aws.ToString(rr.Value))
+			}
+			return values, aws.ToInt64(set.TTL)
+		}
+	}
+	return nil, 0
+}
+
+func setOrder(set types.ResourceRecordSet) string {
+	return aws.ToString(set.Name) + " " + string(set.Type)
+}
+
+func simpleSet(name, rrType string, ttl int64, values ...string) types.ResourceRecordSet {
+	set := types.ResourceRecordSet{Name: aws.String(name), Type: types.RRType(rrType), TTL: aws.Int64(ttl)}
+	for _, value := range values {
+		set.ResourceRecords = append(set.ResourceRecords, types.ResourceRecord{Value: aws.String(value)})
+	}
+	return set
+}
+
+func newTestProvider(t *testing.T, fake *fakeAPI) *Provider {
+	t.Helper()
+	p, err := New("route53", &Config{ZoneID: "Z123", Region: DefaultRegion, TTL: 300}, withAPI(fake))
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestProvider_Ping(t *testing.T) {
+	fake := &fakeAPI{}
+	p := newTestProvider(t, fake)
+	if err := p.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() unexpected error: %v", err)
+	}
+	if p.Zone() != "example.com" {
+		t.Errorf("Zone() = %q, want example.com", p.Zone())
+	}
+
+	fake.err = &smithy.GenericAPIError{Code: "Throttling", Message: "Rate exceeded"}
+	if err := p.Ping(context.Background()); !errors.Is(err, provider.ErrQuota) {
+		t.Errorf("Ping() error = %v, want ErrQuota", err)
+	}
+}
+
+func TestProvider_List(t *testing.T) {
+	alias := simpleSet("www.example.com.", "A", 0)
+	alias.AliasTarget = &types.AliasTarget{DNSName: aws.String("lb.amazonaws.com.")}
+	weighted := simpleSet("api.example.com.", "A", 60, "10.0.0.9")
+	weighted.SetIdentifier = aws.String("blue")
+
+	fake := &fakeAPI{pageSize: 2, sets: []types.ResourceRecordSet{
+		simpleSet("example.com.", "SOA", 900, "ns-1.awsdns-00.com. awsdns-hostmaster.amazon.com. 1 7200 900 1209600 86400"),
+		simpleSet("app.example.com.", "A", 300, "10.0.0.1", "10.0.0.2"),
+		simpleSet("_dnsweaver.app.example.com.", "TXT", 300, `"heritage=dnsweaver"`),
+		simpleSet(`\052.dev.example.com.`, "CNAME", 300, "app.example.com."),
+		simpleSet("example.com.", "MX", 3600, "10 mail.example.com."),
+		alias,
+		weighted,
+	}}
+	p := newTestProvider(t, fake)
+
+	records, err := p.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, r := range records {
+		got = append(got, fmt.Sprintf("%s %s %s", r.Hostname, r.Type, r.Target))
+	}
+	sort.Strings(got)
+	want := []string{
+		"*.dev.example.com CNAME app.example.com",
+		"_dnsweaver.app.example.com TXT heritage=dnsweaver",
+		"app.example.com A 10.0.0.1",
+		"app.example.com A 10.0.0.2",
+		"example.com MX 10 mail.example.com",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+}
+
+func TestProvider_CreateAndDelete(t *testing.T) {
+	fake := &fakeAPI{}
+	p := newTestProvider(t, fake)
+	ctx := context.Background()
+
+	first := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"}
+	second := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.2", TTL: 60}
+
+	if err := p.Create(ctx, first); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if err := p.Create(ctx, second); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	values, ttl := fake.find("app.example.com.", "A")
+	if !slices.Equal(values, []string{"10.0.0.1", "10.0.0.2"}) || ttl != 60 {
+		t.Fatalf("record set after two creates = %v (TTL %d), want both values with TTL 60", values, ttl)
+	}
+
+	if err := p.Create(ctx, first); !errors.Is(err, provider.ErrConflict) {
+		t.Errorf("duplicate Create() error = %v, want ErrConflict", err)
+	}
+
+	if err := p.Delete(ctx, first); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if values, _ := fake.find("app.example.com.", "A"); !slices.Equal(values, []string{"10.0.0.2"}) {
+		t.Fatalf("record set after delete = %v, want only 10.0.0.2", values)
+	}
+
+	if err := p.Delete(ctx, second); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if values, _ := fake.find("app.example.com.", "A"); values != nil {
+		t.Errorf("record set should be removed when empty, got %v", values)
+	}
+
+	batches := fake.batches
+	if err := p.Delete(ctx, second); err != nil {
+		t.Errorf("Delete() of missing record unexpected error: %v", err)
+	}
+	if fake.batches != batches {
+		t.Error("Delete() of missing record should not submit a change")
+	}
+}
+
+func TestProvider_Update(t *testing.T) {
+	fake := &fakeAPI{sets: []types.ResourceRecordSet{
+		simpleSet("app.example.com.", "CNAME", 300, "old.example.com."),
+	}}
+	p := newTestProvider(t, fake)
+	ctx := context.Background()
+
+	existing := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeCNAME, Target: "old.example.com"}
+	desired := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeCNAME, Target: "new.example.com", TTL: 120}
+
+	if err := p.Update(ctx, existing, desired); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	values, ttl := fake.find("app.example.com.", "CNAME")
+	if !slices.Equal(values, []string{"new.example.com"}) || ttl != 120 {
+		t.Errorf("record set after update = %v (TTL %d)", values, ttl)
+	}
+	if fake.batches != 1 {
+		t.Errorf("Update() submitted %d change batches, want 1", fake.batches)
+	}
+
+	if err := p.Update(ctx, existing, desired); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("Update() of missing record error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestProvider_PendingChanges(t *testing.T) {
+	fake := &fakeAPI{lag: true, sets: []types.ResourceRecordSet{
+		simpleSet("old.example.com.", "A", 300, "10.0.0.9"),
+	}}
+	p := newTestProvider(t, fake)
+	ctx := context.Background()
+
+	app := provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"}
+	if err := p.Create(ctx, app); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	// A second create must build on the pending set, not the stale listing
+	if err := p.Create(ctx, provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.2"}); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if err := p.Delete(ctx, provider.Record{Hostname: "old.example.com", Type: provider.RecordTypeA, Target: "10.0.0.9"}); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+
+	listed := func() []string {
+		records, err := p.List(ctx)
+		if err != nil {
+			t.Fatalf("List() unexpected error: %v", err)
+		}
+		var got []string
+		for _, r := range records {
+			got = append(got, r.Hostname+" "+r.Target)
+		}
+		sort.Strings(got)
+		return got
+	}
+
+	want := []string{"app.example.com 10.0.0.1", "app.example.com 10.0.0.2"}
+	if got := listed(); !slices.Equal(got, want) {
+		t.Errorf("List() while pending = %v, want %v", got, want)
+	}
+
+	fake.sync()
+	if got := listed(); !slices.Equal(got, want) {
+		t.Errorf("List() after sync = %v, want %v", got, want)
+	}
+	if len(p.pending) != 0 {
+		t.Errorf("%d changes still pending after INSYNC, want none", len(p.pending))
+	}
+}
+
+func TestProvider_BulkCreate(t *testing.T) {
+	fake := &fakeAPI{sets: []types.ResourceRecordSet{
+		simpleSet("app.example.com.", "A", 300, "10.0.0.1"),
+	}}
+	p := newTestProvider(t, fake)
+
+	var records []provider.Record
+	for i := range 600 {
+		name := fmt.Sprintf("host%d.example.com", i)
+		records = append(records,
+			provider.Record{Hostname: name, Type: provider.RecordTypeA, Target: "10.0.1.1"},
+			provider.Record{Hostname: "_dnsweaver." + name, Type: provider.RecordTypeTXT, Target: "heritage=dnsweaver"},
+		)
+	}
+	records = append(records,
+		provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.1"},
+		provider.Record{Hostname: "app.example.com", Type: provider.RecordTypeA, Target: "10.0.0.2"},
+	)
+
+	if err := p.BulkCreate(context.Background(), records); err != nil {
+		t.Fatalf("BulkCreate() unexpected error: %v", err)
+	}
+	// 1201 UPSERTs with one value each count twice toward the 1000 value limit
+	if fake.batches != 3 {
+		t.Errorf("BulkCreate() submitted %d change batches, want 3", fake.batches)
+	}
+	if values, _ := fake.find("app.example.com.", "A"); !slices.Equal(values, []string{"10.0.0.1", "10.0.0.2"}) {
+		t.Errorf("app.example.com values = %v, want existing value kept", values)
+	}
+	if values, _ := fake.find("_dnsweaver.host599.example.com.", "TXT"); !slices.Equal(values, []string{`"heritage=dnsweaver"`}) {
+		t.Errorf("ownership TXT values = %v", values)
+	}
+
+	err := p.BulkCreate(context.Background(), []provider.Record{{Hostname: "bad.example.com", Type: provider.RecordTypeA, Target: "nope"}})
+	if err == nil || !strings.Contains(err.Error(), "bad.example.com") {
+		t.Errorf("BulkCreate() error = %v, want invalid record error", err)
+	}
+}
@@ -146,6 +146,9 @@ func (c *Client) doRequest(ctx context.Context, endpoint string, params url.Valu
 	}
 
 	if resp.StatusCode != http.StatusOK {
+		if base := provider.ErrorForStatus(resp.StatusCode); base != nil {
+			return nil, fmt.Errorf("%w: status %d: %s", base, resp.StatusCode, string(body))
+		}
 		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
 	}
 
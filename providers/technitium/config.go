@@ -18,6 +18,8 @@ type Config struct {
 	Zone               string // DNS zone to manage
 	TTL                int    // Record TTL (defaults to DefaultTTL)
 	InsecureSkipVerify bool   // Skip TLS certificate verification (use with caution)
+	HTTP2              bool   // Negotiate HTTP/2 for HTTPS endpoints
+	MaxIdleConns       int    // Idle keep-alive connections to keep (0 = net/http default)
 }
 
 // Validate checks that all required configuration is present.
@@ -36,6 +38,9 @@ func (c *Config) Validate() error {
 	if c.TTL < 0 {
 		errs = append(errs, "TTL must be non-negative")
 	}
+	if c.MaxIdleConns < 0 {
+		errs = append(errs, "MAX_IDLE_CONNS must be non-negative")
+	}
 
 	if len(errs) > 0 {
 		return fmt.Errorf("technitium config validation failed: %s", strings.Join(errs, "; "))
@@ -55,6 +60,8 @@ func (c *Config) Validate() error {
 //   - TOKEN: API token (required, supports _FILE suffix for Docker secrets)
 //   - ZONE: DNS zone to manage (required)
 //   - TTL: Record TTL (optional, defaults to 300)
+//   - HTTP2: Negotiate HTTP/2 for HTTPS endpoints (optional, default: false)
+//   - MAX_IDLE_CONNS: Idle keep-alive connections to keep (optional)
 func LoadConfig(instanceName string) (*Config, error) {
 	prefix := envPrefix(instanceName)
 
@@ -79,6 +86,10 @@ func LoadConfig(instanceName string) (*Config, error) {
 		config.InsecureSkipVerify = strings.EqualFold(skipStr, "true") || skipStr == "1"
 	}
 
+	if err := config.applyConnectionSettings(func(key string) string { return getEnv(prefix + key) }); err != nil {
+		return nil, err
+	}
+
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
 	}
@@ -86,6 +97,21 @@ func LoadConfig(instanceName string) (*Config, error) {
 	return config, nil
 }
 
+// applyConnectionSettings parses the optional HTTP2 and MAX_IDLE_CONNS settings using get.
+func (c *Config) applyConnectionSettings(get func(key string) string) error {
+	if v := get("HTTP2"); v != "" {
+		c.HTTP2 = strings.EqualFold(v, "true") || v == "1"
+	}
+	if v := get("MAX_IDLE_CONNS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid MAX_IDLE_CONNS value %q: %w", v, err)
+		}
+		c.MaxIdleConns = n
+	}
+	return nil
+}
+
 // envPrefix converts an instance name to an environment variable prefix.
 // Example: "internal-dns" → "DNSWEAVER_INTERNAL_DNS_"
 func envPrefix(instanceName string) string {
@@ -138,7 +164,7 @@ func IsConfigError(err error) bool {
 // configuration that was already parsed from environment variables.
 //
 // Required keys: URL, TOKEN, ZONE
-// Optional keys: TTL (defaults to 300)
+// Optional keys: TTL (defaults to 300), INSECURE_SKIP_VERIFY, HTTP2, MAX_IDLE_CONNS
 func LoadConfigFromMap(instanceName string, configMap map[string]string) (*Config, error) {
 	config := &Config{
 		URL:   configMap["URL"],
@@ -161,6 +187,10 @@ func LoadConfigFromMap(instanceName string, configMap map[string]string) (*Confi
 		config.InsecureSkipVerify = strings.EqualFold(skipStr, "true") || skipStr == "1"
 	}
 
+	if err := config.applyConnectionSettings(func(key string) string { return configMap[key] }); err != nil {
+		return nil, err
+	}
+
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
 	}
@@ -288,3 +288,45 @@ func TestLoadConfigFromMap_InsecureSkipVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadConfigFromMap_ConnectionSettings(t *testing.T) {
+	base := map[string]string{
+		"URL":   "https://dns.example.com:53443",
+		"TOKEN": "token",
+		"ZONE":  "example.com",
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		config, err := LoadConfigFromMap("test", base)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config.HTTP2 || config.MaxIdleConns != 0 {
+			t.Errorf("HTTP2 = %v, MaxIdleConns = %d, want false, 0", config.HTTP2, config.MaxIdleConns)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		configMap := map[string]string{"HTTP2": "true", "MAX_IDLE_CONNS": "16"}
+		for k, v := range base {
+			configMap[k] = v
+		}
+		config, err := LoadConfigFromMap("test", configMap)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !config.HTTP2 || config.MaxIdleConns != 16 {
+			t.Errorf("HTTP2 = %v, MaxIdleConns = %d, want true, 16", config.HTTP2, config.MaxIdleConns)
+		}
+	})
+
+	t.Run("invalid max idle conns", func(t *testing.T) {
+		configMap := map[string]string{"MAX_IDLE_CONNS": "many"}
+		for k, v := range base {
+			configMap[k] = v
+		}
+		if _, err := LoadConfigFromMap("test", configMap); err == nil {
+			t.Error("expected error for invalid MAX_IDLE_CONNS")
+		}
+	})
+}
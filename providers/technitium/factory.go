@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
@@ -22,10 +23,21 @@ func Factory() provider.Factory {
 		// Merge TLS skip verify: HTTP config from registry (global/per-instance) OR legacy per-provider setting
 		tlsSkipVerify := cfg.HTTP.TLSSkipVerify || providerCfg.InsecureSkipVerify
 
+		// HTTP/2 is only negotiated over TLS; plain HTTP endpoints keep HTTP/1.1
+		http2 := providerCfg.HTTP2 && strings.HasPrefix(strings.ToLower(providerCfg.URL), "https://")
+		if providerCfg.HTTP2 && !http2 && cfg.HTTP.Logger != nil {
+			cfg.HTTP.Logger.Warn("HTTP2 requires an https:// URL, using HTTP/1.1 for Technitium provider",
+				slog.String("provider", cfg.Name),
+				slog.String("url", providerCfg.URL),
+			)
+		}
+
 		// Create HTTP client with the merged HTTP configuration
 		httpClient := httputil.NewClient(&httputil.ClientConfig{
 			Timeout:       cfg.HTTP.Timeout,
 			TLSSkipVerify: tlsSkipVerify,
+			HTTP2:         http2,
+			MaxIdleConns:  providerCfg.MaxIdleConns,
 			UserAgent:     cfg.HTTP.UserAgent,
 			Logger:        cfg.HTTP.Logger,
 		})
@@ -94,8 +94,9 @@ func (p *Provider) Type() string {
 // Technitium supports all features: TXT ownership, native update, and all record types.
 func (p *Provider) Capabilities() provider.Capabilities {
 	return provider.Capabilities{
-		SupportsOwnershipTXT: true,
-		SupportsNativeUpdate: true,
+		SupportsOwnershipTXT:    true,
+		SupportsNativeUpdate:    true,
+		SupportsMultipleTargets: true,
 		SupportedRecordTypes: []provider.RecordType{
 			provider.RecordTypeA,
 			provider.RecordTypeAAAA,
@@ -1,6 +1,7 @@
 package webhook
 
 import (
+	"crypto/tls"
 	"fmt"
 	"os"
 	"strconv"
@@ -25,6 +26,35 @@ type Config struct {
 	AuthToken  string        // Authentication token value (optional)
 	Retries    int           // Number of retry attempts (default: 3)
 	RetryDelay time.Duration // Base delay between retries (default: 1s)
+
+	ClientCertFile     string // PEM client certificate for mutual TLS (optional)
+	ClientKeyFile      string // PEM private key for ClientCertFile (optional)
+	InsecureSkipVerify bool   // Skip TLS certificate verification (use with caution)
+}
+
+// ClientCertificates loads the configured TLS client certificate.
+// Returns nil if no client certificate is configured.
+func (c *Config) ClientCertificates() ([]tls.Certificate, error) {
+	if c.ClientCertFile == "" {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading client certificate: %w", err)
+	}
+	return []tls.Certificate{cert}, nil
+}
+
+// applyTLSSettings reads the mutual TLS and verification settings using get.
+// SKIP_TLS_VERIFY is accepted as an alias for INSECURE_SKIP_VERIFY.
+func (c *Config) applyTLSSettings(get func(key string) string) {
+	c.ClientCertFile = get("CLIENT_CERT_FILE")
+	c.ClientKeyFile = get("CLIENT_KEY_FILE")
+	for _, key := range []string{"INSECURE_SKIP_VERIFY", "SKIP_TLS_VERIFY"} {
+		if v := get(key); v != "" {
+			c.InsecureSkipVerify = strings.EqualFold(v, "true") || v == "1"
+		}
+	}
 }
 
 // Validate checks that all required configuration is present.
@@ -57,6 +87,10 @@ func (c *Config) Validate() error {
 		errs = append(errs, "RETRY_DELAY must be non-negative")
 	}
 
+	if (c.ClientCertFile == "") != (c.ClientKeyFile == "") {
+		errs = append(errs, "CLIENT_CERT_FILE and CLIENT_KEY_FILE must be set together")
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("webhook config validation failed: %s", strings.Join(errs, "; "))
 	}
@@ -77,6 +111,8 @@ func (c *Config) Validate() error {
 //   - AUTH_TOKEN: Auth token value (required if AUTH_HEADER set, supports _FILE)
 //   - RETRIES: Number of retry attempts (optional, default: 3)
 //   - RETRY_DELAY: Base delay between retries (optional, default: 1s)
+//   - CLIENT_CERT_FILE / CLIENT_KEY_FILE: PEM client certificate and key for mutual TLS (optional)
+//   - INSECURE_SKIP_VERIFY (alias SKIP_TLS_VERIFY): Skip TLS verification (optional, default: false)
 func LoadConfig(instanceName string) (*Config, error) {
 	prefix := envPrefix(instanceName)
 
@@ -116,6 +152,8 @@ func LoadConfig(instanceName string) (*Config, error) {
 		config.RetryDelay = delay
 	}
 
+	config.applyTLSSettings(func(key string) string { return getEnv(prefix + key) })
+
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
 	}
@@ -187,6 +225,8 @@ func LoadConfigFromMap(instanceName string, config map[string]string) (*Config,
 		}
 	}
 
+	cfg.applyTLSSettings(func(key string) string { return config[key] })
+
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
 	}
@@ -48,6 +48,15 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "client cert without key",
+			config: Config{
+				URL:            "https://webhook.example.com",
+				ClientCertFile: "/etc/dnsweaver/client.crt",
+			},
+			wantErr: true,
+			errMsg:  "CLIENT_CERT_FILE and CLIENT_KEY_FILE must be set together",
+		},
 		{
 			name: "missing URL",
 			config: Config{
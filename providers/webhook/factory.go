@@ -1,6 +1,7 @@
 package webhook
 
 import (
+	"fmt"
 	"log/slog"
 
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
@@ -17,18 +18,27 @@ func Factory() provider.Factory {
 			return nil, err
 		}
 
+		// Load the mutual TLS client certificate once; the transport built
+		// below is shared by all requests of this provider instance
+		certs, err := providerCfg.ClientCertificates()
+		if err != nil {
+			return nil, fmt.Errorf("configuration for %s: %w", cfg.Name, err)
+		}
+		tlsSkipVerify := cfg.HTTP.TLSSkipVerify || providerCfg.InsecureSkipVerify
+
 		// Create HTTP client with the factory's HTTP configuration
 		// Note: Webhook provider has its own timeout handling via config.Timeout,
 		// but we use the factory's HTTP config for TLS, user-agent, and logging
 		httpClient := httputil.NewClient(&httputil.ClientConfig{
 			Timeout:       cfg.HTTP.Timeout,
-			TLSSkipVerify: cfg.HTTP.TLSSkipVerify,
+			TLSSkipVerify: tlsSkipVerify,
+			Certificates:  certs,
 			UserAgent:     cfg.HTTP.UserAgent,
 			Logger:        cfg.HTTP.Logger,
 		})
 
 		// Log warning if TLS verification is disabled
-		if cfg.HTTP.TLSSkipVerify && cfg.HTTP.Logger != nil {
+		if tlsSkipVerify && cfg.HTTP.Logger != nil {
 			cfg.HTTP.Logger.Warn("TLS certificate verification disabled for Webhook provider",
 				slog.String("provider", cfg.Name),
 				slog.String("url", providerCfg.URL),
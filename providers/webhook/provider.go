@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"time"
 
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
 )
 
@@ -67,6 +68,17 @@ func New(name string, config *Config, opts ...ProviderOption) (*Provider, error)
 		WithRetries(config.Retries),
 		WithRetryDelay(config.RetryDelay),
 	}
+	if p.httpClient == nil && (config.ClientCertFile != "" || config.InsecureSkipVerify) {
+		certs, err := config.ClientCertificates()
+		if err != nil {
+			return nil, err
+		}
+		p.httpClient = httputil.NewClient(&httputil.ClientConfig{
+			Timeout:       config.Timeout,
+			TLSSkipVerify: config.InsecureSkipVerify,
+			Certificates:  certs,
+		})
+	}
 	if p.httpClient != nil {
 		clientOpts = append(clientOpts, WithHTTPClient(p.httpClient))
 	}
@@ -126,6 +138,8 @@ func NewFromMap(name string, config map[string]string) (*Provider, error) {
 		}
 	}
 
+	cfg.applyTLSSettings(func(key string) string { return config[key] })
+
 	return New(name, cfg)
 }
 
@@ -150,8 +164,9 @@ func (p *Provider) Type() string {
 // is responsible for handling all record types and operations.
 func (p *Provider) Capabilities() provider.Capabilities {
 	return provider.Capabilities{
-		SupportsOwnershipTXT: true,
-		SupportsNativeUpdate: true,
+		SupportsOwnershipTXT:    true,
+		SupportsNativeUpdate:    true,
+		SupportsMultipleTargets: true,
 		SupportedRecordTypes: []provider.RecordType{
 			provider.RecordTypeA,
 			provider.RecordTypeAAAA,
@@ -2,9 +2,19 @@ package webhook
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -363,3 +373,95 @@ func TestFactory(t *testing.T) {
 		}
 	})
 }
+
+func TestProvider_ClientCertificate(t *testing.T) {
+	certFile, keyFile := writeTestKeyPair(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	t.Run("presents client certificate", func(t *testing.T) {
+		p, err := New("test", &Config{
+			URL:                server.URL,
+			Timeout:            5 * time.Second,
+			ClientCertFile:     certFile,
+			ClientKeyFile:      keyFile,
+			InsecureSkipVerify: true,
+		})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if err := p.Ping(context.Background()); err != nil {
+			t.Errorf("Ping() unexpected error: %v", err)
+		}
+	})
+
+	t.Run("handshake fails without certificate", func(t *testing.T) {
+		p, err := New("test", &Config{
+			URL:                server.URL,
+			Timeout:            5 * time.Second,
+			InsecureSkipVerify: true,
+		})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if err := p.Ping(context.Background()); err == nil {
+			t.Error("Ping() expected error without client certificate")
+		}
+	})
+
+	t.Run("unreadable key pair", func(t *testing.T) {
+		_, err := New("test", &Config{
+			URL:            server.URL,
+			ClientCertFile: certFile,
+			ClientKeyFile:  filepath.Join(t.TempDir(), "missing.key"),
+		})
+		if err == nil {
+			t.Error("New() expected error for missing key file")
+		}
+	})
+}
+
+// writeTestKeyPair writes a self-signed certificate and key to temp files.
+func writeTestKeyPair(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "dnsweaver-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshaling key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("writing certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("writing key: %v", err)
+	}
+	return certFile, keyFile
+}
@@ -0,0 +1,378 @@
+package windns
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf16"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/sshutil"
+)
+
+// passwordEnvVar passes the WinRM password to the local powershell.exe
+// process so it never appears on a command line.
+const passwordEnvVar = "DNSWEAVER_WINDNS_PASSWORD"
+
+// CommandRunner executes a PowerShell command line and returns its output.
+// It is satisfied by *sshutil.SSHCommandRunner.
+type CommandRunner interface {
+	RunWithOutput(ctx context.Context, command string) (*sshutil.CommandResult, error)
+}
+
+// windnsRecord is a resource record as reported by the list script.
+type windnsRecord struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	TTL      int    `json:"ttl"`
+	Data     string `json:"data"`
+	Priority int    `json:"priority"`
+	Weight   int    `json:"weight"`
+	Port     int    `json:"port"`
+}
+
+// Client manages records on a Windows DNS Server by running DnsServer
+// PowerShell cmdlets through a CommandRunner.
+type Client struct {
+	zone      string
+	dnsServer string
+	runner    CommandRunner
+	wrap      func(script string) string // Wraps scripts for remote execution (WinRM mode)
+	logger    *slog.Logger
+}
+
+// ClientOption is a functional option for configuring the Client.
+type ClientOption func(*Client)
+
+// WithLogger sets a custom logger for the client.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithRunner sets the command runner (for testing).
+func WithRunner(runner CommandRunner) ClientOption {
+	return func(c *Client) {
+		c.runner = runner
+	}
+}
+
+// NewClient creates a new Windows DNS client. Unless a runner is supplied
+// via WithRunner, the transport is chosen from config.Mode.
+func NewClient(config *Config, opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		zone:      config.Zone,
+		dnsServer: config.DNSServer,
+		logger:    slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.runner == nil {
+		switch config.Mode {
+		case ModeSSH:
+			runner, err := newSSHRunner(config, c.logger)
+			if err != nil {
+				return nil, err
+			}
+			c.runner = runner
+		default:
+			c.runner = &localRunner{password: config.Password}
+		}
+	}
+
+	if config.Mode == ModeWinRM {
+		c.wrap = invokeCommand(config.Host, config.User)
+	}
+
+	return c, nil
+}
+
+// Close releases the underlying transport, if it holds one.
+func (c *Client) Close() error {
+	if closer, ok := c.runner.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Ping checks that the zone exists on the DNS server.
+func (c *Client) Ping(ctx context.Context) error {
+	script := fmt.Sprintf("Get-DnsServerZone -Name %s%s | Out-Null", psQuote(c.zone), c.computerArg())
+	if _, err := c.run(ctx, script); err != nil {
+		return fmt.Errorf("checking zone %s: %w", c.zone, err)
+	}
+	return nil
+}
+
+// List returns the A, AAAA, CNAME, TXT, and SRV records in the zone.
+func (c *Client) List(ctx context.Context) ([]windnsRecord, error) {
+	script := fmt.Sprintf(`$records = Get-DnsServerResourceRecord -ZoneName %s%s |
+  Where-Object { $_.RecordType -in 'A','AAAA','CNAME','TXT','SRV' } |
+  ForEach-Object {
+    $d = $_.RecordData
+    $data = switch ($_.RecordType) {
+      'A' { $d.IPv4Address.IPAddressToString }
+      'AAAA' { $d.IPv6Address.IPAddressToString }
+      'CNAME' { $d.HostNameAlias }
+      'TXT' { $d.DescriptiveText }
+      'SRV' { $d.DomainName }
+    }
+    [pscustomobject]@{
+      name = $_.HostName; type = [string]$_.RecordType; ttl = [int]$_.TimeToLive.TotalSeconds
+      data = [string]$data; priority = [int]$d.Priority; weight = [int]$d.Weight; port = [int]$d.Port
+    }
+  }
+ConvertTo-Json -InputObject @($records) -Compress`, psQuote(c.zone), c.computerArg())
+
+	out, err := c.run(ctx, script)
+	if err != nil {
+		return nil, err
+	}
+
+	out = strings.TrimSpace(out)
+	if out == "" {
+		return nil, nil
+	}
+
+	var records []windnsRecord
+	if err := json.Unmarshal([]byte(out), &records); err != nil {
+		return nil, fmt.Errorf("parsing record list: %w", err)
+	}
+	return records, nil
+}
+
+// Create adds a record to the zone.
+func (c *Client) Create(ctx context.Context, name string, record provider.Record, ttl int) error {
+	var typeArgs string
+	switch record.Type {
+	case provider.RecordTypeA:
+		typeArgs = "-A -IPv4Address " + psQuote(record.Target)
+	case provider.RecordTypeAAAA:
+		typeArgs = "-AAAA -IPv6Address " + psQuote(record.Target)
+	case provider.RecordTypeCNAME:
+		typeArgs = "-CName -HostNameAlias " + psQuote(record.Target)
+	case provider.RecordTypeTXT:
+		typeArgs = "-Txt -DescriptiveText " + psQuote(record.Target)
+	case provider.RecordTypeSRV:
+		if record.SRV == nil {
+			return fmt.Errorf("SRV data is required")
+		}
+		typeArgs = fmt.Sprintf("-Srv -DomainName %s -Priority %d -Weight %d -Port %d",
+			psQuote(record.Target), record.SRV.Priority, record.SRV.Weight, record.SRV.Port)
+	default:
+		return fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+
+	script := fmt.Sprintf("Add-DnsServerResourceRecord -ZoneName %s -Name %s %s -TimeToLive (New-TimeSpan -Seconds %d)%s",
+		psQuote(c.zone), psQuote(name), typeArgs, ttl, c.computerArg())
+	_, err := c.run(ctx, script)
+	return err
+}
+
+// Delete removes the records matching name, type, and target from the zone.
+// Deleting a record that does not exist is not an error.
+func (c *Client) Delete(ctx context.Context, name string, record provider.Record) error {
+	var match string
+	switch record.Type {
+	case provider.RecordTypeA:
+		match = "$_.RecordData.IPv4Address.IPAddressToString -eq " + psQuote(record.Target)
+	case provider.RecordTypeAAAA:
+		target := record.Target
+		if ip := net.ParseIP(target); ip != nil {
+			target = ip.String()
+		}
+		match = "$_.RecordData.IPv6Address.IPAddressToString -eq " + psQuote(target)
+	case provider.RecordTypeCNAME:
+		match = "$_.RecordData.HostNameAlias.TrimEnd('.') -eq " + psQuote(strings.TrimSuffix(record.Target, "."))
+	case provider.RecordTypeTXT:
+		match = "$_.RecordData.DescriptiveText -eq " + psQuote(record.Target)
+	case provider.RecordTypeSRV:
+		match = "$_.RecordData.DomainName.TrimEnd('.') -eq " + psQuote(strings.TrimSuffix(record.Target, "."))
+		if record.SRV != nil {
+			match += fmt.Sprintf(" -and $_.RecordData.Port -eq %d", record.SRV.Port)
+		}
+	default:
+		return fmt.Errorf("unsupported record type: %s", record.Type)
+	}
+
+	script := fmt.Sprintf(`Get-DnsServerResourceRecord -ZoneName %s -Name %s -RRType %s%s -ErrorAction SilentlyContinue |
+  Where-Object { %s } |
+  Remove-DnsServerResourceRecord -ZoneName %s%s -Force`,
+		psQuote(c.zone), psQuote(name), string(record.Type), c.computerArg(),
+		match,
+		psQuote(c.zone), c.computerArg())
+	_, err := c.run(ctx, script)
+	return err
+}
+
+// computerArg returns the -ComputerName argument when a separate DNS server is configured.
+func (c *Client) computerArg() string {
+	if c.dnsServer == "" {
+		return ""
+	}
+	return " -ComputerName " + psQuote(c.dnsServer)
+}
+
+// run executes script and returns its stdout. A non-zero exit code is
+// reported as an error carrying the PowerShell error output.
+func (c *Client) run(ctx context.Context, script string) (string, error) {
+	// Set inside the (possibly remote) script block, where the caller's preference does not apply
+	script = "$ErrorActionPreference = 'Stop'\n" + script
+	if c.wrap != nil {
+		script = "$ErrorActionPreference = 'Stop'\n" + c.wrap(script)
+	}
+	command := powershellCommand(script)
+
+	result, err := c.runner.RunWithOutput(ctx, command)
+	if err != nil {
+		return "", err
+	}
+	if result.ExitCode != 0 {
+		msg := strings.TrimSpace(result.Stderr)
+		if msg == "" {
+			msg = strings.TrimSpace(result.Stdout)
+		}
+		return "", fmt.Errorf("powershell exited with code %d: %s", result.ExitCode, msg)
+	}
+	return result.Stdout, nil
+}
+
+// powershellCommand builds a powershell.exe command line for script.
+// The script is passed with -EncodedCommand (base64 UTF-16LE) so it survives
+// both cmd.exe and PowerShell as the remote SSH shell without quoting issues.
+func powershellCommand(script string) string {
+	return "powershell.exe -NoProfile -NonInteractive -EncodedCommand " + encodeCommand(script)
+}
+
+// encodeCommand encodes script in the format expected by -EncodedCommand.
+func encodeCommand(script string) string {
+	units := utf16.Encode([]rune(script))
+	buf := make([]byte, len(units)*2)
+	for i, u := range units {
+		binary.LittleEndian.PutUint16(buf[i*2:], u)
+	}
+	return base64.StdEncoding.EncodeToString(buf)
+}
+
+// psQuote returns s as a single-quoted PowerShell string literal.
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// sshRunner runs commands on a Windows jump host over SSH, connecting on first use.
+type sshRunner struct {
+	addr   string
+	client *sshutil.Client
+	runner *sshutil.SSHCommandRunner
+
+	mu sync.Mutex
+}
+
+// newSSHRunner creates an sshRunner for config.Host.
+func newSSHRunner(config *Config, logger *slog.Logger) (*sshRunner, error) {
+	host, port, err := config.sshHostPort()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := sshutil.NewClient(&sshutil.Config{
+		Host:           host,
+		Port:           port,
+		User:           config.User,
+		Password:       config.Password,
+		KnownHostsFile: config.KnownHostsFile,
+	}, sshutil.WithLogger(logger))
+	if err != nil {
+		return nil, fmt.Errorf("creating SSH client: %w", err)
+	}
+
+	return &sshRunner{
+		addr:   net.JoinHostPort(host, strconv.Itoa(port)),
+		client: client,
+		runner: sshutil.NewSSHCommandRunner(client, sshutil.WithCommandLogger(logger)),
+	}, nil
+}
+
+// RunWithOutput connects if needed and runs command on the jump host.
+func (r *sshRunner) RunWithOutput(ctx context.Context, command string) (*sshutil.CommandResult, error) {
+	r.mu.Lock()
+	if !r.client.IsConnected() {
+		if err := r.client.Connect(ctx); err != nil && !errors.Is(err, sshutil.ErrAlreadyConnected) {
+			r.mu.Unlock()
+			return nil, fmt.Errorf("connecting to %s: %w", r.addr, err)
+		}
+	}
+	r.mu.Unlock()
+
+	return r.runner.RunWithOutput(ctx, command)
+}
+
+// Close closes the SSH connection.
+func (r *sshRunner) Close() error {
+	return r.client.Close()
+}
+
+// localRunner runs command lines on the local machine. Commands are split on
+// whitespace, which is safe for powershellCommand output. The WinRM password
+// is passed to the child process in its environment.
+type localRunner struct {
+	password string
+}
+
+// RunWithOutput runs command and returns its result. A non-zero exit code is
+// reported in the result, not as an error.
+func (r *localRunner) RunWithOutput(ctx context.Context, command string) (*sshutil.CommandResult, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Env = append(os.Environ(), passwordEnvVar+"="+r.password)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	result := &sshutil.CommandResult{}
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("running %s: %w", args[0], err)
+		}
+		result.ExitCode = exitErr.ExitCode()
+	}
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+	return result, nil
+}
+
+// invokeCommand wraps script in an Invoke-Command block that runs it on host
+// over WinRM. Without a user, the dnsweaver service account is used.
+func invokeCommand(host, user string) func(string) string {
+	return func(script string) string {
+		if user == "" {
+			return fmt.Sprintf("Invoke-Command -ComputerName %s -ScriptBlock {\n%s\n}", psQuote(host), script)
+		}
+		return fmt.Sprintf("$cred = New-Object System.Management.Automation.PSCredential(%s, (ConvertTo-SecureString $env:%s -AsPlainText -Force))\n"+
+			"Invoke-Command -ComputerName %s -Credential $cred -ScriptBlock {\n%s\n}",
+			psQuote(user), passwordEnvVar, psQuote(host), script)
+	}
+}
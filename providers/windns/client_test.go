@@ -0,0 +1,223 @@
+package windns
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/sshutil"
+)
+
+// fakeRunner records the scripts it is asked to run and returns a canned result.
+type fakeRunner struct {
+	scripts []string
+	result  *sshutil.CommandResult
+	err     error
+}
+
+func (f *fakeRunner) RunWithOutput(_ context.Context, command string) (*sshutil.CommandResult, error) {
+	f.scripts = append(f.scripts, decodeScript(command))
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.result != nil {
+		return f.result, nil
+	}
+	return &sshutil.CommandResult{}, nil
+}
+
+// decodeScript extracts the script from a powershellCommand command line.
+func decodeScript(command string) string {
+	fields := strings.Fields(command)
+	raw, err := base64.StdEncoding.DecodeString(fields[len(fields)-1])
+	if err != nil {
+		return ""
+	}
+	units := make([]uint16, len(raw)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(raw[i*2:])
+	}
+	return string(utf16.Decode(units))
+}
+
+func newTestClient(t *testing.T, runner *fakeRunner, mode, dnsServer string) *Client {
+	t.Helper()
+	c, err := NewClient(&Config{
+		Host:      "jump",
+		User:      "admin",
+		Password:  "secret",
+		Zone:      "corp.example.com",
+		DNSServer: dnsServer,
+		Mode:      mode,
+	}, WithRunner(runner))
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	return c
+}
+
+func TestPowershellCommand_RoundTrip(t *testing.T) {
+	script := "Write-Output 'héllo'\n$x = 1"
+	cmd := powershellCommand(script)
+	if !strings.HasPrefix(cmd, "powershell.exe -NoProfile -NonInteractive -EncodedCommand ") {
+		t.Fatalf("unexpected command prefix: %s", cmd)
+	}
+	if got := decodeScript(cmd); got != script {
+		t.Errorf("decoded script = %q, want %q", got, script)
+	}
+}
+
+func TestPsQuote(t *testing.T) {
+	if got := psQuote("it's"); got != "'it''s'" {
+		t.Errorf("psQuote() = %s, want 'it''s'", got)
+	}
+}
+
+func TestClient_List(t *testing.T) {
+	runner := &fakeRunner{result: &sshutil.CommandResult{
+		Stdout: `[{"name":"app","type":"A","ttl":300,"data":"10.0.0.5","priority":0,"weight":0,"port":0},` +
+			`{"name":"_http._tcp","type":"SRV","ttl":60,"data":"web.corp.example.com.","priority":10,"weight":5,"port":80}]` + "\r\n",
+	}}
+	c := newTestClient(t, runner, ModeSSH, "dc01")
+
+	records, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("List() returned %d records, want 2", len(records))
+	}
+	if records[1].Port != 80 || records[1].Data != "web.corp.example.com." {
+		t.Errorf("unexpected SRV record: %+v", records[1])
+	}
+
+	script := runner.scripts[0]
+	if !strings.Contains(script, "Get-DnsServerResourceRecord -ZoneName 'corp.example.com' -ComputerName 'dc01'") {
+		t.Errorf("script does not target zone and server:\n%s", script)
+	}
+	if strings.Contains(script, "Invoke-Command") {
+		t.Errorf("ssh mode script should not use Invoke-Command:\n%s", script)
+	}
+}
+
+func TestClient_List_Empty(t *testing.T) {
+	runner := &fakeRunner{result: &sshutil.CommandResult{Stdout: "[]"}}
+	c := newTestClient(t, runner, ModeSSH, "")
+
+	records, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("List() returned %d records, want 0", len(records))
+	}
+}
+
+func TestClient_Create(t *testing.T) {
+	tests := []struct {
+		name   string
+		record provider.Record
+		want   string
+	}{
+		{
+			name:   "A",
+			record: provider.Record{Type: provider.RecordTypeA, Target: "10.0.0.5"},
+			want:   "-A -IPv4Address '10.0.0.5'",
+		},
+		{
+			name:   "CNAME",
+			record: provider.Record{Type: provider.RecordTypeCNAME, Target: "proxy.corp.example.com"},
+			want:   "-CName -HostNameAlias 'proxy.corp.example.com'",
+		},
+		{
+			name:   "TXT with quote",
+			record: provider.Record{Type: provider.RecordTypeTXT, Target: "it's owned"},
+			want:   "-Txt -DescriptiveText 'it''s owned'",
+		},
+		{
+			name: "SRV",
+			record: provider.Record{Type: provider.RecordTypeSRV, Target: "web.corp.example.com",
+				SRV: &provider.SRVData{Priority: 10, Weight: 5, Port: 443}},
+			want: "-Srv -DomainName 'web.corp.example.com' -Priority 10 -Weight 5 -Port 443",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeRunner{}
+			c := newTestClient(t, runner, ModeSSH, "")
+
+			if err := c.Create(context.Background(), "app", tt.record, 120); err != nil {
+				t.Fatalf("Create() unexpected error: %v", err)
+			}
+			script := runner.scripts[0]
+			if !strings.Contains(script, "Add-DnsServerResourceRecord -ZoneName 'corp.example.com' -Name 'app' "+tt.want) {
+				t.Errorf("unexpected script:\n%s", script)
+			}
+			if !strings.Contains(script, "-TimeToLive (New-TimeSpan -Seconds 120)") {
+				t.Errorf("script missing TTL:\n%s", script)
+			}
+		})
+	}
+}
+
+func TestClient_Delete(t *testing.T) {
+	runner := &fakeRunner{}
+	c := newTestClient(t, runner, ModeSSH, "")
+
+	record := provider.Record{Type: provider.RecordTypeAAAA, Target: "2001:db8:0:0::1"}
+	if err := c.Delete(context.Background(), "app", record); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+
+	script := runner.scripts[0]
+	for _, want := range []string{
+		"Get-DnsServerResourceRecord -ZoneName 'corp.example.com' -Name 'app' -RRType AAAA",
+		"IPAddressToString -eq '2001:db8::1'",
+		"Remove-DnsServerResourceRecord -ZoneName 'corp.example.com' -Force",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q:\n%s", want, script)
+		}
+	}
+}
+
+func TestClient_WinRMWrapsScript(t *testing.T) {
+	runner := &fakeRunner{}
+	c := newTestClient(t, runner, ModeWinRM, "")
+
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() unexpected error: %v", err)
+	}
+
+	script := runner.scripts[0]
+	if !strings.Contains(script, "Invoke-Command -ComputerName 'jump' -Credential $cred -ScriptBlock {") {
+		t.Errorf("script not wrapped in Invoke-Command:\n%s", script)
+	}
+	if strings.Contains(script, "secret") {
+		t.Errorf("password must not appear in the script:\n%s", script)
+	}
+	if !strings.Contains(script, "$env:"+passwordEnvVar) {
+		t.Errorf("script should read the password from the environment:\n%s", script)
+	}
+}
+
+func TestClient_CommandFailure(t *testing.T) {
+	runner := &fakeRunner{result: &sshutil.CommandResult{
+		ExitCode: 1,
+		Stderr:   "Get-DnsServerZone : The zone corp.example.com was not found\r\n",
+	}}
+	c := newTestClient(t, runner, ModeSSH, "")
+
+	err := c.Ping(context.Background())
+	if err == nil {
+		t.Fatal("Ping() expected error")
+	}
+	if !strings.Contains(err.Error(), "was not found") {
+		t.Errorf("error should include PowerShell output, got: %v", err)
+	}
+}
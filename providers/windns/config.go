@@ -0,0 +1,199 @@
+// Package windns implements the DNSWeaver provider interface for Windows DNS Server
+// using the DnsServer PowerShell module.
+package windns
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// DefaultTTL is the default TTL for Windows DNS records.
+const DefaultTTL = 300
+
+// Transport modes for reaching the Windows DNS Server.
+const (
+	// ModeWinRM runs powershell.exe locally and uses Invoke-Command (WinRM)
+	// to execute the cmdlets on HOST. Only available when dnsweaver runs on Windows.
+	ModeWinRM = "winrm"
+
+	// ModeSSH runs the cmdlets over SSH on HOST, a Windows jump host with the
+	// DnsServer PowerShell module (RSAT) installed.
+	ModeSSH = "ssh"
+)
+
+// Config holds Windows DNS-specific configuration.
+type Config struct {
+	Host      string // WinRM target or SSH jump host (host or host:port)
+	User      string // Account used for WinRM or SSH
+	Password  string // Password for User
+	Zone      string // DNS zone to manage (e.g., "corp.example.com")
+	DNSServer string // DNS server passed as -ComputerName when it differs from Host (optional)
+	Mode      string // Transport: "winrm" or "ssh"
+	TTL       int    // Default record TTL
+
+	KnownHostsFile string // known_hosts file verifying the SSH jump host (optional)
+}
+
+// defaultMode returns the transport used when MODE is not set: WinRM on
+// Windows hosts, SSH to a Windows jump host everywhere else.
+func defaultMode() string {
+	if runtime.GOOS == "windows" {
+		return ModeWinRM
+	}
+	return ModeSSH
+}
+
+// Validate checks that all required configuration is present.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.Host == "" {
+		errs = append(errs, "HOST is required")
+	}
+	if c.Zone == "" {
+		errs = append(errs, "ZONE is required")
+	}
+	if c.TTL < 0 {
+		errs = append(errs, "TTL must be non-negative")
+	}
+
+	switch c.Mode {
+	case ModeWinRM:
+		if runtime.GOOS != "windows" {
+			errs = append(errs, "MODE winrm requires dnsweaver to run on Windows; use MODE ssh with a Windows jump host")
+		}
+		if c.User != "" && c.Password == "" {
+			errs = append(errs, "PASSWORD is required when USER is set")
+		}
+	case ModeSSH:
+		if c.User == "" {
+			errs = append(errs, "USER is required for MODE ssh")
+		}
+		if c.Password == "" {
+			errs = append(errs, "PASSWORD is required for MODE ssh")
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("MODE must be %q or %q, got %q", ModeWinRM, ModeSSH, c.Mode))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("windns config validation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// sshHostPort splits Host into the SSH host and port (default: 22).
+func (c *Config) sshHostPort() (string, int, error) {
+	host, portStr, err := net.SplitHostPort(c.Host)
+	if err != nil {
+		// No port given
+		return c.Host, 22, nil
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port in HOST %q", c.Host)
+	}
+	return host, port, nil
+}
+
+// LoadConfig loads Windows DNS configuration from environment variables.
+// Environment variable pattern: DNSWEAVER_{INSTANCE_NAME}_{SETTING}
+//
+// Instance names are normalized: lowercase with hyphens becomes uppercase with underscores.
+// Example: "windns" looks for DNSWEAVER_WINDNS_*
+//
+// Supported settings:
+//   - HOST: WinRM target or SSH jump host (required)
+//   - USER: Account for WinRM or SSH (required for MODE ssh)
+//   - PASSWORD: Password for USER (supports _FILE suffix for Docker secrets)
+//   - ZONE: DNS zone to manage (required)
+//   - DNS_SERVER: DNS server to manage when it is not HOST (optional)
+//   - MODE: winrm or ssh (default: winrm on Windows, ssh elsewhere)
+//   - TTL: Default record TTL (optional, default: 300)
+//   - KNOWN_HOSTS_FILE: known_hosts file for verifying the SSH jump host (optional)
+func LoadConfig(instanceName string) (*Config, error) {
+	prefix := envPrefix(instanceName)
+
+	return LoadConfigFromMap(instanceName, map[string]string{
+		"HOST":             getEnv(prefix + "HOST"),
+		"USER":             getEnv(prefix + "USER"),
+		"PASSWORD":         getEnvOrFile(prefix+"PASSWORD", prefix+"PASSWORD_FILE"),
+		"ZONE":             getEnv(prefix + "ZONE"),
+		"DNS_SERVER":       getEnv(prefix + "DNS_SERVER"),
+		"MODE":             getEnv(prefix + "MODE"),
+		"TTL":              getEnv(prefix + "TTL"),
+		"KNOWN_HOSTS_FILE": getEnv(prefix + "KNOWN_HOSTS_FILE"),
+	})
+}
+
+// LoadConfigFromMap creates a Config from a map of key-value pairs.
+// This is used by the provider registry to create instances from
+// configuration that was already parsed from environment variables.
+//
+// Required keys: HOST, ZONE
+// Optional keys: USER, PASSWORD, DNS_SERVER, MODE, TTL, KNOWN_HOSTS_FILE
+func LoadConfigFromMap(instanceName string, configMap map[string]string) (*Config, error) {
+	config := &Config{
+		Host:           configMap["HOST"],
+		User:           configMap["USER"],
+		Password:       configMap["PASSWORD"],
+		Zone:           strings.TrimSuffix(strings.ToLower(configMap["ZONE"]), "."),
+		DNSServer:      configMap["DNS_SERVER"],
+		Mode:           strings.ToLower(configMap["MODE"]),
+		TTL:            DefaultTTL,
+		KnownHostsFile: configMap["KNOWN_HOSTS_FILE"],
+	}
+	if config.Mode == "" {
+		config.Mode = defaultMode()
+	}
+
+	// Parse optional TTL
+	if ttlStr, ok := configMap["TTL"]; ok && ttlStr != "" {
+		ttl, err := strconv.Atoi(ttlStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TTL value %q: %w", ttlStr, err)
+		}
+		config.TTL = ttl
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration for %s: %w", instanceName, err)
+	}
+
+	return config, nil
+}
+
+// envPrefix converts an instance name to an environment variable prefix.
+// Example: "windns" → "DNSWEAVER_WINDNS_"
+func envPrefix(instanceName string) string {
+	normalized := strings.ToUpper(instanceName)
+	normalized = strings.ReplaceAll(normalized, "-", "_")
+	return "DNSWEAVER_" + normalized + "_"
+}
+
+// getEnv retrieves an environment variable value.
+func getEnv(key string) string {
+	return os.Getenv(key)
+}
+
+// getEnvOrFile retrieves a value from either a direct environment variable
+// or a file path specified by the file key (Docker secrets pattern).
+//
+// If both are set, the file takes precedence.
+// The file contents are trimmed of leading/trailing whitespace.
+func getEnvOrFile(directKey, fileKey string) string {
+	if filePath := os.Getenv(fileKey); filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+		// If file read fails, fall through to direct value
+	}
+
+	return os.Getenv(directKey)
+}
@@ -0,0 +1,128 @@
+package windns
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigFromMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		configMap map[string]string
+		wantErr   string
+		check     func(t *testing.T, c *Config)
+	}{
+		{
+			name: "ssh mode",
+			configMap: map[string]string{
+				"HOST":     "jump.corp.example.com",
+				"USER":     "CORP\\dnsweaver",
+				"PASSWORD": "secret",
+				"ZONE":     "Corp.Example.com.",
+				"MODE":     "SSH",
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.Mode != ModeSSH {
+					t.Errorf("Mode = %q, want %q", c.Mode, ModeSSH)
+				}
+				if c.Zone != "corp.example.com" {
+					t.Errorf("Zone = %q, want corp.example.com", c.Zone)
+				}
+				if c.TTL != DefaultTTL {
+					t.Errorf("TTL = %d, want %d", c.TTL, DefaultTTL)
+				}
+			},
+		},
+		{
+			name: "custom TTL and DNS server",
+			configMap: map[string]string{
+				"HOST":       "jump",
+				"USER":       "admin",
+				"PASSWORD":   "secret",
+				"ZONE":       "corp.example.com",
+				"MODE":       "ssh",
+				"DNS_SERVER": "dc01",
+				"TTL":        "600",
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.TTL != 600 {
+					t.Errorf("TTL = %d, want 600", c.TTL)
+				}
+				if c.DNSServer != "dc01" {
+					t.Errorf("DNSServer = %q, want dc01", c.DNSServer)
+				}
+			},
+		},
+		{
+			name:      "missing host and zone",
+			configMap: map[string]string{"MODE": "ssh", "USER": "admin", "PASSWORD": "secret"},
+			wantErr:   "HOST is required",
+		},
+		{
+			name:      "ssh requires credentials",
+			configMap: map[string]string{"HOST": "jump", "ZONE": "corp.example.com", "MODE": "ssh"},
+			wantErr:   "USER is required",
+		},
+		{
+			name:      "invalid mode",
+			configMap: map[string]string{"HOST": "jump", "ZONE": "corp.example.com", "MODE": "rpc"},
+			wantErr:   "MODE must be",
+		},
+		{
+			name: "invalid TTL",
+			configMap: map[string]string{
+				"HOST": "jump", "USER": "admin", "PASSWORD": "secret",
+				"ZONE": "corp.example.com", "MODE": "ssh", "TTL": "abc",
+			},
+			wantErr: "invalid TTL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := LoadConfigFromMap("windns", tt.configMap)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfigFromMap() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfigFromMap() unexpected error: %v", err)
+			}
+			tt.check(t, c)
+		})
+	}
+}
+
+func TestLoadConfig_PasswordFile(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(secret, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("DNSWEAVER_WINDNS_HOST", "jump:2222")
+	t.Setenv("DNSWEAVER_WINDNS_USER", "admin")
+	t.Setenv("DNSWEAVER_WINDNS_PASSWORD", "from-env")
+	t.Setenv("DNSWEAVER_WINDNS_PASSWORD_FILE", secret)
+	t.Setenv("DNSWEAVER_WINDNS_ZONE", "corp.example.com")
+	t.Setenv("DNSWEAVER_WINDNS_MODE", "ssh")
+
+	c, err := LoadConfig("windns")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	if c.Password != "from-file" {
+		t.Errorf("Password = %q, want from-file", c.Password)
+	}
+
+	host, port, err := c.sshHostPort()
+	if err != nil {
+		t.Fatalf("sshHostPort() unexpected error: %v", err)
+	}
+	if host != "jump" || port != 2222 {
+		t.Errorf("sshHostPort() = %s, %d; want jump, 2222", host, port)
+	}
+}
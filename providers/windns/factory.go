@@ -0,0 +1,16 @@
+package windns
+
+import (
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Factory returns a provider.Factory for creating Windows DNS provider instances.
+// This is the recommended way to register the windns provider with the registry.
+//
+// Note: windns runs PowerShell over WinRM or SSH and does not use HTTP clients,
+// so the HTTP configuration from FactoryConfig is not used.
+func Factory() provider.Factory {
+	return func(cfg provider.FactoryConfig) (provider.Provider, error) {
+		return NewFromMap(cfg.Name, cfg.ProviderConfig)
+	}
+}
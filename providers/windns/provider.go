@@ -0,0 +1,243 @@
+// Package windns implements the DNSWeaver provider interface for Windows DNS Server
+// using the DnsServer PowerShell module.
+package windns
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+)
+
+// Provider implements provider.Provider for Windows DNS Server.
+type Provider struct {
+	name   string
+	zone   string
+	ttl    int
+	client *Client
+	logger *slog.Logger
+}
+
+// ProviderOption is a functional option for configuring the Provider.
+type ProviderOption func(*Provider)
+
+// WithProviderLogger sets a custom logger for the provider.
+func WithProviderLogger(logger *slog.Logger) ProviderOption {
+	return func(p *Provider) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// WithClient sets a custom client (for testing).
+func WithClient(client *Client) ProviderOption {
+	return func(p *Provider) {
+		p.client = client
+	}
+}
+
+// New creates a new Windows DNS provider instance.
+func New(name string, config *Config, opts ...ProviderOption) (*Provider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		name:   name,
+		zone:   config.Zone,
+		ttl:    config.TTL,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// Create client if not provided via options (testing)
+	if p.client == nil {
+		client, err := NewClient(config, WithLogger(p.logger))
+		if err != nil {
+			return nil, err
+		}
+		p.client = client
+	}
+
+	return p, nil
+}
+
+// NewFromEnv creates a new Windows DNS provider from environment variables.
+// This is a convenience function for use with the provider registry.
+func NewFromEnv(instanceName string, opts ...ProviderOption) (*Provider, error) {
+	config, err := LoadConfig(instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(instanceName, config, opts...)
+}
+
+// NewFromMap creates a new Windows DNS provider from a configuration map.
+// This is used by the provider registry Factory pattern.
+func NewFromMap(name string, config map[string]string) (*Provider, error) {
+	cfg, err := LoadConfigFromMap(name, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(name, cfg)
+}
+
+// Name returns the provider instance name.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Type returns "windns".
+func (p *Provider) Type() string {
+	return "windns"
+}
+
+// Capabilities returns the provider's feature support.
+// Windows DNS stores TXT records and several records per name, but has no
+// in-place update through the cmdlets used here.
+func (p *Provider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{
+		SupportsOwnershipTXT:    true,
+		SupportsNativeUpdate:    false,
+		SupportsMultipleTargets: true,
+		SupportedRecordTypes: []provider.RecordType{
+			provider.RecordTypeA,
+			provider.RecordTypeAAAA,
+			provider.RecordTypeCNAME,
+			provider.RecordTypeTXT,
+			provider.RecordTypeSRV,
+		},
+	}
+}
+
+// Zone returns the configured DNS zone.
+func (p *Provider) Zone() string {
+	return p.zone
+}
+
+// Ping checks that the zone is reachable on the DNS server.
+func (p *Provider) Ping(ctx context.Context) error {
+	return p.client.Ping(ctx)
+}
+
+// Close closes the connection to the jump host, if any.
+func (p *Provider) Close() error {
+	return p.client.Close()
+}
+
+// List returns all A, AAAA, CNAME, TXT, and SRV records in the zone.
+func (p *Provider) List(ctx context.Context) ([]provider.Record, error) {
+	winRecords, err := p.client.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing records: %w", err)
+	}
+
+	records := make([]provider.Record, 0, len(winRecords))
+	for _, r := range winRecords {
+		rec := provider.Record{
+			Hostname: p.fqdn(r.Name),
+			Type:     provider.RecordType(strings.ToUpper(r.Type)),
+			Target:   strings.TrimSuffix(r.Data, "."),
+			TTL:      r.TTL,
+		}
+		if rec.Type == provider.RecordTypeSRV {
+			rec.SRV = &provider.SRVData{
+				Priority: uint16(r.Priority),
+				Weight:   uint16(r.Weight),
+				Port:     uint16(r.Port),
+			}
+		}
+		rec.ProviderID = fmt.Sprintf("%s:%s:%s", rec.Hostname, rec.Type, rec.Target)
+		records = append(records, rec)
+	}
+
+	p.logger.Debug("listed records",
+		slog.String("provider", p.name),
+		slog.Int("count", len(records)),
+	)
+
+	return records, nil
+}
+
+// Create adds a new DNS record to the zone.
+func (p *Provider) Create(ctx context.Context, record provider.Record) error {
+	name, err := p.relativeName(record.Hostname)
+	if err != nil {
+		return err
+	}
+
+	ttl := record.TTL
+	if ttl <= 0 {
+		ttl = p.ttl
+	}
+
+	if err := p.client.Create(ctx, name, record, ttl); err != nil {
+		return fmt.Errorf("creating %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("created record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+		slog.String("target", record.Target),
+		slog.Int("ttl", ttl),
+	)
+
+	return nil
+}
+
+// Delete removes a DNS record from the zone.
+func (p *Provider) Delete(ctx context.Context, record provider.Record) error {
+	name, err := p.relativeName(record.Hostname)
+	if err != nil {
+		return err
+	}
+
+	if err := p.client.Delete(ctx, name, record); err != nil {
+		return fmt.Errorf("deleting %s record: %w", record.Type, err)
+	}
+
+	p.logger.Info("deleted record",
+		slog.String("provider", p.name),
+		slog.String("hostname", record.Hostname),
+		slog.String("type", string(record.Type)),
+	)
+
+	return nil
+}
+
+// relativeName converts a hostname to a name relative to the zone,
+// as the DnsServer cmdlets expect ("@" for the zone apex).
+func (p *Provider) relativeName(hostname string) (string, error) {
+	host := strings.TrimSuffix(strings.ToLower(hostname), ".")
+	if host == p.zone {
+		return "@", nil
+	}
+	if name, ok := strings.CutSuffix(host, "."+p.zone); ok && name != "" {
+		return name, nil
+	}
+	return "", fmt.Errorf("hostname %s is not in zone %s", hostname, p.zone)
+}
+
+// fqdn converts a zone-relative name reported by Windows DNS to a hostname.
+func (p *Provider) fqdn(name string) string {
+	if name == "@" || name == "" {
+		return p.zone
+	}
+	return strings.ToLower(name) + "." + p.zone
+}
+
+// Ensure Provider implements provider.Provider at compile time.
+var _ provider.Provider = (*Provider)(nil)
@@ -0,0 +1,130 @@
+package windns
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/provider"
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/sshutil"
+)
+
+func newTestProvider(t *testing.T, runner *fakeRunner) *Provider {
+	t.Helper()
+	config := &Config{
+		Host:     "jump",
+		User:     "admin",
+		Password: "secret",
+		Zone:     "corp.example.com",
+		Mode:     ModeSSH,
+		TTL:      300,
+	}
+	client, err := NewClient(config, WithRunner(runner))
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	p, err := New("windns", config, WithClient(client))
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("windns", nil); err == nil {
+		t.Error("New() with nil config expected error")
+	}
+	if _, err := New("windns", &Config{Mode: ModeSSH}); err == nil {
+		t.Error("New() with invalid config expected error")
+	}
+}
+
+func TestProvider_List(t *testing.T) {
+	runner := &fakeRunner{result: &sshutil.CommandResult{
+		Stdout: `[{"name":"@","type":"A","ttl":3600,"data":"10.0.0.1"},` +
+			`{"name":"App","type":"CNAME","ttl":300,"data":"proxy.corp.example.com."},` +
+			`{"name":"_http._tcp","type":"SRV","ttl":60,"data":"web.corp.example.com.","priority":10,"weight":5,"port":80}]`,
+	}}
+	p := newTestProvider(t, runner)
+
+	records, err := p.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("List() returned %d records, want 3", len(records))
+	}
+
+	if records[0].Hostname != "corp.example.com" {
+		t.Errorf("apex hostname = %q, want corp.example.com", records[0].Hostname)
+	}
+	if records[1].Hostname != "app.corp.example.com" || records[1].Target != "proxy.corp.example.com" {
+		t.Errorf("unexpected CNAME record: %+v", records[1])
+	}
+	srv := records[2]
+	if srv.SRV == nil || srv.SRV.Port != 80 || srv.SRV.Priority != 10 || srv.SRV.Weight != 5 {
+		t.Errorf("unexpected SRV record: %+v", srv)
+	}
+}
+
+func TestProvider_Create(t *testing.T) {
+	runner := &fakeRunner{}
+	p := newTestProvider(t, runner)
+
+	err := p.Create(context.Background(), provider.Record{
+		Hostname: "app.corp.example.com",
+		Type:     provider.RecordTypeA,
+		Target:   "10.0.0.5",
+	})
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+
+	script := runner.scripts[0]
+	if !strings.Contains(script, "-Name 'app' -A") {
+		t.Errorf("record name should be zone-relative:\n%s", script)
+	}
+	if !strings.Contains(script, "-Seconds 300") {
+		t.Errorf("default TTL not applied:\n%s", script)
+	}
+}
+
+func TestProvider_OutsideZone(t *testing.T) {
+	runner := &fakeRunner{}
+	p := newTestProvider(t, runner)
+
+	record := provider.Record{Hostname: "app.other.com", Type: provider.RecordTypeA, Target: "10.0.0.5"}
+	if err := p.Create(context.Background(), record); err == nil {
+		t.Error("Create() outside zone expected error")
+	}
+	if err := p.Delete(context.Background(), record); err == nil {
+		t.Error("Delete() outside zone expected error")
+	}
+	if len(runner.scripts) != 0 {
+		t.Errorf("no commands should run for hostnames outside the zone, got %d", len(runner.scripts))
+	}
+}
+
+func TestProvider_RelativeName(t *testing.T) {
+	p := &Provider{zone: "corp.example.com"}
+
+	tests := map[string]string{
+		"corp.example.com":      "@",
+		"App.Corp.Example.com.": "app",
+		"a.b.corp.example.com":  "a.b",
+	}
+	for hostname, want := range tests {
+		got, err := p.relativeName(hostname)
+		if err != nil {
+			t.Errorf("relativeName(%q) unexpected error: %v", hostname, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("relativeName(%q) = %q, want %q", hostname, got, want)
+		}
+	}
+
+	if _, err := p.relativeName("xcorp.example.com"); err == nil {
+		t.Error("relativeName() for a sibling domain expected error")
+	}
+}
@@ -0,0 +1,125 @@
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+)
+
+// Client is a minimal Consul HTTP API client supporting blocking queries.
+type Client struct {
+	addr       string
+	token      string
+	datacenter string
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// ClientOption is a functional option for configuring the Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets a custom HTTP client.
+// The client timeout must be longer than the blocking query wait time.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithClientLogger sets a custom logger.
+func WithClientLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// NewClient creates a new Consul API client.
+// waitTime is the longest blocking query the client will issue; the HTTP
+// timeout is set above it so blocking queries are not cut short.
+func NewClient(addr, token, datacenter string, waitTime time.Duration, opts ...ClientOption) *Client {
+	c := &Client{
+		addr:       strings.TrimSuffix(addr, "/"),
+		token:      token,
+		datacenter: datacenter,
+		httpClient: httputil.NewClient(&httputil.ClientConfig{
+			// Consul adds up to wait/16 of jitter to blocking queries
+			Timeout: waitTime + waitTime/16 + httputil.DefaultTimeout,
+		}),
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Services returns the service catalog as a map of service name to tags.
+//
+// When index is non-zero, the request is a blocking query that returns once
+// the catalog changes past index or wait elapses. The returned index is the
+// X-Consul-Index of the response, to be passed to the next call.
+func (c *Client) Services(ctx context.Context, index uint64, wait time.Duration) (map[string][]string, uint64, error) {
+	params := url.Values{}
+	if c.datacenter != "" {
+		params.Set("dc", c.datacenter)
+	}
+	if index > 0 {
+		params.Set("index", strconv.FormatUint(index, 10))
+		params.Set("wait", wait.String())
+	}
+
+	reqURL := c.addr + "/v1/catalog/services"
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("creating request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("X-Consul-Token", c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing services: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("listing services: unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var services map[string][]string
+	if err := json.Unmarshal(body, &services); err != nil {
+		return nil, 0, fmt.Errorf("parsing response JSON: %w", err)
+	}
+
+	newIndex, err := strconv.ParseUint(resp.Header.Get("X-Consul-Index"), 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid X-Consul-Index header %q", resp.Header.Get("X-Consul-Index"))
+	}
+
+	return services, newIndex, nil
+}
@@ -0,0 +1,104 @@
+package consul
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Configuration defaults.
+const (
+	// DefaultAddr is the default Consul HTTP API address.
+	DefaultAddr = "http://127.0.0.1:8500"
+
+	// DefaultTagPrefix is the service tag prefix that marks hostnames.
+	DefaultTagPrefix = "dnsweaver.hostname="
+
+	// DefaultWaitTime is the maximum duration of a blocking query.
+	DefaultWaitTime = 5 * time.Minute
+)
+
+// Config holds Consul source configuration.
+type Config struct {
+	Addr       string        // Consul HTTP API address
+	Token      string        // ACL token (optional)
+	Datacenter string        // Datacenter to query (empty for the agent's datacenter)
+	TagPrefix  string        // Service tag prefix for hostnames
+	WaitTime   time.Duration // Maximum duration of a blocking query
+}
+
+// Validate checks that the configuration is usable.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.Addr == "" {
+		errs = append(errs, "ADDR is required")
+	}
+	if c.TagPrefix == "" {
+		errs = append(errs, "TAG_PREFIX is required")
+	}
+	if c.WaitTime < time.Second {
+		errs = append(errs, "WAIT_TIME must be at least 1s")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("consul config validation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// LoadConfig loads Consul source configuration from environment variables.
+//
+// Supported settings:
+//   - DNSWEAVER_CONSUL_ADDR: Consul API address (default: http://127.0.0.1:8500)
+//   - DNSWEAVER_CONSUL_TOKEN: ACL token (supports _FILE suffix for Docker secrets)
+//   - DNSWEAVER_CONSUL_DATACENTER: Datacenter to query (default: agent's datacenter)
+//   - DNSWEAVER_CONSUL_TAG_PREFIX: Service tag prefix (default: dnsweaver.hostname=)
+//   - DNSWEAVER_CONSUL_WAIT_TIME: Blocking query wait time (default: 5m)
+func LoadConfig() (*Config, error) {
+	cfg := &Config{
+		Addr:       os.Getenv("DNSWEAVER_CONSUL_ADDR"),
+		Token:      getEnvOrFile("DNSWEAVER_CONSUL_TOKEN", "DNSWEAVER_CONSUL_TOKEN_FILE"),
+		Datacenter: os.Getenv("DNSWEAVER_CONSUL_DATACENTER"),
+		TagPrefix:  os.Getenv("DNSWEAVER_CONSUL_TAG_PREFIX"),
+		WaitTime:   DefaultWaitTime,
+	}
+
+	if cfg.Addr == "" {
+		cfg.Addr = DefaultAddr
+	}
+	if cfg.TagPrefix == "" {
+		cfg.TagPrefix = DefaultTagPrefix
+	}
+
+	if waitStr := os.Getenv("DNSWEAVER_CONSUL_WAIT_TIME"); waitStr != "" {
+		wait, err := time.ParseDuration(waitStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid WAIT_TIME value %q: %w", waitStr, err)
+		}
+		cfg.WaitTime = wait
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// getEnvOrFile retrieves a value from either a direct environment variable
+// or a file path specified by the file key (Docker secrets pattern).
+//
+// If both are set, the file takes precedence.
+func getEnvOrFile(directKey, fileKey string) string {
+	if filePath := os.Getenv(fileKey); filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+	}
+
+	return os.Getenv(directKey)
+}
@@ -0,0 +1,250 @@
+// Package consul provides a Source implementation for discovering hostnames
+// from the HashiCorp Consul service catalog.
+//
+// The source watches /v1/catalog/services with blocking queries and extracts
+// hostnames from service tags that start with the configured tag prefix.
+// Tag values may contain multiple comma-separated hostnames.
+//
+// Example service registration:
+//
+//	service {
+//	  name = "web"
+//	  tags = ["dnsweaver.hostname=app.example.com,www.example.com"]
+//	}
+package consul
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+const sourceName = "consul"
+
+// Retry backoff for failed blocking queries.
+const (
+	minRetryDelay = time.Second
+	maxRetryDelay = time.Minute
+)
+
+// Consul implements the source.Source and source.Notifier interfaces for the
+// Consul service catalog.
+type Consul struct {
+	config *Config
+	client *Client
+	logger *slog.Logger
+
+	mu        sync.Mutex
+	loaded    bool
+	index     uint64
+	hostnames []source.Hostname
+}
+
+// Option is a functional option for configuring Consul.
+type Option func(*Consul)
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Consul) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithClient sets a custom Consul API client (useful for testing).
+func WithClient(client *Client) Option {
+	return func(c *Consul) {
+		c.client = client
+	}
+}
+
+// New creates a new Consul source.
+func New(config *Config, opts ...Option) (*Consul, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	c := &Consul{
+		config: config,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.client == nil {
+		c.client = NewClient(config.Addr, config.Token, config.Datacenter, config.WaitTime,
+			WithClientLogger(c.logger))
+	}
+
+	return c, nil
+}
+
+// Name returns the source identifier.
+func (c *Consul) Name() string {
+	return sourceName
+}
+
+// Extract is a no-op: Consul hostnames are not carried on Docker labels.
+func (c *Consul) Extract(_ context.Context, _ map[string]string) ([]source.Hostname, error) {
+	return nil, nil
+}
+
+// SupportsDiscovery always returns true; Consul hostnames come from Discover.
+func (c *Consul) SupportsDiscovery() bool {
+	return true
+}
+
+// Discover returns the hostnames from the most recent catalog snapshot.
+// The catalog is only queried directly if Watch has not loaded it yet.
+func (c *Consul) Discover(ctx context.Context) ([]source.Hostname, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loaded {
+		return c.hostnames, nil
+	}
+
+	services, index, err := c.client.Services(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	c.update(services, index)
+
+	return c.hostnames, nil
+}
+
+// Watch runs blocking queries against the service catalog until ctx is
+// cancelled, calling onChange whenever the discovered hostnames change.
+// Failed queries are retried with exponential backoff.
+func (c *Consul) Watch(ctx context.Context, onChange func()) error {
+	delay := minRetryDelay
+
+	for {
+		c.mu.Lock()
+		index := c.index
+		c.mu.Unlock()
+
+		services, newIndex, err := c.client.Services(ctx, index, c.config.WaitTime)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			c.logger.Warn("consul catalog query failed, retrying",
+				slog.String("error", err.Error()),
+				slog.Duration("retry_in", delay),
+			)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, maxRetryDelay)
+			continue
+		}
+		delay = minRetryDelay
+
+		c.mu.Lock()
+		changed := c.update(services, newIndex)
+		c.mu.Unlock()
+
+		if changed {
+			c.logger.Info("consul catalog changed",
+				slog.Uint64("index", newIndex),
+				slog.Int("services", len(services)),
+			)
+			onChange()
+		}
+	}
+}
+
+// update stores a catalog snapshot and reports whether the hostname set changed.
+// Callers must hold c.mu.
+func (c *Consul) update(services map[string][]string, index uint64) bool {
+	// Consul indexes may go backwards (e.g., after a snapshot restore);
+	// reset to 0 so the next query returns immediately.
+	if index < c.index {
+		index = 0
+	}
+	c.index = index
+
+	hostnames := c.extractHostnames(services)
+	changed := !c.loaded || !sameHostnames(c.hostnames, hostnames)
+	c.hostnames = hostnames
+	c.loaded = true
+
+	c.logger.Debug("discovered hostnames from consul",
+		slog.String("datacenter", c.config.Datacenter),
+		slog.Int("services", len(services)),
+		slog.Int("count", len(hostnames)),
+	)
+
+	return changed
+}
+
+// extractHostnames returns the deduplicated hostnames from tags matching the tag prefix.
+func (c *Consul) extractHostnames(services map[string][]string) []source.Hostname {
+	seen := make(map[string]struct{})
+	var names []string
+
+	for _, tags := range services {
+		for _, tag := range tags {
+			value, ok := strings.CutPrefix(tag, c.config.TagPrefix)
+			if !ok {
+				continue
+			}
+			for _, name := range strings.Split(value, ",") {
+				name = strings.TrimSpace(name)
+				if name == "" {
+					continue
+				}
+				key := strings.ToLower(name)
+				if _, exists := seen[key]; exists {
+					continue
+				}
+				seen[key] = struct{}{}
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	hostnames := make([]source.Hostname, 0, len(names))
+	for _, name := range names {
+		hostnames = append(hostnames, source.Hostname{
+			Name:   name,
+			Source: sourceName,
+		})
+	}
+	return hostnames
+}
+
+// sameHostnames reports whether two sorted hostname lists have the same names.
+func sameHostnames(a, b []source.Hostname) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+// Ensure Consul implements source.Source and source.Notifier
+var (
+	_ source.Source   = (*Consul)(nil)
+	_ source.Notifier = (*Consul)(nil)
+)
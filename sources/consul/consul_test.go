@@ -0,0 +1,273 @@
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// fakeCatalog serves /v1/catalog/services with blocking query support.
+type fakeCatalog struct {
+	t *testing.T
+
+	mu       sync.Mutex
+	index    uint64
+	services map[string][]string
+	changed  chan struct{}
+	requests atomic.Int32
+}
+
+func newFakeCatalog(t *testing.T, services map[string][]string) *fakeCatalog {
+	return &fakeCatalog{t: t, index: 10, services: services, changed: make(chan struct{})}
+}
+
+// set replaces the catalog and wakes blocked queries.
+func (f *fakeCatalog) set(services map[string][]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.index++
+	f.services = services
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (f *fakeCatalog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests.Add(1)
+	if r.URL.Path != "/v1/catalog/services" {
+		f.t.Errorf("unexpected path: %s", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if got := r.Header.Get("X-Consul-Token"); got != "test-token" {
+		f.t.Errorf("X-Consul-Token = %q, want test-token", got)
+	}
+	if got := r.URL.Query().Get("dc"); got != "dc1" {
+		f.t.Errorf("dc = %q, want dc1", got)
+	}
+
+	f.mu.Lock()
+	index, changed := f.index, f.changed
+	f.mu.Unlock()
+
+	if want, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64); want > 0 && want >= index {
+		wait, _ := time.ParseDuration(r.URL.Query().Get("wait"))
+		select {
+		case <-changed:
+		case <-time.After(wait):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(f.index, 10))
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(f.services)
+}
+
+func newTestSource(t *testing.T, serverURL string) *Consul {
+	t.Helper()
+	src, err := New(&Config{
+		Addr:       serverURL,
+		Token:      "test-token",
+		Datacenter: "dc1",
+		TagPrefix:  DefaultTagPrefix,
+		WaitTime:   time.Second,
+	}, WithLogger(testLogger()))
+	if err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+	return src
+}
+
+func TestConsul_Discover(t *testing.T) {
+	catalog := newFakeCatalog(t, map[string][]string{
+		"web":    {"dnsweaver.hostname=app.example.com, www.example.com", "http"},
+		"api":    {"dnsweaver.hostname=API.example.com", "dnsweaver.hostname=app.example.com"},
+		"consul": {},
+	})
+	server := httptest.NewServer(catalog)
+	defer server.Close()
+
+	src := newTestSource(t, server.URL)
+
+	if src.Name() != "consul" {
+		t.Errorf("Name() = %q, want consul", src.Name())
+	}
+	if !src.SupportsDiscovery() {
+		t.Error("expected SupportsDiscovery to be true")
+	}
+
+	hostnames, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	want := []string{"API.example.com", "app.example.com", "www.example.com"}
+	if len(hostnames) != len(want) {
+		t.Fatalf("expected %d hostnames, got %d: %v", len(want), len(hostnames), hostnames)
+	}
+	for i, h := range hostnames {
+		if h.Name != want[i] {
+			t.Errorf("hostnames[%d] = %q, want %q", i, h.Name, want[i])
+		}
+		if h.Source != "consul" {
+			t.Errorf("Source = %q, want consul", h.Source)
+		}
+	}
+
+	// Subsequent calls use the cached snapshot
+	if _, err := src.Discover(context.Background()); err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if got := catalog.requests.Load(); got != 1 {
+		t.Errorf("expected 1 catalog request, got %d", got)
+	}
+}
+
+func TestConsul_Watch(t *testing.T) {
+	catalog := newFakeCatalog(t, map[string][]string{
+		"web": {"dnsweaver.hostname=app.example.com"},
+	})
+	server := httptest.NewServer(catalog)
+	defer server.Close()
+
+	src := newTestSource(t, server.URL)
+	if _, err := src.Discover(context.Background()); err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notified := make(chan struct{}, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- src.Watch(ctx, func() { notified <- struct{}{} })
+	}()
+
+	// Tag-only change to another service must not trigger a reconcile
+	time.Sleep(50 * time.Millisecond)
+	catalog.set(map[string][]string{
+		"web": {"dnsweaver.hostname=app.example.com"},
+		"db":  {"primary"},
+	})
+	select {
+	case <-notified:
+		t.Fatal("unexpected notification for unchanged hostnames")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	catalog.set(map[string][]string{
+		"web": {"dnsweaver.hostname=app.example.com"},
+		"api": {"dnsweaver.hostname=api.example.com"},
+	})
+	select {
+	case <-notified:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected notification after catalog change")
+	}
+
+	hostnames, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(hostnames) != 2 {
+		t.Errorf("expected 2 hostnames after change, got %v", hostnames)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("Watch() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watch did not stop after cancel")
+	}
+}
+
+func TestConsul_Discover_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("ACL not found"))
+	}))
+	defer server.Close()
+
+	src := newTestSource(t, server.URL)
+	if _, err := src.Discover(context.Background()); err == nil {
+		t.Fatal("expected error for forbidden response")
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Error("expected error for nil config")
+	}
+	if _, err := New(&Config{}); err == nil {
+		t.Error("expected error for empty config")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("DNSWEAVER_CONSUL_ADDR", "")
+	t.Setenv("DNSWEAVER_CONSUL_TOKEN", "")
+	t.Setenv("DNSWEAVER_CONSUL_DATACENTER", "")
+	t.Setenv("DNSWEAVER_CONSUL_TAG_PREFIX", "")
+	t.Setenv("DNSWEAVER_CONSUL_WAIT_TIME", "")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Addr != DefaultAddr {
+		t.Errorf("Addr = %q, want %q", cfg.Addr, DefaultAddr)
+	}
+	if cfg.TagPrefix != DefaultTagPrefix {
+		t.Errorf("TagPrefix = %q, want %q", cfg.TagPrefix, DefaultTagPrefix)
+	}
+	if cfg.WaitTime != DefaultWaitTime {
+		t.Errorf("WaitTime = %v, want %v", cfg.WaitTime, DefaultWaitTime)
+	}
+
+	tokenFile := t.TempDir() + "/token"
+	if err := os.WriteFile(tokenFile, []byte("secret-token\n"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	t.Setenv("DNSWEAVER_CONSUL_ADDR", "https://consul.example.com:8501")
+	t.Setenv("DNSWEAVER_CONSUL_TOKEN_FILE", tokenFile)
+	t.Setenv("DNSWEAVER_CONSUL_DATACENTER", "dc2")
+	t.Setenv("DNSWEAVER_CONSUL_WAIT_TIME", "1m")
+
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Token != "secret-token" {
+		t.Errorf("Token = %q, want secret-token", cfg.Token)
+	}
+	if cfg.Datacenter != "dc2" {
+		t.Errorf("Datacenter = %q, want dc2", cfg.Datacenter)
+	}
+	if cfg.WaitTime != time.Minute {
+		t.Errorf("WaitTime = %v, want 1m", cfg.WaitTime)
+	}
+
+	t.Setenv("DNSWEAVER_CONSUL_WAIT_TIME", "forever")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected error for invalid wait time")
+	}
+}
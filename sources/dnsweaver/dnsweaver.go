@@ -1,13 +1,25 @@
 // Package dnsweaver provides a Source implementation for extracting hostnames
 // from native dnsweaver labels on Docker containers/services.
 //
-// This package parses Docker container labels in two formats:
+// This package parses Docker container labels in three formats:
 //
-// 1. Simple hostname (uses provider defaults for type/target):
+// 1. Simple hostname (uses provider defaults unless overridden):
 //
 //	dnsweaver.hostname=app.example.com
+//	dnsweaver.record_type=A
+//	dnsweaver.target=192.0.2.100
+//	dnsweaver.provider=internal-dns
+//	dnsweaver.ttl=300
 //
-// 2. Named records (explicit control per record):
+// 2. Indexed records, for several records on one container:
+//
+//	dnsweaver.0.hostname=app.example.com
+//	dnsweaver.0.target=192.0.2.100
+//	dnsweaver.1.hostname=api.example.com
+//	dnsweaver.1.record_type=CNAME
+//	dnsweaver.1.target=app.example.com
+//
+// 3. Named records (explicit control per record):
 //
 //	dnsweaver.records.myapp.hostname=app.example.com
 //	dnsweaver.records.myapp.type=A
@@ -23,6 +35,14 @@
 //	dnsweaver.records.mc.port=25565
 //	dnsweaver.records.mc.priority=0
 //	dnsweaver.records.mc.weight=5
+//
+// SRV fields can also be written as srv.port, srv.priority and srv.weight,
+// which is the only form for simple hostnames (dnsweaver.srv.port=25565).
+// record_type is an alias of type in every format.
+//
+// Provider-specific settings apply to every record of the workload:
+//
+//	dnsweaver.cloudflare.proxied=true
 package dnsweaver
 
 import (
@@ -75,6 +95,7 @@ func (d *DNSWeaver) Name() string {
 //
 // This method looks for:
 //   - dnsweaver.hostname=<hostname> (simple format)
+//   - dnsweaver.<index>.hostname=<hostname> (indexed record format)
 //   - dnsweaver.records.<name>.hostname=<hostname> (named record format)
 //
 // Returns an empty slice if no dnsweaver labels are found.
@@ -97,10 +118,12 @@ func (d *DNSWeaver) Extract(ctx context.Context, labels map[string]string) ([]so
 		// Copy record hints if present
 		if e.HasHints() {
 			h.RecordHints = &source.RecordHints{
-				Type:     e.Type,
-				Target:   e.Target,
-				TTL:      e.TTL,
-				Provider: e.Provider,
+				Type:          e.Type,
+				Target:        e.Target,
+				Targets:       e.Targets,
+				TTL:           e.TTL,
+				Provider:      e.Provider,
+				ProviderHints: e.ProviderHints,
 			}
 			if e.SRV != nil {
 				h.RecordHints.SRV = &source.SRVHints{
@@ -2,7 +2,10 @@ package dnsweaver
 
 import (
 	"context"
+	"reflect"
 	"testing"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
 )
 
 func TestDNSWeaver_Name(t *testing.T) {
@@ -224,3 +227,36 @@ func TestDNSWeaver_Extract_MultipleRecords(t *testing.T) {
 		}
 	}
 }
+
+func TestDNSWeaver_Extract_IndexedRecords(t *testing.T) {
+	d := New(WithLogger(testLogger()))
+
+	hostnames, err := d.Extract(context.Background(), map[string]string{
+		"dnsweaver.0.hostname":     "app.example.com",
+		"dnsweaver.0.record_type":  "A",
+		"dnsweaver.0.target":       "10.0.0.1",
+		"dnsweaver.0.ttl":          "120",
+		"dnsweaver.0.provider":     "internal-dns",
+		"dnsweaver.1.hostname":     "_sip._tcp.example.com",
+		"dnsweaver.1.record_type":  "SRV",
+		"dnsweaver.1.target":       "sip.example.com",
+		"dnsweaver.1.srv.port":     "5060",
+		"dnsweaver.1.srv.priority": "10",
+		"dnsweaver.1.srv.weight":   "20",
+	})
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(hostnames) != 2 {
+		t.Fatalf("Extract() returned %d hostnames, want 2", len(hostnames))
+	}
+
+	want := source.RecordHints{Type: "A", Target: "10.0.0.1", TTL: 120, Provider: "internal-dns"}
+	if h := hostnames[0]; h.Router != "0" || h.RecordHints == nil || !reflect.DeepEqual(*h.RecordHints, want) {
+		t.Errorf("hostnames[0] = %+v, hints %+v", h, h.RecordHints)
+	}
+	if h := hostnames[1]; h.RecordHints == nil || h.RecordHints.SRV == nil ||
+		*h.RecordHints.SRV != (source.SRVHints{Port: 5060, Priority: 10, Weight: 20}) {
+		t.Errorf("hostnames[1] SRV hints = %+v", h.RecordHints)
+	}
+}
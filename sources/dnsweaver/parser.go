@@ -3,8 +3,11 @@ package dnsweaver
 import (
 	"log/slog"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
 )
 
 // Label prefixes for dnsweaver labels.
@@ -19,6 +22,15 @@ const (
 	// TTLLabel sets the TTL for simple hostname mode.
 	TTLLabel = "dnsweaver.ttl"
 
+	// RecordTypeLabel sets the record type for simple hostname mode.
+	RecordTypeLabel = "dnsweaver.record_type"
+
+	// TargetLabel sets the record target for simple hostname mode.
+	TargetLabel = "dnsweaver.target"
+
+	// ProviderLabel sets the provider instance for simple hostname mode.
+	ProviderLabel = "dnsweaver.provider"
+
 	// RecordsPrefix is the prefix for named record definitions.
 	// Format: dnsweaver.records.<name>.<field>
 	RecordsPrefix = "dnsweaver.records."
@@ -35,11 +47,32 @@ const (
 	FieldPriority = "priority"
 	FieldWeight   = "weight"
 	FieldEnabled  = "enabled"
+
+	// FieldRecordType is an alias of FieldType.
+	FieldRecordType = "record_type"
+
+	// SRV fields may also be written with an "srv." prefix
+	// (e.g., dnsweaver.srv.port), which is the only form in simple mode.
+	FieldSRVPort     = "srv.port"
+	FieldSRVPriority = "srv.priority"
+	FieldSRVWeight   = "srv.weight"
 )
 
+// simpleFields are the record fields read from dnsweaver.<field> labels in
+// simple hostname mode. Unprefixed SRV fields are not included there, as
+// they would be ambiguous next to other dnsweaver.* labels.
+var simpleFields = []string{
+	FieldHostname, FieldType, FieldRecordType, FieldTarget, FieldProvider, FieldTTL,
+	FieldSRVPort, FieldSRVPriority, FieldSRVWeight,
+}
+
 // namedRecordRegex matches dnsweaver.records.<name>.<field> labels.
 // Captures: [1]=name, [2]=field
-var namedRecordRegex = regexp.MustCompile(`^dnsweaver\.records\.([a-zA-Z0-9_-]+)\.([a-zA-Z0-9_]+)$`)
+var namedRecordRegex = regexp.MustCompile(`^dnsweaver\.records\.([a-zA-Z0-9_-]+)\.([a-zA-Z0-9_]+(?:\.[a-zA-Z0-9_]+)?)$`)
+
+// indexedRecordRegex matches dnsweaver.<index>.<field> labels.
+// Captures: [1]=index, [2]=field
+var indexedRecordRegex = regexp.MustCompile(`^dnsweaver\.([0-9]+)\.([a-zA-Z0-9_]+(?:\.[a-zA-Z0-9_]+)?)$`)
 
 // SRVData contains SRV record-specific fields.
 type SRVData struct {
@@ -53,7 +86,8 @@ type Extraction struct {
 	// Hostname is the FQDN extracted from labels.
 	Hostname string
 
-	// RecordName is the identifier for named records (empty for simple hostname).
+	// RecordName is the identifier for named records, or the index for
+	// indexed records (empty for simple hostname).
 	RecordName string
 
 	// Type is the record type override (A, AAAA, CNAME, SRV, PTR, TXT).
@@ -64,6 +98,10 @@ type Extraction struct {
 	// Empty means use provider default.
 	Target string
 
+	// Targets holds every target when the target label lists several
+	// comma-separated values (round-robin records). Target is Targets[0].
+	Targets []string
+
 	// Provider is the target provider instance name.
 	// Empty means use domain matching.
 	Provider string
@@ -74,11 +112,15 @@ type Extraction struct {
 
 	// SRV contains SRV-specific fields when Type is "SRV".
 	SRV *SRVData
+
+	// ProviderHints holds provider-specific settings from workload labels
+	// such as dnsweaver.cloudflare.proxied, applied to every record.
+	ProviderHints map[string]string
 }
 
 // HasHints returns true if any hint fields are set.
 func (e Extraction) HasHints() bool {
-	return e.Type != "" || e.Target != "" || e.Provider != "" || e.TTL > 0 || e.SRV != nil
+	return e.Type != "" || e.Target != "" || e.Provider != "" || e.TTL > 0 || e.SRV != nil || len(e.ProviderHints) > 0
 }
 
 // Parser extracts hostnames from dnsweaver labels.
@@ -109,7 +151,9 @@ func NewParser(opts ...ParserOption) *Parser {
 	return p
 }
 
-// ExtractHostnames parses dnsweaver labels and returns all discovered hostnames.
+// ExtractHostnames parses dnsweaver labels and returns all discovered hostnames:
+// the simple hostname first, then indexed records in index order, then named
+// records sorted by name.
 func (p *Parser) ExtractHostnames(labels map[string]string) []Extraction {
 	var extractions []Extraction
 
@@ -121,148 +165,203 @@ func (p *Parser) ExtractHostnames(labels map[string]string) []Extraction {
 		}
 	}
 
-	// Handle simple hostname label
-	if hostname, ok := labels[SimpleHostnameLabel]; ok {
-		hostname = strings.TrimSpace(hostname)
-		if hostname != "" {
-			extraction := Extraction{
-				Hostname: hostname,
-			}
+	providerHints := source.ProviderHintsFromLabels(labels, "")
 
-			// Parse TTL for simple hostname
-			if ttlStr, ok := labels[TTLLabel]; ok && ttlStr != "" {
-				if ttl, err := strconv.Atoi(strings.TrimSpace(ttlStr)); err == nil && ttl > 0 {
-					extraction.TTL = ttl
-				} else {
-					p.logger.Warn("invalid TTL value for simple hostname",
-						slog.String("hostname", hostname),
-						slog.String("ttl", ttlStr),
-					)
-				}
+	// Handle simple hostname labels
+	if hostname := strings.TrimSpace(labels[SimpleHostnameLabel]); hostname != "" {
+		fields := make(map[string]string)
+		for _, field := range simpleFields {
+			if value, ok := labels["dnsweaver."+field]; ok {
+				fields[normalizeField(field)] = strings.TrimSpace(value)
 			}
+		}
 
+		if extraction, ok := p.parseRecord("", fields, providerHints); ok {
 			extractions = append(extractions, extraction)
 			p.logger.Debug("found simple dnsweaver hostname",
 				slog.String("hostname", hostname),
+				slog.String("type", extraction.Type),
+				slog.String("target", extraction.Target),
 				slog.Int("ttl", extraction.TTL),
 			)
 		}
 	}
 
-	// Collect named record fields
+	// Collect indexed and named record fields
+	indexedRecords := make(map[string]map[string]string)
 	namedRecords := make(map[string]map[string]string)
 
 	for key, value := range labels {
+		records := namedRecords
 		matches := namedRecordRegex.FindStringSubmatch(key)
+		if matches == nil {
+			records = indexedRecords
+			matches = indexedRecordRegex.FindStringSubmatch(key)
+		}
 		if matches == nil {
 			continue
 		}
 
 		recordName := matches[1]
-		field := strings.ToLower(matches[2])
-		value = strings.TrimSpace(value)
+		if records[recordName] == nil {
+			records[recordName] = make(map[string]string)
+		}
+		records[recordName][normalizeField(matches[2])] = strings.TrimSpace(value)
+	}
 
-		if namedRecords[recordName] == nil {
-			namedRecords[recordName] = make(map[string]string)
+	// Process indexed records in numeric order
+	indexes := make([]string, 0, len(indexedRecords))
+	for index := range indexedRecords {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		a, _ := strconv.Atoi(indexes[i])
+		b, _ := strconv.Atoi(indexes[j])
+		return a < b
+	})
+	for _, index := range indexes {
+		if extraction, ok := p.parseRecord(index, indexedRecords[index], providerHints); ok {
+			extractions = append(extractions, extraction)
+			p.logger.Debug("found indexed dnsweaver record",
+				slog.String("index", index),
+				slog.String("hostname", extraction.Hostname),
+				slog.String("type", extraction.Type),
+				slog.String("target", extraction.Target),
+				slog.String("provider", extraction.Provider),
+			)
 		}
-		namedRecords[recordName][field] = value
 	}
 
 	// Process named records
-	for name, fields := range namedRecords {
-		// Check if this named record is explicitly disabled
-		if enabled, ok := fields[FieldEnabled]; ok {
-			if strings.EqualFold(strings.TrimSpace(enabled), "false") {
-				p.logger.Debug("named record disabled",
-					slog.String("record", name),
-				)
-				continue
-			}
+	names := make([]string, 0, len(namedRecords))
+	for name := range namedRecords {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if extraction, ok := p.parseRecord(name, namedRecords[name], providerHints); ok {
+			extractions = append(extractions, extraction)
+			p.logger.Debug("found named dnsweaver record",
+				slog.String("name", name),
+				slog.String("hostname", extraction.Hostname),
+				slog.String("type", extraction.Type),
+				slog.String("target", extraction.Target),
+				slog.String("provider", extraction.Provider),
+			)
 		}
+	}
+
+	return extractions
+}
+
+// normalizeField lowercases a record field and maps aliases to their
+// canonical field: record_type to type, and srv.<field> to <field>.
+func normalizeField(field string) string {
+	field = strings.ToLower(field)
+	if field == FieldRecordType {
+		return FieldType
+	}
+	if rest, ok := strings.CutPrefix(field, "srv."); ok {
+		return rest
+	}
+	return field
+}
 
-		hostname, ok := fields[FieldHostname]
-		if !ok || hostname == "" {
-			p.logger.Warn("named record missing hostname",
+// parseRecord builds an extraction from the normalized fields of one record.
+// name is the record name or index, empty in simple hostname mode. Returns
+// false if the record is disabled or has no hostname. Invalid TTL and SRV
+// values are logged and ignored.
+func (p *Parser) parseRecord(name string, fields map[string]string, providerHints map[string]string) (Extraction, bool) {
+	// Check if this record is explicitly disabled
+	if enabled, ok := fields[FieldEnabled]; ok {
+		if strings.EqualFold(strings.TrimSpace(enabled), "false") {
+			p.logger.Debug("record disabled",
 				slog.String("record", name),
 			)
-			continue
+			return Extraction{}, false
 		}
+	}
 
-		extraction := Extraction{
-			Hostname:   hostname,
-			RecordName: name,
-			Type:       strings.ToUpper(fields[FieldType]),
-			Target:     fields[FieldTarget],
-			Provider:   fields[FieldProvider],
-		}
+	hostname, ok := fields[FieldHostname]
+	if !ok || hostname == "" {
+		p.logger.Warn("record missing hostname",
+			slog.String("record", name),
+		)
+		return Extraction{}, false
+	}
 
-		// Parse TTL
-		if ttlStr, ok := fields[FieldTTL]; ok && ttlStr != "" {
-			if ttl, err := strconv.Atoi(ttlStr); err == nil && ttl > 0 {
-				extraction.TTL = ttl
-			} else {
-				p.logger.Warn("invalid TTL value",
-					slog.String("record", name),
-					slog.String("ttl", ttlStr),
-				)
-			}
-		}
+	extraction := Extraction{
+		Hostname:      hostname,
+		RecordName:    name,
+		Type:          strings.ToUpper(fields[FieldType]),
+		Target:        fields[FieldTarget],
+		Provider:      fields[FieldProvider],
+		ProviderHints: providerHints,
+	}
 
-		// Parse SRV fields if type is SRV or if port is specified
-		if extraction.Type == "SRV" || fields[FieldPort] != "" {
-			srv := &SRVData{}
-			hasSRVData := false
-
-			if portStr, ok := fields[FieldPort]; ok && portStr != "" {
-				if port, err := strconv.ParseUint(portStr, 10, 16); err == nil {
-					srv.Port = uint16(port)
-					hasSRVData = true
-				} else {
-					p.logger.Warn("invalid port value",
-						slog.String("record", name),
-						slog.String("port", portStr),
-					)
-				}
+	// A comma-separated target creates one record per target
+	if strings.Contains(extraction.Target, ",") {
+		for _, t := range strings.Split(extraction.Target, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				extraction.Targets = append(extraction.Targets, t)
 			}
+		}
+		extraction.Target = ""
+		if len(extraction.Targets) > 0 {
+			extraction.Target = extraction.Targets[0]
+		}
+		if len(extraction.Targets) < 2 {
+			extraction.Targets = nil
+		}
+	}
 
-			if priorityStr, ok := fields[FieldPriority]; ok && priorityStr != "" {
-				if priority, err := strconv.ParseUint(priorityStr, 10, 16); err == nil {
-					srv.Priority = uint16(priority)
-					hasSRVData = true
-				} else {
-					p.logger.Warn("invalid priority value",
-						slog.String("record", name),
-						slog.String("priority", priorityStr),
-					)
-				}
-			}
+	// Parse TTL
+	if ttlStr, ok := fields[FieldTTL]; ok && ttlStr != "" {
+		if ttl, err := source.ParseTTL(ttlStr); err == nil {
+			extraction.TTL = ttl
+		} else {
+			p.logger.Warn("invalid TTL value",
+				slog.String("record", name),
+				slog.String("hostname", hostname),
+				slog.String("ttl", ttlStr),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
 
-			if weightStr, ok := fields[FieldWeight]; ok && weightStr != "" {
-				if weight, err := strconv.ParseUint(weightStr, 10, 16); err == nil {
-					srv.Weight = uint16(weight)
-					hasSRVData = true
-				} else {
-					p.logger.Warn("invalid weight value",
-						slog.String("record", name),
-						slog.String("weight", weightStr),
-					)
-				}
+	// Parse SRV fields if type is SRV or if port is specified
+	if extraction.Type == "SRV" || fields[FieldPort] != "" {
+		srv := &SRVData{}
+		hasSRVData := false
+
+		for _, f := range []struct {
+			field string
+			dest  *uint16
+		}{
+			{FieldPort, &srv.Port},
+			{FieldPriority, &srv.Priority},
+			{FieldWeight, &srv.Weight},
+		} {
+			value, ok := fields[f.field]
+			if !ok || value == "" {
+				continue
 			}
-
-			if hasSRVData {
-				extraction.SRV = srv
+			n, err := strconv.ParseUint(value, 10, 16)
+			if err != nil {
+				p.logger.Warn("invalid "+f.field+" value",
+					slog.String("record", name),
+					slog.String(f.field, value),
+				)
+				continue
 			}
+			*f.dest = uint16(n)
+			hasSRVData = true
 		}
 
-		extractions = append(extractions, extraction)
-		p.logger.Debug("found named dnsweaver record",
-			slog.String("name", name),
-			slog.String("hostname", hostname),
-			slog.String("type", extraction.Type),
-			slog.String("target", extraction.Target),
-			slog.String("provider", extraction.Provider),
-		)
+		if hasSRVData {
+			extraction.SRV = srv
+		}
 	}
 
-	return extractions
+	return extraction, true
 }
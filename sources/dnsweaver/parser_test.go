@@ -3,6 +3,7 @@ package dnsweaver
 import (
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -89,6 +90,29 @@ func TestParser_NamedRecord_Basic(t *testing.T) {
 	}
 }
 
+func TestParser_NamedRecord_MultipleTargets(t *testing.T) {
+	parser := NewParser(WithParserLogger(testLogger()))
+
+	labels := map[string]string{
+		"dnsweaver.records.web.hostname": "web.example.com",
+		"dnsweaver.records.web.target":   "10.0.0.1, 10.0.0.2",
+	}
+
+	extractions := parser.ExtractHostnames(labels)
+
+	if len(extractions) != 1 {
+		t.Fatalf("expected 1 extraction, got %d", len(extractions))
+	}
+
+	e := extractions[0]
+	if e.Target != "10.0.0.1" {
+		t.Errorf("target = %q, want %q", e.Target, "10.0.0.1")
+	}
+	if len(e.Targets) != 2 || e.Targets[0] != "10.0.0.1" || e.Targets[1] != "10.0.0.2" {
+		t.Errorf("targets = %v, want [10.0.0.1 10.0.0.2]", e.Targets)
+	}
+}
+
 func TestParser_NamedRecord_AllFields(t *testing.T) {
 	parser := NewParser(WithParserLogger(testLogger()))
 
@@ -261,6 +285,25 @@ func TestParser_InvalidTTL(t *testing.T) {
 	}
 }
 
+func TestParser_TTLOutOfRange(t *testing.T) {
+	parser := NewParser(WithParserLogger(testLogger()))
+
+	for _, ttl := range []string{"0", "-1", "86401"} {
+		labels := map[string]string{
+			"dnsweaver.hostname": "app.example.com",
+			"dnsweaver.ttl":      ttl,
+		}
+
+		extractions := parser.ExtractHostnames(labels)
+		if len(extractions) != 1 {
+			t.Fatalf("expected 1 extraction, got %d", len(extractions))
+		}
+		if extractions[0].TTL != 0 {
+			t.Errorf("ttl %q: got %d, want 0 (rejected)", ttl, extractions[0].TTL)
+		}
+	}
+}
+
 func TestParser_NoLabels(t *testing.T) {
 	parser := NewParser(WithParserLogger(testLogger()))
 
@@ -451,6 +494,31 @@ func TestParser_SimpleHostname_WithTTL_Trimmed(t *testing.T) {
 	}
 }
 
+func TestParser_ProviderHints(t *testing.T) {
+	parser := NewParser(WithParserLogger(testLogger()))
+
+	labels := map[string]string{
+		"dnsweaver.hostname":                "app.example.com",
+		"dnsweaver.records.api.hostname":    "api.example.com",
+		"dnsweaver.cloudflare.proxied":      "true",
+		"dnsweaver.unknownprovider.setting": "x",
+	}
+
+	extractions := parser.ExtractHostnames(labels)
+
+	if len(extractions) != 2 {
+		t.Fatalf("expected 2 extractions, got %d", len(extractions))
+	}
+	for _, e := range extractions {
+		if !e.HasHints() {
+			t.Errorf("%s: expected HasHints() to be true", e.Hostname)
+		}
+		if len(e.ProviderHints) != 1 || e.ProviderHints["cloudflare.proxied"] != "true" {
+			t.Errorf("%s: ProviderHints = %v, want only cloudflare.proxied=true", e.Hostname, e.ProviderHints)
+		}
+	}
+}
+
 func TestParser_SimpleHostname_InvalidTTL(t *testing.T) {
 	parser := NewParser(WithParserLogger(testLogger()))
 
@@ -505,3 +573,95 @@ func TestParser_NamedRecord_MixedEnabled(t *testing.T) {
 		t.Errorf("hostname = %q, expected enabled.example.com", extractions[0].Hostname)
 	}
 }
+
+func TestParser_SimpleHostname_AllFields(t *testing.T) {
+	parser := NewParser(WithParserLogger(testLogger()))
+
+	labels := map[string]string{
+		"dnsweaver.hostname":     "_minecraft._tcp.mc.example.com",
+		"dnsweaver.record_type":  "srv",
+		"dnsweaver.target":       "mc-server.example.com",
+		"dnsweaver.ttl":          "300",
+		"dnsweaver.provider":     "internal-dns",
+		"dnsweaver.srv.priority": "10",
+		"dnsweaver.srv.weight":   "5",
+		"dnsweaver.srv.port":     "25565",
+	}
+
+	extractions := parser.ExtractHostnames(labels)
+
+	if len(extractions) != 1 {
+		t.Fatalf("expected 1 extraction, got %d", len(extractions))
+	}
+
+	e := extractions[0]
+	if e.Type != "SRV" || e.Target != "mc-server.example.com" || e.TTL != 300 || e.Provider != "internal-dns" {
+		t.Errorf("extraction = %+v", e)
+	}
+	if e.SRV == nil || *e.SRV != (SRVData{Port: 25565, Priority: 10, Weight: 5}) {
+		t.Errorf("SRV = %+v, want port 25565, priority 10, weight 5", e.SRV)
+	}
+}
+
+func TestParser_IndexedRecords(t *testing.T) {
+	parser := NewParser(WithParserLogger(testLogger()))
+
+	labels := map[string]string{
+		"dnsweaver.hostname":         "simple.example.com",
+		"dnsweaver.10.hostname":      "ten.example.com",
+		"dnsweaver.0.hostname":       "app.example.com",
+		"dnsweaver.0.target":         "10.0.0.1, 10.0.0.2",
+		"dnsweaver.1.hostname":       "api.example.com",
+		"dnsweaver.1.record_type":    "cname",
+		"dnsweaver.1.target":         "app.example.com",
+		"dnsweaver.1.provider":       "public-dns",
+		"dnsweaver.2.hostname":       "_sip._tcp.example.com",
+		"dnsweaver.2.type":           "SRV",
+		"dnsweaver.2.srv.port":       "5060",
+		"dnsweaver.3.hostname":       "off.example.com",
+		"dnsweaver.3.enabled":        "false",
+		"dnsweaver.4.target":         "10.0.0.9",
+		"dnsweaver.records.web.type": "A",
+	}
+
+	extractions := parser.ExtractHostnames(labels)
+
+	var got []string
+	for _, e := range extractions {
+		got = append(got, e.RecordName+"="+e.Hostname)
+	}
+	want := []string{"=simple.example.com", "0=app.example.com", "1=api.example.com", "2=_sip._tcp.example.com", "10=ten.example.com"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("extractions = %v, want %v", got, want)
+	}
+
+	if e := extractions[1]; len(e.Targets) != 2 || e.Target != "10.0.0.1" {
+		t.Errorf("record 0 targets = %q / %v, want round-robin targets", e.Target, e.Targets)
+	}
+	if e := extractions[2]; e.Type != "CNAME" || e.Target != "app.example.com" || e.Provider != "public-dns" {
+		t.Errorf("record 1 = %+v", e)
+	}
+	if e := extractions[3]; e.SRV == nil || e.SRV.Port != 5060 {
+		t.Errorf("record 2 SRV = %+v, want port 5060", e.SRV)
+	}
+}
+
+func TestParser_NamedRecord_SRVPrefixedFields(t *testing.T) {
+	parser := NewParser(WithParserLogger(testLogger()))
+
+	labels := map[string]string{
+		"dnsweaver.records.mc.hostname":     "_minecraft._tcp.mc.example.com",
+		"dnsweaver.records.mc.record_type":  "SRV",
+		"dnsweaver.records.mc.srv.port":     "25565",
+		"dnsweaver.records.mc.srv.priority": "1",
+	}
+
+	extractions := parser.ExtractHostnames(labels)
+
+	if len(extractions) != 1 {
+		t.Fatalf("expected 1 extraction, got %d", len(extractions))
+	}
+	if e := extractions[0]; e.Type != "SRV" || e.SRV == nil || e.SRV.Port != 25565 || e.SRV.Priority != 1 {
+		t.Errorf("extraction = %+v, SRV = %+v", e, e.SRV)
+	}
+}
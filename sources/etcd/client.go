@@ -0,0 +1,287 @@
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+)
+
+// errCompacted is returned by Watch when the requested revision was compacted
+// away and the caller must reload the full key range.
+var errCompacted = errors.New("watch revision compacted")
+
+// KeyValue is a key and value from etcd.
+type KeyValue struct {
+	Key   string
+	Value []byte
+}
+
+// Event is a change to a key under the watched prefix.
+type Event struct {
+	Deleted bool
+	KV      KeyValue
+}
+
+// Client is a minimal client for the etcd v3 JSON gateway (/v3/kv/range and
+// /v3/watch). Endpoints are tried in order; the last one that answered is
+// used first for the next request.
+type Client struct {
+	endpoints   []string
+	httpClient  *http.Client // bounded requests
+	watchClient *http.Client // long-lived watch streams
+	logger      *slog.Logger
+
+	mu      sync.Mutex
+	current int
+}
+
+// ClientOption is a functional option for configuring the Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets a custom HTTP client. Its transport is also used for
+// watch streams, which run without a client timeout.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithClientLogger sets a custom logger.
+func WithClientLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// NewClient creates a new etcd gateway client from the source configuration.
+func NewClient(config *Config, opts ...ClientOption) (*Client, error) {
+	pool, certs, err := config.tlsSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		endpoints: config.Endpoints,
+		httpClient: httputil.NewClient(&httputil.ClientConfig{
+			RootCAs:      pool,
+			Certificates: certs,
+		}),
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.watchClient = &http.Client{Transport: c.httpClient.Transport}
+	return c, nil
+}
+
+// rangeRequest is the body of POST /v3/kv/range.
+type rangeRequest struct {
+	Key      string `json:"key"`
+	RangeEnd string `json:"range_end"`
+}
+
+// rangeResponse is the subset of the /v3/kv/range response used here.
+// The gateway encodes 64-bit integers as strings and bytes as base64.
+type rangeResponse struct {
+	Header struct {
+		Revision int64 `json:"revision,string"`
+	} `json:"header"`
+	KVs []struct {
+		Key   []byte `json:"key"`
+		Value []byte `json:"value"`
+	} `json:"kvs"`
+}
+
+// Range returns every key under prefix and the store revision of the read.
+func (c *Client) Range(ctx context.Context, prefix string) ([]KeyValue, int64, error) {
+	body, err := json.Marshal(rangeRequest{
+		Key:      base64.StdEncoding.EncodeToString([]byte(prefix)),
+		RangeEnd: base64.StdEncoding.EncodeToString(prefixEnd(prefix)),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("encoding range request: %w", err)
+	}
+
+	resp, err := c.post(ctx, c.httpClient, "/v3/kv/range", body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing keys: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result rangeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, 0, fmt.Errorf("parsing response JSON: %w", err)
+	}
+
+	kvs := make([]KeyValue, 0, len(result.KVs))
+	for _, kv := range result.KVs {
+		kvs = append(kvs, KeyValue{Key: string(kv.Key), Value: kv.Value})
+	}
+	return kvs, result.Header.Revision, nil
+}
+
+// watchRequest is the body of POST /v3/watch.
+type watchRequest struct {
+	CreateRequest struct {
+		Key           string `json:"key"`
+		RangeEnd      string `json:"range_end"`
+		StartRevision int64  `json:"start_revision,string"`
+	} `json:"create_request"`
+}
+
+// watchResponse is one message of the /v3/watch response stream.
+type watchResponse struct {
+	Result *struct {
+		Header struct {
+			Revision int64 `json:"revision,string"`
+		} `json:"header"`
+		CompactRevision int64  `json:"compact_revision,string"`
+		Canceled        bool   `json:"canceled"`
+		CancelReason    string `json:"cancel_reason"`
+		Events          []struct {
+			Type string `json:"type"`
+			KV   struct {
+				Key   []byte `json:"key"`
+				Value []byte `json:"value"`
+			} `json:"kv"`
+		} `json:"events"`
+	} `json:"result"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Watch streams changes under prefix starting at startRevision, calling
+// onEvents with each batch and the revision it brings the store to. It blocks
+// until ctx is cancelled or the stream ends. errCompacted is returned when
+// startRevision is no longer available.
+func (c *Client) Watch(ctx context.Context, prefix string, startRevision int64, onEvents func([]Event, int64)) error {
+	var req watchRequest
+	req.CreateRequest.Key = base64.StdEncoding.EncodeToString([]byte(prefix))
+	req.CreateRequest.RangeEnd = base64.StdEncoding.EncodeToString(prefixEnd(prefix))
+	req.CreateRequest.StartRevision = startRevision
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("encoding watch request: %w", err)
+	}
+
+	resp, err := c.post(ctx, c.watchClient, "/v3/watch", body)
+	if err != nil {
+		return fmt.Errorf("starting watch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var msg watchResponse
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("watch stream closed")
+			}
+			return fmt.Errorf("reading watch stream: %w", err)
+		}
+
+		if msg.Error != nil {
+			return fmt.Errorf("watch failed: %s", msg.Error.Message)
+		}
+		if msg.Result == nil {
+			continue
+		}
+		if msg.Result.CompactRevision > 0 {
+			return errCompacted
+		}
+		if msg.Result.Canceled {
+			return fmt.Errorf("watch canceled: %s", msg.Result.CancelReason)
+		}
+		if len(msg.Result.Events) == 0 {
+			continue
+		}
+
+		events := make([]Event, 0, len(msg.Result.Events))
+		for _, ev := range msg.Result.Events {
+			events = append(events, Event{
+				Deleted: ev.Type == "DELETE",
+				KV:      KeyValue{Key: string(ev.KV.Key), Value: ev.KV.Value},
+			})
+		}
+		onEvents(events, msg.Result.Header.Revision)
+	}
+}
+
+// post sends a JSON request to the first endpoint that answers.
+// The caller must close the response body.
+func (c *Client) post(ctx context.Context, httpClient *http.Client, path string, body []byte) (*http.Response, error) {
+	c.mu.Lock()
+	start := c.current
+	c.mu.Unlock()
+
+	var lastErr error
+	for i := range c.endpoints {
+		idx := (start + i) % len(c.endpoints)
+		endpoint := c.endpoints[idx]
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+path, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			c.logger.Debug("etcd endpoint unavailable",
+				slog.String("endpoint", endpoint),
+				slog.String("error", err.Error()),
+			)
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+			lastErr = fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, endpoint, strings.TrimSpace(string(msg)))
+			continue
+		}
+
+		c.mu.Lock()
+		c.current = idx
+		c.mu.Unlock()
+		return resp, nil
+	}
+
+	return nil, lastErr
+}
+
+// prefixEnd returns the range end that selects every key starting with prefix.
+func prefixEnd(prefix string) []byte {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// Prefix is all 0xff bytes: range to the end of the keyspace
+	return []byte{0}
+}
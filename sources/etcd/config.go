@@ -0,0 +1,113 @@
+package etcd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Configuration defaults.
+const (
+	// DefaultEndpoint is the default etcd client URL.
+	DefaultEndpoint = "http://127.0.0.1:2379"
+
+	// DefaultPrefix is the key prefix watched for hostname entries.
+	DefaultPrefix = "/dnsweaver/hostnames/"
+)
+
+// Config holds etcd source configuration.
+type Config struct {
+	Endpoints []string // etcd client URLs, tried in order
+	Prefix    string   // Key prefix holding hostname entries
+	CAFile    string   // PEM CA bundle for verifying etcd (optional)
+	CertFile  string   // PEM client certificate for mutual TLS (optional)
+	KeyFile   string   // PEM private key for CertFile (optional)
+}
+
+// Validate checks that the configuration is usable.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if len(c.Endpoints) == 0 {
+		errs = append(errs, "ENDPOINTS is required")
+	}
+	for _, ep := range c.Endpoints {
+		if !strings.HasPrefix(ep, "http://") && !strings.HasPrefix(ep, "https://") {
+			errs = append(errs, fmt.Sprintf("endpoint %q must start with http:// or https://", ep))
+		}
+	}
+	if c.Prefix == "" {
+		errs = append(errs, "PREFIX is required")
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		errs = append(errs, "CERT_FILE and KEY_FILE must be set together")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("etcd config validation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// LoadConfig loads etcd source configuration from environment variables.
+//
+// Supported settings:
+//   - DNSWEAVER_ETCD_ENDPOINTS: Comma-separated client URLs (default: http://127.0.0.1:2379)
+//   - DNSWEAVER_ETCD_PREFIX: Key prefix to watch (default: /dnsweaver/hostnames/)
+//   - DNSWEAVER_ETCD_CA_FILE: PEM CA bundle for TLS verification (optional)
+//   - DNSWEAVER_ETCD_CERT_FILE / DNSWEAVER_ETCD_KEY_FILE: Client certificate and key (optional)
+func LoadConfig() (*Config, error) {
+	cfg := &Config{
+		Prefix:   os.Getenv("DNSWEAVER_ETCD_PREFIX"),
+		CAFile:   os.Getenv("DNSWEAVER_ETCD_CA_FILE"),
+		CertFile: os.Getenv("DNSWEAVER_ETCD_CERT_FILE"),
+		KeyFile:  os.Getenv("DNSWEAVER_ETCD_KEY_FILE"),
+	}
+
+	for _, ep := range strings.Split(os.Getenv("DNSWEAVER_ETCD_ENDPOINTS"), ",") {
+		if ep = strings.TrimSpace(ep); ep != "" {
+			cfg.Endpoints = append(cfg.Endpoints, strings.TrimSuffix(ep, "/"))
+		}
+	}
+	if len(cfg.Endpoints) == 0 {
+		cfg.Endpoints = []string{DefaultEndpoint}
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = DefaultPrefix
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// tlsSettings loads the configured CA bundle and client certificate.
+func (c *Config) tlsSettings() (*x509.CertPool, []tls.Certificate, error) {
+	var pool *x509.CertPool
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading CA file: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, nil, fmt.Errorf("no certificates found in CA file %s", c.CAFile)
+		}
+	}
+
+	var certs []tls.Certificate
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	return pool, certs, nil
+}
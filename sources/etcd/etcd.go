@@ -0,0 +1,344 @@
+// Package etcd provides a Source implementation for discovering hostnames
+// from keys in an etcd v3 cluster.
+//
+// Every key under the configured prefix holds a JSON document describing one
+// hostname. The source loads the prefix with a range request and then follows
+// changes through the etcd v3 JSON gateway's watch stream.
+//
+// Example entry:
+//
+//	etcdctl put /dnsweaver/hostnames/app '{"hostname":"app.example.com","ttl":300}'
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+const sourceName = "etcd"
+
+// Retry backoff for failed range and watch requests.
+const (
+	minRetryDelay = time.Second
+	maxRetryDelay = time.Minute
+)
+
+// entry is the JSON document stored under each key.
+type entry struct {
+	Hostname string `json:"hostname"`
+	TTL      int    `json:"ttl,omitempty"`
+}
+
+// Etcd implements the source.Source and source.Notifier interfaces for
+// hostnames stored in etcd.
+type Etcd struct {
+	config *Config
+	client *Client
+	logger *slog.Logger
+
+	mu        sync.Mutex
+	loaded    bool
+	revision  int64
+	entries   map[string]entry // keyed by etcd key
+	hostnames []source.Hostname
+}
+
+// Option is a functional option for configuring Etcd.
+type Option func(*Etcd)
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(e *Etcd) {
+		if logger != nil {
+			e.logger = logger
+		}
+	}
+}
+
+// WithClient sets a custom etcd gateway client (useful for testing).
+func WithClient(client *Client) Option {
+	return func(e *Etcd) {
+		e.client = client
+	}
+}
+
+// New creates a new etcd source.
+func New(config *Config, opts ...Option) (*Etcd, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	e := &Etcd{
+		config:  config,
+		logger:  slog.Default(),
+		entries: make(map[string]entry),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	if e.client == nil {
+		client, err := NewClient(config, WithClientLogger(e.logger))
+		if err != nil {
+			return nil, err
+		}
+		e.client = client
+	}
+
+	return e, nil
+}
+
+// Name returns the source identifier.
+func (e *Etcd) Name() string {
+	return sourceName
+}
+
+// Extract is a no-op: etcd hostnames are not carried on Docker labels.
+func (e *Etcd) Extract(_ context.Context, _ map[string]string) ([]source.Hostname, error) {
+	return nil, nil
+}
+
+// SupportsDiscovery always returns true; etcd hostnames come from Discover.
+func (e *Etcd) SupportsDiscovery() bool {
+	return true
+}
+
+// Discover returns the hostnames from the most recent snapshot of the prefix.
+// etcd is only queried directly if Watch has not loaded the prefix yet.
+func (e *Etcd) Discover(ctx context.Context) ([]source.Hostname, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.loaded {
+		return e.hostnames, nil
+	}
+
+	kvs, revision, err := e.client.Range(ctx, e.config.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	e.load(kvs, revision)
+
+	return e.hostnames, nil
+}
+
+// Watch follows changes under the prefix until ctx is cancelled, calling
+// onChange whenever the discovered hostnames change. The prefix is reloaded
+// when the watch falls behind a compaction, and failed requests are retried
+// with exponential backoff.
+func (e *Etcd) Watch(ctx context.Context, onChange func()) error {
+	delay := minRetryDelay
+
+	for {
+		err := e.watchOnce(ctx, onChange, func() { delay = minRetryDelay })
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if errors.Is(err, errCompacted) {
+			e.logger.Info("etcd watch revision compacted, reloading prefix",
+				slog.String("prefix", e.config.Prefix),
+			)
+		} else {
+			e.logger.Warn("etcd watch failed, retrying",
+				slog.String("error", err.Error()),
+				slog.Duration("retry_in", delay),
+			)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, maxRetryDelay)
+		}
+
+		// Reload the full prefix so no change made while disconnected is missed
+		e.mu.Lock()
+		e.loaded = false
+		e.mu.Unlock()
+	}
+}
+
+// watchOnce loads the prefix if needed and then streams changes until the
+// watch ends. connected is called once the prefix is loaded.
+func (e *Etcd) watchOnce(ctx context.Context, onChange func(), connected func()) error {
+	e.mu.Lock()
+	loaded := e.loaded
+	e.mu.Unlock()
+
+	if !loaded {
+		kvs, revision, err := e.client.Range(ctx, e.config.Prefix)
+		if err != nil {
+			return err
+		}
+		e.mu.Lock()
+		changed := e.load(kvs, revision)
+		e.mu.Unlock()
+		if changed {
+			onChange()
+		}
+	}
+	connected()
+
+	e.mu.Lock()
+	start := e.revision + 1
+	e.mu.Unlock()
+
+	return e.client.Watch(ctx, e.config.Prefix, start, func(events []Event, revision int64) {
+		e.mu.Lock()
+		changed := e.apply(events, revision)
+		e.mu.Unlock()
+
+		if changed {
+			e.logger.Info("etcd hostnames changed",
+				slog.Int64("revision", revision),
+				slog.Int("events", len(events)),
+			)
+			onChange()
+		}
+	})
+}
+
+// load replaces the stored entries with a full range snapshot and reports
+// whether the hostname set changed. Callers must hold e.mu.
+func (e *Etcd) load(kvs []KeyValue, revision int64) bool {
+	e.entries = make(map[string]entry, len(kvs))
+	for _, kv := range kvs {
+		e.put(kv)
+	}
+	e.revision = revision
+
+	return e.rebuild()
+}
+
+// apply updates the stored entries from watch events and reports whether the
+// hostname set changed. Callers must hold e.mu.
+func (e *Etcd) apply(events []Event, revision int64) bool {
+	for _, ev := range events {
+		if ev.Deleted {
+			delete(e.entries, ev.KV.Key)
+			continue
+		}
+		e.put(ev.KV)
+	}
+	if revision > e.revision {
+		e.revision = revision
+	}
+
+	return e.rebuild()
+}
+
+// put parses and stores a single key. Invalid values are logged and dropped.
+// Callers must hold e.mu.
+func (e *Etcd) put(kv KeyValue) {
+	var ent entry
+	if err := json.Unmarshal(kv.Value, &ent); err != nil {
+		e.logger.Warn("ignoring etcd key with invalid JSON value",
+			slog.String("key", kv.Key),
+			slog.String("error", err.Error()),
+		)
+		delete(e.entries, kv.Key)
+		return
+	}
+	ent.Hostname = strings.TrimSpace(ent.Hostname)
+	if ent.Hostname == "" {
+		e.logger.Warn("ignoring etcd key without hostname",
+			slog.String("key", kv.Key),
+		)
+		delete(e.entries, kv.Key)
+		return
+	}
+	e.entries[kv.Key] = ent
+}
+
+// rebuild recomputes the hostname list from the stored entries and reports
+// whether it changed. Callers must hold e.mu.
+func (e *Etcd) rebuild() bool {
+	hostnames := extractHostnames(e.entries)
+	changed := !e.loaded || !sameHostnames(e.hostnames, hostnames)
+	e.hostnames = hostnames
+	e.loaded = true
+
+	e.logger.Debug("discovered hostnames from etcd",
+		slog.String("prefix", e.config.Prefix),
+		slog.Int("keys", len(e.entries)),
+		slog.Int("count", len(hostnames)),
+	)
+
+	return changed
+}
+
+// extractHostnames returns the deduplicated, sorted hostnames from the entries.
+// When several keys name the same hostname, the entry with the smallest key wins.
+func extractHostnames(entries map[string]entry) []source.Hostname {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	seen := make(map[string]struct{})
+	hostnames := make([]source.Hostname, 0, len(keys))
+	for _, key := range keys {
+		ent := entries[key]
+		lower := strings.ToLower(ent.Hostname)
+		if _, exists := seen[lower]; exists {
+			continue
+		}
+		seen[lower] = struct{}{}
+
+		hostname := source.Hostname{
+			Name:   ent.Hostname,
+			Source: sourceName,
+		}
+		if ent.TTL > 0 {
+			hostname.RecordHints = &source.RecordHints{TTL: ent.TTL}
+		}
+		hostnames = append(hostnames, hostname)
+	}
+
+	sort.Slice(hostnames, func(i, j int) bool {
+		return hostnames[i].Name < hostnames[j].Name
+	})
+	return hostnames
+}
+
+// sameHostnames reports whether two sorted hostname lists have the same names and TTLs.
+func sameHostnames(a, b []source.Hostname) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || hintTTL(a[i]) != hintTTL(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// hintTTL returns the TTL hint of a hostname, or 0 if none is set.
+func hintTTL(h source.Hostname) int {
+	if h.RecordHints == nil {
+		return 0
+	}
+	return h.RecordHints.TTL
+}
+
+// Ensure Etcd implements source.Source and source.Notifier
+var (
+	_ source.Source   = (*Etcd)(nil)
+	_ source.Notifier = (*Etcd)(nil)
+)
@@ -0,0 +1,363 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// fakeGateway serves the etcd v3 JSON gateway endpoints used by the source.
+type fakeGateway struct {
+	t *testing.T
+
+	mu       sync.Mutex
+	revision int64
+	kvs      map[string]string
+	watchers []chan map[string]any
+	ranges   atomic.Int32
+}
+
+func newFakeGateway(t *testing.T, kvs map[string]string) *fakeGateway {
+	return &fakeGateway{t: t, revision: 5, kvs: kvs}
+}
+
+// put stores a key and notifies watchers.
+func (f *fakeGateway) put(key, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.revision++
+	f.kvs[key] = value
+	f.broadcast(map[string]any{"kv": f.kv(key, value)})
+}
+
+// remove deletes a key and notifies watchers.
+func (f *fakeGateway) remove(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.revision++
+	delete(f.kvs, key)
+	f.broadcast(map[string]any{"type": "DELETE", "kv": f.kv(key, "")})
+}
+
+func (f *fakeGateway) broadcast(event map[string]any) {
+	msg := map[string]any{"result": map[string]any{
+		"header": map[string]any{"revision": strconv.FormatInt(f.revision, 10)},
+		"events": []any{event},
+	}}
+	for _, ch := range f.watchers {
+		ch <- msg
+	}
+}
+
+func (f *fakeGateway) kv(key, value string) map[string]any {
+	return map[string]any{"key": []byte(key), "value": []byte(value)}
+}
+
+func (f *fakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v3/kv/range":
+		f.serveRange(w, r)
+	case "/v3/watch":
+		f.serveWatch(w, r)
+	default:
+		f.t.Errorf("unexpected path: %s", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeGateway) serveRange(w http.ResponseWriter, r *http.Request) {
+	f.ranges.Add(1)
+
+	var req struct {
+		Key      []byte `json:"key"`
+		RangeEnd []byte `json:"range_end"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		f.t.Errorf("invalid range request: %v", err)
+	}
+	if string(req.Key) != DefaultPrefix || string(req.RangeEnd) != "/dnsweaver/hostnames0" {
+		f.t.Errorf("range = [%q, %q), want prefix %q", req.Key, req.RangeEnd, DefaultPrefix)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	kvs := make([]any, 0, len(f.kvs))
+	for key, value := range f.kvs {
+		kvs = append(kvs, f.kv(key, value))
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"header": map[string]any{"revision": strconv.FormatInt(f.revision, 10)},
+		"kvs":    kvs,
+	})
+}
+
+func (f *fakeGateway) serveWatch(w http.ResponseWriter, r *http.Request) {
+	ch := make(chan map[string]any, 10)
+	f.mu.Lock()
+	f.watchers = append(f.watchers, ch)
+	f.mu.Unlock()
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"result": map[string]any{"created": true}})
+	w.(http.Flusher).Flush()
+
+	for {
+		select {
+		case msg := <-ch:
+			_ = json.NewEncoder(w).Encode(msg)
+			w.(http.Flusher).Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func newTestSource(t *testing.T, serverURL string) *Etcd {
+	t.Helper()
+	src, err := New(&Config{
+		Endpoints: []string{serverURL},
+		Prefix:    DefaultPrefix,
+	}, WithLogger(testLogger()))
+	if err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+	return src
+}
+
+func TestEtcd_Discover(t *testing.T) {
+	gateway := newFakeGateway(t, map[string]string{
+		"/dnsweaver/hostnames/web":   `{"hostname":"app.example.com","ttl":300}`,
+		"/dnsweaver/hostnames/api":   `{"hostname":"api.example.com"}`,
+		"/dnsweaver/hostnames/dup":   `{"hostname":"APP.example.com"}`,
+		"/dnsweaver/hostnames/bad":   `not json`,
+		"/dnsweaver/hostnames/empty": `{"ttl":60}`,
+	})
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	src := newTestSource(t, server.URL)
+
+	if src.Name() != "etcd" {
+		t.Errorf("Name() = %q, want etcd", src.Name())
+	}
+	if !src.SupportsDiscovery() {
+		t.Error("expected SupportsDiscovery to be true")
+	}
+
+	hostnames, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	// "dup" sorts before "web", so its spelling wins for app.example.com
+	want := []struct {
+		name string
+		ttl  int
+	}{
+		{"APP.example.com", 0},
+		{"api.example.com", 0},
+	}
+	if len(hostnames) != len(want) {
+		t.Fatalf("expected %d hostnames, got %d: %v", len(want), len(hostnames), hostnames)
+	}
+	for i, h := range hostnames {
+		if h.Name != want[i].name {
+			t.Errorf("hostnames[%d] = %q, want %q", i, h.Name, want[i].name)
+		}
+		if got := hintTTL(h); got != want[i].ttl {
+			t.Errorf("hostnames[%d] TTL = %d, want %d", i, got, want[i].ttl)
+		}
+		if h.Source != "etcd" {
+			t.Errorf("Source = %q, want etcd", h.Source)
+		}
+	}
+
+	// Subsequent calls use the cached snapshot
+	if _, err := src.Discover(context.Background()); err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if got := gateway.ranges.Load(); got != 1 {
+		t.Errorf("expected 1 range request, got %d", got)
+	}
+}
+
+func TestEtcd_Watch(t *testing.T) {
+	gateway := newFakeGateway(t, map[string]string{
+		"/dnsweaver/hostnames/web": `{"hostname":"app.example.com"}`,
+	})
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	src := newTestSource(t, server.URL)
+	if _, err := src.Discover(context.Background()); err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notified := make(chan struct{}, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- src.Watch(ctx, func() { notified <- struct{}{} })
+	}()
+
+	// Wait for the watch stream to be established
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		gateway.mu.Lock()
+		n := len(gateway.watchers)
+		gateway.mu.Unlock()
+		if n > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("watch was not started")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Rewriting a key with the same hostname must not trigger a reconcile
+	gateway.put("/dnsweaver/hostnames/web", `{"hostname":"app.example.com"}`)
+	select {
+	case <-notified:
+		t.Fatal("unexpected notification for unchanged hostnames")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	gateway.put("/dnsweaver/hostnames/api", `{"hostname":"api.example.com","ttl":120}`)
+	select {
+	case <-notified:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected notification after put")
+	}
+
+	hostnames, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(hostnames) != 2 || hintTTL(hostnames[0]) != 120 {
+		t.Errorf("unexpected hostnames after put: %v", hostnames)
+	}
+
+	gateway.remove("/dnsweaver/hostnames/web")
+	select {
+	case <-notified:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected notification after delete")
+	}
+
+	hostnames, _ = src.Discover(context.Background())
+	if len(hostnames) != 1 || hostnames[0].Name != "api.example.com" {
+		t.Errorf("unexpected hostnames after delete: %v", hostnames)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("Watch() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watch did not stop after cancel")
+	}
+}
+
+func TestEtcd_Discover_EndpointFailover(t *testing.T) {
+	gateway := newFakeGateway(t, map[string]string{
+		"/dnsweaver/hostnames/web": `{"hostname":"app.example.com"}`,
+	})
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	src, err := New(&Config{
+		Endpoints: []string{down.URL, server.URL},
+		Prefix:    DefaultPrefix,
+	}, WithLogger(testLogger()))
+	if err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+
+	hostnames, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(hostnames) != 1 {
+		t.Errorf("expected 1 hostname, got %v", hostnames)
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Error("expected error for nil config")
+	}
+	if _, err := New(&Config{}); err == nil {
+		t.Error("expected error for empty config")
+	}
+	if _, err := New(&Config{Endpoints: []string{"127.0.0.1:2379"}, Prefix: "/x/"}); err == nil {
+		t.Error("expected error for endpoint without scheme")
+	}
+	if _, err := New(&Config{Endpoints: []string{DefaultEndpoint}, Prefix: "/x/", CertFile: "cert.pem"}); err == nil {
+		t.Error("expected error for certificate without key")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("DNSWEAVER_ETCD_ENDPOINTS", "")
+	t.Setenv("DNSWEAVER_ETCD_PREFIX", "")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(cfg.Endpoints) != 1 || cfg.Endpoints[0] != DefaultEndpoint {
+		t.Errorf("Endpoints = %v, want [%s]", cfg.Endpoints, DefaultEndpoint)
+	}
+	if cfg.Prefix != DefaultPrefix {
+		t.Errorf("Prefix = %q, want %q", cfg.Prefix, DefaultPrefix)
+	}
+
+	t.Setenv("DNSWEAVER_ETCD_ENDPOINTS", "https://etcd1:2379/, https://etcd2:2379")
+	t.Setenv("DNSWEAVER_ETCD_PREFIX", "/dns/")
+
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(cfg.Endpoints) != 2 || cfg.Endpoints[0] != "https://etcd1:2379" {
+		t.Errorf("Endpoints = %v", cfg.Endpoints)
+	}
+	if cfg.Prefix != "/dns/" {
+		t.Errorf("Prefix = %q, want /dns/", cfg.Prefix)
+	}
+}
+
+func TestPrefixEnd(t *testing.T) {
+	tests := map[string]string{
+		"/a/":      "/a0",
+		"a":        "b",
+		"a\xff":    "b",
+		"\xff\xff": "\x00",
+	}
+	for prefix, want := range tests {
+		if got := string(prefixEnd(prefix)); got != want {
+			t.Errorf("prefixEnd(%q) = %q, want %q", prefix, got, want)
+		}
+	}
+}
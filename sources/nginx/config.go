@@ -0,0 +1,76 @@
+package nginx
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultPattern matches every file in the config directory. The
+// sites-enabled convention does not require a file extension.
+const DefaultPattern = "*"
+
+// Config holds nginx source configuration.
+type Config struct {
+	// ConfigDir is the directory containing nginx server configs.
+	ConfigDir string
+
+	// Pattern is a comma-separated list of glob patterns matched against
+	// file names in ConfigDir.
+	Pattern string
+}
+
+// Patterns returns the configured glob patterns.
+func (c *Config) Patterns() []string {
+	var patterns []string
+	for _, p := range strings.Split(c.Pattern, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	if len(patterns) == 0 {
+		return []string{DefaultPattern}
+	}
+	return patterns
+}
+
+// Validate checks that the configuration is usable.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.ConfigDir == "" {
+		errs = append(errs, "CONFIG_DIR is required")
+	}
+	for _, p := range c.Patterns() {
+		if _, err := filepath.Match(p, ""); err != nil {
+			errs = append(errs, fmt.Sprintf("CONFIG_PATTERN %q is invalid: %v", p, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("nginx config validation failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// LoadConfig loads nginx source configuration from environment variables.
+//
+// Supported settings:
+//   - DNSWEAVER_NGINX_CONFIG_DIR: Directory of nginx configs (required)
+//   - DNSWEAVER_NGINX_CONFIG_PATTERN: Comma-separated file globs (default: *)
+func LoadConfig() (*Config, error) {
+	cfg := &Config{
+		ConfigDir: os.Getenv("DNSWEAVER_NGINX_CONFIG_DIR"),
+		Pattern:   os.Getenv("DNSWEAVER_NGINX_CONFIG_PATTERN"),
+	}
+	if cfg.Pattern == "" {
+		cfg.Pattern = DefaultPattern
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
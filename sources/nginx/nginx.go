@@ -0,0 +1,180 @@
+// Package nginx provides a Source implementation that discovers hostnames
+// from the server_name directives in nginx configuration files.
+//
+// The source scans a directory such as /etc/nginx/sites-enabled, so hosts
+// served by an nginx instance outside Docker can be kept in DNS without
+// duplicating their names in labels or a static file.
+//
+// Example file:
+//
+//	server {
+//	    listen 443 ssl;
+//	    server_name app.example.com www.example.com;
+//	}
+package nginx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+const sourceName = "nginx"
+
+// Nginx implements the source.Source interface for nginx config files.
+type Nginx struct {
+	config *Config
+	logger *slog.Logger
+}
+
+// Option is a functional option for configuring Nginx.
+type Option func(*Nginx)
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(n *Nginx) {
+		if logger != nil {
+			n.logger = logger
+		}
+	}
+}
+
+// New creates a new nginx source.
+func New(config *Config, opts ...Option) (*Nginx, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	n := &Nginx{
+		config: config,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n, nil
+}
+
+// Name returns the source identifier.
+func (n *Nginx) Name() string {
+	return sourceName
+}
+
+// Extract is a no-op: nginx hostnames are not carried on Docker labels.
+func (n *Nginx) Extract(_ context.Context, _ map[string]string) ([]source.Hostname, error) {
+	return nil, nil
+}
+
+// SupportsDiscovery always returns true; nginx hostnames come from Discover.
+func (n *Nginx) SupportsDiscovery() bool {
+	return true
+}
+
+// Discover scans the matching config files for server_name directives.
+//
+// A missing directory is logged and treated as empty. Names that are not
+// valid DNS hostnames are skipped with a warning; a file that cannot be read
+// is returned as an error so that its records are not treated as orphans.
+func (n *Nginx) Discover(ctx context.Context) ([]source.Hostname, error) {
+	files, err := n.files()
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			n.logger.Warn("nginx config directory not found",
+				slog.String("path", n.config.ConfigDir),
+			)
+			return []source.Hostname{}, nil
+		}
+		return nil, err
+	}
+
+	hostnames := make(source.Hostnames, 0)
+	for _, path := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading nginx config %s: %w", path, err)
+		}
+
+		for _, name := range ServerNames(string(data)) {
+			h := source.Hostname{
+				Name:   name,
+				Source: sourceName,
+				Router: filepath.Base(path),
+			}
+			if err := h.Validate(); err != nil {
+				n.logger.Warn("skipping invalid nginx server_name",
+					slog.String("path", path),
+					slog.String("name", name),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			hostnames = append(hostnames, h)
+		}
+	}
+
+	hostnames = hostnames.Deduplicate()
+
+	n.logger.Debug("discovered nginx hostnames",
+		slog.String("path", n.config.ConfigDir),
+		slog.Int("files", len(files)),
+		slog.Int("count", len(hostnames)),
+	)
+
+	return hostnames, nil
+}
+
+// files returns the regular files in ConfigDir matching any configured
+// pattern, sorted by path. Symlinks are followed, as sites-enabled entries
+// usually point into sites-available.
+func (n *Nginx) files() ([]string, error) {
+	if _, err := os.Stat(n.config.ConfigDir); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	var files []string
+	for _, pattern := range n.config.Patterns() {
+		matches, err := filepath.Glob(filepath.Join(n.config.ConfigDir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("matching nginx config pattern %q: %w", pattern, err)
+		}
+		for _, path := range matches {
+			if _, ok := seen[path]; ok {
+				continue
+			}
+			seen[path] = struct{}{}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				n.logger.Warn("skipping unreadable nginx config",
+					slog.String("path", path),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			if info.IsDir() {
+				continue
+			}
+			files = append(files, path)
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
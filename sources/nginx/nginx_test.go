@@ -0,0 +1,164 @@
+package nginx
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing file: %v", err)
+	}
+	return path
+}
+
+func TestNew_RequiresConfigDir(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Error("expected error for nil config")
+	}
+	if _, err := New(&Config{}); err == nil {
+		t.Error("expected error for empty config dir")
+	}
+	if _, err := New(&Config{ConfigDir: "/etc/nginx", Pattern: "[a"}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("DNSWEAVER_NGINX_CONFIG_DIR", "")
+	t.Setenv("DNSWEAVER_NGINX_CONFIG_PATTERN", "")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected error when DNSWEAVER_NGINX_CONFIG_DIR is unset")
+	}
+
+	t.Setenv("DNSWEAVER_NGINX_CONFIG_DIR", "/etc/nginx/sites-enabled")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.ConfigDir != "/etc/nginx/sites-enabled" {
+		t.Errorf("ConfigDir = %q, want /etc/nginx/sites-enabled", cfg.ConfigDir)
+	}
+	if cfg.Pattern != DefaultPattern {
+		t.Errorf("Pattern = %q, want %q", cfg.Pattern, DefaultPattern)
+	}
+
+	t.Setenv("DNSWEAVER_NGINX_CONFIG_PATTERN", "*.conf, *.site")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := cfg.Patterns(); len(got) != 2 || got[0] != "*.conf" || got[1] != "*.site" {
+		t.Errorf("Patterns() = %v, want [*.conf *.site]", got)
+	}
+}
+
+func TestNginx_Discover(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "app.conf", `
+server {
+    listen 443 ssl;
+    server_name app.example.com www.example.com;
+}`)
+	writeFile(t, dir, "other.conf", `
+server {
+    server_name WWW.example.com api.example.com;
+}`)
+	writeFile(t, dir, "default", `server { server_name _; }`)
+	writeFile(t, dir, "notes.txt", `server_name ignored.example.com;`)
+	if err := os.Mkdir(filepath.Join(dir, "sub.conf"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := New(&Config{ConfigDir: dir, Pattern: "*.conf,default"}, WithLogger(testLogger()))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got, err := n.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+
+	want := map[string]string{
+		"app.example.com": "app.conf",
+		"www.example.com": "app.conf",
+		"api.example.com": "other.conf",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Discover() returned %d hostnames, want %d: %v", len(got), len(want), got)
+	}
+	for _, h := range got {
+		router, ok := want[h.Name]
+		if !ok {
+			t.Errorf("unexpected hostname %q", h.Name)
+			continue
+		}
+		if h.Source != sourceName {
+			t.Errorf("%s: Source = %q, want %q", h.Name, h.Source, sourceName)
+		}
+		if h.Router != router {
+			t.Errorf("%s: Router = %q, want %q", h.Name, h.Router, router)
+		}
+	}
+}
+
+func TestNginx_Discover_FollowsSymlinks(t *testing.T) {
+	available := t.TempDir()
+	enabled := t.TempDir()
+	target := writeFile(t, available, "site", `server { server_name linked.example.com; }`)
+	if err := os.Symlink(target, filepath.Join(enabled, "site")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	n, err := New(&Config{ConfigDir: enabled, Pattern: DefaultPattern}, WithLogger(testLogger()))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got, err := n.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "linked.example.com" {
+		t.Errorf("Discover() = %v, want [linked.example.com]", got)
+	}
+}
+
+func TestNginx_Discover_MissingDir(t *testing.T) {
+	n, err := New(&Config{ConfigDir: filepath.Join(t.TempDir(), "missing")}, WithLogger(testLogger()))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got, err := n.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Discover() = %v, want empty", got)
+	}
+}
+
+func TestNginx_Extract(t *testing.T) {
+	n, err := New(&Config{ConfigDir: "/etc/nginx"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	got, err := n.Extract(context.Background(), map[string]string{"server_name": "x.example.com"})
+	if err != nil || got != nil {
+		t.Errorf("Extract() = %v, %v; want nil, nil", got, err)
+	}
+	if !n.SupportsDiscovery() {
+		t.Error("SupportsDiscovery() = false, want true")
+	}
+}
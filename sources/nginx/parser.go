@@ -0,0 +1,119 @@
+package nginx
+
+import (
+	"net"
+	"strings"
+)
+
+// ServerNames scans nginx configuration text and returns the hostnames
+// named by server_name directives, in order of appearance.
+//
+// This is a token scanner, not a full nginx parser: it strips comments,
+// honours quoting, and collects the arguments of every server_name
+// directive regardless of the block it appears in. Names dnsweaver cannot
+// manage are dropped: the catch-all "_", regular expressions, names with
+// variables, trailing wildcards, and IP addresses. A leading-dot name such
+// as ".example.com" yields both example.com and *.example.com.
+func ServerNames(data string) []string {
+	var names []string
+
+	tokens := tokenize(data)
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i] != "server_name" {
+			continue
+		}
+		for i++; i < len(tokens) && !isDelimiter(tokens[i]); i++ {
+			names = append(names, normalizeServerName(tokens[i])...)
+		}
+	}
+
+	return names
+}
+
+// tokenize splits nginx configuration text into words and the delimiters
+// ";", "{" and "}". Comments are dropped and quoted strings are returned
+// without their quotes.
+func tokenize(data string) []string {
+	var (
+		tokens []string
+		word   strings.Builder
+		quote  byte
+		inWord bool
+	)
+
+	flush := func() {
+		if inWord {
+			tokens = append(tokens, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		if quote != 0 {
+			switch {
+			case c == '\\' && i+1 < len(data):
+				i++
+				word.WriteByte(data[i])
+			case c == quote:
+				quote = 0
+			default:
+				word.WriteByte(c)
+			}
+			continue
+		}
+
+		switch c {
+		case '#':
+			flush()
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+		case '"', '\'':
+			quote = c
+			inWord = true
+		case ';', '{', '}':
+			flush()
+			tokens = append(tokens, string(c))
+		case ' ', '\t', '\r', '\n':
+			flush()
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+func isDelimiter(token string) bool {
+	return token == ";" || token == "{" || token == "}"
+}
+
+// normalizeServerName converts a server_name argument to the hostnames it
+// covers, or nil when it cannot be represented in DNS.
+func normalizeServerName(name string) []string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+
+	switch {
+	case name == "", name == "_":
+		return nil
+	case strings.HasPrefix(name, "~"), strings.Contains(name, "$"):
+		return nil
+	case strings.HasSuffix(name, ".*"):
+		return nil
+	case net.ParseIP(strings.Trim(name, "[]")) != nil:
+		return nil
+	case strings.HasPrefix(name, "."):
+		base := strings.TrimPrefix(name, ".")
+		if base == "" {
+			return nil
+		}
+		return []string{base, "*." + base}
+	}
+
+	return []string{name}
+}
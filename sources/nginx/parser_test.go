@@ -0,0 +1,71 @@
+package nginx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestServerNames(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "single server",
+			data: `server { listen 80; server_name app.example.com; }`,
+			want: []string{"app.example.com"},
+		},
+		{
+			name: "multiple names and servers",
+			data: `
+server {
+    server_name a.example.com B.Example.com.;
+}
+server {
+    server_name
+        c.example.com
+        d.example.com;
+}`,
+			want: []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com"},
+		},
+		{
+			name: "comments and quotes",
+			data: `
+# server_name commented.example.com;
+server {
+    server_name "quoted.example.com" 'single.example.com'; # trailing.example.com
+}`,
+			want: []string{"quoted.example.com", "single.example.com"},
+		},
+		{
+			name: "wildcards",
+			data: `server_name *.example.com .example.org www.example.*;`,
+			want: []string{"*.example.com", "example.org", "*.example.org"},
+		},
+		{
+			name: "unsupported names skipped",
+			data: `server_name _ "" ~^(?<sub>.+)\.example\.com$ $host 10.0.0.1 [::1] ok.example.com;`,
+			want: []string{"ok.example.com"},
+		},
+		{
+			name: "directive name must match exactly",
+			data: `server_name_in_redirect off; proxy_set_header Host server_name;`,
+			want: nil,
+		},
+		{
+			name: "unterminated directive",
+			data: `server_name last.example.com`,
+			want: []string{"last.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ServerNames(tt.data)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ServerNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package nginxproxy
+
+import "os"
+
+// Config holds nginx-proxy source configuration.
+type Config struct {
+	// LabelPrefix is prepended to the VIRTUAL_HOST and VIRTUAL_HOST_WEIGHT
+	// label names. Empty matches the labels nginx-proxy uses by default.
+	LabelPrefix string
+}
+
+// HostLabel returns the label carrying the comma-separated hostnames.
+func (c *Config) HostLabel() string {
+	return c.LabelPrefix + "VIRTUAL_HOST"
+}
+
+// WeightLabel returns the label carrying the optional routing weight.
+func (c *Config) WeightLabel() string {
+	return c.LabelPrefix + "VIRTUAL_HOST_WEIGHT"
+}
+
+// LoadConfig loads nginx-proxy source configuration from environment variables.
+//
+// Supported settings:
+//   - DNSWEAVER_NGINXPROXY_LABEL_PREFIX: Prefix for the VIRTUAL_HOST labels (default: none)
+func LoadConfig() *Config {
+	return &Config{
+		LabelPrefix: os.Getenv("DNSWEAVER_NGINXPROXY_LABEL_PREFIX"),
+	}
+}
@@ -0,0 +1,154 @@
+// Package nginxproxy provides a Source implementation for containers
+// published through nginx-proxy (jwilder/nginx-proxy).
+//
+// nginx-proxy routes traffic by the VIRTUAL_HOST setting of each container.
+// This source reads the same value from container labels, so services that
+// are already configured for nginx-proxy need no extra dnsweaver labels:
+//
+//	VIRTUAL_HOST=app.example.com,www.example.com
+//	VIRTUAL_HOST_WEIGHT=10
+//
+// VIRTUAL_HOST_WEIGHT becomes a weight hint for providers that support
+// weighted records. Both label names can be prefixed with
+// DNSWEAVER_NGINXPROXY_LABEL_PREFIX.
+package nginxproxy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+const sourceName = "nginx-proxy"
+
+// NginxProxy implements the source.Source interface for VIRTUAL_HOST labels.
+type NginxProxy struct {
+	config *Config
+	logger *slog.Logger
+}
+
+// Option is a functional option for configuring NginxProxy.
+type Option func(*NginxProxy)
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(n *NginxProxy) {
+		if logger != nil {
+			n.logger = logger
+		}
+	}
+}
+
+// New creates a new nginx-proxy source.
+func New(config *Config, opts ...Option) (*NginxProxy, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	n := &NginxProxy{
+		config: config,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n, nil
+}
+
+// Name returns the source identifier.
+func (n *NginxProxy) Name() string {
+	return sourceName
+}
+
+// Extract parses the VIRTUAL_HOST label and returns the hostnames it lists.
+//
+// Regular expressions (~...) and trailing wildcards (app.*) cannot be
+// expressed in DNS and are skipped, as are invalid hostnames; both are
+// logged. An invalid VIRTUAL_HOST_WEIGHT is ignored with a warning.
+func (n *NginxProxy) Extract(_ context.Context, labels map[string]string) ([]source.Hostname, error) {
+	value, ok := labels[n.config.HostLabel()]
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	weight := n.weight(labels)
+
+	hostnames := make(source.Hostnames, 0)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+		if name == "" {
+			continue
+		}
+		if strings.HasPrefix(name, "~") || strings.HasSuffix(name, ".*") {
+			n.logger.Debug("skipping unsupported VIRTUAL_HOST pattern",
+				slog.String("name", name),
+			)
+			continue
+		}
+
+		h := source.Hostname{
+			Name:   name,
+			Source: sourceName,
+		}
+		if weight > 0 {
+			h.RecordHints = &source.RecordHints{Weight: weight}
+		}
+		if err := h.Validate(); err != nil {
+			n.logger.Warn("skipping invalid VIRTUAL_HOST hostname",
+				slog.String("name", name),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		hostnames = append(hostnames, h)
+	}
+
+	hostnames = hostnames.Deduplicate()
+
+	if len(hostnames) > 0 {
+		n.logger.Debug("extracted hostnames from nginx-proxy labels",
+			slog.Int("count", len(hostnames)),
+		)
+	}
+
+	return hostnames, nil
+}
+
+// weight returns VIRTUAL_HOST_WEIGHT, or zero when the label is absent or
+// invalid.
+func (n *NginxProxy) weight(labels map[string]string) uint16 {
+	value, ok := labels[n.config.WeightLabel()]
+	if !ok {
+		return 0
+	}
+
+	weight, err := strconv.ParseUint(strings.TrimSpace(value), 10, 16)
+	if err != nil {
+		n.logger.Warn("ignoring invalid VIRTUAL_HOST_WEIGHT",
+			slog.String("label", n.config.WeightLabel()),
+			slog.String("value", value),
+		)
+		return 0
+	}
+
+	return uint16(weight)
+}
+
+// Discover is not supported: VIRTUAL_HOST only comes from container labels.
+func (n *NginxProxy) Discover(_ context.Context) ([]source.Hostname, error) {
+	return nil, nil
+}
+
+// SupportsDiscovery returns false since nginx-proxy settings are labels only.
+func (n *NginxProxy) SupportsDiscovery() bool {
+	return false
+}
+
+// Ensure NginxProxy implements source.Source
+var _ source.Source = (*NginxProxy)(nil)
@@ -0,0 +1,129 @@
+package nginxproxy
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("DNSWEAVER_NGINXPROXY_LABEL_PREFIX", "")
+	cfg := LoadConfig()
+	if cfg.HostLabel() != "VIRTUAL_HOST" || cfg.WeightLabel() != "VIRTUAL_HOST_WEIGHT" {
+		t.Errorf("labels = %q, %q; want VIRTUAL_HOST, VIRTUAL_HOST_WEIGHT", cfg.HostLabel(), cfg.WeightLabel())
+	}
+
+	t.Setenv("DNSWEAVER_NGINXPROXY_LABEL_PREFIX", "proxy.")
+	cfg = LoadConfig()
+	if cfg.HostLabel() != "proxy.VIRTUAL_HOST" {
+		t.Errorf("HostLabel() = %q, want proxy.VIRTUAL_HOST", cfg.HostLabel())
+	}
+}
+
+func TestNew_RequiresConfig(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Error("expected error for nil config")
+	}
+}
+
+func TestNginxProxy_Extract(t *testing.T) {
+	tests := []struct {
+		name       string
+		prefix     string
+		labels     map[string]string
+		want       []string
+		wantWeight uint16
+	}{
+		{
+			name:   "single hostname",
+			labels: map[string]string{"VIRTUAL_HOST": "app.example.com"},
+			want:   []string{"app.example.com"},
+		},
+		{
+			name:   "comma-separated list",
+			labels: map[string]string{"VIRTUAL_HOST": " app.example.com, WWW.example.com.,,app.example.com "},
+			want:   []string{"app.example.com", "www.example.com"},
+		},
+		{
+			name:   "unsupported patterns skipped",
+			labels: map[string]string{"VIRTUAL_HOST": `~^api\..*$,app.*,*.example.com,bad_host!.example.com`},
+			want:   []string{"*.example.com"},
+		},
+		{
+			name:       "weight hint",
+			labels:     map[string]string{"VIRTUAL_HOST": "app.example.com", "VIRTUAL_HOST_WEIGHT": "10"},
+			want:       []string{"app.example.com"},
+			wantWeight: 10,
+		},
+		{
+			name:   "invalid weight ignored",
+			labels: map[string]string{"VIRTUAL_HOST": "app.example.com", "VIRTUAL_HOST_WEIGHT": "heavy"},
+			want:   []string{"app.example.com"},
+		},
+		{
+			name:   "custom prefix",
+			prefix: "proxy.",
+			labels: map[string]string{
+				"VIRTUAL_HOST":              "ignored.example.com",
+				"proxy.VIRTUAL_HOST":        "app.example.com",
+				"proxy.VIRTUAL_HOST_WEIGHT": "5",
+			},
+			want:       []string{"app.example.com"},
+			wantWeight: 5,
+		},
+		{
+			name:   "no label",
+			labels: map[string]string{"traefik.enable": "true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := New(&Config{LabelPrefix: tt.prefix}, WithLogger(testLogger()))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			got, err := n.Extract(context.Background(), tt.labels)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Extract() = %v, want %v", got, tt.want)
+			}
+			for i, h := range got {
+				if h.Name != tt.want[i] {
+					t.Errorf("hostname[%d] = %q, want %q", i, h.Name, tt.want[i])
+				}
+				if h.Source != "nginx-proxy" {
+					t.Errorf("Source = %q, want nginx-proxy", h.Source)
+				}
+				var weight uint16
+				if h.RecordHints != nil {
+					weight = h.RecordHints.Weight
+				}
+				if weight != tt.wantWeight {
+					t.Errorf("%s: weight = %d, want %d", h.Name, weight, tt.wantWeight)
+				}
+			}
+		})
+	}
+}
+
+func TestNginxProxy_NoDiscovery(t *testing.T) {
+	n, err := New(LoadConfig())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if n.SupportsDiscovery() {
+		t.Error("SupportsDiscovery() = true, want false")
+	}
+	if got, err := n.Discover(context.Background()); got != nil || err != nil {
+		t.Errorf("Discover() = %v, %v; want nil, nil", got, err)
+	}
+}
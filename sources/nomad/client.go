@@ -0,0 +1,158 @@
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+)
+
+// jobStub is a job entry from GET /v1/jobs.
+type jobStub struct {
+	ID        string `json:"ID"`
+	Namespace string `json:"Namespace"`
+	Status    string `json:"Status"`
+}
+
+// job is the subset of a Nomad job specification used for discovery.
+type job struct {
+	ID         string            `json:"ID"`
+	Namespace  string            `json:"Namespace"`
+	Meta       map[string]string `json:"Meta"`
+	TaskGroups []taskGroup       `json:"TaskGroups"`
+}
+
+// taskGroup is a task group within a Nomad job.
+type taskGroup struct {
+	Name  string            `json:"Name"`
+	Meta  map[string]string `json:"Meta"`
+	Tasks []task            `json:"Tasks"`
+}
+
+// task is a single task within a task group.
+type task struct {
+	Name string            `json:"Name"`
+	Meta map[string]string `json:"Meta"`
+}
+
+// allocation is an allocation entry from GET /v1/job/{id}/allocations.
+type allocation struct {
+	ID           string `json:"ID"`
+	TaskGroup    string `json:"TaskGroup"`
+	ClientStatus string `json:"ClientStatus"`
+}
+
+// Client is a minimal Nomad HTTP API client.
+type Client struct {
+	addr       string
+	token      string
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// ClientOption is a functional option for configuring the Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithClientLogger sets a custom logger.
+func WithClientLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// NewClient creates a new Nomad API client.
+func NewClient(addr, token string, opts ...ClientOption) *Client {
+	c := &Client{
+		addr:       strings.TrimSuffix(addr, "/"),
+		token:      token,
+		httpClient: httputil.DefaultClient(),
+		logger:     slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// doRequest performs a GET request against the Nomad API and decodes the JSON response.
+func (c *Client) doRequest(ctx context.Context, path, namespace string, out any) error {
+	reqURL := c.addr + path
+	if namespace != "" {
+		reqURL += "?" + url.Values{"namespace": []string{namespace}}.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("X-Nomad-Token", c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("parsing response JSON: %w", err)
+	}
+
+	return nil
+}
+
+// ListJobs returns all jobs in the given namespace ("*" for all namespaces).
+func (c *Client) ListJobs(ctx context.Context, namespace string) ([]jobStub, error) {
+	var jobs []jobStub
+	if err := c.doRequest(ctx, "/v1/jobs", namespace, &jobs); err != nil {
+		return nil, fmt.Errorf("listing jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// GetJob returns the specification of a single job.
+func (c *Client) GetJob(ctx context.Context, id, namespace string) (*job, error) {
+	var j job
+	if err := c.doRequest(ctx, "/v1/job/"+url.PathEscape(id), namespace, &j); err != nil {
+		return nil, fmt.Errorf("getting job %s: %w", id, err)
+	}
+	return &j, nil
+}
+
+// ListAllocations returns the allocations of a single job.
+func (c *Client) ListAllocations(ctx context.Context, id, namespace string) ([]allocation, error) {
+	var allocs []allocation
+	if err := c.doRequest(ctx, "/v1/job/"+url.PathEscape(id)+"/allocations", namespace, &allocs); err != nil {
+		return nil, fmt.Errorf("listing allocations for job %s: %w", id, err)
+	}
+	return allocs, nil
+}
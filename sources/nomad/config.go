@@ -0,0 +1,110 @@
+package nomad
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Configuration defaults.
+const (
+	// DefaultAddr is the default Nomad HTTP API address.
+	DefaultAddr = "http://127.0.0.1:4646"
+
+	// DefaultNamespace is the Nomad namespace queried when none is configured.
+	DefaultNamespace = "default"
+
+	// DefaultLabelPrefix is the task meta key prefix that marks hostnames.
+	DefaultLabelPrefix = "dnsweaver.hostname"
+
+	// DefaultPollInterval is the default interval between Nomad API polls.
+	DefaultPollInterval = 30 * time.Second
+)
+
+// Config holds Nomad source configuration.
+type Config struct {
+	Addr         string        // Nomad HTTP API address
+	Token        string        // ACL token (optional)
+	Namespace    string        // Namespace to query ("*" for all namespaces)
+	LabelPrefix  string        // Task meta key prefix for hostnames
+	PollInterval time.Duration // Minimum interval between API polls
+}
+
+// Validate checks that the configuration is usable.
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.Addr == "" {
+		errs = append(errs, "ADDR is required")
+	}
+	if c.LabelPrefix == "" {
+		errs = append(errs, "LABEL_PREFIX is required")
+	}
+	if c.PollInterval < 0 {
+		errs = append(errs, "POLL_INTERVAL must be non-negative")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("nomad config validation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// LoadConfig loads Nomad source configuration from environment variables.
+//
+// Supported settings:
+//   - DNSWEAVER_NOMAD_ADDR: Nomad API address (default: http://127.0.0.1:4646)
+//   - DNSWEAVER_NOMAD_TOKEN: ACL token (supports _FILE suffix for Docker secrets)
+//   - DNSWEAVER_NOMAD_NAMESPACE: Namespace filter (default: "default", "*" for all)
+//   - DNSWEAVER_NOMAD_LABEL_PREFIX: Task meta key prefix (default: dnsweaver.hostname)
+//   - DNSWEAVER_NOMAD_POLL_INTERVAL: Poll interval (default: 30s)
+func LoadConfig() (*Config, error) {
+	cfg := &Config{
+		Addr:         os.Getenv("DNSWEAVER_NOMAD_ADDR"),
+		Token:        getEnvOrFile("DNSWEAVER_NOMAD_TOKEN", "DNSWEAVER_NOMAD_TOKEN_FILE"),
+		Namespace:    os.Getenv("DNSWEAVER_NOMAD_NAMESPACE"),
+		LabelPrefix:  os.Getenv("DNSWEAVER_NOMAD_LABEL_PREFIX"),
+		PollInterval: DefaultPollInterval,
+	}
+
+	if cfg.Addr == "" {
+		cfg.Addr = DefaultAddr
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = DefaultNamespace
+	}
+	if cfg.LabelPrefix == "" {
+		cfg.LabelPrefix = DefaultLabelPrefix
+	}
+
+	if intervalStr := os.Getenv("DNSWEAVER_NOMAD_POLL_INTERVAL"); intervalStr != "" {
+		interval, err := time.ParseDuration(intervalStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid POLL_INTERVAL value %q: %w", intervalStr, err)
+		}
+		cfg.PollInterval = interval
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// getEnvOrFile retrieves a value from either a direct environment variable
+// or a file path specified by the file key (Docker secrets pattern).
+//
+// If both are set, the file takes precedence.
+func getEnvOrFile(directKey, fileKey string) string {
+	if filePath := os.Getenv(fileKey); filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+	}
+
+	return os.Getenv(directKey)
+}
@@ -0,0 +1,234 @@
+// Package nomad provides a Source implementation for discovering hostnames
+// from HashiCorp Nomad jobs.
+//
+// The source polls the Nomad HTTP API for jobs with running allocations and
+// extracts hostnames from task meta keys that start with the configured
+// label prefix. Meta is merged job → group → task, matching Nomad's own
+// runtime behaviour. Values may contain multiple comma-separated hostnames.
+//
+// Example job (HCL):
+//
+//	task "web" {
+//	  meta {
+//	    "dnsweaver.hostname" = "app.example.com"
+//	    "dnsweaver.hostname.www" = "www.example.com"
+//	  }
+//	}
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+const sourceName = "nomad"
+
+// Nomad implements the source.Source interface for Nomad jobs.
+type Nomad struct {
+	config *Config
+	client *Client
+	logger *slog.Logger
+
+	mu        sync.Mutex
+	lastPoll  time.Time
+	loaded    bool // At least one poll succeeded
+	hostnames []source.Hostname
+}
+
+// Option is a functional option for configuring Nomad.
+type Option func(*Nomad)
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(n *Nomad) {
+		if logger != nil {
+			n.logger = logger
+		}
+	}
+}
+
+// WithClient sets a custom Nomad API client (useful for testing).
+func WithClient(client *Client) Option {
+	return func(n *Nomad) {
+		n.client = client
+	}
+}
+
+// New creates a new Nomad source.
+func New(config *Config, opts ...Option) (*Nomad, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	n := &Nomad{
+		config: config,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	if n.client == nil {
+		n.client = NewClient(config.Addr, config.Token, WithClientLogger(n.logger))
+	}
+
+	return n, nil
+}
+
+// Name returns the source identifier.
+func (n *Nomad) Name() string {
+	return sourceName
+}
+
+// Extract is a no-op: Nomad hostnames are not carried on Docker labels.
+func (n *Nomad) Extract(_ context.Context, _ map[string]string) ([]source.Hostname, error) {
+	return nil, nil
+}
+
+// SupportsDiscovery always returns true; Nomad hostnames come from Discover.
+func (n *Nomad) SupportsDiscovery() bool {
+	return true
+}
+
+// APIPollInterval returns the minimum interval between Nomad API polls.
+func (n *Nomad) APIPollInterval() time.Duration {
+	return n.config.PollInterval
+}
+
+// Discover polls the Nomad API for hostnames of running tasks.
+//
+// The API is only queried when the cached result is older than the poll
+// interval, so frequent reconciliations do not overload the Nomad servers.
+// If a poll fails, the last successful result is returned so a short API
+// outage does not make its hostnames look removed; the error is only
+// returned if no poll has succeeded yet.
+func (n *Nomad) Discover(ctx context.Context) ([]source.Hostname, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !n.lastPoll.IsZero() && time.Since(n.lastPoll) < n.config.PollInterval {
+		return n.hostnames, nil
+	}
+
+	hostnames, err := n.discover(ctx)
+	if err != nil {
+		if !n.loaded {
+			return nil, err
+		}
+		n.logger.Warn("nomad API poll failed, keeping previous hostnames",
+			slog.Int("hostnames", len(n.hostnames)),
+			slog.String("error", err.Error()),
+		)
+		return n.hostnames, nil
+	}
+
+	n.hostnames = hostnames
+	n.lastPoll = time.Now()
+	n.loaded = true
+
+	return hostnames, nil
+}
+
+// discover queries all jobs and extracts hostnames from running task groups.
+func (n *Nomad) discover(ctx context.Context) ([]source.Hostname, error) {
+	jobs, err := n.client.ListJobs(ctx, n.config.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var hostnames []source.Hostname
+	seen := make(map[string]struct{})
+
+	for _, stub := range jobs {
+		if stub.Status == "dead" {
+			continue
+		}
+
+		allocs, err := n.client.ListAllocations(ctx, stub.ID, stub.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		runningGroups := make(map[string]struct{})
+		for _, a := range allocs {
+			if a.ClientStatus == "running" {
+				runningGroups[a.TaskGroup] = struct{}{}
+			}
+		}
+		if len(runningGroups) == 0 {
+			continue
+		}
+
+		j, err := n.client.GetJob(ctx, stub.ID, stub.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, group := range j.TaskGroups {
+			if _, ok := runningGroups[group.Name]; !ok {
+				continue
+			}
+			for _, t := range group.Tasks {
+				for _, name := range n.extractHostnames(j.Meta, group.Meta, t.Meta) {
+					key := strings.ToLower(name)
+					if _, exists := seen[key]; exists {
+						continue
+					}
+					seen[key] = struct{}{}
+					hostnames = append(hostnames, source.Hostname{
+						Name:   name,
+						Source: sourceName,
+					})
+				}
+			}
+		}
+	}
+
+	n.logger.Debug("discovered hostnames from nomad",
+		slog.String("namespace", n.config.Namespace),
+		slog.Int("jobs", len(jobs)),
+		slog.Int("count", len(hostnames)),
+	)
+
+	return hostnames, nil
+}
+
+// extractHostnames merges meta maps (later maps override earlier ones) and
+// returns the hostnames from keys matching the label prefix.
+func (n *Nomad) extractHostnames(metas ...map[string]string) []string {
+	merged := make(map[string]string)
+	for _, meta := range metas {
+		for k, v := range meta {
+			merged[k] = v
+		}
+	}
+
+	var names []string
+	for key, value := range merged {
+		if !strings.HasPrefix(key, n.config.LabelPrefix) {
+			continue
+		}
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Ensure Nomad implements source.Source
+var _ source.Source = (*Nomad)(nil)
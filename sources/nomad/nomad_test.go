@@ -0,0 +1,258 @@
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// newTestServer serves a fake Nomad API with two jobs: "web" (running) and
+// "batch" (no running allocations).
+func newTestServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls != nil && r.URL.Path == "/v1/jobs" {
+			calls.Add(1)
+		}
+		if got := r.Header.Get("X-Nomad-Token"); got != "test-token" {
+			t.Errorf("X-Nomad-Token = %q, want test-token", got)
+		}
+		if got := r.URL.Query().Get("namespace"); got != "apps" {
+			t.Errorf("namespace = %q, want apps", got)
+		}
+
+		var resp any
+		switch r.URL.Path {
+		case "/v1/jobs":
+			resp = []map[string]any{
+				{"ID": "web", "Namespace": "apps", "Status": "running"},
+				{"ID": "batch", "Namespace": "apps", "Status": "pending"},
+				{"ID": "old", "Namespace": "apps", "Status": "dead"},
+			}
+		case "/v1/job/web/allocations":
+			resp = []map[string]any{
+				{"ID": "a1", "TaskGroup": "frontend", "ClientStatus": "running"},
+				{"ID": "a2", "TaskGroup": "worker", "ClientStatus": "failed"},
+			}
+		case "/v1/job/batch/allocations":
+			resp = []map[string]any{
+				{"ID": "a3", "TaskGroup": "batch", "ClientStatus": "pending"},
+			}
+		case "/v1/job/web":
+			resp = map[string]any{
+				"ID":   "web",
+				"Meta": map[string]string{"dnsweaver.hostname.job": "job.example.com"},
+				"TaskGroups": []map[string]any{
+					{
+						"Name": "frontend",
+						"Tasks": []map[string]any{
+							{"Name": "nginx", "Meta": map[string]string{
+								"dnsweaver.hostname":     "app.example.com, www.example.com",
+								"dnsweaver.hostname.dup": "APP.example.com",
+								"other":                  "ignored.example.com",
+							}},
+						},
+					},
+					{
+						"Name": "worker",
+						"Tasks": []map[string]any{
+							{"Name": "worker", "Meta": map[string]string{"dnsweaver.hostname": "worker.example.com"}},
+						},
+					},
+				},
+			}
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func newTestSource(t *testing.T, serverURL string, interval time.Duration) *Nomad {
+	t.Helper()
+	src, err := New(&Config{
+		Addr:         serverURL,
+		Token:        "test-token",
+		Namespace:    "apps",
+		LabelPrefix:  DefaultLabelPrefix,
+		PollInterval: interval,
+	}, WithLogger(testLogger()))
+	if err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+	return src
+}
+
+func TestNomad_Discover(t *testing.T) {
+	server := newTestServer(t, nil)
+	defer server.Close()
+
+	src := newTestSource(t, server.URL, 0)
+
+	if src.Name() != "nomad" {
+		t.Errorf("Name() = %q, want nomad", src.Name())
+	}
+	if !src.SupportsDiscovery() {
+		t.Error("expected SupportsDiscovery to be true")
+	}
+
+	hostnames, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	want := map[string]bool{
+		"app.example.com": true,
+		"www.example.com": true,
+		"job.example.com": true,
+	}
+	if len(hostnames) != len(want) {
+		t.Fatalf("expected %d hostnames, got %d: %v", len(want), len(hostnames), hostnames)
+	}
+	for _, h := range hostnames {
+		if !want[strings.ToLower(h.Name)] {
+			t.Errorf("unexpected hostname %q", h.Name)
+		}
+		if h.Source != "nomad" {
+			t.Errorf("Source = %q, want nomad", h.Source)
+		}
+	}
+}
+
+func TestNomad_Discover_CachesWithinPollInterval(t *testing.T) {
+	var calls atomic.Int32
+	server := newTestServer(t, &calls)
+	defer server.Close()
+
+	src := newTestSource(t, server.URL, time.Hour)
+	for i := 0; i < 3; i++ {
+		if _, err := src.Discover(context.Background()); err != nil {
+			t.Fatalf("Discover failed: %v", err)
+		}
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 job listing, got %d", calls.Load())
+	}
+}
+
+func TestNomad_Discover_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("Permission denied"))
+	}))
+	defer server.Close()
+
+	src := newTestSource(t, server.URL, 0)
+	if _, err := src.Discover(context.Background()); err == nil {
+		t.Fatal("expected error for forbidden response")
+	}
+}
+
+func TestNomad_Discover_KeepsLastResultOnError(t *testing.T) {
+	server := newTestServer(t, nil)
+	src := newTestSource(t, server.URL, time.Nanosecond)
+
+	want, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	server.Close()
+	got, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("expected the previous result instead of an error, got %v", err)
+	}
+	if len(got) == 0 || len(got) != len(want) {
+		t.Errorf("Discover returned %d hostnames, want %d", len(got), len(want))
+	}
+}
+
+func TestNomad_Extract(t *testing.T) {
+	src := newTestSource(t, DefaultAddr, 0)
+
+	hostnames, err := src.Extract(context.Background(), map[string]string{"dnsweaver.hostname": "app.example.com"})
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(hostnames) != 0 {
+		t.Errorf("expected no hostnames from labels, got %v", hostnames)
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Error("expected error for nil config")
+	}
+	if _, err := New(&Config{}); err == nil {
+		t.Error("expected error for empty config")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("DNSWEAVER_NOMAD_ADDR", "")
+	t.Setenv("DNSWEAVER_NOMAD_TOKEN", "")
+	t.Setenv("DNSWEAVER_NOMAD_NAMESPACE", "")
+	t.Setenv("DNSWEAVER_NOMAD_LABEL_PREFIX", "")
+	t.Setenv("DNSWEAVER_NOMAD_POLL_INTERVAL", "")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Addr != DefaultAddr {
+		t.Errorf("Addr = %q, want %q", cfg.Addr, DefaultAddr)
+	}
+	if cfg.Namespace != DefaultNamespace {
+		t.Errorf("Namespace = %q, want %q", cfg.Namespace, DefaultNamespace)
+	}
+	if cfg.LabelPrefix != DefaultLabelPrefix {
+		t.Errorf("LabelPrefix = %q, want %q", cfg.LabelPrefix, DefaultLabelPrefix)
+	}
+	if cfg.PollInterval != DefaultPollInterval {
+		t.Errorf("PollInterval = %v, want %v", cfg.PollInterval, DefaultPollInterval)
+	}
+
+	tokenFile := t.TempDir() + "/token"
+	if err := os.WriteFile(tokenFile, []byte("secret-token\n"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	t.Setenv("DNSWEAVER_NOMAD_ADDR", "https://nomad.example.com:4646")
+	t.Setenv("DNSWEAVER_NOMAD_TOKEN_FILE", tokenFile)
+	t.Setenv("DNSWEAVER_NOMAD_NAMESPACE", "*")
+	t.Setenv("DNSWEAVER_NOMAD_POLL_INTERVAL", "1m")
+
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Token != "secret-token" {
+		t.Errorf("Token = %q, want secret-token", cfg.Token)
+	}
+	if cfg.Namespace != "*" {
+		t.Errorf("Namespace = %q, want *", cfg.Namespace)
+	}
+	if cfg.PollInterval != time.Minute {
+		t.Errorf("PollInterval = %v, want 1m", cfg.PollInterval)
+	}
+
+	t.Setenv("DNSWEAVER_NOMAD_POLL_INTERVAL", "often")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected error for invalid poll interval")
+	}
+}
@@ -0,0 +1,36 @@
+package static
+
+import (
+	"fmt"
+	"os"
+)
+
+// Config holds static source configuration.
+type Config struct {
+	// File is the path to the YAML file listing static hostnames.
+	File string
+}
+
+// Validate checks that the configuration is usable.
+func (c *Config) Validate() error {
+	if c.File == "" {
+		return fmt.Errorf("static config validation failed: FILE is required")
+	}
+	return nil
+}
+
+// LoadConfig loads static source configuration from environment variables.
+//
+// Supported settings:
+//   - DNSWEAVER_STATIC_FILE: Path to the YAML hostname list (required)
+func LoadConfig() (*Config, error) {
+	cfg := &Config{
+		File: os.Getenv("DNSWEAVER_STATIC_FILE"),
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,193 @@
+// Package static provides a Source implementation for hostnames listed in a
+// YAML file.
+//
+// Static hostnames are kept in DNS independently of any running container,
+// which is useful for infrastructure that dnsweaver cannot discover (NAS
+// devices, printers, VMs). Each entry may override the record type, target,
+// TTL, and provider.
+//
+// Example file:
+//
+//	hostnames:
+//	  - name: nas.example.com
+//	    type: A
+//	    target: 10.0.0.1
+//	    ttl: 300
+//	  - name: docs.example.com
+//	    type: CNAME
+//	    target: nas.example.com
+package static
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+const sourceName = "static"
+
+// File is the on-disk format of the static hostname list.
+type File struct {
+	Hostnames []Entry `yaml:"hostnames"`
+}
+
+// Entry is a single static hostname.
+type Entry struct {
+	Name     string   `yaml:"name"`
+	Type     string   `yaml:"type,omitempty"`
+	Target   string   `yaml:"target,omitempty"`
+	Targets  []string `yaml:"targets,omitempty"`
+	TTL      int      `yaml:"ttl,omitempty"`
+	Provider string   `yaml:"provider,omitempty"`
+}
+
+// Static implements the source.Source interface for a static hostname file.
+type Static struct {
+	config *Config
+	logger *slog.Logger
+}
+
+// Option is a functional option for configuring Static.
+type Option func(*Static)
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Static) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
+// New creates a new static source.
+func New(config *Config, opts ...Option) (*Static, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := &Static{
+		config: config,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+// Name returns the source identifier.
+func (s *Static) Name() string {
+	return sourceName
+}
+
+// Extract is a no-op: static hostnames are not carried on Docker labels.
+func (s *Static) Extract(_ context.Context, _ map[string]string) ([]source.Hostname, error) {
+	return nil, nil
+}
+
+// SupportsDiscovery always returns true; static hostnames come from Discover.
+func (s *Static) SupportsDiscovery() bool {
+	return true
+}
+
+// Discover reads the static hostname file.
+//
+// A missing file is logged and treated as empty so that the file can be
+// created after startup. Invalid entries are skipped with a warning; a file
+// that cannot be parsed is returned as an error.
+func (s *Static) Discover(_ context.Context) ([]source.Hostname, error) {
+	data, err := os.ReadFile(s.config.File)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			s.logger.Warn("static hostname file not found",
+				slog.String("path", s.config.File),
+			)
+			return []source.Hostname{}, nil
+		}
+		return nil, fmt.Errorf("reading static hostname file: %w", err)
+	}
+
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing static hostname file %s: %w", s.config.File, err)
+	}
+
+	hostnames := make(source.Hostnames, 0, len(file.Hostnames))
+	for i, entry := range file.Hostnames {
+		h, err := entry.hostname()
+		if err != nil {
+			s.logger.Warn("skipping invalid static hostname",
+				slog.String("path", s.config.File),
+				slog.Int("index", i),
+				slog.String("name", entry.Name),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		hostnames = append(hostnames, h)
+	}
+
+	hostnames = hostnames.Deduplicate()
+
+	s.logger.Debug("discovered static hostnames",
+		slog.String("path", s.config.File),
+		slog.Int("count", len(hostnames)),
+	)
+
+	return hostnames, nil
+}
+
+// hostname converts an entry to a source.Hostname with record hints.
+func (e Entry) hostname() (source.Hostname, error) {
+	h := source.Hostname{
+		Name:   strings.TrimSpace(e.Name),
+		Source: sourceName,
+	}
+	if h.Name == "" {
+		return h, fmt.Errorf("name is required")
+	}
+
+	if e.TTL != 0 && (e.TTL < 1 || e.TTL > source.MaxTTL) {
+		return h, fmt.Errorf("%w: %d", source.ErrInvalidTTL, e.TTL)
+	}
+
+	hints := &source.RecordHints{
+		Type:     strings.ToUpper(strings.TrimSpace(e.Type)),
+		Target:   strings.TrimSpace(e.Target),
+		TTL:      e.TTL,
+		Provider: strings.TrimSpace(e.Provider),
+	}
+	for _, target := range e.Targets {
+		if target = strings.TrimSpace(target); target != "" {
+			hints.Targets = append(hints.Targets, target)
+		}
+	}
+	if len(hints.Targets) > 0 {
+		if hints.Target != "" {
+			return h, fmt.Errorf("cannot set both target and targets")
+		}
+		hints.Target = hints.Targets[0]
+	}
+	if hints.Type != "" || hints.Target != "" || hints.TTL != 0 || hints.Provider != "" {
+		h.RecordHints = hints
+	}
+
+	if err := h.Validate(); err != nil {
+		return h, err
+	}
+
+	return h, nil
+}
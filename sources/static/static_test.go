@@ -0,0 +1,131 @@
+package static
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hostnames.yml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing file: %v", err)
+	}
+	return path
+}
+
+func TestNew_RequiresFile(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Error("expected error for nil config")
+	}
+	if _, err := New(&Config{}); err == nil {
+		t.Error("expected error for empty file path")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("DNSWEAVER_STATIC_FILE", "")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected error when DNSWEAVER_STATIC_FILE is unset")
+	}
+
+	t.Setenv("DNSWEAVER_STATIC_FILE", "/config/hostnames.yml")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.File != "/config/hostnames.yml" {
+		t.Errorf("File = %q, want /config/hostnames.yml", cfg.File)
+	}
+}
+
+func TestStatic_Discover(t *testing.T) {
+	path := writeFile(t, `
+hostnames:
+  - name: nas.example.com
+    type: a
+    target: 10.0.0.1
+    ttl: 300
+  - name: docs.example.com
+    type: CNAME
+    target: nas.example.com
+    provider: internal
+  - name: plain.example.com
+  - name: NAS.example.com
+    target: 10.0.0.2
+  - name: bad_host!
+  - name: ""
+  - name: ttl.example.com
+    ttl: 100000
+`)
+
+	src, err := New(&Config{File: path}, WithLogger(testLogger()))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !src.SupportsDiscovery() {
+		t.Error("SupportsDiscovery() = false, want true")
+	}
+
+	hostnames, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if len(hostnames) != 3 {
+		t.Fatalf("expected 3 hostnames, got %d: %v", len(hostnames), hostnames)
+	}
+
+	nas := hostnames[0]
+	if nas.Name != "nas.example.com" || nas.Source != "static" {
+		t.Errorf("unexpected first hostname: %+v", nas)
+	}
+	if nas.RecordHints == nil || nas.RecordHints.Type != "A" || nas.RecordHints.Target != "10.0.0.1" || nas.RecordHints.TTL != 300 {
+		t.Errorf("unexpected hints for nas: %+v", nas.RecordHints)
+	}
+
+	docs := hostnames[1]
+	if docs.RecordHints == nil || docs.RecordHints.Type != "CNAME" || docs.RecordHints.Provider != "internal" {
+		t.Errorf("unexpected hints for docs: %+v", docs.RecordHints)
+	}
+
+	if plain := hostnames[2]; plain.RecordHints != nil {
+		t.Errorf("expected no hints for plain hostname, got %+v", plain.RecordHints)
+	}
+}
+
+func TestStatic_Discover_MissingFile(t *testing.T) {
+	src, _ := New(&Config{File: filepath.Join(t.TempDir(), "missing.yml")}, WithLogger(testLogger()))
+
+	hostnames, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if len(hostnames) != 0 {
+		t.Errorf("expected no hostnames, got %d", len(hostnames))
+	}
+}
+
+func TestStatic_Discover_InvalidYAML(t *testing.T) {
+	path := writeFile(t, "hostnames: [unterminated")
+	src, _ := New(&Config{File: path}, WithLogger(testLogger()))
+
+	if _, err := src.Discover(context.Background()); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
+
+func TestStatic_Extract(t *testing.T) {
+	src, _ := New(&Config{File: "hostnames.yml"})
+
+	hostnames, err := src.Extract(context.Background(), map[string]string{"dnsweaver.hostname": "app.example.com"})
+	if err != nil || hostnames != nil {
+		t.Errorf("Extract() = %v, %v; want nil, nil", hostnames, err)
+	}
+}
@@ -0,0 +1,169 @@
+package traefik
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/httputil"
+)
+
+// DefaultAPIPollInterval is the default interval between Traefik API polls.
+const DefaultAPIPollInterval = 30 * time.Second
+
+// routersPath is the Traefik API endpoint listing all HTTP routers.
+const routersPath = "/api/http/routers"
+
+// apiRouter is the subset of a Traefik API router object used for discovery.
+type apiRouter struct {
+	Name       string `json:"name"`
+	Rule       string `json:"rule"`
+	RuleSyntax string `json:"ruleSyntax"`
+	Status     string `json:"status"`
+	Provider   string `json:"provider"`
+}
+
+// apiPoller fetches router rules from the Traefik API and caches the
+// extracted hostnames for the configured poll interval.
+type apiPoller struct {
+	endpoint     string
+	pollInterval time.Duration
+	httpClient   *http.Client
+	logger       *slog.Logger
+	version      int // Forced rule syntax version (VersionAuto honors ruleSyntax)
+
+	mu        sync.Mutex
+	lastPoll  time.Time
+	loaded    bool // At least one poll succeeded
+	hostnames []HostnameExtraction
+}
+
+// newAPIPoller creates a poller for the given Traefik API base URL.
+func newAPIPoller(endpoint string, pollInterval time.Duration, logger *slog.Logger) *apiPoller {
+	if pollInterval <= 0 {
+		pollInterval = DefaultAPIPollInterval
+	}
+
+	return &apiPoller{
+		endpoint:     strings.TrimSuffix(endpoint, "/"),
+		pollInterval: pollInterval,
+		httpClient:   httputil.DefaultClient(),
+		logger:       logger,
+	}
+}
+
+// Hostnames returns hostnames from the Traefik API.
+//
+// The API is only queried when the cached result is older than the poll
+// interval. If a poll fails, the last successful result is returned so a
+// short API outage does not make its routers look removed; the API is
+// queried again on the next call. The error is only returned if no poll has
+// succeeded yet.
+func (a *apiPoller) Hostnames(ctx context.Context) ([]HostnameExtraction, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.lastPoll.IsZero() && time.Since(a.lastPoll) < a.pollInterval {
+		return a.hostnames, nil
+	}
+
+	routers, err := a.fetchRouters(ctx)
+	if err != nil {
+		if !a.loaded {
+			return nil, err
+		}
+		a.logger.Warn("traefik API poll failed, keeping previous routers",
+			slog.String("endpoint", a.endpoint),
+			slog.Int("hostnames", len(a.hostnames)),
+			slog.String("error", err.Error()),
+		)
+		return a.hostnames, nil
+	}
+
+	var extractions []HostnameExtraction
+	for _, r := range routers {
+		if strings.EqualFold(r.Status, "disabled") || r.Rule == "" {
+			continue
+		}
+		hosts, skipped := parseRule(r.Rule, ruleVersion(a.version, r.RuleSyntax))
+		for _, pattern := range skipped {
+			a.logger.Debug("skipping HostRegexp matcher, no static hostname",
+				slog.String("router", r.Name),
+				slog.String("pattern", pattern),
+			)
+		}
+		for _, host := range hosts {
+			extractions = append(extractions, HostnameExtraction{
+				Hostname: host,
+				Router:   r.Name,
+			})
+		}
+	}
+
+	a.hostnames = extractions
+	a.lastPoll = time.Now()
+	a.loaded = true
+
+	a.logger.Debug("polled traefik API",
+		slog.String("endpoint", a.endpoint),
+		slog.Int("routers", len(routers)),
+		slog.Int("hostnames", len(extractions)),
+	)
+
+	return extractions, nil
+}
+
+// fetchRouters retrieves all HTTP routers, following Traefik's X-Next-Page
+// pagination header.
+func (a *apiPoller) fetchRouters(ctx context.Context) ([]apiRouter, error) {
+	var all []apiRouter
+
+	for page := 1; ; {
+		params := url.Values{}
+		params.Set("page", strconv.Itoa(page))
+
+		reqURL := a.endpoint + routersPath + "?" + params.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating traefik API request: %w", err)
+		}
+
+		resp, err := a.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("querying traefik API: %w", err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading traefik API response: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("traefik API returned status %d: %s", resp.StatusCode, string(body))
+		}
+
+		var routers []apiRouter
+		if err := json.Unmarshal(body, &routers); err != nil {
+			return nil, fmt.Errorf("parsing traefik API response: %w", err)
+		}
+		all = append(all, routers...)
+
+		// Traefik reports the next page number, or 1 when on the last page
+		next, err := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+		if err != nil || next <= page || len(routers) == 0 {
+			break
+		}
+		page = next
+	}
+
+	return all, nil
+}
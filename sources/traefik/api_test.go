@@ -0,0 +1,225 @@
+package traefik
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+func TestAPIPoller_Hostnames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/http/routers" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"name": "app@file", "rule": "Host(` + "`app.example.com`" + `)", "status": "enabled", "provider": "file"},
+			{"name": "multi@consulcatalog", "rule": "Host(` + "`a.example.com`" + `) || Host(` + "`b.example.com`" + `)", "status": "enabled"},
+			{"name": "off@file", "rule": "Host(` + "`off.example.com`" + `)", "status": "disabled"},
+			{"name": "path@file", "rule": "PathPrefix(` + "`/api`" + `)", "status": "enabled"}
+		]`))
+	}))
+	defer server.Close()
+
+	poller := newAPIPoller(server.URL+"/", 0, testLogger())
+	if poller.pollInterval != DefaultAPIPollInterval {
+		t.Errorf("pollInterval = %v, want %v", poller.pollInterval, DefaultAPIPollInterval)
+	}
+
+	got, err := poller.Hostnames(context.Background())
+	if err != nil {
+		t.Fatalf("Hostnames failed: %v", err)
+	}
+
+	want := map[string]string{
+		"app.example.com": "app@file",
+		"a.example.com":   "multi@consulcatalog",
+		"b.example.com":   "multi@consulcatalog",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d hostnames, got %d: %v", len(want), len(got), got)
+	}
+	for _, e := range got {
+		if want[e.Hostname] != e.Router {
+			t.Errorf("hostname %q router = %q, want %q", e.Hostname, e.Router, want[e.Hostname])
+		}
+	}
+}
+
+func TestAPIPoller_CachesWithinPollInterval(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`[{"name": "app@file", "rule": "Host(` + "`app.example.com`" + `)"}]`))
+	}))
+	defer server.Close()
+
+	poller := newAPIPoller(server.URL, time.Hour, testLogger())
+	for i := 0; i < 3; i++ {
+		if _, err := poller.Hostnames(context.Background()); err != nil {
+			t.Fatalf("Hostnames failed: %v", err)
+		}
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 API call, got %d", calls.Load())
+	}
+}
+
+func TestAPIPoller_Pagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = w.Write([]byte(`[{"name": "one@file", "rule": "Host(` + "`one.example.com`" + `)"}]`))
+		case "2":
+			w.Header().Set("X-Next-Page", "1")
+			_, _ = w.Write([]byte(`[{"name": "two@file", "rule": "Host(` + "`two.example.com`" + `)"}]`))
+		default:
+			t.Errorf("unexpected page: %s", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	got, err := newAPIPoller(server.URL, 0, testLogger()).Hostnames(context.Background())
+	if err != nil {
+		t.Fatalf("Hostnames failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 hostnames, got %d", len(got))
+	}
+}
+
+func TestAPIPoller_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := newAPIPoller(server.URL, 0, testLogger()).Hostnames(context.Background())
+	if err == nil {
+		t.Fatal("expected error for non-200 response")
+	}
+}
+
+func TestAPIPoller_KeepsLastResultOnError(t *testing.T) {
+	var down atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"name": "app@file", "rule": "Host(` + "`app.example.com`" + `)"}]`))
+	}))
+	defer server.Close()
+
+	poller := newAPIPoller(server.URL, time.Nanosecond, testLogger())
+	if _, err := poller.Hostnames(context.Background()); err != nil {
+		t.Fatalf("Hostnames failed: %v", err)
+	}
+
+	down.Store(true)
+	got, err := poller.Hostnames(context.Background())
+	if err != nil {
+		t.Fatalf("expected the previous result instead of an error, got %v", err)
+	}
+	if len(got) != 1 || got[0].Hostname != "app.example.com" {
+		t.Errorf("unexpected hostnames: %v", got)
+	}
+}
+
+func TestTraefik_Discover_APIErrorKeepsFiles(t *testing.T) {
+	dir := t.TempDir()
+	yamlContent := "http:\n  routers:\n    app:\n      rule: \"Host(`app.example.com`)\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "routers.yml"), []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	cfg := source.DefaultFileDiscoveryConfig()
+	cfg.FilePaths = []string{dir}
+	src := New(
+		WithLogger(testLogger()),
+		WithFileDiscovery(cfg),
+		WithAPIEndpoint(server.URL, 0),
+	)
+
+	hostnames, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(hostnames) != 1 || hostnames[0].Name != "app.example.com" {
+		t.Errorf("expected the file hostname, got %v", hostnames)
+	}
+}
+
+func TestTraefik_Discover_MergesFilesAndAPI(t *testing.T) {
+	dir := t.TempDir()
+	yamlContent := "http:\n  routers:\n    app:\n      rule: \"Host(`App.Example.com`)\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "routers.yml"), []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"name": "app@file", "rule": "Host(` + "`app.example.com`" + `)"},
+			{"name": "api@consulcatalog", "rule": "Host(` + "`api.example.com`" + `)"}
+		]`))
+	}))
+	defer server.Close()
+
+	cfg := source.DefaultFileDiscoveryConfig()
+	cfg.FilePaths = []string{dir}
+	src := New(
+		WithLogger(testLogger()),
+		WithFileDiscovery(cfg),
+		WithAPIEndpoint(server.URL, time.Minute),
+	)
+
+	if !src.SupportsDiscovery() {
+		t.Fatal("expected SupportsDiscovery to be true")
+	}
+	if src.APIPollInterval() != time.Minute {
+		t.Errorf("APIPollInterval() = %v, want 1m", src.APIPollInterval())
+	}
+
+	hostnames, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	if len(hostnames) != 2 {
+		t.Fatalf("expected 2 deduplicated hostnames, got %d: %v", len(hostnames), hostnames)
+	}
+}
+
+func TestTraefik_Discover_APIOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name": "app@docker", "rule": "Host(` + "`app.example.com`" + `)"}]`))
+	}))
+	defer server.Close()
+
+	src := New(WithLogger(testLogger()), WithAPIEndpoint(server.URL, 0))
+
+	hostnames, err := src.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(hostnames) != 1 || hostnames[0].Name != "app.example.com" {
+		t.Errorf("unexpected hostnames: %v", hostnames)
+	}
+	if hostnames[0].Source != "traefik" {
+		t.Errorf("Source = %q, want traefik", hostnames[0].Source)
+	}
+}
@@ -199,7 +199,7 @@ func (p *Parser) extractFromConfig(config *traefikFileConfig, path string) ([]Ho
 			continue
 		}
 
-		hosts := extractHostsFromRule(router.Rule)
+		hosts := p.hostsFromRule(routerName, router.Rule, router.RuleSyntax)
 		for _, hostname := range hosts {
 			extractions = append(extractions, HostnameExtraction{
 				Hostname: hostname,
@@ -229,6 +229,7 @@ type traefikHTTPConfig struct {
 }
 
 type traefikRouter struct {
-	Rule string `yaml:"rule" toml:"rule"`
+	Rule       string `yaml:"rule" toml:"rule"`
+	RuleSyntax string `yaml:"ruleSyntax" toml:"ruleSyntax"`
 	// EntryPoints, Service, Middlewares, etc. are intentionally ignored
 }
@@ -4,11 +4,33 @@ import (
 	"log/slog"
 	"regexp"
 	"strings"
+
+	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
+)
+
+// Rule syntax versions understood by the parser.
+const (
+	// VersionAuto accepts both v2 and v3 rule syntax, honoring a router's
+	// ruleSyntax setting when present.
+	VersionAuto = 0
+	// Version2 parses Traefik v2 rules: Host() and HostHeader() take one or
+	// more backtick-quoted hostnames.
+	Version2 = 2
+	// Version3 parses Traefik v3 rules: Host() takes a single hostname quoted
+	// with backticks or double quotes.
+	Version3 = 3
 )
 
-// hostRegex matches Host(`hostname`) patterns in Traefik router rules.
-// Captures the hostname inside the backticks.
-var hostRegex = regexp.MustCompile(`Host\(` + "`" + `([^` + "`" + `]+)` + "`" + `\)`)
+// hostMatcherRegex matches Host() and HostHeader() matchers in Traefik router
+// rules and captures their argument list. HostRegexp() is not matched.
+var hostMatcherRegex = regexp.MustCompile(`\b(Host|HostHeader)\(([^)]*)\)`)
+
+// hostRegexpRegex matches HostRegexp() matchers, which cannot be resolved to
+// a static hostname.
+var hostRegexpRegex = regexp.MustCompile(`\bHostRegexp\(([^)]*)\)`)
+
+// ruleStringRegex matches a backtick- or double-quoted string in a rule.
+var ruleStringRegex = regexp.MustCompile("`([^`]*)`|\"([^\"]*)\"")
 
 // routerLabelPrefix is the prefix for Traefik HTTP router labels.
 const routerLabelPrefix = "traefik.http.routers."
@@ -16,15 +38,39 @@ const routerLabelPrefix = "traefik.http.routers."
 // routerRuleSuffix is the suffix for router rule labels.
 const routerRuleSuffix = ".rule"
 
+// routerRuleSyntaxSuffix is the suffix for the Traefik v3 per-router rule
+// syntax label. Example: traefik.http.routers.myapp.ruleSyntax=v2
+const routerRuleSyntaxSuffix = ".ruleSyntax"
+
+// routerEntrypointsSuffix is the suffix for the comma-separated list of
+// entrypoints a router is bound to.
+// Example: traefik.http.routers.myapp.entrypoints=websecure
+const routerEntrypointsSuffix = ".entrypoints"
+
+// routerTTLSuffix is the suffix for per-router TTL override labels.
+// Example: traefik.http.routers.myapp.dnsweaver-ttl=60
+const routerTTLSuffix = ".dnsweaver-ttl"
+
+// ttlLabel sets the TTL for all hostnames of a workload.
+// Per-router dnsweaver-ttl labels take precedence.
+const ttlLabel = "dnsweaver.ttl"
+
 // HostnameExtraction represents a hostname extracted from a specific router.
 type HostnameExtraction struct {
 	Hostname string // The extracted hostname
 	Router   string // The router name (e.g., "myapp")
+	TTL      int    // TTL override from labels (0 = provider default)
+
+	// ProviderHints holds provider-specific settings such as
+	// cloudflare.proxied. Router-level labels win over workload-level ones.
+	ProviderHints map[string]string
 }
 
 // Parser extracts hostnames from Traefik labels.
 type Parser struct {
-	logger *slog.Logger
+	logger      *slog.Logger
+	version     int
+	entrypoints map[string]struct{}
 }
 
 // ParserOption is a functional option for configuring the Parser.
@@ -37,6 +83,32 @@ func WithParserLogger(logger *slog.Logger) ParserOption {
 	}
 }
 
+// WithParserVersion forces Traefik v2 or v3 rule syntax.
+// VersionAuto (the default) accepts both.
+func WithParserVersion(version int) ParserOption {
+	return func(p *Parser) {
+		p.version = version
+	}
+}
+
+// WithParserEntrypoints only extracts hostnames from routers bound to at
+// least one of the given entrypoints. Routers without an entrypoints label
+// listen on every default entrypoint and are always kept. Empty disables
+// filtering.
+func WithParserEntrypoints(entrypoints []string) ParserOption {
+	return func(p *Parser) {
+		p.entrypoints = nil
+		for _, ep := range entrypoints {
+			if ep = strings.TrimSpace(ep); ep != "" {
+				if p.entrypoints == nil {
+					p.entrypoints = make(map[string]struct{})
+				}
+				p.entrypoints[ep] = struct{}{}
+			}
+		}
+	}
+}
+
 // NewParser creates a new Traefik label parser.
 func NewParser(opts ...ParserOption) *Parser {
 	p := &Parser{
@@ -63,20 +135,30 @@ func (p *Parser) ExtractHostnames(labels map[string]string) []HostnameExtraction
 			continue
 		}
 
+		if !p.routerOnEntrypoints(labels, router) {
+			p.logger.Debug("skipping router not bound to configured entrypoints",
+				slog.String("router", router),
+				slog.String("entrypoints", labels[routerLabelPrefix+router+routerEntrypointsSuffix]),
+			)
+			continue
+		}
+
 		p.logger.Debug("parsing traefik rule",
 			slog.String("router", router),
 			slog.String("rule", value),
 		)
 
 		// Extract all Host() patterns from the rule
-		hosts := extractHostsFromRule(value)
+		hosts := p.hostsFromRule(router, value, labels[routerLabelPrefix+router+routerRuleSyntaxSuffix])
 		for _, hostname := range hosts {
 			// Deduplicate by hostname (first occurrence wins)
 			if _, exists := seen[hostname]; !exists {
 				seen[hostname] = struct{}{}
 				extractions = append(extractions, HostnameExtraction{
-					Hostname: hostname,
-					Router:   router,
+					Hostname:      hostname,
+					Router:        router,
+					TTL:           p.ttlForRouter(labels, router),
+					ProviderHints: providerHintsForRouter(labels, router),
 				})
 				p.logger.Debug("extracted hostname",
 					slog.String("hostname", hostname),
@@ -93,6 +175,79 @@ func (p *Parser) ExtractHostnames(labels map[string]string) []HostnameExtraction
 	return extractions
 }
 
+// routerOnEntrypoints reports whether a router passes the entrypoint filter.
+// Routers without an entrypoints label pass, since Traefik binds them to all
+// default entrypoints.
+func (p *Parser) routerOnEntrypoints(labels map[string]string, router string) bool {
+	if len(p.entrypoints) == 0 {
+		return true
+	}
+	value, ok := labels[routerLabelPrefix+router+routerEntrypointsSuffix]
+	if !ok || strings.TrimSpace(value) == "" {
+		return true
+	}
+	for _, ep := range strings.Split(value, ",") {
+		if _, ok := p.entrypoints[strings.TrimSpace(ep)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hostsFromRule extracts the hostnames of a router rule using the forced
+// version or, in auto mode, the router's ruleSyntax. HostRegexp() matchers
+// are skipped with a debug log.
+func (p *Parser) hostsFromRule(router, rule, ruleSyntax string) []string {
+	hosts, skipped := parseRule(rule, ruleVersion(p.version, ruleSyntax))
+	for _, pattern := range skipped {
+		p.logger.Debug("skipping HostRegexp matcher, no static hostname",
+			slog.String("router", router),
+			slog.String("pattern", pattern),
+		)
+	}
+	return hosts
+}
+
+// ttlForRouter returns the TTL override for a router.
+// A router-level dnsweaver-ttl label wins over the workload-level dnsweaver.ttl label.
+// Invalid values are logged and ignored.
+func (p *Parser) ttlForRouter(labels map[string]string, router string) int {
+	key := routerLabelPrefix + router + routerTTLSuffix
+	value, ok := labels[key]
+	if !ok {
+		key = ttlLabel
+		value, ok = labels[key]
+	}
+	if !ok || strings.TrimSpace(value) == "" {
+		return 0
+	}
+
+	ttl, err := source.ParseTTL(value)
+	if err != nil {
+		p.logger.Warn("invalid TTL label, using provider default",
+			slog.String("router", router),
+			slog.String("label", key),
+			slog.String("error", err.Error()),
+		)
+		return 0
+	}
+	return ttl
+}
+
+// providerHintsForRouter returns the provider-specific hints for a router.
+// Router-level labels (traefik.http.routers.<router>.dnsweaver.cloudflare.proxied)
+// win over workload-level ones (dnsweaver.cloudflare.proxied).
+func providerHintsForRouter(labels map[string]string, router string) map[string]string {
+	hints := source.ProviderHintsFromLabels(labels, "")
+	for k, v := range source.ProviderHintsFromLabels(labels, routerLabelPrefix+router+".") {
+		if hints == nil {
+			hints = make(map[string]string)
+		}
+		hints[k] = v
+	}
+	return hints
+}
+
 // ExtractHosts extracts all hostnames from Traefik labels.
 // Returns a deduplicated slice of hostname strings.
 // This is a convenience method that discards router information.
@@ -134,34 +289,77 @@ func extractRouterName(key string) string {
 	return withoutSuffix
 }
 
-// extractHostsFromRule extracts all hostnames from a Traefik rule string.
+// extractHostsFromRule extracts all hostnames from a Traefik rule string,
+// accepting both v2 and v3 syntax.
 // Handles various rule formats:
 //   - Host(`example.com`)
 //   - Host(`a.com`) || Host(`b.com`)
 //   - Host(`example.com`) && PathPrefix(`/api`)
 //   - (Host(`a.com`) || Host(`b.com`)) && PathPrefix(`/`)
+//   - Host(`a.com`, `b.com`) (v2)
+//   - Host("example.com") (v3)
 func extractHostsFromRule(rule string) []string {
+	hosts, _ := parseRule(rule, VersionAuto)
+	return hosts
+}
+
+// parseRule extracts the hostnames from a rule's Host() matchers using the
+// given syntax version. It also returns the patterns of HostRegexp()
+// matchers, which are not resolvable to hostnames.
+func parseRule(rule string, version int) (hosts []string, skipped []string) {
 	seen := make(map[string]struct{})
-	var hosts []string
 
-	matches := hostRegex.FindAllStringSubmatch(rule, -1)
-	for _, match := range matches {
-		if len(match) < 2 {
-			continue
-		}
-		hostname := strings.TrimSpace(match[1])
-		if hostname == "" {
+	for _, match := range hostMatcherRegex.FindAllStringSubmatch(rule, -1) {
+		matcher, args := match[1], match[2]
+		// HostHeader() was removed in v3
+		if matcher == "HostHeader" && version == Version3 {
 			continue
 		}
 
-		// Deduplicate within the same rule
-		if _, exists := seen[hostname]; !exists {
-			seen[hostname] = struct{}{}
-			hosts = append(hosts, hostname)
+		for i, arg := range ruleStringRegex.FindAllStringSubmatch(args, -1) {
+			backtick := strings.HasPrefix(arg[0], "`")
+			// v2 only accepts backticks; v3 takes a single hostname
+			if version == Version2 && !backtick {
+				continue
+			}
+			if version == Version3 && i > 0 {
+				break
+			}
+
+			hostname := strings.TrimSpace(arg[1] + arg[2])
+			if hostname == "" {
+				continue
+			}
+
+			// Deduplicate within the same rule
+			if _, exists := seen[hostname]; !exists {
+				seen[hostname] = struct{}{}
+				hosts = append(hosts, hostname)
+			}
 		}
 	}
 
-	return hosts
+	for _, match := range hostRegexpRegex.FindAllStringSubmatch(rule, -1) {
+		skipped = append(skipped, strings.TrimSpace(match[1]))
+	}
+
+	return hosts, skipped
+}
+
+// ruleVersion returns the syntax version for a router: the forced version
+// if set, otherwise the router's ruleSyntax ("v2" or "v3"), otherwise auto.
+func ruleVersion(forced int, ruleSyntax string) int {
+	if forced != VersionAuto {
+		return forced
+	}
+	switch strings.ToLower(strings.TrimSpace(ruleSyntax)) {
+	case "v2":
+		return Version2
+	case "v3":
+		return Version3
+	default:
+		return VersionAuto
+	}
 }
 
 // ExtractHostsFromRule extracts hostnames from a single rule string.
@@ -103,6 +103,40 @@ func TestParser_ExtractHosts_MultipleRouters(t *testing.T) {
 	}
 }
 
+func TestParser_ExtractHosts_Entrypoints(t *testing.T) {
+	labels := map[string]string{
+		"traefik.http.routers.public.rule":          "Host(`app.example.com`)",
+		"traefik.http.routers.public.entrypoints":   "web, websecure",
+		"traefik.http.routers.internal.rule":        "Host(`admin.example.com`)",
+		"traefik.http.routers.internal.entrypoints": "internal",
+		"traefik.http.routers.default.rule":         "Host(`default.example.com`)",
+	}
+
+	tests := []struct {
+		name        string
+		entrypoints []string
+		expected    []string
+	}{
+		{"no filter", nil, []string{"admin.example.com", "app.example.com", "default.example.com"}},
+		{"websecure", []string{"websecure"}, []string{"app.example.com", "default.example.com"}},
+		{"internal", []string{"internal"}, []string{"admin.example.com", "default.example.com"}},
+		{"unknown", []string{"metrics"}, []string{"default.example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(WithParserLogger(testLogger()), WithParserEntrypoints(tt.entrypoints))
+
+			hosts := parser.ExtractHosts(labels)
+			sort.Strings(hosts)
+
+			if !reflect.DeepEqual(hosts, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, hosts)
+			}
+		})
+	}
+}
+
 func TestParser_ExtractHosts_DuplicatesRemoved(t *testing.T) {
 	parser := NewParser(WithParserLogger(testLogger()))
 
@@ -273,6 +307,61 @@ func TestParser_ExtractHostnames_RouterContext(t *testing.T) {
 	}
 }
 
+func TestParser_ExtractHostnames_TTL(t *testing.T) {
+	parser := NewParser(WithParserLogger(testLogger()))
+
+	labels := map[string]string{
+		"traefik.http.routers.frontend.rule":          "Host(`app.example.com`)",
+		"traefik.http.routers.frontend.dnsweaver-ttl": "60",
+		"traefik.http.routers.backend.rule":           "Host(`api.example.com`)",
+		"traefik.http.routers.admin.rule":             "Host(`admin.example.com`)",
+		"traefik.http.routers.admin.dnsweaver-ttl":    "99999",
+		"dnsweaver.ttl": "300",
+	}
+
+	byHost := make(map[string]int)
+	for _, e := range parser.ExtractHostnames(labels) {
+		byHost[e.Hostname] = e.TTL
+	}
+
+	tests := map[string]int{
+		"app.example.com":   60,  // router label wins
+		"api.example.com":   300, // falls back to workload label
+		"admin.example.com": 0,   // out of range, rejected
+	}
+	for host, want := range tests {
+		if got := byHost[host]; got != want {
+			t.Errorf("%s: TTL = %d, want %d", host, got, want)
+		}
+	}
+}
+
+func TestParser_ExtractHostnames_ProviderHints(t *testing.T) {
+	parser := NewParser(WithParserLogger(testLogger()))
+
+	labels := map[string]string{
+		"traefik.http.routers.frontend.rule":                         "Host(`app.example.com`)",
+		"traefik.http.routers.frontend.dnsweaver.cloudflare.proxied": "false",
+		"traefik.http.routers.backend.rule":                          "Host(`api.example.com`)",
+		"dnsweaver.cloudflare.proxied":                               "true",
+	}
+
+	byHost := make(map[string]string)
+	for _, e := range parser.ExtractHostnames(labels) {
+		byHost[e.Hostname] = e.ProviderHints["cloudflare.proxied"]
+	}
+
+	tests := map[string]string{
+		"app.example.com": "false", // router label wins
+		"api.example.com": "true",  // falls back to workload label
+	}
+	for host, want := range tests {
+		if got := byHost[host]; got != want {
+			t.Errorf("%s: cloudflare.proxied = %q, want %q", host, got, want)
+		}
+	}
+}
+
 func TestExtractRouterName(t *testing.T) {
 	tests := []struct {
 		key  string
@@ -367,3 +456,65 @@ func TestExtractHostsFromRule(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRule_Versions(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		version int
+		want    []string
+		skipped []string
+	}{
+		{"v2 multiple args", "Host(`a.example.com`, `b.example.com`)", Version2, []string{"a.example.com", "b.example.com"}, nil},
+		{"v2 HostHeader", "HostHeader(`a.example.com`)", Version2, []string{"a.example.com"}, nil},
+		{"v2 ignores double quotes", `Host("a.example.com")`, Version2, nil, nil},
+		{"v3 double quotes", `Host("a.example.com")`, Version3, []string{"a.example.com"}, nil},
+		{"v3 backticks", "Host(`a.example.com`)", Version3, []string{"a.example.com"}, nil},
+		{"v3 single hostname", "Host(`a.example.com`, `b.example.com`)", Version3, []string{"a.example.com"}, nil},
+		{"v3 no HostHeader", "HostHeader(`a.example.com`)", Version3, nil, nil},
+		{"auto accepts both", "Host(`a.example.com`) || Host(\"b.example.com\")", VersionAuto, []string{"a.example.com", "b.example.com"}, nil},
+		{
+			"HostRegexp skipped",
+			"Host(`a.example.com`) || HostRegexp(`^.+\\.example\\.com$`)",
+			VersionAuto,
+			[]string{"a.example.com"},
+			[]string{"`^.+\\.example\\.com$`"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, skipped := parseRule(tt.rule, tt.version)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRule() hosts = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(skipped, tt.skipped) {
+				t.Errorf("parseRule() skipped = %v, want %v", skipped, tt.skipped)
+			}
+		})
+	}
+}
+
+func TestParser_ExtractHosts_RuleSyntax(t *testing.T) {
+	labels := map[string]string{
+		"traefik.http.routers.legacy.rule":       "Host(`a.example.com`, `b.example.com`)",
+		"traefik.http.routers.legacy.ruleSyntax": "v2",
+		"traefik.http.routers.modern.rule":       "Host(`c.example.com`, `d.example.com`)",
+		"traefik.http.routers.modern.ruleSyntax": "v3",
+	}
+
+	hosts := NewParser(WithParserLogger(testLogger())).ExtractHosts(labels)
+	sort.Strings(hosts)
+	want := []string{"a.example.com", "b.example.com", "c.example.com"}
+	if !reflect.DeepEqual(hosts, want) {
+		t.Errorf("ExtractHosts() = %v, want %v", hosts, want)
+	}
+
+	// A forced version overrides ruleSyntax
+	hosts = NewParser(WithParserLogger(testLogger()), WithParserVersion(Version2)).ExtractHosts(labels)
+	sort.Strings(hosts)
+	want = []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com"}
+	if !reflect.DeepEqual(hosts, want) {
+		t.Errorf("ExtractHosts() with Version2 = %v, want %v", hosts, want)
+	}
+}
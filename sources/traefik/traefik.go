@@ -3,6 +3,8 @@
 //
 // This package parses Docker container labels in the format used by Traefik v2/v3
 // to configure HTTP routers, as well as static YAML/TOML configuration files.
+// Optionally, routers can also be discovered by polling the Traefik API, which
+// covers routers defined by other Traefik providers (file, Consul Catalog, etc.).
 //
 // Example labels:
 //
@@ -21,6 +23,8 @@ package traefik
 import (
 	"context"
 	"log/slog"
+	"strings"
+	"time"
 
 	"gitlab.bluewillows.net/root/dnsweaver/pkg/source"
 )
@@ -37,6 +41,13 @@ type Traefik struct {
 	parser     *Parser
 	logger     *slog.Logger
 	fileConfig source.FileDiscoveryConfig
+
+	version     int
+	entrypoints []string
+
+	apiEndpoint     string
+	apiPollInterval time.Duration
+	api             *apiPoller
 }
 
 // Option is a functional option for configuring Traefik.
@@ -49,6 +60,24 @@ func WithLogger(logger *slog.Logger) Option {
 	}
 }
 
+// WithVersion forces Traefik v2 or v3 rule syntax for labels, files, and
+// the API. By default both are accepted and a router's ruleSyntax setting
+// selects the format.
+func WithVersion(version int) Option {
+	return func(t *Traefik) {
+		t.version = version
+	}
+}
+
+// WithEntrypoints only creates records for label-defined routers bound to at
+// least one of the given entrypoints (traefik.http.routers.<name>.entrypoints).
+// Routers without an entrypoints label are kept. Empty disables filtering.
+func WithEntrypoints(entrypoints []string) Option {
+	return func(t *Traefik) {
+		t.entrypoints = entrypoints
+	}
+}
+
 // WithFileDiscovery configures file-based discovery.
 func WithFileDiscovery(config source.FileDiscoveryConfig) Option {
 	return func(t *Traefik) {
@@ -60,6 +89,17 @@ func WithFileDiscovery(config source.FileDiscoveryConfig) Option {
 	}
 }
 
+// WithAPIEndpoint enables router discovery via the Traefik API.
+// The source polls GET /api/http/routers on the given base URL
+// (e.g., "http://traefik:8080") at most once per pollInterval.
+// A pollInterval of zero uses DefaultAPIPollInterval.
+func WithAPIEndpoint(endpoint string, pollInterval time.Duration) Option {
+	return func(t *Traefik) {
+		t.apiEndpoint = endpoint
+		t.apiPollInterval = pollInterval
+	}
+}
+
 // New creates a new Traefik source.
 func New(opts ...Option) *Traefik {
 	t := &Traefik{
@@ -71,7 +111,16 @@ func New(opts ...Option) *Traefik {
 		opt(t)
 	}
 
-	t.parser = NewParser(WithParserLogger(t.logger))
+	t.parser = NewParser(
+		WithParserLogger(t.logger),
+		WithParserVersion(t.version),
+		WithParserEntrypoints(t.entrypoints),
+	)
+
+	if t.apiEndpoint != "" {
+		t.api = newAPIPoller(t.apiEndpoint, t.apiPollInterval, t.logger)
+		t.api.version = t.version
+	}
 
 	return t
 }
@@ -98,11 +147,15 @@ func (t *Traefik) Extract(ctx context.Context, labels map[string]string) ([]sour
 
 	hostnames := make([]source.Hostname, 0, len(extractions))
 	for _, e := range extractions {
-		hostnames = append(hostnames, source.Hostname{
+		h := source.Hostname{
 			Name:   e.Hostname,
 			Source: sourceName,
 			Router: e.Router,
-		})
+		}
+		if e.TTL > 0 || len(e.ProviderHints) > 0 {
+			h.RecordHints = &source.RecordHints{TTL: e.TTL, ProviderHints: e.ProviderHints}
+		}
+		hostnames = append(hostnames, h)
 	}
 
 	if len(hostnames) > 0 {
@@ -114,31 +167,60 @@ func (t *Traefik) Extract(ctx context.Context, labels map[string]string) ([]sour
 	return hostnames, nil
 }
 
-// Discover finds hostnames from configured Traefik static configuration files.
+// Discover finds hostnames from configured Traefik static configuration files
+// and, if configured, from the Traefik API.
 //
-// This method parses Traefik YAML/TOML files for http.routers.*.rule entries,
-// extracting Host() patterns. It ONLY parses router rules - middleware files,
-// service definitions, and other config sections are safely ignored.
+// File parsing only considers http.routers.*.rule entries, extracting Host()
+// patterns. Middleware files, service definitions, and other config sections
+// are safely ignored. Results from files and the API are merged, with
+// duplicates removed case-insensitively (RFC 1035). If the API fails while
+// file discovery is configured, the file results are returned on their own.
 //
-// Returns nil, nil if file discovery is not configured for this source.
+// Returns nil, nil if neither file nor API discovery is configured.
 func (t *Traefik) Discover(ctx context.Context) ([]source.Hostname, error) {
 	if !t.SupportsDiscovery() {
 		return nil, nil
 	}
 
-	t.logger.Debug("discovering hostnames from traefik files",
-		slog.Any("paths", t.fileConfig.FilePaths),
-		slog.String("pattern", t.fileConfig.FilePattern),
-	)
+	var extractions []HostnameExtraction
+
+	if t.fileConfig.IsEnabled() {
+		t.logger.Debug("discovering hostnames from traefik files",
+			slog.Any("paths", t.fileConfig.FilePaths),
+			slog.String("pattern", t.fileConfig.FilePattern),
+		)
+
+		fromFiles, err := t.parser.DiscoverFromFiles(ctx, t.fileConfig.FilePaths, t.fileConfig.FilePattern)
+		if err != nil {
+			return nil, err
+		}
+		extractions = append(extractions, fromFiles...)
+	}
 
-	hostnames, err := t.parser.DiscoverFromFiles(ctx, t.fileConfig.FilePaths, t.fileConfig.FilePattern)
-	if err != nil {
-		return nil, err
+	if t.api != nil {
+		fromAPI, err := t.api.Hostnames(ctx)
+		switch {
+		case err == nil:
+			extractions = append(extractions, fromAPI...)
+		case !t.fileConfig.IsEnabled():
+			return nil, err
+		default:
+			// Keep the file results rather than failing the whole source
+			t.logger.Warn("traefik API discovery failed, using file discovery only",
+				slog.String("error", err.Error()),
+			)
+		}
 	}
 
-	// Convert to source.Hostname
-	result := make([]source.Hostname, 0, len(hostnames))
-	for _, e := range hostnames {
+	// Convert to source.Hostname, deduplicating case-insensitively
+	result := make([]source.Hostname, 0, len(extractions))
+	seen := make(map[string]struct{}, len(extractions))
+	for _, e := range extractions {
+		key := strings.ToLower(e.Hostname)
+		if _, exists := seen[key]; exists {
+			continue
+		}
+		seen[key] = struct{}{}
 		result = append(result, source.Hostname{
 			Name:   e.Hostname,
 			Source: sourceName,
@@ -147,17 +229,27 @@ func (t *Traefik) Discover(ctx context.Context) ([]source.Hostname, error) {
 	}
 
 	if len(result) > 0 {
-		t.logger.Debug("discovered hostnames from traefik files",
+		t.logger.Debug("discovered hostnames from traefik",
 			slog.Int("count", len(result)),
+			slog.Bool("api", t.api != nil),
 		)
 	}
 
 	return result, nil
 }
 
-// SupportsDiscovery returns true if file paths are configured.
+// SupportsDiscovery returns true if file paths or an API endpoint are configured.
 func (t *Traefik) SupportsDiscovery() bool {
-	return t.fileConfig.IsEnabled()
+	return t.fileConfig.IsEnabled() || t.api != nil
+}
+
+// APIPollInterval returns the effective Traefik API poll interval,
+// or zero if API discovery is not configured.
+func (t *Traefik) APIPollInterval() time.Duration {
+	if t.api == nil {
+		return 0
+	}
+	return t.api.pollInterval
 }
 
 // FileConfig returns the file discovery configuration.
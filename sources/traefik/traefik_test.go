@@ -70,6 +70,31 @@ func TestTraefik_Extract_SingleHost(t *testing.T) {
 	}
 }
 
+func TestTraefik_Extract_TTLHint(t *testing.T) {
+	src := New(WithLogger(testLogger()))
+
+	labels := map[string]string{
+		"traefik.http.routers.myapp.rule":          "Host(`app.example.com`)",
+		"traefik.http.routers.myapp.dnsweaver-ttl": "60",
+	}
+
+	hostnames, err := src.Extract(context.Background(), labels)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(hostnames) != 1 {
+		t.Fatalf("expected 1 hostname, got %d", len(hostnames))
+	}
+
+	h := hostnames[0]
+	if h.RecordHints == nil {
+		t.Fatal("expected RecordHints to be set")
+	}
+	if h.RecordHints.TTL != 60 {
+		t.Errorf("RecordHints.TTL = %d, want %d", h.RecordHints.TTL, 60)
+	}
+}
+
 func TestTraefik_Extract_MultipleHostnames(t *testing.T) {
 	src := New(WithLogger(testLogger()))
 	ctx := context.Background()